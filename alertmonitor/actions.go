@@ -0,0 +1,273 @@
+package alertmonitor
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+const (
+	actionWebhook = "webhook"
+	actionExec    = "exec"
+
+	formatJSON      = "json"
+	formatSlack     = "slack"
+	formatPagerDuty = "pagerduty"
+
+	pagerDutyURL          = "https://events.pagerduty.com/v2/enqueue"
+	defaultMessage        = "[{{.Severity}}] {{.Name}} is {{.State}}: {{.Sensor}} {{.Key}} is {{.Value}}, {{.Operator}} {{.Threshold}}"
+	defaultWebhookTimeout = 10 * time.Second
+	defaultExecTimeout    = 60 * time.Second
+	webhookAttempts       = 3
+	maxOutputLen          = 200
+
+	// execActionWorkers is how many exec actions of all the alert monitors run at once
+	execActionWorkers = 2
+)
+
+// actionData is what message and args templates can use, e.g. {{.Name}} or {{.Value}}.
+type actionData struct {
+	Name        string
+	Sensor      string
+	Key         string
+	Operator    string
+	Threshold   float64
+	Severity    string
+	State       string // firing or resolved
+	Value       float64
+	Time        string // RFC 3339
+	DurationSec float64
+	Host        string
+}
+
+func newActionData(event alertEvent, host string) actionData {
+	return actionData{
+		Name:        event.Rule.Name,
+		Sensor:      event.Rule.Sensor,
+		Key:         event.Rule.Key,
+		Operator:    event.Rule.Operator,
+		Threshold:   event.Rule.Threshold,
+		Severity:    event.Rule.Severity,
+		State:       event.Type,
+		Value:       event.Value,
+		Time:        event.Time.Format(time.RFC3339),
+		DurationSec: utils.RoundValue(event.Duration.Seconds(), 3),
+		Host:        host,
+	}
+}
+
+// execActionRunner runs the exec actions. They have their own workers rather than the module's command pool, so a slow
+// action can't hold up the vcgencmd and iw reads the sensors poll with, or queue behind them while an alert fires.
+var execActionRunner = utils.NewPooledRunner(utils.ExecRunner{}, execActionWorkers, defaultExecTimeout, nil)
+
+// action is an Action with its templates parsed.
+type action struct {
+	Action
+	message *template.Template
+	args    []*template.Template
+	timeout time.Duration
+	// runner runs exec actions, nil for execActionRunner
+	runner utils.CommandRunner
+}
+
+func newAction(conf Action) (*action, error) {
+	a := &action{Action: conf}
+	message := conf.Message
+	if message == "" {
+		message = defaultMessage
+	}
+	var err error
+	if a.message, err = template.New("message").Option("missingkey=error").Parse(message); err != nil {
+		return nil, fmt.Errorf("failed to parse message: %w", err)
+	}
+	for i, arg := range conf.Args {
+		t, err := template.New("arg").Option("missingkey=error").Parse(arg)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse args[%d]: %w", i, err)
+		}
+		a.args = append(a.args, t)
+	}
+	a.timeout = time.Duration(conf.TimeoutSec * float64(time.Second))
+	if a.timeout == 0 {
+		a.timeout = defaultWebhookTimeout
+		if conf.Type == actionExec {
+			a.timeout = defaultExecTimeout
+		}
+	}
+	return a, nil
+}
+
+// handles reports whether the action runs for events of eventType.
+func (a *action) handles(eventType string) bool {
+	if len(a.On) == 0 {
+		return true
+	}
+	for _, on := range a.On {
+		if on == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+func execute(t *template.Template, data actionData) (string, error) {
+	var buf strings.Builder
+	if err := t.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+func (a *action) run(ctx context.Context, client *http.Client, data actionData) error {
+	ctx, cancel := context.WithTimeout(ctx, a.timeout)
+	defer cancel()
+	if a.Type == actionExec {
+		return a.runCommand(ctx, data)
+	}
+	return a.postWebhook(ctx, client, data)
+}
+
+// runCommand runs the command with the templated args. The alert is also in the environment, e.g. ALERT_NAME.
+func (a *action) runCommand(ctx context.Context, data actionData) error {
+	args := make([]string, 0, len(a.args))
+	for _, t := range a.args {
+		arg, err := execute(t, data)
+		if err != nil {
+			return err
+		}
+		args = append(args, arg)
+	}
+	cmd := utils.Command{Name: a.Command, Args: args, Combined: true, Env: []string{
+		"ALERT_NAME=" + data.Name,
+		"ALERT_STATE=" + data.State,
+		"ALERT_SEVERITY=" + data.Severity,
+		"ALERT_SENSOR=" + data.Sensor,
+		"ALERT_KEY=" + data.Key,
+		"ALERT_VALUE=" + strconv.FormatFloat(data.Value, 'g', -1, 64),
+		"ALERT_THRESHOLD=" + strconv.FormatFloat(data.Threshold, 'g', -1, 64),
+	}, Timeout: a.timeout}
+	runner := a.runner
+	if runner == nil {
+		runner = execActionRunner
+	}
+	if output, err := runner.Run(ctx, cmd); err != nil {
+		return fmt.Errorf("%s failed: %w: %s", a.Command, err, truncate(strings.TrimSpace(string(output))))
+	}
+	return nil
+}
+
+func (a *action) postWebhook(ctx context.Context, client *http.Client, data actionData) error {
+	message, err := execute(a.message, data)
+	if err != nil {
+		return err
+	}
+	body, err := json.Marshal(a.payload(data, message))
+	if err != nil {
+		return err
+	}
+	url := a.URL
+	if url == "" && a.Format == formatPagerDuty {
+		url = pagerDutyURL
+	}
+
+	for attempt := 1; ; attempt++ {
+		retry, err := a.post(ctx, client, url, body)
+		if err == nil || !retry || attempt == webhookAttempts {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(time.Duration(attempt) * time.Second):
+		}
+	}
+}
+
+// post sends one request and reports whether a failure is worth retrying.
+func (a *action) post(ctx context.Context, client *http.Client, url string, body []byte) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range a.Headers {
+		req.Header.Set(key, value)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return true, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return false, nil
+	}
+	text, _ := io.ReadAll(io.LimitReader(resp.Body, maxOutputLen))
+	err = fmt.Errorf("%s returned %s: %s", url, resp.Status, strings.TrimSpace(string(text)))
+	// Other client errors won't go away by sending the same request again
+	return resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500, err
+}
+
+func (a *action) payload(data actionData, message string) interface{} {
+	switch a.Format {
+	case formatSlack:
+		return map[string]interface{}{"text": message}
+	case formatPagerDuty:
+		eventAction := "trigger"
+		if data.State == eventResolved {
+			eventAction = "resolve"
+		}
+		return map[string]interface{}{
+			"routing_key":  a.RoutingKey,
+			"event_action": eventAction,
+			// Resolving needs the key the alert was triggered with
+			"dedup_key": data.Host + "/" + data.Name,
+			"payload": map[string]interface{}{
+				"summary":   message,
+				"source":    data.Host,
+				"severity":  data.Severity,
+				"timestamp": data.Time,
+				"custom_details": map[string]interface{}{
+					"sensor":    data.Sensor,
+					"key":       data.Key,
+					"value":     data.Value,
+					"operator":  data.Operator,
+					"threshold": data.Threshold,
+				},
+			},
+		}
+	default:
+		payload := map[string]interface{}{
+			"alert":     data.Name,
+			"state":     data.State,
+			"severity":  data.Severity,
+			"sensor":    data.Sensor,
+			"key":       data.Key,
+			"value":     data.Value,
+			"operator":  data.Operator,
+			"threshold": data.Threshold,
+			"time":      data.Time,
+			"host":      data.Host,
+			"message":   message,
+		}
+		if data.State == eventResolved {
+			payload["duration_sec"] = data.DurationSec
+		}
+		return payload
+	}
+}
+
+func truncate(s string) string {
+	if len(s) <= maxOutputLen {
+		return s
+	}
+	return s[:maxOutputLen] + "..."
+}
@@ -0,0 +1,144 @@
+package alertmonitor
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+func testEvent(eventType string) actionData {
+	rule := Rule{Name: "cpu_hot", Sensor: "temperatures", Key: "CPU", Operator: ">", Threshold: 80, Severity: severityCritical}
+	return newActionData(alertEvent{
+		Time:     time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC),
+		Rule:     rule,
+		Type:     eventType,
+		Value:    85.5,
+		Duration: 90 * time.Second,
+	}, "robot1")
+}
+
+// webhookServer records the bodies it's sent and answers with the given statuses in turn, then 200.
+func webhookServer(t *testing.T, statuses ...int) (*httptest.Server, *[]map[string]interface{}) {
+	var bodies []map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+		bodies = append(bodies, body)
+		if len(statuses) > 0 {
+			w.WriteHeader(statuses[0])
+			statuses = statuses[1:]
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server, &bodies
+}
+
+func TestWebhookFormats(t *testing.T) {
+	server, bodies := webhookServer(t)
+	for _, format := range []string{formatJSON, formatSlack, formatPagerDuty} {
+		a, err := newAction(Action{Name: format, Type: actionWebhook, URL: server.URL, Format: format, RoutingKey: "key", Headers: map[string]string{"Authorization": "Bearer secret"}})
+		require.NoError(t, err)
+		require.NoError(t, a.run(context.Background(), server.Client(), testEvent(eventResolved)))
+	}
+	require.Len(t, *bodies, 3)
+
+	generic := (*bodies)[0]
+	assert.Equal(t, "cpu_hot", generic["alert"])
+	assert.Equal(t, "resolved", generic["state"])
+	assert.Equal(t, 85.5, generic["value"])
+	assert.Equal(t, 90.0, generic["duration_sec"])
+	assert.Equal(t, "robot1", generic["host"])
+
+	assert.Equal(t, "[critical] cpu_hot is resolved: temperatures CPU is 85.5, > 80", (*bodies)[1]["text"])
+
+	pagerDuty := (*bodies)[2]
+	assert.Equal(t, "key", pagerDuty["routing_key"])
+	assert.Equal(t, "resolve", pagerDuty["event_action"])
+	assert.Equal(t, "robot1/cpu_hot", pagerDuty["dedup_key"])
+	assert.Equal(t, "critical", pagerDuty["payload"].(map[string]interface{})["severity"])
+}
+
+func TestWebhookRetries(t *testing.T) {
+	server, bodies := webhookServer(t, http.StatusServiceUnavailable)
+	a, err := newAction(Action{Name: "hook", Type: actionWebhook, URL: server.URL, Message: "{{.Name}} on {{.Host}}", Headers: map[string]string{"Authorization": "Bearer secret"}})
+	require.NoError(t, err)
+	require.NoError(t, a.run(context.Background(), server.Client(), testEvent(eventFiring)))
+	require.Len(t, *bodies, 2)
+	assert.Equal(t, "cpu_hot on robot1", (*bodies)[1]["message"])
+
+	// Client errors aren't retried
+	server, bodies = webhookServer(t, http.StatusBadRequest)
+	a.URL = server.URL
+	assert.Error(t, a.run(context.Background(), server.Client(), testEvent(eventFiring)))
+	assert.Len(t, *bodies, 1)
+}
+
+func TestExec(t *testing.T) {
+	out := filepath.Join(t.TempDir(), "out")
+	a, err := newAction(Action{Name: "fan", Type: actionExec, Command: "/bin/sh", Args: []string{"-c", `echo "$1 $ALERT_STATE $ALERT_VALUE" > ` + out, "sh", "{{.Name}}"}})
+	require.NoError(t, err)
+	require.NoError(t, a.run(context.Background(), nil, testEvent(eventFiring)))
+	data, err := os.ReadFile(out)
+	require.NoError(t, err)
+	assert.Equal(t, "cpu_hot firing 85.5\n", string(data))
+
+	a, err = newAction(Action{Name: "fail", Type: actionExec, Command: "/bin/sh", Args: []string{"-c", "echo broken; exit 3"}})
+	require.NoError(t, err)
+	err = a.run(context.Background(), nil, testEvent(eventFiring))
+	assert.ErrorContains(t, err, "broken")
+
+	// Commands run through the runner with the action's timeout
+	a, err = newAction(Action{Name: "slow", Type: actionExec, Command: "/usr/local/bin/notify", TimeoutSec: 120})
+	require.NoError(t, err)
+	var ran utils.Command
+	a.runner = utils.CommandRunnerFunc(func(ctx context.Context, cmd utils.Command) ([]byte, error) {
+		ran = cmd
+		return nil, nil
+	})
+	require.NoError(t, a.run(context.Background(), nil, testEvent(eventFiring)))
+	assert.Equal(t, "/usr/local/bin/notify", ran.Name)
+	assert.Equal(t, 2*time.Minute, ran.Timeout)
+}
+
+func TestExecDoesNotUseModuleRunner(t *testing.T) {
+	t.Cleanup(func() { utils.SetCommandRunner(utils.DefaultCommandRunner()) })
+	utils.SetCommandRunner(utils.CommandRunnerFunc(func(ctx context.Context, cmd utils.Command) ([]byte, error) {
+		t.Errorf("%s ran on the module's command runner", cmd.Name)
+		return nil, nil
+	}))
+	// Actions run on their own workers, so a busy module pool doesn't hold them up
+	out := filepath.Join(t.TempDir(), "out")
+	a, err := newAction(Action{Name: "touch", Type: actionExec, Command: "/bin/sh", Args: []string{"-c", "echo ran > " + out}})
+	require.NoError(t, err)
+	require.NoError(t, a.run(context.Background(), nil, testEvent(eventFiring)))
+	data, err := os.ReadFile(out)
+	require.NoError(t, err)
+	assert.Equal(t, "ran\n", string(data))
+}
+
+func TestActionHandles(t *testing.T) {
+	a, err := newAction(Action{Name: "shutdown", Type: actionExec, Command: "/sbin/poweroff", On: []string{eventFiring}})
+	require.NoError(t, err)
+	assert.True(t, a.handles(eventFiring))
+	assert.False(t, a.handles(eventResolved))
+	assert.Equal(t, defaultExecTimeout, a.timeout)
+
+	a, err = newAction(Action{Name: "hook", Type: actionWebhook, URL: "http://localhost"})
+	require.NoError(t, err)
+	assert.True(t, a.handles(eventResolved))
+	assert.Equal(t, defaultWebhookTimeout, a.timeout)
+
+	_, err = newAction(Action{Name: "bad", Type: actionExec, Command: "/bin/true", Args: []string{"{{.Name"}})
+	assert.Error(t, err)
+}
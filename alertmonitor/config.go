@@ -0,0 +1,172 @@
+package alertmonitor
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"path/filepath"
+)
+
+type Rule struct {
+	Name      string   `json:"name"`               // Identifies the alert in readings and events
+	Sensor    string   `json:"sensor"`             // The sensor whose reading is checked
+	Key       string   `json:"key"`                // The reading, the keys of nested readings are joined with underscores
+	Operator  string   `json:"operator"`           // >, >=, <, <=, == or !=
+	Threshold float64  `json:"threshold"`          // Bools are compared as 1 or 0
+	ForSec    float64  `json:"for_sec,omitempty"`  // How long the condition must hold before the alert fires
+	Severity  string   `json:"severity,omitempty"` // info, warning or critical, defaults to warning
+	Actions   []string `json:"actions,omitempty"`  // The names of the actions run when the alert fires or resolves
+	// A firing alert only resolves once the value no longer crosses this, e.g. 75 for > 80, defaults to the threshold
+	ClearThreshold *float64 `json:"clear_threshold,omitempty"`
+	// How long a firing alert's condition must have cleared before it resolves
+	ClearForSec float64 `json:"clear_for_sec,omitempty"`
+}
+
+type Action struct {
+	Name       string            `json:"name"`
+	Type       string            `json:"type"`                  // webhook or exec
+	On         []string          `json:"on,omitempty"`          // firing, resolved or both, defaults to both
+	Message    string            `json:"message,omitempty"`     // A template for the text of webhooks
+	TimeoutSec float64           `json:"timeout_sec,omitempty"` // Defaults to 10 for webhooks and 60 for commands
+	URL        string            `json:"url,omitempty"`         // webhook, optional with the pagerduty format
+	Format     string            `json:"format,omitempty"`      // webhook, json, slack or pagerduty, defaults to json
+	RoutingKey string            `json:"routing_key,omitempty"` // webhook, the PagerDuty integration key
+	Headers    map[string]string `json:"headers,omitempty"`     // webhook, e.g. Authorization
+	Command    string            `json:"command,omitempty"`     // exec, the absolute path of the command, which isn't run by a shell
+	Args       []string          `json:"args,omitempty"`        // exec, each is a template
+}
+
+type ComponentConfig struct {
+	Rules       []Rule   `json:"rules"`
+	Actions     []Action `json:"actions,omitempty"`
+	IntervalSec float64  `json:"interval_sec,omitempty"` // How often the rules are evaluated, defaults to 10
+}
+
+func (conf *ComponentConfig) Validate(path string) ([]string, error) {
+	if len(conf.Rules) == 0 {
+		return nil, errors.New("at least one rule is required")
+	}
+	if conf.IntervalSec < 0 {
+		return nil, errors.New("interval_sec must not be negative")
+	}
+	actions := make(map[string]bool, len(conf.Actions))
+	for i, action := range conf.Actions {
+		if action.Name == "" {
+			return nil, fmt.Errorf("action %d: name is required", i)
+		}
+		if actions[action.Name] {
+			return nil, fmt.Errorf("action %s: name is used by another action", action.Name)
+		}
+		actions[action.Name] = true
+		if err := action.validate(); err != nil {
+			return nil, fmt.Errorf("action %s: %w", action.Name, err)
+		}
+	}
+
+	names := make(map[string]bool, len(conf.Rules))
+	for i, rule := range conf.Rules {
+		if rule.Name == "" {
+			return nil, fmt.Errorf("rule %d: name is required", i)
+		}
+		if names[rule.Name] {
+			return nil, fmt.Errorf("rule %s: name is used by another rule", rule.Name)
+		}
+		names[rule.Name] = true
+		if rule.Sensor == "" || rule.Key == "" {
+			return nil, fmt.Errorf("rule %s: sensor and key are required", rule.Name)
+		}
+		if _, ok := operators[rule.Operator]; !ok {
+			return nil, fmt.Errorf("rule %s: operator must be one of >, >=, <, <=, == or !=", rule.Name)
+		}
+		if rule.Severity != "" && severityRank(rule.Severity) == 0 {
+			return nil, fmt.Errorf("rule %s: severity must be info, warning or critical", rule.Name)
+		}
+		if rule.ForSec < 0 || rule.ClearForSec < 0 {
+			return nil, fmt.Errorf("rule %s: for_sec and clear_for_sec must not be negative", rule.Name)
+		}
+		if err := rule.validateClearThreshold(); err != nil {
+			return nil, fmt.Errorf("rule %s: %w", rule.Name, err)
+		}
+		for _, action := range rule.Actions {
+			if !actions[action] {
+				return nil, fmt.Errorf("rule %s: there is no action named %s", rule.Name, action)
+			}
+		}
+	}
+	return conf.sensors(), nil
+}
+
+// validateClearThreshold checks that the clear threshold is on the side of the threshold where the condition doesn't
+// hold, otherwise an alert could resolve while its condition still holds.
+func (rule *Rule) validateClearThreshold() error {
+	if rule.ClearThreshold == nil {
+		return nil
+	}
+	clearAt := *rule.ClearThreshold
+	switch rule.Operator {
+	case ">", ">=":
+		if clearAt > rule.Threshold {
+			return errors.New("clear_threshold must not be above the threshold")
+		}
+	case "<", "<=":
+		if clearAt < rule.Threshold {
+			return errors.New("clear_threshold must not be below the threshold")
+		}
+	default:
+		return errors.New("clear_threshold can only be used with >, >=, < and <=")
+	}
+	return nil
+}
+
+// sensors returns the sensors the rules read, each once.
+func (conf *ComponentConfig) sensors() []string {
+	var ret []string
+	seen := make(map[string]bool)
+	for _, rule := range conf.Rules {
+		if !seen[rule.Sensor] {
+			seen[rule.Sensor] = true
+			ret = append(ret, rule.Sensor)
+		}
+	}
+	return ret
+}
+
+func (action *Action) validate() error {
+	for _, on := range action.On {
+		if on != eventFiring && on != eventResolved {
+			return errors.New("on must only contain firing and resolved")
+		}
+	}
+	if action.TimeoutSec < 0 {
+		return errors.New("timeout_sec must not be negative")
+	}
+	switch action.Type {
+	case actionWebhook:
+		switch action.Format {
+		case "", formatJSON, formatSlack:
+			if action.URL == "" {
+				return errors.New("url is required")
+			}
+		case formatPagerDuty:
+			if action.RoutingKey == "" {
+				return errors.New("routing_key is required with the pagerduty format")
+			}
+		default:
+			return errors.New("format must be json, slack or pagerduty")
+		}
+		if action.URL != "" {
+			if u, err := url.Parse(action.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+				return errors.New("url must be an http or https URL")
+			}
+		}
+	case actionExec:
+		if !filepath.IsAbs(action.Command) {
+			return errors.New("command must be an absolute path")
+		}
+	default:
+		return errors.New("type must be webhook or exec")
+	}
+	// Templates that don't parse would only fail when the alert fires
+	_, err := newAction(*action)
+	return err
+}
@@ -0,0 +1,76 @@
+package alertmonitor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidate(t *testing.T) {
+	conf := &ComponentConfig{Rules: []Rule{
+		{Name: "cpu_hot", Sensor: "temperatures", Key: "CPU", Operator: ">", Threshold: 80, ForSec: 60},
+		{Name: "gpu_hot", Sensor: "temperatures", Key: "GPU", Operator: ">=", Threshold: 85, Severity: "critical"},
+		{Name: "undervolt", Sensor: "throttling", Key: "undervolt", Operator: "==", Threshold: 1},
+	}}
+	deps, err := conf.Validate("")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"temperatures", "throttling"}, deps)
+
+	valid := Rule{Name: "cpu_hot", Sensor: "temperatures", Key: "CPU", Operator: ">", Threshold: 80}
+	above, below := 85.0, -5.0
+	for _, rules := range [][]Rule{
+		nil,
+		{{Sensor: "temperatures", Key: "CPU", Operator: ">"}},
+		{valid, valid},
+		{{Name: "cpu_hot", Key: "CPU", Operator: ">"}},
+		{{Name: "cpu_hot", Sensor: "temperatures", Key: "CPU", Operator: "=>"}},
+		{{Name: "cpu_hot", Sensor: "temperatures", Key: "CPU", Operator: ">", Severity: "page"}},
+		{{Name: "cpu_hot", Sensor: "temperatures", Key: "CPU", Operator: ">", ForSec: -1}},
+		{{Name: "cpu_hot", Sensor: "temperatures", Key: "CPU", Operator: ">", ClearForSec: -1}},
+		{{Name: "cpu_hot", Sensor: "temperatures", Key: "CPU", Operator: ">", Threshold: 80, ClearThreshold: &above}},
+		{{Name: "cpu_cold", Sensor: "temperatures", Key: "CPU", Operator: "<=", Threshold: 0, ClearThreshold: &below}},
+		{{Name: "undervolt", Sensor: "throttling", Key: "undervolt", Operator: "==", Threshold: 1, ClearThreshold: &below}},
+	} {
+		_, err := (&ComponentConfig{Rules: rules}).Validate("")
+		assert.Error(t, err, rules)
+	}
+	_, err = (&ComponentConfig{Rules: []Rule{valid}, IntervalSec: -1}).Validate("")
+	assert.Error(t, err)
+
+	clearAt := 75.0
+	_, err = (&ComponentConfig{Rules: []Rule{
+		{Name: "cpu_hot", Sensor: "temperatures", Key: "CPU", Operator: ">", Threshold: 80, ClearThreshold: &clearAt, ClearForSec: 30},
+		{Name: "cpu_cold", Sensor: "temperatures", Key: "CPU", Operator: "<", Threshold: 0, ClearThreshold: &above},
+	}}).Validate("")
+	assert.NoError(t, err)
+}
+
+func TestValidateActions(t *testing.T) {
+	rule := Rule{Name: "cpu_hot", Sensor: "temperatures", Key: "CPU", Operator: ">", Threshold: 80, Actions: []string{"slack", "fan"}}
+	slack := Action{Name: "slack", Type: "webhook", URL: "https://hooks.slack.com/services/x", Format: "slack"}
+	fan := Action{Name: "fan", Type: "exec", Command: "/usr/local/bin/fan", Args: []string{"--max", "{{.Name}}"}, On: []string{"firing"}}
+	_, err := (&ComponentConfig{Rules: []Rule{rule}, Actions: []Action{slack, fan}}).Validate("")
+	assert.NoError(t, err)
+	_, err = (&ComponentConfig{Rules: []Rule{rule}, Actions: []Action{slack}}).Validate("")
+	assert.Error(t, err)
+
+	for _, action := range []Action{
+		{Type: "webhook", URL: "https://example.com"},
+		{Name: "a", Type: "email"},
+		{Name: "a", Type: "webhook"},
+		{Name: "a", Type: "webhook", URL: "ftp://example.com"},
+		{Name: "a", Type: "webhook", URL: "https://example.com", Format: "teams"},
+		{Name: "a", Type: "webhook", Format: "pagerduty"},
+		{Name: "a", Type: "webhook", URL: "https://example.com", Message: "{{.Name"},
+		{Name: "a", Type: "webhook", URL: "https://example.com", On: []string{"pending"}},
+		{Name: "a", Type: "exec", Command: "fan"},
+		{Name: "a", Type: "exec", Command: "/usr/local/bin/fan", TimeoutSec: -1},
+	} {
+		_, err := (&ComponentConfig{Rules: []Rule{{Name: "cpu_hot", Sensor: "temperatures", Key: "CPU", Operator: ">"}}, Actions: []Action{action}}).Validate("")
+		assert.Error(t, err, action)
+	}
+	_, err = (&ComponentConfig{Rules: []Rule{{Name: "cpu_hot", Sensor: "temperatures", Key: "CPU", Operator: ">"}}, Actions: []Action{slack, slack}}).Validate("")
+	assert.Error(t, err)
+	_, err = (&ComponentConfig{Rules: []Rule{{Name: "cpu_hot", Sensor: "temperatures", Key: "CPU", Operator: ">"}}, Actions: []Action{{Name: "pd", Type: "webhook", Format: "pagerduty", RoutingKey: "key"}}}).Validate("")
+	assert.NoError(t, err)
+}
@@ -0,0 +1,142 @@
+package alertmonitor
+
+import (
+	"time"
+)
+
+const (
+	stateOK      = "ok"
+	statePending = "pending"
+	stateFiring  = "firing"
+	// Events are only for alerts firing and resolving, pending alerts don't notify anyone
+	eventFiring   = "firing"
+	eventResolved = "resolved"
+
+	severityNone     = "none"
+	severityInfo     = "info"
+	severityWarning  = "warning"
+	severityCritical = "critical"
+)
+
+var operators = map[string]func(value, threshold float64) bool{
+	">":  func(v, t float64) bool { return v > t },
+	">=": func(v, t float64) bool { return v >= t },
+	"<":  func(v, t float64) bool { return v < t },
+	"<=": func(v, t float64) bool { return v <= t },
+	"==": func(v, t float64) bool { return v == t },
+	"!=": func(v, t float64) bool { return v != t },
+}
+
+// severityRank orders severities, unknown ones are 0.
+func severityRank(severity string) int {
+	switch severity {
+	case severityInfo:
+		return 1
+	case severityWarning:
+		return 2
+	case severityCritical:
+		return 3
+	default:
+		return 0
+	}
+}
+
+type alertEvent struct {
+	Time     time.Time
+	Rule     Rule
+	Type     string
+	Value    float64
+	Duration time.Duration // How long a resolved alert was firing
+}
+
+// alert tracks one rule. It's pending while the condition holds for less than for_sec, then firing until the
+// condition has cleared for clear_for_sec.
+type alert struct {
+	rule       Rule
+	state      string
+	value      float64
+	hasValue   bool
+	since      time.Time // When the condition started holding
+	clearing   time.Time // When the condition of a firing alert cleared
+	firedAt    time.Time
+	resolvedAt time.Time
+}
+
+func newAlert(rule Rule) *alert {
+	if rule.Severity == "" {
+		rule.Severity = severityWarning
+	}
+	return &alert{rule: rule, state: stateOK}
+}
+
+// sameCondition reports whether the alerts fire under the same conditions, so the state of one applies to the other.
+func (a *alert) sameCondition(other *alert) bool {
+	r, o := a.rule, other.rule
+	return r.Sensor == o.Sensor && r.Key == o.Key && r.Operator == o.Operator && r.Threshold == o.Threshold &&
+		r.ForSec == o.ForSec && r.Severity == o.Severity && r.ClearForSec == o.ClearForSec &&
+		a.clearThreshold() == other.clearThreshold()
+}
+
+func (a *alert) clearThreshold() float64 {
+	if a.rule.ClearThreshold != nil {
+		return *a.rule.ClearThreshold
+	}
+	return a.rule.Threshold
+}
+
+// evaluate updates the alert with the value read at now and returns the event it caused, if any.
+func (a *alert) evaluate(now time.Time, value float64) *alertEvent {
+	a.value, a.hasValue = value, true
+	crosses := operators[a.rule.Operator]
+
+	// A firing alert resolves once the value is back past the clear threshold, so a value hovering around the
+	// threshold doesn't keep resolving and firing again
+	if a.state == stateFiring {
+		if crosses(value, a.clearThreshold()) {
+			a.clearing = time.Time{}
+			return nil
+		}
+		if a.clearing.IsZero() {
+			a.clearing = now
+		}
+		if now.Sub(a.clearing) < time.Duration(a.rule.ClearForSec*float64(time.Second)) {
+			return nil
+		}
+		a.state = stateOK
+		a.clearing = time.Time{}
+		a.resolvedAt = now
+		return &alertEvent{Time: now, Rule: a.rule, Type: eventResolved, Value: value, Duration: now.Sub(a.firedAt)}
+	}
+
+	if !crosses(value, a.rule.Threshold) {
+		a.state = stateOK
+		return nil
+	}
+
+	if a.state == stateOK {
+		a.state = statePending
+		a.since = now
+	}
+	if a.state == statePending && now.Sub(a.since) >= time.Duration(a.rule.ForSec*float64(time.Second)) {
+		a.state = stateFiring
+		a.firedAt = now
+		return &alertEvent{Time: now, Rule: a.rule, Type: eventFiring, Value: value}
+	}
+	return nil
+}
+
+// evaluateAll evaluates every alert against the numeric readings of each sensor. Alerts whose sensor couldn't be read
+// or didn't have the key keep their state, a missing reading says nothing about the condition.
+func evaluateAll(now time.Time, alerts []*alert, readings map[string]map[string]float64) []alertEvent {
+	var events []alertEvent
+	for _, a := range alerts {
+		value, ok := readings[a.rule.Sensor][a.rule.Key]
+		if !ok {
+			continue
+		}
+		if event := a.evaluate(now, value); event != nil {
+			events = append(events, *event)
+		}
+	}
+	return events
+}
@@ -0,0 +1,157 @@
+package alertmonitor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAlertForDuration(t *testing.T) {
+	a := newAlert(Rule{Name: "cpu_hot", Sensor: "temperatures", Key: "CPU", Operator: ">", Threshold: 80, ForSec: 30})
+	assert.Equal(t, severityWarning, a.rule.Severity)
+	start := time.Unix(1700000000, 0)
+
+	assert.Nil(t, a.evaluate(start, 85))
+	assert.Equal(t, statePending, a.state)
+	assert.Nil(t, a.evaluate(start.Add(20*time.Second), 86))
+	assert.Equal(t, statePending, a.state)
+
+	event := a.evaluate(start.Add(30*time.Second), 87)
+	require.NotNil(t, event)
+	assert.Equal(t, eventFiring, event.Type)
+	assert.Equal(t, 87.0, event.Value)
+	assert.Equal(t, stateFiring, a.state)
+	assert.Nil(t, a.evaluate(start.Add(40*time.Second), 88))
+
+	event = a.evaluate(start.Add(100*time.Second), 70)
+	require.NotNil(t, event)
+	assert.Equal(t, eventResolved, event.Type)
+	assert.Equal(t, 70*time.Second, event.Duration)
+	assert.Equal(t, stateOK, a.state)
+	assert.Equal(t, start.Add(100*time.Second), a.resolvedAt)
+
+	// A condition that stops holding before for_sec never fires
+	assert.Nil(t, a.evaluate(start.Add(200*time.Second), 90))
+	assert.Nil(t, a.evaluate(start.Add(210*time.Second), 60))
+	assert.Equal(t, stateOK, a.state)
+	assert.Nil(t, a.evaluate(start.Add(220*time.Second), 90))
+	assert.Equal(t, statePending, a.state)
+	assert.Equal(t, start.Add(220*time.Second), a.since)
+}
+
+func TestAlertFiresImmediately(t *testing.T) {
+	a := newAlert(Rule{Name: "undervolt", Sensor: "throttling", Key: "undervolt", Operator: "==", Threshold: 1, Severity: severityCritical})
+	start := time.Unix(1700000000, 0)
+	assert.Nil(t, a.evaluate(start, 0))
+	event := a.evaluate(start.Add(time.Second), 1)
+	require.NotNil(t, event)
+	assert.Equal(t, eventFiring, event.Type)
+	assert.Equal(t, severityCritical, event.Rule.Severity)
+}
+
+func TestAlertHysteresis(t *testing.T) {
+	clearAt := 75.0
+	a := newAlert(Rule{Name: "cpu_hot", Sensor: "temperatures", Key: "CPU", Operator: ">", Threshold: 80, ClearThreshold: &clearAt})
+	start := time.Unix(1700000000, 0)
+	require.NotNil(t, a.evaluate(start, 81))
+
+	// Hovering around the threshold doesn't resolve the alert
+	for i, v := range []float64{79, 81, 78, 80, 76} {
+		assert.Nil(t, a.evaluate(start.Add(time.Duration(i+1)*time.Second), v), v)
+		assert.Equal(t, stateFiring, a.state)
+	}
+	event := a.evaluate(start.Add(10*time.Second), 75)
+	require.NotNil(t, event)
+	assert.Equal(t, eventResolved, event.Type)
+
+	// Below the threshold the alert doesn't fire again
+	assert.Nil(t, a.evaluate(start.Add(11*time.Second), 78))
+	assert.Equal(t, stateOK, a.state)
+}
+
+func TestAlertClearFor(t *testing.T) {
+	// A flapping link only resolves once it has been up for clear_for_sec
+	a := newAlert(Rule{Name: "link_down", Sensor: "network", Key: "eth0_up", Operator: "==", Threshold: 0, ClearForSec: 30})
+	start := time.Unix(1700000000, 0)
+	require.NotNil(t, a.evaluate(start, 0))
+	assert.Nil(t, a.evaluate(start.Add(10*time.Second), 1))
+	assert.Equal(t, start.Add(10*time.Second), a.clearing)
+	assert.Nil(t, a.evaluate(start.Add(20*time.Second), 0))
+	assert.True(t, a.clearing.IsZero())
+	assert.Nil(t, a.evaluate(start.Add(30*time.Second), 1))
+	assert.Nil(t, a.evaluate(start.Add(50*time.Second), 1))
+	assert.Equal(t, stateFiring, a.state)
+
+	event := a.evaluate(start.Add(60*time.Second), 1)
+	require.NotNil(t, event)
+	assert.Equal(t, eventResolved, event.Type)
+	assert.Equal(t, 60*time.Second, event.Duration)
+	assert.True(t, a.clearing.IsZero())
+}
+
+func TestOperators(t *testing.T) {
+	for op, expected := range map[string][3]bool{
+		">":  {false, false, true},
+		">=": {false, true, true},
+		"<":  {true, false, false},
+		"<=": {true, true, false},
+		"==": {false, true, false},
+		"!=": {true, false, true},
+	} {
+		for i, value := range []float64{1, 2, 3} {
+			assert.Equal(t, expected[i], operators[op](value, 2), "%v %s 2", value, op)
+		}
+	}
+}
+
+func TestEvaluateAll(t *testing.T) {
+	alerts := []*alert{
+		newAlert(Rule{Name: "cpu_hot", Sensor: "temperatures", Key: "CPU", Operator: ">", Threshold: 80}),
+		newAlert(Rule{Name: "gpu_hot", Sensor: "temperatures", Key: "GPU", Operator: ">", Threshold: 80}),
+		newAlert(Rule{Name: "undervolt", Sensor: "throttling", Key: "undervolt", Operator: "==", Threshold: 1}),
+	}
+	now := time.Unix(1700000000, 0)
+	events := evaluateAll(now, alerts, map[string]map[string]float64{
+		"temperatures": {"CPU": 85, "GPU": 60},
+		"throttling":   {"undervolt": 1},
+	})
+	require.Len(t, events, 2)
+	assert.Equal(t, "cpu_hot", events[0].Rule.Name)
+	assert.Equal(t, "undervolt", events[1].Rule.Name)
+
+	// A sensor that couldn't be read leaves its alerts as they were
+	events = evaluateAll(now.Add(time.Minute), alerts, map[string]map[string]float64{
+		"temperatures": {"CPU": 70},
+	})
+	require.Len(t, events, 1)
+	assert.Equal(t, eventResolved, events[0].Type)
+	assert.Equal(t, stateFiring, alerts[2].state)
+	assert.Equal(t, 60.0, alerts[1].value)
+}
+
+func TestSeverityRank(t *testing.T) {
+	assert.Less(t, severityRank(severityInfo), severityRank(severityWarning))
+	assert.Less(t, severityRank(severityWarning), severityRank(severityCritical))
+	assert.Equal(t, 0, severityRank(severityNone))
+}
+
+func TestSameCondition(t *testing.T) {
+	rule := Rule{Name: "cpu_hot", Sensor: "temperatures", Key: "CPU", Operator: ">", Threshold: 80, ForSec: 60}
+	a := newAlert(rule)
+	withActions := rule
+	withActions.Actions = []string{"slack"}
+	assert.True(t, a.sameCondition(newAlert(withActions)))
+	changed := rule
+	changed.Threshold = 85
+	assert.False(t, a.sameCondition(newAlert(changed)))
+
+	// A clear threshold equal to the threshold is the default
+	clearAt := 80.0
+	changed = rule
+	changed.ClearThreshold = &clearAt
+	assert.True(t, a.sameCondition(newAlert(changed)))
+	clearAt = 75
+	assert.False(t, a.sameCondition(newAlert(changed)))
+}
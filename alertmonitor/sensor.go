@@ -0,0 +1,384 @@
+package alertmonitor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"go.viam.com/rdk/components/sensor"
+	"go.viam.com/rdk/logging"
+	"go.viam.com/rdk/resource"
+	viamutils "go.viam.com/utils"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+var (
+	Model       = resource.NewModel(utils.Namespace, "hwmonitor", "alert_monitor")
+	API         = sensor.API
+	PrettyName  = "Alert Monitor"
+	Description = "A sensor that evaluates threshold rules against the readings of other sensors and reports which alerts are firing"
+	Version     = utils.Version
+)
+
+const (
+	defaultIntervalSec = 10
+	maxEvents          = 100
+	maxQueuedActions   = 100
+	readTimeout        = 5 * time.Second
+)
+
+type actionJob struct {
+	action *action
+	event  alertEvent
+}
+
+type Config struct {
+	resource.Named
+	configLock        sync.Mutex
+	readingsLock      sync.RWMutex
+	logger            logging.Logger
+	workers           *viamutils.StoppableWorkers
+	task              *utils.Task
+	client            *http.Client
+	host              string
+	alerts            []*alert
+	actions           map[string]*action
+	jobs              chan actionJob
+	events            utils.RingBuffer[alertEvent]
+	actionCount       int
+	actionFailedCount int
+	lastActionErr     error
+	interval          time.Duration
+	lastErr           error
+	freshness         utils.Freshness
+}
+
+func init() {
+	resource.RegisterComponent(
+		API,
+		Model,
+		resource.Registration[sensor.Sensor, *ComponentConfig]{Constructor: utils.FilterReadings(NewSensor)})
+}
+
+func NewSensor(ctx context.Context, deps resource.Dependencies, conf resource.Config, logger logging.Logger) (sensor.Sensor, error) {
+	logger.Infof("Starting %s %s", PrettyName, Version)
+	host, err := os.Hostname()
+	if err != nil {
+		logger.Warnf("Failed to read the hostname: %v", err)
+	}
+	b := Config{
+		Named:  conf.ResourceName().AsNamed(),
+		logger: logger,
+		client: &http.Client{},
+		host:   host,
+		events: utils.NewRingBuffer[alertEvent](maxEvents),
+		jobs:   make(chan actionJob, maxQueuedActions),
+	}
+
+	if err := b.Reconfigure(ctx, deps, conf); err != nil {
+		return nil, err
+	}
+	// The actions are run by one worker for the life of the sensor, so the actions still queued when it's
+	// reconfigured run rather than being dropped
+	b.workers = viamutils.NewBackgroundStoppableWorkers(func(ctx context.Context) {
+		b.runActions(ctx, b.jobs)
+	})
+	return &b, nil
+}
+
+func (c *Config) Reconfigure(ctx context.Context, deps resource.Dependencies, rawConf resource.Config) error {
+	c.configLock.Lock()
+	defer c.configLock.Unlock()
+	c.logger.Debugf("Reconfiguring %s", PrettyName)
+
+	c.stopPolling()
+
+	conf, err := resource.NativeConfig[*ComponentConfig](rawConf)
+	if err != nil {
+		return err
+	}
+
+	// In case the component has changed name
+	c.Named = rawConf.ResourceName().AsNamed()
+
+	sources, err := utils.ResolveSources(deps, conf.sensors())
+	if err != nil {
+		return err
+	}
+	intervalSec := conf.IntervalSec
+	if intervalSec == 0 {
+		intervalSec = defaultIntervalSec
+	}
+	interval := time.Duration(intervalSec * float64(time.Second))
+
+	actions := make(map[string]*action, len(conf.Actions))
+	for _, actionConf := range conf.Actions {
+		a, err := newAction(actionConf)
+		if err != nil {
+			return fmt.Errorf("action %s: %w", actionConf.Name, err)
+		}
+		actions[actionConf.Name] = a
+	}
+
+	// Alerts whose condition didn't change keep their state, so a firing alert doesn't resolve and fire again
+	c.readingsLock.Lock()
+	previous := make(map[string]*alert, len(c.alerts))
+	for _, a := range c.alerts {
+		previous[a.rule.Name] = a
+	}
+	c.alerts = make([]*alert, 0, len(conf.Rules))
+	for _, rule := range conf.Rules {
+		a := newAlert(rule)
+		if old, ok := previous[rule.Name]; ok && old.sameCondition(a) {
+			old.rule = a.rule
+			a = old
+		}
+		c.alerts = append(c.alerts, a)
+	}
+	c.actions = actions
+	c.interval = interval
+	c.lastErr = nil
+	c.readingsLock.Unlock()
+
+	c.task = utils.ScheduleAligned(interval, utils.ReadingsSource, func(ctx context.Context) {
+		c.evaluate(ctx, sources)
+	})
+	return nil
+}
+
+// stopPolling stops evaluating the rules, the actions already queued still run. configLock must be held.
+func (c *Config) stopPolling() {
+	if c.task == nil {
+		return
+	}
+	c.logger.Debug("Stopping polling")
+	c.task.Stop()
+	c.task = nil
+	c.logger.Debugf("Polling stopped")
+}
+
+// evaluate evaluates the rules against a reading of every source, the scheduler calls it every interval.
+func (c *Config) evaluate(ctx context.Context, sources []utils.Source) {
+	readings := make(map[string]map[string]float64, len(sources))
+	var readErr error
+	for _, s := range sources {
+		readCtx, cancel := context.WithTimeout(ctx, readTimeout)
+		r, err := s.Sensor.Readings(readCtx, nil)
+		cancel()
+		if ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			readErr = fmt.Errorf("failed to read %s: %w", s.Name, err)
+			continue
+		}
+		readings[s.Name] = utils.NumericReadings(r)
+	}
+
+	now := time.Now()
+	c.readingsLock.Lock()
+	for _, event := range evaluateAll(now, c.alerts, readings) {
+		c.recordEvent(event)
+	}
+	if readErr != nil && c.lastErr == nil {
+		c.logger.Warnf("Alerts can't be evaluated: %v", readErr)
+	}
+	c.lastErr = readErr
+	if readErr != nil {
+		c.freshness.Failed(readErr)
+	} else {
+		c.freshness.Succeeded(now)
+	}
+	c.readingsLock.Unlock()
+}
+
+// recordEvent is called with readingsLock held. The event's actions are queued rather than run, so a slow webhook
+// doesn't hold up evaluating the rules.
+func (c *Config) recordEvent(event alertEvent) {
+	c.events.Push(event)
+	rule := event.Rule
+	switch event.Type {
+	case eventFiring:
+		c.logger.Warnf("Alert %s (%s) is firing: %s.%s is %v, %s %v", rule.Name, rule.Severity, rule.Sensor, rule.Key, event.Value, rule.Operator, rule.Threshold)
+	case eventResolved:
+		c.logger.Infof("Alert %s resolved after %v: %s.%s is %v", rule.Name, event.Duration.Round(time.Second), rule.Sensor, rule.Key, event.Value)
+	}
+	for _, name := range rule.Actions {
+		a := c.actions[name]
+		if !a.handles(event.Type) {
+			continue
+		}
+		select {
+		case c.jobs <- actionJob{action: a, event: event}:
+		default:
+			c.actionFailedCount++
+			c.lastActionErr = fmt.Errorf("action %s for alert %s was dropped, too many actions are waiting to run", name, rule.Name)
+			c.logger.Warn(c.lastActionErr)
+		}
+	}
+}
+
+func (c *Config) runActions(ctx context.Context, jobs <-chan actionJob) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case job := <-jobs:
+			err := job.action.run(ctx, c.client, newActionData(job.event, c.host))
+			if ctx.Err() != nil {
+				return
+			}
+			c.readingsLock.Lock()
+			c.actionCount++
+			if err != nil {
+				c.actionFailedCount++
+				c.lastActionErr = fmt.Errorf("action %s for alert %s: %w", job.action.Name, job.event.Rule.Name, err)
+				c.logger.Warnf("Failed to run %v", c.lastActionErr)
+			} else {
+				c.logger.Debugf("Ran action %s for alert %s %s", job.action.Name, job.event.Rule.Name, job.event.Type)
+			}
+			c.readingsLock.Unlock()
+		}
+	}
+}
+
+func (c *Config) Readings(ctx context.Context, extra map[string]interface{}) (map[string]interface{}, error) {
+	c.readingsLock.RLock()
+	defer c.readingsLock.RUnlock()
+	ret := make(map[string]interface{})
+	firing, pending := 0, 0
+	highest := severityNone
+	for _, a := range c.alerts {
+		name := a.rule.Name
+		ret[name+"_state"] = a.state
+		if a.hasValue {
+			ret[name+"_value"] = a.value
+		}
+		switch a.state {
+		case stateFiring:
+			firing++
+			ret[name+"_firing_time"] = a.firedAt.Format(time.RFC3339)
+			if severityRank(a.rule.Severity) > severityRank(highest) {
+				highest = a.rule.Severity
+			}
+		case statePending:
+			pending++
+		}
+		if !a.resolvedAt.IsZero() {
+			ret[name+"_resolved_time"] = a.resolvedAt.Format(time.RFC3339)
+		}
+	}
+	ret["firing_count"] = firing
+	ret["pending_count"] = pending
+	ret["highest_severity"] = highest
+	ret["action_count"] = c.actionCount
+	ret["action_failed_count"] = c.actionFailedCount
+	if c.lastActionErr != nil {
+		ret["last_action_error"] = c.lastActionErr.Error()
+	}
+	if c.lastErr != nil {
+		ret["last_error"] = c.lastErr.Error()
+	}
+	return c.freshness.Stamp(ret, c.interval, time.Now()), nil
+}
+
+func (c *Config) DoCommand(ctx context.Context, cmd map[string]interface{}) (map[string]interface{}, error) {
+	command, ok := cmd["command"].(string)
+	if !ok {
+		return nil, errors.New("missing or invalid 'command' field")
+	}
+
+	switch command {
+	case "get_alerts":
+		return c.handleGetAlerts()
+	case "get_events":
+		return c.handleGetEvents()
+	default:
+		return nil, fmt.Errorf("unknown command: %s", command)
+	}
+}
+
+// handleGetAlerts returns every alert with its rule and state.
+func (c *Config) handleGetAlerts() (map[string]interface{}, error) {
+	c.readingsLock.RLock()
+	defer c.readingsLock.RUnlock()
+	ret := make([]interface{}, 0, len(c.alerts))
+	for _, a := range c.alerts {
+		alert := ruleMap(a.rule)
+		alert["state"] = a.state
+		if a.hasValue {
+			alert["value"] = a.value
+		}
+		switch a.state {
+		case stateFiring:
+			alert["firing_time"] = a.firedAt.Format(time.RFC3339)
+		case statePending:
+			alert["pending_time"] = a.since.Format(time.RFC3339)
+		}
+		if !a.clearing.IsZero() {
+			alert["clearing_time"] = a.clearing.Format(time.RFC3339)
+		}
+		if !a.resolvedAt.IsZero() {
+			alert["resolved_time"] = a.resolvedAt.Format(time.RFC3339)
+		}
+		ret = append(ret, alert)
+	}
+	return map[string]interface{}{"alerts": ret}, nil
+}
+
+// handleGetEvents returns the last alerts that fired or resolved, oldest first.
+func (c *Config) handleGetEvents() (map[string]interface{}, error) {
+	events := c.events.Items()
+	ret := make([]interface{}, 0, len(events))
+	for _, event := range events {
+		e := ruleMap(event.Rule)
+		e["type"] = event.Type
+		e["time"] = event.Time.Format(time.RFC3339)
+		e["value"] = event.Value
+		if event.Type == eventResolved {
+			e["duration_sec"] = utils.RoundValue(event.Duration.Seconds(), 3)
+		}
+		ret = append(ret, e)
+	}
+	return map[string]interface{}{"events": ret}, nil
+}
+
+func ruleMap(rule Rule) map[string]interface{} {
+	ret := map[string]interface{}{
+		"name":      rule.Name,
+		"sensor":    rule.Sensor,
+		"key":       rule.Key,
+		"operator":  rule.Operator,
+		"threshold": rule.Threshold,
+		"for_sec":   rule.ForSec,
+		"severity":  rule.Severity,
+	}
+	if rule.ClearThreshold != nil {
+		ret["clear_threshold"] = *rule.ClearThreshold
+	}
+	if rule.ClearForSec > 0 {
+		ret["clear_for_sec"] = rule.ClearForSec
+	}
+	return ret
+}
+
+func (c *Config) Close(ctx context.Context) error {
+	c.configLock.Lock()
+	defer c.configLock.Unlock()
+	c.logger.Infof("Shutting down %s", PrettyName)
+	c.stopPolling()
+	if c.workers != nil {
+		c.workers.Stop()
+	}
+	c.logger.Infof("%s Shutdown complete", PrettyName)
+	return nil
+}
+
+func (c *Config) Ready(ctx context.Context, extra map[string]interface{}) (bool, error) {
+	return false, nil
+}
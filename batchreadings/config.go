@@ -0,0 +1,15 @@
+package batchreadings
+
+import "errors"
+
+type ComponentConfig struct {
+	Sensors   []string `json:"sensors,omitempty"`    // The sensors to read, every component of the module when empty
+	TimeoutMs int      `json:"timeout_ms,omitempty"` // How long each sensor has to return its readings, defaults to 5000
+}
+
+func (conf *ComponentConfig) Validate(path string) ([]string, error) {
+	if conf.TimeoutMs < 0 {
+		return nil, errors.New("timeout_ms must not be negative")
+	}
+	return conf.Sensors, nil
+}
@@ -0,0 +1,224 @@
+package batchreadings
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"go.viam.com/rdk/components/sensor"
+	"go.viam.com/rdk/logging"
+	"go.viam.com/rdk/resource"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+var (
+	Model       = resource.NewModel(utils.Namespace, "hwmonitor", "batch_readings")
+	API         = sensor.API
+	PrettyName  = "Batch Readings"
+	Description = "A sensor that returns the readings of every sensor of the module in one DoCommand"
+	Version     = utils.Version
+)
+
+const (
+	// ReadAllCommand returns the readings of every sensor
+	ReadAllCommand = "get_all_readings"
+	defaultTimeout = 5 * time.Second
+)
+
+type Config struct {
+	resource.Named
+	mu           sync.RWMutex
+	logger       logging.Logger
+	sensors      map[string]sensor.Sensor
+	timeout      time.Duration
+	requestCount int
+	lastDuration time.Duration
+}
+
+func init() {
+	resource.RegisterComponent(
+		API,
+		Model,
+		resource.Registration[sensor.Sensor, *ComponentConfig]{Constructor: utils.FilterReadings(NewSensor)})
+}
+
+func NewSensor(ctx context.Context, deps resource.Dependencies, conf resource.Config, logger logging.Logger) (sensor.Sensor, error) {
+	logger.Infof("Starting %s %s", PrettyName, Version)
+	b := Config{
+		Named:  conf.ResourceName().AsNamed(),
+		logger: logger,
+	}
+
+	if err := b.Reconfigure(ctx, deps, conf); err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
+
+func (c *Config) Reconfigure(ctx context.Context, deps resource.Dependencies, rawConf resource.Config) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.logger.Debugf("Reconfiguring %s", PrettyName)
+
+	conf, err := resource.NativeConfig[*ComponentConfig](rawConf)
+	if err != nil {
+		return err
+	}
+
+	// In case the component has changed name
+	c.Named = rawConf.ResourceName().AsNamed()
+
+	// Without configured sensors every component of the module is read, whichever are running when the command comes
+	var sensors map[string]sensor.Sensor
+	if len(conf.Sensors) > 0 {
+		sensors = make(map[string]sensor.Sensor, len(conf.Sensors))
+		for _, name := range conf.Sensors {
+			s, err := sensor.FromDependencies(deps, name)
+			if err != nil {
+				return err
+			}
+			sensors[name] = s
+		}
+	}
+	c.sensors = sensors
+	c.timeout = time.Duration(conf.TimeoutMs) * time.Millisecond
+	if c.timeout == 0 {
+		c.timeout = defaultTimeout
+	}
+	return nil
+}
+
+func (c *Config) Readings(ctx context.Context, extra map[string]interface{}) (map[string]interface{}, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return map[string]interface{}{
+		"sensor_count":     len(c.sources()),
+		"request_count":    c.requestCount,
+		"last_duration_ms": utils.RoundValue(float64(c.lastDuration)/float64(time.Millisecond), 3),
+	}, nil
+}
+
+func (c *Config) DoCommand(ctx context.Context, cmd map[string]interface{}) (map[string]interface{}, error) {
+	command, ok := cmd["command"].(string)
+	if !ok {
+		return nil, errors.New("missing or invalid 'command' field")
+	}
+
+	switch command {
+	case ReadAllCommand:
+		return c.handleReadAll(ctx, cmd)
+	default:
+		return nil, fmt.Errorf("unknown command: %s", command)
+	}
+}
+
+// handleReadAll reads every sensor at once, or those in the command's sensors, and returns their readings and the
+// errors, and their kinds, of those that failed keyed by the sensor.
+func (c *Config) handleReadAll(ctx context.Context, cmd map[string]interface{}) (map[string]interface{}, error) {
+	c.mu.RLock()
+	sources, timeout := c.sources(), c.timeout
+	c.mu.RUnlock()
+	if raw, ok := cmd["sensors"]; ok {
+		names, ok := raw.([]interface{})
+		if !ok {
+			return nil, errors.New("sensors must be a list of sensor names")
+		}
+		selected := make(map[string]sensor.Sensor, len(names))
+		for _, raw := range names {
+			name, ok := raw.(string)
+			if !ok {
+				return nil, errors.New("sensors must be a list of sensor names")
+			}
+			s, ok := sources[name]
+			if !ok {
+				return nil, fmt.Errorf("unknown sensor: %s", name)
+			}
+			selected[name] = s
+		}
+		sources = selected
+	}
+
+	start := time.Now()
+	readings, errs, kinds := readAll(ctx, sources, timeout)
+	duration := time.Since(start)
+	c.mu.Lock()
+	c.requestCount++
+	c.lastDuration = duration
+	c.mu.Unlock()
+
+	names := make([]string, 0, len(sources))
+	for name := range sources {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return map[string]interface{}{
+		"sensors":     stringsToInterfaces(names),
+		"readings":    readings,
+		"errors":      errs,
+		"error_kinds": kinds,
+		"time":        start.UTC().Format(time.RFC3339Nano),
+		"duration_ms": utils.RoundValue(float64(duration)/float64(time.Millisecond), 3),
+	}, nil
+}
+
+// sources returns the sensors to read, the configured ones or every other component of the module. The lock must be
+// held.
+func (c *Config) sources() map[string]sensor.Sensor {
+	if c.sensors != nil {
+		return c.sensors
+	}
+	sources := utils.ModuleSensors()
+	delete(sources, c.Name().Name)
+	return sources
+}
+
+// readAll reads the sensors concurrently, so a slow sensor only delays the response by its own timeout rather than
+// the sum of every sensor's. It returns the readings, errors and error kinds, see utils.ErrorKind, keyed by the sensor.
+func readAll(ctx context.Context, sources map[string]sensor.Sensor, timeout time.Duration) (map[string]interface{}, map[string]interface{}, map[string]interface{}) {
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	readings := make(map[string]interface{}, len(sources))
+	errs := make(map[string]interface{})
+	kinds := make(map[string]interface{})
+	for name, s := range sources {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			readCtx, cancel := context.WithTimeout(ctx, timeout)
+			defer cancel()
+			r, err := s.Readings(readCtx, nil)
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				errs[name] = err.Error()
+				kinds[name] = utils.ErrorKind(err)
+				return
+			}
+			readings[name] = r
+		}()
+	}
+	wg.Wait()
+	return readings, errs, kinds
+}
+
+func (c *Config) Close(ctx context.Context) error {
+	c.logger.Infof("Shutting down %s", PrettyName)
+	return nil
+}
+
+func (c *Config) Ready(ctx context.Context, extra map[string]interface{}) (bool, error) {
+	return false, nil
+}
+
+// stringsToInterfaces converts a []string so the result can be returned over DoCommand.
+func stringsToInterfaces(values []string) []interface{} {
+	ret := make([]interface{}, 0, len(values))
+	for _, value := range values {
+		ret = append(ret, value)
+	}
+	return ret
+}
@@ -0,0 +1,53 @@
+package batchreadings
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+	"github.com/stretchr/testify/assert"
+	"go.viam.com/rdk/components/sensor"
+)
+
+type testSensor struct {
+	sensor.Sensor
+	readings map[string]interface{}
+	err      error
+	delay    time.Duration
+}
+
+func (s *testSensor) Readings(ctx context.Context, extra map[string]interface{}) (map[string]interface{}, error) {
+	select {
+	case <-time.After(s.delay):
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	return s.readings, s.err
+}
+
+func TestReadAll(t *testing.T) {
+	sources := map[string]sensor.Sensor{
+		"cpu":    &testSensor{readings: map[string]interface{}{"usage": 12.5}},
+		"disk":   &testSensor{err: utils.NewError(utils.ErrHardwareAbsent, "no disks")},
+		"stuck":  &testSensor{delay: time.Minute},
+		"memory": &testSensor{readings: map[string]interface{}{"used_percent": 40.0}, delay: 10 * time.Millisecond},
+	}
+	start := time.Now()
+	readings, errs, kinds := readAll(context.Background(), sources, 50*time.Millisecond)
+
+	// The stuck sensor only holds the response up for its own timeout
+	assert.Less(t, time.Since(start), time.Second)
+	assert.Equal(t, map[string]interface{}{
+		"cpu":    map[string]interface{}{"usage": 12.5},
+		"memory": map[string]interface{}{"used_percent": 40.0},
+	}, readings)
+	assert.Equal(t, map[string]interface{}{
+		"disk":  "no disks",
+		"stuck": context.DeadlineExceeded.Error(),
+	}, errs)
+	assert.Equal(t, map[string]interface{}{
+		"disk":  utils.ErrorKindHardwareAbsent,
+		"stuck": utils.ErrorKindTransient,
+	}, kinds)
+}
@@ -0,0 +1,130 @@
+package boardhealth
+
+import (
+	"fmt"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+const (
+	statusOK       = "ok"
+	statusDegraded = "degraded"
+	statusCritical = "critical"
+)
+
+var operators = map[string]func(value, threshold float64) bool{
+	">":  func(v, t float64) bool { return v > t },
+	">=": func(v, t float64) bool { return v >= t },
+	"<":  func(v, t float64) bool { return v < t },
+	"<=": func(v, t float64) bool { return v <= t },
+	"==": func(v, t float64) bool { return v == t },
+	"!=": func(v, t float64) bool { return v != t },
+}
+
+// statusRank orders statuses from healthy to failing.
+func statusRank(status string) int {
+	switch status {
+	case statusDegraded:
+		return 1
+	case statusCritical:
+		return 2
+	default:
+		return 0
+	}
+}
+
+// statusScore is how much of its weight a check adds to the score.
+func statusScore(status string) float64 {
+	switch status {
+	case statusDegraded:
+		return 0.5
+	case statusCritical:
+		return 0
+	default:
+		return 1
+	}
+}
+
+// sensorReading is what was read from one sensor, err is set when it couldn't be read.
+type sensorReading struct {
+	values map[string]float64
+	err    error
+}
+
+// weight is the check's share of the score.
+func (check Check) weight() float64 {
+	if check.Weight == 0 {
+		return 1
+	}
+	return check.Weight
+}
+
+type checkResult struct {
+	check    Check
+	status   string
+	value    float64
+	hasValue bool
+	reason   string // Why the check isn't ok
+}
+
+// evaluate works out the status of the check from what its sensor returned.
+func evaluate(check Check, reading sensorReading) checkResult {
+	res := checkResult{check: check, status: statusOK}
+	missing := check.MissingStatus
+	if missing == "" {
+		missing = statusDegraded
+	}
+	if reading.err != nil {
+		res.status = missing
+		res.reason = reading.err.Error()
+		return res
+	}
+	if check.Key == "" {
+		return res
+	}
+	value, ok := reading.values[check.Key]
+	if !ok {
+		res.status = missing
+		res.reason = fmt.Sprintf("%s has no reading %s", check.Sensor, check.Key)
+		return res
+	}
+	res.value, res.hasValue = value, true
+	crosses := operators[check.Operator]
+	switch {
+	case check.Critical != nil && crosses(value, *check.Critical):
+		res.status = statusCritical
+		res.reason = fmt.Sprintf("%s is %v, %s %v", check.Key, value, check.Operator, *check.Critical)
+	case check.Degraded != nil && crosses(value, *check.Degraded):
+		res.status = statusDegraded
+		res.reason = fmt.Sprintf("%s is %v, %s %v", check.Key, value, check.Operator, *check.Degraded)
+	}
+	return res
+}
+
+type summary struct {
+	status  string
+	score   float64 // 0 to 100
+	failing []string
+	checks  []checkResult
+}
+
+// summarize evaluates every check. The status is the worst status of the checks and the score is the weighted share
+// of the checks that are ok, with degraded checks counting half.
+func summarize(checks []Check, readings map[string]sensorReading) summary {
+	s := summary{status: statusOK, checks: make([]checkResult, 0, len(checks))}
+	var total, weights float64
+	for _, check := range checks {
+		res := evaluate(check, readings[check.Sensor])
+		s.checks = append(s.checks, res)
+		if statusRank(res.status) > statusRank(s.status) {
+			s.status = res.status
+		}
+		if res.status != statusOK {
+			s.failing = append(s.failing, check.Name)
+		}
+		total += check.weight() * statusScore(res.status)
+		weights += check.weight()
+	}
+	s.score = utils.RoundValue(100*total/weights, 1)
+	return s
+}
@@ -0,0 +1,66 @@
+package boardhealth
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEvaluate(t *testing.T) {
+	degraded, critical := 70.0, 85.0
+	check := Check{Name: "cpu_temp", Sensor: "temperatures", Key: "CPU", Operator: ">", Degraded: &degraded, Critical: &critical}
+	for value, status := range map[float64]string{50: statusOK, 70: statusOK, 75: statusDegraded, 90: statusCritical} {
+		res := evaluate(check, sensorReading{values: map[string]float64{"CPU": value}})
+		assert.Equal(t, status, res.status, value)
+		assert.Equal(t, value, res.value)
+	}
+
+	res := evaluate(check, sensorReading{values: map[string]float64{"GPU": 50}})
+	assert.Equal(t, statusDegraded, res.status)
+	assert.False(t, res.hasValue)
+	assert.Equal(t, "temperatures has no reading CPU", res.reason)
+
+	check.MissingStatus = statusCritical
+	res = evaluate(check, sensorReading{err: errors.New("failed to read temperatures")})
+	assert.Equal(t, statusCritical, res.status)
+	assert.Equal(t, "failed to read temperatures", res.reason)
+
+	// Without a key the sensor only has to be readable
+	reachable := Check{Name: "disk", Sensor: "disk_monitor"}
+	assert.Equal(t, statusOK, evaluate(reachable, sensorReading{values: map[string]float64{}}).status)
+	assert.Equal(t, statusDegraded, evaluate(reachable, sensorReading{err: errors.New("timeout")}).status)
+}
+
+func TestSummarize(t *testing.T) {
+	degraded, critical, undervolt := 70.0, 85.0, 1.0
+	checks := []Check{
+		{Name: "cpu_temp", Sensor: "temperatures", Key: "CPU", Operator: ">", Degraded: &degraded, Critical: &critical, Weight: 2},
+		{Name: "undervolt", Sensor: "throttling", Key: "undervolt", Operator: "==", Critical: &undervolt},
+		{Name: "disk", Sensor: "disk_monitor"},
+	}
+	readings := map[string]sensorReading{
+		"temperatures": {values: map[string]float64{"CPU": 60}},
+		"throttling":   {values: map[string]float64{"undervolt": 0}},
+		"disk_monitor": {values: map[string]float64{}},
+	}
+	s := summarize(checks, readings)
+	assert.Equal(t, statusOK, s.status)
+	assert.Equal(t, 100.0, s.score)
+	assert.Empty(t, s.failing)
+	require.Len(t, s.checks, 3)
+
+	readings["temperatures"] = sensorReading{values: map[string]float64{"CPU": 75}}
+	s = summarize(checks, readings)
+	assert.Equal(t, statusDegraded, s.status)
+	assert.Equal(t, 75.0, s.score)
+	assert.Equal(t, []string{"cpu_temp"}, s.failing)
+
+	readings["throttling"] = sensorReading{values: map[string]float64{"undervolt": 1}}
+	readings["disk_monitor"] = sensorReading{err: errors.New("timeout")}
+	s = summarize(checks, readings)
+	assert.Equal(t, statusCritical, s.status)
+	assert.Equal(t, 37.5, s.score)
+	assert.Equal(t, []string{"cpu_temp", "undervolt", "disk"}, s.failing)
+}
@@ -0,0 +1,74 @@
+package boardhealth
+
+import (
+	"errors"
+	"fmt"
+)
+
+type Check struct {
+	Name          string   `json:"name"`                     // Identifies the check in readings
+	Sensor        string   `json:"sensor"`                   // The sensor the check reads
+	Key           string   `json:"key,omitempty"`            // The reading, without one the check only needs the sensor to be readable
+	Operator      string   `json:"operator,omitempty"`       // >, >=, <, <=, == or !=, how a value crosses the thresholds
+	Degraded      *float64 `json:"degraded,omitempty"`       // The check is degraded when the value crosses this
+	Critical      *float64 `json:"critical,omitempty"`       // The check is critical when the value crosses this
+	Weight        float64  `json:"weight,omitempty"`         // The share of the score, defaults to 1
+	MissingStatus string   `json:"missing_status,omitempty"` // The status when the value can't be read, degraded or critical, defaults to degraded
+}
+
+type ComponentConfig struct {
+	Checks      []Check `json:"checks"`
+	IntervalSec float64 `json:"interval_sec,omitempty"` // How often the checks are evaluated, defaults to 10
+}
+
+func (conf *ComponentConfig) Validate(path string) ([]string, error) {
+	if len(conf.Checks) == 0 {
+		return nil, errors.New("at least one check is required")
+	}
+	if conf.IntervalSec < 0 {
+		return nil, errors.New("interval_sec must not be negative")
+	}
+	names := make(map[string]bool, len(conf.Checks))
+	for i, check := range conf.Checks {
+		if check.Name == "" {
+			return nil, fmt.Errorf("check %d: name is required", i)
+		}
+		if names[check.Name] {
+			return nil, fmt.Errorf("check %s: name is used by another check", check.Name)
+		}
+		names[check.Name] = true
+		if check.Sensor == "" {
+			return nil, fmt.Errorf("check %s: sensor is required", check.Name)
+		}
+		if check.Key != "" {
+			if _, ok := operators[check.Operator]; !ok {
+				return nil, fmt.Errorf("check %s: operator must be one of >, >=, <, <=, == or !=", check.Name)
+			}
+			if check.Degraded == nil && check.Critical == nil {
+				return nil, fmt.Errorf("check %s: degraded or critical is required", check.Name)
+			}
+		} else if check.Operator != "" || check.Degraded != nil || check.Critical != nil {
+			return nil, fmt.Errorf("check %s: operator, degraded and critical need a key", check.Name)
+		}
+		if check.Weight < 0 {
+			return nil, fmt.Errorf("check %s: weight must not be negative", check.Name)
+		}
+		if check.MissingStatus != "" && check.MissingStatus != statusDegraded && check.MissingStatus != statusCritical {
+			return nil, fmt.Errorf("check %s: missing_status must be degraded or critical", check.Name)
+		}
+	}
+	return conf.sensors(), nil
+}
+
+// sensors returns the sensors the checks read, each once.
+func (conf *ComponentConfig) sensors() []string {
+	var ret []string
+	seen := make(map[string]bool)
+	for _, check := range conf.Checks {
+		if !seen[check.Sensor] {
+			seen[check.Sensor] = true
+			ret = append(ret, check.Sensor)
+		}
+	}
+	return ret
+}
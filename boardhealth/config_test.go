@@ -0,0 +1,37 @@
+package boardhealth
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidate(t *testing.T) {
+	hot, critical := 70.0, 85.0
+	conf := &ComponentConfig{Checks: []Check{
+		{Name: "cpu_temp", Sensor: "temperatures", Key: "CPU", Operator: ">", Degraded: &hot, Critical: &critical, Weight: 2},
+		{Name: "gpu_temp", Sensor: "temperatures", Key: "GPU", Operator: ">", Critical: &critical},
+		{Name: "disk", Sensor: "disk_monitor", MissingStatus: "critical"},
+	}}
+	deps, err := conf.Validate("")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"temperatures", "disk_monitor"}, deps)
+
+	valid := Check{Name: "disk", Sensor: "disk_monitor"}
+	for _, checks := range [][]Check{
+		nil,
+		{{Sensor: "disk_monitor"}},
+		{valid, valid},
+		{{Name: "disk"}},
+		{{Name: "cpu_temp", Sensor: "temperatures", Key: "CPU", Operator: "=>", Critical: &critical}},
+		{{Name: "cpu_temp", Sensor: "temperatures", Key: "CPU", Operator: ">"}},
+		{{Name: "cpu_temp", Sensor: "temperatures", Operator: ">", Critical: &critical}},
+		{{Name: "disk", Sensor: "disk_monitor", Weight: -1}},
+		{{Name: "disk", Sensor: "disk_monitor", MissingStatus: "ok"}},
+	} {
+		_, err := (&ComponentConfig{Checks: checks}).Validate("")
+		assert.Error(t, err, checks)
+	}
+	_, err = (&ComponentConfig{Checks: []Check{valid}, IntervalSec: -1}).Validate("")
+	assert.Error(t, err)
+}
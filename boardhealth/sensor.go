@@ -0,0 +1,221 @@
+package boardhealth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"go.viam.com/rdk/components/sensor"
+	"go.viam.com/rdk/logging"
+	"go.viam.com/rdk/resource"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+var (
+	Model       = resource.NewModel(utils.Namespace, "hwmonitor", "board_health")
+	API         = sensor.API
+	PrettyName  = "Board Health"
+	Description = "A sensor that rolls the readings of other sensors up into one health status and score"
+	Version     = utils.Version
+)
+
+const (
+	defaultIntervalSec = 10
+	readTimeout        = 5 * time.Second
+	statusUnknown      = "unknown"
+)
+
+type Config struct {
+	resource.Named
+	configLock   sync.Mutex
+	readingsLock sync.RWMutex
+	logger       logging.Logger
+	task         *utils.Task
+	summary      *summary
+	lastChecked  time.Time
+	interval     time.Duration
+	freshness    utils.Freshness
+}
+
+func init() {
+	resource.RegisterComponent(
+		API,
+		Model,
+		resource.Registration[sensor.Sensor, *ComponentConfig]{Constructor: utils.FilterReadings(NewSensor)})
+}
+
+func NewSensor(ctx context.Context, deps resource.Dependencies, conf resource.Config, logger logging.Logger) (sensor.Sensor, error) {
+	logger.Infof("Starting %s %s", PrettyName, Version)
+	b := Config{
+		Named:  conf.ResourceName().AsNamed(),
+		logger: logger,
+	}
+
+	if err := b.Reconfigure(ctx, deps, conf); err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
+
+func (c *Config) Reconfigure(ctx context.Context, deps resource.Dependencies, rawConf resource.Config) error {
+	c.configLock.Lock()
+	defer c.configLock.Unlock()
+	c.logger.Debugf("Reconfiguring %s", PrettyName)
+
+	if c.task != nil {
+		c.logger.Debug("Stopping polling")
+		c.task.Stop()
+		c.logger.Debugf("Polling stopped")
+		c.task = nil
+	}
+
+	conf, err := resource.NativeConfig[*ComponentConfig](rawConf)
+	if err != nil {
+		return err
+	}
+
+	// In case the component has changed name
+	c.Named = rawConf.ResourceName().AsNamed()
+
+	sources, err := utils.ResolveSources(deps, conf.sensors())
+	if err != nil {
+		return err
+	}
+	intervalSec := conf.IntervalSec
+	if intervalSec == 0 {
+		intervalSec = defaultIntervalSec
+	}
+	interval := time.Duration(intervalSec * float64(time.Second))
+
+	// The previous summary is kept until the new checks have run, so the status doesn't go missing and its change is
+	// only logged when it actually changes
+	checks := conf.Checks
+	c.readingsLock.Lock()
+	c.interval = interval
+	c.readingsLock.Unlock()
+	c.task = utils.ScheduleAligned(interval, utils.ReadingsSource, func(ctx context.Context) {
+		c.check(ctx, sources, checks)
+	})
+	return nil
+}
+
+// check evaluates the checks against a reading of every source, the scheduler calls it every interval.
+func (c *Config) check(ctx context.Context, sources []utils.Source, checks []Check) {
+	readings := make(map[string]sensorReading, len(sources))
+	for _, s := range sources {
+		readCtx, cancel := context.WithTimeout(ctx, readTimeout)
+		r, err := s.Sensor.Readings(readCtx, nil)
+		cancel()
+		if ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			readings[s.Name] = sensorReading{err: fmt.Errorf("failed to read %s: %w", s.Name, err)}
+			continue
+		}
+		readings[s.Name] = sensorReading{values: utils.NumericReadings(r)}
+	}
+
+	s := summarize(checks, readings)
+	c.readingsLock.Lock()
+	previous := statusUnknown
+	if c.summary != nil {
+		previous = c.summary.status
+	}
+	if s.status != previous {
+		if s.status == statusOK {
+			c.logger.Infof("Board health is ok")
+		} else {
+			c.logger.Warnf("Board health is %s, failing checks: %s", s.status, strings.Join(s.failing, ", "))
+		}
+	}
+	c.summary = &s
+	c.lastChecked = time.Now()
+	// Sources that can't be read fail their checks, the checks themselves were still evaluated
+	c.freshness.Succeeded(c.lastChecked)
+	c.readingsLock.Unlock()
+}
+
+func (c *Config) Readings(ctx context.Context, extra map[string]interface{}) (map[string]interface{}, error) {
+	c.readingsLock.RLock()
+	defer c.readingsLock.RUnlock()
+	now := time.Now()
+	if c.summary == nil {
+		return c.freshness.Stamp(map[string]interface{}{"status": statusUnknown}, c.interval, now), nil
+	}
+	ret := map[string]interface{}{
+		"status":        c.summary.status,
+		"score":         c.summary.score,
+		"failing":       strings.Join(c.summary.failing, ","),
+		"failing_count": len(c.summary.failing),
+		"check_count":   len(c.summary.checks),
+		"checked_time":  c.lastChecked.Format(time.RFC3339),
+	}
+	for _, res := range c.summary.checks {
+		ret[res.check.Name+"_status"] = res.status
+		if res.hasValue {
+			ret[res.check.Name+"_value"] = res.value
+		}
+	}
+	return c.freshness.Stamp(ret, c.interval, now), nil
+}
+
+func (c *Config) DoCommand(ctx context.Context, cmd map[string]interface{}) (map[string]interface{}, error) {
+	command, ok := cmd["command"].(string)
+	if !ok {
+		return nil, errors.New("missing or invalid 'command' field")
+	}
+
+	switch command {
+	case "get_checks":
+		return c.handleGetChecks()
+	default:
+		return nil, fmt.Errorf("unknown command: %s", command)
+	}
+}
+
+// handleGetChecks returns every check with its status and why it's failing.
+func (c *Config) handleGetChecks() (map[string]interface{}, error) {
+	c.readingsLock.RLock()
+	defer c.readingsLock.RUnlock()
+	if c.summary == nil {
+		return map[string]interface{}{"checks": []interface{}{}}, nil
+	}
+	ret := make([]interface{}, 0, len(c.summary.checks))
+	for _, res := range c.summary.checks {
+		check := map[string]interface{}{
+			"name":   res.check.Name,
+			"sensor": res.check.Sensor,
+			"key":    res.check.Key,
+			"status": res.status,
+			"weight": res.check.weight(),
+		}
+		if res.hasValue {
+			check["value"] = res.value
+		}
+		if res.reason != "" {
+			check["reason"] = res.reason
+		}
+		ret = append(ret, check)
+	}
+	return map[string]interface{}{"checks": ret}, nil
+}
+
+func (c *Config) Close(ctx context.Context) error {
+	c.configLock.Lock()
+	defer c.configLock.Unlock()
+	c.logger.Infof("Shutting down %s", PrettyName)
+	if c.task != nil {
+		c.task.Stop()
+	}
+	c.logger.Infof("%s Shutdown complete", PrettyName)
+	return nil
+}
+
+func (c *Config) Ready(ctx context.Context, extra map[string]interface{}) (bool, error) {
+	return false, nil
+}
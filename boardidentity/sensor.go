@@ -0,0 +1,101 @@
+package boardidentity
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+
+	"go.viam.com/rdk/components/sensor"
+	"go.viam.com/rdk/logging"
+	"go.viam.com/rdk/resource"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/linux"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+var (
+	Model       = resource.NewModel(utils.Namespace, "hwmonitor", "board_identity")
+	API         = sensor.API
+	PrettyName  = "SBC Board Identity"
+	Description = "A sensor that reports the board serial number, SoC unique ID, MAC addresses and machine-id"
+	Version     = utils.Version
+)
+
+type Config struct {
+	resource.Named
+	mu         sync.RWMutex
+	logger     logging.Logger
+	cancelCtx  context.Context
+	cancelFunc func()
+}
+
+func init() {
+	resource.RegisterComponent(
+		API,
+		Model,
+		resource.Registration[sensor.Sensor, *ComponentConfig]{Constructor: utils.FilterReadings(NewSensor)})
+	utils.RegisterSimulator(Model, simulate)
+}
+
+func NewSensor(ctx context.Context, deps resource.Dependencies, conf resource.Config, logger logging.Logger) (sensor.Sensor, error) {
+	logger.Infof("Starting %s %s", PrettyName, Version)
+	cancelCtx, cancelFunc := context.WithCancel(context.Background())
+
+	b := Config{
+		Named:      conf.ResourceName().AsNamed(),
+		logger:     logger,
+		cancelCtx:  cancelCtx,
+		cancelFunc: cancelFunc,
+		mu:         sync.RWMutex{},
+	}
+
+	if err := b.Reconfigure(ctx, deps, conf); err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
+
+func (c *Config) Reconfigure(ctx context.Context, _ resource.Dependencies, conf resource.Config) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.logger.Debugf("Reconfiguring %s", PrettyName)
+
+	// In case the module has changed name
+	c.Named = conf.ResourceName().AsNamed()
+
+	return nil
+}
+
+func (c *Config) Readings(ctx context.Context, extra map[string]interface{}) (map[string]interface{}, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	// Not cached, USB network adapters can come and go while the module runs
+	identity, err := linux.ReadIdentity(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ret := map[string]interface{}{
+		"serial":     identity.Serial,
+		"soc_id":     identity.SoCID,
+		"machine_id": identity.MachineID,
+	}
+	interfaces := make([]string, 0, len(identity.MACAddresses))
+	for name, address := range identity.MACAddresses {
+		ret[name+"_mac"] = address
+		interfaces = append(interfaces, name)
+	}
+	sort.Strings(interfaces)
+	ret["interfaces"] = strings.Join(interfaces, ",")
+	return ret, nil
+}
+
+func (c *Config) Close(ctx context.Context) error {
+	c.logger.Infof("Shutting down %s", PrettyName)
+	c.cancelFunc()
+	return nil
+}
+
+func (c *Config) Ready(ctx context.Context, extra map[string]interface{}) (bool, error) {
+	return false, nil
+}
@@ -0,0 +1,15 @@
+package boardidentity
+
+import "github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+
+// simulate returns the identity of a Raspberry Pi 5 with its ethernet and wifi interfaces.
+func simulate(sim *utils.Simulation) map[string]interface{} {
+	return map[string]interface{}{
+		"serial":     "9f3c1b2a7e5d4c68",
+		"soc_id":     "9f3c1b2a7e5d4c68",
+		"machine_id": "3b1e9a7c5d2f4e6a8b0c1d2e3f4a5b6c",
+		"eth0_mac":   "2c:cf:67:1a:2b:3c",
+		"wlan0_mac":  "2c:cf:67:1a:2b:3d",
+		"interfaces": "eth0,wlan0",
+	}
+}
@@ -0,0 +1,8 @@
+package boardinfo
+
+type ComponentConfig struct {
+}
+
+func (conf *ComponentConfig) Validate(path string) ([]string, error) {
+	return nil, nil
+}
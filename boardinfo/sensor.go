@@ -0,0 +1,92 @@
+package boardinfo
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"go.viam.com/rdk/components/sensor"
+	"go.viam.com/rdk/logging"
+	"go.viam.com/rdk/resource"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/linux"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+var (
+	Model       = resource.NewModel(utils.Namespace, "hwmonitor", "board_info")
+	API         = sensor.API
+	PrettyName  = "SBC Board Info"
+	Description = "A sensor that reports the board family, model and revision detected from the device tree or DMI"
+	Version     = utils.Version
+)
+
+type Config struct {
+	resource.Named
+	mu         sync.RWMutex
+	logger     logging.Logger
+	cancelCtx  context.Context
+	cancelFunc func()
+}
+
+func init() {
+	resource.RegisterComponent(
+		API,
+		Model,
+		resource.Registration[sensor.Sensor, *ComponentConfig]{Constructor: utils.FilterReadings(NewSensor)})
+	utils.RegisterSimulator(Model, simulate)
+}
+
+func NewSensor(ctx context.Context, deps resource.Dependencies, conf resource.Config, logger logging.Logger) (sensor.Sensor, error) {
+	logger.Infof("Starting %s %s", PrettyName, Version)
+	cancelCtx, cancelFunc := context.WithCancel(context.Background())
+
+	b := Config{
+		Named:      conf.ResourceName().AsNamed(),
+		logger:     logger,
+		cancelCtx:  cancelCtx,
+		cancelFunc: cancelFunc,
+		mu:         sync.RWMutex{},
+	}
+
+	if err := b.Reconfigure(ctx, deps, conf); err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
+
+func (c *Config) Reconfigure(ctx context.Context, _ resource.Dependencies, conf resource.Config) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.logger.Debugf("Reconfiguring %s", PrettyName)
+
+	// In case the module has changed name
+	c.Named = conf.ResourceName().AsNamed()
+
+	return nil
+}
+
+func (c *Config) Readings(ctx context.Context, extra map[string]interface{}) (map[string]interface{}, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	// The board doesn't change while the module runs, so this reports the same cached result the backends were selected with
+	board := linux.Board()
+	return map[string]interface{}{
+		"family":     string(board.Family),
+		"vendor":     board.Vendor,
+		"model":      board.Model,
+		"revision":   board.Revision,
+		"soc":        board.SoC,
+		"compatible": strings.Join(board.Compatible, ","),
+		"source":     board.Source,
+	}, nil
+}
+
+func (c *Config) Close(ctx context.Context) error {
+	c.logger.Infof("Shutting down %s", PrettyName)
+	return nil
+}
+
+func (c *Config) Ready(ctx context.Context, extra map[string]interface{}) (bool, error) {
+	return false, nil
+}
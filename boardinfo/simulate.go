@@ -0,0 +1,19 @@
+package boardinfo
+
+import (
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/linux"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+// simulate returns a Raspberry Pi 5 as it's identified from its device tree.
+func simulate(sim *utils.Simulation) map[string]interface{} {
+	return map[string]interface{}{
+		"family":     string(linux.BoardFamilyRaspberryPi),
+		"vendor":     "raspberrypi",
+		"model":      "Raspberry Pi 5 Model B Rev 1.0",
+		"revision":   "d04170",
+		"soc":        "bcm2712",
+		"compatible": "raspberrypi,5-model-b,brcm,bcm2712",
+		"source":     "device-tree",
+	}
+}
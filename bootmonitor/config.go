@@ -0,0 +1,17 @@
+package bootmonitor
+
+import (
+	"fmt"
+	"path/filepath"
+)
+
+type ComponentConfig struct {
+	StateDir string `json:"state_dir"` // Where the boot count is persisted, defaults to the module data directory
+}
+
+func (conf *ComponentConfig) Validate(path string) ([]string, error) {
+	if conf.StateDir != "" && !filepath.IsAbs(conf.StateDir) {
+		return nil, fmt.Errorf("state_dir must be an absolute path: %s", conf.StateDir)
+	}
+	return nil, nil
+}
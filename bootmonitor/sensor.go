@@ -0,0 +1,144 @@
+package bootmonitor
+
+import (
+	"context"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/shirou/gopsutil/v4/host"
+	"go.viam.com/rdk/components/sensor"
+	"go.viam.com/rdk/logging"
+	"go.viam.com/rdk/resource"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/linux"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+var (
+	Model       = resource.NewModel(utils.Namespace, "hwmonitor", "boot_monitor")
+	API         = sensor.API
+	PrettyName  = "Boot Monitor"
+	Description = "A sensor that reports uptime, boot count and whether the last shutdown was clean"
+	Version     = utils.Version
+)
+
+type Config struct {
+	resource.Named
+	mu             sync.RWMutex
+	logger         logging.Logger
+	cancelCtx      context.Context
+	cancelFunc     func()
+	statePath      string
+	state          *bootState
+	journalClean   bool
+	journalChecked bool
+}
+
+func init() {
+	resource.RegisterComponent(
+		API,
+		Model,
+		resource.Registration[sensor.Sensor, *ComponentConfig]{Constructor: utils.FilterReadings(NewSensor)})
+	utils.RegisterSimulator(Model, simulate)
+}
+
+func NewSensor(ctx context.Context, deps resource.Dependencies, conf resource.Config, logger logging.Logger) (sensor.Sensor, error) {
+	logger.Infof("Starting %s %s", PrettyName, Version)
+	cancelCtx, cancelFunc := context.WithCancel(context.Background())
+
+	b := Config{
+		Named:      conf.ResourceName().AsNamed(),
+		logger:     logger,
+		cancelCtx:  cancelCtx,
+		cancelFunc: cancelFunc,
+		mu:         sync.RWMutex{},
+	}
+
+	if err := b.Reconfigure(ctx, deps, conf); err != nil {
+		return nil, err
+	}
+	// The previous boot's journal doesn't change, it only needs to be checked once
+	b.journalClean, b.journalChecked = linux.PreviousBootShutdownClean(ctx)
+	return &b, nil
+}
+
+func (c *Config) Reconfigure(ctx context.Context, _ resource.Dependencies, conf resource.Config) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.logger.Debugf("Reconfiguring %s", PrettyName)
+
+	newConf, err := resource.NativeConfig[*ComponentConfig](conf)
+	if err != nil {
+		return err
+	}
+	stateDir := newConf.StateDir
+	if stateDir == "" {
+		stateDir = utils.ModuleDataDir()
+	}
+	c.statePath = filepath.Join(stateDir, stateFileName)
+
+	bootID, err := linux.ReadBootID(ctx)
+	if err != nil {
+		return err
+	}
+	state, err := loadBootState(c.statePath)
+	if err != nil {
+		// A corrupt state file shouldn't keep the sensor from starting, the boot count starts over
+		c.logger.Warnf("Failed to load boot state from %s, starting over: %v", c.statePath, err)
+		state = &bootState{}
+	}
+	state.recordBoot(bootID)
+	if err := state.save(c.statePath); err != nil {
+		c.logger.Warnf("Failed to save boot state to %s: %v", c.statePath, err)
+	}
+	c.state = state
+
+	// In case the module has changed name
+	c.Named = conf.ResourceName().AsNamed()
+
+	return nil
+}
+
+func (c *Config) Readings(ctx context.Context, extra map[string]interface{}) (map[string]interface{}, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	uptime, err := host.UptimeWithContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	bootTime, err := host.BootTimeWithContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ret := make(map[string]interface{})
+	ret["uptime_seconds"] = uptime
+	ret["boot_time"] = time.Unix(int64(bootTime), 0).Format(time.RFC3339)
+	ret["boot_id"] = c.state.BootID
+	ret["boot_count"] = c.state.BootCount
+	// The journal knows about shutdowns while the module wasn't running, so it is preferred over the sentinel
+	if c.journalChecked {
+		ret["last_shutdown_clean"] = c.journalClean
+		ret["last_shutdown_source"] = "journal"
+	} else if c.state.PreviousCleanShutdown != nil {
+		ret["last_shutdown_clean"] = *c.state.PreviousCleanShutdown
+		ret["last_shutdown_source"] = "sentinel"
+	}
+	return ret, nil
+}
+
+func (c *Config) Close(ctx context.Context) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.logger.Infof("Shutting down %s", PrettyName)
+	c.state.CleanShutdown = true
+	if err := c.state.save(c.statePath); err != nil {
+		c.logger.Warnf("Failed to save boot state to %s: %v", c.statePath, err)
+	}
+	return nil
+}
+
+func (c *Config) Ready(ctx context.Context, extra map[string]interface{}) (bool, error) {
+	return false, nil
+}
@@ -0,0 +1,20 @@
+package bootmonitor
+
+import (
+	"time"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+// simulate returns a board that booted a day before the simulation started and last shut down cleanly.
+func simulate(sim *utils.Simulation) map[string]interface{} {
+	uptime := sim.Counter(86400, 1, 1, 1)
+	return map[string]interface{}{
+		"uptime_seconds":       uptime,
+		"boot_time":            time.Now().Add(-time.Duration(uptime) * time.Second).Format(time.RFC3339),
+		"boot_id":              "6e0f3a52-8d1b-4c7e-9a24-5b7c1d8e2f90",
+		"boot_count":           42,
+		"last_shutdown_clean":  true,
+		"last_shutdown_source": "journal",
+	}
+}
@@ -0,0 +1,64 @@
+package bootmonitor
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+)
+
+const stateFileName = "boot_state.json"
+
+// bootState is persisted across reboots. CleanShutdown doubles as a sentinel: it is cleared while the module
+// runs and set when the module is closed, so a crash or power loss leaves it false.
+type bootState struct {
+	BootID                string `json:"boot_id"`
+	BootCount             int    `json:"boot_count"`
+	CleanShutdown         bool   `json:"clean_shutdown"`
+	PreviousCleanShutdown *bool  `json:"previous_clean_shutdown,omitempty"`
+}
+
+func loadBootState(path string) (*bootState, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return &bootState{}, nil
+		}
+		return nil, err
+	}
+	state := &bootState{}
+	if err := json.Unmarshal(data, state); err != nil {
+		return nil, err
+	}
+	return state, nil
+}
+
+func (s *bootState) save(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	data, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+	// Write and rename so a power cut while saving can't leave a truncated file behind
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// recordBoot updates the state for the current boot. A new boot id increments the boot count and keeps the
+// shutdown state of the previous boot, a module restart within the same boot only clears the sentinel.
+func (s *bootState) recordBoot(bootID string) {
+	if s.BootID != bootID {
+		if s.BootID != "" {
+			clean := s.CleanShutdown
+			s.PreviousCleanShutdown = &clean
+		}
+		s.BootID = bootID
+		s.BootCount++
+	}
+	s.CleanShutdown = false
+}
@@ -0,0 +1,46 @@
+package bootmonitor
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBootState(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state", stateFileName)
+	state, err := loadBootState(path)
+	require.NoError(t, err)
+
+	// First boot the module sees, there's nothing known about the previous shutdown
+	state.recordBoot("boot-1")
+	assert.Equal(t, 1, state.BootCount)
+	assert.Nil(t, state.PreviousCleanShutdown)
+	require.NoError(t, state.save(path))
+
+	// Module restart within the same boot
+	state, err = loadBootState(path)
+	require.NoError(t, err)
+	state.recordBoot("boot-1")
+	assert.Equal(t, 1, state.BootCount)
+
+	// Clean shutdown followed by a reboot
+	state.CleanShutdown = true
+	require.NoError(t, state.save(path))
+	state, err = loadBootState(path)
+	require.NoError(t, err)
+	state.recordBoot("boot-2")
+	assert.Equal(t, 2, state.BootCount)
+	require.NotNil(t, state.PreviousCleanShutdown)
+	assert.True(t, *state.PreviousCleanShutdown)
+	assert.False(t, state.CleanShutdown)
+	require.NoError(t, state.save(path))
+
+	// Power loss, the sentinel was never set
+	state, err = loadBootState(path)
+	require.NoError(t, err)
+	state.recordBoot("boot-3")
+	assert.Equal(t, 3, state.BootCount)
+	assert.False(t, *state.PreviousCleanShutdown)
+}
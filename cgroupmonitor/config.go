@@ -0,0 +1,27 @@
+package cgroupmonitor
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+type ComponentConfig struct {
+	Cgroups []string `json:"cgroups"` // cgroup v2 paths, absolute or relative to /sys/fs/cgroup, e.g. system.slice/viam-agent.service
+}
+
+func (conf *ComponentConfig) Validate(path string) ([]string, error) {
+	if len(conf.Cgroups) == 0 {
+		return nil, errors.New("at least one cgroup is required")
+	}
+	for _, cgroup := range conf.Cgroups {
+		if strings.Trim(cgroup, "/") == "" {
+			return nil, errors.New("cgroups can't be empty or the root cgroup")
+		}
+		if slices.Contains(strings.Split(cgroup, "/"), "..") {
+			return nil, fmt.Errorf("cgroup %s can't contain ..", cgroup)
+		}
+	}
+	return nil, nil
+}
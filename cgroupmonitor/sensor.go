@@ -0,0 +1,181 @@
+package cgroupmonitor
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"go.viam.com/rdk/components/sensor"
+	"go.viam.com/rdk/logging"
+	"go.viam.com/rdk/resource"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/linux"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+var (
+	Model       = resource.NewModel(utils.Namespace, "hwmonitor", "cgroup_monitor")
+	API         = sensor.API
+	PrettyName  = "Cgroup Monitor"
+	Description = "A sensor that reports the CPU, memory and IO of cgroups, e.g. a systemd service and every process it started"
+	Version     = utils.Version
+)
+
+// ioCounters are the io.stat counters reported summed over the devices.
+var ioCounters = []string{"rbytes", "wbytes", "rios", "wios", "dbytes", "dios"}
+
+// cpuSample is a cgroup's CPU usage and when it was read.
+type cpuSample struct {
+	usageUsec uint64
+	at        time.Time
+}
+
+type Config struct {
+	resource.Named
+	mu      sync.Mutex
+	logger  logging.Logger
+	cgroups []string
+	// prevCPU is the CPU usage of each cgroup at the previous reading
+	prevCPU map[string]cpuSample
+}
+
+func init() {
+	resource.RegisterComponent(
+		API,
+		Model,
+		resource.Registration[sensor.Sensor, *ComponentConfig]{Constructor: utils.FilterReadings(NewSensor)})
+	utils.RegisterSimulator(Model, simulate)
+}
+
+func NewSensor(ctx context.Context, deps resource.Dependencies, conf resource.Config, logger logging.Logger) (sensor.Sensor, error) {
+	logger.Infof("Starting %s %s", PrettyName, Version)
+
+	b := Config{
+		Named:   conf.ResourceName().AsNamed(),
+		logger:  logger,
+		prevCPU: make(map[string]cpuSample),
+	}
+
+	if err := b.Reconfigure(ctx, deps, conf); err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
+
+func (c *Config) Reconfigure(ctx context.Context, _ resource.Dependencies, rawConf resource.Config) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.logger.Debugf("Reconfiguring %s", PrettyName)
+
+	conf, err := resource.NativeConfig[*ComponentConfig](rawConf)
+	if err != nil {
+		return err
+	}
+	c.cgroups = conf.Cgroups
+	for cgroup := range c.prevCPU {
+		if !slices.Contains(c.cgroups, cgroup) {
+			delete(c.prevCPU, cgroup)
+		}
+	}
+
+	// In case the module has changed name
+	c.Named = rawConf.ResourceName().AsNamed()
+
+	return nil
+}
+
+func (c *Config) Readings(ctx context.Context, extra map[string]interface{}) (map[string]interface{}, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	ret := make(map[string]interface{})
+	errs := make(utils.ReadingErrors)
+	now := time.Now()
+	for _, cgroup := range c.cgroups {
+		name := linux.CgroupName(cgroup)
+		stats, err := linux.ReadCgroupStats(ctx, cgroup)
+		if err != nil {
+			errs.Add(name, err)
+			delete(c.prevCPU, cgroup)
+			continue
+		}
+		addCPUReadings(ret, name, stats.CPU, c.prevCPU[cgroup], now)
+		if usage, ok := stats.CPU["usage_usec"]; ok {
+			c.prevCPU[cgroup] = cpuSample{usageUsec: usage, at: now}
+		}
+		addMemoryReadings(ret, name, stats)
+		addIOReadings(ret, name, stats.IO)
+		if stats.PidsCurrent != nil {
+			ret[name+"_pids_current"] = *stats.PidsCurrent
+		}
+	}
+	return errs.Readings(ret)
+}
+
+// addCPUReadings adds the cgroup's cpu.stat and, once there's a previous reading to compare with, its CPU usage since
+// then as a percentage of one core.
+func addCPUReadings(ret map[string]interface{}, name string, cpu map[string]uint64, prev cpuSample, now time.Time) {
+	for _, key := range []string{"usage_usec", "user_usec", "system_usec", "nr_periods", "nr_throttled", "throttled_usec"} {
+		if value, ok := cpu[key]; ok {
+			ret[name+"_cpu_"+key] = value
+		}
+	}
+	usage, ok := cpu["usage_usec"]
+	elapsed := now.Sub(prev.at)
+	// A cgroup that was removed and created again starts counting over
+	if !ok || prev.at.IsZero() || elapsed <= 0 || usage < prev.usageUsec {
+		return
+	}
+	used := time.Duration(usage-prev.usageUsec) * time.Microsecond
+	ret[name+"_cpu_percent"] = utils.RoundValue(float64(used)/float64(elapsed)*100, 2)
+}
+
+// addMemoryReadings adds the cgroup's memory use, its limit and memory.events. The percentage is of the limit set on
+// the cgroup itself, not on its parents.
+func addMemoryReadings(ret map[string]interface{}, name string, stats linux.CgroupStats) {
+	if stats.MemoryCurrent != nil {
+		ret[name+"_memory_current_bytes"] = *stats.MemoryCurrent
+	}
+	if stats.MemoryPeak != nil {
+		ret[name+"_memory_peak_bytes"] = *stats.MemoryPeak
+	}
+	if stats.MemoryMax != nil {
+		ret[name+"_memory_max_bytes"] = *stats.MemoryMax
+		if stats.MemoryCurrent != nil && *stats.MemoryMax > 0 {
+			ret[name+"_memory_percent"] = utils.RoundValue(float64(*stats.MemoryCurrent)/float64(*stats.MemoryMax)*100, 2)
+		}
+	}
+	for key, value := range stats.MemoryEvents {
+		ret[name+"_memory_events_"+key] = value
+	}
+}
+
+// addIOReadings adds the cgroup's io.stat counters summed over the devices, and the bytes read and written of each
+// device.
+func addIOReadings(ret map[string]interface{}, name string, io map[string]map[string]uint64) {
+	if io == nil {
+		return
+	}
+	totals := make(map[string]uint64, len(ioCounters))
+	for device, counters := range io {
+		for _, key := range ioCounters {
+			totals[key] += counters[key]
+		}
+		device = strings.ReplaceAll(device, ":", "_")
+		ret[name+"_io_"+device+"_rbytes"] = counters["rbytes"]
+		ret[name+"_io_"+device+"_wbytes"] = counters["wbytes"]
+	}
+	for key, value := range totals {
+		ret[name+"_io_"+key] = value
+	}
+}
+
+func (c *Config) Close(ctx context.Context) error {
+	c.logger.Infof("Shutting down %s", PrettyName)
+	return nil
+}
+
+func (c *Config) Ready(ctx context.Context, extra map[string]interface{}) (bool, error) {
+	return false, nil
+}
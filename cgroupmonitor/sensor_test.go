@@ -0,0 +1,55 @@
+package cgroupmonitor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/linux"
+)
+
+func TestAddCPUReadings(t *testing.T) {
+	now := time.Now()
+	cpu := map[string]uint64{"usage_usec": 3_000_000, "user_usec": 2_000_000, "system_usec": 1_000_000}
+
+	// The first reading has nothing to compare with
+	ret := make(map[string]interface{})
+	addCPUReadings(ret, "app", cpu, cpuSample{}, now)
+	assert.Equal(t, map[string]interface{}{"app_cpu_usage_usec": uint64(3_000_000), "app_cpu_user_usec": uint64(2_000_000), "app_cpu_system_usec": uint64(1_000_000)}, ret)
+
+	// 1.5 cores over 2 seconds
+	addCPUReadings(ret, "app", cpu, cpuSample{usageUsec: 0, at: now.Add(-2 * time.Second)}, now)
+	assert.Equal(t, 150.0, ret["app_cpu_percent"])
+
+	// The cgroup was created again
+	ret = make(map[string]interface{})
+	addCPUReadings(ret, "app", cpu, cpuSample{usageUsec: 9_000_000, at: now.Add(-2 * time.Second)}, now)
+	assert.NotContains(t, ret, "app_cpu_percent")
+}
+
+func TestAddMemoryAndIOReadings(t *testing.T) {
+	current, limit := uint64(256<<20), uint64(1<<30)
+	ret := make(map[string]interface{})
+	addMemoryReadings(ret, "app", linux.CgroupStats{MemoryCurrent: &current, MemoryMax: &limit, MemoryEvents: map[string]uint64{"oom_kill": 2}})
+	addIOReadings(ret, "app", map[string]map[string]uint64{
+		"mmcblk0": {"rbytes": 100, "wbytes": 200, "rios": 1, "wios": 2},
+		"8:0":     {"rbytes": 10, "wbytes": 20, "rios": 1, "wios": 1},
+	})
+	assert.Equal(t, map[string]interface{}{
+		"app_memory_current_bytes":   current,
+		"app_memory_max_bytes":       limit,
+		"app_memory_percent":         25.0,
+		"app_memory_events_oom_kill": uint64(2),
+		"app_io_mmcblk0_rbytes":      uint64(100),
+		"app_io_mmcblk0_wbytes":      uint64(200),
+		"app_io_8_0_rbytes":          uint64(10),
+		"app_io_8_0_wbytes":          uint64(20),
+		"app_io_rbytes":              uint64(110),
+		"app_io_wbytes":              uint64(220),
+		"app_io_rios":                uint64(2),
+		"app_io_wios":                uint64(3),
+		"app_io_dbytes":              uint64(0),
+		"app_io_dios":                uint64(0),
+	}, ret)
+}
@@ -0,0 +1,40 @@
+package cgroupmonitor
+
+import (
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/linux"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+// simulate returns every configured cgroup using CPU and memory that follow the board's load, on a board with an
+// mmcblk0 SD card.
+func simulate(sim *utils.Simulation) map[string]interface{} {
+	ret := make(map[string]interface{})
+	conf, ok := sim.Config().(*ComponentConfig)
+	if !ok {
+		return ret
+	}
+	for _, cgroup := range conf.Cgroups {
+		name := linux.CgroupName(cgroup)
+		ret[name+"_cpu_usage_usec"] = sim.Counter(3.2e9, 4e4, 6e5, 4.5e5)
+		ret[name+"_cpu_user_usec"] = sim.Counter(2.6e9, 3e4, 5e5, 3.7e5)
+		ret[name+"_cpu_system_usec"] = sim.Counter(6e8, 1e4, 1e5, 8e4)
+		ret[name+"_cpu_percent"] = sim.Percent(4, 60, 45, 2)
+		current := uint64(sim.Value(2.1e8, 3.6e8, 3.5e8, 4e6))
+		ret[name+"_memory_current_bytes"] = current
+		ret[name+"_memory_peak_bytes"] = uint64(3.8e8)
+		for _, key := range []string{"low", "high", "max", "oom", "oom_kill", "oom_group_kill"} {
+			ret[name+"_memory_events_"+key] = uint64(0)
+		}
+		rbytes, wbytes := sim.Counter(1.2e8, 2e3, 2e4, 1.5e4), sim.Counter(4.6e8, 3e4, 2e5, 1.8e5)
+		ret[name+"_io_rbytes"] = rbytes
+		ret[name+"_io_wbytes"] = wbytes
+		ret[name+"_io_rios"] = sim.Counter(5200, 0.5, 4, 3)
+		ret[name+"_io_wios"] = sim.Counter(21000, 6, 40, 30)
+		ret[name+"_io_dbytes"] = uint64(0)
+		ret[name+"_io_dios"] = uint64(0)
+		ret[name+"_io_mmcblk0_rbytes"] = rbytes
+		ret[name+"_io_mmcblk0_wbytes"] = wbytes
+		ret[name+"_pids_current"] = uint64(sim.Value(18, 26, 24, 1))
+	}
+	return ret
+}
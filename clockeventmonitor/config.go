@@ -0,0 +1,16 @@
+package clockeventmonitor
+
+import "errors"
+
+type ComponentConfig struct {
+	SleepTimeMs          int `json:"sleep_time_ms"`
+	SuspendThresholdMs   int `json:"suspend_threshold_ms"`    // Defaults to 2000
+	ClockJumpThresholdMs int `json:"clock_jump_threshold_ms"` // Defaults to 1000
+}
+
+func (conf *ComponentConfig) Validate(path string) ([]string, error) {
+	if conf.SuspendThresholdMs < 0 || conf.ClockJumpThresholdMs < 0 {
+		return nil, errors.New("thresholds must not be negative")
+	}
+	return nil, nil
+}
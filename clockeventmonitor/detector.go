@@ -0,0 +1,58 @@
+package clockeventmonitor
+
+import (
+	"time"
+)
+
+const (
+	eventSuspend   = "suspend"
+	eventClockJump = "clock_jump"
+)
+
+// clockSample is a reading of the three clocks the detector compares. The monotonic clock stops while the system is
+// suspended, the boot clock keeps running, and the wall clock can additionally be stepped by NTP or an RTC correction.
+type clockSample struct {
+	monotonic time.Duration
+	boot      time.Duration
+	wall      time.Time
+}
+
+type clockEvent struct {
+	Type string
+	// Time is when the event was detected, for a suspend this is the time the system resumed
+	Time time.Time
+	// Duration is how long the system was suspended, or how far the wall clock jumped, negative when it went backwards
+	Duration time.Duration
+}
+
+type clockDetector struct {
+	suspendThreshold time.Duration
+	jumpThreshold    time.Duration
+	last             *clockSample
+}
+
+func newClockDetector(suspendThreshold, jumpThreshold time.Duration) *clockDetector {
+	return &clockDetector{suspendThreshold: suspendThreshold, jumpThreshold: jumpThreshold}
+}
+
+// add compares the sample against the previous one and returns the events that happened in between.
+func (d *clockDetector) add(sample clockSample) []clockEvent {
+	last := d.last
+	d.last = &sample
+	if last == nil {
+		return nil
+	}
+	var events []clockEvent
+	monotonic := sample.monotonic - last.monotonic
+	boot := sample.boot - last.boot
+	wall := sample.wall.Sub(last.wall)
+
+	if suspended := boot - monotonic; suspended > d.suspendThreshold {
+		events = append(events, clockEvent{Type: eventSuspend, Time: sample.wall, Duration: suspended})
+	}
+	// The wall clock keeps running during a suspend, so compare it against the boot clock
+	if jump := wall - boot; jump > d.jumpThreshold || jump < -d.jumpThreshold {
+		events = append(events, clockEvent{Type: eventClockJump, Time: sample.wall, Duration: jump})
+	}
+	return events
+}
@@ -0,0 +1,41 @@
+package clockeventmonitor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClockDetector(t *testing.T) {
+	start := time.Date(2024, time.May, 1, 12, 0, 0, 0, time.UTC)
+	detector := newClockDetector(2*time.Second, time.Second)
+	sample := func(monotonic, boot, wall time.Duration) clockSample {
+		return clockSample{monotonic: monotonic, boot: boot, wall: start.Add(wall)}
+	}
+
+	assert.Empty(t, detector.add(sample(0, 100*time.Second, 0)))
+
+	// Normal tick with a little jitter between the clocks
+	assert.Empty(t, detector.add(sample(time.Second, 101*time.Second+10*time.Millisecond, time.Second)))
+
+	// Suspended for a minute, the monotonic clock only advanced by one tick
+	events := detector.add(sample(2*time.Second, 162*time.Second, 62*time.Second))
+	require.Len(t, events, 1)
+	assert.Equal(t, eventSuspend, events[0].Type)
+	assert.InDelta(t, float64(60*time.Second), float64(events[0].Duration), float64(20*time.Millisecond))
+	assert.Equal(t, start.Add(62*time.Second), events[0].Time)
+
+	// NTP stepped the clock back by 5 seconds
+	events = detector.add(sample(3*time.Second, 163*time.Second, 58*time.Second))
+	require.Len(t, events, 1)
+	assert.Equal(t, eventClockJump, events[0].Type)
+	assert.InDelta(t, float64(-5*time.Second), float64(events[0].Duration), float64(20*time.Millisecond))
+
+	// RTC correction forward by an hour
+	events = detector.add(sample(4*time.Second, 164*time.Second, time.Hour+59*time.Second))
+	require.Len(t, events, 1)
+	assert.Equal(t, eventClockJump, events[0].Type)
+	assert.Equal(t, time.Hour, events[0].Duration)
+}
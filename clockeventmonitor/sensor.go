@@ -0,0 +1,207 @@
+package clockeventmonitor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"go.viam.com/rdk/components/sensor"
+	"go.viam.com/rdk/logging"
+	"go.viam.com/rdk/resource"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/linux"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+var (
+	Model       = resource.NewModel(utils.Namespace, "hwmonitor", "clock_event_monitor")
+	API         = sensor.API
+	PrettyName  = "SBC Clock Event Monitor"
+	Description = "A sensor that detects suspend, resume and wall clock jumps"
+	Version     = utils.Version
+)
+
+const maxEvents = 100
+
+type Config struct {
+	resource.Named
+	configLock     sync.Mutex
+	readingsLock   sync.RWMutex
+	logger         logging.Logger
+	sleepTime      time.Duration
+	task           *utils.Task
+	events         utils.CappedCollection[clockEvent]
+	suspendCount   int
+	totalSuspended time.Duration
+	lastSuspend    *clockEvent
+	jumpCount      int
+	lastJump       *clockEvent
+	freshness      utils.Freshness
+}
+
+func init() {
+	resource.RegisterComponent(
+		API,
+		Model,
+		resource.Registration[sensor.Sensor, *ComponentConfig]{Constructor: utils.FilterReadings(NewSensor)})
+	utils.RegisterSimulator(Model, simulate)
+}
+
+func NewSensor(ctx context.Context, deps resource.Dependencies, conf resource.Config, logger logging.Logger) (sensor.Sensor, error) {
+	logger.Infof("Starting %s %s", PrettyName, Version)
+	b := Config{
+		Named:  conf.ResourceName().AsNamed(),
+		logger: logger,
+		events: utils.NewCappedCollection[clockEvent](maxEvents),
+	}
+
+	if err := b.Reconfigure(ctx, deps, conf); err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
+
+func (c *Config) Reconfigure(ctx context.Context, _ resource.Dependencies, rawConf resource.Config) error {
+	c.configLock.Lock()
+	defer c.configLock.Unlock()
+	c.logger.Debugf("Reconfiguring %s", PrettyName)
+
+	if c.task != nil {
+		c.logger.Debug("Stopping polling")
+		c.task.Stop()
+		c.logger.Debugf("Polling stopped")
+	}
+
+	conf, err := resource.NativeConfig[*ComponentConfig](rawConf)
+	if err != nil {
+		return err
+	}
+
+	// In case the component has changed name
+	c.Named = rawConf.ResourceName().AsNamed()
+	if conf.SleepTimeMs <= 0 {
+		// Default to 1000ms if no sleep time is provided
+		c.logger.Warnf("Invalid sleep time %d, defaulting to 1000ms", conf.SleepTimeMs)
+		conf.SleepTimeMs = 1000 // Default to 1 second
+	}
+	c.sleepTime = time.Duration(conf.SleepTimeMs * int(time.Millisecond))
+	if conf.SuspendThresholdMs == 0 {
+		conf.SuspendThresholdMs = 2000
+	}
+	if conf.ClockJumpThresholdMs == 0 {
+		conf.ClockJumpThresholdMs = 1000
+	}
+	detector := newClockDetector(time.Duration(conf.SuspendThresholdMs)*time.Millisecond, time.Duration(conf.ClockJumpThresholdMs)*time.Millisecond)
+
+	start := time.Now()
+	c.task = utils.Schedule(c.sleepTime, func(ctx context.Context) {
+		c.sampleClocks(ctx, detector, start)
+	})
+	return nil
+}
+
+// sampleClocks gives the detector a sample of the clocks, the scheduler calls it every interval.
+func (c *Config) sampleClocks(ctx context.Context, detector *clockDetector, start time.Time) {
+	boot, err := linux.ReadUptime(ctx)
+	if err != nil {
+		c.logger.Warnf("Failed to read uptime: %v", err)
+		c.readingsLock.Lock()
+		c.freshness.Failed(err)
+		c.readingsLock.Unlock()
+		return
+	}
+	now := time.Now()
+	// Sub uses the monotonic readings, Round(0) strips them so only the wall clock is compared later
+	sample := clockSample{monotonic: now.Sub(start), boot: boot, wall: now.Round(0)}
+	for _, event := range detector.add(sample) {
+		c.recordEvent(event)
+	}
+	c.readingsLock.Lock()
+	c.freshness.Succeeded(now)
+	c.readingsLock.Unlock()
+}
+
+func (c *Config) recordEvent(event clockEvent) {
+	c.readingsLock.Lock()
+	defer c.readingsLock.Unlock()
+	c.events.Push(event)
+	switch event.Type {
+	case eventSuspend:
+		c.logger.Infof("System resumed after being suspended for %v", event.Duration)
+		c.suspendCount++
+		c.totalSuspended += event.Duration
+		c.lastSuspend = &event
+	case eventClockJump:
+		c.logger.Warnf("Wall clock jumped by %v", event.Duration)
+		c.jumpCount++
+		c.lastJump = &event
+	}
+}
+
+func (c *Config) Readings(ctx context.Context, extra map[string]interface{}) (map[string]interface{}, error) {
+	c.readingsLock.RLock()
+	defer c.readingsLock.RUnlock()
+	ret := make(map[string]interface{})
+	ret["suspend_count"] = c.suspendCount
+	ret["total_suspended_sec"] = utils.RoundValue(c.totalSuspended.Seconds(), 2)
+	if c.lastSuspend != nil {
+		ret["last_suspend_time"] = c.lastSuspend.Time.Add(-c.lastSuspend.Duration).Format(time.RFC3339)
+		ret["last_resume_time"] = c.lastSuspend.Time.Format(time.RFC3339)
+		ret["last_suspend_duration_sec"] = utils.RoundValue(c.lastSuspend.Duration.Seconds(), 2)
+	}
+	ret["clock_jump_count"] = c.jumpCount
+	if c.lastJump != nil {
+		ret["last_clock_jump_time"] = c.lastJump.Time.Format(time.RFC3339)
+		ret["last_clock_jump_sec"] = utils.RoundValue(c.lastJump.Duration.Seconds(), 3)
+	}
+	return c.freshness.Stamp(ret, c.sleepTime, time.Now()), nil
+}
+
+func (c *Config) DoCommand(ctx context.Context, cmd map[string]interface{}) (map[string]interface{}, error) {
+	command, ok := cmd["command"].(string)
+	if !ok {
+		return nil, errors.New("missing or invalid 'command' field")
+	}
+
+	switch command {
+	case "get_events":
+		return c.handleGetEvents()
+	default:
+		return nil, fmt.Errorf("unknown command: %s", command)
+	}
+}
+
+func (c *Config) handleGetEvents() (map[string]interface{}, error) {
+	events := c.events.Items()
+	// The collection overwrites the oldest event once it is full, so it isn't in order
+	sort.Slice(events, func(i, j int) bool {
+		return events[i].Time.Before(events[j].Time)
+	})
+	ret := make([]interface{}, 0, len(events))
+	for _, event := range events {
+		ret = append(ret, map[string]interface{}{
+			"type":         event.Type,
+			"time":         event.Time.Format(time.RFC3339),
+			"duration_sec": utils.RoundValue(event.Duration.Seconds(), 3),
+		})
+	}
+	return map[string]interface{}{"events": ret}, nil
+}
+
+func (c *Config) Close(ctx context.Context) error {
+	c.configLock.Lock()
+	defer c.configLock.Unlock()
+	c.logger.Infof("Shutting down %s", PrettyName)
+	if c.task != nil {
+		c.task.Stop()
+	}
+	c.logger.Infof("%s Shutdown complete", PrettyName)
+	return nil
+}
+
+func (c *Config) Ready(ctx context.Context, extra map[string]interface{}) (bool, error) {
+	return false, nil
+}
@@ -0,0 +1,12 @@
+package clockeventmonitor
+
+import "github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+
+// simulate returns a board that hasn't been suspended and whose clock hasn't jumped, as a running board's usually hasn't.
+func simulate(sim *utils.Simulation) map[string]interface{} {
+	return map[string]interface{}{
+		"suspend_count":       0,
+		"total_suspended_sec": 0.0,
+		"clock_jump_count":    0,
+	}
+}
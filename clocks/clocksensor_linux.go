@@ -3,25 +3,34 @@ package clocks
 import (
 	"context"
 
-	"github.com/rinzlerlabs/sbcidentify"
 	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/linux"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/linux/allwinner"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/linux/imx"
 	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/linux/jetson"
 	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/linux/raspberrypi"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/linux/rockchip"
 	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/sensors"
 	"go.viam.com/rdk/logging"
 )
 
+// backend is the board family whose clocks are read.
+func backend() string {
+	return string(linux.Board().Family)
+}
+
 func getClockSensors(ctx context.Context, logger logging.Logger) ([]sensors.ClockSensor, error) {
-	if sbcidentify.IsRaspberryPi() {
+	if linux.IsFamily(linux.BoardFamilyRaspberryPi) {
 		return raspberrypi.GetClockSensors(ctx, logger)
-	} else if sbcidentify.IsNvidia() {
+	} else if linux.IsFamily(linux.BoardFamilyJetson) {
 		return jetson.GetClockSensors(ctx, logger)
+	} else if linux.IsFamily(linux.BoardFamilyRockchip) {
+		return rockchip.GetClockSensors(ctx, logger)
+	} else if linux.IsFamily(linux.BoardFamilyAllwinner) {
+		return allwinner.GetClockSensors(ctx, logger)
+	} else if linux.IsFamily(linux.BoardFamilyIMX) {
+		return imx.GetClockSensors(ctx, logger)
 	}
-	boardtype, err := sbcidentify.GetBoardType()
-	if err != nil {
-		logger.Warnf("Failed to get board type: %v", err)
-	}
-	logger.Debugf("No SBC clock sensors found for %s, assuming genericlinux", boardtype)
+	logger.Debugf("No SBC clock sensors found for %s, assuming genericlinux", linux.Board().Model)
 
 	return linux.GetClockSensors(ctx, logger)
 }
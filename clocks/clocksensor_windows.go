@@ -8,6 +8,11 @@ import (
 	"go.viam.com/rdk/logging"
 )
 
+// backend is where the clocks are read from.
+func backend() string {
+	return "windows"
+}
+
 func getClockSensors(ctx context.Context, logger logging.Logger) ([]sensors.ClockSensor, error) {
 	return windows.GetClockSensors(ctx, logger)
 }
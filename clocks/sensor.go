@@ -2,6 +2,8 @@ package clocks
 
 import (
 	"context"
+	"fmt"
+	"strings"
 	"sync"
 
 	"go.viam.com/rdk/components/sensor"
@@ -27,13 +29,15 @@ type Config struct {
 	cancelCtx  context.Context
 	cancelFunc func()
 	sensors    []sensors.ClockSensor
+	units      utils.Units
 }
 
 func init() {
 	resource.RegisterComponent(
 		API,
 		Model,
-		resource.Registration[sensor.Sensor, *ComponentConfig]{Constructor: NewSensor})
+		resource.Registration[sensor.Sensor, *ComponentConfig]{Constructor: utils.FilterReadings(NewSensor)})
+	utils.RegisterSimulator(Model, simulate)
 }
 
 func NewSensor(ctx context.Context, deps resource.Dependencies, conf resource.Config, logger logging.Logger) (sensor.Sensor, error) {
@@ -58,6 +62,12 @@ func (c *Config) Reconfigure(ctx context.Context, _ resource.Dependencies, conf
 	c.mu.Lock()
 	defer c.mu.Unlock()
 	c.logger.Debugf("Reconfiguring %s", PrettyName)
+	newConf, err := resource.NativeConfig[*ComponentConfig](conf)
+	if err != nil {
+		return err
+	}
+	c.units = newConf.Units
+
 	if c.cancelFunc != nil {
 		c.cancelFunc()
 	}
@@ -84,16 +94,54 @@ func (c *Config) Readings(ctx context.Context, extra map[string]interface{}) (ma
 	c.mu.RLock()
 	defer c.mu.RUnlock()
 	readings := make(map[string]interface{})
+	errs := make(utils.ReadingErrors)
 	for _, s := range c.sensors {
-		readings, err := s.GetReadingMap()
+		clockReadings, err := s.GetReadingMap(ctx)
 		if err != nil {
-			return nil, err
+			errs.Add(s.Name(), err)
+			continue
 		}
-		for k, v := range readings {
+		for k, v := range clockReadings {
 			readings[k] = v
 		}
 	}
-	return readings, nil
+	// Fail rather than report only the unit when every clock failed
+	if len(readings) == 0 && len(errs) > 0 {
+		return errs.Readings(readings)
+	}
+	return errs.Readings(convertReadings(c.units, readings))
+}
+
+// convertReadings converts the frequencies from Hz to the configured unit.
+func convertReadings(units utils.Units, readings map[string]interface{}) map[string]interface{} {
+	for key, value := range readings {
+		if isFrequencyReading(key) {
+			readings[key] = units.Convert(utils.Frequency, value)
+		}
+	}
+	units.AddUnit(readings, utils.Frequency)
+	return readings
+}
+
+// Capabilities reports the backend the clocks are read with.
+func (c *Config) Capabilities(ctx context.Context) utils.Capabilities {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	caps := utils.Capabilities{Backend: backend(), Unavailable: make(map[string]string)}
+	if len(c.sensors) == 0 {
+		caps.Unavailable["clocks"] = fmt.Sprintf("no clocks found by the %s backend", caps.Backend)
+	}
+	return caps
+}
+
+// Some clocks also report e.g. their PVTM value, OPP count or voltage, those aren't converted to the configured unit
+func isFrequencyReading(key string) bool {
+	for _, suffix := range []string{"_pvtm", "_opp_count", "_voltage"} {
+		if strings.HasSuffix(key, suffix) {
+			return false
+		}
+	}
+	return true
 }
 
 func (c *Config) Close(ctx context.Context) error {
@@ -0,0 +1,20 @@
+package clocks
+
+import "github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+
+// simulate returns the clocks of a Raspberry Pi 5 in Hz. The ARM clock idles at 1.5GHz, runs at 2.4GHz under load and
+// is held back when the board throttles.
+func simulate(sim *utils.Simulation) map[string]interface{} {
+	clock := func(idle, loaded, throttling float64) int64 {
+		return int64(sim.Level(idle, loaded, throttling))
+	}
+	return map[string]interface{}{
+		"arm":  clock(1500000000, 2400000000, 1600000000),
+		"core": clock(500000000, 910000000, 750000000),
+		"emmc": clock(200000000, 200000000, 200000000),
+		"hdmi": clock(648000000, 648000000, 648000000),
+		"isp":  clock(500000000, 910000000, 750000000),
+		"uart": clock(44000000, 44000000, 44000000),
+		"v3d":  clock(500000000, 960000000, 750000000),
+	}
+}
@@ -0,0 +1,41 @@
+package containermonitor
+
+import (
+	"context"
+	"errors"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+type ComponentConfig struct {
+	Socket    string   `json:"socket,omitempty"`     // Docker or Podman API socket, defaults to the first of the usual sockets that exists
+	Names     []string `json:"names,omitempty"`      // Patterns of the container names to report, e.g. nav-*, defaults to every container
+	All       bool     `json:"all,omitempty"`        // Report stopped containers too
+	TimeoutMs int      `json:"timeout_ms,omitempty"` // Defaults to 5000
+}
+
+func (conf *ComponentConfig) Validate(path string) ([]string, error) {
+	for _, name := range conf.Names {
+		if name == "" {
+			return nil, errors.New("names can't be empty")
+		}
+	}
+	if conf.TimeoutMs < 0 {
+		return nil, errors.New("timeout_ms must not be negative")
+	}
+	return nil, nil
+}
+
+// Permissions implements utils.PermissionReporter, the Docker socket needs the docker group.
+func (conf *ComponentConfig) Permissions(ctx context.Context) utils.Permissions {
+	socket := conf.Socket
+	if socket == "" {
+		socket = defaultSocket()
+	}
+	perms := utils.Permissions{Groups: []string{"docker"}}
+	if socket != "" {
+		perms.Readable = []string{socket}
+		perms.Writable = []string{socket}
+	}
+	return perms
+}
@@ -0,0 +1,184 @@
+package containermonitor
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// apiVersion is the Docker API version requested, 1.41 is Docker 20.10 and is served by Podman's compatible API
+const apiVersion = "v1.41"
+
+// maxErrorBody is how much of an error response is read for its message
+const maxErrorBody = 4096
+
+// defaultSockets are the Docker and Podman API sockets, in the order they're tried.
+var defaultSockets = []string{"/var/run/docker.sock", "/run/podman/podman.sock"}
+
+// defaultSocket returns the first of the Docker and Podman API sockets that exists, or an empty string. Rootless
+// Podman's is in the user's runtime directory.
+func defaultSocket() string {
+	sockets := defaultSockets
+	if dir := os.Getenv("XDG_RUNTIME_DIR"); dir != "" {
+		sockets = append(sockets[:len(sockets):len(sockets)], filepath.Join(dir, "podman", "podman.sock"))
+	}
+	for _, socket := range sockets {
+		if info, err := os.Stat(socket); err == nil && info.Mode()&os.ModeSocket != 0 {
+			return socket
+		}
+	}
+	return ""
+}
+
+// containerSummary is a container in the response to /containers/json.
+type containerSummary struct {
+	ID    string   `json:"Id"`
+	Names []string `json:"Names"`
+	State string   `json:"State"`
+}
+
+// name returns the container's name without the leading slash the API reports it with.
+func (c containerSummary) name() string {
+	if len(c.Names) == 0 {
+		return c.ID[:min(len(c.ID), 12)]
+	}
+	return strings.TrimPrefix(c.Names[0], "/")
+}
+
+// containerInspect is the part of the response to /containers/<id>/json that's reported.
+type containerInspect struct {
+	RestartCount int `json:"RestartCount"`
+	State        struct {
+		Status    string `json:"Status"`
+		OOMKilled bool   `json:"OOMKilled"`
+		// Health is only set for containers with a health check
+		Health *containerHealth `json:"Health"`
+	} `json:"State"`
+}
+
+// containerHealth is the result of a container's health check, its status is starting, healthy or unhealthy.
+type containerHealth struct {
+	Status        string `json:"Status"`
+	FailingStreak int    `json:"FailingStreak"`
+}
+
+// containerStats is the part of the response to /containers/<id>/stats that's reported.
+type containerStats struct {
+	CPUStats    cpuStats `json:"cpu_stats"`
+	MemoryStats struct {
+		Usage uint64            `json:"usage"`
+		Limit uint64            `json:"limit"`
+		Stats map[string]uint64 `json:"stats"`
+	} `json:"memory_stats"`
+}
+
+type cpuStats struct {
+	CPUUsage struct {
+		TotalUsage uint64 `json:"total_usage"`
+	} `json:"cpu_usage"`
+	SystemUsage uint64 `json:"system_cpu_usage"`
+	OnlineCPUs  int    `json:"online_cpus"`
+}
+
+// memoryUsed returns the memory the container uses without the page cache that can be reclaimed, as docker stats
+// reports it. cgroup v2 reports the cache as inactive_file, v1 as total_inactive_file.
+func (s containerStats) memoryUsed() uint64 {
+	cache, ok := s.MemoryStats.Stats["inactive_file"]
+	if !ok {
+		cache = s.MemoryStats.Stats["total_inactive_file"]
+	}
+	if cache > s.MemoryStats.Usage {
+		return s.MemoryStats.Usage
+	}
+	return s.MemoryStats.Usage - cache
+}
+
+// cpuPercent returns the CPU the container used between prev and cur as a percentage of one core, as docker stats
+// reports it. It's false when there's nothing to compare, e.g. prev is the first sample or the container restarted.
+func cpuPercent(prev, cur cpuStats) (float64, bool) {
+	if prev.SystemUsage == 0 || cur.SystemUsage <= prev.SystemUsage || cur.CPUUsage.TotalUsage < prev.CPUUsage.TotalUsage {
+		return 0, false
+	}
+	cpus := max(cur.OnlineCPUs, 1)
+	used := float64(cur.CPUUsage.TotalUsage - prev.CPUUsage.TotalUsage)
+	system := float64(cur.SystemUsage - prev.SystemUsage)
+	return used / system * float64(cpus) * 100, true
+}
+
+// apiClient talks to the Docker API, or Podman's Docker compatible API, over its Unix socket.
+type apiClient struct {
+	socket string
+	http   *http.Client
+}
+
+func newAPIClient(socket string, timeout time.Duration) *apiClient {
+	transport := &http.Transport{
+		DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+			var dialer net.Dialer
+			return dialer.DialContext(ctx, "unix", socket)
+		},
+	}
+	return &apiClient{socket: socket, http: &http.Client{Transport: transport, Timeout: timeout}}
+}
+
+// get decodes the JSON response to a GET of path into v.
+func (c *apiClient) get(ctx context.Context, path string, v interface{}) error {
+	// The host is ignored, the connection is always to the socket
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "http://docker/"+apiVersion+path, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+		var apiErr struct {
+			Message string `json:"message"`
+		}
+		if json.Unmarshal(body, &apiErr) == nil && apiErr.Message != "" {
+			return fmt.Errorf("%s returned %s: %s", path, resp.Status, apiErr.Message)
+		}
+		return fmt.Errorf("%s returned %s", path, resp.Status)
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
+// list returns the containers, stopped ones too when all is set.
+func (c *apiClient) list(ctx context.Context, all bool) ([]containerSummary, error) {
+	var containers []containerSummary
+	path := "/containers/json"
+	if all {
+		path += "?all=1"
+	}
+	err := c.get(ctx, path, &containers)
+	return containers, err
+}
+
+func (c *apiClient) inspect(ctx context.Context, id string) (containerInspect, error) {
+	var info containerInspect
+	err := c.get(ctx, "/containers/"+url.PathEscape(id)+"/json", &info)
+	return info, err
+}
+
+// stats returns a single sample of the container's stats. one-shot skips waiting for a second sample to fill in
+// precpu_stats, the CPU usage is computed from the previous reading's sample instead.
+func (c *apiClient) stats(ctx context.Context, id string) (containerStats, error) {
+	var stats containerStats
+	err := c.get(ctx, "/containers/"+url.PathEscape(id)+"/stats?stream=false&one-shot=true", &stats)
+	return stats, err
+}
+
+func (c *apiClient) close() {
+	c.http.CloseIdleConnections()
+}
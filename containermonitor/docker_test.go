@@ -0,0 +1,113 @@
+package containermonitor
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+// fakeEngine serves the parts of the Docker API the sensor reads on a Unix socket.
+func fakeEngine(t *testing.T, handler http.HandlerFunc) string {
+	socket := filepath.Join(t.TempDir(), "docker.sock")
+	listener, err := net.Listen("unix", socket)
+	require.NoError(t, err)
+	server := httptest.NewUnstartedServer(handler)
+	server.Listener = listener
+	server.Start()
+	t.Cleanup(server.Close)
+	return socket
+}
+
+func TestReadContainers(t *testing.T) {
+	var systemUsage, navUsage uint64 = 1_000_000_000, 100_000_000
+	socket := fakeEngine(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1.41/containers/json":
+			w.Write([]byte(`[{"Id":"aaa","Names":["/nav"],"State":"running"},{"Id":"bbb","Names":["/db"],"State":"running"},{"Id":"ccc","Names":["/nav-sidecar"],"State":"exited"}]`))
+		case "/v1.41/containers/aaa/json":
+			w.Write([]byte(`{"RestartCount":3,"State":{"Status":"running","OOMKilled":false,"Health":{"Status":"unhealthy","FailingStreak":2}}}`))
+		case "/v1.41/containers/ccc/json":
+			w.Write([]byte(`{"RestartCount":0,"State":{"Status":"exited","OOMKilled":true}}`))
+		case "/v1.41/containers/aaa/stats":
+			assert.Equal(t, "true", r.URL.Query().Get("one-shot"))
+			w.Write([]byte(`{"cpu_stats":{"cpu_usage":{"total_usage":` + strconv.FormatUint(navUsage, 10) + `},"system_cpu_usage":` + strconv.FormatUint(systemUsage, 10) + `,"online_cpus":4},` +
+				`"memory_stats":{"usage":300000000,"limit":1000000000,"stats":{"inactive_file":50000000}}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"message":"No such container"}`))
+		}
+	})
+	client := newAPIClient(socket, time.Second)
+	defer client.close()
+	prevCPU := make(map[string]cpuStats)
+
+	readings, err := readContainers(context.Background(), client, []string{"nav*"}, true, prevCPU)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"nav_state":                 "running",
+		"nav_restarts":              3,
+		"nav_oom_killed":            false,
+		"nav_health":                "unhealthy",
+		"nav_health_failing_streak": 2,
+		"nav_memory_bytes":          uint64(250000000),
+		"nav_memory_limit_bytes":    uint64(1000000000),
+		"nav_memory_percent":        25.0,
+		"nav-sidecar_state":         "exited",
+		"nav-sidecar_restarts":      0,
+		"nav-sidecar_oom_killed":    true,
+		"nav-sidecar_health":        "none",
+		"container_count":           2,
+		"running_count":             1,
+		"unhealthy_count":           1,
+	}, readings)
+
+	// A quarter of the system's CPU time on 4 cores is one core
+	systemUsage += 400_000_000
+	navUsage += 100_000_000
+	readings, err = readContainers(context.Background(), client, []string{"nav*"}, true, prevCPU)
+	require.NoError(t, err)
+	assert.Equal(t, 100.0, readings["nav_cpu_percent"])
+
+	// The container that can't be inspected is reported with the others
+	readings, err = readContainers(context.Background(), client, nil, false, prevCPU)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"db": "/containers/bbb/json returned 404 Not Found: No such container"}, readings[utils.ReadingErrorsKey])
+	assert.Equal(t, 3, readings["container_count"])
+	// Only the running container has a CPU sample
+	assert.Len(t, prevCPU, 1)
+	assert.Contains(t, prevCPU, "aaa")
+}
+
+func TestReadContainersWithoutEngine(t *testing.T) {
+	client := newAPIClient(filepath.Join(t.TempDir(), "docker.sock"), time.Second)
+	_, err := readContainers(context.Background(), client, nil, false, make(map[string]cpuStats))
+	assert.ErrorContains(t, err, "the container engine isn't running")
+	assert.Equal(t, utils.ErrorKindBackendUnavailable, utils.ErrorKind(err))
+}
+
+func TestCPUPercent(t *testing.T) {
+	var prev, cur cpuStats
+	_, ok := cpuPercent(prev, cur)
+	assert.False(t, ok)
+
+	prev.SystemUsage, prev.CPUUsage.TotalUsage = 1000, 100
+	cur.SystemUsage, cur.CPUUsage.TotalUsage, cur.OnlineCPUs = 2000, 350, 2
+	percent, ok := cpuPercent(prev, cur)
+	assert.True(t, ok)
+	assert.Equal(t, 50.0, percent)
+
+	// A container that restarted starts counting over
+	cur.CPUUsage.TotalUsage = 10
+	_, ok = cpuPercent(prev, cur)
+	assert.False(t, ok)
+}
@@ -0,0 +1,221 @@
+package containermonitor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"slices"
+	"sync"
+	"syscall"
+	"time"
+
+	"go.viam.com/rdk/components/sensor"
+	"go.viam.com/rdk/logging"
+	"go.viam.com/rdk/resource"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+var (
+	Model       = resource.NewModel(utils.Namespace, "hwmonitor", "container_monitor")
+	API         = sensor.API
+	PrettyName  = "Container Monitor"
+	Description = "A sensor that reports the CPU, memory, restarts and health of Docker and Podman containers"
+	Version     = utils.Version
+)
+
+type Config struct {
+	resource.Named
+	mu     sync.Mutex
+	logger logging.Logger
+	// client is nil when no socket was configured or found
+	client *apiClient
+	names  []string
+	all    bool
+	// prevCPU is the CPU sample of each container's previous reading, keyed by the container's id
+	prevCPU map[string]cpuStats
+}
+
+func init() {
+	resource.RegisterComponent(
+		API,
+		Model,
+		resource.Registration[sensor.Sensor, *ComponentConfig]{Constructor: utils.FilterReadings(NewSensor)})
+	utils.RegisterSimulator(Model, simulate)
+}
+
+func NewSensor(ctx context.Context, deps resource.Dependencies, conf resource.Config, logger logging.Logger) (sensor.Sensor, error) {
+	logger.Infof("Starting %s %s", PrettyName, Version)
+
+	b := Config{
+		Named:   conf.ResourceName().AsNamed(),
+		logger:  logger,
+		prevCPU: make(map[string]cpuStats),
+	}
+
+	if err := b.Reconfigure(ctx, deps, conf); err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
+
+func (c *Config) Reconfigure(ctx context.Context, _ resource.Dependencies, rawConf resource.Config) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.logger.Debugf("Reconfiguring %s", PrettyName)
+
+	conf, err := resource.NativeConfig[*ComponentConfig](rawConf)
+	if err != nil {
+		return err
+	}
+	socket := conf.Socket
+	if socket == "" {
+		socket = defaultSocket()
+	}
+	timeout := time.Duration(conf.TimeoutMs) * time.Millisecond
+	if timeout == 0 {
+		timeout = 5 * time.Second
+	}
+	if c.client != nil {
+		c.client.close()
+		c.client = nil
+	}
+	if socket != "" {
+		c.client = newAPIClient(socket, timeout)
+	} else {
+		c.logger.Warnf("No Docker or Podman socket found, set socket to the container engine's API socket")
+	}
+	c.names = conf.Names
+	c.all = conf.All
+
+	// In case the module has changed name
+	c.Named = rawConf.ResourceName().AsNamed()
+
+	return nil
+}
+
+func (c *Config) Readings(ctx context.Context, extra map[string]interface{}) (map[string]interface{}, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.client == nil {
+		return nil, utils.NewError(utils.ErrBackendUnavailable, "no Docker or Podman socket found, set socket to the container engine's API socket")
+	}
+	return readContainers(ctx, c.client, c.names, c.all, c.prevCPU)
+}
+
+// readContainers reports the containers whose names match names, or every container when there are none. The CPU
+// usage is over the time since the previous reading, prevCPU holds its samples and is updated.
+func readContainers(ctx context.Context, client *apiClient, names []string, all bool, prevCPU map[string]cpuStats) (map[string]interface{}, error) {
+	containers, err := client.list(ctx, all)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) || errors.Is(err, syscall.ECONNREFUSED) {
+			return nil, utils.WithKind(utils.ErrBackendUnavailable, fmt.Errorf("the container engine isn't running at %s: %w", client.socket, err))
+		}
+		return nil, err
+	}
+	ret := make(map[string]interface{})
+	errs := make(utils.ReadingErrors)
+	seen := make(map[string]bool, len(containers))
+	var count, running, unhealthy int
+	for _, container := range containers {
+		name := container.name()
+		if !matches(names, name) {
+			continue
+		}
+		count++
+		seen[container.ID] = true
+		info, err := client.inspect(ctx, container.ID)
+		if err != nil {
+			errs.Add(name, err)
+			continue
+		}
+		addContainerReadings(ret, name, info)
+		if info.State.Health != nil && info.State.Health.Status == "unhealthy" {
+			unhealthy++
+		}
+		if info.State.Status != "running" {
+			delete(prevCPU, container.ID)
+			continue
+		}
+		running++
+		stats, err := client.stats(ctx, container.ID)
+		if err != nil {
+			errs.Add(name+"_stats", err)
+			continue
+		}
+		prev, ok := prevCPU[container.ID]
+		prevCPU[container.ID] = stats.CPUStats
+		addStatsReadings(ret, name, stats, prev, ok)
+	}
+	// Forget the containers that are gone, e.g. recreated by compose with a new id
+	for id := range prevCPU {
+		if !seen[id] {
+			delete(prevCPU, id)
+		}
+	}
+	ret["container_count"] = count
+	ret["running_count"] = running
+	ret["unhealthy_count"] = unhealthy
+	return errs.Readings(ret)
+}
+
+func matches(patterns []string, name string) bool {
+	return len(patterns) == 0 || slices.ContainsFunc(patterns, func(pattern string) bool {
+		return utils.MatchPattern(pattern, name)
+	})
+}
+
+// addContainerReadings adds the container's state, restarts and health, containers without a health check report
+// none.
+func addContainerReadings(ret map[string]interface{}, name string, info containerInspect) {
+	ret[name+"_state"] = info.State.Status
+	ret[name+"_restarts"] = info.RestartCount
+	ret[name+"_oom_killed"] = info.State.OOMKilled
+	health := "none"
+	if info.State.Health != nil {
+		health = info.State.Health.Status
+		ret[name+"_health_failing_streak"] = info.State.Health.FailingStreak
+	}
+	ret[name+"_health"] = health
+}
+
+// addStatsReadings adds the CPU and memory of a running container, the CPU once there's a previous sample, prev, to
+// compare with.
+func addStatsReadings(ret map[string]interface{}, name string, stats containerStats, prev cpuStats, hasPrev bool) {
+	if hasPrev {
+		if percent, ok := cpuPercent(prev, stats.CPUStats); ok {
+			ret[name+"_cpu_percent"] = utils.RoundValue(percent, 2)
+		}
+	}
+	used := stats.memoryUsed()
+	ret[name+"_memory_bytes"] = used
+	if limit := stats.MemoryStats.Limit; limit > 0 {
+		ret[name+"_memory_limit_bytes"] = limit
+		ret[name+"_memory_percent"] = utils.RoundValue(float64(used)/float64(limit)*100, 2)
+	}
+}
+
+// Capabilities reports the socket the containers are read from.
+func (c *Config) Capabilities(ctx context.Context) utils.Capabilities {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.client == nil {
+		return utils.Capabilities{}
+	}
+	return utils.Capabilities{Backend: c.client.socket}
+}
+
+func (c *Config) Close(ctx context.Context) error {
+	c.logger.Infof("Shutting down %s", PrettyName)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.client != nil {
+		c.client.close()
+	}
+	return nil
+}
+
+func (c *Config) Ready(ctx context.Context, extra map[string]interface{}) (bool, error) {
+	return false, nil
+}
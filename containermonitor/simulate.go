@@ -0,0 +1,48 @@
+package containermonitor
+
+import (
+	"strings"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+// simulatedMemoryLimit is the limit of containers without one, the simulated board's memory
+const simulatedMemoryLimit = 8 * 1024 * 1024 * 1024
+
+// simulate returns the configured containers, or a single app container when names has only patterns, running and
+// healthy with their CPU following the board's load.
+func simulate(sim *utils.Simulation) map[string]interface{} {
+	var names []string
+	if conf, ok := sim.Config().(*ComponentConfig); ok {
+		for _, name := range conf.Names {
+			if !strings.Contains(name, "*") {
+				names = append(names, name)
+			}
+		}
+	}
+	if len(names) == 0 {
+		names = []string{"app"}
+	}
+	ret := map[string]interface{}{
+		"container_count": len(names),
+		"running_count":   len(names),
+		"unhealthy_count": 0,
+	}
+	for _, name := range names {
+		memory := uint64(sim.Value(180_000_000, 420_000_000, 400_000_000, 8_000_000))
+		addContainerReadings(ret, name, simulatedContainer())
+		ret[name+"_cpu_percent"] = sim.Percent(2, 85, 60, 2)
+		ret[name+"_memory_bytes"] = memory
+		ret[name+"_memory_limit_bytes"] = uint64(simulatedMemoryLimit)
+		ret[name+"_memory_percent"] = utils.RoundValue(float64(memory)/simulatedMemoryLimit*100, 2)
+	}
+	return ret
+}
+
+// simulatedContainer is a running container with a passing health check.
+func simulatedContainer() containerInspect {
+	var info containerInspect
+	info.State.Status = "running"
+	info.State.Health = &containerHealth{Status: "healthy"}
+	return info
+}
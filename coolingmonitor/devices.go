@@ -0,0 +1,49 @@
+package coolingmonitor
+
+import (
+	"strings"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/linux"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+// deviceNames returns the names the devices' readings are prefixed with, their types, e.g. pwm-fan, or, when several
+// devices have the same type, e.g. Processor on x86, the type and the device's number.
+func deviceNames(devices []linux.CoolingDevice) []string {
+	count := make(map[string]int, len(devices))
+	for _, device := range devices {
+		count[device.Type]++
+	}
+	names := make([]string, len(devices))
+	for i, device := range devices {
+		name := strings.ReplaceAll(device.Type, " ", "_")
+		if count[device.Type] > 1 {
+			name += "_" + strings.TrimPrefix(device.Name, "cooling_device")
+		}
+		names[i] = name
+	}
+	return names
+}
+
+// addDeviceReadings adds each device's kind and states, and which kinds of cooling the kernel is using.
+func addDeviceReadings(ret map[string]interface{}, devices []linux.CoolingDevice) {
+	active := 0
+	for i, name := range deviceNames(devices) {
+		device := devices[i]
+		ret[name+"_kind"] = device.Kind
+		ret[name+"_cur_state"] = device.CurState
+		ret[name+"_max_state"] = device.MaxState
+		if device.MaxState > 0 {
+			ret[name+"_percent"] = utils.RoundValue(float64(device.CurState)/float64(device.MaxState)*100, 1)
+		}
+		ret[name+"_active"] = device.CurState > 0
+		// Suffixed so a device whose type is a kind, e.g. fan, doesn't collide with its kind
+		kindActive, _ := ret[device.Kind+"_cooling_active"].(bool)
+		ret[device.Kind+"_cooling_active"] = kindActive || device.CurState > 0
+		if device.CurState > 0 {
+			active++
+		}
+	}
+	ret["cooling_device_count"] = len(devices)
+	ret["active_count"] = active
+}
@@ -0,0 +1,37 @@
+package coolingmonitor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/linux"
+)
+
+func TestDeviceReadings(t *testing.T) {
+	devices := []linux.CoolingDevice{
+		{Name: "cooling_device0", Type: "pwm-fan", Kind: linux.CoolingKindFan, CurState: 2, MaxState: 4},
+		{Name: "cooling_device1", Type: "Processor", Kind: linux.CoolingKindOther, MaxState: 3},
+		{Name: "cooling_device2", Type: "Processor", Kind: linux.CoolingKindOther, CurState: 1, MaxState: 3},
+		{Name: "cooling_device3", Type: "TCC Offset", Kind: linux.CoolingKindOther, MaxState: 0},
+		{Name: "cooling_device4", Type: "cpufreq-cpu0", Kind: linux.CoolingKindCpufreq, MaxState: 14},
+	}
+	assert.Equal(t, []string{"pwm-fan", "Processor_1", "Processor_2", "TCC_Offset", "cpufreq-cpu0"}, deviceNames(devices))
+
+	ret := make(map[string]interface{})
+	addDeviceReadings(ret, devices)
+	assert.Equal(t, "fan", ret["pwm-fan_kind"])
+	assert.Equal(t, int64(2), ret["pwm-fan_cur_state"])
+	assert.Equal(t, int64(4), ret["pwm-fan_max_state"])
+	assert.Equal(t, 50.0, ret["pwm-fan_percent"])
+	assert.Equal(t, true, ret["pwm-fan_active"])
+	assert.Equal(t, false, ret["Processor_1_active"])
+	assert.Equal(t, true, ret["Processor_2_active"])
+	assert.NotContains(t, ret, "TCC_Offset_percent")
+	assert.Equal(t, true, ret["fan_cooling_active"])
+	assert.Equal(t, true, ret["other_cooling_active"])
+	assert.Equal(t, false, ret["cpufreq_cooling_active"])
+	assert.NotContains(t, ret, "devfreq_cooling_active")
+	assert.Equal(t, 5, ret["cooling_device_count"])
+	assert.Equal(t, 2, ret["active_count"])
+}
@@ -0,0 +1,97 @@
+package coolingmonitor
+
+import (
+	"context"
+	"sync"
+
+	"go.viam.com/rdk/components/sensor"
+	"go.viam.com/rdk/logging"
+	"go.viam.com/rdk/resource"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/linux"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+var (
+	Model       = resource.NewModel(utils.Namespace, "hwmonitor", "cooling_monitor")
+	API         = sensor.API
+	PrettyName  = "Cooling Device Monitor"
+	Description = "A sensor that reports the state of the kernel's cooling devices, the fans and the CPU and GPU frequency caps it cools the board with"
+	Version     = utils.Version
+)
+
+type Config struct {
+	resource.Named
+	mu         sync.RWMutex
+	logger     logging.Logger
+	cancelCtx  context.Context
+	cancelFunc func()
+}
+
+func init() {
+	resource.RegisterComponent(
+		API,
+		Model,
+		resource.Registration[sensor.Sensor, *ComponentConfig]{Constructor: utils.FilterReadings(NewSensor)})
+	utils.RegisterSimulator(Model, simulate)
+}
+
+func NewSensor(ctx context.Context, deps resource.Dependencies, conf resource.Config, logger logging.Logger) (sensor.Sensor, error) {
+	logger.Infof("Starting %s %s", PrettyName, Version)
+	cancelCtx, cancelFunc := context.WithCancel(context.Background())
+
+	b := Config{
+		Named:      conf.ResourceName().AsNamed(),
+		logger:     logger,
+		cancelCtx:  cancelCtx,
+		cancelFunc: cancelFunc,
+		mu:         sync.RWMutex{},
+	}
+
+	if err := b.Reconfigure(ctx, deps, conf); err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
+
+func (c *Config) Reconfigure(ctx context.Context, _ resource.Dependencies, conf resource.Config) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.logger.Debugf("Reconfiguring %s", PrettyName)
+
+	// In case the module has changed name
+	c.Named = conf.ResourceName().AsNamed()
+
+	return nil
+}
+
+func (c *Config) Readings(ctx context.Context, extra map[string]interface{}) (map[string]interface{}, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	devices, err := linux.ReadCoolingDevices(ctx, linux.ThermalZonesRoot, linux.DevfreqRoot)
+	if err != nil {
+		return nil, err
+	}
+	ret := make(map[string]interface{})
+	addDeviceReadings(ret, devices)
+	return ret, nil
+}
+
+// Capabilities reports when the kernel has no cooling devices, e.g. on boards whose firmware does the cooling.
+func (c *Config) Capabilities(ctx context.Context) utils.Capabilities {
+	caps := utils.Capabilities{Backend: linux.ThermalZonesRoot, Unavailable: make(map[string]string)}
+	if devices, err := linux.ReadCoolingDevices(ctx, linux.ThermalZonesRoot, linux.DevfreqRoot); err == nil && len(devices) == 0 {
+		caps.Unavailable["cooling_devices"] = "the kernel has no cooling devices"
+	}
+	return caps
+}
+
+func (c *Config) Close(ctx context.Context) error {
+	c.logger.Infof("Shutting down %s", PrettyName)
+	c.cancelFunc()
+	return nil
+}
+
+func (c *Config) Ready(ctx context.Context, extra map[string]interface{}) (bool, error) {
+	return false, nil
+}
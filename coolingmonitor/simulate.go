@@ -0,0 +1,22 @@
+package coolingmonitor
+
+import (
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/linux"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+// simulate returns the cooling devices of a Rockchip RK3588 board with a fan, which speeds up under load, and caps the
+// big cores' and the GPU's clocks when throttling.
+func simulate(sim *utils.Simulation) map[string]interface{} {
+	throttling := int64(sim.Level(0, 0, 1))
+	devices := []linux.CoolingDevice{
+		{Name: "cooling_device0", Type: "cpufreq-cpu0", Kind: linux.CoolingKindCpufreq, MaxState: 8},
+		{Name: "cooling_device1", Type: "cpufreq-cpu4", Kind: linux.CoolingKindCpufreq, CurState: 3 * throttling, MaxState: 8},
+		{Name: "cooling_device2", Type: "cpufreq-cpu6", Kind: linux.CoolingKindCpufreq, CurState: 3 * throttling, MaxState: 8},
+		{Name: "cooling_device3", Type: "fb000000.gpu", Kind: linux.CoolingKindDevfreq, CurState: 2 * throttling, MaxState: 4},
+		{Name: "cooling_device4", Type: "pwm-fan", Kind: linux.CoolingKindFan, CurState: int64(sim.Level(1, 3, 4)), MaxState: 4},
+	}
+	ret := make(map[string]interface{})
+	addDeviceReadings(ret, devices)
+	return ret
+}
@@ -0,0 +1,9 @@
+package coredumpmonitor
+
+type ComponentConfig struct {
+	Directory string `json:"directory"` // Directory core dumps are written to, defaults to /var/lib/systemd/coredump
+}
+
+func (conf *ComponentConfig) Validate(path string) ([]string, error) {
+	return nil, nil
+}
@@ -0,0 +1,146 @@
+package coredumpmonitor
+
+import (
+	"context"
+	"os"
+	"sync"
+	"time"
+
+	"go.viam.com/rdk/components/sensor"
+	"go.viam.com/rdk/logging"
+	"go.viam.com/rdk/resource"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/linux"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+var (
+	Model       = resource.NewModel(utils.Namespace, "hwmonitor", "core_dump_monitor")
+	API         = sensor.API
+	PrettyName  = "SBC Core Dump Monitor"
+	Description = "A sensor that reports new core dumps and the executable that crashed"
+	Version     = utils.Version
+)
+
+type Config struct {
+	resource.Named
+	mu         sync.RWMutex
+	logger     logging.Logger
+	cancelCtx  context.Context
+	cancelFunc func()
+	directory  string
+	seen       map[string]bool
+	newDumps   int
+	lastDump   *linux.CoreDump
+}
+
+func init() {
+	resource.RegisterComponent(
+		API,
+		Model,
+		resource.Registration[sensor.Sensor, *ComponentConfig]{Constructor: utils.FilterReadings(NewSensor)})
+	utils.RegisterSimulator(Model, simulate)
+}
+
+func NewSensor(ctx context.Context, deps resource.Dependencies, conf resource.Config, logger logging.Logger) (sensor.Sensor, error) {
+	logger.Infof("Starting %s %s", PrettyName, Version)
+	cancelCtx, cancelFunc := context.WithCancel(context.Background())
+
+	b := Config{
+		Named:      conf.ResourceName().AsNamed(),
+		logger:     logger,
+		cancelCtx:  cancelCtx,
+		cancelFunc: cancelFunc,
+		mu:         sync.RWMutex{},
+	}
+
+	if err := b.Reconfigure(ctx, deps, conf); err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
+
+func (c *Config) Reconfigure(ctx context.Context, _ resource.Dependencies, conf resource.Config) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.logger.Debugf("Reconfiguring %s", PrettyName)
+
+	newConf, err := resource.NativeConfig[*ComponentConfig](conf)
+	if err != nil {
+		return err
+	}
+	directory := newConf.Directory
+	if directory == "" {
+		directory = linux.SystemdCoredumpDir
+	}
+	// A reconfigure that doesn't change the directory keeps counting the new dumps
+	if directory != c.directory || c.seen == nil {
+		// Dumps that already exist are reported as the last dump but aren't counted as new
+		seen := make(map[string]bool)
+		var lastDump *linux.CoreDump
+		dumps, err := linux.ListCoreDumps(directory)
+		if err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		for _, dump := range dumps {
+			seen[dump.Path] = true
+			lastDump = dump
+		}
+		c.directory = directory
+		c.seen = seen
+		c.newDumps = 0
+		c.lastDump = lastDump
+	}
+
+	if pattern, err := linux.ReadCorePattern(ctx); err == nil && newConf.Directory == "" && pattern != "" && pattern[0] != '|' {
+		c.logger.Warnf("kernel.core_pattern is %s, core dumps are not handled by systemd-coredump", pattern)
+	}
+
+	// In case the module has changed name
+	c.Named = conf.ResourceName().AsNamed()
+
+	return nil
+}
+
+func (c *Config) Readings(ctx context.Context, extra map[string]interface{}) (map[string]interface{}, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	dumps, err := linux.ListCoreDumps(c.directory)
+	// systemd-coredump creates the directory on the first crash
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	// Rebuilt on every reading so dumps removed by systemd-tmpfiles are forgotten
+	seen := make(map[string]bool, len(dumps))
+	for _, dump := range dumps {
+		seen[dump.Path] = true
+		if c.seen[dump.Path] {
+			continue
+		}
+		c.newDumps++
+		c.lastDump = dump
+		c.logger.Warnf("New core dump %s from %s (pid %d)", dump.Path, dump.Executable, dump.PID)
+	}
+	c.seen = seen
+
+	ret := make(map[string]interface{})
+	ret["core_dump_count"] = len(dumps)
+	ret["new_core_dump_count"] = c.newDumps
+	if c.lastDump != nil {
+		ret["last_executable"] = c.lastDump.Executable
+		ret["last_pid"] = c.lastDump.PID
+		ret["last_time"] = c.lastDump.Time.Format(time.RFC3339)
+		ret["last_size"] = c.lastDump.Size
+		ret["last_path"] = c.lastDump.Path
+	}
+	return ret, nil
+}
+
+func (c *Config) Close(ctx context.Context) error {
+	c.logger.Infof("Shutting down %s", PrettyName)
+	return nil
+}
+
+func (c *Config) Ready(ctx context.Context, extra map[string]interface{}) (bool, error) {
+	return false, nil
+}
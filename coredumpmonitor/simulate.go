@@ -0,0 +1,11 @@
+package coredumpmonitor
+
+import "github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+
+// simulate returns a board where nothing has crashed.
+func simulate(sim *utils.Simulation) map[string]interface{} {
+	return map[string]interface{}{
+		"core_dump_count":     0,
+		"new_core_dump_count": 0,
+	}
+}
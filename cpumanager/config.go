@@ -4,6 +4,7 @@
 package cpumanager
 
 import (
+	"context"
 	"errors"
 	"slices"
 	"strconv"
@@ -18,7 +19,7 @@ type ComponentConfig struct {
 
 func (conf *ComponentConfig) Validate(path string) ([]string, error) {
 	if conf.Governor != "" {
-		availableGovernors, err := getAvailableGovernors()
+		availableGovernors, err := getAvailableGovernors(context.Background())
 		if err != nil {
 			return nil, err
 		}
@@ -28,7 +29,7 @@ func (conf *ComponentConfig) Validate(path string) ([]string, error) {
 	}
 
 	if conf.Frequency != 0 {
-		min, max, err := getFrequencyLimits()
+		min, max, err := getFrequencyLimits(context.Background())
 		if err != nil {
 			return nil, err
 		}
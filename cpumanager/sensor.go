@@ -5,15 +5,14 @@ package cpumanager
 
 import (
 	"context"
-	"os/exec"
 	"strconv"
 	"sync"
 
-	"github.com/rinzlerlabs/sbcidentify"
 	"go.viam.com/rdk/components/sensor"
 	"go.viam.com/rdk/logging"
 	"go.viam.com/rdk/resource"
 
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/linux"
 	"github.com/rinzlerlabs/viam-sbc-hwmonitor/powermanager"
 	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
 )
@@ -42,7 +41,7 @@ func init() {
 	resource.RegisterComponent(
 		API,
 		Model,
-		resource.Registration[sensor.Sensor, *ComponentConfig]{Constructor: NewSensor})
+		resource.Registration[sensor.Sensor, *ComponentConfig]{Constructor: utils.FilterReadings(NewSensor)})
 }
 
 func NewSensor(ctx context.Context, deps resource.Dependencies, conf resource.Config, logger logging.Logger) (sensor.Sensor, error) {
@@ -77,7 +76,7 @@ func (c *Config) Reconfigure(ctx context.Context, _ resource.Dependencies, conf
 	// In case the module has changed name
 	c.Named = conf.ResourceName().AsNamed()
 
-	if !sbcidentify.IsRaspberryPi() {
+	if !linux.IsFamily(linux.BoardFamilyRaspberryPi) {
 		c.logger.Errorf("This sensor is only supported on Raspberry Pi")
 		return utils.ErrBoardNotSupported
 	}
@@ -108,9 +107,7 @@ func (c *Config) Reconfigure(ctx context.Context, _ resource.Dependencies, conf
 	}
 
 	if len(args) > 0 {
-		proc := exec.Command("cpufreq-set", args...)
-
-		outputBytes, err := proc.Output()
+		outputBytes, err := utils.Output(ctx, "cpufreq-set", args...)
 		if err != nil {
 			c.logger.Errorf("Error configuring CPU: %s", err)
 		}
@@ -125,12 +122,12 @@ func (c *Config) Reconfigure(ctx context.Context, _ resource.Dependencies, conf
 func (c *Config) Readings(ctx context.Context, extra map[string]interface{}) (map[string]interface{}, error) {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
-	min, max, governor, err := getCurrentPolicy()
+	min, max, governor, err := getCurrentPolicy(ctx)
 	if err != nil {
 		return nil, err
 
 	}
-	currentFrequency, err := getCurrentFrequency()
+	currentFrequency, err := getCurrentFrequency(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -1,23 +1,23 @@
 package cpumanager
 
 import (
-	"os/exec"
+	"context"
 	"strconv"
 	"strings"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
 )
 
-func getAvailableGovernors() ([]string, error) {
-	proc := exec.Command("cpufreq-info", "--governors")
-	outputBytes, err := proc.Output()
+func getAvailableGovernors(ctx context.Context) ([]string, error) {
+	outputBytes, err := utils.Output(ctx, "cpufreq-info", "--governors")
 	if err != nil {
 		return nil, err
 	}
 	return strings.Split(string(outputBytes), " "), nil
 }
 
-func getFrequencyLimits() (Minimum int, Maximum int, Err error) {
-	proc := exec.Command("cpufreq-info", "-l")
-	outputBytes, err := proc.Output()
+func getFrequencyLimits(ctx context.Context) (Minimum int, Maximum int, Err error) {
+	outputBytes, err := utils.Output(ctx, "cpufreq-info", "-l")
 	if err != nil {
 		return 0, 0, err
 	}
@@ -33,9 +33,8 @@ func getFrequencyLimits() (Minimum int, Maximum int, Err error) {
 	return min, max, nil
 }
 
-func getCurrentPolicy() (MinimumFrequency int, MaximumFrequency int, Governor string, Err error) {
-	proc := exec.Command("cpufreq-info", "-p")
-	outputBytes, err := proc.Output()
+func getCurrentPolicy(ctx context.Context) (MinimumFrequency int, MaximumFrequency int, Governor string, Err error) {
+	outputBytes, err := utils.Output(ctx, "cpufreq-info", "-p")
 	if err != nil {
 		return 0, 0, "", err
 	}
@@ -51,9 +50,8 @@ func getCurrentPolicy() (MinimumFrequency int, MaximumFrequency int, Governor st
 	return min, max, strings.TrimSpace(policy[2]), nil
 }
 
-func getCurrentFrequency() (Frequency int, Err error) {
-	proc := exec.Command("cpufreq-info", "-f")
-	outputBytes, err := proc.Output()
+func getCurrentFrequency(ctx context.Context) (Frequency int, Err error) {
+	outputBytes, err := utils.Output(ctx, "cpufreq-info", "-f")
 	if err != nil {
 		return 0, err
 	}
@@ -1,9 +1,17 @@
 package cpumonitor
 
+import "github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+
 type ComponentConfig struct {
-	SleepTimeMs int `json:"sleep_time_ms"`
+	SleepTimeMs int                     `json:"sleep_time_ms"`
+	Adaptive    *utils.AdaptiveInterval `json:"adaptive,omitempty"` // Polls faster while the usage changes, see utils.AdaptiveInterval
 }
 
 func (conf *ComponentConfig) Validate(path string) ([]string, error) {
+	if conf.Adaptive != nil {
+		if err := conf.Adaptive.Validate(); err != nil {
+			return nil, err
+		}
+	}
 	return nil, nil
 }
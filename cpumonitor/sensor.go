@@ -11,7 +11,6 @@ import (
 
 	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/sensors"
 	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
-	viamutils "go.viam.com/utils"
 )
 
 var (
@@ -27,16 +26,21 @@ type Config struct {
 	readingsLock sync.RWMutex
 	configLock   sync.Mutex
 	logger       logging.Logger
-	sleepTime    time.Duration
-	workers      *viamutils.StoppableWorkers
+	task         *utils.Task
 	reading      map[string]interface{}
+	freshness    utils.Freshness
+	adaptive     *utils.AdaptivePoller
+	// lastStats and lastReadings are only used by poll, which the scheduler never runs twice at once
+	lastStats    map[string]sensors.CPUCoreStats
+	lastReadings map[string]interface{}
 }
 
 func init() {
 	resource.RegisterComponent(
 		API,
 		Model,
-		resource.Registration[sensor.Sensor, *ComponentConfig]{Constructor: NewSensor})
+		resource.Registration[sensor.Sensor, *ComponentConfig]{Constructor: utils.FilterReadings(NewSensor)})
+	utils.RegisterSimulator(Model, simulate)
 }
 
 func NewSensor(ctx context.Context, deps resource.Dependencies, conf resource.Config, logger logging.Logger) (sensor.Sensor, error) {
@@ -61,12 +65,6 @@ func (c *Config) Reconfigure(ctx context.Context, _ resource.Dependencies, rawCo
 	defer c.configLock.Unlock()
 	c.logger.Infof("Reconfiguring %s", PrettyName)
 
-	if c.workers != nil {
-		c.logger.Debug("Stopping background worker")
-		c.workers.Stop()
-		c.logger.Debugf("Background worker stopped")
-	}
-
 	conf, err := resource.NativeConfig[*ComponentConfig](rawConf)
 	if err != nil {
 		return err
@@ -79,8 +77,23 @@ func (c *Config) Reconfigure(ctx context.Context, _ resource.Dependencies, rawCo
 		c.logger.Warnf("Invalid sleep time %d, defaulting to 1000ms", conf.SleepTimeMs)
 		conf.SleepTimeMs = 1000 // Default to 1 second
 	}
-	c.sleepTime = time.Duration(conf.SleepTimeMs * int(time.Millisecond))
-	c.workers = viamutils.NewBackgroundStoppableWorkers(c.startUpdating)
+	sleepTime := time.Duration(conf.SleepTimeMs * int(time.Millisecond))
+	var adaptive *utils.AdaptivePoller
+	if conf.Adaptive != nil {
+		adaptive = utils.NewAdaptivePoller(*conf.Adaptive)
+		sleepTime = conf.Adaptive.Clamp(sleepTime)
+	}
+	c.readingsLock.Lock()
+	c.adaptive = adaptive
+	c.readingsLock.Unlock()
+	if c.task != nil {
+		// The task keeps its previous sample, so there's no gap in the usage
+		c.task.SetInterval(sleepTime)
+	} else {
+		// The first poll takes the sample the first usage is calculated from
+		c.poll(ctx)
+		c.task = utils.Schedule(sleepTime, c.poll)
+	}
 
 	c.logger.Debugf("Reconfigure complete %s", PrettyName)
 	return nil
@@ -89,67 +102,60 @@ func (c *Config) Reconfigure(ctx context.Context, _ resource.Dependencies, rawCo
 func (c *Config) Readings(ctx context.Context, extra map[string]interface{}) (map[string]interface{}, error) {
 	c.readingsLock.RLock()
 	defer c.readingsLock.RUnlock()
-	return c.reading, nil
+	return c.freshness.Stamp(c.reading, c.task.Interval(), time.Now()), nil
 }
 
 func (c *Config) Close(ctx context.Context) error {
 	c.configLock.Lock()
 	defer c.configLock.Unlock()
 	c.logger.Infof("Shutting down %v", PrettyName)
-	c.workers.Stop()
+	c.task.Stop()
 	c.logger.Infof("%v Shutdown complete", PrettyName)
 	return nil
 }
 
-// startUpdating is a goroutine that updates the CPU stats every sleepTime
-// It ensures if there are multiple readers of this sensor, it doesn't cause short samples
-func (c *Config) startUpdating(ctx context.Context) {
-	var err error
-	var lastStats map[string]sensors.CPUCoreStats
-	var lastReadings map[string]interface{}
-	for {
-		if lastStats == nil {
-			lastStats, err = sensors.ReadCPUStats()
-			if err != nil {
-				c.logger.Warnf("Failed to read CPU stats, skipping iteration: %v", err)
-				continue
-			}
-			lastReadings = make(map[string]interface{})
+// poll updates the CPU usage since the previous poll, the scheduler polls every interval so if there are multiple
+// readers of this sensor, they don't cause short samples
+func (c *Config) poll(ctx context.Context) {
+	currStats, err := sensors.ReadSharedCPUStats(ctx)
+	if err != nil {
+		c.logger.Warnf("Failed to read CPU stats, skipping iteration: %v", err)
+		c.readingsLock.Lock()
+		c.freshness.Failed(err)
+		c.readingsLock.Unlock()
+		return
+	}
+	if c.lastStats == nil {
+		c.lastStats = currStats
+		c.lastReadings = make(map[string]interface{})
+		return
+	}
+	ret := make(map[string]interface{})
+	for core, prev := range c.lastStats {
+		curr, ok := currStats[core]
+		if !ok {
+			c.logger.Warnf("Core %s not found in current stats", core)
+			continue
 		}
-		select {
-		case <-ctx.Done():
-			return
-		case <-time.After(c.sleepTime):
-			currStats, err := sensors.ReadCPUStats()
-			if err != nil {
-				c.logger.Warnf("Failed to read CPU stats, skipping iteration: %v", err)
-				continue
-			}
-			ret := make(map[string]interface{})
-			for core, prev := range lastStats {
-				curr, ok := currStats[core]
-				if !ok {
-					c.logger.Warnf("Core %s not found in current stats", core)
-					continue
-				}
-				usage := sensors.CalculateUsage(prev, curr)
-				if usage < 0 {
-					// Counter regression detected - keep previous reading and don't update baseline
-					if prevReading, ok := lastReadings[core]; ok {
-						ret[core] = prevReading
-					} else {
-						ret[core] = 0.0
-					}
-					continue
-				}
-				ret[core] = usage
-				// Only update baseline for this core if reading was valid
-				lastStats[core] = curr
+		usage := sensors.CalculateUsage(prev, curr)
+		if usage < 0 {
+			// Counter regression detected - keep previous reading and don't update baseline
+			if prevReading, ok := c.lastReadings[core]; ok {
+				ret[core] = prevReading
+			} else {
+				ret[core] = 0.0
 			}
-			c.readingsLock.Lock()
-			c.reading = ret
-			lastReadings = ret
-			c.readingsLock.Unlock()
+			continue
 		}
+		ret[core] = usage
+		// Only update baseline for this core if reading was valid
+		c.lastStats[core] = curr
 	}
+	c.readingsLock.Lock()
+	c.reading = ret
+	c.freshness.Succeeded(time.Now())
+	c.lastReadings = ret
+	adaptive := c.adaptive
+	c.readingsLock.Unlock()
+	adaptive.Adjust(c.task, ret)
 }
@@ -9,26 +9,35 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.viam.com/rdk/logging"
-	viamutils "go.viam.com/utils"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
 )
 
+func waitForReading(sensor *Config) map[string]interface{} {
+	for {
+		sensor.readingsLock.RLock()
+		reading := sensor.reading
+		sensor.readingsLock.RUnlock()
+		if len(reading) > 0 {
+			return reading
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
 func TestCaptureCPUStats(t *testing.T) {
 	logger := logging.NewTestLogger(t)
 	sensor := &Config{
-		logger:    logger,
-		sleepTime: 1 * time.Second,
+		logger: logger,
 	}
 
-	sensor.workers = viamutils.NewBackgroundStoppableWorkers(sensor.startUpdating)
+	sensor.poll(context.Background())
+	sensor.task = utils.Schedule(1*time.Second, sensor.poll)
 
-	for {
-		if len(sensor.reading) > 0 {
-			break
-		}
-	}
+	reading := waitForReading(sensor)
 	sensor.Close(context.Background())
-	require.Equal(t, runtime.NumCPU()+1, len(sensor.reading))
-	for k, v := range sensor.reading {
+	require.Equal(t, runtime.NumCPU()+1, len(reading))
+	for k, v := range reading {
 		logger.Infof("%v: %v", k, v)
 	}
 }
@@ -36,11 +45,10 @@ func TestCaptureCPUStats(t *testing.T) {
 func TestCaptureCPUStatsExitsImmediately(t *testing.T) {
 	logger := logging.NewTestLogger(t)
 	sensor := &Config{
-		logger:    logger,
-		sleepTime: 1 * time.Second,
+		logger: logger,
 	}
 
-	sensor.workers = viamutils.NewBackgroundStoppableWorkers(sensor.startUpdating)
+	sensor.task = utils.Schedule(1*time.Second, sensor.poll)
 	start := time.Now()
 	sensor.Close(context.Background())
 	end := time.Now()
@@ -51,21 +59,25 @@ func TestCaptureCPUStatsRespectsSleepTime(t *testing.T) {
 	logger := logging.NewTestLogger(t)
 	ctx := context.Background()
 	sensor := &Config{
-		logger:    logger,
-		sleepTime: 100 * time.Millisecond,
+		logger: logger,
 	}
 
-	now := time.Now()
-	sensor.workers = viamutils.NewBackgroundStoppableWorkers(sensor.startUpdating)
-
-	for {
-		if len(sensor.reading) > 0 {
-			break
+	sensor.poll(ctx)
+	// The first poll is at a random point within the interval, the ones after it are at least an interval apart
+	started := make(chan time.Time, 1)
+	sensor.task = utils.Schedule(100*time.Millisecond, func(ctx context.Context) {
+		select {
+		case started <- time.Now():
+		default:
 		}
-	}
-	sensor.Close(ctx)
+		sensor.poll(ctx)
+	})
+	now := <-started
+	<-started
+	reading := waitForReading(sensor)
 	end := time.Now()
-	assert.Equal(t, runtime.NumCPU()+1, len(sensor.reading))
+	sensor.Close(ctx)
+	assert.Equal(t, runtime.NumCPU()+1, len(reading))
 	testLength := end.Sub(now)
 	logger.Infof("Test took %s", testLength)
 	assert.True(t, testLength > 100*time.Millisecond)
@@ -0,0 +1,24 @@
+package cpumonitor
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+// simulatedCores is how many cores the simulated board has, like a Raspberry Pi 5
+const simulatedCores = 4
+
+// simulate returns the usage of each core and their average.
+func simulate(sim *utils.Simulation) map[string]interface{} {
+	ret := make(map[string]interface{}, simulatedCores+1)
+	total := 0.0
+	for i := 0; i < simulatedCores; i++ {
+		usage := sim.Percent(4, 78, 97, 8)
+		ret[fmt.Sprintf("cpu%d", i)] = usage
+		total += usage
+	}
+	ret["cpu"] = math.Round(total/simulatedCores*100) / 100
+	return ret
+}
@@ -0,0 +1,9 @@
+package csicameramonitor
+
+type ComponentConfig struct {
+	ExpectedModels []string `json:"expected_models,omitempty"` // Sensor models that should be attached, list a model twice for a stereo pair
+}
+
+func (conf *ComponentConfig) Validate(path string) ([]string, error) {
+	return nil, nil
+}
@@ -0,0 +1,145 @@
+package csicameramonitor
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"go.viam.com/rdk/components/sensor"
+	"go.viam.com/rdk/logging"
+	"go.viam.com/rdk/resource"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/linux"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+var (
+	Model       = resource.NewModel(utils.Namespace, "hwmonitor", "csi_camera_monitor")
+	API         = sensor.API
+	PrettyName  = "CSI Camera Monitor"
+	Description = "A sensor that reports the attached MIPI CSI cameras and whether the expected cameras are detected"
+	Version     = utils.Version
+)
+
+type Config struct {
+	resource.Named
+	mu             sync.RWMutex
+	logger         logging.Logger
+	cancelCtx      context.Context
+	cancelFunc     func()
+	expectedModels []string
+	// missing is the last missing list that was logged, so each change is only logged once
+	missing string
+}
+
+func init() {
+	resource.RegisterComponent(
+		API,
+		Model,
+		resource.Registration[sensor.Sensor, *ComponentConfig]{Constructor: utils.FilterReadings(NewSensor)})
+	utils.RegisterSimulator(Model, simulate)
+}
+
+func NewSensor(ctx context.Context, deps resource.Dependencies, conf resource.Config, logger logging.Logger) (sensor.Sensor, error) {
+	logger.Infof("Starting %s %s", PrettyName, Version)
+	cancelCtx, cancelFunc := context.WithCancel(context.Background())
+
+	b := Config{
+		Named:      conf.ResourceName().AsNamed(),
+		logger:     logger,
+		cancelCtx:  cancelCtx,
+		cancelFunc: cancelFunc,
+		mu:         sync.RWMutex{},
+	}
+
+	if err := b.Reconfigure(ctx, deps, conf); err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
+
+func (c *Config) Reconfigure(ctx context.Context, _ resource.Dependencies, rawConf resource.Config) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.logger.Debugf("Reconfiguring %s", PrettyName)
+
+	conf, err := resource.NativeConfig[*ComponentConfig](rawConf)
+	if err != nil {
+		return err
+	}
+
+	// In case the module has changed name
+	c.Named = rawConf.ResourceName().AsNamed()
+	c.expectedModels = make([]string, 0, len(conf.ExpectedModels))
+	for _, model := range conf.ExpectedModels {
+		c.expectedModels = append(c.expectedModels, strings.ToLower(model))
+	}
+	c.missing = ""
+
+	return nil
+}
+
+func (c *Config) Readings(ctx context.Context, extra map[string]interface{}) (map[string]interface{}, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	cameras, err := linux.ReadCSICameras(ctx, linux.Video4LinuxRoot, "/dev")
+	if err != nil {
+		return nil, err
+	}
+
+	ret := make(map[string]interface{})
+	models := make([]string, 0, len(cameras))
+	for i, camera := range cameras {
+		prefix := fmt.Sprintf("camera%d", i)
+		ret[prefix+"_model"] = camera.Model
+		ret[prefix+"_i2c"] = camera.I2C
+		ret[prefix+"_device"] = camera.Device
+		ret[prefix+"_device_exists"] = camera.DeviceExists
+		// A camera without its device node can't be opened, so it doesn't count as detected
+		if camera.DeviceExists {
+			models = append(models, camera.Model)
+		}
+	}
+	ret["camera_count"] = len(cameras)
+	ret["cameras"] = strings.Join(models, ",")
+	if len(c.expectedModels) == 0 {
+		return ret, nil
+	}
+
+	missing := strings.Join(missingModels(c.expectedModels, models), ",")
+	ret["missing"] = missing
+	ret["all_expected_present"] = missing == ""
+	if missing != c.missing && missing != "" {
+		c.logger.Warnf("Expected CSI cameras are not detected: %s", missing)
+	}
+	c.missing = missing
+	return ret, nil
+}
+
+// missingModels returns the expected models that weren't found, a model expected twice needs two cameras.
+func missingModels(expected, found []string) []string {
+	available := make(map[string]int)
+	for _, model := range found {
+		available[model]++
+	}
+	missing := make([]string, 0)
+	for _, model := range expected {
+		if available[model] > 0 {
+			available[model]--
+			continue
+		}
+		missing = append(missing, model)
+	}
+	return missing
+}
+
+func (c *Config) Close(ctx context.Context) error {
+	c.logger.Infof("Shutting down %s", PrettyName)
+	c.cancelFunc()
+	return nil
+}
+
+func (c *Config) Ready(ctx context.Context, extra map[string]interface{}) (bool, error) {
+	return false, nil
+}
@@ -0,0 +1,13 @@
+package csicameramonitor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMissingModels(t *testing.T) {
+	assert.Empty(t, missingModels([]string{"imx708"}, []string{"imx708", "imx296"}))
+	assert.Equal(t, []string{"imx219"}, missingModels([]string{"imx219", "imx219"}, []string{"imx219"}))
+	assert.Equal(t, []string{"imx708"}, missingModels([]string{"imx708"}, []string{}))
+}
@@ -0,0 +1,32 @@
+package csicameramonitor
+
+import (
+	"strings"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+// simulatedModel is a Raspberry Pi Camera Module 3 on the simulated board's first camera connector.
+const simulatedModel = "imx708"
+
+// simulate returns the camera, and the expected models that aren't it as missing.
+func simulate(sim *utils.Simulation) map[string]interface{} {
+	ret := map[string]interface{}{
+		"camera0_model":         simulatedModel,
+		"camera0_i2c":           "6-001a",
+		"camera0_device":        "/dev/v4l-subdev2",
+		"camera0_device_exists": true,
+		"camera_count":          1,
+		"cameras":               simulatedModel,
+	}
+	if conf, ok := sim.Config().(*ComponentConfig); ok && len(conf.ExpectedModels) > 0 {
+		expected := make([]string, 0, len(conf.ExpectedModels))
+		for _, model := range conf.ExpectedModels {
+			expected = append(expected, strings.ToLower(model))
+		}
+		missing := strings.Join(missingModels(expected, []string{simulatedModel}), ",")
+		ret["missing"] = missing
+		ret["all_expected_present"] = missing == ""
+	}
+	return ret
+}
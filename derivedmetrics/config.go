@@ -0,0 +1,119 @@
+package derivedmetrics
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/expr"
+)
+
+// Names have to be usable as variables in expressions
+var namePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+type Input struct {
+	Name   string `json:"name"`   // The variable expressions use
+	Sensor string `json:"sensor"` // The sensor it's read from
+	Key    string `json:"key"`    // The reading, the keys of nested readings are joined with underscores
+}
+
+type Rate struct {
+	Name        string `json:"name"`                   // The reading this sensor reports, metrics can use it too
+	Sensor      string `json:"sensor"`                 // The sensor the counter is read from
+	Key         string `json:"key"`                    // The counter, e.g. bytes, retries or interrupts
+	CounterBits int    `json:"counter_bits,omitempty"` // 32 or 64 if the counter wraps around, otherwise going back is a reset
+}
+
+type Metric struct {
+	Name       string `json:"name"`       // The reading this sensor reports, later metrics can use it too
+	Expression string `json:"expression"` // e.g. volts * amps
+}
+
+type ComponentConfig struct {
+	Inputs  []Input  `json:"inputs,omitempty"`
+	Rates   []Rate   `json:"rates,omitempty"`
+	Metrics []Metric `json:"metrics,omitempty"`
+}
+
+func (conf *ComponentConfig) Validate(path string) ([]string, error) {
+	if len(conf.Rates) == 0 && len(conf.Metrics) == 0 {
+		return nil, errors.New("at least one rate or metric is required")
+	}
+	if _, err := conf.parse(); err != nil {
+		return nil, err
+	}
+	return conf.sensors(), nil
+}
+
+// parse parses the metrics' expressions and checks that every variable they use is an input, a rate or an earlier
+// metric.
+func (conf *ComponentConfig) parse() ([]*expr.Expr, error) {
+	defined := make(map[string]bool, len(conf.Inputs)+len(conf.Metrics))
+	for i, input := range conf.Inputs {
+		if !namePattern.MatchString(input.Name) {
+			return nil, fmt.Errorf("input %d: name must be letters, digits and underscores, and not start with a digit", i)
+		}
+		if defined[input.Name] {
+			return nil, fmt.Errorf("input %s: name is used by another input", input.Name)
+		}
+		defined[input.Name] = true
+		if input.Sensor == "" || input.Key == "" {
+			return nil, fmt.Errorf("input %s: sensor and key are required", input.Name)
+		}
+	}
+	for i, rate := range conf.Rates {
+		if !namePattern.MatchString(rate.Name) {
+			return nil, fmt.Errorf("rate %d: name must be letters, digits and underscores, and not start with a digit", i)
+		}
+		if defined[rate.Name] {
+			return nil, fmt.Errorf("rate %s: name is used by another input or rate", rate.Name)
+		}
+		defined[rate.Name] = true
+		if rate.Sensor == "" || rate.Key == "" {
+			return nil, fmt.Errorf("rate %s: sensor and key are required", rate.Name)
+		}
+		if rate.CounterBits != 0 && rate.CounterBits != 32 && rate.CounterBits != 64 {
+			return nil, fmt.Errorf("rate %s: counter_bits must be 32 or 64", rate.Name)
+		}
+	}
+	exprs := make([]*expr.Expr, 0, len(conf.Metrics))
+	for i, metric := range conf.Metrics {
+		if !namePattern.MatchString(metric.Name) {
+			return nil, fmt.Errorf("metric %d: name must be letters, digits and underscores, and not start with a digit", i)
+		}
+		if defined[metric.Name] {
+			return nil, fmt.Errorf("metric %s: name is used by another input, rate or metric", metric.Name)
+		}
+		e, err := expr.Parse(metric.Expression)
+		if err != nil {
+			return nil, fmt.Errorf("metric %s: %w", metric.Name, err)
+		}
+		for _, v := range e.Vars() {
+			if !defined[v] {
+				return nil, fmt.Errorf("metric %s: %s isn't an input, a rate or an earlier metric", metric.Name, v)
+			}
+		}
+		defined[metric.Name] = true
+		exprs = append(exprs, e)
+	}
+	return exprs, nil
+}
+
+// sensors returns the sensors the inputs and rates are read from, each once.
+func (conf *ComponentConfig) sensors() []string {
+	var ret []string
+	seen := make(map[string]bool)
+	add := func(name string) {
+		if !seen[name] {
+			seen[name] = true
+			ret = append(ret, name)
+		}
+	}
+	for _, input := range conf.Inputs {
+		add(input.Sensor)
+	}
+	for _, rate := range conf.Rates {
+		add(rate.Sensor)
+	}
+	return ret
+}
@@ -0,0 +1,64 @@
+package derivedmetrics
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidate(t *testing.T) {
+	inputs := []Input{
+		{Name: "volts", Sensor: "power", Key: "VDD_IN_voltage"},
+		{Name: "amps", Sensor: "power", Key: "VDD_IN_current"},
+		{Name: "soc_temp", Sensor: "temperatures", Key: "SOC"},
+	}
+	conf := &ComponentConfig{Inputs: inputs, Metrics: []Metric{
+		{Name: "watts", Expression: "volts * amps"},
+		{Name: "headroom", Expression: "85 - soc_temp"},
+		{Name: "milliwatts", Expression: "watts * 1000"},
+	}}
+	deps, err := conf.Validate("")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"power", "temperatures"}, deps)
+
+	for _, c := range []*ComponentConfig{
+		{Inputs: inputs},
+		{Inputs: []Input{{Name: "1volts", Sensor: "power", Key: "v"}}, Metrics: []Metric{{Name: "one", Expression: "1"}}},
+		{Inputs: []Input{inputs[0], inputs[0]}, Metrics: []Metric{{Name: "one", Expression: "1"}}},
+		{Inputs: []Input{{Name: "volts", Sensor: "power"}}, Metrics: []Metric{{Name: "one", Expression: "1"}}},
+		{Inputs: inputs, Metrics: []Metric{{Name: "volts", Expression: "1"}}},
+		{Inputs: inputs, Metrics: []Metric{{Name: "watts", Expression: "volts *"}}},
+		{Inputs: inputs, Metrics: []Metric{{Name: "watts", Expression: "volts * current"}}},
+		// Metrics can only use the ones before them
+		{Inputs: inputs, Metrics: []Metric{{Name: "milliwatts", Expression: "watts * 1000"}, {Name: "watts", Expression: "volts * amps"}}},
+		{Inputs: inputs, Metrics: []Metric{{Name: "watts-total", Expression: "volts * amps"}}},
+	} {
+		_, err := c.Validate("")
+		assert.Error(t, err, c)
+	}
+}
+
+func TestValidateRates(t *testing.T) {
+	rates := []Rate{
+		{Name: "rx_bytes_per_sec", Sensor: "network", Key: "wlan0_rx_bytes"},
+		{Name: "irqs_per_sec", Sensor: "interrupts", Key: "total", CounterBits: 32},
+	}
+	conf := &ComponentConfig{Rates: rates, Metrics: []Metric{{Name: "rx_bits_per_sec", Expression: "rx_bytes_per_sec * 8"}}}
+	deps, err := conf.Validate("")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"network", "interrupts"}, deps)
+	_, err = (&ComponentConfig{Rates: rates}).Validate("")
+	assert.NoError(t, err)
+
+	for _, c := range []*ComponentConfig{
+		{},
+		{Rates: []Rate{rates[0], rates[0]}},
+		{Rates: []Rate{{Name: "rx bytes", Sensor: "network", Key: "wlan0_rx_bytes"}}},
+		{Rates: []Rate{{Name: "rx", Sensor: "network"}}},
+		{Rates: []Rate{{Name: "rx", Sensor: "network", Key: "wlan0_rx_bytes", CounterBits: 16}}},
+		{Inputs: []Input{{Name: "rx", Sensor: "network", Key: "wlan0_rx_bytes"}}, Rates: []Rate{{Name: "rx", Sensor: "network", Key: "wlan0_rx_bytes"}}},
+	} {
+		_, err := c.Validate("")
+		assert.Error(t, err, c)
+	}
+}
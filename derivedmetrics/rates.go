@@ -0,0 +1,41 @@
+package derivedmetrics
+
+import (
+	"math"
+	"time"
+)
+
+// counterRate turns samples of a cumulative counter into a per second rate.
+type counterRate struct {
+	bits      int // The width of the counter, 0 if it's not known to wrap
+	last      float64
+	lastTime  time.Time
+	hasSample bool
+}
+
+// update records a sample and returns the rate since the previous one. A counter that went backwards wrapped around if
+// it's a bits wide counter and the wrapped delta is less than half its range, otherwise it was reset, e.g. because the
+// device or process restarted. There's no rate for the first sample or after a reset.
+func (r *counterRate) update(value float64, now time.Time) (float64, bool) {
+	last, lastTime, hasSample := r.last, r.lastTime, r.hasSample
+	r.last, r.lastTime, r.hasSample = value, now, true
+	if !hasSample {
+		return 0, false
+	}
+	elapsed := now.Sub(lastTime).Seconds()
+	if elapsed <= 0 {
+		return 0, false
+	}
+	delta := value - last
+	if delta < 0 {
+		if r.bits == 0 {
+			return 0, false
+		}
+		size := math.Pow(2, float64(r.bits))
+		delta += size
+		if delta < 0 || delta >= size/2 {
+			return 0, false
+		}
+	}
+	return delta / elapsed, true
+}
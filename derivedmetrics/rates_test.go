@@ -0,0 +1,49 @@
+package derivedmetrics
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCounterRate(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	r := &counterRate{}
+	_, ok := r.update(1000, now)
+	assert.False(t, ok)
+
+	rate, ok := r.update(3000, now.Add(2*time.Second))
+	assert.True(t, ok)
+	assert.Equal(t, 1000.0, rate)
+
+	rate, ok = r.update(3000, now.Add(4*time.Second))
+	assert.True(t, ok)
+	assert.Equal(t, 0.0, rate)
+
+	// A counter that isn't known to wrap was reset, the next sample has a rate again
+	_, ok = r.update(10, now.Add(6*time.Second))
+	assert.False(t, ok)
+	rate, ok = r.update(40, now.Add(7*time.Second))
+	assert.True(t, ok)
+	assert.Equal(t, 30.0, rate)
+
+	// Samples at the same time have no rate
+	_, ok = r.update(50, now.Add(7*time.Second))
+	assert.False(t, ok)
+}
+
+func TestCounterRateWraparound(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	r := &counterRate{bits: 32}
+	r.update(math.MaxUint32-99, now)
+	rate, ok := r.update(100, now.Add(time.Second))
+	assert.True(t, ok)
+	assert.Equal(t, 200.0, rate)
+
+	// Going back by more than half the range is a reset rather than a wrap
+	r.update(math.MaxUint32/2-1000, now.Add(2*time.Second))
+	_, ok = r.update(5, now.Add(3*time.Second))
+	assert.False(t, ok)
+}
@@ -0,0 +1,184 @@
+package derivedmetrics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.viam.com/rdk/components/sensor"
+	"go.viam.com/rdk/logging"
+	"go.viam.com/rdk/resource"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/expr"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+var (
+	Model       = resource.NewModel(utils.Namespace, "hwmonitor", "derived_metrics")
+	API         = sensor.API
+	PrettyName  = "Derived Metrics"
+	Description = "A sensor that computes readings from formulas over the readings of other sensors, e.g. watts = volts * amps"
+	Version     = utils.Version
+)
+
+const readTimeout = 5 * time.Second
+
+type rate struct {
+	Rate
+	counter *counterRate
+}
+
+type metric struct {
+	name string
+	expr *expr.Expr
+}
+
+type Config struct {
+	resource.Named
+	mu      sync.RWMutex
+	logger  logging.Logger
+	sources []utils.Source
+	inputs  []Input
+	rates   []rate
+	metrics []metric
+}
+
+func init() {
+	resource.RegisterComponent(
+		API,
+		Model,
+		resource.Registration[sensor.Sensor, *ComponentConfig]{Constructor: utils.FilterReadings(NewSensor)})
+}
+
+func NewSensor(ctx context.Context, deps resource.Dependencies, conf resource.Config, logger logging.Logger) (sensor.Sensor, error) {
+	logger.Infof("Starting %s %s", PrettyName, Version)
+	b := Config{
+		Named:  conf.ResourceName().AsNamed(),
+		logger: logger,
+	}
+
+	if err := b.Reconfigure(ctx, deps, conf); err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
+
+func (c *Config) Reconfigure(ctx context.Context, deps resource.Dependencies, rawConf resource.Config) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.logger.Debugf("Reconfiguring %s", PrettyName)
+
+	conf, err := resource.NativeConfig[*ComponentConfig](rawConf)
+	if err != nil {
+		return err
+	}
+
+	// In case the component has changed name
+	c.Named = rawConf.ResourceName().AsNamed()
+
+	exprs, err := conf.parse()
+	if err != nil {
+		return err
+	}
+	sources, err := utils.ResolveSources(deps, conf.sensors())
+	if err != nil {
+		return err
+	}
+	metrics := make([]metric, len(conf.Metrics))
+	for i, m := range conf.Metrics {
+		metrics[i] = metric{name: m.Name, expr: exprs[i]}
+	}
+
+	// Counters keep their last sample across reconfigures, so a rate that didn't change isn't missing from a reading
+	previous := make(map[Rate]*counterRate, len(c.rates))
+	for _, r := range c.rates {
+		previous[r.Rate] = r.counter
+	}
+	rates := make([]rate, len(conf.Rates))
+	for i, r := range conf.Rates {
+		counter, ok := previous[r]
+		if !ok {
+			counter = &counterRate{bits: r.CounterBits}
+		}
+		rates[i] = rate{Rate: r, counter: counter}
+	}
+
+	c.sources = sources
+	c.inputs = conf.Inputs
+	c.rates = rates
+	c.metrics = metrics
+	return nil
+}
+
+// Readings reads the inputs and counters and evaluates the metrics in order. Rates are per second since the previous
+// call. A metric whose inputs can't be read, or that can't be evaluated, e.g. because it divides by zero, is left out
+// and the reason is reported in error.
+func (c *Config) Readings(ctx context.Context, extra map[string]interface{}) (map[string]interface{}, error) {
+	// Rates update their counters
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	readings := make(map[string]map[string]float64, len(c.sources))
+	var errs []error
+	for _, s := range c.sources {
+		readCtx, cancel := context.WithTimeout(ctx, readTimeout)
+		r, err := s.Sensor.Readings(readCtx, nil)
+		cancel()
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to read %s: %w", s.Name, err))
+			continue
+		}
+		readings[s.Name] = utils.NumericReadings(r)
+	}
+	vars := make(map[string]float64, len(c.inputs)+len(c.metrics))
+	for _, input := range c.inputs {
+		if value, ok := readings[input.Sensor][input.Key]; ok {
+			vars[input.Name] = value
+		}
+	}
+
+	ret := make(map[string]interface{}, len(c.rates)+len(c.metrics))
+	now := time.Now()
+	for _, r := range c.rates {
+		values, read := readings[r.Sensor]
+		if !read {
+			continue
+		}
+		value, ok := values[r.Key]
+		if !ok {
+			errs = append(errs, fmt.Errorf("%s: %s has no reading %s", r.Name, r.Sensor, r.Key))
+			continue
+		}
+		// The first sample and a counter reset have no rate, which isn't an error
+		if perSec, ok := r.counter.update(value, now); ok {
+			perSec = utils.RoundValue(perSec, 3)
+			vars[r.Name] = perSec
+			ret[r.Name] = perSec
+		}
+	}
+	for _, m := range c.metrics {
+		value, err := m.expr.Eval(vars)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", m.name, err))
+			continue
+		}
+		vars[m.name] = value
+		ret[m.name] = value
+	}
+	if err := errors.Join(errs...); err != nil {
+		c.logger.Debugf("Failed to evaluate metrics: %v", err)
+		ret["error"] = err.Error()
+	}
+	return ret, nil
+}
+
+func (c *Config) Close(ctx context.Context) error {
+	c.logger.Infof("Shutting down %s", PrettyName)
+	return nil
+}
+
+func (c *Config) Ready(ctx context.Context, extra map[string]interface{}) (bool, error) {
+	return false, nil
+}
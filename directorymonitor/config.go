@@ -0,0 +1,25 @@
+package directorymonitor
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+)
+
+type ComponentConfig struct {
+	Directories      []string `json:"directories"`         // Directories to measure, defaults to /var/log and /tmp
+	SleepTimeMs      int      `json:"sleep_time_ms"`       // Time between scans, defaults to 60 seconds
+	MaxEntriesPerSec int      `json:"max_entries_per_sec"` // Limits how fast the scanner walks the directories
+}
+
+func (conf *ComponentConfig) Validate(path string) ([]string, error) {
+	for _, dir := range conf.Directories {
+		if !filepath.IsAbs(dir) {
+			return nil, fmt.Errorf("directory must be an absolute path: %s", dir)
+		}
+	}
+	if conf.MaxEntriesPerSec < 0 {
+		return nil, errors.New("max_entries_per_sec must not be negative")
+	}
+	return nil, nil
+}
@@ -0,0 +1,70 @@
+package directorymonitor
+
+import (
+	"context"
+	"io/fs"
+	"path/filepath"
+	"time"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+type dirSize struct {
+	Bytes uint64
+	Files uint64
+}
+
+// scanDirectory sums the apparent size of every file below root, like du --apparent-size. It sleeps between
+// batches so walking a huge log directory doesn't starve the SD card. Files that disappear or can't be read
+// while walking are skipped.
+func scanDirectory(ctx context.Context, root string, maxEntriesPerSec int) (*dirSize, error) {
+	const batch = 100
+	ret := &dirSize{}
+	entries := 0
+	batchStart := time.Now()
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if path == root {
+				return err
+			}
+			return nil
+		}
+		entries++
+		if maxEntriesPerSec > 0 && entries%batch == 0 {
+			minimum := time.Duration(batch) * time.Second / time.Duration(maxEntriesPerSec)
+			if elapsed := time.Since(batchStart); elapsed < minimum {
+				select {
+				case <-ctx.Done():
+					return ctx.Err()
+				case <-time.After(minimum - elapsed):
+				}
+			}
+			batchStart = time.Now()
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return nil
+		}
+		ret.Bytes += uint64(info.Size())
+		ret.Files++
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// growthPerHour returns how fast a directory grew between two scans, negative when it shrank.
+func growthPerHour(prev, cur uint64, elapsed time.Duration) float64 {
+	if elapsed <= 0 {
+		return 0
+	}
+	return utils.RoundValue((float64(cur)-float64(prev))/elapsed.Hours(), 0)
+}
@@ -0,0 +1,48 @@
+package directorymonitor
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScanDirectory(t *testing.T) {
+	root := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "nested", "deeper"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "a.log"), make([]byte, 1000), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "nested", "b.log"), make([]byte, 200), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "nested", "deeper", "c.log"), make([]byte, 30), 0o644))
+
+	size, err := scanDirectory(context.Background(), root, 0)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1230), size.Bytes)
+	assert.Equal(t, uint64(3), size.Files)
+
+	_, err = scanDirectory(context.Background(), filepath.Join(root, "missing"), 0)
+	assert.Error(t, err)
+}
+
+func TestScanDirectoryRateLimit(t *testing.T) {
+	root := t.TempDir()
+	for i := 0; i < 250; i++ {
+		require.NoError(t, os.WriteFile(filepath.Join(root, fmt.Sprintf("%d.log", i)), nil, 0o644))
+	}
+	start := time.Now()
+	size, err := scanDirectory(context.Background(), root, 1000)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(250), size.Files)
+	// Two full batches of 100 at 1000 entries per second
+	assert.GreaterOrEqual(t, time.Since(start), 150*time.Millisecond)
+}
+
+func TestGrowthPerHour(t *testing.T) {
+	assert.Equal(t, 2000.0, growthPerHour(1000, 2000, 30*time.Minute))
+	assert.Equal(t, -1000.0, growthPerHour(2000, 1000, time.Hour))
+	assert.Equal(t, 0.0, growthPerHour(1000, 2000, 0))
+}
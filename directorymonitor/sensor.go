@@ -0,0 +1,180 @@
+package directorymonitor
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/shirou/gopsutil/v4/disk"
+	"go.viam.com/rdk/components/sensor"
+	"go.viam.com/rdk/logging"
+	"go.viam.com/rdk/resource"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+var (
+	Model       = resource.NewModel(utils.Namespace, "hwmonitor", "directory_monitor")
+	API         = sensor.API
+	PrettyName  = "Directory Size Monitor"
+	Description = "A sensor that reports the size and growth rate of directories such as /var/log and /tmp"
+	Version     = utils.Version
+
+	defaultDirectories = []string{"/var/log", "/tmp"}
+)
+
+const defaultMaxEntriesPerSec = 2000
+
+type Config struct {
+	resource.Named
+	configLock       sync.Mutex
+	readingsLock     sync.RWMutex
+	logger           logging.Logger
+	directories      []string
+	sleepTime        time.Duration
+	maxEntriesPerSec int
+	task             *utils.Task
+	currentReadings  map[string]interface{}
+	freshness        utils.Freshness
+}
+
+type scanResult struct {
+	size *dirSize
+	at   time.Time
+}
+
+func init() {
+	resource.RegisterComponent(
+		API,
+		Model,
+		resource.Registration[sensor.Sensor, *ComponentConfig]{Constructor: utils.FilterReadings(NewSensor)})
+	utils.RegisterSimulator(Model, simulate)
+}
+
+func NewSensor(ctx context.Context, deps resource.Dependencies, conf resource.Config, logger logging.Logger) (sensor.Sensor, error) {
+	logger.Infof("Starting %s %s", PrettyName, Version)
+	b := Config{
+		Named:           conf.ResourceName().AsNamed(),
+		logger:          logger,
+		currentReadings: make(map[string]interface{}),
+	}
+
+	if err := b.Reconfigure(ctx, deps, conf); err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
+
+func (c *Config) Reconfigure(ctx context.Context, _ resource.Dependencies, rawConf resource.Config) error {
+	c.configLock.Lock()
+	defer c.configLock.Unlock()
+	c.logger.Debugf("Reconfiguring %s", PrettyName)
+
+	if c.task != nil {
+		c.logger.Debug("Stopping polling")
+		c.task.Stop()
+		c.logger.Debugf("Polling stopped")
+	}
+
+	conf, err := resource.NativeConfig[*ComponentConfig](rawConf)
+	if err != nil {
+		return err
+	}
+
+	// In case the component has changed name
+	c.Named = rawConf.ResourceName().AsNamed()
+	c.directories = conf.Directories
+	if len(c.directories) == 0 {
+		c.directories = defaultDirectories
+	}
+	if conf.SleepTimeMs <= 0 {
+		// Scanning is expensive, default to once a minute
+		conf.SleepTimeMs = 60000
+	}
+	c.sleepTime = time.Duration(conf.SleepTimeMs * int(time.Millisecond))
+	c.maxEntriesPerSec = conf.MaxEntriesPerSec
+	if c.maxEntriesPerSec == 0 {
+		c.maxEntriesPerSec = defaultMaxEntriesPerSec
+	}
+	previous := make(map[string]scanResult)
+	c.task = utils.Schedule(c.sleepTime, func(ctx context.Context) {
+		c.scan(ctx, previous)
+	})
+	return nil
+}
+
+func (c *Config) Readings(ctx context.Context, extra map[string]interface{}) (map[string]interface{}, error) {
+	c.readingsLock.RLock()
+	defer c.readingsLock.RUnlock()
+	// Stamp returns a copy, callers get a map of their own rather than the one the next scan replaces
+	return c.freshness.Stamp(c.currentReadings, c.sleepTime, time.Now()), nil
+}
+
+// scan measures the directories, previous is the last scan of each, which the growth is calculated from.
+func (c *Config) scan(ctx context.Context, previous map[string]scanResult) {
+	readings := make(map[string]interface{})
+	var scanErr error
+	scanned := 0
+	for _, dir := range c.directories {
+		size, err := scanDirectory(ctx, dir, c.maxEntriesPerSec)
+		if ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			c.logger.Warnf("Failed to scan %s: %v", dir, err)
+			scanErr = err
+			continue
+		}
+		scanned++
+		now := time.Now()
+		name := directoryName(dir)
+		readings[name+"_bytes"] = size.Bytes
+		readings[name+"_files"] = size.Files
+		if prev, ok := previous[dir]; ok {
+			growth := growthPerHour(prev.size.Bytes, size.Bytes, now.Sub(prev.at))
+			readings[name+"_growth_bytes_per_hour"] = growth
+			// tmpfs is backed by RAM, filling it takes memory from everything else
+			if usage, err := disk.UsageWithContext(ctx, dir); err == nil {
+				readings[name+"_filesystem_available"] = usage.Free
+				if growth > 0 {
+					readings[name+"_hours_until_full"] = utils.RoundValue(float64(usage.Free)/growth, 2)
+				}
+			}
+		}
+		previous[dir] = scanResult{size: size, at: now}
+	}
+	c.readingsLock.Lock()
+	c.currentReadings = readings
+	// The directories that could be scanned are still current
+	if scanned == 0 && scanErr != nil {
+		c.freshness.Failed(scanErr)
+	} else {
+		c.freshness.Succeeded(time.Now())
+	}
+	c.readingsLock.Unlock()
+}
+
+// directoryName converts a directory into a reading key prefix, e.g. /var/log becomes var_log.
+func directoryName(dir string) string {
+	name := strings.Trim(dir, "/")
+	if name == "" {
+		return "root"
+	}
+	return strings.NewReplacer("/", "_", " ", "_", "-", "_", ".", "_").Replace(name)
+}
+
+func (c *Config) Close(ctx context.Context) error {
+	c.configLock.Lock()
+	defer c.configLock.Unlock()
+	c.logger.Infof("Shutting down %s", PrettyName)
+	if c.task != nil {
+		c.task.Stop()
+	}
+	c.logger.Infof("%s Shutdown complete", PrettyName)
+	return nil
+}
+
+func (c *Config) Ready(ctx context.Context, extra map[string]interface{}) (bool, error) {
+	return false, nil
+}
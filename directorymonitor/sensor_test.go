@@ -0,0 +1,33 @@
+package directorymonitor
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadingsReturnsCopy(t *testing.T) {
+	root := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(root, "a.log"), make([]byte, 100), 0o644))
+	c := &Config{directories: []string{root}, maxEntriesPerSec: 1000, currentReadings: make(map[string]interface{})}
+	name := directoryName(root)
+	previous := make(map[string]scanResult)
+	c.scan(context.Background(), previous)
+
+	readings, err := c.Readings(context.Background(), nil)
+	require.NoError(t, err)
+	require.Equal(t, uint64(100), readings[name+"_bytes"])
+	readings[name+"_bytes"] = uint64(0)
+
+	// Neither the caller's changes nor the next scan show up in the other's map
+	require.NoError(t, os.WriteFile(filepath.Join(root, "b.log"), make([]byte, 50), 0o644))
+	c.scan(context.Background(), previous)
+	assert.Equal(t, uint64(0), readings[name+"_bytes"])
+	readings, err = c.Readings(context.Background(), nil)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(150), readings[name+"_bytes"])
+}
@@ -0,0 +1,25 @@
+package directorymonitor
+
+import "github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+
+// simulatedAvailable is the free space of the simulated board's root filesystem.
+const simulatedAvailable = 49e9
+
+// simulate returns the configured directories, growing as the board logs and faster when it's busy.
+func simulate(sim *utils.Simulation) map[string]interface{} {
+	directories := defaultDirectories
+	if conf, ok := sim.Config().(*ComponentConfig); ok && len(conf.Directories) > 0 {
+		directories = conf.Directories
+	}
+	ret := make(map[string]interface{})
+	for _, dir := range directories {
+		name := directoryName(dir)
+		growth := utils.RoundValue(sim.Value(2e5, 4e6, 4e6, 1e5), 0)
+		ret[name+"_bytes"] = sim.Counter(1.8e8, 2e5/3600, 4e6/3600, 4e6/3600)
+		ret[name+"_files"] = sim.Counter(340, 0, 0.001, 0.001)
+		ret[name+"_growth_bytes_per_hour"] = growth
+		ret[name+"_filesystem_available"] = uint64(simulatedAvailable)
+		ret[name+"_hours_until_full"] = utils.RoundValue(simulatedAvailable/growth, 2)
+	}
+	return sim.Stamp(ret)
+}
@@ -0,0 +1,26 @@
+package diskiomonitor
+
+import (
+	"path/filepath"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/linux"
+)
+
+type ComponentConfig struct {
+	Devices           []string `json:"devices"`            // Block devices to report, defaults to every disk in /sys/block
+	IncludePartitions bool     `json:"include_partitions"` // Also report partitions when devices is empty
+	Simulate          bool     `json:"simulate,omitempty"` // Skips checking the devices exist, see utils.SimulateAttribute
+}
+
+func (conf *ComponentConfig) Validate(path string) ([]string, error) {
+	if conf.Simulate {
+		return nil, nil
+	}
+	for _, device := range conf.Devices {
+		// Devices can be given as /dev/sda or sda
+		if err := linux.CheckBlockDevice(linux.BlockClassRoot, linux.BlockDevicesRoot, filepath.Base(device)); err != nil {
+			return nil, err
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,57 @@
+package diskiomonitor
+
+import (
+	"time"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/linux"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+type diskIORates struct {
+	ReadIOPS           float64
+	WriteIOPS          float64
+	ReadBytesPerSec    float64
+	WriteBytesPerSec   float64
+	ReadLatencyMs      float64
+	WriteLatencyMs     float64
+	AverageQueueDepth  float64
+	UtilizationPercent float64
+}
+
+// computeRates returns the activity of a device between two samples.
+// It returns false when the counters went backwards, e.g. because the device was removed and re-added.
+func computeRates(prev, cur linux.DiskStats, elapsed time.Duration) (*diskIORates, bool) {
+	if elapsed <= 0 || wentBackwards(prev, cur) {
+		return nil, false
+	}
+	seconds := elapsed.Seconds()
+	elapsedMs := float64(elapsed.Milliseconds())
+	reads := float64(cur.ReadsCompleted - prev.ReadsCompleted)
+	writes := float64(cur.WritesCompleted - prev.WritesCompleted)
+
+	rates := &diskIORates{
+		ReadIOPS:         utils.RoundValue(reads/seconds, 2),
+		WriteIOPS:        utils.RoundValue(writes/seconds, 2),
+		ReadBytesPerSec:  utils.RoundValue(float64(cur.SectorsRead-prev.SectorsRead)*linux.DiskSectorSize/seconds, 2),
+		WriteBytesPerSec: utils.RoundValue(float64(cur.SectorsWritten-prev.SectorsWritten)*linux.DiskSectorSize/seconds, 2),
+		// The weighted time grows by the number of in flight requests every millisecond
+		AverageQueueDepth:  utils.RoundValue(float64(cur.WeightedIOTimeMs-prev.WeightedIOTimeMs)/elapsedMs, 2),
+		UtilizationPercent: utils.RoundValue(min(float64(cur.IOTimeMs-prev.IOTimeMs)/elapsedMs*100, 100), 2),
+	}
+	if reads > 0 {
+		rates.ReadLatencyMs = utils.RoundValue(float64(cur.ReadTimeMs-prev.ReadTimeMs)/reads, 2)
+	}
+	if writes > 0 {
+		rates.WriteLatencyMs = utils.RoundValue(float64(cur.WriteTimeMs-prev.WriteTimeMs)/writes, 2)
+	}
+	return rates, true
+}
+
+// wentBackwards reports whether any of the counters the rates are computed from is lower than in the previous sample,
+// subtracting them would wrap around to a huge rate.
+func wentBackwards(prev, cur linux.DiskStats) bool {
+	return cur.ReadsCompleted < prev.ReadsCompleted || cur.WritesCompleted < prev.WritesCompleted ||
+		cur.SectorsRead < prev.SectorsRead || cur.SectorsWritten < prev.SectorsWritten ||
+		cur.ReadTimeMs < prev.ReadTimeMs || cur.WriteTimeMs < prev.WriteTimeMs ||
+		cur.IOTimeMs < prev.IOTimeMs || cur.WeightedIOTimeMs < prev.WeightedIOTimeMs
+}
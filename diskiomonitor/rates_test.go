@@ -0,0 +1,61 @@
+package diskiomonitor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/linux"
+)
+
+func TestComputeRates(t *testing.T) {
+	prev := linux.DiskStats{ReadsCompleted: 100, SectorsRead: 1000, ReadTimeMs: 500, WritesCompleted: 50, SectorsWritten: 800, WriteTimeMs: 200, IOTimeMs: 1000, WeightedIOTimeMs: 3000}
+	cur := linux.DiskStats{ReadsCompleted: 120, SectorsRead: 1400, ReadTimeMs: 600, WritesCompleted: 60, SectorsWritten: 2800, WriteTimeMs: 1200, InFlight: 3, IOTimeMs: 1500, WeightedIOTimeMs: 4000}
+
+	rates, ok := computeRates(prev, cur, 2*time.Second)
+	require.True(t, ok)
+	assert.Equal(t, 10.0, rates.ReadIOPS)
+	assert.Equal(t, 5.0, rates.WriteIOPS)
+	assert.Equal(t, 102400.0, rates.ReadBytesPerSec)
+	assert.Equal(t, 512000.0, rates.WriteBytesPerSec)
+	assert.Equal(t, 5.0, rates.ReadLatencyMs)
+	assert.Equal(t, 100.0, rates.WriteLatencyMs)
+	assert.Equal(t, 0.5, rates.AverageQueueDepth)
+	assert.Equal(t, 25.0, rates.UtilizationPercent)
+}
+
+func TestComputeRatesIdleDevice(t *testing.T) {
+	stats := linux.DiskStats{ReadsCompleted: 100, ReadTimeMs: 500}
+	rates, ok := computeRates(stats, stats, time.Second)
+	require.True(t, ok)
+	assert.Equal(t, 0.0, rates.ReadIOPS)
+	assert.Equal(t, 0.0, rates.ReadLatencyMs)
+}
+
+func TestComputeRatesCounterReset(t *testing.T) {
+	_, ok := computeRates(linux.DiskStats{ReadsCompleted: 100}, linux.DiskStats{ReadsCompleted: 5}, time.Second)
+	assert.False(t, ok)
+}
+
+func TestComputeRatesSkipsAnyCounterGoingBackwards(t *testing.T) {
+	prev := linux.DiskStats{ReadsCompleted: 100, SectorsRead: 1000, ReadTimeMs: 500, WritesCompleted: 50, SectorsWritten: 800, WriteTimeMs: 200, IOTimeMs: 1000, WeightedIOTimeMs: 3000}
+	for name, reset := range map[string]func(*linux.DiskStats){
+		"sectors read":    func(s *linux.DiskStats) { s.SectorsRead = 10 },
+		"sectors written": func(s *linux.DiskStats) { s.SectorsWritten = 10 },
+		"read time":       func(s *linux.DiskStats) { s.ReadTimeMs = 10 },
+		"write time":      func(s *linux.DiskStats) { s.WriteTimeMs = 10 },
+		"weighted time":   func(s *linux.DiskStats) { s.WeightedIOTimeMs = 10 },
+	} {
+		t.Run(name, func(t *testing.T) {
+			cur := prev
+			cur.ReadsCompleted += 10
+			cur.WritesCompleted += 10
+			reset(&cur)
+			rates, ok := computeRates(prev, cur, time.Second)
+			assert.False(t, ok)
+			assert.Nil(t, rates)
+		})
+	}
+}
@@ -0,0 +1,155 @@
+package diskiomonitor
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"go.viam.com/rdk/components/sensor"
+	"go.viam.com/rdk/logging"
+	"go.viam.com/rdk/resource"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/linux"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+var (
+	Model       = resource.NewModel(utils.Namespace, "hwmonitor", "disk_io_monitor")
+	API         = sensor.API
+	PrettyName  = "Disk IO Monitor"
+	Description = "A sensor that reports IOPS, throughput, latency and queue depth of block devices"
+	Version     = utils.Version
+)
+
+type Config struct {
+	resource.Named
+	mu                sync.RWMutex
+	logger            logging.Logger
+	cancelCtx         context.Context
+	cancelFunc        func()
+	devices           []string
+	includePartitions bool
+	lastStats         map[string]linux.DiskStats
+	lastTime          time.Time
+}
+
+func init() {
+	resource.RegisterComponent(
+		API,
+		Model,
+		resource.Registration[sensor.Sensor, *ComponentConfig]{Constructor: utils.FilterReadings(NewSensor)})
+	utils.RegisterSimulator(Model, simulate)
+}
+
+func NewSensor(ctx context.Context, deps resource.Dependencies, conf resource.Config, logger logging.Logger) (sensor.Sensor, error) {
+	logger.Infof("Starting %s %s", PrettyName, Version)
+	cancelCtx, cancelFunc := context.WithCancel(context.Background())
+
+	b := Config{
+		Named:      conf.ResourceName().AsNamed(),
+		logger:     logger,
+		cancelCtx:  cancelCtx,
+		cancelFunc: cancelFunc,
+		mu:         sync.RWMutex{},
+	}
+
+	if err := b.Reconfigure(ctx, deps, conf); err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
+
+func (c *Config) Reconfigure(ctx context.Context, _ resource.Dependencies, conf resource.Config) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.logger.Debugf("Reconfiguring %s", PrettyName)
+
+	newConf, err := resource.NativeConfig[*ComponentConfig](conf)
+	if err != nil {
+		return err
+	}
+	c.devices = make([]string, 0, len(newConf.Devices))
+	for _, device := range newConf.Devices {
+		// Accept both "sda" and "/dev/sda"
+		c.devices = append(c.devices, filepath.Base(device))
+	}
+	c.includePartitions = newConf.IncludePartitions
+	// The previous sample is kept so the first reading after a reconfigure still has rates
+
+	// In case the module has changed name
+	c.Named = conf.ResourceName().AsNamed()
+
+	return nil
+}
+
+func (c *Config) Readings(ctx context.Context, extra map[string]interface{}) (map[string]interface{}, error) {
+	// The previous sample is updated on every reading, so this needs the write lock
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	stats, err := linux.ReadDiskstats(ctx)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	lastStats, lastTime := c.lastStats, c.lastTime
+	c.lastStats, c.lastTime = stats, now
+
+	ret := make(map[string]interface{})
+	for name, cur := range stats {
+		if !c.shouldReport(name) {
+			continue
+		}
+		ret[name+"_in_flight"] = cur.InFlight
+		prev, ok := lastStats[name]
+		if !ok {
+			// Rates need two samples, the first reading after startup or hotplug only has the queue depth
+			continue
+		}
+		rates, ok := computeRates(prev, cur, now.Sub(lastTime))
+		if !ok {
+			c.logger.Debugf("Counters for %s went backwards, skipping rates", name)
+			continue
+		}
+		ret[name+"_read_iops"] = rates.ReadIOPS
+		ret[name+"_write_iops"] = rates.WriteIOPS
+		ret[name+"_read_bytes_per_sec"] = rates.ReadBytesPerSec
+		ret[name+"_write_bytes_per_sec"] = rates.WriteBytesPerSec
+		ret[name+"_read_latency_ms"] = rates.ReadLatencyMs
+		ret[name+"_write_latency_ms"] = rates.WriteLatencyMs
+		ret[name+"_avg_queue_depth"] = rates.AverageQueueDepth
+		ret[name+"_utilization_percent"] = rates.UtilizationPercent
+	}
+	return ret, nil
+}
+
+func (c *Config) shouldReport(device string) bool {
+	if len(c.devices) > 0 {
+		for _, d := range c.devices {
+			if d == device {
+				return true
+			}
+		}
+		return false
+	}
+	// ram and loop devices are always idle or mirror another device, they'd just add noise
+	if strings.HasPrefix(device, "ram") || strings.HasPrefix(device, "loop") {
+		return false
+	}
+	if c.includePartitions {
+		return true
+	}
+	// Whole disks are listed in /sys/block, partitions only live under their parent disk
+	_, err := utils.Stat(filepath.Join("/sys/block", device))
+	return err == nil
+}
+
+func (c *Config) Close(ctx context.Context) error {
+	c.logger.Infof("Shutting down %s", PrettyName)
+	return nil
+}
+
+func (c *Config) Ready(ctx context.Context, extra map[string]interface{}) (bool, error) {
+	return false, nil
+}
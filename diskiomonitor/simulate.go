@@ -0,0 +1,21 @@
+package diskiomonitor
+
+import "github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+
+// simulatedDisk is the SD card the simulated board boots from
+const simulatedDisk = "mmcblk0"
+
+// simulate returns the activity of the SD card, which is mostly writes from logging when idle.
+func simulate(sim *utils.Simulation) map[string]interface{} {
+	return map[string]interface{}{
+		simulatedDisk + "_in_flight":           uint64(sim.Level(0, 2, 3)),
+		simulatedDisk + "_read_iops":           sim.Value(0.2, 45, 45, 10),
+		simulatedDisk + "_write_iops":          sim.Value(1.5, 80, 80, 15),
+		simulatedDisk + "_read_bytes_per_sec":  sim.Value(4e3, 5.2e6, 5.2e6, 1e6),
+		simulatedDisk + "_write_bytes_per_sec": sim.Value(2.4e4, 8.5e6, 8.5e6, 2e6),
+		simulatedDisk + "_read_latency_ms":     sim.Value(0.8, 2.1, 2.3, 0.4),
+		simulatedDisk + "_write_latency_ms":    sim.Value(3.5, 12, 14, 3),
+		simulatedDisk + "_avg_queue_depth":     sim.Value(0.01, 1.4, 1.6, 0.3),
+		simulatedDisk + "_utilization_percent": sim.Percent(0.5, 62, 70, 10),
+	}
+}
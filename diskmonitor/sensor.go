@@ -36,7 +36,8 @@ func init() {
 	resource.RegisterComponent(
 		API,
 		Model,
-		resource.Registration[sensor.Sensor, *ComponentConfig]{Constructor: NewSensor})
+		resource.Registration[sensor.Sensor, *ComponentConfig]{Constructor: utils.FilterReadings(NewSensor)})
+	utils.RegisterSimulator(Model, simulate)
 }
 
 func NewSensor(ctx context.Context, deps resource.Dependencies, conf resource.Config, logger logging.Logger) (sensor.Sensor, error) {
@@ -83,6 +84,7 @@ func (c *Config) Readings(ctx context.Context, extra map[string]interface{}) (ma
 	c.mu.RLock()
 	defer c.mu.RUnlock()
 	ret := make(map[string]interface{})
+	errs := make(utils.ReadingErrors)
 	if c.includeIOCounters {
 		devices := make([]string, 0)
 		for _, d := range c.disks {
@@ -90,7 +92,7 @@ func (c *Config) Readings(ctx context.Context, extra map[string]interface{}) (ma
 		}
 		iocounters, err := disk.IOCountersWithContext(ctx, devices...)
 		if err != nil {
-			return nil, err
+			errs.Add("io_counters", err)
 		}
 
 		for name, ioc := range iocounters {
@@ -112,18 +114,24 @@ func (c *Config) Readings(ctx context.Context, extra map[string]interface{}) (ma
 	for _, d := range c.disks {
 		name := d.Device
 
+		// A disk that's been unmounted or removed doesn't hide the others
 		usage, err := disk.UsageWithContext(ctx, d.Mountpoint)
 		if err != nil {
-			return nil, err
+			errs.Add(name, err)
+			continue
 		}
 
 		ret[name+"_total"] = usage.Total
 		ret[name+"_used"] = usage.Used
 		ret[name+"_free"] = usage.Free
 		ret[name+"_used_percent"] = math.Round(usage.UsedPercent*100) / 100
+		ret[name+"_inodes_total"] = usage.InodesTotal
+		ret[name+"_inodes_used"] = usage.InodesUsed
+		ret[name+"_inodes_free"] = usage.InodesFree
+		ret[name+"_inodes_used_percent"] = math.Round(usage.InodesUsedPercent*100) / 100
 	}
 
-	return ret, nil
+	return errs.Readings(ret)
 }
 
 func (c *Config) Close(ctx context.Context) error {
@@ -52,9 +52,9 @@ func TestGetDiskInfo(t *testing.T) {
 			assert.NotNil(t, readings)
 			assert.NotEmpty(t, readings)
 			if tt.includeIOCounters {
-				assert.Len(t, readings, len(parts)*17)
+				assert.Len(t, readings, len(parts)*21)
 			} else {
-				assert.Len(t, readings, len(parts)*4)
+				assert.Len(t, readings, len(parts)*8)
 			}
 			for k, v := range readings {
 				logger.Infof("%v: %v", k, v)
@@ -0,0 +1,30 @@
+package diskmonitor
+
+import (
+	"math"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+const (
+	// The simulated board boots from a 64GB SD card
+	simulatedDisk   = "/dev/mmcblk0p2"
+	simulatedSize   = 62453055488
+	simulatedInodes = 3872768
+)
+
+// simulate returns the usage of the root filesystem, which fills slowly as the board logs.
+func simulate(sim *utils.Simulation) map[string]interface{} {
+	used := sim.Counter(9.8e9, 50, 5e3, 5e3)
+	inodesUsed := sim.Counter(221000, 0, 0.01, 0.01)
+	return map[string]interface{}{
+		simulatedDisk + "_total":               uint64(simulatedSize),
+		simulatedDisk + "_used":                used,
+		simulatedDisk + "_free":                simulatedSize - used,
+		simulatedDisk + "_used_percent":        math.Round(float64(used)/simulatedSize*10000) / 100,
+		simulatedDisk + "_inodes_total":        uint64(simulatedInodes),
+		simulatedDisk + "_inodes_used":         inodesUsed,
+		simulatedDisk + "_inodes_free":         simulatedInodes - inodesUsed,
+		simulatedDisk + "_inodes_used_percent": math.Round(float64(inodesUsed)/simulatedInodes*10000) / 100,
+	}
+}
@@ -0,0 +1,9 @@
+package displaymonitor
+
+type ComponentConfig struct {
+	Required []string `json:"required,omitempty"` // Connectors that must have a display attached, e.g. HDMI-A-1
+}
+
+func (conf *ComponentConfig) Validate(path string) ([]string, error) {
+	return nil, nil
+}
@@ -0,0 +1,171 @@
+package displaymonitor
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"go.viam.com/rdk/components/sensor"
+	"go.viam.com/rdk/logging"
+	"go.viam.com/rdk/resource"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/linux"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+var (
+	Model       = resource.NewModel(utils.Namespace, "hwmonitor", "display_monitor")
+	API         = sensor.API
+	PrettyName  = "Display Monitor"
+	Description = "A sensor that reports whether displays are connected to the HDMI and other display connectors, and which monitor and resolution they are"
+	Version     = utils.Version
+)
+
+type Config struct {
+	resource.Named
+	mu         sync.RWMutex
+	logger     logging.Logger
+	cancelCtx  context.Context
+	cancelFunc func()
+	required   []string
+	// missing is the last missing list that was logged, so each change is only logged once
+	missing string
+}
+
+func init() {
+	resource.RegisterComponent(
+		API,
+		Model,
+		resource.Registration[sensor.Sensor, *ComponentConfig]{Constructor: utils.FilterReadings(NewSensor)})
+	utils.RegisterSimulator(Model, simulate)
+}
+
+func NewSensor(ctx context.Context, deps resource.Dependencies, conf resource.Config, logger logging.Logger) (sensor.Sensor, error) {
+	logger.Infof("Starting %s %s", PrettyName, Version)
+	cancelCtx, cancelFunc := context.WithCancel(context.Background())
+
+	b := Config{
+		Named:      conf.ResourceName().AsNamed(),
+		logger:     logger,
+		cancelCtx:  cancelCtx,
+		cancelFunc: cancelFunc,
+		mu:         sync.RWMutex{},
+	}
+
+	if err := b.Reconfigure(ctx, deps, conf); err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
+
+func (c *Config) Reconfigure(ctx context.Context, _ resource.Dependencies, rawConf resource.Config) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.logger.Debugf("Reconfiguring %s", PrettyName)
+
+	conf, err := resource.NativeConfig[*ComponentConfig](rawConf)
+	if err != nil {
+		return err
+	}
+
+	// In case the module has changed name
+	c.Named = rawConf.ResourceName().AsNamed()
+	c.required = conf.Required
+	c.missing = ""
+
+	return nil
+}
+
+func (c *Config) Readings(ctx context.Context, extra map[string]interface{}) (map[string]interface{}, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	connectors, err := linux.ReadDRMConnectors(ctx, linux.DRMClassRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	ret := make(map[string]interface{})
+	keys := connectorKeys(connectors)
+	connected := make(map[string]bool)
+	for i, connector := range connectors {
+		key := keys[i]
+		isConnected := connector.Status == "connected"
+		ret[key+"_status"] = connector.Status
+		ret[key+"_connected"] = isConnected
+		ret[key+"_enabled"] = connector.Enabled
+		if isConnected {
+			connected[connector.Name] = true
+		}
+		if resolution := resolution(connector); resolution != "" {
+			ret[key+"_resolution"] = resolution
+		}
+		if connector.EDID != nil {
+			ret[key+"_monitor_name"] = connector.EDID.MonitorName
+			ret[key+"_manufacturer"] = connector.EDID.Manufacturer
+			ret[key+"_serial"] = connector.EDID.Serial
+		}
+	}
+	ret["connector_count"] = len(connectors)
+	ret["connected_count"] = len(connected)
+	if len(c.required) == 0 {
+		return ret, nil
+	}
+
+	missingList := make([]string, 0)
+	for _, name := range c.required {
+		if !connected[name] {
+			missingList = append(missingList, name)
+		}
+	}
+	sort.Strings(missingList)
+	missing := strings.Join(missingList, ",")
+	ret["missing"] = missing
+	ret["all_required_connected"] = missing == ""
+	if missing != c.missing && missing != "" {
+		c.logger.Warnf("Required displays are not connected: %s", missing)
+	}
+	c.missing = missing
+	return ret, nil
+}
+
+// connectorKeys returns the reading key prefix for each connector, e.g. HDMI-A-1 becomes hdmi_a_1. The card is only
+// included when two cards have a connector of the same name, because the card numbers aren't stable across boots.
+func connectorKeys(connectors []linux.DRMConnector) []string {
+	counts := make(map[string]int)
+	for _, connector := range connectors {
+		counts[connector.Name]++
+	}
+	keys := make([]string, 0, len(connectors))
+	for _, connector := range connectors {
+		key := strings.ToLower(strings.ReplaceAll(connector.Name, "-", "_"))
+		if counts[connector.Name] > 1 {
+			key = fmt.Sprintf("%s_%s", connector.Card, key)
+		}
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// resolution returns the display's native resolution from its EDID, falling back to its preferred mode for panels
+// without one.
+func resolution(connector linux.DRMConnector) string {
+	if connector.EDID != nil && connector.EDID.Width > 0 {
+		return fmt.Sprintf("%dx%d", connector.EDID.Width, connector.EDID.Height)
+	}
+	if connector.Status != "disconnected" && len(connector.Modes) > 0 {
+		return connector.Modes[0]
+	}
+	return ""
+}
+
+func (c *Config) Close(ctx context.Context) error {
+	c.logger.Infof("Shutting down %s", PrettyName)
+	c.cancelFunc()
+	return nil
+}
+
+func (c *Config) Ready(ctx context.Context, extra map[string]interface{}) (bool, error) {
+	return false, nil
+}
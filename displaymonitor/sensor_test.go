@@ -0,0 +1,30 @@
+package displaymonitor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/linux"
+)
+
+func TestConnectorKeys(t *testing.T) {
+	assert.Equal(t, []string{"dsi_1", "hdmi_a_1"}, connectorKeys([]linux.DRMConnector{
+		{Card: "card1", Name: "DSI-1"},
+		{Card: "card1", Name: "HDMI-A-1"},
+	}))
+	assert.Equal(t, []string{"card0_hdmi_a_1", "card1_hdmi_a_1"}, connectorKeys([]linux.DRMConnector{
+		{Card: "card0", Name: "HDMI-A-1"},
+		{Card: "card1", Name: "HDMI-A-1"},
+	}))
+}
+
+func TestResolution(t *testing.T) {
+	assert.Equal(t, "1920x1200", resolution(linux.DRMConnector{
+		Status: "connected",
+		Modes:  []string{"1920x1080"},
+		EDID:   &linux.EDID{Width: 1920, Height: 1200},
+	}))
+	assert.Equal(t, "800x480", resolution(linux.DRMConnector{Status: "unknown", Modes: []string{"800x480"}}))
+	assert.Equal(t, "", resolution(linux.DRMConnector{Status: "disconnected", Modes: []string{"1920x1080"}}))
+}
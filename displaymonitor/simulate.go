@@ -0,0 +1,41 @@
+package displaymonitor
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+// simulate returns a Raspberry Pi 5 with a monitor on its first HDMI port and nothing on its second.
+func simulate(sim *utils.Simulation) map[string]interface{} {
+	ret := map[string]interface{}{
+		"hdmi_a_1_status":       "connected",
+		"hdmi_a_1_connected":    true,
+		"hdmi_a_1_enabled":      true,
+		"hdmi_a_1_resolution":   "1920x1080",
+		"hdmi_a_1_monitor_name": "DELL P2419H",
+		"hdmi_a_1_manufacturer": "DEL",
+		"hdmi_a_1_serial":       "5KXJ8T2",
+		"hdmi_a_2_status":       "disconnected",
+		"hdmi_a_2_connected":    false,
+		"hdmi_a_2_enabled":      false,
+		"connector_count":       2,
+		"connected_count":       1,
+	}
+	conf, ok := sim.Config().(*ComponentConfig)
+	if !ok || len(conf.Required) == 0 {
+		return ret
+	}
+	missingList := make([]string, 0)
+	for _, name := range conf.Required {
+		if name != "HDMI-A-1" {
+			missingList = append(missingList, name)
+		}
+	}
+	sort.Strings(missingList)
+	missing := strings.Join(missingList, ",")
+	ret["missing"] = missing
+	ret["all_required_connected"] = missing == ""
+	return ret
+}
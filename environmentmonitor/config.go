@@ -0,0 +1,81 @@
+package environmentmonitor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/linux"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/linux/envsensor"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+// devRoot is where the I2C buses are checked for, tests point it elsewhere
+var devRoot = "/dev"
+
+type ComponentConfig struct {
+	Devices  []DeviceConfig `json:"devices"`
+	Simulate bool           `json:"simulate,omitempty"` // Skips checking the buses exist, see utils.SimulateAttribute
+}
+
+type DeviceConfig struct {
+	Name    string `json:"name"`              // Used as the reading key
+	Type    string `json:"type"`              // bme280, sht3x or sgp30
+	Bus     int    `json:"bus"`               // The N in /dev/i2c-N
+	Address string `json:"address,omitempty"` // Defaults to the sensor's default address, e.g. 0x76 for the BME280
+}
+
+func (d *DeviceConfig) address() (uint16, error) {
+	if d.Address == "" {
+		return envsensor.DefaultAddresses[d.Type], nil
+	}
+	address, err := strconv.ParseUint(d.Address, 0, 16)
+	if err != nil || address < 0x08 || address > 0x77 {
+		return 0, fmt.Errorf("device %s has an invalid address %q, expected e.g. 0x76", d.Name, d.Address)
+	}
+	return uint16(address), nil
+}
+
+func (conf *ComponentConfig) Validate(path string) ([]string, error) {
+	if len(conf.Devices) == 0 {
+		return nil, errors.New("at least one device is required")
+	}
+	names := make(map[string]bool)
+	for _, device := range conf.Devices {
+		if device.Name == "" {
+			return nil, errors.New("every device requires a name")
+		}
+		if names[device.Name] {
+			return nil, fmt.Errorf("duplicate device name: %s", device.Name)
+		}
+		names[device.Name] = true
+		if _, ok := envsensor.DefaultAddresses[device.Type]; !ok {
+			return nil, fmt.Errorf("device %s has an unknown type %s, expected bme280, sht3x or sgp30", device.Name, device.Type)
+		}
+		if device.Bus < 0 {
+			return nil, fmt.Errorf("device %s bus must not be negative", device.Name)
+		}
+		if _, err := device.address(); err != nil {
+			return nil, err
+		}
+	}
+	if conf.Simulate {
+		return nil, nil
+	}
+	for _, device := range conf.Devices {
+		if err := linux.CheckI2CBus(devRoot, device.Bus); err != nil {
+			return nil, fmt.Errorf("device %s: %w", device.Name, err)
+		}
+	}
+	return nil, nil
+}
+
+// Permissions implements utils.PermissionReporter, the buses need the i2c group.
+func (conf *ComponentConfig) Permissions(ctx context.Context) utils.Permissions {
+	buses := make([]int, 0, len(conf.Devices))
+	for _, device := range conf.Devices {
+		buses = append(buses, device.Bus)
+	}
+	return linux.I2CPermissions(buses)
+}
@@ -0,0 +1,42 @@
+package environmentmonitor
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidate(t *testing.T) {
+	oldDevRoot := devRoot
+	defer func() { devRoot = oldDevRoot }()
+	devRoot = t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(devRoot, "i2c-1"), nil, 0o600))
+
+	conf := &ComponentConfig{Devices: []DeviceConfig{
+		{Name: "enclosure", Type: "bme280", Bus: 1},
+		{Name: "battery", Type: "sht3x", Bus: 1, Address: "0x45"},
+		{Name: "air", Type: "sgp30", Bus: 1},
+	}}
+	_, err := conf.Validate("")
+	require.NoError(t, err)
+	address, err := conf.Devices[0].address()
+	require.NoError(t, err)
+	assert.Equal(t, uint16(0x76), address)
+
+	invalid := []*ComponentConfig{
+		{},
+		{Devices: []DeviceConfig{{Type: "bme280", Bus: 1}}},
+		{Devices: []DeviceConfig{{Name: "enclosure", Type: "bmp180", Bus: 1}}},
+		{Devices: []DeviceConfig{{Name: "enclosure", Type: "bme280", Bus: 1, Address: "0x80"}}},
+		{Devices: []DeviceConfig{{Name: "enclosure", Type: "bme280"}, {Name: "enclosure", Type: "sht3x"}}},
+		// The bus isn't enabled
+		{Devices: []DeviceConfig{{Name: "enclosure", Type: "bme280", Bus: 2}}},
+	}
+	for _, conf := range invalid {
+		_, err := conf.Validate("")
+		assert.Error(t, err)
+	}
+}
@@ -0,0 +1,199 @@
+package environmentmonitor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.viam.com/rdk/components/sensor"
+	"go.viam.com/rdk/logging"
+	"go.viam.com/rdk/resource"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/linux"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/linux/envsensor"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+var (
+	Model       = resource.NewModel(utils.Namespace, "hwmonitor", "environment_monitor")
+	API         = sensor.API
+	PrettyName  = "Enclosure Environment Monitor"
+	Description = "A sensor that reports temperature, humidity, pressure and VOCs from BME280, SHT3x and SGP30 sensors over I2C"
+	Version     = utils.Version
+)
+
+// The SGP30 has to be measured once a second for its baseline compensation, the other sensors are measured along
+// with it
+const measureInterval = time.Second
+
+type device struct {
+	name   string
+	i2c    *linux.I2CDevice
+	sensor envsensor.Sensor
+}
+
+type Config struct {
+	resource.Named
+	configLock   sync.Mutex
+	readingsLock sync.RWMutex
+	logger       logging.Logger
+	devices      []*device
+	task         *utils.Task
+	// failing is the devices whose last measurement failed, only the poll touches it
+	failing         map[string]bool
+	currentReadings map[string]interface{}
+	freshness       utils.Freshness
+}
+
+func init() {
+	resource.RegisterComponent(
+		API,
+		Model,
+		resource.Registration[sensor.Sensor, *ComponentConfig]{Constructor: utils.FilterReadings(NewSensor)})
+	utils.RegisterSimulator(Model, simulate)
+}
+
+func NewSensor(ctx context.Context, deps resource.Dependencies, conf resource.Config, logger logging.Logger) (sensor.Sensor, error) {
+	logger.Infof("Starting %s %s", PrettyName, Version)
+	b := Config{
+		Named:           conf.ResourceName().AsNamed(),
+		logger:          logger,
+		currentReadings: make(map[string]interface{}),
+	}
+
+	if err := b.Reconfigure(ctx, deps, conf); err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
+
+func (c *Config) Reconfigure(ctx context.Context, _ resource.Dependencies, rawConf resource.Config) error {
+	c.configLock.Lock()
+	defer c.configLock.Unlock()
+	c.logger.Debugf("Reconfiguring %s", PrettyName)
+
+	conf, err := resource.NativeConfig[*ComponentConfig](rawConf)
+	if err != nil {
+		return err
+	}
+
+	c.stop()
+
+	// In case the component has changed name
+	c.Named = rawConf.ResourceName().AsNamed()
+
+	devices := make([]*device, 0, len(conf.Devices))
+	for _, deviceConf := range conf.Devices {
+		d, err := openDevice(deviceConf)
+		if err != nil {
+			for _, d := range devices {
+				d.i2c.Close()
+			}
+			return err
+		}
+		devices = append(devices, d)
+	}
+	c.devices = devices
+	c.failing = make(map[string]bool)
+	c.task = utils.Schedule(measureInterval, func(ctx context.Context) {
+		c.measure(devices)
+	})
+	return nil
+}
+
+func openDevice(conf DeviceConfig) (*device, error) {
+	address, err := conf.address()
+	if err != nil {
+		return nil, err
+	}
+	i2c, err := linux.OpenI2CDevice(conf.Bus, address)
+	if err != nil {
+		if errors.Is(err, linux.ErrI2CAddressBusy) {
+			// e.g. the bme280 or sht3x kernel drivers, their readings are available from iio_monitor or hwmon
+			return nil, fmt.Errorf("device %s is bound to the %s kernel driver, use iio_monitor instead",
+				conf.Name, linux.I2CDriver(linux.I2CDevicesRoot, conf.Bus, address))
+		}
+		return nil, err
+	}
+	s, err := envsensor.New(conf.Type, i2c)
+	if err != nil {
+		i2c.Close()
+		return nil, fmt.Errorf("failed to initialize %s: %w", conf.Name, err)
+	}
+	return &device{name: conf.Name, i2c: i2c, sensor: s}, nil
+}
+
+func (c *Config) Readings(ctx context.Context, extra map[string]interface{}) (map[string]interface{}, error) {
+	c.readingsLock.RLock()
+	defer c.readingsLock.RUnlock()
+	return c.freshness.Stamp(c.currentReadings, measureInterval, time.Now()), nil
+}
+
+// measure measures every device, the scheduler calls it every measureInterval.
+func (c *Config) measure(devices []*device) {
+	readings := make(map[string]interface{})
+	var measureErr error
+	measured := 0
+	for _, d := range devices {
+		measurement, err := d.sensor.Measure()
+		if err != nil {
+			measureErr = fmt.Errorf("failed to measure %s: %w", d.name, err)
+			// Only log when a device starts failing, it is measured every second
+			if !c.failing[d.name] {
+				c.logger.Warnf("Failed to measure %s: %v", d.name, err)
+			}
+			c.failing[d.name] = true
+			continue
+		}
+		c.failing[d.name] = false
+		measured++
+		for key, value := range measurement {
+			readings[d.name+"_"+key] = utils.RoundValue(value, 2)
+		}
+		temperature, hasTemperature := measurement["temperature"]
+		humidity, hasHumidity := measurement["humidity"]
+		if hasTemperature && hasHumidity && humidity > 0 {
+			dewPoint := envsensor.DewPoint(temperature, humidity)
+			readings[d.name+"_dew_point"] = utils.RoundValue(dewPoint, 2)
+			// Condensation forms on surfaces at the dew point, a small margin means it is close
+			readings[d.name+"_dew_point_margin"] = utils.RoundValue(temperature-dewPoint, 2)
+		}
+	}
+
+	c.readingsLock.Lock()
+	c.currentReadings = readings
+	// The devices that could be measured are still current
+	if measured == 0 && measureErr != nil {
+		c.freshness.Failed(measureErr)
+	} else {
+		c.freshness.Succeeded(time.Now())
+	}
+	c.readingsLock.Unlock()
+}
+
+func (c *Config) stop() {
+	if c.task != nil {
+		c.logger.Debug("Stopping polling")
+		c.task.Stop()
+		c.task = nil
+	}
+	for _, d := range c.devices {
+		d.i2c.Close()
+	}
+	c.devices = nil
+}
+
+func (c *Config) Close(ctx context.Context) error {
+	c.configLock.Lock()
+	defer c.configLock.Unlock()
+	c.logger.Infof("Shutting down %s", PrettyName)
+	c.stop()
+	c.logger.Infof("%s Shutdown complete", PrettyName)
+	return nil
+}
+
+func (c *Config) Ready(ctx context.Context, extra map[string]interface{}) (bool, error) {
+	return false, nil
+}
@@ -0,0 +1,41 @@
+package environmentmonitor
+
+import (
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/linux/envsensor"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+// simulate returns the configured devices in an enclosure that warms up as the board does.
+func simulate(sim *utils.Simulation) map[string]interface{} {
+	ret := make(map[string]interface{})
+	conf, ok := sim.Config().(*ComponentConfig)
+	if !ok {
+		return sim.Stamp(ret)
+	}
+	temperature := sim.Value(28, 34, 41, 0.4)
+	humidity := sim.Value(45, 38, 31, 1)
+	for _, d := range conf.Devices {
+		measurement := make(map[string]float64)
+		switch d.Type {
+		case "bme280":
+			measurement["temperature"] = temperature
+			measurement["humidity"] = humidity
+			measurement["pressure"] = sim.Value(1013.2, 1013.2, 1013.2, 0.5)
+		case "sht3x":
+			measurement["temperature"] = temperature
+			measurement["humidity"] = humidity
+		case "sgp30":
+			measurement["eco2_ppm"] = float64(int(sim.Value(420, 450, 450, 20)))
+			measurement["tvoc_ppb"] = float64(int(sim.Value(15, 40, 60, 10)))
+		}
+		for key, value := range measurement {
+			ret[d.Name+"_"+key] = utils.RoundValue(value, 2)
+		}
+		if _, ok := measurement["humidity"]; ok {
+			dewPoint := envsensor.DewPoint(temperature, humidity)
+			ret[d.Name+"_dew_point"] = utils.RoundValue(dewPoint, 2)
+			ret[d.Name+"_dew_point_margin"] = utils.RoundValue(temperature-dewPoint, 2)
+		}
+	}
+	return sim.Stamp(ret)
+}
@@ -0,0 +1,23 @@
+package fanmonitor
+
+import (
+	"context"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/linux"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/linux/odroid"
+)
+
+func getFans(ctx context.Context) ([]linux.Fan, error) {
+	if linux.IsFamily(linux.BoardFamilyODROID) {
+		return odroid.GetFans(ctx)
+	}
+	return linux.ReadHwmonFans(ctx, linux.HwmonRoot)
+}
+
+// backend is what the fans are read from, ODROID boards report their fan outside of hwmon.
+func backend() string {
+	if linux.IsFamily(linux.BoardFamilyODROID) {
+		return "odroid"
+	}
+	return "hwmon"
+}
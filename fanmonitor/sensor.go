@@ -0,0 +1,112 @@
+package fanmonitor
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"go.viam.com/rdk/components/sensor"
+	"go.viam.com/rdk/logging"
+	"go.viam.com/rdk/resource"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+var (
+	Model       = resource.NewModel(utils.Namespace, "hwmonitor", "fan_monitor")
+	API         = sensor.API
+	PrettyName  = "SBC Fan Monitor"
+	Description = "A sensor that reports the speed and duty cycle of the fans on the board"
+	Version     = utils.Version
+)
+
+type Config struct {
+	resource.Named
+	mu         sync.RWMutex
+	logger     logging.Logger
+	cancelCtx  context.Context
+	cancelFunc func()
+}
+
+func init() {
+	resource.RegisterComponent(
+		API,
+		Model,
+		resource.Registration[sensor.Sensor, *ComponentConfig]{Constructor: utils.FilterReadings(NewSensor)})
+	utils.RegisterSimulator(Model, simulate)
+}
+
+func NewSensor(ctx context.Context, deps resource.Dependencies, conf resource.Config, logger logging.Logger) (sensor.Sensor, error) {
+	logger.Infof("Starting %s %s", PrettyName, Version)
+	cancelCtx, cancelFunc := context.WithCancel(context.Background())
+
+	b := Config{
+		Named:      conf.ResourceName().AsNamed(),
+		logger:     logger,
+		cancelCtx:  cancelCtx,
+		cancelFunc: cancelFunc,
+		mu:         sync.RWMutex{},
+	}
+
+	if err := b.Reconfigure(ctx, deps, conf); err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
+
+func (c *Config) Reconfigure(ctx context.Context, _ resource.Dependencies, conf resource.Config) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.logger.Debugf("Reconfiguring %s", PrettyName)
+
+	// In case the module has changed name
+	c.Named = conf.ResourceName().AsNamed()
+
+	return nil
+}
+
+func (c *Config) Readings(ctx context.Context, extra map[string]interface{}) (map[string]interface{}, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	fans, err := getFans(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ret := make(map[string]interface{})
+	for _, fan := range fans {
+		if fan.Label != "" {
+			ret[fan.Name+"_label"] = fan.Label
+		}
+		if fan.RPM != nil {
+			ret[fan.Name+"_rpm"] = *fan.RPM
+		}
+		if fan.Duty != nil {
+			ret[fan.Name+"_duty_percent"] = *fan.Duty
+		}
+		if fan.Mode != "" {
+			ret[fan.Name+"_mode"] = fan.Mode
+		}
+	}
+	ret["fan_count"] = len(fans)
+	return ret, nil
+}
+
+// Capabilities reports what the fans are read from, and when none were found.
+func (c *Config) Capabilities(ctx context.Context) utils.Capabilities {
+	caps := utils.Capabilities{Backend: backend(), Unavailable: make(map[string]string)}
+	if fans, err := getFans(ctx); err == nil && len(fans) == 0 {
+		caps.Unavailable["fans"] = fmt.Sprintf("no fans found by the %s backend", caps.Backend)
+	}
+	return caps
+}
+
+func (c *Config) Close(ctx context.Context) error {
+	c.logger.Infof("Shutting down %s", PrettyName)
+	c.cancelFunc()
+	return nil
+}
+
+func (c *Config) Ready(ctx context.Context, extra map[string]interface{}) (bool, error) {
+	return false, nil
+}
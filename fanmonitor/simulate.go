@@ -0,0 +1,13 @@
+package fanmonitor
+
+import "github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+
+// simulate returns the Raspberry Pi 5 active cooler, which speeds up with the temperature.
+func simulate(sim *utils.Simulation) map[string]interface{} {
+	return map[string]interface{}{
+		"pwmfan_rpm":          int64(sim.Value(0, 3300, 8100, 150)),
+		"pwmfan_duty_percent": sim.Level(0, 39.22, 100),
+		"pwmfan_mode":         "auto",
+		"fan_count":           1,
+	}
+}
@@ -0,0 +1,48 @@
+package filesink
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	formatCSV     = "csv"
+	formatParquet = "parquet"
+)
+
+type ComponentConfig struct {
+	Directory     string   `json:"directory"`                // Where the files are written, it must exist, e.g. a USB drive's mount point
+	RequireMount  bool     `json:"require_mount,omitempty"`  // Only write when directory is on its own mount, so an unmounted drive doesn't fill the root filesystem
+	Format        string   `json:"format,omitempty"`         // csv or parquet, defaults to csv
+	FilePrefix    string   `json:"file_prefix,omitempty"`    // Defaults to hwmonitor
+	Sensors       []string `json:"sensors"`                  // The sensors whose readings are written
+	IntervalSec   float64  `json:"interval_sec,omitempty"`   // How often the sensors are read, defaults to 10
+	RotateMinutes float64  `json:"rotate_minutes,omitempty"` // How long a file is written before starting the next, defaults to 60
+	MaxFileMB     float64  `json:"max_file_mb,omitempty"`    // A file is also rotated when it reaches this size, defaults to 16
+	MaxTotalMB    float64  `json:"max_total_mb,omitempty"`   // The oldest files are deleted beyond this, defaults to keeping every file
+}
+
+func (conf *ComponentConfig) Validate(path string) ([]string, error) {
+	if conf.Directory == "" {
+		return nil, errors.New("directory is required")
+	}
+	if !filepath.IsAbs(conf.Directory) {
+		return nil, errors.New("directory must be absolute")
+	}
+	switch conf.Format {
+	case "", formatCSV, formatParquet:
+	default:
+		return nil, errors.New("format must be csv or parquet")
+	}
+	if strings.ContainsAny(conf.FilePrefix, `/\`) {
+		return nil, errors.New("file_prefix must not contain a path separator")
+	}
+	if len(conf.Sensors) == 0 {
+		return nil, errors.New("at least one sensor is required")
+	}
+	if conf.IntervalSec < 0 || conf.RotateMinutes < 0 || conf.MaxFileMB < 0 || conf.MaxTotalMB < 0 {
+		return nil, errors.New("interval_sec, rotate_minutes, max_file_mb and max_total_mb must not be negative")
+	}
+	return conf.Sensors, nil
+}
@@ -0,0 +1,30 @@
+package filesink
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidate(t *testing.T) {
+	for _, conf := range []*ComponentConfig{
+		{Directory: "/media/usb", Sensors: []string{"cpu"}},
+		{Directory: "/media/usb/logs", RequireMount: true, Format: "parquet", FilePrefix: "robot1", Sensors: []string{"cpu"}, RotateMinutes: 1440, MaxTotalMB: 4096},
+	} {
+		deps, err := conf.Validate("")
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"cpu"}, deps)
+	}
+
+	for _, conf := range []*ComponentConfig{
+		{Sensors: []string{"cpu"}},
+		{Directory: "usb", Sensors: []string{"cpu"}},
+		{Directory: "/media/usb", Format: "json", Sensors: []string{"cpu"}},
+		{Directory: "/media/usb", FilePrefix: "../robot", Sensors: []string{"cpu"}},
+		{Directory: "/media/usb"},
+		{Directory: "/media/usb", Sensors: []string{"cpu"}, MaxFileMB: -1},
+	} {
+		_, err := conf.Validate("")
+		assert.Error(t, err, conf)
+	}
+}
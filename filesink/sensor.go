@@ -0,0 +1,252 @@
+package filesink
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/shirou/gopsutil/v4/disk"
+	"go.viam.com/rdk/components/sensor"
+	"go.viam.com/rdk/logging"
+	"go.viam.com/rdk/resource"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+var (
+	Model       = resource.NewModel(utils.Namespace, "hwmonitor", "file_sink")
+	API         = sensor.API
+	PrettyName  = "File Sink"
+	Description = "A sensor that appends the readings of other sensors to rotating CSV or Parquet files, e.g. on a USB drive"
+	Version     = utils.Version
+)
+
+const (
+	defaultFilePrefix    = "hwmonitor"
+	defaultIntervalSec   = 10
+	defaultRotateMinutes = 60
+	defaultMaxFileMB     = 16
+	readTimeout          = 5 * time.Second
+)
+
+type Config struct {
+	resource.Named
+	configLock   sync.Mutex
+	readingsLock sync.RWMutex
+	logger       logging.Logger
+	task         *utils.Task
+	rotator      *rotator
+	directory    string
+	format       string
+	currentFile  string
+	writeCount   int
+	failedCount  int
+	rowCount     int
+	lastWrite    time.Time
+	lastErr      error
+}
+
+func init() {
+	resource.RegisterComponent(
+		API,
+		Model,
+		resource.Registration[sensor.Sensor, *ComponentConfig]{Constructor: utils.FilterReadings(NewSensor)})
+}
+
+func NewSensor(ctx context.Context, deps resource.Dependencies, conf resource.Config, logger logging.Logger) (sensor.Sensor, error) {
+	logger.Infof("Starting %s %s", PrettyName, Version)
+	b := Config{
+		Named:  conf.ResourceName().AsNamed(),
+		logger: logger,
+	}
+
+	if err := b.Reconfigure(ctx, deps, conf); err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
+
+func (c *Config) Reconfigure(ctx context.Context, deps resource.Dependencies, rawConf resource.Config) error {
+	c.configLock.Lock()
+	defer c.configLock.Unlock()
+	c.logger.Debugf("Reconfiguring %s", PrettyName)
+
+	c.stopWriting()
+
+	conf, err := resource.NativeConfig[*ComponentConfig](rawConf)
+	if err != nil {
+		return err
+	}
+
+	// In case the component has changed name
+	c.Named = rawConf.ResourceName().AsNamed()
+
+	sources, err := utils.ResolveSources(deps, conf.Sensors)
+	if err != nil {
+		return err
+	}
+	r := &rotator{
+		dir:         filepath.Clean(conf.Directory),
+		prefix:      conf.FilePrefix,
+		format:      conf.Format,
+		rotateEvery: time.Duration(conf.RotateMinutes * float64(time.Minute)),
+		maxFile:     int64(conf.MaxFileMB * 1024 * 1024),
+		maxTotal:    int64(conf.MaxTotalMB * 1024 * 1024),
+	}
+	if r.prefix == "" {
+		r.prefix = defaultFilePrefix
+	}
+	if r.format == "" {
+		r.format = formatCSV
+	}
+	if r.rotateEvery == 0 {
+		r.rotateEvery = defaultRotateMinutes * time.Minute
+	}
+	if r.maxFile == 0 {
+		r.maxFile = defaultMaxFileMB * 1024 * 1024
+	}
+	intervalSec := conf.IntervalSec
+	if intervalSec == 0 {
+		intervalSec = defaultIntervalSec
+	}
+	interval := time.Duration(intervalSec * float64(time.Second))
+
+	c.readingsLock.Lock()
+	c.directory = r.dir
+	c.format = r.format
+	c.currentFile = ""
+	c.lastErr = nil
+	c.readingsLock.Unlock()
+
+	requireMount := conf.RequireMount
+	c.rotator = r
+	c.task = utils.ScheduleAligned(interval, utils.ReadingsSource, func(ctx context.Context) {
+		c.write(ctx, r, sources, requireMount)
+	})
+	return nil
+}
+
+// stopWriting stops polling and finishes the current file, so a Parquet file isn't lost on reconfigure or shutdown,
+// configLock must be held.
+func (c *Config) stopWriting() {
+	if c.task == nil {
+		return
+	}
+	c.logger.Debug("Stopping polling")
+	c.task.Stop()
+	if err := c.rotator.close(); err != nil {
+		c.logger.Warnf("Failed to finish the current file: %v", err)
+	}
+	c.task, c.rotator = nil, nil
+	c.logger.Debugf("Polling stopped")
+}
+
+// write appends a reading of every source to the current file, the scheduler calls it every interval.
+func (c *Config) write(ctx context.Context, r *rotator, sources []utils.Source, requireMount bool) {
+	now := time.Now()
+	var rows []row
+	var readErr error
+	for _, s := range sources {
+		readCtx, cancel := context.WithTimeout(ctx, readTimeout)
+		readings, err := s.Sensor.Readings(readCtx, nil)
+		cancel()
+		if ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			readErr = fmt.Errorf("failed to read %s: %w", s.Name, err)
+			continue
+		}
+		values := utils.NumericReadings(readings)
+		keys := make([]string, 0, len(values))
+		for key := range values {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			rows = append(rows, row{time: now, sensor: s.Name, key: key, value: values[key]})
+		}
+	}
+
+	err := checkDirectory(ctx, r.dir, requireMount)
+	if err == nil {
+		err = r.write(now, rows)
+	}
+	c.readingsLock.Lock()
+	if err != nil {
+		c.failedCount++
+		readErr = err
+	} else {
+		c.writeCount++
+		c.rowCount += len(rows)
+		c.lastWrite = now
+	}
+	c.currentFile = ""
+	if r.current != nil {
+		c.currentFile = r.currentPath
+	}
+	if readErr != nil && c.lastErr == nil {
+		c.logger.Warnf("Failed to write readings: %v", readErr)
+	} else if readErr == nil && c.lastErr != nil {
+		c.logger.Infof("Writing readings to %s again", r.dir)
+	}
+	c.lastErr = readErr
+	c.readingsLock.Unlock()
+}
+
+// checkDirectory makes sure that with requireMount dir is on its own mount, rather than an empty mount point on the
+// root filesystem left behind by a drive that was pulled.
+func checkDirectory(ctx context.Context, dir string, requireMount bool) error {
+	if !requireMount {
+		return nil
+	}
+	parts, err := disk.PartitionsWithContext(ctx, true)
+	if err != nil {
+		return fmt.Errorf("failed to read the mounts: %w", err)
+	}
+	mountpoints := make([]string, 0, len(parts))
+	for _, p := range parts {
+		mountpoints = append(mountpoints, p.Mountpoint)
+	}
+	if !onOwnMount(dir, mountpoints) {
+		return errors.New(dir + " isn't mounted")
+	}
+	return nil
+}
+
+func (c *Config) Readings(ctx context.Context, extra map[string]interface{}) (map[string]interface{}, error) {
+	c.readingsLock.RLock()
+	defer c.readingsLock.RUnlock()
+	ret := map[string]interface{}{
+		"directory":    c.directory,
+		"format":       c.format,
+		"current_file": c.currentFile,
+		"write_count":  c.writeCount,
+		"failed_count": c.failedCount,
+		"row_count":    c.rowCount,
+	}
+	if !c.lastWrite.IsZero() {
+		ret["last_write_time"] = c.lastWrite.Format(time.RFC3339)
+	}
+	if c.lastErr != nil {
+		ret["last_error"] = c.lastErr.Error()
+	}
+	return ret, nil
+}
+
+func (c *Config) Close(ctx context.Context) error {
+	c.configLock.Lock()
+	defer c.configLock.Unlock()
+	c.logger.Infof("Shutting down %s", PrettyName)
+	c.stopWriting()
+	c.logger.Infof("%s Shutdown complete", PrettyName)
+	return nil
+}
+
+func (c *Config) Ready(ctx context.Context, extra map[string]interface{}) (bool, error) {
+	return false, nil
+}
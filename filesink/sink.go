@@ -0,0 +1,316 @@
+package filesink
+
+import (
+	"bufio"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/parquet"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+// Times in file names sort in the order the files were written
+const fileTimeFormat = "20060102T150405Z"
+
+// row is one reading, files have one row per sensor and key so new keys don't change their columns.
+type row struct {
+	time   time.Time
+	sensor string
+	key    string
+	value  float64
+}
+
+type file interface {
+	write(rows []row) error
+	size() int64
+	close() error
+}
+
+// csvFile appends rows to a CSV file as they come, so everything written is readable even if the drive is pulled.
+type csvFile struct {
+	f       *os.File
+	w       *csv.Writer
+	counter *countingWriter
+}
+
+type countingWriter struct {
+	w *bufio.Writer
+	n int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
+
+func createCSV(path string) (*csvFile, error) {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	counter := &countingWriter{w: bufio.NewWriter(f)}
+	c := &csvFile{f: f, w: csv.NewWriter(counter), counter: counter}
+	if err := c.w.Write([]string{"time", "sensor", "key", "value"}); err != nil {
+		f.Close()
+		return nil, err
+	}
+	return c, nil
+}
+
+func (c *csvFile) write(rows []row) error {
+	for _, r := range rows {
+		record := []string{
+			r.time.UTC().Format("2006-01-02T15:04:05.000Z07:00"),
+			r.sensor,
+			r.key,
+			strconv.FormatFloat(r.value, 'g', -1, 64),
+		}
+		if err := c.w.Write(record); err != nil {
+			return err
+		}
+	}
+	c.w.Flush()
+	if err := c.w.Error(); err != nil {
+		return err
+	}
+	if err := c.counter.w.Flush(); err != nil {
+		return err
+	}
+	return c.f.Sync()
+}
+
+func (c *csvFile) size() int64 {
+	return c.counter.n
+}
+
+func (c *csvFile) close() error {
+	return c.f.Close()
+}
+
+// parquetFile keeps its rows in memory and writes them when it's closed, since a Parquet file can't be read until its
+// footer is written. The file is written under a temporary name and renamed, so only complete files are visible.
+type parquetFile struct {
+	path  string
+	rows  []row
+	bytes int64
+}
+
+func (p *parquetFile) write(rows []row) error {
+	for _, r := range rows {
+		// The encoded size: the time and value, and the strings with their length prefixes
+		p.bytes += 8 + 8 + 4 + int64(len(r.sensor)) + 4 + int64(len(r.key))
+	}
+	p.rows = append(p.rows, rows...)
+	return nil
+}
+
+func (p *parquetFile) size() int64 {
+	return p.bytes
+}
+
+func (p *parquetFile) close() error {
+	if len(p.rows) == 0 {
+		return nil
+	}
+	columns := []parquet.Column{
+		{Name: "time", Type: parquet.Timestamp, Int64s: make([]int64, len(p.rows))},
+		{Name: "sensor", Type: parquet.String, Strings: make([]string, len(p.rows))},
+		{Name: "key", Type: parquet.String, Strings: make([]string, len(p.rows))},
+		{Name: "value", Type: parquet.Double, Doubles: make([]float64, len(p.rows))},
+	}
+	for i, r := range p.rows {
+		columns[0].Int64s[i] = r.time.UnixMilli()
+		columns[1].Strings[i] = r.sensor
+		columns[2].Strings[i] = r.key
+		columns[3].Doubles[i] = r.value
+	}
+
+	tmp := p.path + ".tmp"
+	f, err := os.OpenFile(tmp, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o644)
+	if err != nil {
+		return err
+	}
+	w := bufio.NewWriter(f)
+	err = parquet.Write(w, columns, "sbc-hwmonitor "+utils.Version)
+	if err == nil {
+		err = w.Flush()
+	}
+	if err == nil {
+		err = f.Sync()
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	return os.Rename(tmp, p.path)
+}
+
+// rotator writes rows to the current file and starts a new one when it gets too old or too large, deleting the oldest
+// files when they take more than maxTotal bytes.
+type rotator struct {
+	dir         string
+	prefix      string
+	format      string
+	rotateEvery time.Duration
+	maxFile     int64
+	maxTotal    int64
+	current     file
+	currentPath string
+	opened      time.Time
+}
+
+func (r *rotator) ext() string {
+	return "." + r.format
+}
+
+// write writes rows read at t. A file that fails to write is abandoned, so the next write starts a new one, e.g. after
+// the drive is plugged back in.
+func (r *rotator) write(t time.Time, rows []row) error {
+	if r.current != nil && (t.Sub(r.opened) >= r.rotateEvery || r.current.size() >= r.maxFile) {
+		if err := r.close(); err != nil {
+			return err
+		}
+	}
+	if r.current == nil {
+		if err := r.open(t); err != nil {
+			return err
+		}
+	}
+	if err := r.current.write(rows); err != nil {
+		r.current.close()
+		r.current = nil
+		return fmt.Errorf("failed to write %s: %w", r.currentPath, err)
+	}
+	return nil
+}
+
+func (r *rotator) open(t time.Time) error {
+	name := r.prefix + "-" + t.UTC().Format(fileTimeFormat)
+	path := filepath.Join(r.dir, name+r.ext())
+	// Files rotated within the same second get a suffix
+	for i := 1; fileExists(path); i++ {
+		path = filepath.Join(r.dir, fmt.Sprintf("%s-%d%s", name, i, r.ext()))
+	}
+	if r.format == formatParquet {
+		r.current = &parquetFile{path: path}
+	} else {
+		f, err := createCSV(path)
+		if err != nil {
+			return err
+		}
+		r.current = f
+	}
+	r.currentPath = path
+	r.opened = t
+	return nil
+}
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+// close finishes the current file and deletes the oldest files if there are too many.
+func (r *rotator) close() error {
+	if r.current == nil {
+		return nil
+	}
+	err := r.current.close()
+	r.current = nil
+	if err != nil {
+		return fmt.Errorf("failed to finish %s: %w", r.currentPath, err)
+	}
+	if r.maxTotal > 0 {
+		return r.prune()
+	}
+	return nil
+}
+
+// prune deletes the oldest finished files until the rest take at most maxTotal bytes.
+func (r *rotator) prune() error {
+	entries, err := os.ReadDir(r.dir)
+	if err != nil {
+		return err
+	}
+	type entry struct {
+		path  string
+		time  string
+		index int
+		size  int64
+	}
+	var files []entry
+	var total int64
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasPrefix(e.Name(), r.prefix+"-") || !strings.HasSuffix(e.Name(), r.ext()) {
+			continue
+		}
+		path := filepath.Join(r.dir, e.Name())
+		if r.current != nil && path == r.currentPath {
+			continue
+		}
+		info, err := e.Info()
+		if err != nil {
+			continue
+		}
+		t, index := r.fileOrder(e.Name())
+		files = append(files, entry{path: path, time: t, index: index, size: info.Size()})
+		total += info.Size()
+	}
+	sort.Slice(files, func(i, j int) bool {
+		if files[i].time != files[j].time {
+			return files[i].time < files[j].time
+		}
+		return files[i].index < files[j].index
+	})
+
+	var errs []error
+	for _, f := range files {
+		if total <= r.maxTotal {
+			break
+		}
+		if err := os.Remove(f.path); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		total -= f.size
+	}
+	return errors.Join(errs...)
+}
+
+// fileOrder splits a file name into its time and the suffix of files rotated within the same second, which is 0 for
+// the first file.
+func (r *rotator) fileOrder(name string) (string, int) {
+	name = strings.TrimSuffix(strings.TrimPrefix(name, r.prefix+"-"), r.ext())
+	t, suffix, found := strings.Cut(name, "-")
+	if !found {
+		return t, 0
+	}
+	index, err := strconv.Atoi(suffix)
+	if err != nil {
+		return name, 0
+	}
+	return t, index
+}
+
+// onOwnMount reports whether dir is below a mount point other than the root, mountpoints is every mounted filesystem.
+func onOwnMount(dir string, mountpoints []string) bool {
+	best := ""
+	for _, m := range mountpoints {
+		m = filepath.Clean(m)
+		if (dir == m || strings.HasPrefix(dir, strings.TrimSuffix(m, "/")+"/")) && len(m) > len(best) {
+			best = m
+		}
+	}
+	return best != "" && best != "/"
+}
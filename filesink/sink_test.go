@@ -0,0 +1,93 @@
+package filesink
+
+import (
+	"encoding/csv"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func listFiles(t *testing.T, dir string) []string {
+	t.Helper()
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	names := make([]string, 0, len(entries))
+	for _, e := range entries {
+		names = append(names, e.Name())
+	}
+	return names
+}
+
+func TestRotatorCSV(t *testing.T) {
+	dir := t.TempDir()
+	r := &rotator{dir: dir, prefix: "robot1", format: formatCSV, rotateEvery: time.Hour, maxFile: 1 << 20}
+	start := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	require.NoError(t, r.write(start, []row{{time: start, sensor: "cpu", key: "usage", value: 12.5}}))
+	require.NoError(t, r.write(start.Add(time.Minute), []row{{time: start.Add(time.Minute), sensor: "temperatures", key: "CPU", value: 48}}))
+
+	// Everything written so far is readable while the file is still open
+	f, err := os.Open(filepath.Join(dir, "robot1-20240501T120000Z.csv"))
+	require.NoError(t, err)
+	records, err := csv.NewReader(f).ReadAll()
+	f.Close()
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{
+		{"time", "sensor", "key", "value"},
+		{"2024-05-01T12:00:00.000Z", "cpu", "usage", "12.5"},
+		{"2024-05-01T12:01:00.000Z", "temperatures", "CPU", "48"},
+	}, records)
+
+	require.NoError(t, r.write(start.Add(time.Hour), []row{{time: start.Add(time.Hour), sensor: "cpu", key: "usage", value: 13}}))
+	require.NoError(t, r.close())
+	assert.Equal(t, []string{"robot1-20240501T120000Z.csv", "robot1-20240501T130000Z.csv"}, listFiles(t, dir))
+}
+
+func TestRotatorMaxSizes(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "notes.txt"), make([]byte, 1000), 0o644))
+	r := &rotator{dir: dir, prefix: "hwmonitor", format: formatCSV, rotateEvery: time.Hour, maxFile: 10, maxTotal: 100}
+	start := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	for i := 0; i < 6; i++ {
+		// Every write is larger than maxFile, so each one starts a new file, two of them within each second
+		t0 := start.Add(time.Duration(i/2) * time.Second)
+		require.NoError(t, r.write(t0, []row{{time: t0, sensor: "cpu", key: "usage", value: float64(i)}}))
+	}
+	require.NoError(t, r.close())
+
+	// Each file is over 50 bytes, so only the newest one fits and other files are left alone
+	assert.Equal(t, []string{"hwmonitor-20240501T120002Z-1.csv", "notes.txt"}, listFiles(t, dir))
+}
+
+func TestRotatorParquet(t *testing.T) {
+	dir := t.TempDir()
+	r := &rotator{dir: dir, prefix: "hwmonitor", format: formatParquet, rotateEvery: time.Hour, maxFile: 1 << 20}
+	start := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	require.NoError(t, r.write(start, []row{{time: start, sensor: "cpu", key: "usage", value: 12.5}}))
+
+	// Parquet files only appear once they're complete
+	assert.Empty(t, listFiles(t, dir))
+	require.NoError(t, r.close())
+	assert.Equal(t, []string{"hwmonitor-20240501T120000Z.parquet"}, listFiles(t, dir))
+	data, err := os.ReadFile(filepath.Join(dir, "hwmonitor-20240501T120000Z.parquet"))
+	require.NoError(t, err)
+	assert.Equal(t, "PAR1", string(data[:4]))
+	assert.Equal(t, "PAR1", string(data[len(data)-4:]))
+}
+
+func TestRotatorMissingDirectory(t *testing.T) {
+	r := &rotator{dir: filepath.Join(t.TempDir(), "usb"), prefix: "hwmonitor", format: formatCSV, rotateEvery: time.Hour, maxFile: 1 << 20}
+	assert.Error(t, r.write(time.Now(), []row{{sensor: "cpu", key: "usage"}}))
+}
+
+func TestOnOwnMount(t *testing.T) {
+	mounts := []string{"/", "/boot/firmware", "/media/usb"}
+	assert.True(t, onOwnMount("/media/usb", mounts))
+	assert.True(t, onOwnMount("/media/usb/logs", mounts))
+	assert.False(t, onOwnMount("/media/usb2", mounts))
+	assert.False(t, onOwnMount("/media", mounts))
+	assert.False(t, onOwnMount("/media/usb", []string{"/"}))
+}
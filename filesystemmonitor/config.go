@@ -0,0 +1,21 @@
+package filesystemmonitor
+
+import (
+	"fmt"
+	"path/filepath"
+)
+
+type ComponentConfig struct {
+	IncludeMountpoints       []string `json:"include_mountpoints"`        // Glob patterns, when set only matching mountpoints are reported
+	ExcludeMountpoints       []string `json:"exclude_mountpoints"`        // Glob patterns of mountpoints to skip
+	IncludePseudoFilesystems bool     `json:"include_pseudo_filesystems"` // Report proc, sysfs, tmpfs, squashfs, etc. too
+}
+
+func (conf *ComponentConfig) Validate(path string) ([]string, error) {
+	for _, pattern := range append(conf.IncludeMountpoints, conf.ExcludeMountpoints...) {
+		if _, err := filepath.Match(pattern, "/"); err != nil {
+			return nil, fmt.Errorf("invalid mountpoint pattern %q: %w", pattern, err)
+		}
+	}
+	return nil, nil
+}
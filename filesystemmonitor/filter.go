@@ -0,0 +1,68 @@
+package filesystemmonitor
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+// pseudoFilesystems are filesystems that don't consume storage, or are always full like squashfs, so
+// they are skipped unless explicitly requested.
+var pseudoFilesystems = map[string]bool{
+	"autofs":      true,
+	"binfmt_misc": true,
+	"bpf":         true,
+	"cgroup":      true,
+	"cgroup2":     true,
+	"configfs":    true,
+	"debugfs":     true,
+	"devpts":      true,
+	"devtmpfs":    true,
+	"efivarfs":    true,
+	"fusectl":     true,
+	"hugetlbfs":   true,
+	"mqueue":      true,
+	"nsfs":        true,
+	"proc":        true,
+	"pstore":      true,
+	"ramfs":       true,
+	"rpc_pipefs":  true,
+	"securityfs":  true,
+	"squashfs":    true,
+	"sysfs":       true,
+	"tmpfs":       true,
+	"tracefs":     true,
+}
+
+type mountFilter struct {
+	include       []string
+	exclude       []string
+	includePseudo bool
+}
+
+func (f *mountFilter) matches(mountpoint, fstype string) bool {
+	if !f.includePseudo && pseudoFilesystems[fstype] {
+		return false
+	}
+	if matchesAny(f.exclude, mountpoint) {
+		return false
+	}
+	return len(f.include) == 0 || matchesAny(f.include, mountpoint)
+}
+
+func matchesAny(patterns []string, mountpoint string) bool {
+	for _, pattern := range patterns {
+		if ok, _ := filepath.Match(pattern, mountpoint); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// mountpointName converts a mountpoint into a reading key prefix, "/" is reported as "root".
+func mountpointName(mountpoint string) string {
+	name := strings.Trim(mountpoint, "/")
+	if name == "" {
+		return "root"
+	}
+	return strings.NewReplacer("/", "_", " ", "_", "-", "_", ".", "_").Replace(name)
+}
@@ -0,0 +1,37 @@
+package filesystemmonitor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMountFilter(t *testing.T) {
+	tests := []struct {
+		name       string
+		filter     mountFilter
+		mountpoint string
+		fstype     string
+		expected   bool
+	}{
+		{"RootIncludedByDefault", mountFilter{}, "/", "ext4", true},
+		{"PseudoSkipped", mountFilter{}, "/proc", "proc", false},
+		{"TmpfsSkipped", mountFilter{}, "/run", "tmpfs", false},
+		{"PseudoRequested", mountFilter{includePseudo: true}, "/run", "tmpfs", true},
+		{"Excluded", mountFilter{exclude: []string{"/media/*"}}, "/media/usb0", "vfat", false},
+		{"NotIncluded", mountFilter{include: []string{"/", "/boot/*"}}, "/data", "ext4", false},
+		{"Included", mountFilter{include: []string{"/", "/boot/*"}}, "/boot/firmware", "vfat", true},
+		{"ExcludeWins", mountFilter{include: []string{"/*"}, exclude: []string{"/data"}}, "/data", "ext4", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.filter.matches(tt.mountpoint, tt.fstype))
+		})
+	}
+}
+
+func TestMountpointName(t *testing.T) {
+	assert.Equal(t, "root", mountpointName("/"))
+	assert.Equal(t, "boot_firmware", mountpointName("/boot/firmware"))
+	assert.Equal(t, "media_usb_stick", mountpointName("/media/usb-stick"))
+}
@@ -0,0 +1,54 @@
+package filesystemmonitor
+
+import (
+	"sync"
+	"time"
+)
+
+// readOnlyTracker remembers when a mount flipped from read-write to read-only, which is how the kernel
+// reacts to errors on ext4 with errors=remount-ro.
+type readOnlyTracker struct {
+	mu      sync.Mutex
+	seenRW  map[string]bool
+	roSince map[string]time.Time
+	now     func() time.Time
+}
+
+func newReadOnlyTracker() *readOnlyTracker {
+	return &readOnlyTracker{
+		seenRW:  make(map[string]bool),
+		roSince: make(map[string]time.Time),
+		now:     time.Now,
+	}
+}
+
+// update records the current state of a mount. It returns the time the mount became read-only and whether
+// that happened on this update. Mounts that were read-only from the start, like a read-only /boot, have no
+// transition time.
+func (t *readOnlyTracker) update(mountpoint string, readOnly bool) (since time.Time, transitioned bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if !readOnly {
+		t.seenRW[mountpoint] = true
+		delete(t.roSince, mountpoint)
+		return time.Time{}, false
+	}
+	if since, ok := t.roSince[mountpoint]; ok {
+		return since, false
+	}
+	if !t.seenRW[mountpoint] {
+		return time.Time{}, false
+	}
+	since = t.now()
+	t.roSince[mountpoint] = since
+	return since, true
+}
+
+func isReadOnly(opts []string) bool {
+	for _, opt := range opts {
+		if opt == "ro" {
+			return true
+		}
+	}
+	return false
+}
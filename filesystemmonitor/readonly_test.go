@@ -0,0 +1,42 @@
+package filesystemmonitor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadOnlyTracker(t *testing.T) {
+	tracker := newReadOnlyTracker()
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	tracker.now = func() time.Time { return now }
+
+	// Read-only from the start isn't a transition
+	since, transitioned := tracker.update("/boot", true)
+	assert.True(t, since.IsZero())
+	assert.False(t, transitioned)
+
+	_, transitioned = tracker.update("/", false)
+	assert.False(t, transitioned)
+
+	since, transitioned = tracker.update("/", true)
+	assert.True(t, transitioned)
+	assert.Equal(t, now, since)
+
+	// The transition time is kept while the mount stays read-only
+	tracker.now = func() time.Time { return now.Add(time.Minute) }
+	since, transitioned = tracker.update("/", true)
+	assert.False(t, transitioned)
+	assert.Equal(t, now, since)
+
+	// Remounting read-write clears it
+	tracker.update("/", false)
+	since, _ = tracker.update("/", true)
+	assert.Equal(t, now.Add(time.Minute), since)
+}
+
+func TestIsReadOnly(t *testing.T) {
+	assert.True(t, isReadOnly([]string{"ro", "relatime"}))
+	assert.False(t, isReadOnly([]string{"rw", "noatime", "errors=remount-ro"}))
+}
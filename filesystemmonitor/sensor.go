@@ -0,0 +1,202 @@
+package filesystemmonitor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/shirou/gopsutil/v4/disk"
+	"go.viam.com/rdk/components/sensor"
+	"go.viam.com/rdk/logging"
+	"go.viam.com/rdk/resource"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/linux"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+var (
+	Model       = resource.NewModel(utils.Namespace, "hwmonitor", "filesystem_monitor")
+	API         = sensor.API
+	PrettyName  = "Filesystem Monitor"
+	Description = "A sensor that reports the size, usage and free space of every mounted filesystem"
+	Version     = utils.Version
+)
+
+type Config struct {
+	resource.Named
+	mu         sync.RWMutex
+	logger     logging.Logger
+	cancelCtx  context.Context
+	cancelFunc func()
+	filter     *mountFilter
+	readOnly   *readOnlyTracker
+	trims      *trimHistory
+}
+
+func init() {
+	resource.RegisterComponent(
+		API,
+		Model,
+		resource.Registration[sensor.Sensor, *ComponentConfig]{Constructor: utils.FilterReadings(NewSensor)})
+	utils.RegisterSimulator(Model, simulate)
+}
+
+func NewSensor(ctx context.Context, deps resource.Dependencies, conf resource.Config, logger logging.Logger) (sensor.Sensor, error) {
+	logger.Infof("Starting %s %s", PrettyName, Version)
+	cancelCtx, cancelFunc := context.WithCancel(context.Background())
+
+	b := Config{
+		Named:      conf.ResourceName().AsNamed(),
+		logger:     logger,
+		cancelCtx:  cancelCtx,
+		cancelFunc: cancelFunc,
+		mu:         sync.RWMutex{},
+		readOnly:   newReadOnlyTracker(),
+		trims:      newTrimHistory(),
+	}
+
+	if err := b.Reconfigure(ctx, deps, conf); err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
+
+func (c *Config) Reconfigure(ctx context.Context, _ resource.Dependencies, conf resource.Config) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.logger.Debugf("Reconfiguring %s", PrettyName)
+
+	newConf, err := resource.NativeConfig[*ComponentConfig](conf)
+	if err != nil {
+		return err
+	}
+	c.filter = &mountFilter{
+		include:       newConf.IncludeMountpoints,
+		exclude:       newConf.ExcludeMountpoints,
+		includePseudo: newConf.IncludePseudoFilesystems,
+	}
+
+	// In case the module has changed name
+	c.Named = conf.ResourceName().AsNamed()
+
+	return nil
+}
+
+func (c *Config) Readings(ctx context.Context, extra map[string]interface{}) (map[string]interface{}, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	// Mounts are listed every time so hotplugged media and remounts are picked up
+	parts, err := disk.PartitionsWithContext(ctx, true)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.trims.refresh(ctx); err != nil {
+		c.logger.Debugf("Failed to read fstrim history from the journal: %v", err)
+	}
+	ret := make(map[string]interface{})
+	for _, part := range parts {
+		if !c.filter.matches(part.Mountpoint, part.Fstype) {
+			continue
+		}
+		usage, err := disk.UsageWithContext(ctx, part.Mountpoint)
+		if err != nil {
+			// A stale network mount or a mountpoint we can't access shouldn't hide the other filesystems
+			c.logger.Debugf("Failed to get usage for %s: %v", part.Mountpoint, err)
+			continue
+		}
+		name := mountpointName(part.Mountpoint)
+		ret[name+"_mountpoint"] = part.Mountpoint
+		ret[name+"_device"] = part.Device
+		ret[name+"_fstype"] = part.Fstype
+
+		readOnly := isReadOnly(part.Opts)
+		since, transitioned := c.readOnly.update(part.Mountpoint, readOnly)
+		if transitioned {
+			c.logger.Errorf("%s (%s) was remounted read-only", part.Mountpoint, part.Device)
+		}
+		ret[name+"_read_only"] = readOnly
+		if !since.IsZero() {
+			ret[name+"_read_only_since"] = since.Format(time.RFC3339)
+		}
+		ret[name+"_total"] = usage.Total
+		ret[name+"_used"] = usage.Used
+		// Free is the space available to unprivileged users, which excludes the blocks reserved for root
+		ret[name+"_available"] = usage.Free
+		ret[name+"_used_percent"] = math.Round(usage.UsedPercent*100) / 100
+		// Filesystems without inodes, like vfat and btrfs, report zero for all of these
+		ret[name+"_inodes_total"] = usage.InodesTotal
+		ret[name+"_inodes_used"] = usage.InodesUsed
+		ret[name+"_inodes_free"] = usage.InodesFree
+		ret[name+"_inodes_used_percent"] = math.Round(usage.InodesUsedPercent*100) / 100
+		if trim, ok := c.trims.get(part.Mountpoint); ok {
+			ret[name+"_last_trim"] = trim.Time.Format(time.RFC3339)
+			ret[name+"_last_trimmed_bytes"] = trim.TrimmedBytes
+		}
+	}
+
+	return ret, nil
+}
+
+func (c *Config) DoCommand(ctx context.Context, cmd map[string]interface{}) (map[string]interface{}, error) {
+	command, ok := cmd["command"].(string)
+	if !ok {
+		return nil, errors.New("missing or invalid 'command' field")
+	}
+
+	switch command {
+	case "fstrim":
+		// Trimming a large filesystem can take minutes, only the filter is needed from the config so readings and
+		// reconfigures aren't held up behind the ioctl
+		c.mu.RLock()
+		filter := c.filter
+		c.mu.RUnlock()
+		return c.handleFstrim(ctx, cmd, filter)
+	default:
+		return nil, fmt.Errorf("unknown command: %s", command)
+	}
+}
+
+// handleFstrim trims the given mountpoint, or every reported filesystem if no mountpoint is given.
+func (c *Config) handleFstrim(ctx context.Context, cmd map[string]interface{}, filter *mountFilter) (map[string]interface{}, error) {
+	mountpoints := make([]string, 0)
+	if mountpoint, ok := cmd["mountpoint"].(string); ok && mountpoint != "" {
+		mountpoints = append(mountpoints, mountpoint)
+	} else {
+		parts, err := disk.PartitionsWithContext(ctx, true)
+		if err != nil {
+			return nil, err
+		}
+		for _, part := range parts {
+			if filter.matches(part.Mountpoint, part.Fstype) && !isReadOnly(part.Opts) {
+				mountpoints = append(mountpoints, part.Mountpoint)
+			}
+		}
+	}
+
+	ret := make(map[string]interface{})
+	for _, mountpoint := range mountpoints {
+		trimmed, err := linux.Fstrim(mountpoint)
+		if err != nil {
+			// Filesystems on devices without discard support fail with EOPNOTSUPP, keep going with the others
+			c.logger.Warnf("Failed to trim %s: %v", mountpoint, err)
+			ret[mountpoint] = err.Error()
+			continue
+		}
+		c.logger.Infof("Trimmed %d bytes on %s", trimmed, mountpoint)
+		c.trims.record(mountpoint, linux.TrimResult{Time: time.Now(), TrimmedBytes: trimmed})
+		ret[mountpoint] = trimmed
+	}
+	return ret, nil
+}
+
+func (c *Config) Close(ctx context.Context) error {
+	c.logger.Infof("Shutting down %s", PrettyName)
+	return nil
+}
+
+func (c *Config) Ready(ctx context.Context, extra map[string]interface{}) (bool, error) {
+	return false, nil
+}
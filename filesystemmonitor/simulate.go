@@ -0,0 +1,63 @@
+package filesystemmonitor
+
+import (
+	"math"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+// simulatedFilesystem is a filesystem of the simulated board, a Raspberry Pi 5 booting from an SD card.
+type simulatedFilesystem struct {
+	mountpoint  string
+	device      string
+	fstype      string
+	total       uint64
+	used        uint64
+	inodesTotal uint64
+	inodesUsed  uint64
+}
+
+var simulatedFilesystems = []simulatedFilesystem{
+	{"/", "/dev/mmcblk0p2", "ext4", 62_226_829_312, 9_663_676_416, 3_878_912, 212_480},
+	{"/boot/firmware", "/dev/mmcblk0p1", "vfat", 535_805_952, 66_060_288, 0, 0},
+	{"/run", "tmpfs", "tmpfs", 843_833_344, 6_029_312, 205_851, 1_024},
+}
+
+// simulate returns the filesystems that match the configured mountpoints, their usage doesn't depend on the profile.
+func simulate(sim *utils.Simulation) map[string]interface{} {
+	filter := &mountFilter{}
+	if conf, ok := sim.Config().(*ComponentConfig); ok {
+		filter = &mountFilter{
+			include:       conf.IncludeMountpoints,
+			exclude:       conf.ExcludeMountpoints,
+			includePseudo: conf.IncludePseudoFilesystems,
+		}
+	}
+	ret := make(map[string]interface{})
+	for _, fs := range simulatedFilesystems {
+		if !filter.matches(fs.mountpoint, fs.fstype) {
+			continue
+		}
+		name := mountpointName(fs.mountpoint)
+		ret[name+"_mountpoint"] = fs.mountpoint
+		ret[name+"_device"] = fs.device
+		ret[name+"_fstype"] = fs.fstype
+		ret[name+"_read_only"] = false
+		ret[name+"_total"] = fs.total
+		ret[name+"_used"] = fs.used
+		ret[name+"_available"] = fs.total - fs.used
+		ret[name+"_used_percent"] = percent(fs.used, fs.total)
+		ret[name+"_inodes_total"] = fs.inodesTotal
+		ret[name+"_inodes_used"] = fs.inodesUsed
+		ret[name+"_inodes_free"] = fs.inodesTotal - fs.inodesUsed
+		ret[name+"_inodes_used_percent"] = percent(fs.inodesUsed, fs.inodesTotal)
+	}
+	return ret
+}
+
+func percent(used, total uint64) float64 {
+	if total == 0 {
+		return 0
+	}
+	return math.Round(float64(used)/float64(total)*10000) / 100
+}
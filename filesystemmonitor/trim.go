@@ -0,0 +1,61 @@
+package filesystemmonitor
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/linux"
+)
+
+// fstrim.timer runs weekly, there's no point in reading the journal on every reading
+const trimJournalInterval = 10 * time.Minute
+
+// trimHistory combines the trims logged by fstrim.service with the ones issued through DoCommand.
+type trimHistory struct {
+	mu            sync.Mutex
+	results       map[string]linux.TrimResult
+	journalReadAt time.Time
+}
+
+func newTrimHistory() *trimHistory {
+	return &trimHistory{results: make(map[string]linux.TrimResult)}
+}
+
+// refresh re-reads the journal if the last read is older than trimJournalInterval.
+func (t *trimHistory) refresh(ctx context.Context) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if time.Since(t.journalReadAt) < trimJournalInterval {
+		return nil
+	}
+	t.journalReadAt = time.Now()
+	results, err := linux.ReadFstrimJournal(ctx)
+	if err != nil {
+		return err
+	}
+	for mountpoint, result := range results {
+		t.recordLocked(mountpoint, result)
+	}
+	return nil
+}
+
+func (t *trimHistory) record(mountpoint string, result linux.TrimResult) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.recordLocked(mountpoint, result)
+}
+
+func (t *trimHistory) recordLocked(mountpoint string, result linux.TrimResult) {
+	if last, ok := t.results[mountpoint]; ok && last.Time.After(result.Time) {
+		return
+	}
+	t.results[mountpoint] = result
+}
+
+func (t *trimHistory) get(mountpoint string) (linux.TrimResult, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	result, ok := t.results[mountpoint]
+	return result, ok
+}
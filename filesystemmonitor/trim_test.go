@@ -0,0 +1,28 @@
+package filesystemmonitor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/linux"
+)
+
+func TestTrimHistoryKeepsNewest(t *testing.T) {
+	history := newTrimHistory()
+	now := time.Now()
+	history.record("/", linux.TrimResult{Time: now, TrimmedBytes: 100})
+	// An older journal entry must not replace a trim issued through DoCommand
+	history.record("/", linux.TrimResult{Time: now.Add(-time.Hour), TrimmedBytes: 5})
+	result, ok := history.get("/")
+	assert.True(t, ok)
+	assert.Equal(t, uint64(100), result.TrimmedBytes)
+
+	history.record("/", linux.TrimResult{Time: now.Add(time.Hour), TrimmedBytes: 7})
+	result, _ = history.get("/")
+	assert.Equal(t, uint64(7), result.TrimmedBytes)
+
+	_, ok = history.get("/data")
+	assert.False(t, ok)
+}
@@ -0,0 +1,15 @@
+package firmwaremonitor
+
+import "errors"
+
+type ComponentConfig struct {
+	RefreshIntervalSec int  `json:"refresh_interval_sec"` // How often the versions are read again, defaults to 3600
+	CheckForUpdates    bool `json:"check_for_updates"`    // Run rpi-eeprom-update to check for a newer bootloader on Raspberry Pi
+}
+
+func (conf *ComponentConfig) Validate(path string) ([]string, error) {
+	if conf.RefreshIntervalSec < 0 {
+		return nil, errors.New("refresh_interval_sec must not be negative")
+	}
+	return nil, nil
+}
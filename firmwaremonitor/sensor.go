@@ -0,0 +1,188 @@
+package firmwaremonitor
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"sync"
+	"time"
+
+	"go.viam.com/rdk/components/sensor"
+	"go.viam.com/rdk/logging"
+	"go.viam.com/rdk/resource"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/linux"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/linux/jetson"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/linux/raspberrypi"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+var (
+	Model       = resource.NewModel(utils.Namespace, "hwmonitor", "firmware_monitor")
+	API         = sensor.API
+	PrettyName  = "SBC Firmware Monitor"
+	Description = "A sensor that reports bootloader, firmware and BSP versions"
+	Version     = utils.Version
+)
+
+type Config struct {
+	resource.Named
+	mu              sync.RWMutex
+	logger          logging.Logger
+	cancelCtx       context.Context
+	cancelFunc      func()
+	refreshInterval time.Duration
+	checkForUpdates bool
+	versions        map[string]interface{}
+	lastRefresh     time.Time
+}
+
+func init() {
+	resource.RegisterComponent(
+		API,
+		Model,
+		resource.Registration[sensor.Sensor, *ComponentConfig]{Constructor: utils.FilterReadings(NewSensor)})
+	utils.RegisterSimulator(Model, simulate)
+}
+
+func NewSensor(ctx context.Context, deps resource.Dependencies, conf resource.Config, logger logging.Logger) (sensor.Sensor, error) {
+	logger.Infof("Starting %s %s", PrettyName, Version)
+	cancelCtx, cancelFunc := context.WithCancel(context.Background())
+
+	b := Config{
+		Named:      conf.ResourceName().AsNamed(),
+		logger:     logger,
+		cancelCtx:  cancelCtx,
+		cancelFunc: cancelFunc,
+		mu:         sync.RWMutex{},
+	}
+
+	if err := b.Reconfigure(ctx, deps, conf); err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
+
+func (c *Config) Reconfigure(ctx context.Context, _ resource.Dependencies, conf resource.Config) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.logger.Debugf("Reconfiguring %s", PrettyName)
+
+	newConf, err := resource.NativeConfig[*ComponentConfig](conf)
+	if err != nil {
+		return err
+	}
+	if newConf.RefreshIntervalSec == 0 {
+		newConf.RefreshIntervalSec = 3600
+	}
+	c.refreshInterval = time.Duration(newConf.RefreshIntervalSec) * time.Second
+	c.checkForUpdates = newConf.CheckForUpdates
+	c.versions = nil
+
+	// In case the module has changed name
+	c.Named = conf.ResourceName().AsNamed()
+
+	return nil
+}
+
+func (c *Config) Readings(ctx context.Context, extra map[string]interface{}) (map[string]interface{}, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	// Firmware only changes on reboot or when a package is upgraded, there is no need to run vcgencmd on every reading
+	if c.versions == nil || time.Since(c.lastRefresh) > c.refreshInterval {
+		c.versions = c.readVersions(ctx)
+		c.lastRefresh = time.Now()
+	}
+	return maps.Clone(c.versions), nil
+}
+
+func (c *Config) readVersions(ctx context.Context) map[string]interface{} {
+	ret := make(map[string]interface{})
+	if release, err := linux.ReadKernelRelease(ctx); err == nil {
+		ret["kernel_release"] = release
+	}
+	if version, err := linux.ReadUBootVersion(ctx); err == nil {
+		ret["uboot_version"] = version
+	}
+	if version, date, err := linux.ReadBIOSVersion(ctx); err == nil {
+		ret["bios_version"] = version
+		ret["bios_date"] = date
+	}
+
+	if linux.IsFamily(linux.BoardFamilyRaspberryPi) {
+		c.readRaspberryPiVersions(ctx, ret)
+	} else if linux.IsFamily(linux.BoardFamilyJetson) {
+		c.readJetsonVersions(ctx, ret)
+	}
+	return ret
+}
+
+func (c *Config) readRaspberryPiVersions(ctx context.Context, ret map[string]interface{}) {
+	if firmware, err := raspberrypi.GetFirmwareVersion(ctx); err == nil {
+		ret["firmware_version"] = firmware.Version
+		ret["firmware_date"] = firmware.Date.Format(time.RFC3339)
+	} else {
+		c.logger.Warnf("Failed to read firmware version: %v", err)
+	}
+	// Older boards boot from bootcode.bin on the SD card and have no bootloader EEPROM
+	bootloader, err := raspberrypi.GetBootloaderVersion(ctx)
+	if err != nil {
+		c.logger.Debugf("Failed to read bootloader version: %v", err)
+		return
+	}
+	ret["bootloader_version"] = bootloader.Version
+	ret["bootloader_date"] = bootloader.Date.Format(time.RFC3339)
+	if !c.checkForUpdates {
+		return
+	}
+	if status, err := raspberrypi.GetBootloaderStatus(ctx); err == nil {
+		ret["bootloader_update_available"] = status.UpdateAvailable
+		ret["bootloader_latest_date"] = status.Latest.Format(time.RFC3339)
+	} else {
+		c.logger.Warnf("Failed to check for bootloader updates: %v", err)
+	}
+}
+
+func (c *Config) readJetsonVersions(ctx context.Context, ret map[string]interface{}) {
+	if release, err := jetson.ReadL4TRelease(ctx); err == nil {
+		ret["l4t_release"] = release.Version()
+		ret["l4t_board"] = release.Board
+		ret["l4t_date"] = release.Date
+	} else {
+		c.logger.Warnf("Failed to read L4T release: %v", err)
+	}
+	if version, err := jetson.ReadJetPackVersion(ctx); err == nil {
+		ret["jetpack_version"] = version
+	} else {
+		c.logger.Debugf("Failed to read JetPack version: %v", err)
+	}
+}
+
+// Capabilities reports where the board's firmware versions are read from, other boards only report the kernel, U-Boot
+// and BIOS versions.
+func (c *Config) Capabilities(ctx context.Context) utils.Capabilities {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	caps := utils.Capabilities{Unavailable: make(map[string]string)}
+	if linux.IsFamily(linux.BoardFamilyRaspberryPi) {
+		caps.Backend = "vcgencmd"
+		if !c.checkForUpdates {
+			caps.Unavailable["bootloader_update_available"] = "check_for_updates isn't set"
+		}
+	} else if linux.IsFamily(linux.BoardFamilyJetson) {
+		caps.Backend = "l4t"
+	} else {
+		caps.Backend = "generic"
+		caps.Unavailable["firmware_version"] = fmt.Sprintf("not reported by %s boards", linux.Board().Family)
+	}
+	return caps
+}
+
+func (c *Config) Close(ctx context.Context) error {
+	c.logger.Infof("Shutting down %s", PrettyName)
+	return nil
+}
+
+func (c *Config) Ready(ctx context.Context, extra map[string]interface{}) (bool, error) {
+	return false, nil
+}
@@ -0,0 +1,20 @@
+package firmwaremonitor
+
+import "github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+
+// simulate returns the versions of a Raspberry Pi 5 running Raspberry Pi OS, with a newer bootloader available when
+// updates are checked for.
+func simulate(sim *utils.Simulation) map[string]interface{} {
+	ret := map[string]interface{}{
+		"kernel_release":     "6.6.51+rpt-rpi-2712",
+		"firmware_version":   "5560078dcc8591a00f57b9068d13e5544aeef3aa",
+		"firmware_date":      "2024-09-10T14:40:30Z",
+		"bootloader_version": "2024-09-23",
+		"bootloader_date":    "2024-09-23T13:02:56Z",
+	}
+	if conf, ok := sim.Config().(*ComponentConfig); ok && conf.CheckForUpdates {
+		ret["bootloader_update_available"] = true
+		ret["bootloader_latest_date"] = "2024-11-12T16:10:44Z"
+	}
+	return ret
+}
@@ -0,0 +1,31 @@
+package gnssmonitor
+
+import "fmt"
+
+const (
+	sourceGpsd = "gpsd"
+	sourceNMEA = "nmea"
+)
+
+type ComponentConfig struct {
+	Source      string `json:"source"`       // gpsd or nmea, defaults to gpsd
+	GpsdAddress string `json:"gpsd_address"` // Defaults to localhost:2947
+	Device      string `json:"device"`       // Serial device the receiver sends NMEA on, required for nmea
+	TimeoutMs   int    `json:"timeout_ms"`   // Defaults to 2000
+}
+
+func (conf *ComponentConfig) Validate(path string) ([]string, error) {
+	switch conf.Source {
+	case "", sourceGpsd:
+	case sourceNMEA:
+		if conf.Device == "" {
+			return nil, fmt.Errorf("device is required when source is %s", sourceNMEA)
+		}
+	default:
+		return nil, fmt.Errorf("invalid source %s, must be %s or %s", conf.Source, sourceGpsd, sourceNMEA)
+	}
+	if conf.TimeoutMs < 0 {
+		return nil, fmt.Errorf("timeout_ms must not be negative")
+	}
+	return nil, nil
+}
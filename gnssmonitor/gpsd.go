@@ -0,0 +1,116 @@
+package gnssmonitor
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"time"
+)
+
+type gpsdTPV struct {
+	Device string `json:"device"`
+	Mode   int    `json:"mode"`
+	// Antenna status, added in gpsd 3.24: 1 ok, 2 open, 3 short
+	Ant int `json:"ant"`
+}
+
+type gpsdSky struct {
+	Device     string   `json:"device"`
+	HDOP       *float64 `json:"hdop"`
+	USat       *int     `json:"uSat"`
+	NSat       *int     `json:"nSat"`
+	Satellites []struct {
+		Used bool `json:"used"`
+	} `json:"satellites"`
+}
+
+type gpsdPoll struct {
+	Class string    `json:"class"`
+	TPV   []gpsdTPV `json:"tpv"`
+	Sky   []gpsdSky `json:"sky"`
+}
+
+// readGpsd asks gpsd for the latest reports of the receivers it manages, see https://gpsd.gitlab.io/gpsd/gpsd_json.html
+func readGpsd(ctx context.Context, address string, timeout time.Duration) (map[string]*receiverStatus, error) {
+	dialer := net.Dialer{Timeout: timeout}
+	conn, err := dialer.DialContext(ctx, "tcp", address)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	if err := conn.SetDeadline(time.Now().Add(timeout)); err != nil {
+		return nil, err
+	}
+	// POLL only returns data once watching is enabled
+	if _, err := conn.Write([]byte(`?WATCH={"enable":true};?POLL;` + "\n")); err != nil {
+		return nil, err
+	}
+	scanner := bufio.NewScanner(conn)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		statuses, ok, err := parseGpsdLine(scanner.Bytes())
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			return statuses, nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return nil, fmt.Errorf("gpsd closed the connection without a POLL response")
+}
+
+// parseGpsdLine returns the receivers in a POLL response, other reports such as VERSION, DEVICES and WATCH are skipped.
+func parseGpsdLine(line []byte) (map[string]*receiverStatus, bool, error) {
+	var poll gpsdPoll
+	if err := json.Unmarshal(line, &poll); err != nil {
+		return nil, false, fmt.Errorf("failed to parse gpsd response: %w", err)
+	}
+	if poll.Class != "POLL" {
+		return nil, false, nil
+	}
+	statuses := make(map[string]*receiverStatus)
+	get := func(device string) *receiverStatus {
+		if _, ok := statuses[device]; !ok {
+			statuses[device] = &receiverStatus{Antenna: "unknown"}
+		}
+		return statuses[device]
+	}
+	for _, tpv := range poll.TPV {
+		status := get(tpv.Device)
+		status.Mode = tpv.Mode
+		switch tpv.Ant {
+		case 1:
+			status.Antenna = "ok"
+		case 2:
+			status.Antenna = "open"
+		case 3:
+			status.Antenna = "short"
+		}
+	}
+	for _, sky := range poll.Sky {
+		status := get(sky.Device)
+		if sky.HDOP != nil {
+			status.HDOP = *sky.HDOP
+			status.HasHDOP = true
+		}
+		// Older gpsd versions only report the satellite list
+		status.SatellitesVisible = len(sky.Satellites)
+		for _, satellite := range sky.Satellites {
+			if satellite.Used {
+				status.SatellitesUsed++
+			}
+		}
+		if sky.NSat != nil {
+			status.SatellitesVisible = *sky.NSat
+		}
+		if sky.USat != nil {
+			status.SatellitesUsed = *sky.USat
+		}
+	}
+	return statuses, true, nil
+}
@@ -0,0 +1,41 @@
+package gnssmonitor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseGpsdLine(t *testing.T) {
+	_, ok, err := parseGpsdLine([]byte(`{"class":"WATCH","enable":true,"json":false}`))
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	statuses, ok, err := parseGpsdLine([]byte(`{"class":"POLL","time":"2024-05-01T12:00:00.000Z","active":1,` +
+		`"tpv":[{"class":"TPV","device":"/dev/ttyACM0","mode":3,"ant":1}],` +
+		`"sky":[{"class":"SKY","device":"/dev/ttyACM0","hdop":0.87,"nSat":14,"uSat":9}]}`))
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Contains(t, statuses, "/dev/ttyACM0")
+	status := statuses["/dev/ttyACM0"]
+	assert.Equal(t, "3d", status.fixType())
+	assert.Equal(t, "ok", status.Antenna)
+	assert.Equal(t, 0.87, status.HDOP)
+	assert.Equal(t, 14, status.SatellitesVisible)
+	assert.Equal(t, 9, status.SatellitesUsed)
+}
+
+func TestParseGpsdLineSatelliteList(t *testing.T) {
+	// gpsd before 3.22 has no uSat and nSat
+	statuses, ok, err := parseGpsdLine([]byte(`{"class":"POLL","tpv":[{"device":"/dev/ttyS0","mode":1}],` +
+		`"sky":[{"device":"/dev/ttyS0","satellites":[{"PRN":1,"used":true},{"PRN":2,"used":false},{"PRN":3,"used":true}]}]}`))
+	require.NoError(t, err)
+	require.True(t, ok)
+	status := statuses["/dev/ttyS0"]
+	assert.Equal(t, "none", status.fixType())
+	assert.Equal(t, 3, status.SatellitesVisible)
+	assert.Equal(t, 2, status.SatellitesUsed)
+	assert.False(t, status.HasHDOP)
+	assert.Equal(t, "unknown", status.Antenna)
+}
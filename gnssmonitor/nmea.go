@@ -0,0 +1,146 @@
+package gnssmonitor
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// readNMEA reads sentences from a receiver until it has seen a full update. The serial port must already be configured
+// with the receiver's baud rate, e.g. with stty.
+func readNMEA(ctx context.Context, device string, timeout time.Duration) (*receiverStatus, error) {
+	file, err := os.Open(device)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	// Closing the file unblocks the scanner when the receiver stops sending
+	go func() {
+		<-ctx.Done()
+		file.Close()
+	}()
+
+	parser := newNMEAParser()
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		parser.parse(scanner.Text())
+		if parser.complete() {
+			return parser.status, nil
+		}
+	}
+	if parser.seenGGA {
+		return parser.status, nil
+	}
+	if ctx.Err() != nil {
+		return nil, fmt.Errorf("no GGA sentence received from %s within %v", device, timeout)
+	}
+	return nil, scanner.Err()
+}
+
+type nmeaParser struct {
+	status  *receiverStatus
+	seenGGA bool
+	seenGSA bool
+	// Satellites in view per talker, GSV is sent separately for every constellation
+	visible map[string]int
+}
+
+func newNMEAParser() *nmeaParser {
+	return &nmeaParser{status: &receiverStatus{Antenna: "unknown"}, visible: make(map[string]int)}
+}
+
+// complete returns true once a fix and the satellites in view were seen, a receiver sends all of them once per update.
+func (p *nmeaParser) complete() bool {
+	return p.seenGGA && p.seenGSA && len(p.visible) > 0
+}
+
+func (p *nmeaParser) parse(sentence string) {
+	fields, ok := splitNMEA(sentence)
+	if !ok || len(fields[0]) < 5 {
+		return
+	}
+	talker, kind := fields[0][:2], fields[0][2:]
+	switch kind {
+	case "GGA":
+		// $GPGGA,time,lat,N,lon,E,quality,satellites,hdop,altitude,M,...
+		if len(fields) < 9 {
+			return
+		}
+		p.seenGGA = true
+		if quality, err := strconv.Atoi(fields[6]); err == nil && quality == 0 {
+			p.status.Mode = 1
+		}
+		if used, err := strconv.Atoi(fields[7]); err == nil {
+			p.status.SatellitesUsed = used
+		}
+		if hdop, err := strconv.ParseFloat(fields[8], 64); err == nil {
+			p.status.HDOP = hdop
+			p.status.HasHDOP = true
+		}
+	case "GSA":
+		// $GNGSA,A,mode,prn...,pdop,hdop,vdop
+		if len(fields) < 3 {
+			return
+		}
+		if mode, err := strconv.Atoi(fields[2]); err == nil && mode > p.status.Mode {
+			// Multi constellation receivers send a GSA per constellation, keep the best fix
+			p.status.Mode = mode
+		}
+		p.seenGSA = true
+	case "GSV":
+		// $GPGSV,messages,number,in view,...
+		if len(fields) < 4 {
+			return
+		}
+		if visible, err := strconv.Atoi(fields[3]); err == nil {
+			p.visible[talker] = visible
+			p.status.SatellitesVisible = 0
+			for _, count := range p.visible {
+				p.status.SatellitesVisible += count
+			}
+		}
+	case "TXT":
+		// u-blox receivers report the antenna supervisor, e.g. $GPTXT,01,01,02,ANTSTATUS=OK*3B
+		if len(fields) < 5 {
+			return
+		}
+		if status, found := strings.CutPrefix(fields[4], "ANTSTATUS="); found {
+			switch status {
+			case "OK":
+				p.status.Antenna = "ok"
+			case "OPEN":
+				p.status.Antenna = "open"
+			case "SHORT":
+				p.status.Antenna = "short"
+			}
+		}
+	}
+}
+
+// splitNMEA verifies the checksum of a sentence and returns its comma separated fields.
+func splitNMEA(sentence string) ([]string, bool) {
+	sentence = strings.TrimSpace(sentence)
+	if !strings.HasPrefix(sentence, "$") {
+		return nil, false
+	}
+	body, checksum, found := strings.Cut(sentence[1:], "*")
+	if found {
+		expected, err := strconv.ParseUint(checksum, 16, 8)
+		if err != nil {
+			return nil, false
+		}
+		var sum byte
+		for i := 0; i < len(body); i++ {
+			sum ^= body[i]
+		}
+		if byte(expected) != sum {
+			return nil, false
+		}
+	}
+	return strings.Split(body, ","), true
+}
@@ -0,0 +1,53 @@
+package gnssmonitor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNMEAParser(t *testing.T) {
+	parser := newNMEAParser()
+	for _, sentence := range []string{
+		"$GPGGA,123519,4807.038,N,01131.000,E,1,08,0.9,545.4,M,46.9,M,,*47",
+		"$GNGSA,A,3,04,05,09,12,24,,,,,,,,2.5,0.9,2.1*2C",
+		"$GNGSA,A,1,,,,,,,,,,,,,2.5,0.9,2.1*23",
+		"$GPTXT,01,01,02,ANTSTATUS=OPEN*2B",
+	} {
+		parser.parse(sentence)
+	}
+	assert.False(t, parser.complete())
+	parser.parse("$GPGSV,3,1,11,03,03,111,00,04,15,270,00,06,01,010,00,13,06,292,00*74")
+	parser.parse("$GLGSV,1,1,04,65,30,045,40,66,45,120,38,72,10,300,20,88,70,200,44*6D")
+	assert.True(t, parser.complete())
+
+	status := parser.status
+	assert.Equal(t, 3, status.Mode)
+	assert.Equal(t, "3d", status.fixType())
+	assert.Equal(t, 8, status.SatellitesUsed)
+	assert.Equal(t, 15, status.SatellitesVisible)
+	assert.True(t, status.HasHDOP)
+	assert.Equal(t, 0.9, status.HDOP)
+	assert.Equal(t, "open", status.Antenna)
+}
+
+func TestNMEAParserNoFix(t *testing.T) {
+	parser := newNMEAParser()
+	parser.parse("$GPGGA,123520,,,,,0,00,99.99,,,,,,*4F")
+	assert.Equal(t, "none", parser.status.fixType())
+	assert.Equal(t, 99.99, parser.status.HDOP)
+	assert.Equal(t, "unknown", parser.status.Antenna)
+}
+
+func TestSplitNMEA(t *testing.T) {
+	fields, ok := splitNMEA("$GPTXT,01,01,02,ANTSTATUS=OPEN*2B\r\n")
+	assert.True(t, ok)
+	assert.Equal(t, []string{"GPTXT", "01", "01", "02", "ANTSTATUS=OPEN"}, fields)
+
+	// Corrupted on the wire
+	_, ok = splitNMEA("$GPTXT,01,01,02,ANTSTATUS=OPEX*2B")
+	assert.False(t, ok)
+
+	_, ok = splitNMEA("garbage")
+	assert.False(t, ok)
+}
@@ -0,0 +1,144 @@
+package gnssmonitor
+
+import (
+	"context"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"go.viam.com/rdk/components/sensor"
+	"go.viam.com/rdk/logging"
+	"go.viam.com/rdk/resource"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+var (
+	Model       = resource.NewModel(utils.Namespace, "hwmonitor", "gnss_monitor")
+	API         = sensor.API
+	PrettyName  = "SBC GNSS Receiver Monitor"
+	Description = "A sensor that reports the health of GNSS receivers from gpsd or NMEA"
+	Version     = utils.Version
+)
+
+type Config struct {
+	resource.Named
+	mu          sync.RWMutex
+	logger      logging.Logger
+	cancelCtx   context.Context
+	cancelFunc  func()
+	source      string
+	gpsdAddress string
+	device      string
+	timeout     time.Duration
+}
+
+func init() {
+	resource.RegisterComponent(
+		API,
+		Model,
+		resource.Registration[sensor.Sensor, *ComponentConfig]{Constructor: utils.FilterReadings(NewSensor)})
+	utils.RegisterSimulator(Model, simulate)
+}
+
+func NewSensor(ctx context.Context, deps resource.Dependencies, conf resource.Config, logger logging.Logger) (sensor.Sensor, error) {
+	logger.Infof("Starting %s %s", PrettyName, Version)
+	cancelCtx, cancelFunc := context.WithCancel(context.Background())
+
+	b := Config{
+		Named:      conf.ResourceName().AsNamed(),
+		logger:     logger,
+		cancelCtx:  cancelCtx,
+		cancelFunc: cancelFunc,
+		mu:         sync.RWMutex{},
+	}
+
+	if err := b.Reconfigure(ctx, deps, conf); err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
+
+func (c *Config) Reconfigure(ctx context.Context, _ resource.Dependencies, conf resource.Config) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.logger.Debugf("Reconfiguring %s", PrettyName)
+
+	newConf, err := resource.NativeConfig[*ComponentConfig](conf)
+	if err != nil {
+		return err
+	}
+	c.source = newConf.Source
+	if c.source == "" {
+		c.source = sourceGpsd
+	}
+	c.gpsdAddress = newConf.GpsdAddress
+	if c.gpsdAddress == "" {
+		c.gpsdAddress = "localhost:2947"
+	}
+	c.device = newConf.Device
+	if newConf.TimeoutMs == 0 {
+		newConf.TimeoutMs = 2000
+	}
+	c.timeout = time.Duration(newConf.TimeoutMs) * time.Millisecond
+
+	// In case the module has changed name
+	c.Named = conf.ResourceName().AsNamed()
+
+	return nil
+}
+
+func (c *Config) Readings(ctx context.Context, extra map[string]interface{}) (map[string]interface{}, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	statuses := make(map[string]*receiverStatus)
+	if c.source == sourceNMEA {
+		status, err := readNMEA(ctx, c.device, c.timeout)
+		if err != nil {
+			return nil, err
+		}
+		statuses[c.device] = status
+	} else {
+		var err error
+		statuses, err = readGpsd(ctx, c.gpsdAddress, c.timeout)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	ret := make(map[string]interface{})
+	ret["receiver_count"] = len(statuses)
+	for device, status := range statuses {
+		prefix := ""
+		// A single receiver is by far the most common setup, keep its keys short
+		if len(statuses) > 1 {
+			prefix = filepath.Base(device) + "_"
+		}
+		ret[prefix+"fix_type"] = status.fixType()
+		ret[prefix+"fix_mode"] = status.Mode
+		ret[prefix+"satellites_used"] = status.SatellitesUsed
+		ret[prefix+"satellites_visible"] = status.SatellitesVisible
+		ret[prefix+"antenna"] = status.Antenna
+		if status.HasHDOP {
+			ret[prefix+"hdop"] = status.HDOP
+		}
+	}
+	return ret, nil
+}
+
+// Capabilities reports the configured source of the fixes.
+func (c *Config) Capabilities(ctx context.Context) utils.Capabilities {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return utils.Capabilities{Backend: c.source}
+}
+
+func (c *Config) Close(ctx context.Context) error {
+	c.logger.Infof("Shutting down %s", PrettyName)
+	return nil
+}
+
+func (c *Config) Ready(ctx context.Context, extra map[string]interface{}) (bool, error) {
+	return false, nil
+}
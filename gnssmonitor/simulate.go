@@ -0,0 +1,22 @@
+package gnssmonitor
+
+import "github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+
+// simulate returns a single receiver with a 3D fix and a working antenna.
+func simulate(sim *utils.Simulation) map[string]interface{} {
+	status := receiverStatus{
+		Mode:              3,
+		SatellitesUsed:    int(sim.Value(11, 11, 11, 3)),
+		SatellitesVisible: 17,
+		Antenna:           "ok",
+	}
+	return map[string]interface{}{
+		"receiver_count":     1,
+		"fix_type":           status.fixType(),
+		"fix_mode":           status.Mode,
+		"satellites_used":    status.SatellitesUsed,
+		"satellites_visible": status.SatellitesVisible,
+		"antenna":            status.Antenna,
+		"hdop":               sim.Value(0.9, 0.9, 0.9, 0.2),
+	}
+}
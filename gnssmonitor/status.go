@@ -0,0 +1,26 @@
+package gnssmonitor
+
+// receiverStatus is the health of a GNSS receiver, independent of whether it was read through gpsd or NMEA.
+type receiverStatus struct {
+	// Mode is 0 when unknown, 1 without a fix, 2 for a 2D fix and 3 for a 3D fix, the same values gpsd and GSA use
+	Mode              int
+	SatellitesUsed    int
+	SatellitesVisible int
+	HDOP              float64
+	HasHDOP           bool
+	// Antenna is ok, open, short or unknown, only some receivers report it
+	Antenna string
+}
+
+func (s *receiverStatus) fixType() string {
+	switch s.Mode {
+	case 2:
+		return "2d"
+	case 3:
+		return "3d"
+	case 1:
+		return "none"
+	default:
+		return "unknown"
+	}
+}
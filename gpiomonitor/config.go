@@ -0,0 +1,65 @@
+package gpiomonitor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/linux"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+type ComponentConfig struct {
+	Lines []LineConfig `json:"lines"`
+}
+
+// LineConfig identifies a line either by its name, or by chip and offset.
+type LineConfig struct {
+	Name       string `json:"name"`                  // Used as the reading key
+	LineName   string `json:"line_name,omitempty"`   // The line's name as shown by gpioinfo, e.g. GPIO17
+	Chip       string `json:"chip,omitempty"`        // e.g. gpiochip0
+	Offset     *int   `json:"offset,omitempty"`      // The line offset on the chip
+	ActiveLow  bool   `json:"active_low,omitempty"`  // Report the line as active when it is low
+	Bias       string `json:"bias,omitempty"`        // pull_up, pull_down or disabled
+	DebounceMs int    `json:"debounce_ms,omitempty"` // Ignore edges shorter than this
+}
+
+func (conf *ComponentConfig) Validate(path string) ([]string, error) {
+	if len(conf.Lines) == 0 {
+		return nil, errors.New("at least one line is required")
+	}
+	names := make(map[string]bool)
+	for _, line := range conf.Lines {
+		if line.Name == "" {
+			return nil, errors.New("every line requires a name")
+		}
+		if names[line.Name] {
+			return nil, fmt.Errorf("duplicate line name: %s", line.Name)
+		}
+		names[line.Name] = true
+		if line.LineName == "" && (line.Chip == "" || line.Offset == nil) {
+			return nil, fmt.Errorf("line %s requires either line_name or chip and offset", line.Name)
+		}
+		if line.Offset != nil && *line.Offset < 0 {
+			return nil, fmt.Errorf("line %s offset must not be negative", line.Name)
+		}
+		switch line.Bias {
+		case "", "pull_up", "pull_down", "disabled":
+		default:
+			return nil, fmt.Errorf("line %s has an unknown bias %s, expected pull_up, pull_down or disabled", line.Name, line.Bias)
+		}
+		if line.DebounceMs < 0 {
+			return nil, fmt.Errorf("line %s debounce_ms must not be negative", line.Name)
+		}
+	}
+	return nil, nil
+}
+
+// Permissions implements utils.PermissionReporter, the chips need the gpio group. Lines found by name need every chip.
+func (conf *ComponentConfig) Permissions(ctx context.Context) utils.Permissions {
+	chips := make([]string, 0, len(conf.Lines))
+	for _, line := range conf.Lines {
+		chips = append(chips, line.Chip)
+	}
+	return linux.GPIOPermissions(chips)
+}
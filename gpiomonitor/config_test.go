@@ -0,0 +1,30 @@
+package gpiomonitor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidate(t *testing.T) {
+	offset := 17
+	valid := &ComponentConfig{Lines: []LineConfig{
+		{Name: "estop", LineName: "GPIO17", ActiveLow: true, Bias: "pull_up", DebounceMs: 20},
+		{Name: "door", Chip: "gpiochip0", Offset: &offset},
+	}}
+	_, err := valid.Validate("")
+	assert.NoError(t, err)
+
+	invalid := []*ComponentConfig{
+		{},
+		{Lines: []LineConfig{{LineName: "GPIO17"}}},
+		{Lines: []LineConfig{{Name: "estop", LineName: "GPIO17"}, {Name: "estop", LineName: "GPIO27"}}},
+		{Lines: []LineConfig{{Name: "door", Chip: "gpiochip0"}}},
+		{Lines: []LineConfig{{Name: "door", LineName: "GPIO17", Bias: "floating"}}},
+		{Lines: []LineConfig{{Name: "door", LineName: "GPIO17", DebounceMs: -1}}},
+	}
+	for _, conf := range invalid {
+		_, err := conf.Validate("")
+		assert.Error(t, err)
+	}
+}
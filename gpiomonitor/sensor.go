@@ -0,0 +1,216 @@
+package gpiomonitor
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.viam.com/rdk/components/sensor"
+	"go.viam.com/rdk/logging"
+	"go.viam.com/rdk/resource"
+	viamutils "go.viam.com/utils"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/linux"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+var (
+	Model       = resource.NewModel(utils.Namespace, "hwmonitor", "gpio_monitor")
+	API         = sensor.API
+	PrettyName  = "GPIO Line Monitor"
+	Description = "A sensor that reports the state of GPIO lines and counts their edges"
+	Version     = utils.Version
+)
+
+// The consumer name shown by gpioinfo for the lines this sensor holds
+const consumer = "viam-hwmonitor"
+
+type line struct {
+	name         string
+	gpio         *linux.GPIOLine
+	risingEdges  int
+	fallingEdges int
+	droppedEdges int
+	lastSeqno    uint32
+	lastEdgeAt   time.Time
+}
+
+type Config struct {
+	resource.Named
+	configLock   sync.Mutex
+	readingsLock sync.RWMutex
+	logger       logging.Logger
+	workers      *viamutils.StoppableWorkers
+	lines        []*line
+}
+
+func init() {
+	resource.RegisterComponent(
+		API,
+		Model,
+		resource.Registration[sensor.Sensor, *ComponentConfig]{Constructor: utils.FilterReadings(NewSensor)})
+	utils.RegisterSimulator(Model, simulate)
+}
+
+func NewSensor(ctx context.Context, deps resource.Dependencies, conf resource.Config, logger logging.Logger) (sensor.Sensor, error) {
+	logger.Infof("Starting %s %s", PrettyName, Version)
+	b := Config{
+		Named:  conf.ResourceName().AsNamed(),
+		logger: logger,
+	}
+
+	if err := b.Reconfigure(ctx, deps, conf); err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
+
+func (c *Config) Reconfigure(ctx context.Context, _ resource.Dependencies, rawConf resource.Config) error {
+	c.configLock.Lock()
+	defer c.configLock.Unlock()
+	c.logger.Debugf("Reconfiguring %s", PrettyName)
+
+	conf, err := resource.NativeConfig[*ComponentConfig](rawConf)
+	if err != nil {
+		return err
+	}
+
+	// The lines have to be released before they can be requested again with the new configuration
+	c.stop()
+
+	// In case the component has changed name
+	c.Named = rawConf.ResourceName().AsNamed()
+
+	lines := make([]*line, 0, len(conf.Lines))
+	for _, lineConf := range conf.Lines {
+		gpio, err := requestLine(lineConf)
+		if err != nil {
+			for _, l := range lines {
+				l.gpio.Close()
+			}
+			return err
+		}
+		lines = append(lines, &line{name: lineConf.Name, gpio: gpio})
+	}
+
+	c.readingsLock.Lock()
+	c.lines = lines
+	c.readingsLock.Unlock()
+
+	c.workers = viamutils.NewBackgroundStoppableWorkers()
+	for _, l := range lines {
+		c.workers.Add(func(ctx context.Context) {
+			c.watchEdges(ctx, l)
+		})
+	}
+	return nil
+}
+
+func requestLine(conf LineConfig) (*linux.GPIOLine, error) {
+	chip := conf.Chip
+	var offset int
+	if conf.Offset != nil {
+		offset = *conf.Offset
+	}
+	if conf.LineName != "" {
+		var err error
+		if chip, offset, err = linux.FindGPIOLine(conf.LineName); err != nil {
+			return nil, err
+		}
+	}
+	return linux.RequestGPIOLine(linux.GPIOLineConfig{
+		Chip:      chip,
+		Offset:    offset,
+		ActiveLow: conf.ActiveLow,
+		Bias:      conf.Bias,
+		Debounce:  time.Duration(conf.DebounceMs) * time.Millisecond,
+	}, consumer)
+}
+
+func (c *Config) watchEdges(ctx context.Context, l *line) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		default:
+		}
+		events, err := l.gpio.ReadEvents(time.Second)
+		if err != nil {
+			c.logger.Warnf("Failed to read edges of GPIO line %s: %v", l.name, err)
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(time.Second):
+			}
+			continue
+		}
+		if len(events) == 0 {
+			continue
+		}
+		c.readingsLock.Lock()
+		for _, event := range events {
+			if event.Rising {
+				l.risingEdges++
+			} else {
+				l.fallingEdges++
+			}
+			// The kernel drops the oldest edges when they aren't read fast enough, which shows up as a gap
+			if l.lastSeqno != 0 && event.Seqno > l.lastSeqno+1 {
+				l.droppedEdges += int(event.Seqno - l.lastSeqno - 1)
+			}
+			l.lastSeqno = event.Seqno
+		}
+		l.lastEdgeAt = time.Now()
+		c.readingsLock.Unlock()
+	}
+}
+
+func (c *Config) Readings(ctx context.Context, extra map[string]interface{}) (map[string]interface{}, error) {
+	c.readingsLock.RLock()
+	defer c.readingsLock.RUnlock()
+	ret := make(map[string]interface{})
+	for _, l := range c.lines {
+		value, err := l.gpio.Value()
+		if err != nil {
+			return nil, err
+		}
+		ret[l.name] = value
+		ret[l.name+"_rising_edges"] = l.risingEdges
+		ret[l.name+"_falling_edges"] = l.fallingEdges
+		ret[l.name+"_dropped_edges"] = l.droppedEdges
+		if !l.lastEdgeAt.IsZero() {
+			ret[l.name+"_last_edge_time"] = l.lastEdgeAt.Format(time.RFC3339)
+		}
+	}
+	return ret, nil
+}
+
+// stop stops the workers before closing the lines, so no worker reads from a closed line.
+func (c *Config) stop() {
+	if c.workers != nil {
+		c.logger.Debug("Stopping background workers")
+		c.workers.Stop()
+		c.workers = nil
+	}
+	c.readingsLock.Lock()
+	defer c.readingsLock.Unlock()
+	for _, l := range c.lines {
+		if err := l.gpio.Close(); err != nil {
+			c.logger.Warnf("Failed to release GPIO line %s: %v", l.name, err)
+		}
+	}
+	c.lines = nil
+}
+
+func (c *Config) Close(ctx context.Context) error {
+	c.configLock.Lock()
+	defer c.configLock.Unlock()
+	c.logger.Infof("Shutting down %s", PrettyName)
+	c.stop()
+	c.logger.Infof("%s Shutdown complete", PrettyName)
+	return nil
+}
+
+func (c *Config) Ready(ctx context.Context, extra map[string]interface{}) (bool, error) {
+	return false, nil
+}
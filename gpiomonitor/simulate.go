@@ -0,0 +1,19 @@
+package gpiomonitor
+
+import "github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+
+// simulate returns the configured lines, all inactive and without edges.
+func simulate(sim *utils.Simulation) map[string]interface{} {
+	ret := make(map[string]interface{})
+	conf, ok := sim.Config().(*ComponentConfig)
+	if !ok {
+		return ret
+	}
+	for _, line := range conf.Lines {
+		ret[line.Name] = false
+		ret[line.Name+"_rising_edges"] = 0
+		ret[line.Name+"_falling_edges"] = 0
+		ret[line.Name+"_dropped_edges"] = 0
+	}
+	return ret
+}
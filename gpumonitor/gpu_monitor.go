@@ -2,17 +2,17 @@ package gpumonitor
 
 import (
 	"context"
-	"errors"
 
-	"github.com/rinzlerlabs/sbcidentify"
-	"github.com/rinzlerlabs/sbcidentify/boardtype"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/linux"
 	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/linux/jetson"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/linux/rockchip"
 	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/sensors"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
 	"go.viam.com/rdk/logging"
 )
 
 var (
-	ErrUnsupportedBoard = errors.New("gpu stats not supported on this board")
+	ErrUnsupportedBoard = utils.NewError(utils.ErrHardwareAbsent, "gpu stats not supported on this board")
 )
 
 type gpuMonitor interface {
@@ -28,11 +28,17 @@ type gpuMonitor interface {
 	GetGPUStats(context.Context) (map[string][]sensors.GPUSensorReading, error)
 }
 
-func newGpuMonitor(logger logging.Logger) (gpuMonitor, error) {
-	if sbcidentify.IsBoardType(boardtype.NVIDIA) {
-		return jetson.NewJetsonGpuMonitor(logger)
+// newGpuMonitor returns the GPU monitor for the board and the backend it reads the GPU stats from.
+func newGpuMonitor(logger logging.Logger) (gpuMonitor, string, error) {
+	if linux.IsFamily(linux.BoardFamilyJetson) {
+		monitor, err := jetson.NewJetsonGpuMonitor(logger)
+		return monitor, "jetson", err
+	} else if linux.IsFamily(linux.BoardFamilyRockchip) {
+		monitor, err := rockchip.NewRockchipGpuMonitor(logger)
+		return monitor, "rockchip", err
 	} else if sensors.HasNvidiaSmiCommand(logger) {
-		return sensors.NewNVIDIAGpuMonitor(logger)
+		monitor, err := sensors.NewNVIDIAGpuMonitor(logger)
+		return monitor, "nvidia-smi", err
 	}
-	return nil, ErrUnsupportedBoard
+	return nil, "", ErrUnsupportedBoard
 }
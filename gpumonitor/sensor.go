@@ -26,13 +26,14 @@ type Config struct {
 	cancelCtx  context.Context
 	cancelFunc func()
 	gpuMonitor gpuMonitor
+	backend    string
 }
 
 func init() {
 	resource.RegisterComponent(
 		API,
 		Model,
-		resource.Registration[sensor.Sensor, *ComponentConfig]{Constructor: NewSensor})
+		resource.Registration[sensor.Sensor, *ComponentConfig]{Constructor: utils.FilterReadings(NewSensor)})
 }
 
 func NewSensor(ctx context.Context, deps resource.Dependencies, conf resource.Config, logger logging.Logger) (sensor.Sensor, error) {
@@ -72,7 +73,7 @@ func (c *Config) Reconfigure(ctx context.Context, _ resource.Dependencies, conf
 
 	// In case the module has changed name
 	c.Named = conf.ResourceName().AsNamed()
-	c.gpuMonitor, err = newGpuMonitor(c.logger)
+	c.gpuMonitor, c.backend, err = newGpuMonitor(c.logger)
 	if err != nil {
 		return err
 	}
@@ -102,6 +103,13 @@ func (c *Config) Readings(ctx context.Context, extra map[string]interface{}) (ma
 	return m, nil
 }
 
+// Capabilities reports what the GPU stats are read from.
+func (c *Config) Capabilities(ctx context.Context) utils.Capabilities {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return utils.Capabilities{Backend: c.backend}
+}
+
 func (c *Config) Close(ctx context.Context) error {
 	c.mu.Lock()
 	defer c.mu.Unlock()
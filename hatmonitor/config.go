@@ -0,0 +1,16 @@
+package hatmonitor
+
+type ComponentConfig struct {
+	// The HAT this robot should have, any field that is set must match. Leave them all empty to only report the HAT.
+	ExpectedVendor    string `json:"expected_vendor,omitempty"`
+	ExpectedProduct   string `json:"expected_product,omitempty"`
+	ExpectedProductID string `json:"expected_product_id,omitempty"`
+}
+
+func (conf *ComponentConfig) Validate(path string) ([]string, error) {
+	return nil, nil
+}
+
+func (conf *ComponentConfig) hasExpectation() bool {
+	return conf.ExpectedVendor != "" || conf.ExpectedProduct != "" || conf.ExpectedProductID != ""
+}
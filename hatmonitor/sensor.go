@@ -0,0 +1,147 @@
+package hatmonitor
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+
+	"go.viam.com/rdk/components/sensor"
+	"go.viam.com/rdk/logging"
+	"go.viam.com/rdk/resource"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/linux"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/linux/raspberrypi"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+var (
+	Model       = resource.NewModel(utils.Namespace, "hwmonitor", "hat_monitor")
+	API         = sensor.API
+	PrettyName  = "Raspberry Pi HAT Monitor"
+	Description = "A sensor that reports which HAT is attached to a Raspberry Pi and whether it is the expected one"
+	Version     = utils.Version
+
+	ErrNotRaspberryPi = utils.NewError(utils.ErrHardwareAbsent, "HAT identification is only supported on Raspberry Pi")
+)
+
+type Config struct {
+	resource.Named
+	mu         sync.RWMutex
+	logger     logging.Logger
+	cancelCtx  context.Context
+	cancelFunc func()
+	expected   *ComponentConfig
+	mismatch   bool
+}
+
+func init() {
+	resource.RegisterComponent(
+		API,
+		Model,
+		resource.Registration[sensor.Sensor, *ComponentConfig]{Constructor: utils.FilterReadings(NewSensor)})
+	utils.RegisterSimulator(Model, simulate)
+}
+
+func NewSensor(ctx context.Context, deps resource.Dependencies, conf resource.Config, logger logging.Logger) (sensor.Sensor, error) {
+	logger.Infof("Starting %s %s", PrettyName, Version)
+	if !linux.IsFamily(linux.BoardFamilyRaspberryPi) {
+		return nil, ErrNotRaspberryPi
+	}
+	cancelCtx, cancelFunc := context.WithCancel(context.Background())
+
+	b := Config{
+		Named:      conf.ResourceName().AsNamed(),
+		logger:     logger,
+		cancelCtx:  cancelCtx,
+		cancelFunc: cancelFunc,
+		mu:         sync.RWMutex{},
+	}
+
+	if err := b.Reconfigure(ctx, deps, conf); err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
+
+func (c *Config) Reconfigure(ctx context.Context, _ resource.Dependencies, rawConf resource.Config) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.logger.Debugf("Reconfiguring %s", PrettyName)
+
+	conf, err := resource.NativeConfig[*ComponentConfig](rawConf)
+	if err != nil {
+		return err
+	}
+
+	// In case the module has changed name
+	c.Named = rawConf.ResourceName().AsNamed()
+	c.expected = conf
+	c.mismatch = false
+
+	return nil
+}
+
+func (c *Config) Readings(ctx context.Context, extra map[string]interface{}) (map[string]interface{}, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	hat, err := raspberrypi.ReadHAT(ctx)
+	if err != nil && !errors.Is(err, raspberrypi.ErrNoHAT) {
+		return nil, err
+	}
+
+	ret := map[string]interface{}{
+		"present": hat != nil,
+	}
+	if hat != nil {
+		ret["vendor"] = hat.Vendor
+		ret["product"] = hat.Product
+		ret["product_id"] = hat.ProductID
+		ret["product_version"] = hat.ProductVersion
+		ret["uuid"] = hat.UUID
+	}
+	if !c.expected.hasExpectation() {
+		return ret, nil
+	}
+
+	mismatch := !matches(c.expected, hat)
+	ret["mismatch"] = mismatch
+	ret["expected_product"] = c.expected.ExpectedProduct
+	// Only log when the HAT changes, not on every reading
+	if mismatch && !c.mismatch {
+		if hat == nil {
+			c.logger.Warnf("Expected HAT %s is not attached", c.expected.ExpectedProduct)
+		} else {
+			c.logger.Warnf("Expected HAT %s but found %s from %s", c.expected.ExpectedProduct, hat.Product, hat.Vendor)
+		}
+	}
+	c.mismatch = mismatch
+	return ret, nil
+}
+
+// matches compares the HAT against the configured fields, ignoring case since vendors aren't consistent about it.
+func matches(expected *ComponentConfig, hat *raspberrypi.HAT) bool {
+	if hat == nil {
+		return false
+	}
+	for _, field := range []struct{ expected, actual string }{
+		{expected.ExpectedVendor, hat.Vendor},
+		{expected.ExpectedProduct, hat.Product},
+		{expected.ExpectedProductID, hat.ProductID},
+	} {
+		if field.expected != "" && !strings.EqualFold(field.expected, field.actual) {
+			return false
+		}
+	}
+	return true
+}
+
+func (c *Config) Close(ctx context.Context) error {
+	c.logger.Infof("Shutting down %s", PrettyName)
+	c.cancelFunc()
+	return nil
+}
+
+func (c *Config) Ready(ctx context.Context, extra map[string]interface{}) (bool, error) {
+	return false, nil
+}
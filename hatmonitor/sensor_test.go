@@ -0,0 +1,17 @@
+package hatmonitor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/linux/raspberrypi"
+)
+
+func TestMatches(t *testing.T) {
+	hat := &raspberrypi.HAT{Vendor: "Pimoroni Ltd.", Product: "Fan SHIM", ProductID: "0x0007"}
+	assert.True(t, matches(&ComponentConfig{ExpectedProduct: "fan shim"}, hat))
+	assert.True(t, matches(&ComponentConfig{ExpectedVendor: "Pimoroni Ltd.", ExpectedProductID: "0x0007"}, hat))
+	assert.False(t, matches(&ComponentConfig{ExpectedProduct: "Sense HAT"}, hat))
+	assert.False(t, matches(&ComponentConfig{ExpectedProduct: "Fan SHIM"}, nil))
+}
@@ -0,0 +1,31 @@
+package hatmonitor
+
+import (
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/linux/raspberrypi"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+var simulatedHAT = &raspberrypi.HAT{
+	Vendor:         "Raspberry Pi",
+	Product:        "Sense HAT",
+	ProductID:      "0x0001",
+	ProductVersion: "0x0001",
+	UUID:           "a6f1d8c2-3b4e-4f5a-9c7d-2e8b1f0a6d34",
+}
+
+// simulate returns a Sense HAT, and whether it's the configured one.
+func simulate(sim *utils.Simulation) map[string]interface{} {
+	ret := map[string]interface{}{
+		"present":         true,
+		"vendor":          simulatedHAT.Vendor,
+		"product":         simulatedHAT.Product,
+		"product_id":      simulatedHAT.ProductID,
+		"product_version": simulatedHAT.ProductVersion,
+		"uuid":            simulatedHAT.UUID,
+	}
+	if conf, ok := sim.Config().(*ComponentConfig); ok && conf.hasExpectation() {
+		ret["mismatch"] = !matches(conf, simulatedHAT)
+		ret["expected_product"] = conf.ExpectedProduct
+	}
+	return ret
+}
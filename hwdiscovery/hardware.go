@@ -0,0 +1,231 @@
+package hwdiscovery
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"go.viam.com/rdk/components/sensor"
+	"go.viam.com/rdk/resource"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/linux"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/linux/jetson"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+// hardware is what was found on the board, each list is empty when the board doesn't have any or it couldn't be read.
+type hardware struct {
+	board            *linux.BoardInfo
+	orin             bool
+	thermalZones     int
+	cpufreqPolicies  int
+	pressure         bool
+	blockDevices     []string
+	zramDevices      []string
+	wiredAdapters    []string
+	wirelessAdapters []string
+	hwmonChips       []string
+	fans             []string
+	iioDevices       []string
+	w1Thermometers   []string
+	leds             []string
+	videoNodes       []string
+	drmConnectors    []string
+	remoteprocs      []string
+}
+
+// scanHardware probes the board. Nothing here is required, so anything that can't be read is left out.
+func scanHardware(ctx context.Context) *hardware {
+	hw := &hardware{board: linux.Board()}
+	hw.orin = jetson.IsOrin(hw.board)
+	if zones, err := linux.ReadThermalZones(ctx, linux.ThermalZonesRoot); err == nil {
+		hw.thermalZones = len(zones)
+	}
+	if policies, err := linux.ReadCPUFreqPolicies(ctx, linux.CPUFreqRoot); err == nil {
+		hw.cpufreqPolicies = len(policies)
+	}
+	_, err := linux.ReadPressure(ctx, "cpu")
+	hw.pressure = err == nil
+	hw.blockDevices, _ = linux.ListBlockDevices(linux.BlockDevicesRoot)
+	hw.zramDevices, _ = linux.ListZramDevices(linux.BlockDevicesRoot)
+	if adapters, err := linux.ListNetworkAdapters(linux.NetClassRoot); err == nil {
+		for _, adapter := range adapters {
+			if adapter.Wireless {
+				hw.wirelessAdapters = append(hw.wirelessAdapters, adapter.Name)
+			} else {
+				hw.wiredAdapters = append(hw.wiredAdapters, adapter.Name)
+			}
+		}
+	}
+	hw.hwmonChips, _ = linux.ListHwmonChips(ctx, linux.HwmonRoot)
+	if fans, err := linux.ReadHwmonFans(ctx, linux.HwmonRoot); err == nil {
+		for _, fan := range fans {
+			hw.fans = append(hw.fans, fan.Name)
+		}
+	}
+	if devices, err := linux.ReadIIODevices(ctx, linux.IIODevicesRoot); err == nil {
+		for _, device := range devices {
+			hw.iioDevices = append(hw.iioDevices, device.Name)
+		}
+	}
+	if thermometers, err := linux.ListW1Thermometers(linux.W1DevicesRoot); err == nil {
+		for _, thermometer := range thermometers {
+			hw.w1Thermometers = append(hw.w1Thermometers, thermometer.ID)
+		}
+	}
+	if leds, err := linux.ReadLEDs(ctx, linux.LEDsRoot); err == nil {
+		for _, led := range leds {
+			hw.leds = append(hw.leds, led.Name)
+		}
+	}
+	hw.videoNodes, _ = linux.ListVideoNodes("/dev")
+	if connectors, err := linux.ReadDRMConnectors(ctx, linux.DRMClassRoot); err == nil {
+		for _, connector := range connectors {
+			hw.drmConnectors = append(hw.drmConnectors, connector.Name)
+		}
+	}
+	if remoteprocs, err := linux.ReadRemoteprocs(ctx, linux.RemoteprocRoot); err == nil {
+		for _, remoteproc := range remoteprocs {
+			hw.remoteprocs = append(hw.remoteprocs, remoteproc.Name)
+		}
+	}
+	return hw
+}
+
+// component is a component config entry, in the form it's pasted into the machine's config.
+type component struct {
+	name       string
+	model      string
+	attributes map[string]interface{}
+}
+
+func (c component) toMap() map[string]interface{} {
+	attributes := c.attributes
+	if attributes == nil {
+		attributes = map[string]interface{}{}
+	}
+	return map[string]interface{}{
+		"name":       c.name,
+		"api":        sensor.API.String(),
+		"model":      resource.NewModel(utils.Namespace, "hwmonitor", c.model).String(),
+		"attributes": attributes,
+	}
+}
+
+// suggest returns the components the module can provide on the hardware, named after their model. The sensors that
+// work on any linux machine are always included, the rest only when the hardware they report on was found.
+func suggest(hw *hardware) []component {
+	components := []component{
+		{name: "board_info", model: "board_info"},
+		{name: "cpu_monitor", model: "cpu_monitor"},
+		{name: "memory_monitor", model: "memory_monitor"},
+		{name: "filesystem_monitor", model: "filesystem_monitor"},
+	}
+	add := func(model string, attributes map[string]interface{}) {
+		components = append(components, component{name: model, model: model, attributes: attributes})
+	}
+	raspberryPi := hw.board != nil && hw.board.Family == linux.BoardFamilyRaspberryPi
+	jetsonBoard := hw.board != nil && hw.board.Family == linux.BoardFamilyJetson
+
+	if hw.thermalZones > 0 || raspberryPi {
+		add("temperatures", nil)
+	}
+	if hw.cpufreqPolicies > 0 || raspberryPi {
+		add("clocks", nil)
+	}
+	if raspberryPi {
+		add("throttling", nil)
+		add("voltages", nil)
+	}
+	if jetsonBoard {
+		add("gpu_monitor", nil)
+		add("jetson_power_mode", nil)
+	}
+	if hw.orin {
+		add("orin_summary", nil)
+	}
+	if hw.pressure {
+		add("pressure_monitor", nil)
+	}
+	if len(hw.blockDevices) > 0 {
+		add("disk_io_monitor", map[string]interface{}{"devices": stringsToInterfaces(hw.blockDevices)})
+	}
+	for _, device := range hw.blockDevices {
+		if strings.HasPrefix(device, "mmcblk") {
+			add("mmc_monitor", nil)
+			break
+		}
+	}
+	if len(hw.zramDevices) > 0 {
+		add("zram_monitor", nil)
+	}
+	// One wifi_monitor per adapter, they only monitor one
+	for _, adapter := range hw.wirelessAdapters {
+		name := "wifi_monitor"
+		if len(hw.wirelessAdapters) > 1 {
+			name = fmt.Sprintf("wifi_monitor_%s", adapter)
+		}
+		components = append(components, component{
+			name:       name,
+			model:      "wifi_monitor",
+			attributes: map[string]interface{}{"adapter": adapter},
+		})
+	}
+	if len(hw.fans) > 0 {
+		add("fan_monitor", nil)
+	}
+	if len(hw.iioDevices) > 0 {
+		add("iio_monitor", map[string]interface{}{"devices": stringsToInterfaces(hw.iioDevices)})
+	}
+	if len(hw.w1Thermometers) > 0 {
+		add("onewire_monitor", nil)
+	}
+	if len(hw.leds) > 0 {
+		add("led_monitor", nil)
+	}
+	if len(hw.videoNodes) > 0 {
+		add("v4l2_monitor", nil)
+	}
+	if len(hw.drmConnectors) > 0 {
+		add("display_monitor", nil)
+	}
+	if len(hw.remoteprocs) > 0 {
+		add("remoteproc_monitor", map[string]interface{}{"required": []interface{}{}})
+	}
+	return components
+}
+
+// toMap returns what was found, for the discover command.
+func (hw *hardware) toMap() map[string]interface{} {
+	ret := map[string]interface{}{
+		"thermal_zones":     hw.thermalZones,
+		"cpufreq_policies":  hw.cpufreqPolicies,
+		"pressure":          hw.pressure,
+		"block_devices":     stringsToInterfaces(hw.blockDevices),
+		"zram_devices":      stringsToInterfaces(hw.zramDevices),
+		"wired_adapters":    stringsToInterfaces(hw.wiredAdapters),
+		"wireless_adapters": stringsToInterfaces(hw.wirelessAdapters),
+		"hwmon_chips":       stringsToInterfaces(hw.hwmonChips),
+		"fans":              stringsToInterfaces(hw.fans),
+		"iio_devices":       stringsToInterfaces(hw.iioDevices),
+		"w1_thermometers":   stringsToInterfaces(hw.w1Thermometers),
+		"leds":              stringsToInterfaces(hw.leds),
+		"video_nodes":       stringsToInterfaces(hw.videoNodes),
+		"drm_connectors":    stringsToInterfaces(hw.drmConnectors),
+		"remoteprocs":       stringsToInterfaces(hw.remoteprocs),
+	}
+	if hw.board != nil {
+		ret["board_family"] = string(hw.board.Family)
+		ret["board_model"] = hw.board.Model
+		ret["soc"] = hw.board.SoC
+	}
+	return ret
+}
+
+func stringsToInterfaces(values []string) []interface{} {
+	ret := make([]interface{}, 0, len(values))
+	for _, value := range values {
+		ret = append(ret, value)
+	}
+	return ret
+}
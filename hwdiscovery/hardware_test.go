@@ -0,0 +1,65 @@
+package hwdiscovery
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/linux"
+)
+
+func names(components []component) []string {
+	ret := make([]string, 0, len(components))
+	for _, component := range components {
+		ret = append(ret, component.name)
+	}
+	return ret
+}
+
+func TestSuggestRaspberryPi(t *testing.T) {
+	hw := &hardware{
+		board:            &linux.BoardInfo{Family: linux.BoardFamilyRaspberryPi, Model: "Raspberry Pi 5 Model B"},
+		thermalZones:     1,
+		cpufreqPolicies:  1,
+		pressure:         true,
+		blockDevices:     []string{"mmcblk0"},
+		zramDevices:      []string{"zram0"},
+		wiredAdapters:    []string{"eth0"},
+		wirelessAdapters: []string{"wlan0"},
+		fans:             []string{"pwmfan"},
+		leds:             []string{"ACT", "PWR"},
+	}
+	components := suggest(hw)
+	assert.Equal(t, []string{
+		"board_info", "cpu_monitor", "memory_monitor", "filesystem_monitor", "temperatures", "clocks", "throttling",
+		"voltages", "pressure_monitor", "disk_io_monitor", "mmc_monitor", "zram_monitor", "wifi_monitor", "fan_monitor",
+		"led_monitor",
+	}, names(components))
+
+	wifi := components[12].toMap()
+	assert.Equal(t, "rdk:component:sensor", wifi["api"])
+	assert.Equal(t, "gambit-robotics:hwmonitor:wifi_monitor", wifi["model"])
+	assert.Equal(t, map[string]interface{}{"adapter": "wlan0"}, wifi["attributes"])
+	assert.Equal(t, map[string]interface{}{"devices": []interface{}{"mmcblk0"}}, components[9].attributes)
+	// Components without attributes still have them, so the config can be pasted as it is
+	assert.Equal(t, map[string]interface{}{}, components[0].toMap()["attributes"])
+}
+
+func TestSuggestJetson(t *testing.T) {
+	hw := &hardware{
+		board:            &linux.BoardInfo{Family: linux.BoardFamilyJetson, SoC: "tegra234"},
+		orin:             true,
+		wirelessAdapters: []string{"wlan0", "wlx00c0ca"},
+		iioDevices:       []string{"ads1015"},
+		remoteprocs:      []string{"4a334000.pru"},
+	}
+	components := suggest(hw)
+	assert.Equal(t, []string{
+		"board_info", "cpu_monitor", "memory_monitor", "filesystem_monitor", "gpu_monitor", "jetson_power_mode",
+		"orin_summary", "wifi_monitor_wlan0", "wifi_monitor_wlx00c0ca", "iio_monitor", "remoteproc_monitor",
+	}, names(components))
+	require.Equal(t, "wifi_monitor", components[8].model)
+	assert.Equal(t, "wlx00c0ca", components[8].attributes["adapter"])
+	assert.Equal(t, []interface{}{"ads1015"}, components[9].attributes["devices"])
+}
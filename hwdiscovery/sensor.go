@@ -0,0 +1,120 @@
+package hwdiscovery
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"go.viam.com/rdk/components/sensor"
+	"go.viam.com/rdk/logging"
+	"go.viam.com/rdk/resource"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+var (
+	Model       = resource.NewModel(utils.Namespace, "hwmonitor", "hardware_discovery")
+	API         = sensor.API
+	PrettyName  = "SBC Hardware Discovery"
+	Description = "A sensor that probes the board and suggests a config for every sensor the module can provide on it"
+	Version     = utils.Version
+)
+
+type Config struct {
+	resource.Named
+	mu     sync.RWMutex
+	logger logging.Logger
+}
+
+func init() {
+	resource.RegisterComponent(
+		API,
+		Model,
+		resource.Registration[sensor.Sensor, *ComponentConfig]{Constructor: utils.FilterReadings(NewSensor)})
+	utils.RegisterSimulator(Model, simulate)
+}
+
+func NewSensor(ctx context.Context, deps resource.Dependencies, conf resource.Config, logger logging.Logger) (sensor.Sensor, error) {
+	logger.Infof("Starting %s %s", PrettyName, Version)
+	b := Config{
+		Named:  conf.ResourceName().AsNamed(),
+		logger: logger,
+	}
+
+	if err := b.Reconfigure(ctx, deps, conf); err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
+
+func (c *Config) Reconfigure(ctx context.Context, _ resource.Dependencies, conf resource.Config) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.logger.Debugf("Reconfiguring %s", PrettyName)
+
+	// In case the module has changed name
+	c.Named = conf.ResourceName().AsNamed()
+
+	return nil
+}
+
+// Readings summarizes what was found, the config itself is returned by the discover command. The board is probed on
+// every call so hardware that is plugged in later shows up.
+func (c *Config) Readings(ctx context.Context, extra map[string]interface{}) (map[string]interface{}, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	hw := scanHardware(ctx)
+	components := suggest(hw)
+	models := make([]string, 0, len(components))
+	for _, component := range components {
+		models = append(models, component.name)
+	}
+	return map[string]interface{}{
+		"board_family":      string(hw.board.Family),
+		"board_model":       hw.board.Model,
+		"suggested_count":   len(components),
+		"suggested":         strings.Join(models, ","),
+		"wireless_adapters": strings.Join(hw.wirelessAdapters, ","),
+		"block_devices":     strings.Join(hw.blockDevices, ","),
+		"hwmon_chips":       strings.Join(hw.hwmonChips, ","),
+	}, nil
+}
+
+func (c *Config) DoCommand(ctx context.Context, cmd map[string]interface{}) (map[string]interface{}, error) {
+	command, ok := cmd["command"].(string)
+	if !ok {
+		return nil, errors.New("missing or invalid 'command' field")
+	}
+
+	switch command {
+	case "discover":
+		return c.handleDiscover(ctx)
+	default:
+		return nil, fmt.Errorf("unknown command: %s", command)
+	}
+}
+
+// handleDiscover returns the suggested components, ready to paste into the components of the machine's config, along
+// with the hardware that was found.
+func (c *Config) handleDiscover(ctx context.Context) (map[string]interface{}, error) {
+	hw := scanHardware(ctx)
+	components := make([]interface{}, 0)
+	for _, component := range suggest(hw) {
+		components = append(components, component.toMap())
+	}
+	return map[string]interface{}{
+		"components": components,
+		"hardware":   hw.toMap(),
+	}, nil
+}
+
+func (c *Config) Close(ctx context.Context) error {
+	c.logger.Infof("Shutting down %s", PrettyName)
+	return nil
+}
+
+func (c *Config) Ready(ctx context.Context, extra map[string]interface{}) (bool, error) {
+	return false, nil
+}
@@ -0,0 +1,48 @@
+package hwdiscovery
+
+import (
+	"strings"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/linux"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+// simulatedHardware is what's found on a Raspberry Pi 5 with an SD card, an active cooler and wifi.
+var simulatedHardware = &hardware{
+	board: &linux.BoardInfo{
+		Family: linux.BoardFamilyRaspberryPi,
+		Vendor: "raspberrypi",
+		Model:  "Raspberry Pi 5 Model B Rev 1.0",
+		SoC:    "bcm2712",
+	},
+	thermalZones:     1,
+	cpufreqPolicies:  1,
+	pressure:         true,
+	blockDevices:     []string{"mmcblk0"},
+	zramDevices:      []string{"zram0"},
+	wiredAdapters:    []string{"eth0"},
+	wirelessAdapters: []string{"wlan0"},
+	hwmonChips:       []string{"cpu_thermal", "pwmfan", "rp1_adc"},
+	fans:             []string{"pwmfan"},
+	leds:             []string{"ACT", "PWR"},
+	drmConnectors:    []string{"HDMI-A-1", "HDMI-A-2"},
+}
+
+// simulate returns what's suggested for the simulated board, the same way it is for a real one.
+func simulate(sim *utils.Simulation) map[string]interface{} {
+	hw := simulatedHardware
+	components := suggest(hw)
+	models := make([]string, 0, len(components))
+	for _, component := range components {
+		models = append(models, component.name)
+	}
+	return map[string]interface{}{
+		"board_family":      string(hw.board.Family),
+		"board_model":       hw.board.Model,
+		"suggested_count":   len(components),
+		"suggested":         strings.Join(models, ","),
+		"wireless_adapters": strings.Join(hw.wirelessAdapters, ","),
+		"block_devices":     strings.Join(hw.blockDevices, ","),
+		"hwmon_chips":       strings.Join(hw.hwmonChips, ","),
+	}
+}
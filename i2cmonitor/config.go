@@ -0,0 +1,90 @@
+package i2cmonitor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/linux"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+// devRoot is where the I2C buses are checked for, tests point it elsewhere
+var devRoot = "/dev"
+
+type ComponentConfig struct {
+	Devices            []DeviceConfig `json:"devices"`
+	MinScanIntervalSec int            `json:"min_scan_interval_sec,omitempty"` // Readings within this interval reuse the last scan, defaults to 10 seconds
+	Simulate           bool           `json:"simulate,omitempty"`              // Skips checking the buses exist, see utils.SimulateAttribute
+}
+
+// DeviceConfig is an expected device. Only the configured addresses are ever probed.
+type DeviceConfig struct {
+	Name    string `json:"name"`            // Used as the reading key
+	Bus     int    `json:"bus"`             // The N in /dev/i2c-N
+	Address string `json:"address"`         // 7-bit address, e.g. 0x48
+	Mode    string `json:"mode,omitempty"`  // read or quick, defaults to read
+	Force   bool   `json:"force,omitempty"` // Probe even when a kernel driver is bound to the address
+}
+
+func (conf *ComponentConfig) Validate(path string) ([]string, error) {
+	if len(conf.Devices) == 0 {
+		return nil, errors.New("at least one device is required")
+	}
+	if conf.MinScanIntervalSec < 0 {
+		return nil, errors.New("min_scan_interval_sec must not be negative")
+	}
+	names := make(map[string]bool)
+	for _, device := range conf.Devices {
+		if device.Name == "" {
+			return nil, errors.New("every device requires a name")
+		}
+		if names[device.Name] {
+			return nil, fmt.Errorf("duplicate device name: %s", device.Name)
+		}
+		names[device.Name] = true
+		if device.Bus < 0 {
+			return nil, fmt.Errorf("device %s bus must not be negative", device.Name)
+		}
+		if _, err := parseAddress(device.Address); err != nil {
+			return nil, fmt.Errorf("device %s: %w", device.Name, err)
+		}
+		switch device.Mode {
+		case "", "read", "quick":
+		default:
+			return nil, fmt.Errorf("device %s has an unknown mode %s, expected read or quick", device.Name, device.Mode)
+		}
+	}
+	if conf.Simulate {
+		return nil, nil
+	}
+	for _, device := range conf.Devices {
+		if err := linux.CheckI2CBus(devRoot, device.Bus); err != nil {
+			return nil, fmt.Errorf("device %s: %w", device.Name, err)
+		}
+	}
+	return nil, nil
+}
+
+// Permissions implements utils.PermissionReporter, the buses need the i2c group.
+func (conf *ComponentConfig) Permissions(ctx context.Context) utils.Permissions {
+	buses := make([]int, 0, len(conf.Devices))
+	for _, device := range conf.Devices {
+		buses = append(buses, device.Bus)
+	}
+	return linux.I2CPermissions(buses)
+}
+
+// parseAddress parses a 7-bit address. The reserved addresses at either end of the range are rejected, probing them
+// can put devices into special modes.
+func parseAddress(value string) (uint16, error) {
+	address, err := strconv.ParseUint(value, 0, 16)
+	if err != nil {
+		return 0, fmt.Errorf("invalid address %q, expected e.g. 0x48", value)
+	}
+	if address < 0x08 || address > 0x77 {
+		return 0, fmt.Errorf("address %s is outside 0x08-0x77", value)
+	}
+	return uint16(address), nil
+}
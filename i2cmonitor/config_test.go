@@ -0,0 +1,46 @@
+package i2cmonitor
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseAddress(t *testing.T) {
+	address, err := parseAddress("0x48")
+	require.NoError(t, err)
+	assert.Equal(t, uint16(0x48), address)
+
+	for _, value := range []string{"", "0x03", "0x78", "foo"} {
+		_, err := parseAddress(value)
+		assert.Error(t, err, value)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	oldDevRoot := devRoot
+	defer func() { devRoot = oldDevRoot }()
+	devRoot = t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(devRoot, "i2c-1"), nil, 0o600))
+
+	conf := &ComponentConfig{Devices: []DeviceConfig{{Name: "imu", Bus: 1, Address: "0x68"}, {Name: "adc", Bus: 1, Address: "0x48", Mode: "quick"}}}
+	_, err := conf.Validate("")
+	assert.NoError(t, err)
+
+	invalid := []*ComponentConfig{
+		{},
+		{Devices: []DeviceConfig{{Bus: 1, Address: "0x68"}}},
+		{Devices: []DeviceConfig{{Name: "imu", Bus: 1, Address: "0x68"}, {Name: "imu", Bus: 1, Address: "0x69"}}},
+		{Devices: []DeviceConfig{{Name: "imu", Bus: 1, Address: "0x68", Mode: "write"}}},
+		{Devices: []DeviceConfig{{Name: "imu", Bus: 1, Address: "0x68"}}, MinScanIntervalSec: -1},
+		// The bus isn't enabled
+		{Devices: []DeviceConfig{{Name: "imu", Bus: 2, Address: "0x68"}}},
+	}
+	for _, conf := range invalid {
+		_, err := conf.Validate("")
+		assert.Error(t, err)
+	}
+}
@@ -0,0 +1,181 @@
+package i2cmonitor
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"go.viam.com/rdk/components/sensor"
+	"go.viam.com/rdk/logging"
+	"go.viam.com/rdk/resource"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/linux"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+var (
+	Model       = resource.NewModel(utils.Namespace, "hwmonitor", "i2c_monitor")
+	API         = sensor.API
+	PrettyName  = "I2C Device Presence Monitor"
+	Description = "A sensor that probes the configured I2C addresses and reports whether the expected devices are present"
+	Version     = utils.Version
+)
+
+const (
+	defaultMinScanInterval = 10 * time.Second
+	// Spaces out the probes so a scan doesn't monopolize a bus other components are using
+	probeDelay = 5 * time.Millisecond
+)
+
+type device struct {
+	DeviceConfig
+	address uint16
+}
+
+type Config struct {
+	resource.Named
+	mu              sync.RWMutex
+	logger          logging.Logger
+	cancelCtx       context.Context
+	cancelFunc      func()
+	devices         []device
+	minScanInterval time.Duration
+	lastScan        map[string]interface{}
+	lastScanAt      time.Time
+	// missing tracks which devices are logged as missing, so each change is only logged once
+	missing map[string]bool
+}
+
+func init() {
+	resource.RegisterComponent(
+		API,
+		Model,
+		resource.Registration[sensor.Sensor, *ComponentConfig]{Constructor: utils.FilterReadings(NewSensor)})
+	utils.RegisterSimulator(Model, simulate)
+}
+
+func NewSensor(ctx context.Context, deps resource.Dependencies, conf resource.Config, logger logging.Logger) (sensor.Sensor, error) {
+	logger.Infof("Starting %s %s", PrettyName, Version)
+	cancelCtx, cancelFunc := context.WithCancel(context.Background())
+
+	b := Config{
+		Named:      conf.ResourceName().AsNamed(),
+		logger:     logger,
+		cancelCtx:  cancelCtx,
+		cancelFunc: cancelFunc,
+		mu:         sync.RWMutex{},
+	}
+
+	if err := b.Reconfigure(ctx, deps, conf); err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
+
+func (c *Config) Reconfigure(ctx context.Context, _ resource.Dependencies, rawConf resource.Config) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.logger.Debugf("Reconfiguring %s", PrettyName)
+
+	conf, err := resource.NativeConfig[*ComponentConfig](rawConf)
+	if err != nil {
+		return err
+	}
+
+	// In case the module has changed name
+	c.Named = rawConf.ResourceName().AsNamed()
+
+	devices := make([]device, 0, len(conf.Devices))
+	for _, deviceConf := range conf.Devices {
+		address, err := parseAddress(deviceConf.Address)
+		if err != nil {
+			return err
+		}
+		devices = append(devices, device{DeviceConfig: deviceConf, address: address})
+	}
+	c.devices = devices
+	c.minScanInterval = defaultMinScanInterval
+	if conf.MinScanIntervalSec > 0 {
+		c.minScanInterval = time.Duration(conf.MinScanIntervalSec) * time.Second
+	}
+	c.lastScan = nil
+	c.missing = make(map[string]bool)
+
+	return nil
+}
+
+func (c *Config) Readings(ctx context.Context, extra map[string]interface{}) (map[string]interface{}, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.lastScan != nil && time.Since(c.lastScanAt) < c.minScanInterval {
+		return c.lastScan, nil
+	}
+
+	ret := make(map[string]interface{})
+	missing := make([]string, 0)
+	for i, d := range c.devices {
+		if i > 0 {
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-time.After(probeDelay):
+			}
+		}
+		present, err := c.probe(d, ret)
+		if err != nil {
+			return nil, err
+		}
+		ret[d.Name+"_present"] = present
+		if !present {
+			missing = append(missing, d.Name)
+		}
+		if !present && !c.missing[d.Name] {
+			c.logger.Warnf("I2C device %s is missing from bus %d at %s", d.Name, d.Bus, d.Address)
+		} else if present && c.missing[d.Name] {
+			c.logger.Infof("I2C device %s is back on bus %d at %s", d.Name, d.Bus, d.Address)
+		}
+		c.missing[d.Name] = !present
+	}
+	sort.Strings(missing)
+	ret["present_count"] = len(c.devices) - len(missing)
+	ret["missing_count"] = len(missing)
+	ret["missing"] = strings.Join(missing, ",")
+
+	c.lastScan = ret
+	c.lastScanAt = time.Now()
+	return ret, nil
+}
+
+// probe probes a device, a device bound to a kernel driver is reported as present without probing it unless force is
+// set, the driver has already found it.
+func (c *Config) probe(d device, ret map[string]interface{}) (bool, error) {
+	if driver := linux.I2CDriver(linux.I2CDevicesRoot, d.Bus, d.address); driver != "" {
+		ret[d.Name+"_driver"] = driver
+		if !d.Force {
+			return true, nil
+		}
+	}
+	mode := linux.I2CProbeRead
+	if d.Mode == string(linux.I2CProbeQuick) {
+		mode = linux.I2CProbeQuick
+	}
+	present, err := linux.ProbeI2C(d.Bus, d.address, mode, d.Force)
+	if errors.Is(err, linux.ErrI2CAddressBusy) {
+		// Something claimed the address without a sysfs device, e.g. another process with I2C_SLAVE_FORCE
+		return true, nil
+	}
+	return present, err
+}
+
+func (c *Config) Close(ctx context.Context) error {
+	c.logger.Infof("Shutting down %s", PrettyName)
+	c.cancelFunc()
+	return nil
+}
+
+func (c *Config) Ready(ctx context.Context, extra map[string]interface{}) (bool, error) {
+	return false, nil
+}
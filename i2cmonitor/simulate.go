@@ -0,0 +1,19 @@
+package i2cmonitor
+
+import "github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+
+// simulate returns every configured device as present.
+func simulate(sim *utils.Simulation) map[string]interface{} {
+	ret := make(map[string]interface{})
+	conf, ok := sim.Config().(*ComponentConfig)
+	if !ok {
+		return ret
+	}
+	for _, d := range conf.Devices {
+		ret[d.Name+"_present"] = true
+	}
+	ret["present_count"] = len(conf.Devices)
+	ret["missing_count"] = 0
+	ret["missing"] = ""
+	return ret
+}
@@ -0,0 +1,9 @@
+package iiomonitor
+
+type ComponentConfig struct {
+	Devices []string `json:"devices,omitempty"` // IIO device names to report, e.g. ads1015, defaults to every device
+}
+
+func (conf *ComponentConfig) Validate(path string) ([]string, error) {
+	return nil, nil
+}
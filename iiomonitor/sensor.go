@@ -0,0 +1,126 @@
+package iiomonitor
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"sync"
+
+	"go.viam.com/rdk/components/sensor"
+	"go.viam.com/rdk/logging"
+	"go.viam.com/rdk/resource"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/linux"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+var (
+	Model       = resource.NewModel(utils.Namespace, "hwmonitor", "iio_monitor")
+	API         = sensor.API
+	PrettyName  = "Industrial I/O Monitor"
+	Description = "A sensor that reports the scaled channels of Industrial I/O devices such as ADCs, IMUs and light sensors"
+	Version     = utils.Version
+)
+
+type Config struct {
+	resource.Named
+	mu         sync.RWMutex
+	logger     logging.Logger
+	cancelCtx  context.Context
+	cancelFunc func()
+	devices    []string
+}
+
+func init() {
+	resource.RegisterComponent(
+		API,
+		Model,
+		resource.Registration[sensor.Sensor, *ComponentConfig]{Constructor: utils.FilterReadings(NewSensor)})
+	utils.RegisterSimulator(Model, simulate)
+}
+
+func NewSensor(ctx context.Context, deps resource.Dependencies, conf resource.Config, logger logging.Logger) (sensor.Sensor, error) {
+	logger.Infof("Starting %s %s", PrettyName, Version)
+	cancelCtx, cancelFunc := context.WithCancel(context.Background())
+
+	b := Config{
+		Named:      conf.ResourceName().AsNamed(),
+		logger:     logger,
+		cancelCtx:  cancelCtx,
+		cancelFunc: cancelFunc,
+		mu:         sync.RWMutex{},
+	}
+
+	if err := b.Reconfigure(ctx, deps, conf); err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
+
+func (c *Config) Reconfigure(ctx context.Context, _ resource.Dependencies, rawConf resource.Config) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.logger.Debugf("Reconfiguring %s", PrettyName)
+
+	conf, err := resource.NativeConfig[*ComponentConfig](rawConf)
+	if err != nil {
+		return err
+	}
+
+	// In case the module has changed name
+	c.Named = rawConf.ResourceName().AsNamed()
+	c.devices = conf.Devices
+
+	return nil
+}
+
+func (c *Config) Readings(ctx context.Context, extra map[string]interface{}) (map[string]interface{}, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	devices, err := linux.ReadIIODevices(ctx, linux.IIODevicesRoot)
+	if err != nil {
+		return nil, err
+	}
+	if len(c.devices) > 0 {
+		devices = slices.DeleteFunc(devices, func(d linux.IIODevice) bool {
+			return !slices.Contains(c.devices, d.Name)
+		})
+	}
+	return deviceReadings(devices), nil
+}
+
+// deviceReadings keys the channels by device name, e.g. ads1015_voltage0. When two devices share a name, e.g. two
+// ADCs of the same model, the IIO device number is appended to the name.
+func deviceReadings(devices []linux.IIODevice) map[string]interface{} {
+	counts := make(map[string]int)
+	for _, d := range devices {
+		counts[d.Name]++
+	}
+	ret := map[string]interface{}{
+		"device_count": len(devices),
+	}
+	for _, d := range devices {
+		number := strings.TrimPrefix(d.Device, "iio:device")
+		prefix := d.Name
+		switch {
+		case d.Name == "":
+			prefix = "device" + number
+		case counts[d.Name] > 1:
+			prefix = d.Name + "_" + number
+		}
+		for channel, value := range d.Channels {
+			ret[prefix+"_"+channel] = value
+		}
+	}
+	return ret
+}
+
+func (c *Config) Close(ctx context.Context) error {
+	c.logger.Infof("Shutting down %s", PrettyName)
+	c.cancelFunc()
+	return nil
+}
+
+func (c *Config) Ready(ctx context.Context, extra map[string]interface{}) (bool, error) {
+	return false, nil
+}
@@ -0,0 +1,25 @@
+package iiomonitor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/linux"
+)
+
+func TestDeviceReadings(t *testing.T) {
+	readings := deviceReadings([]linux.IIODevice{
+		{Device: "iio:device0", Name: "ads1015", Channels: map[string]float64{"voltage0": 3.3}},
+		{Device: "iio:device1", Name: "ads1015", Channels: map[string]float64{"voltage0": 1.2}},
+		{Device: "iio:device2", Name: "bh1750", Channels: map[string]float64{"illuminance": 250}},
+		{Device: "iio:device3", Channels: map[string]float64{"temp": 40.5}},
+	})
+	assert.Equal(t, map[string]interface{}{
+		"device_count":       4,
+		"ads1015_0_voltage0": 3.3,
+		"ads1015_1_voltage0": 1.2,
+		"bh1750_illuminance": 250.0,
+		"device3_temp":       40.5,
+	}, readings)
+}
@@ -0,0 +1,28 @@
+package iiomonitor
+
+import (
+	"slices"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/linux"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+// simulate returns an ADS1015 ADC with a 5V rail on its first input, unless the component only reports other devices.
+func simulate(sim *utils.Simulation) map[string]interface{} {
+	devices := []linux.IIODevice{{
+		Device: "iio:device0",
+		Name:   "ads1015",
+		Channels: map[string]float64{
+			"voltage0": sim.Value(5.08, 5.02, 5.02, 0.02),
+			"voltage1": sim.Value(1.65, 1.65, 1.65, 0.01),
+			"voltage2": 0,
+			"voltage3": 0,
+		},
+	}}
+	if conf, ok := sim.Config().(*ComponentConfig); ok && len(conf.Devices) > 0 {
+		devices = slices.DeleteFunc(devices, func(d linux.IIODevice) bool {
+			return !slices.Contains(conf.Devices, d.Name)
+		})
+	}
+	return deviceReadings(devices)
+}
@@ -0,0 +1,41 @@
+package influxexporter
+
+// lineBuffer holds lines until they are written, dropping the oldest once it is full so a robot that is offline for
+// days keeps its most recent readings.
+type lineBuffer struct {
+	lines   []string
+	max     int
+	dropped int
+}
+
+func newLineBuffer(max int) *lineBuffer {
+	return &lineBuffer{max: max}
+}
+
+func (b *lineBuffer) add(lines ...string) {
+	b.lines = append(b.lines, lines...)
+	if over := len(b.lines) - b.max; over > 0 {
+		b.dropped += over
+		b.lines = append(b.lines[:0], b.lines[over:]...)
+	}
+}
+
+// batch returns up to n of the oldest lines without removing them.
+func (b *lineBuffer) batch(n int) []string {
+	if n > len(b.lines) {
+		n = len(b.lines)
+	}
+	return b.lines[:n]
+}
+
+// remove removes the n oldest lines once they are written.
+func (b *lineBuffer) remove(n int) {
+	if n > len(b.lines) {
+		n = len(b.lines)
+	}
+	b.lines = append(b.lines[:0], b.lines[n:]...)
+}
+
+func (b *lineBuffer) len() int {
+	return len(b.lines)
+}
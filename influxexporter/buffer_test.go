@@ -0,0 +1,25 @@
+package influxexporter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLineBuffer(t *testing.T) {
+	b := newLineBuffer(3)
+	b.add("a", "b")
+	assert.Equal(t, []string{"a"}, b.batch(1))
+	assert.Equal(t, []string{"a", "b"}, b.batch(5))
+
+	b.add("c", "d", "e")
+	assert.Equal(t, 3, b.len())
+	assert.Equal(t, 2, b.dropped)
+	assert.Equal(t, []string{"c", "d", "e"}, b.batch(3))
+
+	b.remove(2)
+	assert.Equal(t, []string{"e"}, b.batch(3))
+	b.remove(5)
+	assert.Equal(t, 0, b.len())
+	assert.Empty(t, b.batch(3))
+}
@@ -0,0 +1,49 @@
+package influxexporter
+
+import (
+	"errors"
+)
+
+type ComponentConfig struct {
+	URL                string            `json:"url"`                        // e.g. http://influxdb:8086
+	Org                string            `json:"org,omitempty"`              // InfluxDB 2.x
+	Bucket             string            `json:"bucket,omitempty"`           // InfluxDB 2.x
+	Token              string            `json:"token,omitempty"`            // InfluxDB 2.x, selects the v2 API
+	Database           string            `json:"database,omitempty"`         // InfluxDB 1.x
+	RetentionPolicy    string            `json:"retention_policy,omitempty"` // InfluxDB 1.x, defaults to the database's default
+	Username           string            `json:"username,omitempty"`         // InfluxDB 1.x
+	Password           string            `json:"password,omitempty"`         // InfluxDB 1.x
+	CAFile             string            `json:"ca_file,omitempty"`          // Verifies the server with this CA rather than the system roots
+	CertFile           string            `json:"cert_file,omitempty"`        // Client certificate for servers that require one
+	KeyFile            string            `json:"key_file,omitempty"`
+	InsecureSkipVerify bool              `json:"insecure_skip_verify,omitempty"` // Don't verify the server's certificate
+	Sensors            []string          `json:"sensors"`                        // The sensors whose readings are written, each is a measurement
+	Tags               map[string]string `json:"tags,omitempty"`                 // Added to every point along with host
+	IntervalSec        float64           `json:"interval_sec,omitempty"`         // How often readings are taken, defaults to 10
+	FlushIntervalSec   float64           `json:"flush_interval_sec,omitempty"`   // How often buffered lines are written, defaults to 60
+	BatchSize          int               `json:"batch_size,omitempty"`           // Lines per write, defaults to 5000
+	MaxBufferLines     int               `json:"max_buffer_lines,omitempty"`     // Lines kept while InfluxDB is unreachable, defaults to 100000
+}
+
+func (conf *ComponentConfig) Validate(path string) ([]string, error) {
+	if conf.URL == "" {
+		return nil, errors.New("url is required")
+	}
+	if conf.Token != "" {
+		if conf.Org == "" || conf.Bucket == "" {
+			return nil, errors.New("org and bucket are required with token")
+		}
+	} else if conf.Database == "" {
+		return nil, errors.New("either token, org and bucket for InfluxDB 2.x or database for InfluxDB 1.x is required")
+	}
+	if len(conf.Sensors) == 0 {
+		return nil, errors.New("at least one sensor is required")
+	}
+	if (conf.CertFile == "") != (conf.KeyFile == "") {
+		return nil, errors.New("cert_file and key_file must be set together")
+	}
+	if conf.IntervalSec < 0 || conf.FlushIntervalSec < 0 || conf.BatchSize < 0 || conf.MaxBufferLines < 0 {
+		return nil, errors.New("interval_sec, flush_interval_sec, batch_size and max_buffer_lines must not be negative")
+	}
+	return conf.Sensors, nil
+}
@@ -0,0 +1,29 @@
+package influxexporter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidate(t *testing.T) {
+	for _, conf := range []*ComponentConfig{
+		{URL: "http://influxdb:8086", Token: "secret", Org: "factory", Bucket: "robots", Sensors: []string{"cpu"}},
+		{URL: "http://influxdb:8086", Database: "robots", Sensors: []string{"cpu"}},
+	} {
+		deps, err := conf.Validate("")
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"cpu"}, deps)
+	}
+
+	for _, conf := range []*ComponentConfig{
+		{Database: "robots", Sensors: []string{"cpu"}},
+		{URL: "http://influxdb:8086", Sensors: []string{"cpu"}},
+		{URL: "http://influxdb:8086", Token: "secret", Sensors: []string{"cpu"}},
+		{URL: "http://influxdb:8086", Database: "robots"},
+		{URL: "http://influxdb:8086", Database: "robots", Sensors: []string{"cpu"}, BatchSize: -1},
+	} {
+		_, err := conf.Validate("")
+		assert.Error(t, err, conf)
+	}
+}
@@ -0,0 +1,310 @@
+package influxexporter
+
+import (
+	"context"
+	"errors"
+	"os"
+	"sync"
+	"time"
+
+	"go.viam.com/rdk/components/sensor"
+	"go.viam.com/rdk/logging"
+	"go.viam.com/rdk/resource"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/influx"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+var (
+	Model       = resource.NewModel(utils.Namespace, "hwmonitor", "influxdb_exporter")
+	API         = sensor.API
+	PrettyName  = "InfluxDB Exporter"
+	Description = "A sensor that writes the readings of other sensors to InfluxDB, buffering them while InfluxDB is unreachable"
+	Version     = utils.Version
+)
+
+const (
+	defaultInterval       = 10 * time.Second
+	defaultFlushInterval  = time.Minute
+	defaultBatchSize      = 5000
+	defaultMaxBufferLines = 100000
+	readTimeout           = 5 * time.Second
+	writeTimeout          = 30 * time.Second
+)
+
+// exporter is the InfluxDB write client and what's written with it, the tags added to every line and how often lines
+// are collected and flushed. The lines waiting to be written are kept in the Config's buffer instead, so they survive
+// a reconfigure that replaces the exporter.
+type exporter struct {
+	client        *influx.Client
+	sources       []utils.Source
+	tags          map[string]string
+	interval      time.Duration
+	flushInterval time.Duration
+	batchSize     int
+}
+
+type Config struct {
+	resource.Named
+	configLock    sync.Mutex
+	readingsLock  sync.RWMutex
+	logger        logging.Logger
+	collectTask   *utils.Task
+	flushTask     *utils.Task
+	exporter      *exporter
+	buffer        *lineBuffer
+	writtenLines  int
+	rejectedLines int
+	failedWrites  int
+	lastWrite     time.Time
+	lastErr       error
+}
+
+func init() {
+	resource.RegisterComponent(
+		API,
+		Model,
+		resource.Registration[sensor.Sensor, *ComponentConfig]{Constructor: utils.FilterReadings(NewSensor)})
+}
+
+func NewSensor(ctx context.Context, deps resource.Dependencies, conf resource.Config, logger logging.Logger) (sensor.Sensor, error) {
+	logger.Infof("Starting %s %s", PrettyName, Version)
+	b := Config{
+		Named:  conf.ResourceName().AsNamed(),
+		logger: logger,
+	}
+
+	if err := b.Reconfigure(ctx, deps, conf); err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
+
+func (c *Config) Reconfigure(ctx context.Context, deps resource.Dependencies, rawConf resource.Config) error {
+	c.configLock.Lock()
+	defer c.configLock.Unlock()
+	c.logger.Debugf("Reconfiguring %s", PrettyName)
+
+	c.stopExporting()
+
+	conf, err := resource.NativeConfig[*ComponentConfig](rawConf)
+	if err != nil {
+		return err
+	}
+
+	// In case the component has changed name
+	c.Named = rawConf.ResourceName().AsNamed()
+
+	e, err := newExporter(conf, deps)
+	if err != nil {
+		return err
+	}
+	maxBufferLines := conf.MaxBufferLines
+	if maxBufferLines == 0 {
+		maxBufferLines = defaultMaxBufferLines
+	}
+
+	c.readingsLock.Lock()
+	// Lines still buffered from before are kept, they may be all there is from a long outage
+	if c.buffer == nil {
+		c.buffer = newLineBuffer(maxBufferLines)
+	} else {
+		old := c.buffer
+		c.buffer = newLineBuffer(maxBufferLines)
+		c.buffer.add(old.lines...)
+		c.buffer.dropped += old.dropped
+	}
+	c.readingsLock.Unlock()
+
+	c.exporter = e
+	c.collectTask = utils.ScheduleAligned(e.interval, utils.ReadingsSource, func(ctx context.Context) {
+		c.collect(ctx, e)
+	})
+	c.flushTask = utils.Schedule(e.flushInterval, func(ctx context.Context) {
+		c.flush(ctx, e)
+	})
+	return nil
+}
+
+func newExporter(conf *ComponentConfig, deps resource.Dependencies) (*exporter, error) {
+	sources, err := utils.ResolveSources(deps, conf.Sensors)
+	if err != nil {
+		return nil, err
+	}
+
+	host, err := os.Hostname()
+	if err != nil {
+		return nil, err
+	}
+	tags := map[string]string{"host": host}
+	for key, value := range conf.Tags {
+		tags[key] = value
+	}
+
+	tlsConfig, err := utils.NewTLSConfig(conf.CAFile, conf.CertFile, conf.KeyFile, conf.InsecureSkipVerify)
+	if err != nil {
+		return nil, err
+	}
+	client, err := influx.NewClient(influx.Options{
+		URL:             conf.URL,
+		Org:             conf.Org,
+		Bucket:          conf.Bucket,
+		Token:           conf.Token,
+		Database:        conf.Database,
+		RetentionPolicy: conf.RetentionPolicy,
+		Username:        conf.Username,
+		Password:        conf.Password,
+		TLSConfig:       tlsConfig,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	e := &exporter{
+		client:        client,
+		sources:       sources,
+		tags:          tags,
+		interval:      time.Duration(conf.IntervalSec * float64(time.Second)),
+		flushInterval: time.Duration(conf.FlushIntervalSec * float64(time.Second)),
+		batchSize:     conf.BatchSize,
+	}
+	if e.interval <= 0 {
+		e.interval = defaultInterval
+	}
+	if e.flushInterval <= 0 {
+		e.flushInterval = defaultFlushInterval
+	}
+	if e.batchSize <= 0 {
+		e.batchSize = defaultBatchSize
+	}
+	return e, nil
+}
+
+// stopExporting stops polling and closes the client, configLock must be held.
+func (c *Config) stopExporting() {
+	if c.collectTask == nil {
+		return
+	}
+	c.logger.Debug("Stopping polling")
+	c.collectTask.Stop()
+	c.flushTask.Stop()
+	c.exporter.client.Close()
+	c.collectTask, c.flushTask, c.exporter = nil, nil, nil
+	c.logger.Debugf("Polling stopped")
+}
+
+// collect buffers the readings of every source, the scheduler calls it every interval.
+func (c *Config) collect(ctx context.Context, e *exporter) {
+	now := time.Now()
+	lines := make([]string, 0, len(e.sources))
+	for _, s := range e.sources {
+		readCtx, cancel := context.WithTimeout(ctx, readTimeout)
+		readings, err := s.Sensor.Readings(readCtx, nil)
+		cancel()
+		if err != nil {
+			c.logger.Debugf("Failed to read %s: %v", s.Name, err)
+			continue
+		}
+		point := influx.Point{Measurement: s.Name, Tags: e.tags, Fields: fields(readings), Time: now}
+		if line := point.Line(); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	c.readingsLock.Lock()
+	c.buffer.add(lines...)
+	c.readingsLock.Unlock()
+}
+
+// fields converts readings to fields, the numeric readings as floats and the top level strings as strings.
+func fields(readings map[string]interface{}) map[string]interface{} {
+	ret := make(map[string]interface{})
+	for key, value := range utils.NumericReadings(readings) {
+		ret[key] = value
+	}
+	for key, value := range readings {
+		if s, ok := value.(string); ok {
+			ret[key] = s
+		}
+	}
+	return ret
+}
+
+// flush writes the buffered lines a batch at a time, stopping at the first failure so the rest are retried at the
+// next flush. The scheduler calls it every flush interval, it may run while collect is buffering readings.
+func (c *Config) flush(ctx context.Context, e *exporter) {
+	for {
+		c.readingsLock.RLock()
+		// The batch is copied, collect may append to the buffer while it is being written
+		batch := append([]string{}, c.buffer.batch(e.batchSize)...)
+		dropped := c.buffer.dropped
+		c.readingsLock.RUnlock()
+		if len(batch) == 0 {
+			return
+		}
+
+		writeCtx, cancel := context.WithTimeout(ctx, writeTimeout)
+		err := e.client.Write(writeCtx, batch)
+		cancel()
+
+		c.readingsLock.Lock()
+		// If the buffer overflowed during the write, some of the batch has already been dropped
+		written := max(len(batch)-(c.buffer.dropped-dropped), 0)
+		switch {
+		case err == nil:
+			if c.lastErr != nil {
+				c.logger.Infof("Writing to InfluxDB again, %d lines buffered", c.buffer.len())
+			}
+			c.buffer.remove(written)
+			c.writtenLines += len(batch)
+			c.lastWrite = time.Now()
+			c.lastErr = nil
+		case errors.Is(err, influx.ErrRejected):
+			c.logger.Warnf("InfluxDB rejected %d lines, dropping them: %v", len(batch), err)
+			c.buffer.remove(written)
+			c.rejectedLines += len(batch)
+			c.lastErr = err
+		default:
+			if c.lastErr == nil {
+				c.logger.Warnf("Failed to write to InfluxDB, buffering until it is reachable: %v", err)
+			}
+			c.failedWrites++
+			c.lastErr = err
+		}
+		c.readingsLock.Unlock()
+		if err != nil && !errors.Is(err, influx.ErrRejected) {
+			return
+		}
+	}
+}
+
+func (c *Config) Readings(ctx context.Context, extra map[string]interface{}) (map[string]interface{}, error) {
+	c.readingsLock.RLock()
+	defer c.readingsLock.RUnlock()
+	ret := map[string]interface{}{
+		"buffered_lines": c.buffer.len(),
+		"dropped_lines":  c.buffer.dropped,
+		"written_lines":  c.writtenLines,
+		"rejected_lines": c.rejectedLines,
+		"failed_writes":  c.failedWrites,
+	}
+	if !c.lastWrite.IsZero() {
+		ret["last_write_time"] = c.lastWrite.Format(time.RFC3339)
+	}
+	if c.lastErr != nil {
+		ret["last_error"] = c.lastErr.Error()
+	}
+	return ret, nil
+}
+
+func (c *Config) Close(ctx context.Context) error {
+	c.configLock.Lock()
+	defer c.configLock.Unlock()
+	c.logger.Infof("Shutting down %s", PrettyName)
+	c.stopExporting()
+	c.logger.Infof("%s Shutdown complete", PrettyName)
+	return nil
+}
+
+func (c *Config) Ready(ctx context.Context, extra map[string]interface{}) (bool, error) {
+	return false, nil
+}
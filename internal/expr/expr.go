@@ -0,0 +1,381 @@
+package expr
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Expr is a parsed arithmetic expression over named variables, e.g. "volts * amps" or "max(cpu, gpu) - 40". It
+// supports numbers, variables, + - * / % and ^ (power), parentheses and the functions abs, ceil, floor, max, min,
+// round and sqrt.
+type Expr struct {
+	root node
+	vars []string
+}
+
+var functions = map[string]struct {
+	args int // -1 for one or more
+	fn   func(args []float64) float64
+}{
+	"abs":   {1, func(a []float64) float64 { return math.Abs(a[0]) }},
+	"ceil":  {1, func(a []float64) float64 { return math.Ceil(a[0]) }},
+	"floor": {1, func(a []float64) float64 { return math.Floor(a[0]) }},
+	"round": {1, func(a []float64) float64 { return math.Round(a[0]) }},
+	"sqrt":  {1, func(a []float64) float64 { return math.Sqrt(a[0]) }},
+	"max": {-1, func(a []float64) float64 {
+		ret := a[0]
+		for _, v := range a[1:] {
+			ret = math.Max(ret, v)
+		}
+		return ret
+	}},
+	"min": {-1, func(a []float64) float64 {
+		ret := a[0]
+		for _, v := range a[1:] {
+			ret = math.Min(ret, v)
+		}
+		return ret
+	}},
+}
+
+// Parse parses an expression.
+func Parse(s string) (*Expr, error) {
+	tokens, err := tokenize(s)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{tokens: tokens}
+	root, err := p.expr()
+	if err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t.kind != tokenEnd {
+		return nil, fmt.Errorf("unexpected %q at %d", t.text, t.pos)
+	}
+	seen := make(map[string]bool)
+	root.vars(seen)
+	vars := make([]string, 0, len(seen))
+	for v := range seen {
+		vars = append(vars, v)
+	}
+	sort.Strings(vars)
+	return &Expr{root: root, vars: vars}, nil
+}
+
+// Vars returns the variables the expression uses, sorted.
+func (e *Expr) Vars() []string {
+	return e.vars
+}
+
+// Eval evaluates the expression. It fails if a variable is missing, on division by zero, and if the result isn't a
+// finite number, e.g. the square root of a negative number.
+func (e *Expr) Eval(vars map[string]float64) (float64, error) {
+	v, err := e.root.eval(vars)
+	if err != nil {
+		return 0, err
+	}
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return 0, errors.New("the result isn't a finite number")
+	}
+	return v, nil
+}
+
+type node interface {
+	eval(vars map[string]float64) (float64, error)
+	vars(seen map[string]bool)
+}
+
+type number float64
+
+func (n number) eval(map[string]float64) (float64, error) { return float64(n), nil }
+func (n number) vars(map[string]bool)                     {}
+
+type variable string
+
+func (v variable) eval(vars map[string]float64) (float64, error) {
+	value, ok := vars[string(v)]
+	if !ok {
+		return 0, fmt.Errorf("%s has no value", string(v))
+	}
+	return value, nil
+}
+
+func (v variable) vars(seen map[string]bool) { seen[string(v)] = true }
+
+type negate struct{ operand node }
+
+func (n negate) eval(vars map[string]float64) (float64, error) {
+	v, err := n.operand.eval(vars)
+	return -v, err
+}
+
+func (n negate) vars(seen map[string]bool) { n.operand.vars(seen) }
+
+type binary struct {
+	op          byte
+	left, right node
+}
+
+func (b binary) eval(vars map[string]float64) (float64, error) {
+	l, err := b.left.eval(vars)
+	if err != nil {
+		return 0, err
+	}
+	r, err := b.right.eval(vars)
+	if err != nil {
+		return 0, err
+	}
+	switch b.op {
+	case '+':
+		return l + r, nil
+	case '-':
+		return l - r, nil
+	case '*':
+		return l * r, nil
+	case '/':
+		if r == 0 {
+			return 0, errors.New("division by zero")
+		}
+		return l / r, nil
+	case '%':
+		if r == 0 {
+			return 0, errors.New("division by zero")
+		}
+		return math.Mod(l, r), nil
+	default:
+		return math.Pow(l, r), nil
+	}
+}
+
+func (b binary) vars(seen map[string]bool) {
+	b.left.vars(seen)
+	b.right.vars(seen)
+}
+
+type call struct {
+	name string
+	args []node
+}
+
+func (c call) eval(vars map[string]float64) (float64, error) {
+	args := make([]float64, len(c.args))
+	for i, arg := range c.args {
+		v, err := arg.eval(vars)
+		if err != nil {
+			return 0, err
+		}
+		args[i] = v
+	}
+	return functions[c.name].fn(args), nil
+}
+
+func (c call) vars(seen map[string]bool) {
+	for _, arg := range c.args {
+		arg.vars(seen)
+	}
+}
+
+type tokenKind int
+
+const (
+	tokenEnd tokenKind = iota
+	tokenNumber
+	tokenIdent
+	tokenOp // One of + - * / % ^ ( ) ,
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+func tokenize(s string) ([]token, error) {
+	var tokens []token
+	for i := 0; i < len(s); {
+		c := rune(s[i])
+		switch {
+		case unicode.IsSpace(c):
+			i++
+		case unicode.IsDigit(c) || c == '.':
+			start := i
+			for i < len(s) && (unicode.IsDigit(rune(s[i])) || s[i] == '.') {
+				i++
+			}
+			// Exponents, e.g. 1e-3
+			if i < len(s) && (s[i] == 'e' || s[i] == 'E') {
+				i++
+				if i < len(s) && (s[i] == '+' || s[i] == '-') {
+					i++
+				}
+				for i < len(s) && unicode.IsDigit(rune(s[i])) {
+					i++
+				}
+			}
+			tokens = append(tokens, token{kind: tokenNumber, text: s[start:i], pos: start})
+		case unicode.IsLetter(c) || c == '_':
+			start := i
+			for i < len(s) && (unicode.IsLetter(rune(s[i])) || unicode.IsDigit(rune(s[i])) || s[i] == '_') {
+				i++
+			}
+			tokens = append(tokens, token{kind: tokenIdent, text: s[start:i], pos: start})
+		case strings.ContainsRune("+-*/%^(),", c):
+			tokens = append(tokens, token{kind: tokenOp, text: string(c), pos: i})
+			i++
+		default:
+			return nil, fmt.Errorf("unexpected %q at %d", c, i)
+		}
+	}
+	return append(tokens, token{kind: tokenEnd, text: "end of expression", pos: len(s)}), nil
+}
+
+// parser is a recursive descent parser, from the lowest precedence: + and -, then * / and %, then unary - and
+// finally ^, which is right associative, so -2^2 is -4 and 2^3^2 is 512.
+type parser struct {
+	tokens []token
+}
+
+func (p *parser) peek() token {
+	return p.tokens[0]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[0]
+	if t.kind != tokenEnd {
+		p.tokens = p.tokens[1:]
+	}
+	return t
+}
+
+func (p *parser) isOp(ops string) bool {
+	t := p.peek()
+	return t.kind == tokenOp && strings.Contains(ops, t.text)
+}
+
+func (p *parser) expect(op string) error {
+	if t := p.next(); t.kind != tokenOp || t.text != op {
+		return fmt.Errorf("expected %q at %d, found %q", op, t.pos, t.text)
+	}
+	return nil
+}
+
+func (p *parser) expr() (node, error) {
+	left, err := p.term()
+	if err != nil {
+		return nil, err
+	}
+	for p.isOp("+-") {
+		op := p.next().text[0]
+		right, err := p.term()
+		if err != nil {
+			return nil, err
+		}
+		left = binary{op: op, left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) term() (node, error) {
+	left, err := p.unary()
+	if err != nil {
+		return nil, err
+	}
+	for p.isOp("*/%") {
+		op := p.next().text[0]
+		right, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		left = binary{op: op, left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) unary() (node, error) {
+	if p.isOp("-+") {
+		op := p.next().text
+		operand, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		if op == "-" {
+			return negate{operand: operand}, nil
+		}
+		return operand, nil
+	}
+	return p.power()
+}
+
+func (p *parser) power() (node, error) {
+	base, err := p.primary()
+	if err != nil {
+		return nil, err
+	}
+	if !p.isOp("^") {
+		return base, nil
+	}
+	p.next()
+	exponent, err := p.unary()
+	if err != nil {
+		return nil, err
+	}
+	return binary{op: '^', left: base, right: exponent}, nil
+}
+
+func (p *parser) primary() (node, error) {
+	t := p.next()
+	switch {
+	case t.kind == tokenNumber:
+		v, err := strconv.ParseFloat(t.text, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %q at %d", t.text, t.pos)
+		}
+		return number(v), nil
+	case t.kind == tokenIdent && p.isOp("("):
+		return p.call(t)
+	case t.kind == tokenIdent:
+		return variable(t.text), nil
+	case t.kind == tokenOp && t.text == "(":
+		inner, err := p.expr()
+		if err != nil {
+			return nil, err
+		}
+		return inner, p.expect(")")
+	default:
+		return nil, fmt.Errorf("unexpected %q at %d", t.text, t.pos)
+	}
+}
+
+func (p *parser) call(name token) (node, error) {
+	fn, ok := functions[name.text]
+	if !ok {
+		return nil, fmt.Errorf("unknown function %s at %d", name.text, name.pos)
+	}
+	p.next()
+	c := call{name: name.text}
+	if !p.isOp(")") {
+		for {
+			arg, err := p.expr()
+			if err != nil {
+				return nil, err
+			}
+			c.args = append(c.args, arg)
+			if !p.isOp(",") {
+				break
+			}
+			p.next()
+		}
+	}
+	if err := p.expect(")"); err != nil {
+		return nil, err
+	}
+	if (fn.args == -1 && len(c.args) == 0) || (fn.args > 0 && len(c.args) != fn.args) {
+		return nil, fmt.Errorf("wrong number of arguments to %s at %d", name.text, name.pos)
+	}
+	return c, nil
+}
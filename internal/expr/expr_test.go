@@ -0,0 +1,61 @@
+package expr
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEval(t *testing.T) {
+	vars := map[string]float64{"volts": 5.1, "amps": 2, "temp_limit": 85, "soc_temp": 61.5, "cpu": 50, "gpu": 70}
+	for expression, expected := range map[string]float64{
+		"volts * amps":           10.2,
+		"temp_limit - soc_temp":  23.5,
+		"1 + 2 * 3":              7,
+		"(1 + 2) * 3":            9,
+		"10 - 4 - 3":             3,
+		"12 / 4 / 3":             1,
+		"7 % 4":                  3,
+		"2 ^ 3 ^ 2":              512,
+		"-2 ^ 2":                 -4,
+		"2 ^ -1":                 0.5,
+		"--3":                    3,
+		"+3":                     3,
+		"1.5e3 / 1E3":            1.5,
+		"max(cpu, gpu, 60) - 40": 30,
+		"min(cpu, gpu)":          50,
+		"abs(cpu - gpu)":         20,
+		"round(soc_temp) + floor(volts) + ceil(volts)": 62 + 5 + 6,
+		"sqrt(16)":    4,
+		" amps*amps ": 4,
+	} {
+		e, err := Parse(expression)
+		require.NoError(t, err, expression)
+		v, err := e.Eval(vars)
+		require.NoError(t, err, expression)
+		assert.InDelta(t, expected, v, 1e-9, expression)
+	}
+}
+
+func TestVars(t *testing.T) {
+	e, err := Parse("max(volts * amps, volts) / 2")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"amps", "volts"}, e.Vars())
+}
+
+func TestEvalErrors(t *testing.T) {
+	for _, expression := range []string{"watts", "1 / (amps - amps)", "amps % 0", "sqrt(-amps)"} {
+		e, err := Parse(expression)
+		require.NoError(t, err, expression)
+		_, err = e.Eval(map[string]float64{"amps": 2})
+		assert.Error(t, err, expression)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, expression := range []string{"", "1 +", "(1 + 2", "1 + 2)", "volts amps", "volts $ amps", "log(2)", "abs(1, 2)", "max()", "1..2", "*2", "max(1,)"} {
+		_, err := Parse(expression)
+		assert.Error(t, err, expression)
+	}
+}
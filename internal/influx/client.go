@@ -0,0 +1,106 @@
+package influx
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// ErrRejected is returned when InfluxDB rejects the data itself, so retrying the same lines will never succeed.
+var ErrRejected = errors.New("rejected by InfluxDB")
+
+// Options configure where lines are written. Token selects the v2 API, otherwise the v1 API is used.
+type Options struct {
+	// URL is the server's base URL, e.g. http://influxdb:8086
+	URL string
+	// Org, Bucket and Token are for InfluxDB 2.x and Cloud
+	Org    string
+	Bucket string
+	Token  string
+	// Database, RetentionPolicy, Username and Password are for InfluxDB 1.x
+	Database        string
+	RetentionPolicy string
+	Username        string
+	Password        string
+	TLSConfig       *tls.Config
+}
+
+// Client writes line protocol over HTTP.
+type Client struct {
+	client   *http.Client
+	writeURL string
+	options  Options
+}
+
+func NewClient(opts Options) (*Client, error) {
+	u, err := url.Parse(opts.URL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid url: %w", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("url must be http:// or https://, got %s", opts.URL)
+	}
+	query := url.Values{"precision": {"ns"}}
+	path := strings.TrimSuffix(u.Path, "/")
+	if opts.Token != "" {
+		u.Path = path + "/api/v2/write"
+		query.Set("org", opts.Org)
+		query.Set("bucket", opts.Bucket)
+	} else {
+		u.Path = path + "/write"
+		query.Set("db", opts.Database)
+		if opts.RetentionPolicy != "" {
+			query.Set("rp", opts.RetentionPolicy)
+		}
+	}
+	u.RawQuery = query.Encode()
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = opts.TLSConfig
+	return &Client{
+		client:   &http.Client{Transport: transport},
+		writeURL: u.String(),
+		options:  opts,
+	}, nil
+}
+
+// Write sends lines to InfluxDB. Errors wrapping ErrRejected mean the lines are bad, anything else is worth retrying.
+func (c *Client) Write(ctx context.Context, lines []string) error {
+	body := strings.Join(lines, "\n")
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.writeURL, bytes.NewBufferString(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "text/plain; charset=utf-8")
+	if c.options.Token != "" {
+		req.Header.Set("Authorization", "Token "+c.options.Token)
+	} else if c.options.Username != "" {
+		req.SetBasicAuth(c.options.Username, c.options.Password)
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 == 2 {
+		return nil
+	}
+	message, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+	err = fmt.Errorf("InfluxDB returned %s: %s", resp.Status, strings.TrimSpace(string(message)))
+	// 400 is bad line protocol and 413 a batch that's too large, neither improves by retrying. Authentication
+	// failures are retried, the token may be fixed without restarting.
+	if resp.StatusCode == http.StatusBadRequest || resp.StatusCode == http.StatusRequestEntityTooLarge {
+		return errors.Join(ErrRejected, err)
+	}
+	return err
+}
+
+func (c *Client) Close() {
+	c.client.CloseIdleConnections()
+}
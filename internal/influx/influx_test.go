@@ -0,0 +1,82 @@
+package influx
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLine(t *testing.T) {
+	point := Point{
+		Measurement: "cpu monitor",
+		Tags:        map[string]string{"host": "robot1", "site": "line 2,east", "empty": ""},
+		Fields: map[string]interface{}{
+			"usage":     12.5,
+			"count":     float64(3),
+			"throttled": true,
+			"governor":  `on"demand\`,
+			"ignored":   []string{"a"},
+		},
+		Time: time.Unix(1700000000, 5),
+	}
+	assert.Equal(t, `cpu\ monitor,host=robot1,site=line\ 2\,east count=3,governor="on\"demand\\",throttled=true,usage=12.5 1700000000000000005`, point.Line())
+
+	point.Fields = map[string]interface{}{"ignored": 1}
+	assert.Equal(t, "", point.Line())
+}
+
+func TestClient(t *testing.T) {
+	var request *http.Request
+	var body string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		request = r
+		data, _ := io.ReadAll(r.Body)
+		body = string(data)
+		switch body {
+		case "bad":
+			http.Error(w, `{"message":"unable to parse"}`, http.StatusBadRequest)
+		case "unavailable":
+			http.Error(w, "", http.StatusServiceUnavailable)
+		default:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer server.Close()
+
+	v2, err := NewClient(Options{URL: server.URL, Org: "factory", Bucket: "robots", Token: "secret"})
+	require.NoError(t, err)
+	require.NoError(t, v2.Write(context.Background(), []string{"a x=1 1", "b x=2 2"}))
+	assert.Equal(t, "/api/v2/write", request.URL.Path)
+	assert.Equal(t, "factory", request.URL.Query().Get("org"))
+	assert.Equal(t, "robots", request.URL.Query().Get("bucket"))
+	assert.Equal(t, "ns", request.URL.Query().Get("precision"))
+	assert.Equal(t, "Token secret", request.Header.Get("Authorization"))
+	assert.Equal(t, "a x=1 1\nb x=2 2", body)
+
+	err = v2.Write(context.Background(), []string{"bad"})
+	assert.ErrorIs(t, err, ErrRejected)
+	assert.ErrorContains(t, err, "unable to parse")
+	err = v2.Write(context.Background(), []string{"unavailable"})
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, ErrRejected)
+
+	v1, err := NewClient(Options{URL: server.URL + "/", Database: "robots", RetentionPolicy: "autogen", Username: "user", Password: "pass"})
+	require.NoError(t, err)
+	require.NoError(t, v1.Write(context.Background(), []string{"a x=1 1"}))
+	assert.Equal(t, "/write", request.URL.Path)
+	assert.Equal(t, "robots", request.URL.Query().Get("db"))
+	assert.Equal(t, "autogen", request.URL.Query().Get("rp"))
+	username, password, ok := request.BasicAuth()
+	assert.True(t, ok)
+	assert.Equal(t, "user", username)
+	assert.Equal(t, "pass", password)
+
+	_, err = NewClient(Options{URL: "influxdb:8086"})
+	assert.Error(t, err)
+}
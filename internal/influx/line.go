@@ -0,0 +1,70 @@
+package influx
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	measurementEscaper = strings.NewReplacer(",", `\,`, " ", `\ `)
+	keyEscaper         = strings.NewReplacer(",", `\,`, "=", `\=`, " ", `\ `)
+	stringEscaper      = strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+)
+
+// Point is a single line of line protocol.
+type Point struct {
+	Measurement string
+	Tags        map[string]string
+	// Fields are float64, bool or string values
+	Fields map[string]interface{}
+	Time   time.Time
+}
+
+// Line encodes the point as line protocol with a nanosecond timestamp, e.g.
+// cpu,host=robot1 usage=12.5,governor="ondemand" 1700000000000000000
+// It returns an empty string for a point without fields, which InfluxDB would reject.
+func (p *Point) Line() string {
+	fields := make([]string, 0, len(p.Fields))
+	for _, key := range sortedKeys(p.Fields) {
+		var value string
+		switch v := p.Fields[key].(type) {
+		case float64:
+			value = strconv.FormatFloat(v, 'g', -1, 64)
+		case bool:
+			value = strconv.FormatBool(v)
+		case string:
+			value = `"` + stringEscaper.Replace(v) + `"`
+		default:
+			continue
+		}
+		fields = append(fields, keyEscaper.Replace(key)+"="+value)
+	}
+	if len(fields) == 0 {
+		return ""
+	}
+
+	var line strings.Builder
+	line.WriteString(measurementEscaper.Replace(p.Measurement))
+	for _, key := range sortedKeys(p.Tags) {
+		// Empty tag values aren't allowed
+		if p.Tags[key] == "" {
+			continue
+		}
+		line.WriteString("," + keyEscaper.Replace(key) + "=" + keyEscaper.Replace(p.Tags[key]))
+	}
+	line.WriteString(" " + strings.Join(fields, ","))
+	line.WriteString(" " + strconv.FormatInt(p.Time.UnixNano(), 10))
+	return line.String()
+}
+
+// sortedKeys sorts the keys so a point always encodes the same way, InfluxDB also prefers tags in key order.
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,68 @@
+package allwinner
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.viam.com/rdk/logging"
+)
+
+func TestGetTemperatures(t *testing.T) {
+	temps, err := getTemperatures(context.Background(), "testdata/h616/thermal")
+	require.NoError(t, err)
+	require.NotNil(t, temps.CPU)
+	assert.Equal(t, 52.5, *temps.CPU)
+	require.NotNil(t, temps.GPU)
+	assert.Equal(t, 50.75, *temps.GPU)
+	assert.Equal(t, map[string]float64{"VE": 51, "DDR": 49.5}, temps.Extra)
+}
+
+func TestGetPowerSensors(t *testing.T) {
+	supplies, err := getPowerSensors(context.Background(), logging.NewTestLogger(t), "testdata/h616/power_supply")
+	require.NoError(t, err)
+	require.Len(t, supplies, 2)
+
+	battery := supplies[0]
+	assert.Equal(t, "battery", battery.GetName())
+	readings, err := battery.GetReadingMap(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 3.95, readings["voltage"])
+	assert.Equal(t, 0.42, readings["current"])
+	assert.Equal(t, int64(87), readings["capacity"])
+	assert.Equal(t, "Discharging", readings["status"])
+
+	usb := supplies[1]
+	assert.Equal(t, "usb", usb.GetName())
+	readings, err = usb.GetReadingMap(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"online": false, "health": "Good"}, readings)
+	_, _, _, err = usb.GetReading(context.Background())
+	assert.Error(t, err)
+}
+
+func TestGetClockSensors(t *testing.T) {
+	oppRoot := t.TempDir()
+	for rate, microvolts := range map[string]string{"1008000000": "950000", "1512000000": "1100000"} {
+		// debugfs names these opp:<rate>, the name isn't used so avoid the colon
+		dir := filepath.Join(oppRoot, "cpu0", "opp_"+rate)
+		require.NoError(t, os.MkdirAll(dir, 0o755))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "rate_hz"), []byte(rate+"\n"), 0o644))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "u_volt_target"), []byte(microvolts+"\n"), 0o644))
+	}
+
+	clocks, err := getClockSensors(context.Background(), logging.NewTestLogger(t), "testdata/h616/cpufreq", oppRoot)
+	require.NoError(t, err)
+	require.Len(t, clocks, 1)
+	readings, err := clocks[0].GetReadingMap(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"cpu":           int64(1008000000),
+		"cpu_opp_count": 6,
+		"cpu_opp_max":   int64(1512000000),
+		"cpu_voltage":   0.95,
+	}, readings)
+}
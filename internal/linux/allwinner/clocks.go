@@ -0,0 +1,125 @@
+package allwinner
+
+import (
+	"context"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"go.viam.com/rdk/logging"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/linux"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/sensors"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+// The OPP table of each CPU is only exposed through debugfs, one directory per operating point with the rate in Hz
+// and the target voltage in uV. Newer kernels move the voltage into a supply-0 subdirectory.
+const oppDebugRoot = "/sys/kernel/debug/opp"
+
+type allwinnerClockSensor struct {
+	logger     logging.Logger
+	mu         sync.RWMutex
+	name       string
+	policyPath string
+	// opps maps the frequencies of the OPP table to their voltage, it's empty when debugfs isn't readable
+	opps map[int64]float64
+}
+
+func (s *allwinnerClockSensor) Close() error {
+	return nil
+}
+
+func (s *allwinnerClockSensor) Name() string {
+	return s.name
+}
+
+func (s *allwinnerClockSensor) GetReadingMap(ctx context.Context) (map[string]interface{}, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	current, err := utils.ReadInt64FromFileWithContext(ctx, filepath.Join(s.policyPath, "scaling_cur_freq"))
+	if err != nil {
+		s.logger.Errorw("failed to read cpufreq", "sensor", s.name, "error", err)
+		return nil, err
+	}
+	frequency := current * 1000
+	ret := map[string]interface{}{
+		s.name: frequency,
+	}
+	if available, err := utils.ReadFileWithContext(ctx, filepath.Join(s.policyPath, "scaling_available_frequencies")); err == nil {
+		frequencies := strings.Fields(available)
+		ret[s.name+"_opp_count"] = len(frequencies)
+		var highest int64
+		for _, f := range frequencies {
+			if value, err := utils.ParseInt64(f); err == nil {
+				highest = max(highest, value*1000)
+			}
+		}
+		ret[s.name+"_opp_max"] = highest
+	}
+	if voltage, ok := s.opps[frequency]; ok {
+		ret[s.name+"_voltage"] = voltage
+	}
+	return ret, nil
+}
+
+// readOPPTable reads the OPP table of a CPU from debugfs, e.g. /sys/kernel/debug/opp/cpu0.
+func readOPPTable(ctx context.Context, path string) (map[int64]float64, error) {
+	entries, err := utils.ReadDir(path)
+	if err != nil {
+		return nil, err
+	}
+	opps := make(map[int64]float64)
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		dir := filepath.Join(path, entry.Name())
+		rate, err := utils.ReadInt64FromFileWithContext(ctx, filepath.Join(dir, "rate_hz"))
+		if err != nil {
+			continue
+		}
+		microvolts, err := utils.ReadInt64FromFileWithContext(ctx, filepath.Join(dir, "supply-0", "u_volt_target"))
+		if err != nil {
+			if microvolts, err = utils.ReadInt64FromFileWithContext(ctx, filepath.Join(dir, "u_volt_target")); err != nil {
+				continue
+			}
+		}
+		opps[rate] = float64(microvolts) / 1e6
+	}
+	return opps, nil
+}
+
+func GetClockSensors(ctx context.Context, logger logging.Logger) ([]sensors.ClockSensor, error) {
+	return getClockSensors(ctx, logger, linux.CPUFreqRoot, oppDebugRoot)
+}
+
+func getClockSensors(ctx context.Context, logger logging.Logger, cpufreqRoot, oppRoot string) ([]sensors.ClockSensor, error) {
+	policies, err := linux.ReadCPUFreqPolicies(ctx, cpufreqRoot)
+	if err != nil {
+		return nil, err
+	}
+	s := make([]sensors.ClockSensor, 0)
+	for _, policy := range policies {
+		// The H-series and A64 SoCs have a single cluster
+		name := "cpu"
+		if len(policies) > 1 {
+			name = "cpu_" + policy.Name
+		}
+		var opps map[int64]float64
+		if len(policy.CPUs) > 0 {
+			opps, err = readOPPTable(ctx, filepath.Join(oppRoot, "cpu"+strconv.Itoa(policy.CPUs[0])))
+			if err != nil {
+				logger.Debugf("OPP table for %s is not available: %v", policy.Name, err)
+			}
+		}
+		s = append(s, &allwinnerClockSensor{
+			logger:     logger.Sublogger(name),
+			name:       name,
+			policyPath: filepath.Join(cpufreqRoot, policy.Name),
+			opps:       opps,
+		})
+	}
+	return s, nil
+}
@@ -0,0 +1,94 @@
+package allwinner
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"go.viam.com/rdk/logging"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/linux"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/sensors"
+)
+
+// axpPowerSensor reads one power supply of an X-Powers AXP PMIC, e.g. axp20x-battery, axp20x-ac or axp20x-usb.
+type axpPowerSensor struct {
+	logger logging.Logger
+	root   string
+	supply string
+	name   string
+}
+
+func (s *axpPowerSensor) Close() error {
+	return nil
+}
+
+func (s *axpPowerSensor) GetName() string {
+	return s.name
+}
+
+func (s *axpPowerSensor) GetReading(ctx context.Context) (voltage, current, power float64, err error) {
+	supply, err := linux.ReadPowerSupply(ctx, s.root, s.supply)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	if supply.Voltage == nil || supply.Current == nil {
+		return 0, 0, 0, fmt.Errorf("%s does not report voltage and current", s.supply)
+	}
+	return *supply.Voltage, *supply.Current, *supply.Voltage * *supply.Current, nil
+}
+
+func (s *axpPowerSensor) GetReadingMap(ctx context.Context) (map[string]interface{}, error) {
+	supply, err := linux.ReadPowerSupply(ctx, s.root, s.supply)
+	if err != nil {
+		return nil, err
+	}
+	ret := make(map[string]interface{})
+	if supply.Voltage != nil {
+		ret["voltage"] = *supply.Voltage
+	}
+	if supply.Current != nil {
+		ret["current"] = *supply.Current
+	}
+	if supply.Voltage != nil && supply.Current != nil {
+		ret["power"] = *supply.Voltage * *supply.Current
+	}
+	if supply.Online != nil {
+		ret["online"] = *supply.Online
+	}
+	if supply.Capacity != nil {
+		ret["capacity"] = *supply.Capacity
+	}
+	if supply.Status != "" {
+		ret["status"] = supply.Status
+	}
+	if supply.Health != "" {
+		ret["health"] = supply.Health
+	}
+	return ret, nil
+}
+
+func GetPowerSensors(ctx context.Context, logger logging.Logger) ([]sensors.PowerSensor, error) {
+	return getPowerSensors(ctx, logger, linux.PowerSupplyRoot)
+}
+
+func getPowerSensors(ctx context.Context, logger logging.Logger, root string) ([]sensors.PowerSensor, error) {
+	supplies, err := linux.ReadPowerSupplies(ctx, root)
+	if err != nil {
+		return nil, err
+	}
+	ret := make([]sensors.PowerSensor, 0)
+	for _, supply := range supplies {
+		if !strings.HasPrefix(supply.Name, "axp") {
+			continue
+		}
+		// axp20x-battery is reported as battery, the PMIC model doesn't matter to the reader
+		_, name, found := strings.Cut(supply.Name, "-")
+		if !found {
+			name = supply.Name
+		}
+		logger.Infof("Creating AXP power sensor for %s", supply.Name)
+		ret = append(ret, &axpPowerSensor{logger: logger, root: root, supply: supply.Name, name: name})
+	}
+	return ret, nil
+}
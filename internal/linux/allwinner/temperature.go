@@ -0,0 +1,59 @@
+package allwinner
+
+import (
+	"context"
+	"strings"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/linux"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/sensors"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+// allwinnerThermalZones maps the THS sensors to reading names. Mainline kernels name the zones cpu-thermal or
+// cpu_thermal, the Orange Pi vendor kernels for the H616 use cpul_thermal_zone, so the suffix is stripped before
+// matching.
+var allwinnerThermalZones = map[string]string{
+	"cpu":  "CPU",
+	"cpul": "CPU",
+	"gpu":  "GPU",
+	"gpu0": "GPU",
+	"gpu1": "GPU1",
+	"ve":   "VE",
+	"ddr":  "DDR",
+}
+
+func GetTemperatures(ctx context.Context) (*sensors.SystemTemperatures, error) {
+	return getTemperatures(ctx, linux.ThermalZonesRoot)
+}
+
+func getTemperatures(ctx context.Context, root string) (*sensors.SystemTemperatures, error) {
+	zones, err := linux.ReadThermalZones(ctx, root)
+	if err != nil {
+		return nil, err
+	}
+	systemTemps := &sensors.SystemTemperatures{Extra: make(map[string]float64)}
+	for zoneType, temp := range zones {
+		name, ok := allwinnerThermalZones[thermalZoneBase(zoneType)]
+		if !ok {
+			continue
+		}
+		temp = utils.RoundValue(temp, 2)
+		switch name {
+		case "CPU":
+			systemTemps.CPU = &temp
+		case "GPU":
+			systemTemps.GPU = &temp
+		default:
+			systemTemps.Extra[name] = temp
+		}
+	}
+	return systemTemps, nil
+}
+
+func thermalZoneBase(zoneType string) string {
+	zoneType = strings.ReplaceAll(zoneType, "-", "_")
+	for _, suffix := range []string{"_thermal_zone", "_thermal"} {
+		zoneType = strings.TrimSuffix(zoneType, suffix)
+	}
+	return zoneType
+}
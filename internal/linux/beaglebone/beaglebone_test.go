@@ -0,0 +1,26 @@
+package beaglebone
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetTemperatures(t *testing.T) {
+	temps, err := getTemperatures(context.Background(), "testdata/thermal/ai64")
+	require.NoError(t, err)
+	require.NotNil(t, temps.CPU)
+	assert.Equal(t, 46.2, *temps.CPU)
+	require.NotNil(t, temps.GPU)
+	assert.Equal(t, 45.0, *temps.GPU)
+	assert.Equal(t, map[string]float64{"WKUP": 44.4, "C7X": 45.6}, temps.Extra)
+
+	temps, err = getTemperatures(context.Background(), "testdata/thermal/am62")
+	require.NoError(t, err)
+	require.NotNil(t, temps.CPU)
+	assert.Equal(t, 39.8, *temps.CPU)
+	assert.Nil(t, temps.GPU)
+	assert.Equal(t, map[string]float64{"MAIN1": 40.1}, temps.Extra)
+}
@@ -0,0 +1,42 @@
+package beaglebone
+
+import (
+	"context"
+	"strings"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/linux"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/sensors"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+// The TDA4VM on the AI-64 names its zones after the block they sit in, e.g. mpu-thermal and gpu-thermal, the AM62 only
+// has main0-thermal and main1-thermal. The AM335x on the Black has no on-die sensor supported by the kernel.
+var cpuThermalZones = []string{"mpu", "main0", "cpu"}
+
+func GetTemperatures(ctx context.Context) (*sensors.SystemTemperatures, error) {
+	return getTemperatures(ctx, linux.ThermalZonesRoot)
+}
+
+func getTemperatures(ctx context.Context, root string) (*sensors.SystemTemperatures, error) {
+	zones, err := linux.ReadThermalZones(ctx, root)
+	if err != nil {
+		return nil, err
+	}
+	systemTemps := &sensors.SystemTemperatures{Extra: make(map[string]float64)}
+	for zoneType, temp := range zones {
+		name := strings.TrimSuffix(strings.TrimSuffix(zoneType, "-thermal"), "_thermal")
+		systemTemps.Extra[strings.ToUpper(name)] = utils.RoundValue(temp, 2)
+	}
+	for _, name := range cpuThermalZones {
+		if temp, ok := systemTemps.Extra[strings.ToUpper(name)]; ok {
+			delete(systemTemps.Extra, strings.ToUpper(name))
+			systemTemps.CPU = &temp
+			break
+		}
+	}
+	if temp, ok := systemTemps.Extra["GPU"]; ok {
+		delete(systemTemps.Extra, "GPU")
+		systemTemps.GPU = &temp
+	}
+	return systemTemps, nil
+}
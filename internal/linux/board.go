@@ -0,0 +1,163 @@
+package linux
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+// BoardFamily groups boards that share the same telemetry backends.
+type BoardFamily string
+
+const (
+	BoardFamilyRaspberryPi BoardFamily = "raspberrypi"
+	BoardFamilyJetson      BoardFamily = "jetson"
+	BoardFamilyRockchip    BoardFamily = "rockchip"
+	BoardFamilyAllwinner   BoardFamily = "allwinner"
+	BoardFamilyAmlogic     BoardFamily = "amlogic"
+	BoardFamilyBeagleBone  BoardFamily = "beaglebone"
+	BoardFamilyODROID      BoardFamily = "odroid"
+	BoardFamilyIMX         BoardFamily = "imx"
+	BoardFamilyPC          BoardFamily = "pc"
+	BoardFamilyGeneric     BoardFamily = "generic"
+)
+
+// BoardInfo identifies the board the module is running on.
+type BoardInfo struct {
+	Family BoardFamily
+	Vendor string
+	Model  string
+	// Revision is the board revision when the firmware reports one, e.g. the Raspberry Pi revision code c03114
+	Revision string
+	SoC      string
+	// Compatible lists the device tree compatible strings, most specific first
+	Compatible []string
+	// Source is device-tree or dmi
+	Source string
+}
+
+// Families are matched against the compatible strings in order, board vendors come before SoC vendors so an ODROID
+// with an Amlogic SoC is reported as an ODROID.
+var boardFamilyRules = []struct {
+	family BoardFamily
+	match  func(compatible string) bool
+}{
+	{BoardFamilyODROID, func(c string) bool { return strings.HasPrefix(c, "hardkernel,odroid") }},
+	{BoardFamilyBeagleBone, func(c string) bool {
+		return strings.HasPrefix(c, "beagle,") || strings.Contains(c, "beaglebone") || strings.HasPrefix(c, "ti,am335x-bone")
+	}},
+	{BoardFamilyRaspberryPi, func(c string) bool {
+		return strings.HasPrefix(c, "raspberrypi,") || strings.HasPrefix(c, "brcm,bcm2")
+	}},
+	{BoardFamilyJetson, func(c string) bool { return strings.HasPrefix(c, "nvidia,tegra") }},
+	{BoardFamilyRockchip, func(c string) bool { return strings.HasPrefix(c, "rockchip,") }},
+	{BoardFamilyAllwinner, func(c string) bool { return strings.HasPrefix(c, "allwinner,") }},
+	{BoardFamilyAmlogic, func(c string) bool { return strings.HasPrefix(c, "amlogic,") }},
+	{BoardFamilyIMX, func(c string) bool { return strings.HasPrefix(c, "fsl,imx") }},
+}
+
+var modelRevisionRegex = regexp.MustCompile(`\s+Rev\s+(\S+)$`)
+
+var (
+	boardOnce sync.Once
+	board     *BoardInfo
+)
+
+// Board returns the board the module is running on, it is identified once and then cached. Boards that can't be
+// identified are reported as generic so callers can always fall back to the generic backends.
+func Board() *BoardInfo {
+	boardOnce.Do(func() {
+		info, err := IdentifyBoard(context.Background())
+		if err != nil {
+			info = &BoardInfo{Family: BoardFamilyGeneric}
+		}
+		board = info
+	})
+	return board
+}
+
+// IsFamily returns true if the module is running on a board of the family.
+func IsFamily(family BoardFamily) bool {
+	return Board().Family == family
+}
+
+// IdentifyBoard reads the device tree, or DMI on boards without one such as x86.
+func IdentifyBoard(ctx context.Context) (*BoardInfo, error) {
+	return identifyBoard(ctx, "/proc/device-tree", "/sys/class/dmi/id")
+}
+
+func identifyBoard(ctx context.Context, deviceTreeRoot, dmiRoot string) (*BoardInfo, error) {
+	if _, err := utils.Stat(deviceTreeRoot); err == nil {
+		return identifyDeviceTreeBoard(ctx, deviceTreeRoot)
+	}
+	if _, err := utils.Stat(dmiRoot); err == nil {
+		return identifyDMIBoard(ctx, dmiRoot), nil
+	}
+	return nil, fmt.Errorf("neither %s nor %s exist", deviceTreeRoot, dmiRoot)
+}
+
+func identifyDeviceTreeBoard(ctx context.Context, root string) (*BoardInfo, error) {
+	model, err := ReadDeviceTreeString(ctx, filepath.Join(root, "model"))
+	if err != nil {
+		return nil, err
+	}
+	info := &BoardInfo{Family: BoardFamilyGeneric, Model: model, Source: "device-tree"}
+	if compatible, err := utils.ReadFileWithContext(ctx, filepath.Join(root, "compatible")); err == nil {
+		for _, c := range strings.Split(compatible, "\x00") {
+			if c != "" {
+				info.Compatible = append(info.Compatible, c)
+			}
+		}
+	}
+	if len(info.Compatible) > 0 {
+		// The first entry is the board and the last is the SoC, e.g. raspberrypi,4-model-b and brcm,bcm2711
+		info.Vendor, _, _ = strings.Cut(info.Compatible[0], ",")
+		_, info.SoC, _ = strings.Cut(info.Compatible[len(info.Compatible)-1], ",")
+	}
+rules:
+	for _, rule := range boardFamilyRules {
+		for _, c := range info.Compatible {
+			if rule.match(c) {
+				info.Family = rule.family
+				break rules
+			}
+		}
+	}
+
+	// Raspberry Pi firmware stores the revision code, other boards often put it at the end of the model
+	if revision, err := utils.ReadFile(filepath.Join(root, "system", "linux,revision")); err == nil && len(revision) == 4 {
+		info.Revision = fmt.Sprintf("%x", binary.BigEndian.Uint32(revision))
+	} else if matches := modelRevisionRegex.FindStringSubmatch(model); matches != nil {
+		info.Revision = matches[1]
+	}
+	return info, nil
+}
+
+func identifyDMIBoard(ctx context.Context, root string) *BoardInfo {
+	read := func(name string) string {
+		value, _ := utils.ReadFileWithContext(ctx, filepath.Join(root, name))
+		return value
+	}
+	info := &BoardInfo{
+		Family:   BoardFamilyPC,
+		Vendor:   read("sys_vendor"),
+		Model:    read("product_name"),
+		Revision: read("product_version"),
+		Source:   "dmi",
+	}
+	// Some mini PCs only fill in the board fields
+	if info.Model == "" || info.Model == "Default string" {
+		info.Model = read("board_name")
+		info.Revision = read("board_version")
+	}
+	if info.Vendor == "" || info.Vendor == "Default string" {
+		info.Vendor = read("board_vendor")
+	}
+	return info
+}
@@ -0,0 +1,36 @@
+package linux
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIdentifyBoard(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected BoardInfo
+	}{
+		{"rpi4", BoardInfo{Family: BoardFamilyRaspberryPi, Vendor: "raspberrypi", Model: "Raspberry Pi 4 Model B Rev 1.4", Revision: "c03114", SoC: "bcm2711", Source: "device-tree"}},
+		{"orin", BoardInfo{Family: BoardFamilyJetson, Vendor: "nvidia", Model: "NVIDIA Jetson Orin Nano Developer Kit", SoC: "tegra234", Source: "device-tree"}},
+		{"odroidn2", BoardInfo{Family: BoardFamilyODROID, Vendor: "hardkernel", Model: "Hardkernel ODROID-N2Plus", SoC: "g12b", Source: "device-tree"}},
+		{"rock5b", BoardInfo{Family: BoardFamilyRockchip, Vendor: "radxa", Model: "Radxa ROCK 5 Model B", SoC: "rk3588", Source: "device-tree"}},
+		{"bbb", BoardInfo{Family: BoardFamilyBeagleBone, Vendor: "ti", Model: "TI AM335x BeagleBone Black Rev 00C0", Revision: "00C0", SoC: "am33xx", Source: "device-tree"}},
+		{"pc", BoardInfo{Family: BoardFamilyPC, Vendor: "Intel Corporation", Model: "NUC11TNBi5", Revision: "M11904-403", Source: "dmi"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := filepath.Join("testdata", "board", tt.name)
+			info, err := identifyBoard(context.Background(), filepath.Join(root, "device-tree"), filepath.Join(root, "dmi"))
+			require.NoError(t, err)
+			info.Compatible = nil
+			assert.Equal(t, tt.expected, *info)
+		})
+	}
+
+	_, err := identifyBoard(context.Background(), "testdata/board/missing/device-tree", "testdata/board/missing/dmi")
+	assert.Error(t, err)
+}
@@ -0,0 +1,61 @@
+package linux
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+// ReadBootID returns the random id the kernel generates on every boot.
+func ReadBootID(ctx context.Context) (string, error) {
+	return utils.ReadFileWithContext(ctx, "/proc/sys/kernel/random/boot_id")
+}
+
+// ReadUptime returns the time since boot from /proc/uptime. Unlike the monotonic clock Go uses this includes the time
+// the system was suspended.
+func ReadUptime(ctx context.Context) (time.Duration, error) {
+	data, err := utils.ReadFileWithContext(ctx, "/proc/uptime")
+	if err != nil {
+		return 0, err
+	}
+	return parseUptime(data)
+}
+
+func parseUptime(data string) (time.Duration, error) {
+	fields := strings.Fields(data)
+	if len(fields) == 0 {
+		return 0, fmt.Errorf("unexpected contents of /proc/uptime %q", data)
+	}
+	seconds, err := strconv.ParseFloat(fields[0], 64)
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(seconds * float64(time.Second)), nil
+}
+
+// PreviousBootShutdownClean checks the journal of the previous boot for the messages systemd logs while shutting
+// down. It returns false for ok when the journal isn't persistent or has no previous boot.
+func PreviousBootShutdownClean(ctx context.Context) (clean bool, ok bool) {
+	if _, err := exec.LookPath("journalctl"); err != nil {
+		return false, false
+	}
+	out, err := utils.Output(ctx, "journalctl", "-b", "-1", "-n", "100", "-o", "cat", "-q", "--no-pager")
+	if err != nil || strings.TrimSpace(string(out)) == "" {
+		return false, false
+	}
+	return journalShowsShutdown(string(out)), true
+}
+
+func journalShowsShutdown(out string) bool {
+	for _, marker := range []string{"Reached target System Power Off", "Reached target System Reboot", "Reached target System Halt", "Reached target Power-Off", "Reached target Reboot", "Journal stopped"} {
+		if strings.Contains(out, marker) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,23 @@
+package linux
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJournalShowsShutdown(t *testing.T) {
+	assert.True(t, journalShowsShutdown("Stopped target Basic System.\nReached target System Reboot.\nShutting down.\nJournal stopped"))
+	assert.False(t, journalShowsShutdown("Started Session 4 of User pi.\nusb 1-1.2: new high-speed USB device number 5"))
+}
+
+func TestParseUptime(t *testing.T) {
+	uptime, err := parseUptime("12345.67 45678.90\n")
+	require.NoError(t, err)
+	assert.Equal(t, 12345670*time.Millisecond, uptime)
+
+	_, err = parseUptime("")
+	assert.Error(t, err)
+}
@@ -0,0 +1,79 @@
+package linux
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+const Video4LinuxRoot = "/sys/class/video4linux"
+
+// CSICamera is a camera sensor attached over MIPI CSI-2.
+type CSICamera struct {
+	// Model is the sensor driver, e.g. imx708 for a Raspberry Pi Camera Module 3
+	Model string
+	// I2C is the bus and address of the sensor's control interface, e.g. 10-001a
+	I2C string
+	// Device is the node applications open, e.g. /dev/v4l-subdev2, or /dev/video0 on a Jetson
+	Device       string
+	DeviceExists bool
+}
+
+// Sensor subdevices are named after their driver and I2C client, e.g. "imx708 10-001a". On the Jetson the capture
+// node carries the sensor's name, e.g. "vi-output, imx219 9-0010".
+var csiSensorNameRegex = regexp.MustCompile(`^(?:vi-output, )?([a-z0-9_]+) (\d+-[0-9a-f]{4})$`)
+
+// Lens focus motors are I2C subdevices too, they are part of a camera module rather than cameras
+var lensDrivers = map[string]bool{
+	"ak7375": true,
+	"dw9714": true,
+	"dw9768": true,
+	"dw9807": true,
+}
+
+// ReadCSICameras finds the camera sensors under root, normally Video4LinuxRoot, and checks their device nodes exist
+// under devRoot. Sensors are found from the kernel's V4L2 devices, so a camera is reported as soon as its driver
+// probes, whether or not libcamera can use it.
+func ReadCSICameras(ctx context.Context, root, devRoot string) ([]CSICamera, error) {
+	entries, err := utils.ReadDir(root)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	cameras := make(map[string]*CSICamera)
+	for _, entry := range entries {
+		name, err := utils.ReadFileWithContext(ctx, filepath.Join(root, entry.Name(), "name"))
+		if err != nil {
+			continue
+		}
+		matches := csiSensorNameRegex.FindStringSubmatch(name)
+		if matches == nil || lensDrivers[matches[1]] {
+			continue
+		}
+		device := filepath.Join(devRoot, entry.Name())
+		camera, ok := cameras[matches[2]]
+		if !ok {
+			camera = &CSICamera{Model: matches[1], I2C: matches[2]}
+			cameras[matches[2]] = camera
+		}
+		// Prefer the capture node when a sensor has both, that is the one applications open
+		if camera.Device == "" || strings.HasPrefix(entry.Name(), "video") {
+			camera.Device = device
+			_, err := utils.Stat(device)
+			camera.DeviceExists = err == nil
+		}
+	}
+	ret := make([]CSICamera, 0, len(cameras))
+	for _, camera := range cameras {
+		ret = append(ret, *camera)
+	}
+	sort.Slice(ret, func(i, j int) bool { return ret[i].I2C < ret[j].I2C })
+	return ret, nil
+}
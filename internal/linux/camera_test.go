@@ -0,0 +1,27 @@
+package linux
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadCSICameras(t *testing.T) {
+	cameras, err := ReadCSICameras(context.Background(), "testdata/camera/rpi5/sys", "testdata/camera/rpi5/dev")
+	require.NoError(t, err)
+	assert.Equal(t, []CSICamera{
+		{Model: "imx708", I2C: "10-001a", Device: "testdata/camera/rpi5/dev/v4l-subdev0", DeviceExists: true},
+		// The second camera's driver probed but its device node is missing
+		{Model: "imx296", I2C: "11-001a", Device: "testdata/camera/rpi5/dev/v4l-subdev3", DeviceExists: false},
+	}, cameras)
+
+	cameras, err = ReadCSICameras(context.Background(), "testdata/camera/jetson/sys", "testdata/camera/jetson/dev")
+	require.NoError(t, err)
+	assert.Equal(t, []CSICamera{{Model: "imx219", I2C: "9-0010", Device: "testdata/camera/jetson/dev/video0", DeviceExists: true}}, cameras)
+
+	cameras, err = ReadCSICameras(context.Background(), "testdata/camera/missing", "testdata/camera/missing")
+	require.NoError(t, err)
+	assert.Empty(t, cameras)
+}
@@ -0,0 +1,145 @@
+package linux
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+// cgroupRoot is where the cgroup v2 hierarchy is mounted.
+const cgroupRoot = "/sys/fs/cgroup"
+
+// CgroupStats is what a cgroup v2 reports of its processes' resource use. The files of controllers that aren't enabled
+// for the cgroup are missing, their fields are nil.
+type CgroupStats struct {
+	// CPU is cpu.stat, e.g. usage_usec and, with the cpu controller, nr_throttled and throttled_usec
+	CPU map[string]uint64
+	// MemoryCurrent is memory.current
+	MemoryCurrent *uint64
+	// MemoryPeak is memory.peak, kernels before 5.19 don't have it
+	MemoryPeak *uint64
+	// MemoryMax is memory.max, nil when it's max
+	MemoryMax *uint64
+	// MemoryEvents is memory.events, e.g. oom_kill
+	MemoryEvents map[string]uint64
+	// IO is io.stat keyed by device, e.g. sda, and then by counter, e.g. rbytes
+	IO map[string]map[string]uint64
+	// PidsCurrent is pids.current
+	PidsCurrent *uint64
+}
+
+// CgroupPath returns the path of a cgroup v2, either absolute or relative to /sys/fs/cgroup.
+func CgroupPath(cgroup string) string {
+	if filepath.IsAbs(cgroup) {
+		return cgroup
+	}
+	return filepath.Join(cgroupRoot, cgroup)
+}
+
+// CgroupName returns the name a cgroup's readings are prefixed with, its path as configured with underscores for
+// slashes, e.g. system.slice_viam-agent.service.
+func CgroupName(cgroup string) string {
+	return strings.Trim(strings.ReplaceAll(cgroup, "/", "_"), "_")
+}
+
+// ReadCgroupStats returns the resource use of a cgroup v2.
+func ReadCgroupStats(ctx context.Context, cgroup string) (CgroupStats, error) {
+	path := CgroupPath(cgroup)
+	var ret CgroupStats
+	data, err := utils.ReadFileWithContext(ctx, filepath.Join(path, "cpu.stat"))
+	if errors.Is(err, fs.ErrNotExist) {
+		if _, statErr := utils.Stat(filepath.Join(cgroupRoot, "cgroup.controllers")); statErr != nil {
+			return ret, fmt.Errorf("%w: cgroup v2 isn't mounted at %s", utils.ErrPlatformNotSupported, cgroupRoot)
+		}
+		return ret, fmt.Errorf("cgroup %s doesn't exist: %w", cgroup, err)
+	}
+	if err != nil {
+		return ret, err
+	}
+	if ret.CPU, err = parseFlatKeyedFile(data); err != nil {
+		return ret, fmt.Errorf("cpu.stat: %w", err)
+	}
+	for file, value := range map[string]**uint64{
+		"memory.current": &ret.MemoryCurrent,
+		"memory.peak":    &ret.MemoryPeak,
+		"memory.max":     &ret.MemoryMax,
+		"pids.current":   &ret.PidsCurrent,
+	} {
+		if *value, err = readCgroupValue(ctx, filepath.Join(path, file)); err != nil {
+			return ret, fmt.Errorf("%s: %w", file, err)
+		}
+	}
+	if ret.MemoryEvents, err = ReadCgroupMemoryEvents(ctx, path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return ret, fmt.Errorf("memory.events: %w", err)
+	}
+	data, err = utils.ReadFileWithContext(ctx, filepath.Join(path, "io.stat"))
+	if err == nil {
+		if ret.IO, err = parseIOStat(data); err != nil {
+			return ret, fmt.Errorf("io.stat: %w", err)
+		}
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return ret, fmt.Errorf("io.stat: %w", err)
+	}
+	return ret, nil
+}
+
+// readCgroupValue reads a single value cgroup file, it's nil when the file doesn't exist or is max.
+func readCgroupValue(ctx context.Context, path string) (*uint64, error) {
+	data, err := utils.ReadFileWithContext(ctx, path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	data = strings.TrimSpace(data)
+	if data == "max" {
+		return nil, nil
+	}
+	value, err := strconv.ParseUint(data, 10, 64)
+	if err != nil {
+		return nil, err
+	}
+	return &value, nil
+}
+
+// parseIOStat parses io.stat, a line per device of its major:minor and key=value counters, e.g.
+// "8:0 rbytes=1459200 wbytes=314773504 rios=192 wios=353 dbytes=0 dios=0". The devices are named from /sys/dev/block.
+func parseIOStat(data string) (map[string]map[string]uint64, error) {
+	ret := make(map[string]map[string]uint64)
+	for _, line := range strings.Split(data, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		counters := make(map[string]uint64, len(fields)-1)
+		for _, field := range fields[1:] {
+			key, value, ok := strings.Cut(field, "=")
+			if !ok {
+				return nil, fmt.Errorf("unexpected io.stat line %q", line)
+			}
+			n, err := strconv.ParseUint(value, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse %s of %s: %w", key, fields[0], err)
+			}
+			counters[key] = n
+		}
+		ret[blockDeviceName(fields[0])] = counters
+	}
+	return ret, nil
+}
+
+// blockDeviceName returns the name of the block device with the major:minor number, or the number when it isn't known.
+func blockDeviceName(majorMinor string) string {
+	link, err := utils.Readlink(filepath.Join("/sys/dev/block", majorMinor))
+	if err != nil {
+		return majorMinor
+	}
+	return filepath.Base(link)
+}
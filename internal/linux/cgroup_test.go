@@ -0,0 +1,62 @@
+package linux
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+func TestReadCgroupStats(t *testing.T) {
+	t.Cleanup(func() { utils.SetFS(utils.OSFS{}) })
+	root := t.TempDir()
+	require.NoError(t, os.CopyFS(filepath.Join(root, "sys/fs/cgroup"), os.DirFS("testdata/sys_fs_cgroup")))
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "sys/dev/block"), 0o755))
+	require.NoError(t, os.Symlink("../../devices/platform/fe340000.mmc/mmc_host/mmc0/mmc0:aaaa/block/mmcblk0", filepath.Join(root, "sys/dev/block/179:0")))
+	utils.SetFS(utils.RootFS{Root: root})
+
+	stats, err := ReadCgroupStats(context.Background(), "system.slice/viam-agent.service")
+	require.NoError(t, err)
+	assert.Equal(t, uint64(81234567), stats.CPU["usage_usec"])
+	assert.Equal(t, uint64(0), stats.CPU["nr_throttled"])
+	require.NotNil(t, stats.MemoryCurrent)
+	assert.Equal(t, uint64(241172480), *stats.MemoryCurrent)
+	require.NotNil(t, stats.MemoryPeak)
+	assert.Equal(t, uint64(398458880), *stats.MemoryPeak)
+	require.NotNil(t, stats.MemoryMax)
+	assert.Equal(t, uint64(1073741824), *stats.MemoryMax)
+	assert.Equal(t, uint64(1), stats.MemoryEvents["oom_kill"])
+	// The device that isn't in /sys/dev/block keeps its number
+	assert.Equal(t, map[string]map[string]uint64{
+		"mmcblk0": {"rbytes": 120586240, "wbytes": 461373440, "rios": 5210, "wios": 20876, "dbytes": 0, "dios": 0},
+		"8:0":     {"rbytes": 4096, "wbytes": 0, "rios": 1, "wios": 0, "dbytes": 0, "dios": 0},
+	}, stats.IO)
+	require.NotNil(t, stats.PidsCurrent)
+	assert.Equal(t, uint64(23), *stats.PidsCurrent)
+
+	// Without a limit, and without the memory controller
+	require.NoError(t, os.WriteFile(filepath.Join(root, "sys/fs/cgroup/system.slice/viam-agent.service/memory.max"), []byte("max\n"), 0o644))
+	require.NoError(t, os.Remove(filepath.Join(root, "sys/fs/cgroup/system.slice/viam-agent.service/memory.peak")))
+	stats, err = ReadCgroupStats(context.Background(), "/sys/fs/cgroup/system.slice/viam-agent.service")
+	require.NoError(t, err)
+	assert.Nil(t, stats.MemoryMax)
+	assert.Nil(t, stats.MemoryPeak)
+
+	_, err = ReadCgroupStats(context.Background(), "system.slice/missing.service")
+	assert.ErrorContains(t, err, "cgroup system.slice/missing.service doesn't exist")
+
+	// cgroup v1 has no cgroup.controllers
+	require.NoError(t, os.Remove(filepath.Join(root, "sys/fs/cgroup/cgroup.controllers")))
+	_, err = ReadCgroupStats(context.Background(), "system.slice/missing.service")
+	assert.Equal(t, utils.ErrorKindHardwareAbsent, utils.ErrorKind(err))
+}
+
+func TestCgroupName(t *testing.T) {
+	assert.Equal(t, "system.slice_viam-agent.service", CgroupName("system.slice/viam-agent.service"))
+	assert.Equal(t, "user.slice", CgroupName("/user.slice/"))
+}
@@ -0,0 +1,76 @@
+package linux
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+// The kinds of cooling devices
+const (
+	CoolingKindFan     = "fan"
+	CoolingKindCpufreq = "cpufreq"
+	CoolingKindDevfreq = "devfreq"
+	CoolingKindOther   = "other"
+)
+
+// CoolingDevice is something the kernel's thermal framework cools a thermal zone with, a fan or a clock it caps.
+// States go from 0, not cooling, to MaxState, e.g. the fan's fastest speed or the lowest frequency.
+type CoolingDevice struct {
+	// Name is the device's directory, e.g. cooling_device0
+	Name string
+	// Type is what the driver calls it, e.g. pwm-fan, cpufreq-cpu0 or fb000000.gpu
+	Type     string
+	Kind     string
+	CurState int64
+	MaxState int64
+}
+
+// ReadCoolingDevices returns every readable cooling device under root, normally ThermalZonesRoot. devfreqRoot, normally
+// DevfreqRoot, tells the devfreq devices apart, newer kernels name them after the device rather than devfreq.
+func ReadCoolingDevices(ctx context.Context, root, devfreqRoot string) ([]CoolingDevice, error) {
+	dirs, err := utils.Glob(filepath.Join(root, "cooling_device*"))
+	if err != nil {
+		return nil, err
+	}
+	devices := make([]CoolingDevice, 0, len(dirs))
+	for _, dir := range dirs {
+		device := CoolingDevice{Name: filepath.Base(dir)}
+		if device.Type, err = utils.ReadFileWithContext(ctx, filepath.Join(dir, "type")); err != nil {
+			continue
+		}
+		// Some drivers fail to read the state of a device that's powered off
+		if device.CurState, err = utils.ReadInt64FromFileWithContext(ctx, filepath.Join(dir, "cur_state")); err != nil {
+			continue
+		}
+		if device.MaxState, err = utils.ReadInt64FromFileWithContext(ctx, filepath.Join(dir, "max_state")); err != nil {
+			continue
+		}
+		device.Kind = coolingKind(device.Type, devfreqRoot)
+		devices = append(devices, device)
+	}
+	return devices, nil
+}
+
+// coolingKind returns the kind of a cooling device from its type, e.g. pwm-fan, cpufreq-cpu0, thermal-cpufreq-0,
+// thermal-devfreq-0 or the name of a devfreq device.
+func coolingKind(coolingType, devfreqRoot string) string {
+	lower := strings.ToLower(coolingType)
+	switch {
+	case strings.Contains(lower, "fan"):
+		return CoolingKindFan
+	case strings.Contains(lower, "cpufreq"):
+		return CoolingKindCpufreq
+	case strings.Contains(lower, "devfreq"):
+		return CoolingKindDevfreq
+	}
+	if coolingType == "" {
+		return CoolingKindOther
+	}
+	if _, err := utils.Stat(filepath.Join(devfreqRoot, coolingType)); err == nil {
+		return CoolingKindDevfreq
+	}
+	return CoolingKindOther
+}
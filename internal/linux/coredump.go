@@ -0,0 +1,113 @@
+package linux
+
+import (
+	"context"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+// SystemdCoredumpDir is where systemd-coredump stores core dumps.
+const SystemdCoredumpDir = "/var/lib/systemd/coredump"
+
+// CoreDump is a core dump file. Executable and PID are only known for files written by systemd-coredump.
+type CoreDump struct {
+	Path       string
+	Executable string
+	PID        int64
+	Time       time.Time
+	Size       int64
+}
+
+// ReadCorePattern returns kernel.core_pattern, a leading | means core dumps are piped to a helper such as systemd-coredump.
+func ReadCorePattern(ctx context.Context) (string, error) {
+	return utils.ReadFileWithContext(ctx, "/proc/sys/kernel/core_pattern")
+}
+
+// ListCoreDumps returns the core dumps in a directory, oldest first.
+func ListCoreDumps(dir string) ([]*CoreDump, error) {
+	entries, err := utils.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	dumps := make([]*CoreDump, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasPrefix(entry.Name(), "core") {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			// The file was removed by systemd-tmpfiles or coredumpctl since the directory was read
+			continue
+		}
+		dump := &CoreDump{
+			Path: filepath.Join(dir, entry.Name()),
+			Time: info.ModTime(),
+			Size: info.Size(),
+		}
+		if executable, pid, at, ok := parseSystemdCoredumpName(entry.Name()); ok {
+			dump.Executable = executable
+			dump.PID = pid
+			dump.Time = at
+		}
+		dumps = append(dumps, dump)
+	}
+	sort.Slice(dumps, func(i, j int) bool {
+		return dumps[i].Time.Before(dumps[j].Time)
+	})
+	return dumps, nil
+}
+
+// parseSystemdCoredumpName parses names like core.viam-server.0.5c6d7f8e9a0b4c1d2e3f4a5b6c7d8e9f.1234.1700000000000000.zst,
+// which are core.<comm>.<uid>.<boot id>.<pid>.<timestamp in usec> followed by an optional compression suffix.
+func parseSystemdCoredumpName(name string) (executable string, pid int64, at time.Time, ok bool) {
+	rest, found := strings.CutPrefix(name, "core.")
+	if !found {
+		return "", 0, time.Time{}, false
+	}
+	parts := strings.Split(rest, ".")
+	switch parts[len(parts)-1] {
+	case "zst", "xz", "lz4":
+		parts = parts[:len(parts)-1]
+	}
+	// The comm can contain dots itself, so parse from the end
+	if len(parts) < 5 {
+		return "", 0, time.Time{}, false
+	}
+	n := len(parts)
+	usec, err := strconv.ParseInt(parts[n-1], 10, 64)
+	if err != nil {
+		return "", 0, time.Time{}, false
+	}
+	pid, err = strconv.ParseInt(parts[n-2], 10, 64)
+	if err != nil {
+		return "", 0, time.Time{}, false
+	}
+	if len(parts[n-3]) != 32 {
+		return "", 0, time.Time{}, false
+	}
+	if _, err := strconv.ParseInt(parts[n-4], 10, 64); err != nil {
+		return "", 0, time.Time{}, false
+	}
+	return unescapeCoredumpComm(strings.Join(parts[:n-4], ".")), pid, time.UnixMicro(usec), true
+}
+
+// unescapeCoredumpComm reverses the \xNN escaping systemd-coredump applies to characters that aren't valid in file names.
+func unescapeCoredumpComm(comm string) string {
+	var b strings.Builder
+	for i := 0; i < len(comm); i++ {
+		if comm[i] == '\\' && i+3 < len(comm) && comm[i+1] == 'x' {
+			if v, err := strconv.ParseUint(comm[i+2:i+4], 16, 8); err == nil {
+				b.WriteByte(byte(v))
+				i += 3
+				continue
+			}
+		}
+		b.WriteByte(comm[i])
+	}
+	return b.String()
+}
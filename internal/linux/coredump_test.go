@@ -0,0 +1,63 @@
+package linux
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSystemdCoredumpName(t *testing.T) {
+	tests := []struct {
+		name       string
+		file       string
+		executable string
+		pid        int64
+		ok         bool
+	}{
+		{"Compressed", "core.viam-server.0.5c6d7f8e9a0b4c1d2e3f4a5b6c7d8e9f.1234.1700000000000000.zst", "viam-server", 1234, true},
+		{"Uncompressed", "core.python3.1000.5c6d7f8e9a0b4c1d2e3f4a5b6c7d8e9f.42.1700000000000000", "python3", 42, true},
+		{"DotInName", "core.python3.11.1000.5c6d7f8e9a0b4c1d2e3f4a5b6c7d8e9f.42.1700000000000000.xz", "python3.11", 42, true},
+		{"Escaped", "core.my\\x2fapp.0.5c6d7f8e9a0b4c1d2e3f4a5b6c7d8e9f.7.1700000000000000.lz4", "my/app", 7, true},
+		{"Kernel", "core.1234", "", 0, false},
+		{"NotACore", "viam-server.log", "", 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			executable, pid, at, ok := parseSystemdCoredumpName(tt.file)
+			assert.Equal(t, tt.ok, ok)
+			if !tt.ok {
+				return
+			}
+			assert.Equal(t, tt.executable, executable)
+			assert.Equal(t, tt.pid, pid)
+			assert.Equal(t, time.UnixMicro(1700000000000000), at)
+		})
+	}
+}
+
+func TestListCoreDumps(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]time.Time{
+		"core.viam-server.0.5c6d7f8e9a0b4c1d2e3f4a5b6c7d8e9f.1234.1700000000000000.zst": time.Now(),
+		"core.4321": time.Unix(1600000000, 0),
+		"notes.txt": time.Now(),
+	}
+	for name, mtime := range files {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(path, []byte("core"), 0o600))
+		require.NoError(t, os.Chtimes(path, mtime, mtime))
+	}
+
+	dumps, err := ListCoreDumps(dir)
+	require.NoError(t, err)
+	require.Len(t, dumps, 2)
+	assert.Equal(t, "", dumps[0].Executable)
+	assert.Equal(t, time.Unix(1600000000, 0), dumps[0].Time)
+	assert.Equal(t, "viam-server", dumps[1].Executable)
+	assert.Equal(t, int64(1234), dumps[1].PID)
+	assert.Equal(t, int64(4), dumps[1].Size)
+}
@@ -0,0 +1,72 @@
+package linux
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+const CPUFreqRoot = "/sys/devices/system/cpu/cpufreq"
+
+// CPUFreqPolicy is a cpufreq policy, a group of cores that share a clock. On big.LITTLE SoCs every cluster has its
+// own policy. Frequencies are in Hz.
+type CPUFreqPolicy struct {
+	Name             string
+	CPUs             []int
+	CurrentFrequency int64
+	MinFrequency     int64
+	MaxFrequency     int64
+}
+
+// ReadCPUFreqPolicies returns the policies under root, normally CPUFreqRoot, ordered by their first CPU.
+func ReadCPUFreqPolicies(ctx context.Context, root string) ([]CPUFreqPolicy, error) {
+	policies, err := utils.Glob(filepath.Join(root, "policy[0-9]*"))
+	if err != nil {
+		return nil, err
+	}
+	ret := make([]CPUFreqPolicy, 0, len(policies))
+	for _, path := range policies {
+		policy := CPUFreqPolicy{Name: filepath.Base(path)}
+		if policy.CurrentFrequency, err = readKHz(ctx, filepath.Join(path, "scaling_cur_freq")); err != nil {
+			// The policy of an offline cluster can't be read
+			continue
+		}
+		if policy.MinFrequency, err = readKHz(ctx, filepath.Join(path, "cpuinfo_min_freq")); err != nil && !os.IsNotExist(err) {
+			return nil, err
+		}
+		if policy.MaxFrequency, err = readKHz(ctx, filepath.Join(path, "cpuinfo_max_freq")); err != nil && !os.IsNotExist(err) {
+			return nil, err
+		}
+		if cpus, err := utils.ReadFileWithContext(ctx, filepath.Join(path, "related_cpus")); err == nil {
+			for _, field := range strings.Fields(cpus) {
+				if cpu, err := strconv.Atoi(field); err == nil {
+					policy.CPUs = append(policy.CPUs, cpu)
+				}
+			}
+		}
+		ret = append(ret, policy)
+	}
+	// Glob sorts policy10 before policy2
+	slices.SortFunc(ret, func(a, b CPUFreqPolicy) int {
+		return policyNumber(a.Name) - policyNumber(b.Name)
+	})
+	return ret, nil
+}
+
+func policyNumber(name string) int {
+	n, _ := strconv.Atoi(strings.TrimPrefix(name, "policy"))
+	return n
+}
+
+func readKHz(ctx context.Context, path string) (int64, error) {
+	value, err := utils.ReadInt64FromFileWithContext(ctx, path)
+	if err != nil {
+		return 0, err
+	}
+	return value * 1000, nil
+}
@@ -0,0 +1,73 @@
+package linux
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+const DevfreqRoot = "/sys/class/devfreq"
+
+// DevfreqDevice is a device with a scalable clock, e.g. a GPU, NPU or memory controller. Frequencies are in Hz.
+type DevfreqDevice struct {
+	Name             string
+	Governor         string
+	CurrentFrequency int64
+	MinFrequency     int64
+	MaxFrequency     int64
+	// Load is the utilization in percent, or -1 when the driver doesn't report it
+	Load float64
+}
+
+// ReadDevfreq reads the device with the given name, e.g. fb000000.gpu, under root, normally DevfreqRoot.
+func ReadDevfreq(ctx context.Context, root, name string) (*DevfreqDevice, error) {
+	path := filepath.Join(root, name)
+	device := &DevfreqDevice{Name: name, Load: -1}
+	var err error
+	if device.CurrentFrequency, err = utils.ReadInt64FromFileWithContext(ctx, filepath.Join(path, "cur_freq")); err != nil {
+		return nil, err
+	}
+	if device.MinFrequency, err = utils.ReadInt64FromFileWithContext(ctx, filepath.Join(path, "min_freq")); err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	if device.MaxFrequency, err = utils.ReadInt64FromFileWithContext(ctx, filepath.Join(path, "max_freq")); err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	device.Governor, _ = utils.ReadFileWithContext(ctx, filepath.Join(path, "governor"))
+	if load, err := utils.ReadFileWithContext(ctx, filepath.Join(path, "load")); err == nil {
+		if value, ok := parseDevfreqLoad(load); ok {
+			device.Load = value
+		}
+	}
+	return device, nil
+}
+
+// FindDevfreq returns the names of the devices under root whose name matches the glob pattern, e.g. *.gpu.
+func FindDevfreq(root, pattern string) ([]string, error) {
+	matches, err := utils.Glob(filepath.Join(root, pattern))
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(matches))
+	for _, match := range matches {
+		names = append(names, filepath.Base(match))
+	}
+	return names, nil
+}
+
+// parseDevfreqLoad parses the load attribute some drivers provide, formatted as <percent>@<frequency>Hz.
+func parseDevfreqLoad(data string) (float64, bool) {
+	percent, _, found := strings.Cut(strings.TrimSpace(data), "@")
+	if !found {
+		return 0, false
+	}
+	value, err := strconv.ParseFloat(percent, 64)
+	if err != nil {
+		return 0, false
+	}
+	return value, true
+}
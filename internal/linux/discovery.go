@@ -0,0 +1,93 @@
+package linux
+
+import (
+	"context"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+const (
+	BlockDevicesRoot = "/sys/block"
+	BlockClassRoot   = "/sys/class/block"
+	NetClassRoot     = "/sys/class/net"
+)
+
+// NetworkAdapter is a physical network interface.
+type NetworkAdapter struct {
+	Name     string
+	Wireless bool
+}
+
+// ListBlockDevices returns the physical disks under root, normally BlockDevicesRoot. Only physical disks have a
+// device, this skips loop, ram, zram and device mapper devices.
+func ListBlockDevices(root string) ([]string, error) {
+	return listPhysical(root)
+}
+
+// ListZramDevices returns the zram devices under root, normally BlockDevicesRoot.
+func ListZramDevices(root string) ([]string, error) {
+	entries, err := utils.ReadDir(root)
+	if err != nil {
+		return nil, err
+	}
+	devices := make([]string, 0)
+	for _, entry := range entries {
+		if strings.HasPrefix(entry.Name(), "zram") {
+			devices = append(devices, entry.Name())
+		}
+	}
+	return devices, nil
+}
+
+// ListNetworkAdapters returns the physical network interfaces under root, normally NetClassRoot, skipping lo, bridges,
+// docker and VPN interfaces.
+func ListNetworkAdapters(root string) ([]NetworkAdapter, error) {
+	names, err := listPhysical(root)
+	if err != nil {
+		return nil, err
+	}
+	adapters := make([]NetworkAdapter, 0, len(names))
+	for _, name := range names {
+		// cfg80211 drivers add a wireless directory, some out of tree drivers only link the phy
+		_, wirelessErr := utils.Stat(filepath.Join(root, name, "wireless"))
+		_, phyErr := utils.Stat(filepath.Join(root, name, "phy80211"))
+		adapters = append(adapters, NetworkAdapter{Name: name, Wireless: wirelessErr == nil || phyErr == nil})
+	}
+	return adapters, nil
+}
+
+// ListHwmonChips returns the names of the hwmon devices under root, normally HwmonRoot, e.g. cpu_thermal and pwmfan.
+func ListHwmonChips(ctx context.Context, root string) ([]string, error) {
+	entries, err := utils.ReadDir(root)
+	if err != nil {
+		return nil, err
+	}
+	chips := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		name, err := utils.ReadFileWithContext(ctx, filepath.Join(root, entry.Name(), "name"))
+		if err != nil || name == "" || slices.Contains(chips, name) {
+			continue
+		}
+		chips = append(chips, name)
+	}
+	slices.Sort(chips)
+	return chips, nil
+}
+
+func listPhysical(root string) ([]string, error) {
+	entries, err := utils.ReadDir(root)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if _, err := utils.Stat(filepath.Join(root, entry.Name(), "device")); err != nil {
+			continue
+		}
+		names = append(names, entry.Name())
+	}
+	return names, nil
+}
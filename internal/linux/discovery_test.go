@@ -0,0 +1,45 @@
+package linux
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListBlockDevices(t *testing.T) {
+	root := t.TempDir()
+	for _, path := range []string{"mmcblk0/device", "nvme0n1/device", "loop0", "zram0", "dm-0"} {
+		require.NoError(t, os.MkdirAll(filepath.Join(root, path), 0o755))
+	}
+	devices, err := ListBlockDevices(root)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"mmcblk0", "nvme0n1"}, devices)
+
+	zram, err := ListZramDevices(root)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"zram0"}, zram)
+}
+
+func TestListNetworkAdapters(t *testing.T) {
+	root := t.TempDir()
+	for _, path := range []string{"eth0/device", "wlan0/device", "wlan0/wireless", "wlx00c0ca/device", "wlx00c0ca/phy80211", "lo", "docker0"} {
+		require.NoError(t, os.MkdirAll(filepath.Join(root, path), 0o755))
+	}
+	adapters, err := ListNetworkAdapters(root)
+	require.NoError(t, err)
+	assert.Equal(t, []NetworkAdapter{
+		{Name: "eth0"},
+		{Name: "wlan0", Wireless: true},
+		{Name: "wlx00c0ca", Wireless: true},
+	}, adapters)
+}
+
+func TestListHwmonChips(t *testing.T) {
+	chips, err := ListHwmonChips(context.Background(), "testdata/sys_class_hwmon")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"cpu_thermal", "pwmfan", "rp1_adc"}, chips)
+}
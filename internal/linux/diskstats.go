@@ -0,0 +1,74 @@
+package linux
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+// DiskSectorSize is the unit of the sector counters in /proc/diskstats, regardless of the device's real sector size.
+const DiskSectorSize = 512
+
+// DiskStats holds the cumulative counters of a block device from /proc/diskstats.
+type DiskStats struct {
+	ReadsCompleted   uint64
+	ReadsMerged      uint64
+	SectorsRead      uint64
+	ReadTimeMs       uint64
+	WritesCompleted  uint64
+	WritesMerged     uint64
+	SectorsWritten   uint64
+	WriteTimeMs      uint64
+	InFlight         uint64
+	IOTimeMs         uint64
+	WeightedIOTimeMs uint64
+}
+
+// ReadDiskstats returns the counters of every block device in /proc/diskstats keyed by device name.
+func ReadDiskstats(ctx context.Context) (map[string]DiskStats, error) {
+	data, err := utils.ReadFileWithContext(ctx, "/proc/diskstats")
+	if err != nil {
+		return nil, err
+	}
+	return parseDiskstats(data)
+}
+
+// parseDiskstats parses /proc/diskstats as documented in Documentation/admin-guide/iostats.rst.
+// Only the first 11 counters are used, newer kernels append discard and flush counters.
+func parseDiskstats(data string) (map[string]DiskStats, error) {
+	ret := make(map[string]DiskStats)
+	for _, line := range strings.Split(data, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) < 14 {
+			return nil, fmt.Errorf("unexpected diskstats line %q", line)
+		}
+		values := make([]uint64, 11)
+		for i := range values {
+			value, err := strconv.ParseUint(fields[i+3], 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse diskstats for %s: %w", fields[2], err)
+			}
+			values[i] = value
+		}
+		ret[fields[2]] = DiskStats{
+			ReadsCompleted:   values[0],
+			ReadsMerged:      values[1],
+			SectorsRead:      values[2],
+			ReadTimeMs:       values[3],
+			WritesCompleted:  values[4],
+			WritesMerged:     values[5],
+			SectorsWritten:   values[6],
+			WriteTimeMs:      values[7],
+			InFlight:         values[8],
+			IOTimeMs:         values[9],
+			WeightedIOTimeMs: values[10],
+		}
+	}
+	return ret, nil
+}
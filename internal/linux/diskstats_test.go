@@ -0,0 +1,33 @@
+package linux
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDiskstats(t *testing.T) {
+	b, err := os.ReadFile("testdata/diskstats.txt")
+	require.NoError(t, err)
+	stats, err := parseDiskstats(string(b))
+	require.NoError(t, err)
+	assert.Len(t, stats, 6)
+
+	mmc := stats["mmcblk0"]
+	assert.Equal(t, uint64(28812), mmc.ReadsCompleted)
+	assert.Equal(t, uint64(2236014), mmc.SectorsRead)
+	assert.Equal(t, uint64(110341), mmc.WritesCompleted)
+	assert.Equal(t, uint64(2398116), mmc.WriteTimeMs)
+	assert.Equal(t, uint64(414920), mmc.IOTimeMs)
+	assert.Equal(t, uint64(2530644), mmc.WeightedIOTimeMs)
+
+	// Older kernels only have the 11 original counters
+	sda := stats["sda"]
+	assert.Equal(t, uint64(2), sda.InFlight)
+	assert.Equal(t, uint64(3900), sda.WeightedIOTimeMs)
+
+	_, err = parseDiskstats("8 0 sda 1 2 3")
+	assert.Error(t, err)
+}
@@ -0,0 +1,137 @@
+package linux
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+const DRMClassRoot = "/sys/class/drm"
+
+var ErrInvalidEDID = errors.New("invalid EDID")
+
+// DRMConnector is a display output, e.g. an HDMI port or a DSI panel.
+type DRMConnector struct {
+	// Card is the GPU the connector belongs to, e.g. card1
+	Card string
+	// Name is the connector's type and index, e.g. HDMI-A-1
+	Name string
+	// Status is connected, disconnected or unknown, the latter for panels that can't detect a display
+	Status  string
+	Enabled bool
+	// Modes are the resolutions the display supports, the preferred one first
+	Modes []string
+	// EDID is nil when there's no display or it didn't provide one
+	EDID *EDID
+}
+
+// EDID is the identity a display reports over the connector.
+type EDID struct {
+	// Manufacturer is the three letter PNP ID, e.g. DEL for Dell
+	Manufacturer string
+	ProductCode  uint16
+	MonitorName  string
+	Serial       string
+	// Width and Height are the native resolution from the first detailed timing
+	Width  int
+	Height int
+}
+
+// ReadDRMConnectors reads the connectors under root, normally DRMClassRoot.
+func ReadDRMConnectors(ctx context.Context, root string) ([]DRMConnector, error) {
+	entries, err := utils.ReadDir(root)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	connectors := make([]DRMConnector, 0)
+	for _, entry := range entries {
+		// Connectors are named <card>-<connector>, e.g. card1-HDMI-A-1, the cards and render nodes have no dash
+		card, name, ok := strings.Cut(entry.Name(), "-")
+		if !ok || !strings.HasPrefix(card, "card") {
+			continue
+		}
+		path := filepath.Join(root, entry.Name())
+		status, err := utils.ReadFileWithContext(ctx, filepath.Join(path, "status"))
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			continue
+		}
+		connector := DRMConnector{Card: card, Name: name, Status: status, Modes: make([]string, 0)}
+		if enabled, err := utils.ReadFileWithContext(ctx, filepath.Join(path, "enabled")); err == nil {
+			connector.Enabled = enabled == "enabled"
+		}
+		if modes, err := utils.ReadFileWithContext(ctx, filepath.Join(path, "modes")); err == nil && modes != "" {
+			connector.Modes = strings.Split(modes, "\n")
+		}
+		// The EDID is binary, so it isn't read as a string which would trim its checksum
+		if data, err := utils.ReadFile(filepath.Join(path, "edid")); err == nil && len(data) > 0 {
+			connector.EDID, _ = ParseEDID(data)
+		}
+		connectors = append(connectors, connector)
+	}
+	sort.Slice(connectors, func(i, j int) bool {
+		if connectors[i].Card != connectors[j].Card {
+			return connectors[i].Card < connectors[j].Card
+		}
+		return connectors[i].Name < connectors[j].Name
+	})
+	return connectors, nil
+}
+
+var edidHeader = []byte{0x00, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x00}
+
+// ParseEDID parses the base block of an EDID, extension blocks are ignored.
+func ParseEDID(data []byte) (*EDID, error) {
+	if len(data) < 128 || string(data[:8]) != string(edidHeader) {
+		return nil, ErrInvalidEDID
+	}
+	var sum byte
+	for _, b := range data[:128] {
+		sum += b
+	}
+	if sum != 0 {
+		return nil, ErrInvalidEDID
+	}
+
+	// The manufacturer is three letters packed into 5 bits each, 1 is A
+	id := binary.BigEndian.Uint16(data[8:10])
+	edid := &EDID{
+		Manufacturer: string([]byte{byte(id>>10&0x1f) + 'A' - 1, byte(id>>5&0x1f) + 'A' - 1, byte(id&0x1f) + 'A' - 1}),
+		ProductCode:  binary.LittleEndian.Uint16(data[10:12]),
+	}
+	for offset := 54; offset < 126; offset += 18 {
+		descriptor := data[offset : offset+18]
+		if descriptor[0] != 0 || descriptor[1] != 0 {
+			// A non-zero pixel clock makes this a detailed timing, the first is the native resolution
+			if edid.Width == 0 {
+				edid.Width = int(descriptor[2]) | int(descriptor[4]&0xf0)<<4
+				edid.Height = int(descriptor[5]) | int(descriptor[7]&0xf0)<<4
+			}
+			continue
+		}
+		switch descriptor[3] {
+		case 0xfc:
+			edid.MonitorName = edidString(descriptor[5:])
+		case 0xff:
+			edid.Serial = edidString(descriptor[5:])
+		}
+	}
+	return edid, nil
+}
+
+// edidString decodes a display descriptor's text, which ends in a newline and is padded with spaces.
+func edidString(data []byte) string {
+	text, _, _ := strings.Cut(string(data), "\n")
+	return strings.TrimSpace(text)
+}
@@ -0,0 +1,50 @@
+package linux
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadDRMConnectors(t *testing.T) {
+	connectors, err := ReadDRMConnectors(context.Background(), "testdata/drm")
+	require.NoError(t, err)
+	require.Len(t, connectors, 3)
+	assert.Equal(t, DRMConnector{Card: "card1", Name: "DSI-1", Status: "unknown", Enabled: true, Modes: []string{"800x480"}}, connectors[0])
+	assert.Equal(t, DRMConnector{
+		Card:    "card1",
+		Name:    "HDMI-A-1",
+		Status:  "connected",
+		Enabled: true,
+		Modes:   []string{"1920x1200", "1920x1080", "1600x1200", "1280x1024", "1280x720", "1024x768", "800x600", "640x480"},
+		EDID: &EDID{
+			Manufacturer: "DEL",
+			ProductCode:  0xa0c4,
+			MonitorName:  "DELL U2415",
+			Serial:       "CFV9N7BR0LUL",
+			Width:        1920,
+			Height:       1200,
+		},
+	}, connectors[1])
+	assert.Equal(t, DRMConnector{Card: "card1", Name: "HDMI-A-2", Status: "disconnected", Modes: []string{}}, connectors[2])
+
+	connectors, err = ReadDRMConnectors(context.Background(), "testdata/drm/missing")
+	require.NoError(t, err)
+	assert.Empty(t, connectors)
+}
+
+func TestParseEDIDInvalid(t *testing.T) {
+	data, err := os.ReadFile("testdata/drm/card1-HDMI-A-1/edid")
+	require.NoError(t, err)
+
+	_, err = ParseEDID(data[:64])
+	assert.ErrorIs(t, err, ErrInvalidEDID)
+
+	corrupt := append([]byte{}, data...)
+	corrupt[127]++
+	_, err = ParseEDID(corrupt)
+	assert.ErrorIs(t, err, ErrInvalidEDID)
+}
@@ -0,0 +1,162 @@
+package envsensor
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"time"
+)
+
+const (
+	bme280ChipID      = 0x60
+	bme280RegChipID   = 0xD0
+	bme280RegCalib00  = 0x88
+	bme280RegCalib26  = 0xE1
+	bme280RegCtrlHum  = 0xF2
+	bme280RegStatus   = 0xF3
+	bme280RegCtrlMeas = 0xF4
+	bme280RegData     = 0xF7
+	// 1x oversampling of temperature and pressure in forced mode
+	bme280CtrlMeasForced = 0x25
+	bme280CtrlHumX1      = 0x01
+)
+
+var ErrNotBME280 = errors.New("device is not a BME280")
+
+type bme280Calibration struct {
+	t1                             uint16
+	t2, t3                         int16
+	p1                             uint16
+	p2, p3, p4, p5, p6, p7, p8, p9 int16
+	h1                             uint8
+	h2                             int16
+	h3                             uint8
+	h4, h5                         int16
+	h6                             int8
+}
+
+// BME280 is a Bosch temperature, humidity and pressure sensor. It is used in forced mode, each measurement is
+// triggered by Measure and the sensor sleeps in between so it doesn't heat itself.
+type BME280 struct {
+	bus   Bus
+	calib bme280Calibration
+}
+
+func NewBME280(bus Bus) (*BME280, error) {
+	id := make([]byte, 1)
+	if err := readRegisters(bus, bme280RegChipID, id); err != nil {
+		return nil, err
+	}
+	if id[0] != bme280ChipID {
+		// The BMP280 looks the same but has no humidity sensor, 0x58
+		return nil, fmt.Errorf("%w: chip id 0x%02x", ErrNotBME280, id[0])
+	}
+	calib00 := make([]byte, 26)
+	if err := readRegisters(bus, bme280RegCalib00, calib00); err != nil {
+		return nil, err
+	}
+	calib26 := make([]byte, 7)
+	if err := readRegisters(bus, bme280RegCalib26, calib26); err != nil {
+		return nil, err
+	}
+	return &BME280{bus: bus, calib: parseBME280Calibration(calib00, calib26)}, nil
+}
+
+func parseBME280Calibration(calib00, calib26 []byte) bme280Calibration {
+	u16 := func(b []byte, i int) uint16 { return binary.LittleEndian.Uint16(b[i:]) }
+	s16 := func(b []byte, i int) int16 { return int16(binary.LittleEndian.Uint16(b[i:])) }
+	return bme280Calibration{
+		t1: u16(calib00, 0),
+		t2: s16(calib00, 2),
+		t3: s16(calib00, 4),
+		p1: u16(calib00, 6),
+		p2: s16(calib00, 8),
+		p3: s16(calib00, 10),
+		p4: s16(calib00, 12),
+		p5: s16(calib00, 14),
+		p6: s16(calib00, 16),
+		p7: s16(calib00, 18),
+		p8: s16(calib00, 20),
+		p9: s16(calib00, 22),
+		h1: calib00[25],
+		h2: s16(calib26, 0),
+		h3: calib26[2],
+		// H4 and H5 are 12 bit values that share a nibble in 0xE5
+		h4: int16(int8(calib26[3]))<<4 | int16(calib26[4]&0x0F),
+		h5: int16(int8(calib26[5]))<<4 | int16(calib26[4]>>4),
+		h6: int8(calib26[6]),
+	}
+}
+
+func (s *BME280) Measure() (map[string]float64, error) {
+	// ctrl_hum only takes effect after ctrl_meas is written
+	if err := s.bus.Write([]byte{bme280RegCtrlHum, bme280CtrlHumX1}); err != nil {
+		return nil, err
+	}
+	if err := s.bus.Write([]byte{bme280RegCtrlMeas, bme280CtrlMeasForced}); err != nil {
+		return nil, err
+	}
+	// A measurement with 1x oversampling takes under 10ms
+	status := make([]byte, 1)
+	for attempt := 0; ; attempt++ {
+		time.Sleep(10 * time.Millisecond)
+		if err := readRegisters(s.bus, bme280RegStatus, status); err != nil {
+			return nil, err
+		}
+		if status[0]&0x08 == 0 {
+			break
+		}
+		if attempt == 10 {
+			return nil, errors.New("BME280 measurement did not complete")
+		}
+	}
+	data := make([]byte, 8)
+	if err := readRegisters(s.bus, bme280RegData, data); err != nil {
+		return nil, err
+	}
+	adcP := int32(data[0])<<12 | int32(data[1])<<4 | int32(data[2])>>4
+	adcT := int32(data[3])<<12 | int32(data[4])<<4 | int32(data[5])>>4
+	adcH := int32(data[6])<<8 | int32(data[7])
+	temperature, tFine := s.calib.temperature(adcT)
+	return map[string]float64{
+		"temperature": temperature,
+		"pressure":    s.calib.pressure(adcP, tFine) / 100,
+		"humidity":    s.calib.humidity(adcH, tFine),
+	}, nil
+}
+
+// The compensation formulas are the floating point versions from the BME280 datasheet, section 8.1.
+
+func (c *bme280Calibration) temperature(adcT int32) (float64, float64) {
+	v1 := (float64(adcT)/16384 - float64(c.t1)/1024) * float64(c.t2)
+	v2 := float64(adcT)/131072 - float64(c.t1)/8192
+	v2 = v2 * v2 * float64(c.t3)
+	tFine := v1 + v2
+	return tFine / 5120, tFine
+}
+
+// pressure returns the pressure in Pa.
+func (c *bme280Calibration) pressure(adcP int32, tFine float64) float64 {
+	v1 := tFine/2 - 64000
+	v2 := v1 * v1 * float64(c.p6) / 32768
+	v2 += v1 * float64(c.p5) * 2
+	v2 = v2/4 + float64(c.p4)*65536
+	v1 = (float64(c.p3)*v1*v1/524288 + float64(c.p2)*v1) / 524288
+	v1 = (1 + v1/32768) * float64(c.p1)
+	if v1 == 0 {
+		return 0
+	}
+	p := 1048576 - float64(adcP)
+	p = (p - v2/4096) * 6250 / v1
+	v1 = float64(c.p9) * p * p / 2147483648
+	v2 = p * float64(c.p8) / 32768
+	return p + (v1+v2+float64(c.p7))/16
+}
+
+func (c *bme280Calibration) humidity(adcH int32, tFine float64) float64 {
+	h := tFine - 76800
+	h = (float64(adcH) - (float64(c.h4)*64 + float64(c.h5)/16384*h)) *
+		(float64(c.h2) / 65536 * (1 + float64(c.h6)/67108864*h*(1+float64(c.h3)/67108864*h)))
+	h *= 1 - float64(c.h1)*h/524288
+	return min(max(h, 0), 100)
+}
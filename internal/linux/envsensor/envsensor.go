@@ -0,0 +1,93 @@
+package envsensor
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// Bus is an open I2C device, linux.I2CDevice in practice.
+type Bus interface {
+	Write(data []byte) error
+	Read(data []byte) error
+}
+
+// Sensor measures the environment, the keys are temperature (°C), humidity (%RH), pressure (hPa), tvoc_ppb and
+// eco2_ppm, depending on what the sensor measures.
+type Sensor interface {
+	Measure() (map[string]float64, error)
+}
+
+var ErrCRC = errors.New("CRC check failed")
+
+// DefaultAddresses are the addresses the sensors use unless their address pin is strapped differently.
+var DefaultAddresses = map[string]uint16{
+	"bme280": 0x76,
+	"sht3x":  0x44,
+	"sgp30":  0x58,
+}
+
+// New initializes a sensor of the given type.
+func New(sensorType string, bus Bus) (Sensor, error) {
+	switch sensorType {
+	case "bme280":
+		return NewBME280(bus)
+	case "sht3x":
+		return NewSHT3x(bus), nil
+	case "sgp30":
+		return NewSGP30(bus)
+	default:
+		return nil, fmt.Errorf("unknown sensor type: %s", sensorType)
+	}
+}
+
+func readRegisters(bus Bus, register byte, data []byte) error {
+	if err := bus.Write([]byte{register}); err != nil {
+		return err
+	}
+	return bus.Read(data)
+}
+
+// sensirionCRC is the CRC-8 the Sensirion sensors append to every 16 bit word, polynomial 0x31 with an initial value
+// of 0xFF.
+func sensirionCRC(data []byte) byte {
+	crc := byte(0xFF)
+	for _, b := range data {
+		crc ^= b
+		for i := 0; i < 8; i++ {
+			if crc&0x80 != 0 {
+				crc = crc<<1 ^ 0x31
+			} else {
+				crc <<= 1
+			}
+		}
+	}
+	return crc
+}
+
+// readWords reads 16 bit words that are each followed by their CRC.
+func readWords(bus Bus, count int) ([]uint16, error) {
+	data := make([]byte, count*3)
+	if err := bus.Read(data); err != nil {
+		return nil, err
+	}
+	words := make([]uint16, count)
+	for i := range words {
+		chunk := data[i*3 : i*3+3]
+		if sensirionCRC(chunk[:2]) != chunk[2] {
+			return nil, ErrCRC
+		}
+		words[i] = uint16(chunk[0])<<8 | uint16(chunk[1])
+	}
+	return words, nil
+}
+
+// DewPoint returns the dew point in °C using the Magnus formula, accurate to about 0.35°C between -45°C and 60°C.
+func DewPoint(temperature, humidity float64) float64 {
+	const b, c = 17.62, 243.12
+	if humidity <= 0 {
+		return math.Inf(-1)
+	}
+	gamma := math.Log(humidity/100) + b*temperature/(c+temperature)
+	return c * gamma / (b - gamma)
+}
@@ -0,0 +1,106 @@
+package envsensor
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// registerBus emulates a device with a register pointer, like the BME280.
+type registerBus struct {
+	registers [256]byte
+	pointer   byte
+}
+
+func (b *registerBus) Write(data []byte) error {
+	b.pointer = data[0]
+	if len(data) > 1 {
+		copy(b.registers[b.pointer:], data[1:])
+	}
+	return nil
+}
+
+func (b *registerBus) Read(data []byte) error {
+	copy(data, b.registers[b.pointer:])
+	return nil
+}
+
+// responseBus returns the same response to every read, like the Sensirion sensors after a command.
+type responseBus struct {
+	response []byte
+	commands [][]byte
+}
+
+func (b *responseBus) Write(data []byte) error {
+	b.commands = append(b.commands, data)
+	return nil
+}
+
+func (b *responseBus) Read(data []byte) error {
+	copy(data, b.response)
+	return nil
+}
+
+func mustDecode(t *testing.T, s string) []byte {
+	data, err := hex.DecodeString(s)
+	require.NoError(t, err)
+	return data
+}
+
+func TestBME280(t *testing.T) {
+	bus := &registerBus{}
+	bus.registers[bme280RegChipID] = bme280ChipID
+	// The temperature and pressure calibration is the example from the datasheet
+	copy(bus.registers[bme280RegCalib00:], mustDecode(t, "706b436718fc7d8e43d6d00b270b8c00f9ff8c3cf8c67017004b"))
+	copy(bus.registers[bme280RegCalib26:], mustDecode(t, "6a01001329031e"))
+	copy(bus.registers[bme280RegData:], mustDecode(t, "655ac07eed007530"))
+
+	sensor, err := NewBME280(bus)
+	require.NoError(t, err)
+	assert.Equal(t, int16(313), sensor.calib.h4)
+	assert.Equal(t, int16(50), sensor.calib.h5)
+	measurement, err := sensor.Measure()
+	require.NoError(t, err)
+	assert.InDelta(t, 25.08, measurement["temperature"], 0.01)
+	assert.InDelta(t, 1006.53, measurement["pressure"], 0.01)
+	assert.InDelta(t, 55.0, measurement["humidity"], 0.01)
+
+	bus.registers[bme280RegChipID] = 0x58
+	_, err = NewBME280(bus)
+	assert.ErrorIs(t, err, ErrNotBME280)
+}
+
+func TestSHT3x(t *testing.T) {
+	// 0x6666 is 25°C and 0x8000 is 50%RH
+	bus := &responseBus{response: []byte{0x66, 0x66, sensirionCRC([]byte{0x66, 0x66}), 0x80, 0x00, sensirionCRC([]byte{0x80, 0x00})}}
+	measurement, err := NewSHT3x(bus).Measure()
+	require.NoError(t, err)
+	assert.InDelta(t, 25.0, measurement["temperature"], 0.01)
+	assert.InDelta(t, 50.0, measurement["humidity"], 0.01)
+
+	bus.response[2] ^= 0xFF
+	_, err = NewSHT3x(bus).Measure()
+	assert.ErrorIs(t, err, ErrCRC)
+}
+
+func TestSGP30(t *testing.T) {
+	bus := &responseBus{response: []byte{0x01, 0x90, sensirionCRC([]byte{0x01, 0x90}), 0x00, 0x2A, sensirionCRC([]byte{0x00, 0x2A})}}
+	sensor, err := NewSGP30(bus)
+	require.NoError(t, err)
+	measurement, err := sensor.Measure()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]float64{"eco2_ppm": 400, "tvoc_ppb": 42}, measurement)
+	assert.Equal(t, [][]byte{{0x20, 0x03}, {0x20, 0x08}}, bus.commands)
+}
+
+func TestSensirionCRC(t *testing.T) {
+	// The example from the SHT3x datasheet
+	assert.Equal(t, byte(0x92), sensirionCRC([]byte{0xBE, 0xEF}))
+}
+
+func TestDewPoint(t *testing.T) {
+	assert.InDelta(t, 16.69, DewPoint(25, 60), 0.01)
+	assert.InDelta(t, 25.0, DewPoint(25, 100), 0.01)
+}
@@ -0,0 +1,36 @@
+package envsensor
+
+import (
+	"time"
+)
+
+// SGP30 is a Sensirion VOC sensor. Its baseline compensation assumes it is measured once a second, readings drift if
+// it is measured less often. For the first 15 seconds after initialization it reports 400ppm eCO2 and 0ppb TVOC.
+type SGP30 struct {
+	bus Bus
+}
+
+func NewSGP30(bus Bus) (*SGP30, error) {
+	// sgp30_iaq_init starts the baseline compensation
+	if err := bus.Write([]byte{0x20, 0x03}); err != nil {
+		return nil, err
+	}
+	time.Sleep(10 * time.Millisecond)
+	return &SGP30{bus: bus}, nil
+}
+
+func (s *SGP30) Measure() (map[string]float64, error) {
+	// sgp30_measure_iaq
+	if err := s.bus.Write([]byte{0x20, 0x08}); err != nil {
+		return nil, err
+	}
+	time.Sleep(12 * time.Millisecond)
+	words, err := readWords(s.bus, 2)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]float64{
+		"eco2_ppm": float64(words[0]),
+		"tvoc_ppb": float64(words[1]),
+	}, nil
+}
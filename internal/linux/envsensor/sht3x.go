@@ -0,0 +1,30 @@
+package envsensor
+
+import (
+	"time"
+)
+
+// SHT3x is a Sensirion temperature and humidity sensor, the SHT30, SHT31 and SHT35 share the protocol.
+type SHT3x struct {
+	bus Bus
+}
+
+func NewSHT3x(bus Bus) *SHT3x {
+	return &SHT3x{bus: bus}
+}
+
+func (s *SHT3x) Measure() (map[string]float64, error) {
+	// Single shot, high repeatability, without clock stretching which not every I2C controller supports
+	if err := s.bus.Write([]byte{0x24, 0x00}); err != nil {
+		return nil, err
+	}
+	time.Sleep(16 * time.Millisecond)
+	words, err := readWords(s.bus, 2)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]float64{
+		"temperature": -45 + 175*float64(words[0])/65535,
+		"humidity":    100 * float64(words[1]) / 65535,
+	}, nil
+}
@@ -0,0 +1,32 @@
+package linux
+
+import (
+	"context"
+	"strings"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+// ReadUBootVersion returns the U-Boot version that booted the board, U-Boot passes it to the kernel in the device tree.
+func ReadUBootVersion(ctx context.Context) (string, error) {
+	return ReadDeviceTreeString(ctx, "/proc/device-tree/chosen/u-boot,version")
+}
+
+// ReadBIOSVersion returns the firmware version and release date from DMI, available on x86 and UEFI arm64 boards.
+func ReadBIOSVersion(ctx context.Context) (version, date string, err error) {
+	version, err = utils.ReadFileWithContext(ctx, "/sys/class/dmi/id/bios_version")
+	if err != nil {
+		return "", "", err
+	}
+	date, _ = utils.ReadFileWithContext(ctx, "/sys/class/dmi/id/bios_date")
+	return version, date, nil
+}
+
+// ReadDeviceTreeString reads a device tree string property, they are NUL terminated.
+func ReadDeviceTreeString(ctx context.Context, path string) (string, error) {
+	value, err := utils.ReadFileWithContext(ctx, path)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(value, "\x00"), nil
+}
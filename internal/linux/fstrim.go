@@ -0,0 +1,68 @@
+package linux
+
+import (
+	"context"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+// TrimResult is the outcome of the last trim of a filesystem.
+type TrimResult struct {
+	Time         time.Time
+	TrimmedBytes uint64
+}
+
+// fstrim -v prints "/boot/firmware: 255.6 MiB (268009472 bytes) trimmed on /dev/mmcblk0p1"
+var fstrimRegex = regexp.MustCompile(`^(/.*?): .*\((\d+) bytes\) trimmed`)
+
+// ReadFstrimJournal returns the last trim of every mountpoint logged by fstrim.service, usually started by fstrim.timer.
+func ReadFstrimJournal(ctx context.Context) (map[string]TrimResult, error) {
+	if _, err := exec.LookPath("journalctl"); err != nil {
+		return nil, err
+	}
+	out, err := utils.Output(ctx, "journalctl", "-u", "fstrim.service", "-o", "short-unix", "-n", "500", "-q", "--no-pager")
+	if err != nil {
+		return nil, err
+	}
+	return parseFstrimJournal(string(out)), nil
+}
+
+// parseFstrimJournal parses journal lines in the short-unix format, e.g.
+//
+//	1715000521.123456 raspberrypi fstrim[1234]: /: 12.3 GiB (13207064576 bytes) trimmed on /dev/mmcblk0p2
+func parseFstrimJournal(out string) map[string]TrimResult {
+	ret := make(map[string]TrimResult)
+	for _, line := range strings.Split(out, "\n") {
+		timestamp, rest, found := strings.Cut(line, " ")
+		if !found {
+			continue
+		}
+		_, message, found := strings.Cut(rest, "]: ")
+		if !found {
+			continue
+		}
+		match := fstrimRegex.FindStringSubmatch(message)
+		if match == nil {
+			continue
+		}
+		seconds, err := strconv.ParseFloat(timestamp, 64)
+		if err != nil {
+			continue
+		}
+		bytes, err := strconv.ParseUint(match[2], 10, 64)
+		if err != nil {
+			continue
+		}
+		// The journal is in chronological order, so later entries replace earlier ones
+		ret[match[1]] = TrimResult{
+			Time:         time.Unix(0, int64(seconds*float64(time.Second))),
+			TrimmedBytes: bytes,
+		}
+	}
+	return ret
+}
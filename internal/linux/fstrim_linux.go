@@ -0,0 +1,31 @@
+package linux
+
+import (
+	"math"
+	"syscall"
+	"unsafe"
+)
+
+// _IOWR('X', 121, struct fstrim_range)
+const fitrim = 0xc0185879
+
+type fstrimRange struct {
+	start  uint64
+	length uint64
+	minLen uint64
+}
+
+// Fstrim discards the unused blocks of the filesystem mounted at mountpoint and returns the number of bytes trimmed.
+func Fstrim(mountpoint string) (uint64, error) {
+	fd, err := syscall.Open(mountpoint, syscall.O_RDONLY|syscall.O_DIRECTORY|syscall.O_CLOEXEC, 0)
+	if err != nil {
+		return 0, err
+	}
+	defer syscall.Close(fd)
+	r := fstrimRange{length: math.MaxUint64}
+	if _, _, errno := syscall.Syscall(syscall.SYS_IOCTL, uintptr(fd), fitrim, uintptr(unsafe.Pointer(&r))); errno != 0 {
+		return 0, errno
+	}
+	// The kernel updates length with the number of bytes trimmed
+	return r.length, nil
+}
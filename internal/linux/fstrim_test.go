@@ -0,0 +1,24 @@
+package linux
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseFstrimJournal(t *testing.T) {
+	out := `1714400000.000000 raspberrypi systemd[1]: Starting fstrim.service - Discard unused blocks on filesystems from /etc/fstab...
+1714400001.500000 raspberrypi fstrim[1234]: /boot/firmware: 255.6 MiB (268009472 bytes) trimmed on /dev/mmcblk0p1
+1714400002.000000 raspberrypi fstrim[1234]: /: 12.3 GiB (13207064576 bytes) trimmed on /dev/mmcblk0p2
+1715000000.000000 raspberrypi fstrim[2345]: /: 1 GiB (1073741824 bytes) trimmed
+1715000001.000000 raspberrypi fstrim[2345]: fstrim: /data: the discard operation is not supported
+`
+	results := parseFstrimJournal(out)
+	assert.Len(t, results, 2)
+	assert.Equal(t, uint64(268009472), results["/boot/firmware"].TrimmedBytes)
+	assert.Equal(t, time.Unix(1714400001, 500000000), results["/boot/firmware"].Time)
+	// The newest entry wins
+	assert.Equal(t, uint64(1073741824), results["/"].TrimmedBytes)
+	assert.Equal(t, time.Unix(1715000000, 0), results["/"].Time)
+}
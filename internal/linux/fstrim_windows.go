@@ -0,0 +1,7 @@
+package linux
+
+import "github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+
+func Fstrim(mountpoint string) (uint64, error) {
+	return 0, utils.ErrPlatformNotSupported
+}
@@ -0,0 +1,111 @@
+package linux
+
+import (
+	"encoding/binary"
+	"fmt"
+	"time"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+// GPIO line flags and attributes from the v2 GPIO character device uAPI, linux/gpio.h.
+const (
+	gpioLineFlagActiveLow    = 1 << 1
+	gpioLineFlagInput        = 1 << 2
+	gpioLineFlagEdgeRising   = 1 << 4
+	gpioLineFlagEdgeFalling  = 1 << 5
+	gpioLineFlagBiasPullUp   = 1 << 8
+	gpioLineFlagBiasPullDown = 1 << 9
+	gpioLineFlagBiasDisabled = 1 << 10
+
+	gpioLineAttrIDDebounce = 3
+
+	gpioLineEventRisingEdge  = 1
+	gpioLineEventFallingEdge = 2
+
+	gpioLineEventSize = 48
+	gpioMaxNameSize   = 32
+)
+
+// GPIOPermissions returns the permissions to request lines from the chips, e.g. gpiochip0, which are opened
+// read-write. An empty chip is a line found by name, which searches every chip.
+func GPIOPermissions(chips []string) utils.Permissions {
+	perms := utils.Permissions{Groups: []string{"gpio"}}
+	for _, chip := range chips {
+		path := "/dev/gpiochip*"
+		if chip != "" {
+			path = "/dev/" + chip
+		}
+		perms.Readable = append(perms.Readable, path)
+		perms.Writable = append(perms.Writable, path)
+	}
+	return perms
+}
+
+// GPIOLineConfig is how a line is requested. The line is always an input with edge detection on both edges.
+type GPIOLineConfig struct {
+	// Chip is the character device name, e.g. gpiochip0
+	Chip   string
+	Offset int
+	// ActiveLow inverts both the value and the edges, so a closed switch to ground reads as true
+	ActiveLow bool
+	// Bias is pull_up, pull_down, disabled or empty to leave the line as configured
+	Bias string
+	// Debounce is done by the kernel, in hardware when the controller supports it
+	Debounce time.Duration
+}
+
+// GPIOEdgeEvent is an edge detected on a line. Rising means the line became active.
+type GPIOEdgeEvent struct {
+	Rising bool
+	// Timestamp is CLOCK_MONOTONIC, it is only useful for comparing events
+	Timestamp time.Duration
+	// Seqno counts the events on the line, a gap means the kernel's event buffer overflowed
+	Seqno uint32
+}
+
+func (c GPIOLineConfig) flags() (uint64, error) {
+	flags := uint64(gpioLineFlagInput | gpioLineFlagEdgeRising | gpioLineFlagEdgeFalling)
+	if c.ActiveLow {
+		flags |= gpioLineFlagActiveLow
+	}
+	switch c.Bias {
+	case "":
+	case "pull_up":
+		flags |= gpioLineFlagBiasPullUp
+	case "pull_down":
+		flags |= gpioLineFlagBiasPullDown
+	case "disabled":
+		flags |= gpioLineFlagBiasDisabled
+	default:
+		return 0, fmt.Errorf("unknown bias: %s", c.Bias)
+	}
+	return flags, nil
+}
+
+// parseGPIOEvents parses struct gpio_v2_line_event records, an incomplete trailing record is ignored.
+func parseGPIOEvents(data []byte) []GPIOEdgeEvent {
+	events := make([]GPIOEdgeEvent, 0, len(data)/gpioLineEventSize)
+	for ; len(data) >= gpioLineEventSize; data = data[gpioLineEventSize:] {
+		id := binary.NativeEndian.Uint32(data[8:12])
+		if id != gpioLineEventRisingEdge && id != gpioLineEventFallingEdge {
+			continue
+		}
+		events = append(events, GPIOEdgeEvent{
+			Rising:    id == gpioLineEventRisingEdge,
+			Timestamp: time.Duration(binary.NativeEndian.Uint64(data[0:8])),
+			Seqno:     binary.NativeEndian.Uint32(data[16:20]),
+		})
+	}
+	return events
+}
+
+// cString converts a NUL padded C string.
+func cString(data []byte) string {
+	for i, b := range data {
+		if b == 0 {
+			return string(data[:i])
+		}
+	}
+	return string(data)
+}
@@ -0,0 +1,189 @@
+package linux
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"syscall"
+	"time"
+	"unsafe"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+// ioctl numbers from linux/gpio.h
+const (
+	gpioGetChipInfoIOCTL     = 0x8044b401 // _IOR(0xB4, 0x01, struct gpiochip_info)
+	gpioV2GetLineInfoIOCTL   = 0xc100b405 // _IOWR(0xB4, 0x05, struct gpio_v2_line_info)
+	gpioV2GetLineIOCTL       = 0xc250b407 // _IOWR(0xB4, 0x07, struct gpio_v2_line_request)
+	gpioV2LineGetValuesIOCTL = 0xc010b40e // _IOWR(0xB4, 0x0E, struct gpio_v2_line_values)
+)
+
+type gpioChipInfo struct {
+	name  [gpioMaxNameSize]byte
+	label [gpioMaxNameSize]byte
+	lines uint32
+}
+
+type gpioV2LineAttribute struct {
+	id      uint32
+	padding uint32
+	// A union of flags, values and debounce_period_us
+	value uint64
+}
+
+type gpioV2LineConfigAttribute struct {
+	attr gpioV2LineAttribute
+	mask uint64
+}
+
+type gpioV2LineConfig struct {
+	flags    uint64
+	numAttrs uint32
+	padding  [5]uint32
+	attrs    [10]gpioV2LineConfigAttribute
+}
+
+type gpioV2LineRequest struct {
+	offsets         [64]uint32
+	consumer        [gpioMaxNameSize]byte
+	config          gpioV2LineConfig
+	numLines        uint32
+	eventBufferSize uint32
+	padding         [5]uint32
+	fd              int32
+}
+
+type gpioV2LineInfo struct {
+	name     [gpioMaxNameSize]byte
+	consumer [gpioMaxNameSize]byte
+	offset   uint32
+	numAttrs uint32
+	flags    uint64
+	attrs    [10]gpioV2LineAttribute
+	padding  [4]uint32
+}
+
+type gpioV2LineValues struct {
+	bits uint64
+	mask uint64
+}
+
+// GPIOLine is a requested input line. The line is held until Close, other consumers can't request it meanwhile.
+type GPIOLine struct {
+	fd   int
+	file *os.File
+	buf  []byte
+}
+
+func ioctl(fd int, request uintptr, arg unsafe.Pointer) error {
+	if _, _, errno := syscall.Syscall(syscall.SYS_IOCTL, uintptr(fd), request, uintptr(arg)); errno != 0 {
+		return errno
+	}
+	return nil
+}
+
+// RequestGPIOLine requests a line as an input with edge detection, consumer is shown by gpioinfo as the line's user.
+func RequestGPIOLine(config GPIOLineConfig, consumer string) (*GPIOLine, error) {
+	flags, err := config.flags()
+	if err != nil {
+		return nil, err
+	}
+	chipFd, err := syscall.Open(filepath.Join("/dev", config.Chip), syscall.O_RDWR|syscall.O_CLOEXEC, 0)
+	if err != nil {
+		return nil, err
+	}
+	defer syscall.Close(chipFd)
+
+	req := gpioV2LineRequest{numLines: 1}
+	req.offsets[0] = uint32(config.Offset)
+	copy(req.consumer[:gpioMaxNameSize-1], consumer)
+	req.config.flags = flags
+	if config.Debounce > 0 {
+		req.config.attrs[0] = gpioV2LineConfigAttribute{
+			attr: gpioV2LineAttribute{id: gpioLineAttrIDDebounce, value: uint64(config.Debounce.Microseconds())},
+			mask: 1,
+		}
+		req.config.numAttrs = 1
+	}
+	if err := ioctl(chipFd, gpioV2GetLineIOCTL, unsafe.Pointer(&req)); err != nil {
+		return nil, fmt.Errorf("failed to request %s line %d: %w", config.Chip, config.Offset, err)
+	}
+	fd := int(req.fd)
+	// A non-blocking fd lets the runtime poller handle the read deadlines in ReadEvents
+	if err := syscall.SetNonblock(fd, true); err != nil {
+		syscall.Close(fd)
+		return nil, err
+	}
+	return &GPIOLine{
+		fd:   fd,
+		file: os.NewFile(uintptr(fd), fmt.Sprintf("%s-%d", config.Chip, config.Offset)),
+		buf:  make([]byte, 16*gpioLineEventSize),
+	}, nil
+}
+
+// Value returns whether the line is active.
+func (l *GPIOLine) Value() (bool, error) {
+	values := gpioV2LineValues{mask: 1}
+	if err := ioctl(l.fd, gpioV2LineGetValuesIOCTL, unsafe.Pointer(&values)); err != nil {
+		return false, err
+	}
+	return values.bits&1 == 1, nil
+}
+
+// ReadEvents waits for edge events, it returns nil without an error when the timeout expired.
+func (l *GPIOLine) ReadEvents(timeout time.Duration) ([]GPIOEdgeEvent, error) {
+	if err := l.file.SetReadDeadline(time.Now().Add(timeout)); err != nil {
+		return nil, err
+	}
+	n, err := l.file.Read(l.buf)
+	if err != nil {
+		if errors.Is(err, os.ErrDeadlineExceeded) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return parseGPIOEvents(l.buf[:n]), nil
+}
+
+func (l *GPIOLine) Close() error {
+	return l.file.Close()
+}
+
+// FindGPIOLine searches every GPIO chip for a line by name, e.g. GPIO17 on a Raspberry Pi. Names are more stable
+// than chip numbers, which differ between kernels and between the Pi 4 and Pi 5.
+func FindGPIOLine(name string) (chip string, offset int, err error) {
+	chips, err := utils.Glob("/dev/gpiochip*")
+	if err != nil {
+		return "", 0, err
+	}
+	for _, path := range chips {
+		if offset, ok := findGPIOLineOnChip(path, name); ok {
+			return filepath.Base(path), offset, nil
+		}
+	}
+	return "", 0, fmt.Errorf("GPIO line %s not found", name)
+}
+
+func findGPIOLineOnChip(path, name string) (int, bool) {
+	fd, err := syscall.Open(path, syscall.O_RDWR|syscall.O_CLOEXEC, 0)
+	if err != nil {
+		return 0, false
+	}
+	defer syscall.Close(fd)
+	var chipInfo gpioChipInfo
+	if err := ioctl(fd, gpioGetChipInfoIOCTL, unsafe.Pointer(&chipInfo)); err != nil {
+		return 0, false
+	}
+	for offset := uint32(0); offset < chipInfo.lines; offset++ {
+		info := gpioV2LineInfo{offset: offset}
+		if err := ioctl(fd, gpioV2GetLineInfoIOCTL, unsafe.Pointer(&info)); err != nil {
+			continue
+		}
+		if cString(info.name[:]) == name {
+			return int(offset), true
+		}
+	}
+	return 0, false
+}
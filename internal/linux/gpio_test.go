@@ -0,0 +1,37 @@
+package linux
+
+import (
+	"encoding/binary"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func gpioEvent(id, seqno uint32, timestamp uint64) []byte {
+	data := make([]byte, gpioLineEventSize)
+	binary.NativeEndian.PutUint64(data[0:8], timestamp)
+	binary.NativeEndian.PutUint32(data[8:12], id)
+	binary.NativeEndian.PutUint32(data[16:20], seqno)
+	return data
+}
+
+func TestParseGPIOEvents(t *testing.T) {
+	data := append(gpioEvent(gpioLineEventRisingEdge, 1, 1000), gpioEvent(gpioLineEventFallingEdge, 2, 2000)...)
+	// A partial record is dropped
+	data = append(data, make([]byte, 10)...)
+	events := parseGPIOEvents(data)
+	require.Len(t, events, 2)
+	assert.Equal(t, GPIOEdgeEvent{Rising: true, Timestamp: time.Microsecond, Seqno: 1}, events[0])
+	assert.Equal(t, GPIOEdgeEvent{Rising: false, Timestamp: 2 * time.Microsecond, Seqno: 2}, events[1])
+}
+
+func TestGPIOLineConfigFlags(t *testing.T) {
+	flags, err := GPIOLineConfig{ActiveLow: true, Bias: "pull_up"}.flags()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(gpioLineFlagInput|gpioLineFlagEdgeRising|gpioLineFlagEdgeFalling|gpioLineFlagActiveLow|gpioLineFlagBiasPullUp), flags)
+
+	_, err = GPIOLineConfig{Bias: "floating"}.flags()
+	assert.Error(t, err)
+}
@@ -0,0 +1,29 @@
+package linux
+
+import (
+	"time"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+type GPIOLine struct{}
+
+func RequestGPIOLine(config GPIOLineConfig, consumer string) (*GPIOLine, error) {
+	return nil, utils.ErrPlatformNotSupported
+}
+
+func (l *GPIOLine) Value() (bool, error) {
+	return false, utils.ErrPlatformNotSupported
+}
+
+func (l *GPIOLine) ReadEvents(timeout time.Duration) ([]GPIOEdgeEvent, error) {
+	return nil, utils.ErrPlatformNotSupported
+}
+
+func (l *GPIOLine) Close() error {
+	return nil
+}
+
+func FindGPIOLine(name string) (chip string, offset int, err error) {
+	return "", 0, utils.ErrPlatformNotSupported
+}
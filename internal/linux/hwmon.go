@@ -0,0 +1,127 @@
+package linux
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+const HwmonRoot = "/sys/class/hwmon"
+
+var (
+	ErrHwmonNotFound = utils.NewError(utils.ErrHardwareAbsent, "hwmon device not found")
+	hwmonFanRegex    = regexp.MustCompile(`^(?:fan(\d+)_input|pwm(\d+))$`)
+)
+
+// Fan is a fan reported by a hwmon device or a board specific fan driver. Attributes the driver doesn't provide are
+// nil or empty.
+type Fan struct {
+	// Name is the hwmon device name, suffixed with the fan number when the device has more than one fan
+	Name  string
+	Label string
+	RPM   *int64
+	// Duty is the PWM duty cycle in percent
+	Duty *float64
+	// Mode is off, manual or auto
+	Mode string
+}
+
+// FindHwmon returns the /sys/class/hwmon directory of the device with the given name, e.g. rp1_adc or pwmfan. The
+// hwmon numbers depend on probe order, so they can't be hardcoded.
+func FindHwmon(ctx context.Context, name string) (string, error) {
+	return findHwmon(ctx, HwmonRoot, name)
+}
+
+func findHwmon(ctx context.Context, root, name string) (string, error) {
+	entries, err := utils.ReadDir(root)
+	if err != nil {
+		return "", err
+	}
+	for _, entry := range entries {
+		path := filepath.Join(root, entry.Name())
+		if hwmonName, err := utils.ReadFileWithContext(ctx, filepath.Join(path, "name")); err == nil && hwmonName == name {
+			return path, nil
+		}
+	}
+	return "", ErrHwmonNotFound
+}
+
+// ReadHwmonTemperature reads a temp*_input attribute and converts it from millidegrees to degrees celsius.
+func ReadHwmonTemperature(ctx context.Context, path string) (float64, error) {
+	value, err := utils.ReadInt64FromFileWithContext(ctx, path)
+	if err != nil {
+		return 0, err
+	}
+	return float64(value) / 1000, nil
+}
+
+// ReadHwmonFans returns every fan reported under root, normally HwmonRoot. A fan has a tachometer (fanN_input), a PWM
+// output (pwmN) or both.
+func ReadHwmonFans(ctx context.Context, root string) ([]Fan, error) {
+	devices, err := utils.ReadDir(root)
+	if err != nil {
+		return nil, err
+	}
+	fans := make([]Fan, 0)
+	for _, device := range devices {
+		path := filepath.Join(root, device.Name())
+		name, err := utils.ReadFileWithContext(ctx, filepath.Join(path, "name"))
+		if err != nil {
+			continue
+		}
+		// A chip that's removed while it's being read, e.g. a USB fan controller, doesn't hide the other fans
+		attributes, err := utils.ReadDir(path)
+		if err != nil {
+			continue
+		}
+		indexes := make([]int, 0)
+		for _, attribute := range attributes {
+			match := hwmonFanRegex.FindStringSubmatch(attribute.Name())
+			if match == nil {
+				continue
+			}
+			index, _ := strconv.Atoi(match[1] + match[2])
+			if !slices.Contains(indexes, index) {
+				indexes = append(indexes, index)
+			}
+		}
+		slices.Sort(indexes)
+		for _, index := range indexes {
+			fan := readHwmonFan(ctx, path, index)
+			fan.Name = name
+			if len(indexes) > 1 {
+				fan.Name = fmt.Sprintf("%s_fan%d", name, index)
+			}
+			fans = append(fans, fan)
+		}
+	}
+	return fans, nil
+}
+
+func readHwmonFan(ctx context.Context, path string, index int) Fan {
+	fan := Fan{}
+	fan.Label, _ = utils.ReadFileWithContext(ctx, filepath.Join(path, fmt.Sprintf("fan%d_label", index)))
+	if rpm, err := utils.ReadInt64FromFileWithContext(ctx, filepath.Join(path, fmt.Sprintf("fan%d_input", index))); err == nil {
+		fan.RPM = &rpm
+	}
+	if pwm, err := utils.ReadInt64FromFileWithContext(ctx, filepath.Join(path, fmt.Sprintf("pwm%d", index))); err == nil {
+		duty := utils.RoundValue(float64(pwm)/255*100, 1)
+		fan.Duty = &duty
+	}
+	if enable, err := utils.ReadInt64FromFileWithContext(ctx, filepath.Join(path, fmt.Sprintf("pwm%d_enable", index))); err == nil {
+		switch enable {
+		case 0:
+			fan.Mode = "off"
+		case 1:
+			fan.Mode = "manual"
+		default:
+			fan.Mode = "auto"
+		}
+	}
+	return fan
+}
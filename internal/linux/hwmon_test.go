@@ -0,0 +1,36 @@
+package linux
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindHwmon(t *testing.T) {
+	ctx := context.Background()
+	path, err := findHwmon(ctx, "testdata/sys_class_hwmon", "rp1_adc")
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join("testdata", "sys_class_hwmon", "hwmon1"), path)
+
+	temperature, err := ReadHwmonTemperature(ctx, filepath.Join(path, "temp1_input"))
+	require.NoError(t, err)
+	assert.Equal(t, 61.234, temperature)
+
+	_, err = findHwmon(ctx, "testdata/sys_class_hwmon", "ina3221")
+	assert.ErrorIs(t, err, ErrHwmonNotFound)
+}
+
+func TestReadHwmonFans(t *testing.T) {
+	fans, err := ReadHwmonFans(context.Background(), "testdata/sys_class_hwmon")
+	require.NoError(t, err)
+	require.Len(t, fans, 1)
+	assert.Equal(t, "pwmfan", fans[0].Name)
+	require.NotNil(t, fans[0].RPM)
+	assert.Equal(t, int64(2891), *fans[0].RPM)
+	require.NotNil(t, fans[0].Duty)
+	assert.Equal(t, 29.4, *fans[0].Duty)
+	assert.Empty(t, fans[0].Mode)
+}
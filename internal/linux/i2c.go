@@ -0,0 +1,45 @@
+package linux
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+const I2CDevicesRoot = "/sys/bus/i2c/devices"
+
+// ErrI2CAddressBusy is returned when a kernel driver is bound to the address, probing it would interleave with the
+// driver's own transfers.
+var ErrI2CAddressBusy = errors.New("i2c address is in use by a kernel driver")
+
+// I2CProbeMode is how an address is probed, the same choice i2cdetect makes.
+type I2CProbeMode string
+
+const (
+	// I2CProbeRead reads a byte, this is safe for devices that latch writes but some write-only devices don't ack it
+	I2CProbeRead I2CProbeMode = "read"
+	// I2CProbeQuick sends an SMBus quick write, it is acked by nearly everything but can corrupt some EEPROMs
+	I2CProbeQuick I2CProbeMode = "quick"
+)
+
+// I2CPermissions returns the permissions to open the I2C buses, which are opened read-write.
+func I2CPermissions(buses []int) utils.Permissions {
+	perms := utils.Permissions{Groups: []string{"i2c"}}
+	for _, bus := range buses {
+		path := fmt.Sprintf("/dev/i2c-%d", bus)
+		perms.Readable = append(perms.Readable, path)
+		perms.Writable = append(perms.Writable, path)
+	}
+	return perms
+}
+
+// I2CDriver returns the kernel driver bound to the device at address on bus, or an empty string.
+func I2CDriver(root string, bus int, address uint16) string {
+	driver, err := utils.Readlink(filepath.Join(root, fmt.Sprintf("%d-%04x", bus, address), "driver"))
+	if err != nil {
+		return ""
+	}
+	return filepath.Base(driver)
+}
@@ -0,0 +1,113 @@
+package linux
+
+import (
+	"errors"
+	"fmt"
+	"syscall"
+	"unsafe"
+)
+
+// ioctl numbers and SMBus transfer types from linux/i2c-dev.h and linux/i2c.h
+const (
+	i2cSlave      = 0x0703
+	i2cSlaveForce = 0x0706
+	i2cSMBus      = 0x0720
+
+	i2cSMBusWrite = 0
+	i2cSMBusRead  = 1
+	i2cSMBusQuick = 0
+	i2cSMBusByte  = 1
+)
+
+type i2cSMBusIoctlData struct {
+	readWrite uint8
+	command   uint8
+	size      uint32
+	data      unsafe.Pointer
+}
+
+// ProbeI2C returns whether a device acks at address on /dev/i2c-<bus>. Addresses bound to a kernel driver return
+// ErrI2CAddressBusy unless force is set.
+func ProbeI2C(bus int, address uint16, mode I2CProbeMode, force bool) (bool, error) {
+	fd, err := syscall.Open(fmt.Sprintf("/dev/i2c-%d", bus), syscall.O_RDWR|syscall.O_CLOEXEC, 0)
+	if err != nil {
+		return false, err
+	}
+	defer syscall.Close(fd)
+
+	request := uintptr(i2cSlave)
+	if force {
+		request = i2cSlaveForce
+	}
+	if _, _, errno := syscall.Syscall(syscall.SYS_IOCTL, uintptr(fd), request, uintptr(address)); errno != 0 {
+		if errors.Is(errno, syscall.EBUSY) {
+			return false, ErrI2CAddressBusy
+		}
+		return false, errno
+	}
+
+	var args i2cSMBusIoctlData
+	var data [34]byte
+	switch mode {
+	case I2CProbeQuick:
+		args = i2cSMBusIoctlData{readWrite: i2cSMBusWrite, size: i2cSMBusQuick}
+	default:
+		args = i2cSMBusIoctlData{readWrite: i2cSMBusRead, size: i2cSMBusByte, data: unsafe.Pointer(&data)}
+	}
+	if _, _, errno := syscall.Syscall(syscall.SYS_IOCTL, uintptr(fd), i2cSMBus, uintptr(unsafe.Pointer(&args))); errno != 0 {
+		// A missing device doesn't ack, the controllers report that as ENXIO, EREMOTEIO or ETIMEDOUT
+		if errors.Is(errno, syscall.ENXIO) || errors.Is(errno, syscall.EREMOTEIO) || errors.Is(errno, syscall.ETIMEDOUT) || errors.Is(errno, syscall.EIO) {
+			return false, nil
+		}
+		return false, errno
+	}
+	return true, nil
+}
+
+// I2CDevice is an open device on an I2C bus, reads and writes are plain I2C transfers to its address.
+type I2CDevice struct {
+	fd int
+}
+
+// OpenI2CDevice opens the device at address on /dev/i2c-<bus>. Addresses bound to a kernel driver return
+// ErrI2CAddressBusy.
+func OpenI2CDevice(bus int, address uint16) (*I2CDevice, error) {
+	fd, err := syscall.Open(fmt.Sprintf("/dev/i2c-%d", bus), syscall.O_RDWR|syscall.O_CLOEXEC, 0)
+	if err != nil {
+		return nil, err
+	}
+	if _, _, errno := syscall.Syscall(syscall.SYS_IOCTL, uintptr(fd), i2cSlave, uintptr(address)); errno != 0 {
+		syscall.Close(fd)
+		if errors.Is(errno, syscall.EBUSY) {
+			return nil, ErrI2CAddressBusy
+		}
+		return nil, errno
+	}
+	return &I2CDevice{fd: fd}, nil
+}
+
+func (d *I2CDevice) Write(data []byte) error {
+	n, err := syscall.Write(d.fd, data)
+	if err != nil {
+		return err
+	}
+	if n != len(data) {
+		return fmt.Errorf("short i2c write, %d of %d bytes", n, len(data))
+	}
+	return nil
+}
+
+func (d *I2CDevice) Read(data []byte) error {
+	n, err := syscall.Read(d.fd, data)
+	if err != nil {
+		return err
+	}
+	if n != len(data) {
+		return fmt.Errorf("short i2c read, %d of %d bytes", n, len(data))
+	}
+	return nil
+}
+
+func (d *I2CDevice) Close() error {
+	return syscall.Close(d.fd)
+}
@@ -0,0 +1,21 @@
+package linux
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestI2CDriver(t *testing.T) {
+	root := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "1-0068"), 0o755))
+	require.NoError(t, os.Symlink("../../../bus/i2c/drivers/rtc-ds1307", filepath.Join(root, "1-0068", "driver")))
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "1-0048"), 0o755))
+
+	assert.Equal(t, "rtc-ds1307", I2CDriver(root, 1, 0x68))
+	assert.Equal(t, "", I2CDriver(root, 1, 0x48))
+	assert.Equal(t, "", I2CDriver(root, 0, 0x68))
+}
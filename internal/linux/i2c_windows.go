@@ -0,0 +1,27 @@
+package linux
+
+import (
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+func ProbeI2C(bus int, address uint16, mode I2CProbeMode, force bool) (bool, error) {
+	return false, utils.ErrPlatformNotSupported
+}
+
+type I2CDevice struct{}
+
+func OpenI2CDevice(bus int, address uint16) (*I2CDevice, error) {
+	return nil, utils.ErrPlatformNotSupported
+}
+
+func (d *I2CDevice) Write(data []byte) error {
+	return utils.ErrPlatformNotSupported
+}
+
+func (d *I2CDevice) Read(data []byte) error {
+	return utils.ErrPlatformNotSupported
+}
+
+func (d *I2CDevice) Close() error {
+	return nil
+}
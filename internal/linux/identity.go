@@ -0,0 +1,105 @@
+package linux
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"path/filepath"
+	"strings"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+// Identity is what uniquely identifies a board. Any field the board doesn't expose is left empty.
+type Identity struct {
+	// Serial is the board serial number from the device tree or DMI
+	Serial string
+	// SoCID is the unique ID fused into the SoC, on the Raspberry Pi this is the same as the serial number
+	SoCID     string
+	MachineID string
+	// MACAddresses maps the physical network interfaces to their MAC addresses, virtual interfaces are skipped
+	MACAddresses map[string]string
+}
+
+// ReadIdentity reads the board identity.
+func ReadIdentity(ctx context.Context) (*Identity, error) {
+	return readIdentity(ctx, "/")
+}
+
+func readIdentity(ctx context.Context, root string) (*Identity, error) {
+	// Reading DMI serials requires root, so they are only used on boards without a device tree
+	serial, _ := ReadDeviceTreeString(ctx, filepath.Join(root, "proc/device-tree/serial-number"))
+	if serial == "" {
+		serial = readFirstFile(ctx, root, "sys/class/dmi/id/product_serial", "sys/class/dmi/id/board_serial")
+	}
+	socID := readFirstFile(ctx, root, "sys/devices/soc0/serial_number")
+	if socID == "" {
+		socID = readCPUInfoSerial(filepath.Join(root, "proc/cpuinfo"))
+	}
+	macs, err := readMACAddresses(ctx, filepath.Join(root, "sys/class/net"))
+	if err != nil {
+		return nil, err
+	}
+	return &Identity{
+		Serial: serial,
+		SoCID:  socID,
+		// Older distributions only have the D-Bus machine-id
+		MachineID:    readFirstFile(ctx, root, "etc/machine-id", "var/lib/dbus/machine-id"),
+		MACAddresses: macs,
+	}, nil
+}
+
+// readFirstFile returns the first of the files that exists and isn't empty. Vendors that don't fill in the DMI
+// serials leave placeholders like "Default string", which are treated as empty.
+func readFirstFile(ctx context.Context, root string, names ...string) string {
+	for _, name := range names {
+		value, err := utils.ReadFileWithContext(ctx, filepath.Join(root, name))
+		if err == nil && value != "" && value != "Default string" && value != "To Be Filled By O.E.M." {
+			return value
+		}
+	}
+	return ""
+}
+
+// readCPUInfoSerial reads the Serial line that the Raspberry Pi and Rockchip kernels add to /proc/cpuinfo.
+func readCPUInfoSerial(path string) string {
+	data, err := utils.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		key, value, ok := strings.Cut(scanner.Text(), ":")
+		if ok && strings.TrimSpace(key) == "Serial" {
+			value = strings.TrimSpace(value)
+			// Kernels without a serial report all zeros
+			if strings.Trim(value, "0") == "" {
+				return ""
+			}
+			return value
+		}
+	}
+	return ""
+}
+
+func readMACAddresses(ctx context.Context, netRoot string) (map[string]string, error) {
+	entries, err := utils.ReadDir(netRoot)
+	if err != nil {
+		return nil, err
+	}
+	macs := make(map[string]string)
+	for _, entry := range entries {
+		path := filepath.Join(netRoot, entry.Name())
+		// Only physical interfaces have a device, this skips lo, bridges, docker and VPN interfaces whose MAC
+		// addresses are random
+		if _, err := utils.Stat(filepath.Join(path, "device")); err != nil {
+			continue
+		}
+		address, err := utils.ReadFileWithContext(ctx, filepath.Join(path, "address"))
+		if err != nil || address == "" {
+			continue
+		}
+		macs[entry.Name()] = address
+	}
+	return macs, nil
+}
@@ -0,0 +1,27 @@
+package linux
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadIdentity(t *testing.T) {
+	identity, err := readIdentity(context.Background(), "testdata/identity/rpi4")
+	require.NoError(t, err)
+	assert.Equal(t, &Identity{
+		Serial:       "10000000a1b2c3d4",
+		SoCID:        "10000000a1b2c3d4",
+		MachineID:    "5f2c8e1a9b3d4c6e8f0a1b2c3d4e5f60",
+		MACAddresses: map[string]string{"eth0": "dc:a6:32:01:02:03", "wlan0": "dc:a6:32:01:02:04"},
+	}, identity)
+
+	identity, err = readIdentity(context.Background(), "testdata/identity/imx8mp")
+	require.NoError(t, err)
+	assert.Equal(t, "", identity.Serial)
+	assert.Equal(t, "1A2B3C4D5E6F7081", identity.SoCID)
+	assert.Equal(t, "0d3e7a9c1b2f4e6a8c0d2e4f6a8b0c1d", identity.MachineID)
+	assert.Equal(t, map[string]string{"eth0": "00:04:9f:07:08:09"}, identity.MACAddresses)
+}
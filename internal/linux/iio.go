@@ -0,0 +1,150 @@
+package linux
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+const IIODevicesRoot = "/sys/bus/iio/devices"
+
+// IIODevice is an Industrial I/O device, e.g. an ADC, IMU or light sensor.
+type IIODevice struct {
+	// Device is the sysfs name, e.g. iio:device0, Name is the driver's name for the chip, e.g. ads1015
+	Device string
+	Name   string
+	// Channels the IIO ABI reports in milli units are converted to volts, amps, watts, degrees Celsius and percent
+	// relative humidity. Other channel types are in the ABI's units, e.g. m/s² for accel and lux for illuminance.
+	Channels map[string]float64
+}
+
+// The IIO ABI reports these channel types in milli units
+var iioMilliTypes = map[string]bool{
+	"voltage": true,
+	"current": true,
+	"power":   true,
+	"temp":    true,
+	// Relative humidity is in milli percent
+	"humidityrelative": true,
+}
+
+// ReadIIODevices reads every IIO device under root, normally IIODevicesRoot. Triggers and buffers also live there
+// and are skipped.
+func ReadIIODevices(ctx context.Context, root string) ([]IIODevice, error) {
+	entries, err := utils.ReadDir(root)
+	if err != nil {
+		// Kernels without IIO support don't have the bus at all
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	devices := make([]IIODevice, 0, len(entries))
+	for _, entry := range entries {
+		if !strings.HasPrefix(entry.Name(), "iio:device") {
+			continue
+		}
+		path := filepath.Join(root, entry.Name())
+		name, _ := utils.ReadFileWithContext(ctx, filepath.Join(path, "name"))
+		channels, err := readIIOChannels(ctx, path)
+		if err != nil {
+			return nil, err
+		}
+		devices = append(devices, IIODevice{Device: entry.Name(), Name: name, Channels: channels})
+	}
+	sort.Slice(devices, func(i, j int) bool {
+		return iioDeviceNumber(devices[i].Device) < iioDeviceNumber(devices[j].Device)
+	})
+	return devices, nil
+}
+
+func iioDeviceNumber(device string) int {
+	n, _ := strconv.Atoi(strings.TrimPrefix(device, "iio:device"))
+	return n
+}
+
+// readIIOChannels reads the channels of a device. A channel with an _input attribute is already processed by the
+// driver, otherwise the value is (raw + offset) * scale.
+func readIIOChannels(ctx context.Context, path string) (map[string]float64, error) {
+	files, err := utils.ReadDir(path)
+	if err != nil {
+		return nil, err
+	}
+	attrs := make(map[string]bool, len(files))
+	for _, file := range files {
+		attrs[file.Name()] = true
+	}
+	readFloat := func(name string) (float64, bool) {
+		if !attrs[name] {
+			return 0, false
+		}
+		value, err := utils.ReadFileWithContext(ctx, filepath.Join(path, name))
+		if err != nil {
+			return 0, false
+		}
+		f, err := utils.ParseFloat64(value)
+		return f, err == nil
+	}
+
+	channels := make(map[string]float64)
+	for attr := range attrs {
+		if !strings.HasPrefix(attr, "in_") {
+			continue
+		}
+		var channel string
+		var value float64
+		if c, ok := strings.CutSuffix(attr, "_input"); ok {
+			channel = strings.TrimPrefix(c, "in_")
+			v, ok := readFloat(attr)
+			if !ok {
+				continue
+			}
+			value = v
+		} else if c, ok := strings.CutSuffix(attr, "_raw"); ok {
+			channel = strings.TrimPrefix(c, "in_")
+			// Prefer the processed value when the driver offers both
+			if attrs["in_"+channel+"_input"] {
+				continue
+			}
+			raw, ok := readFloat(attr)
+			if !ok {
+				continue
+			}
+			channelType := iioChannelType(channel)
+			// Scale and offset are either per channel or shared by every channel of the type
+			offset, ok := readFloat("in_" + channel + "_offset")
+			if !ok {
+				offset, _ = readFloat("in_" + channelType + "_offset")
+			}
+			scale, ok := readFloat("in_" + channel + "_scale")
+			if !ok {
+				if scale, ok = readFloat("in_" + channelType + "_scale"); !ok {
+					scale = 1
+				}
+			}
+			value = (raw + offset) * scale
+		} else {
+			continue
+		}
+		if iioMilliTypes[iioChannelType(channel)] {
+			value /= 1000
+		}
+		channels[channel] = utils.RoundValue(value, 4)
+	}
+	return channels, nil
+}
+
+// iioChannelType returns the type of a channel, e.g. voltage for voltage0, accel for accel_x and voltage for the
+// differential channel voltage0-voltage1.
+func iioChannelType(channel string) string {
+	end := strings.IndexAny(channel, "0123456789_-")
+	if end < 0 {
+		return channel
+	}
+	return channel[:end]
+}
@@ -0,0 +1,56 @@
+package linux
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadIIODevices(t *testing.T) {
+	// IIO device names contain a colon, so the tree is built here rather than kept in testdata
+	root := t.TempDir()
+	for device, attrs := range map[string]map[string]string{
+		"iio:device0": {
+			"name":              "ads1015",
+			"in_voltage0_raw":   "1650",
+			"in_voltage1_raw":   "800",
+			"in_voltage0_scale": "2.000000000",
+			"in_voltage_scale":  "1.000000000",
+		},
+		"iio:device1": {
+			"name":           "mpu6050",
+			"in_accel_x_raw": "16384",
+			"in_accel_scale": "0.000598",
+			"in_temp_raw":    "-2000",
+			"in_temp_offset": "12420",
+			"in_temp_scale":  "2.941176",
+		},
+		"iio:device2": {
+			"name":                      "sht4x",
+			"in_humidityrelative_input": "41250",
+			"in_temp_input":             "23150",
+			"in_temp_raw":               "99999",
+		},
+		"trigger0": {"name": "sysfstrig0"},
+	} {
+		require.NoError(t, os.MkdirAll(filepath.Join(root, device), 0o755))
+		for attr, value := range attrs {
+			require.NoError(t, os.WriteFile(filepath.Join(root, device, attr), []byte(value+"\n"), 0o644))
+		}
+	}
+
+	devices, err := ReadIIODevices(context.Background(), root)
+	require.NoError(t, err)
+	require.Len(t, devices, 3)
+	assert.Equal(t, IIODevice{Device: "iio:device0", Name: "ads1015", Channels: map[string]float64{"voltage0": 3.3, "voltage1": 0.8}}, devices[0])
+	assert.Equal(t, map[string]float64{"accel_x": 9.7976, "temp": 30.6471}, devices[1].Channels)
+	assert.Equal(t, map[string]float64{"humidityrelative": 41.25, "temp": 23.15}, devices[2].Channels)
+
+	devices, err = ReadIIODevices(context.Background(), filepath.Join(root, "missing"))
+	require.NoError(t, err)
+	assert.Empty(t, devices)
+}
@@ -0,0 +1,109 @@
+package imx
+
+import (
+	"context"
+	"path/filepath"
+	"sync"
+
+	"go.viam.com/rdk/logging"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/linux"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/sensors"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+// The GPU, VPU and NPU on the i.MX8M don't use devfreq, their clocks can only be read from the clock tree in debugfs,
+// which needs root. The clock names differ between the family members, so each reading lists the names to try.
+const clkDebugRoot = "/sys/kernel/debug/clk"
+
+var imxDebugClocks = []struct {
+	name     string
+	clkNames []string
+}{
+	{"gpu", []string{"gpu3d_core", "gpu_core"}},
+	{"gpu_2d", []string{"gpu2d_core"}},
+	{"vpu_g1", []string{"vpu_g1"}},
+	{"vpu_g2", []string{"vpu_g2"}},
+	{"vpu_encoder", []string{"vpu_h1", "vpu_vc8000e"}},
+	{"npu", []string{"ml_core"}},
+	{"dram", []string{"dram_core_clk", "dram_core"}},
+}
+
+type imxClockSensor struct {
+	logger     logging.Logger
+	mu         sync.RWMutex
+	name       string
+	sensorType string
+	path       string
+}
+
+func (s *imxClockSensor) Close() error {
+	return nil
+}
+
+func (s *imxClockSensor) Name() string {
+	return s.name
+}
+
+func (s *imxClockSensor) GetReadingMap(ctx context.Context) (map[string]interface{}, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	frequency, err := utils.ReadInt64FromFileWithContext(ctx, s.path)
+	if err != nil {
+		s.logger.Errorw("failed to read clock", "sensor", s.name, "error", err)
+		return nil, err
+	}
+	// cpufreq reports kHz, the clock tree reports Hz
+	if s.sensorType == "cpufreq" {
+		frequency *= 1000
+	}
+	return map[string]interface{}{
+		s.name: frequency,
+	}, nil
+}
+
+func newImxClockSensor(ctx context.Context, logger logging.Logger, name, sensorType, path string) *imxClockSensor {
+	logger.Debugf("Initializing i.MX %s clock sensor: %v", sensorType, path)
+	return &imxClockSensor{
+		logger:     logger.Sublogger(name),
+		name:       name,
+		sensorType: sensorType,
+		path:       path,
+	}
+}
+
+func GetClockSensors(ctx context.Context, logger logging.Logger) ([]sensors.ClockSensor, error) {
+	return getClockSensors(ctx, logger, linux.CPUFreqRoot, clkDebugRoot)
+}
+
+func getClockSensors(ctx context.Context, logger logging.Logger, cpufreqRoot, clkRoot string) ([]sensors.ClockSensor, error) {
+	policies, err := linux.ReadCPUFreqPolicies(ctx, cpufreqRoot)
+	if err != nil {
+		return nil, err
+	}
+	s := make([]sensors.ClockSensor, 0)
+	// The i.MX8M family has a single Cortex-A53 cluster
+	for _, policy := range policies {
+		name := "cpu"
+		if len(policies) > 1 {
+			name = "cpu_" + policy.Name
+		}
+		s = append(s, newImxClockSensor(ctx, logger, name, "cpufreq", filepath.Join(cpufreqRoot, policy.Name, "scaling_cur_freq")))
+	}
+
+	if _, err := utils.Stat(clkRoot); err != nil {
+		logger.Debugf("GPU and VPU clocks are not available without access to %s: %v", clkRoot, err)
+		return s, nil
+	}
+	for _, clock := range imxDebugClocks {
+		for _, clkName := range clock.clkNames {
+			path := filepath.Join(clkRoot, clkName, "clk_rate")
+			if _, err := utils.Stat(path); err != nil {
+				continue
+			}
+			s = append(s, newImxClockSensor(ctx, logger, clock.name, "clk", path))
+			break
+		}
+	}
+	return s, nil
+}
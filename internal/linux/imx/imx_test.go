@@ -0,0 +1,44 @@
+package imx
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.viam.com/rdk/logging"
+)
+
+func TestGetTemperatures(t *testing.T) {
+	temps, err := getTemperatures(context.Background(), "testdata/imx8mp/thermal")
+	require.NoError(t, err)
+	require.NotNil(t, temps.CPU)
+	assert.Equal(t, 45.0, *temps.CPU)
+	assert.Nil(t, temps.GPU)
+	assert.Equal(t, map[string]float64{"SOC": 44}, temps.Extra)
+}
+
+func TestGetClockSensors(t *testing.T) {
+	clocks, err := getClockSensors(context.Background(), logging.NewTestLogger(t), "testdata/imx8mp/cpufreq", "testdata/imx8mp/clk")
+	require.NoError(t, err)
+	readings := make(map[string]interface{})
+	for _, clock := range clocks {
+		reading, err := clock.GetReadingMap(context.Background())
+		require.NoError(t, err)
+		for k, v := range reading {
+			readings[k] = v
+		}
+	}
+	assert.Equal(t, map[string]interface{}{
+		"cpu":         int64(1800000000),
+		"gpu":         int64(1000000000),
+		"gpu_2d":      int64(1000000000),
+		"vpu_g1":      int64(800000000),
+		"vpu_encoder": int64(500000000),
+		"npu":         int64(1000000000),
+	}, readings)
+
+	clocks, err = getClockSensors(context.Background(), logging.NewTestLogger(t), "testdata/imx8mp/cpufreq", "testdata/imx8mp/missing")
+	require.NoError(t, err)
+	assert.Len(t, clocks, 1)
+}
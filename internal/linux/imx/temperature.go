@@ -0,0 +1,47 @@
+package imx
+
+import (
+	"context"
+	"strings"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/linux"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/sensors"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+// The i.MX8M Mini and Nano only have cpu-thermal, the Plus adds soc-thermal and the i.MX8MQ has gpu-thermal and
+// vpu-thermal.
+var imxThermalZones = map[string]string{
+	"cpu-thermal": "CPU",
+	"gpu-thermal": "GPU",
+	"soc-thermal": "SOC",
+	"vpu-thermal": "VPU",
+}
+
+func GetTemperatures(ctx context.Context) (*sensors.SystemTemperatures, error) {
+	return getTemperatures(ctx, linux.ThermalZonesRoot)
+}
+
+func getTemperatures(ctx context.Context, root string) (*sensors.SystemTemperatures, error) {
+	zones, err := linux.ReadThermalZones(ctx, root)
+	if err != nil {
+		return nil, err
+	}
+	systemTemps := &sensors.SystemTemperatures{Extra: make(map[string]float64)}
+	for zoneType, temp := range zones {
+		temp = utils.RoundValue(temp, 2)
+		name, ok := imxThermalZones[strings.ReplaceAll(zoneType, "_", "-")]
+		if !ok {
+			continue
+		}
+		switch name {
+		case "CPU":
+			systemTemps.CPU = &temp
+		case "GPU":
+			systemTemps.GPU = &temp
+		default:
+			systemTemps.Extra[name] = temp
+		}
+	}
+	return systemTemps, nil
+}
@@ -9,6 +9,7 @@ import (
 	"sync"
 
 	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/sensors"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
 	"go.viam.com/rdk/logging"
 )
 
@@ -16,14 +17,11 @@ type jetsonClockSensor struct {
 	logger     logging.Logger
 	mu         sync.RWMutex
 	name       string
-	cancelCtx  context.Context
-	cancelFunc context.CancelFunc
 	sensorType string
 	path       string
 }
 
 func (s *jetsonClockSensor) Close() error {
-	s.cancelFunc()
 	return nil
 }
 
@@ -31,14 +29,14 @@ func (s *jetsonClockSensor) Name() string {
 	return s.name
 }
 
-func (s *jetsonClockSensor) GetReadingMap() (map[string]interface{}, error) {
+func (s *jetsonClockSensor) GetReadingMap(ctx context.Context) (map[string]interface{}, error) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 	var frequency int64
 	var err error
 	switch s.sensorType {
 	case "sysfs":
-		frequency, err = s.readSysfsClock()
+		frequency, err = s.readSysfsClock(ctx)
 	default:
 		return nil, errors.New("unknown sensor type")
 	}
@@ -47,8 +45,8 @@ func (s *jetsonClockSensor) GetReadingMap() (map[string]interface{}, error) {
 	}, err
 }
 
-func (s *jetsonClockSensor) readSysfsClock() (int64, error) {
-	current, err := sensors.GetSysFsClock(s.cancelCtx, s.path)
+func (s *jetsonClockSensor) readSysfsClock(ctx context.Context) (int64, error) {
+	current, err := sensors.GetSysFsClock(ctx, s.path)
 	if err != nil {
 		s.logger.Errorw("failed to read sysfs clock", "sensor", s.name, "error", err)
 		return 0, err
@@ -59,14 +57,11 @@ func (s *jetsonClockSensor) readSysfsClock() (int64, error) {
 
 func newNvidiaJetsonCpuClockSensor(ctx context.Context, logger logging.Logger, path string) *jetsonClockSensor {
 	logger.Debugf("Initializing NVIDIA Jetson CPU clock sensor: %v", path)
-	cancelCtx, cancelFunc := context.WithCancel(ctx)
 	parts := strings.Split(path, "/")
 	sensorName := parts[len(parts)-1]
 	s := &jetsonClockSensor{
 		logger:     logger.Sublogger(sensorName),
 		name:       sensorName,
-		cancelCtx:  cancelCtx,
-		cancelFunc: cancelFunc,
 		path:       filepath.Join(path, "cpufreq/cpuinfo_cur_freq"),
 		sensorType: "sysfs",
 	}
@@ -81,19 +76,16 @@ func newNvidiaJetsonGpuClockSensor(ctx context.Context, logger logging.Logger) *
 	}
 	var gpuPath string
 	for _, path := range paths {
-		if _, err := os.Stat(path); os.IsNotExist(err) {
+		if _, err := utils.Stat(path); os.IsNotExist(err) {
 			continue
 		}
 		gpuPath = path
 		break
 	}
-	cancelCtx, cancelFunc := context.WithCancel(ctx)
 	name := "gpu0"
 	return &jetsonClockSensor{
 		logger:     logger.Sublogger(name),
 		name:       name,
-		cancelCtx:  cancelCtx,
-		cancelFunc: cancelFunc,
 		path:       gpuPath,
 		sensorType: "sysfs",
 	}
@@ -14,8 +14,8 @@ import (
 )
 
 var (
-	ErrDevicePathNotFound = errors.New("device path not found")
-	ErrStatsNotAvailable  = errors.New("stats not available for this device")
+	ErrDevicePathNotFound = utils.NewError(utils.ErrHardwareAbsent, "device path not found")
+	ErrStatsNotAvailable  = utils.NewError(utils.ErrHardwareAbsent, "stats not available for this device")
 
 	jetpack5Sensors = []jetsonGpuSensor{
 		{sensorType: sensors.GPUReadingTypeClocksGraphics, currentValuePath: "/sys/class/devfreq/17000000.ga10b/cur_freq"},
@@ -101,9 +101,9 @@ func (s *jetsonGpuSensor) GetSensorReading(ctx context.Context) (*sensors.GPUSen
 }
 
 func getJetsonGpuSensors() ([]jetsonGpuSensor, error) {
-	if _, err := os.Stat(jetpack5Sensors[0].currentValuePath); !os.IsNotExist(err) {
+	if _, err := utils.Stat(jetpack5Sensors[0].currentValuePath); !os.IsNotExist(err) {
 		return jetpack5Sensors, nil
-	} else if _, err := os.Stat(jetpack6Sensors[0].currentValuePath); !os.IsNotExist(err) {
+	} else if _, err := utils.Stat(jetpack6Sensors[0].currentValuePath); !os.IsNotExist(err) {
 		return jetpack6Sensors, nil
 	}
 
@@ -0,0 +1,127 @@
+package jetson
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+// ClockState is read back from sysfs rather than by parsing jetson_clocks --show, which needs root. jetson_clocks
+// pins the minimum CPU and GPU frequencies to their maximums, Locked reports whether that is the case.
+type ClockState struct {
+	CPUFrequency int64
+	GPUFrequency int64
+	Locked       bool
+}
+
+// The GPU is 17000000.ga10b or 17000000.gpu on Orin depending on the JetPack version, 17000000.gv11b on Xavier and
+// 57000000.gpu on the Nano.
+var gpuDevfreqPatterns = []string{"17000000.*", "57000000.gpu"}
+
+func GetClockState(ctx context.Context) (*ClockState, error) {
+	return readClockState(ctx, "/sys")
+}
+
+func readClockState(ctx context.Context, sysRoot string) (*ClockState, error) {
+	cpuFrequency, cpuLocked, err := readCPUClocks(ctx, filepath.Join(sysRoot, "devices/system/cpu"))
+	if err != nil {
+		return nil, err
+	}
+	gpuFrequency, gpuLocked, err := readGPUClocks(ctx, filepath.Join(sysRoot, "class/devfreq"))
+	if err != nil {
+		return nil, err
+	}
+	return &ClockState{
+		CPUFrequency: cpuFrequency,
+		GPUFrequency: gpuFrequency,
+		Locked:       cpuLocked && gpuLocked,
+	}, nil
+}
+
+// readCPUClocks returns the highest current CPU frequency in Hz and whether every CPU's minimum frequency is pinned
+// to its maximum.
+func readCPUClocks(ctx context.Context, cpuRoot string) (int64, bool, error) {
+	policies, err := utils.Glob(filepath.Join(cpuRoot, "cpu[0-9]*", "cpufreq"))
+	if err != nil {
+		return 0, false, err
+	}
+	var frequency int64
+	locked := len(policies) > 0
+	for _, policy := range policies {
+		current, err := utils.ReadInt64FromFileWithContext(ctx, filepath.Join(policy, "scaling_cur_freq"))
+		if err != nil {
+			// Offline CPUs keep their cpufreq directory but can't be read
+			continue
+		}
+		frequency = max(frequency, current*1000)
+		minimum, err := utils.ReadInt64FromFileWithContext(ctx, filepath.Join(policy, "scaling_min_freq"))
+		if err != nil {
+			return 0, false, err
+		}
+		maximum, err := utils.ReadInt64FromFileWithContext(ctx, filepath.Join(policy, "cpuinfo_max_freq"))
+		if err != nil {
+			return 0, false, err
+		}
+		locked = locked && minimum == maximum
+	}
+	return frequency, locked, nil
+}
+
+func readGPUClocks(ctx context.Context, devfreqRoot string) (int64, bool, error) {
+	var gpu string
+	for _, pattern := range gpuDevfreqPatterns {
+		if matches, err := utils.Glob(filepath.Join(devfreqRoot, pattern)); err == nil && len(matches) > 0 {
+			gpu = matches[0]
+			break
+		}
+	}
+	if gpu == "" {
+		return 0, false, nil
+	}
+	current, err := utils.ReadInt64FromFileWithContext(ctx, filepath.Join(gpu, "cur_freq"))
+	if err != nil {
+		return 0, false, err
+	}
+	minimum, err := utils.ReadInt64FromFileWithContext(ctx, filepath.Join(gpu, "min_freq"))
+	if err != nil {
+		return 0, false, err
+	}
+	maximum, err := utils.ReadInt64FromFileWithContext(ctx, filepath.Join(gpu, "max_freq"))
+	if err != nil {
+		return 0, false, err
+	}
+	return current, minimum == maximum, nil
+}
+
+// SetJetsonClocks engages or releases jetson_clocks. The clock settings are stored in the module data directory
+// before they are pinned so they can be restored afterwards.
+func SetJetsonClocks(ctx context.Context, enable bool) error {
+	storeFile := filepath.Join(utils.ModuleDataDir(), "jetson_clocks.conf")
+	var args []string
+	if enable {
+		if err := os.MkdirAll(utils.ModuleDataDir(), 0o755); err != nil {
+			return err
+		}
+		// Only store the clocks the first time, storing them again while they're pinned would lose the originals
+		if _, err := utils.Stat(storeFile); os.IsNotExist(err) {
+			if output, err := utils.CombinedOutput(ctx, "jetson_clocks", "--store", storeFile); err != nil {
+				return fmt.Errorf("failed to store clocks: %v, output: %s", err, string(output))
+			}
+		}
+	} else {
+		if _, err := utils.Stat(storeFile); err != nil {
+			return fmt.Errorf("no stored clocks to restore: %w", err)
+		}
+		args = []string{"--restore", storeFile}
+	}
+	if output, err := utils.CombinedOutput(ctx, "jetson_clocks", args...); err != nil {
+		return fmt.Errorf("failed to run jetson_clocks: %v, output: %s", err, string(output))
+	}
+	if !enable {
+		return os.Remove(storeFile)
+	}
+	return nil
+}
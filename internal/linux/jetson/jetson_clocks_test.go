@@ -0,0 +1,43 @@
+package jetson
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadClockState(t *testing.T) {
+	clocks, err := readClockState(context.Background(), "testdata/orin")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1510400000), clocks.CPUFrequency)
+	assert.Equal(t, int64(624750000), clocks.GPUFrequency)
+	// The CPUs are pinned but the GPU isn't
+	assert.False(t, clocks.Locked)
+}
+
+func TestReadClockStateLocked(t *testing.T) {
+	root := t.TempDir()
+	cpufreq := filepath.Join(root, "devices/system/cpu/cpu0/cpufreq")
+	gpu := filepath.Join(root, "class/devfreq/57000000.gpu")
+	require.NoError(t, os.MkdirAll(cpufreq, 0o755))
+	require.NoError(t, os.MkdirAll(gpu, 0o755))
+	for file, value := range map[string]string{
+		filepath.Join(cpufreq, "scaling_cur_freq"): "1479000",
+		filepath.Join(cpufreq, "scaling_min_freq"): "1479000",
+		filepath.Join(cpufreq, "cpuinfo_max_freq"): "1479000",
+		filepath.Join(gpu, "cur_freq"):             "921600000",
+		filepath.Join(gpu, "min_freq"):             "921600000",
+		filepath.Join(gpu, "max_freq"):             "921600000",
+	} {
+		require.NoError(t, os.WriteFile(file, []byte(value+"\n"), 0o644))
+	}
+
+	clocks, err := readClockState(context.Background(), root)
+	require.NoError(t, err)
+	assert.Equal(t, int64(921600000), clocks.GPUFrequency)
+	assert.True(t, clocks.Locked)
+}
@@ -0,0 +1,85 @@
+package jetson
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+const nvpmodelConfPath = "/etc/nvpmodel.conf"
+
+var (
+	ErrUnknownPowerMode = errors.New("unknown power mode")
+	// Power modes are declared in nvpmodel.conf as e.g. < POWER_MODEL ID=0 NAME=MAXN >
+	powerModelRegex = regexp.MustCompile(`<\s*POWER_MODEL\s+ID=(\d+)\s+NAME=(\S+)\s*>`)
+)
+
+type PowerMode struct {
+	ID   int
+	Name string
+}
+
+// GetPowerMode returns the active nvpmodel power mode.
+func GetPowerMode(ctx context.Context) (*PowerMode, error) {
+	output, err := utils.Output(ctx, "nvpmodel", "-q")
+	if err != nil {
+		return nil, err
+	}
+	return parsePowerMode(string(output))
+}
+
+func parsePowerMode(output string) (*PowerMode, error) {
+	id, err := parsePowerModeOutput(strings.TrimSpace(output))
+	if err != nil {
+		return nil, err
+	}
+	firstLine, _, _ := strings.Cut(output, "\n")
+	_, name, _ := strings.Cut(firstLine, ":")
+	return &PowerMode{ID: id, Name: strings.TrimSpace(name)}, nil
+}
+
+// ListPowerModes returns the power modes defined for this module in nvpmodel.conf.
+func ListPowerModes(ctx context.Context) ([]PowerMode, error) {
+	data, err := utils.ReadFileWithContext(ctx, nvpmodelConfPath)
+	if err != nil {
+		return nil, err
+	}
+	return parseNvpmodelConf(data), nil
+}
+
+func parseNvpmodelConf(data string) []PowerMode {
+	modes := make([]PowerMode, 0)
+	for _, match := range powerModelRegex.FindAllStringSubmatch(data, -1) {
+		id, err := strconv.Atoi(match[1])
+		if err != nil {
+			continue
+		}
+		modes = append(modes, PowerMode{ID: id, Name: match[2]})
+	}
+	return modes
+}
+
+// FindPowerMode looks up a power mode by its name, ignoring case, e.g. MAXN or 15W.
+func FindPowerMode(modes []PowerMode, name string) (*PowerMode, error) {
+	for _, mode := range modes {
+		if strings.EqualFold(mode.Name, name) {
+			return &mode, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s", ErrUnknownPowerMode, name)
+}
+
+// SetPowerMode switches nvpmodel to the given mode. Some mode changes only take effect after a reboot, nvpmodel asks
+// whether to reboot now, which is always declined so the robot can choose when to reboot.
+func SetPowerMode(ctx context.Context, id int) (rebootRequired bool, err error) {
+	output, err := utils.CombinedOutputWithInput(ctx, "no\n", "nvpmodel", "-m", strconv.Itoa(id))
+	if err != nil {
+		return false, fmt.Errorf("failed to set power mode: %v, output: %s", err, string(output))
+	}
+	return strings.Contains(strings.ToLower(string(output)), "reboot"), nil
+}
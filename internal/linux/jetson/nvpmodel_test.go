@@ -0,0 +1,47 @@
+package jetson
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const nvpmodelConf = `# Orin Nano 8GB
+< PARAM TYPE=FILE NAME=CPU_ONLINE >
+CORE_0 /sys/devices/system/cpu/cpu0/online
+
+< POWER_MODEL ID=0 NAME=15W >
+CPU_ONLINE CORE_0 1
+< POWER_MODEL ID=1 NAME=7W >
+CPU_ONLINE CORE_0 1
+< POWER_MODEL ID=2 NAME=MAXN_SUPER >
+CPU_ONLINE CORE_0 1
+
+< PM_CONFIG DEFAULT=2 >
+`
+
+func TestParseNvpmodelConf(t *testing.T) {
+	modes := parseNvpmodelConf(nvpmodelConf)
+	assert.Equal(t, []PowerMode{{ID: 0, Name: "15W"}, {ID: 1, Name: "7W"}, {ID: 2, Name: "MAXN_SUPER"}}, modes)
+
+	mode, err := FindPowerMode(modes, "maxn_super")
+	require.NoError(t, err)
+	assert.Equal(t, 2, mode.ID)
+
+	_, err = FindPowerMode(modes, "MAXN")
+	assert.ErrorIs(t, err, ErrUnknownPowerMode)
+}
+
+func TestParsePowerMode(t *testing.T) {
+	mode, err := parsePowerMode("NV Power Mode: MAXN\n0\n")
+	require.NoError(t, err)
+	assert.Equal(t, &PowerMode{ID: 0, Name: "MAXN"}, mode)
+
+	mode, err = parsePowerMode("NV Power Mode: 15W\n2\n")
+	require.NoError(t, err)
+	assert.Equal(t, &PowerMode{ID: 2, Name: "15W"}, mode)
+
+	_, err = parsePowerMode("")
+	assert.Error(t, err)
+}
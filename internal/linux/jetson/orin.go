@@ -0,0 +1,141 @@
+package jetson
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/linux"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+var (
+	ErrNotJetson = utils.NewError(utils.ErrHardwareAbsent, "board is not a Jetson")
+	ErrNotOrin   = utils.NewError(utils.ErrHardwareAbsent, "board is not a Jetson Orin")
+)
+
+// The thermal zone order differs between the AGX, NX and Nano modules, so the zones are matched by type instead of
+// by index like the older Jetsons.
+var orinThermalZones = map[string]string{
+	"cpu-thermal":    "cpu",
+	"gpu-thermal":    "gpu",
+	"cv0-thermal":    "cv0",
+	"cv1-thermal":    "cv1",
+	"cv2-thermal":    "cv2",
+	"soc0-thermal":   "soc0",
+	"soc1-thermal":   "soc1",
+	"soc2-thermal":   "soc2",
+	"tj-thermal":     "tj",
+	"tboard-thermal": "board",
+	"tdiode-thermal": "diode",
+}
+
+// OrinPowerRails are the rails the INA3221 on the Orin NX and Orin Nano modules measures. VDD_IN is the total
+// module input.
+var OrinPowerRails = []string{"VDD_IN", "VDD_CPU_GPU_CV", "VDD_SOC"}
+
+type OrinRail struct {
+	Voltage float64
+	Current float64
+	Power   float64
+}
+
+type OrinSummary struct {
+	Temperatures map[string]float64
+	Rails        map[string]OrinRail
+	CPUFrequency int64
+	GPUFrequency int64
+	// ClocksLocked is true when jetson_clocks has pinned the CPU and GPU minimum frequencies to their maximums
+	ClocksLocked bool
+}
+
+func IsOrin(board *linux.BoardInfo) bool {
+	return board.Family == linux.BoardFamilyJetson && board.SoC == "tegra234"
+}
+
+func GetOrinSummary(ctx context.Context) (*OrinSummary, error) {
+	if !IsOrin(linux.Board()) {
+		return nil, ErrNotOrin
+	}
+	return getOrinSummary(ctx, "/sys")
+}
+
+func getOrinSummary(ctx context.Context, sysRoot string) (*OrinSummary, error) {
+	temperatures, err := getOrinTemperatures(ctx, filepath.Join(sysRoot, "class/thermal"))
+	if err != nil {
+		return nil, err
+	}
+	rails, err := getOrinRails(ctx, filepath.Join(sysRoot, "class/hwmon"))
+	if err != nil {
+		return nil, err
+	}
+	summary := &OrinSummary{
+		Temperatures: temperatures,
+		Rails:        rails,
+	}
+	clocks, err := readClockState(ctx, sysRoot)
+	if err != nil {
+		return nil, err
+	}
+	summary.CPUFrequency = clocks.CPUFrequency
+	summary.GPUFrequency = clocks.GPUFrequency
+	summary.ClocksLocked = clocks.Locked
+	return summary, nil
+}
+
+func getOrinTemperatures(ctx context.Context, thermalRoot string) (map[string]float64, error) {
+	zones, err := linux.ReadThermalZones(ctx, thermalRoot)
+	if err != nil {
+		return nil, err
+	}
+	temperatures := make(map[string]float64)
+	for zoneType, temp := range zones {
+		// Zones for engines that are powered off, e.g. the CV zones on the Orin Nano, fail to read and are skipped
+		if name, ok := orinThermalZones[zoneType]; ok {
+			temperatures[name] = utils.RoundValue(temp, 2)
+		}
+	}
+	return temperatures, nil
+}
+
+func getOrinRails(ctx context.Context, hwmonRoot string) (map[string]OrinRail, error) {
+	// The AGX Orin has two INA3221s, so every ina3221 hwmon device is read rather than just the first
+	devices, err := utils.ReadDir(hwmonRoot)
+	if err != nil {
+		return nil, err
+	}
+	rails := make(map[string]OrinRail)
+	for _, device := range devices {
+		path := filepath.Join(hwmonRoot, device.Name())
+		if name, err := utils.ReadFileWithContext(ctx, filepath.Join(path, "name")); err != nil || name != "ina3221" {
+			continue
+		}
+		labels, err := utils.Glob(filepath.Join(path, "in*_label"))
+		if err != nil {
+			return nil, err
+		}
+		for _, labelFile := range labels {
+			label, err := utils.ReadFileWithContext(ctx, labelFile)
+			if err != nil || strings.Contains(label, "sum") {
+				continue
+			}
+			channel := strings.TrimSuffix(strings.TrimPrefix(filepath.Base(labelFile), "in"), "_label")
+			millivolts, err := utils.ReadInt64FromFileWithContext(ctx, filepath.Join(path, "in"+channel+"_input"))
+			if err != nil {
+				continue
+			}
+			milliamps, err := utils.ReadInt64FromFileWithContext(ctx, filepath.Join(path, "curr"+channel+"_input"))
+			if err != nil {
+				continue
+			}
+			voltage := float64(millivolts) / 1000
+			current := float64(milliamps) / 1000
+			rails[label] = OrinRail{
+				Voltage: voltage,
+				Current: current,
+				Power:   utils.RoundValue(voltage*current, 3),
+			}
+		}
+	}
+	return rails, nil
+}
@@ -0,0 +1,20 @@
+package jetson
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetOrinSummary(t *testing.T) {
+	summary, err := getOrinSummary(context.Background(), "testdata/orin")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]float64{"cpu": 48.13, "gpu": 46.5, "tj": 49.03}, summary.Temperatures)
+	require.Len(t, summary.Rails, 3)
+	assert.Equal(t, OrinRail{Voltage: 5.016, Current: 1.04, Power: 5.217}, summary.Rails["VDD_IN"])
+	assert.Equal(t, 2.4, summary.Rails["VDD_SOC"].Power)
+	assert.Equal(t, int64(1510400000), summary.CPUFrequency)
+	assert.False(t, summary.ClocksLocked)
+}
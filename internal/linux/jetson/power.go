@@ -24,8 +24,6 @@ type jetsonPowerSensor struct {
 	mu                           sync.RWMutex
 	index                        int
 	name                         string
-	cancelCtx                    context.Context
-	cancelFunc                   context.CancelFunc
 	voltageFile                  string
 	currentFile                  string
 	overCurrentAlarmFile         string
@@ -34,7 +32,6 @@ type jetsonPowerSensor struct {
 
 func (s *jetsonPowerSensor) Close() error {
 	s.logger.Infof("Shutting down %s", s.name)
-	s.cancelFunc()
 	s.logger.Infof("Shutdown complete")
 	return nil
 }
@@ -43,14 +40,14 @@ func (s *jetsonPowerSensor) GetName() string {
 	return s.name
 }
 
-func (s *jetsonPowerSensor) GetReading() (voltage, current, power float64, err error) {
+func (s *jetsonPowerSensor) GetReading(ctx context.Context) (voltage, current, power float64, err error) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
-	rawVoltage, err := utils.ReadInt64FromFileWithContext(s.cancelCtx, s.voltageFile)
+	rawVoltage, err := utils.ReadInt64FromFileWithContext(ctx, s.voltageFile)
 	if err != nil {
 		return 0, 0, 0, err
 	}
-	rawCurrent, err := utils.ReadInt64FromFileWithContext(s.cancelCtx, s.currentFile)
+	rawCurrent, err := utils.ReadInt64FromFileWithContext(ctx, s.currentFile)
 	if err != nil {
 		return 0, 0, 0, err
 	}
@@ -60,11 +57,11 @@ func (s *jetsonPowerSensor) GetReading() (voltage, current, power float64, err e
 	return voltage, current, voltage * current, nil
 }
 
-func (s *jetsonPowerSensor) GetReadingMap() (map[string]interface{}, error) {
+func (s *jetsonPowerSensor) GetReadingMap(ctx context.Context) (map[string]interface{}, error) {
 	ret := make(map[string]interface{})
 	s.mu.RLock()
 	defer s.mu.RUnlock()
-	current, voltage, power, err := s.GetReading()
+	current, voltage, power, err := s.GetReading(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -72,7 +69,7 @@ func (s *jetsonPowerSensor) GetReadingMap() (map[string]interface{}, error) {
 	ret["current"] = current
 	ret["power"] = power
 
-	overCurrentAlarm, err := utils.ReadBoolFromFileWithContext(s.cancelCtx, s.overCurrentAlarmFile)
+	overCurrentAlarm, err := utils.ReadBoolFromFileWithContext(ctx, s.overCurrentAlarmFile)
 	if err != nil {
 		if !os.IsNotExist(err) {
 			return nil, err
@@ -80,7 +77,7 @@ func (s *jetsonPowerSensor) GetReadingMap() (map[string]interface{}, error) {
 	} else {
 		ret["over_current_alarm"] = overCurrentAlarm // ensure we set this in the map if it was read successfully
 	}
-	criticalOverCurrentAlarm, err := utils.ReadBoolFromFileWithContext(s.cancelCtx, s.criticalOverCurrentAlarmFile)
+	criticalOverCurrentAlarm, err := utils.ReadBoolFromFileWithContext(ctx, s.criticalOverCurrentAlarmFile)
 	if err != nil {
 		if !os.IsNotExist(err) {
 			return nil, err
@@ -101,13 +98,10 @@ func newJetsonPowerSensor(ctx context.Context, logger logging.Logger, index int)
 		return nil, ErrIgnoredSensor
 	}
 	logger.Infof("Creating Jetson Power Sensor: %s", name)
-	ctx, cancel := context.WithCancel(ctx)
 	return &jetsonPowerSensor{
 		logger:                       logger.Sublogger(name),
 		index:                        index,
 		name:                         name,
-		cancelCtx:                    ctx,
-		cancelFunc:                   cancel,
 		overCurrentAlarmFile:         fmt.Sprintf("/sys/bus/i2c/drivers/ina3221/1-0040/hwmon/hwmon1/curr%v_alarm", index),
 		criticalOverCurrentAlarmFile: fmt.Sprintf("/sys/bus/i2c/drivers/ina3221/1-0040/hwmon/hwmon1/curr%v_crit_alarm", index),
 		voltageFile:                  fmt.Sprintf("/sys/bus/i2c/drivers/ina3221/1-0040/hwmon/hwmon1/in%v_input", index),
@@ -117,7 +111,7 @@ func newJetsonPowerSensor(ctx context.Context, logger logging.Logger, index int)
 
 func GetPowerSensors(ctx context.Context, logger logging.Logger) ([]sensors.PowerSensor, error) {
 	sensors := make([]sensors.PowerSensor, 0)
-	matches, err := filepath.Glob("/sys/bus/i2c/drivers/ina3221/1-0040/hwmon/hwmon*/in*_label")
+	matches, err := utils.Glob("/sys/bus/i2c/drivers/ina3221/1-0040/hwmon/hwmon*/in*_label")
 	if err != nil {
 		return nil, err
 	}
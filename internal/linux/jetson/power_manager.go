@@ -1,13 +1,15 @@
 package jetson
 
 import (
+	"context"
 	"errors"
 	"fmt"
-	"os/exec"
 	"strconv"
 	"strings"
 
 	"go.viam.com/rdk/logging"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
 )
 
 type PowerManagerConfig struct {
@@ -33,8 +35,8 @@ func NewPowerManager(config *PowerManagerConfig, logger logging.Logger) (*jetson
 	}, nil
 }
 
-func (pm *jetsonPowerManager) ApplyPowerMode() (rebootRequired bool, err error) {
-	currentPowerMode, err := pm.GetCurrentPowerMode()
+func (pm *jetsonPowerManager) ApplyPowerMode(ctx context.Context) (rebootRequired bool, err error) {
+	currentPowerMode, err := pm.GetCurrentPowerMode(ctx)
 	if err != nil {
 		return false, fmt.Errorf("failed to get current power mode: %v", err)
 	}
@@ -45,18 +47,15 @@ func (pm *jetsonPowerManager) ApplyPowerMode() (rebootRequired bool, err error)
 		pm.logger.Debugf("Power mode is already set to %d", pm.config.PowerMode)
 		return false, nil
 	}
-	cmd := exec.Command("nvpmodel", "-m", fmt.Sprintf("%d", pm.config.PowerMode))
-	cmd.Stdin = strings.NewReader("no\n")
-	output, err := cmd.CombinedOutput()
+	output, err := utils.CombinedOutputWithInput(ctx, "no\n", "nvpmodel", "-m", fmt.Sprintf("%d", pm.config.PowerMode))
 	if err != nil {
 		return false, fmt.Errorf("failed to set power mode: %v, output: %s", err, string(output))
 	}
 	return true, nil
 }
 
-func (pm *jetsonPowerManager) GetCurrentPowerMode() (interface{}, error) {
-	cmd := exec.Command("nvpmodel", "-q")
-	output, err := cmd.CombinedOutput()
+func (pm *jetsonPowerManager) GetCurrentPowerMode(ctx context.Context) (interface{}, error) {
+	output, err := utils.CombinedOutput(ctx, "nvpmodel", "-q")
 	if err != nil {
 		return nil, fmt.Errorf("failed to get current power mode: %v, output: %s", err, string(output))
 	}
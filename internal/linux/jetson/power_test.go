@@ -22,7 +22,7 @@ func TestJetsonPowerSensors(t *testing.T) {
 	time.Sleep(1 * time.Second)
 	for _, s := range res {
 		require.NotNil(t, s)
-		readings, err := s.GetReadingMap()
+		readings, err := s.GetReadingMap(ctx)
 		require.NoError(t, err)
 		assert.NotNil(t, readings)
 		logger.Infof("s: %v", readings)
@@ -0,0 +1,75 @@
+package jetson
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+// L4TRelease is the Linux for Tegra BSP release from /etc/nv_tegra_release.
+type L4TRelease struct {
+	Major    string
+	Revision string
+	Board    string
+	Date     string
+}
+
+// Version returns the release in the format NVIDIA uses in the release notes, e.g. 35.4.1.
+func (r *L4TRelease) Version() string {
+	return fmt.Sprintf("%s.%s", r.Major, r.Revision)
+}
+
+// ReadL4TRelease returns the L4T release the root filesystem was flashed with.
+func ReadL4TRelease(ctx context.Context) (*L4TRelease, error) {
+	data, err := utils.ReadFileWithContext(ctx, "/etc/nv_tegra_release")
+	if err != nil {
+		return nil, err
+	}
+	return parseL4TRelease(data)
+}
+
+// ReadJetPackVersion returns the version of the nvidia-jetpack meta package, it is only installed on the full JetPack images.
+func ReadJetPackVersion(ctx context.Context) (string, error) {
+	output, err := utils.Output(ctx, "dpkg-query", "-W", "-f=${Version}", "nvidia-jetpack")
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// parseL4TRelease parses the first line of /etc/nv_tegra_release, e.g.
+// "# R35 (release), REVISION: 4.1, GCID: 33958178, BOARD: t186ref, EABI: aarch64, DATE: Tue Aug  1 19:57:35 UTC 2023"
+func parseL4TRelease(data string) (*L4TRelease, error) {
+	line := strings.TrimSpace(strings.Split(data, "\n")[0])
+	line = strings.TrimSpace(strings.TrimPrefix(line, "#"))
+	ret := &L4TRelease{}
+	for i, field := range strings.Split(line, ",") {
+		field = strings.TrimSpace(field)
+		if i == 0 {
+			if fields := strings.Fields(field); len(fields) > 0 {
+				ret.Major = strings.TrimPrefix(fields[0], "R")
+			}
+			continue
+		}
+		key, value, found := strings.Cut(field, ":")
+		if !found {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		switch key {
+		case "REVISION":
+			ret.Revision = value
+		case "BOARD":
+			ret.Board = value
+		case "DATE":
+			ret.Date = value
+		}
+	}
+	if ret.Major == "" || ret.Revision == "" {
+		return nil, errors.New("unexpected contents of /etc/nv_tegra_release")
+	}
+	return ret, nil
+}
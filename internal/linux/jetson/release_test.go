@@ -0,0 +1,21 @@
+package jetson
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseL4TRelease(t *testing.T) {
+	release, err := parseL4TRelease("# R35 (release), REVISION: 4.1, GCID: 33958178, BOARD: t186ref, EABI: aarch64, DATE: Tue Aug  1 19:57:35 UTC 2023\n# KERNEL_VARIANT: oot\nTARGET_USERSPACE_LIB_DIR=nvidia\n")
+	require.NoError(t, err)
+	assert.Equal(t, "35", release.Major)
+	assert.Equal(t, "4.1", release.Revision)
+	assert.Equal(t, "35.4.1", release.Version())
+	assert.Equal(t, "t186ref", release.Board)
+	assert.Equal(t, "Tue Aug  1 19:57:35 UTC 2023", release.Date)
+
+	_, err = parseL4TRelease("")
+	assert.Error(t, err)
+}
@@ -0,0 +1,113 @@
+package linux
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+// JournalEntry is a single systemd journal entry.
+type JournalEntry struct {
+	Cursor   string
+	Unit     string
+	Priority int
+	Message  string
+	Time     time.Time
+}
+
+// JournalCursor returns the cursor of the newest journal entry, reading after it only returns new entries.
+func JournalCursor(ctx context.Context) (string, error) {
+	out, err := utils.Output(ctx, "journalctl", "-n", "0", "--show-cursor", "-q", "--no-pager")
+	if err != nil {
+		return "", err
+	}
+	for _, line := range strings.Split(string(out), "\n") {
+		if cursor, found := strings.CutPrefix(line, "-- cursor: "); found {
+			return strings.TrimSpace(cursor), nil
+		}
+	}
+	return "", fmt.Errorf("journalctl did not report a cursor")
+}
+
+// ReadJournal returns the entries after the cursor at the given priority or above, optionally limited to some units.
+// This shells out to journalctl rather than linking libsystemd, which would require cgo.
+func ReadJournal(ctx context.Context, afterCursor, priority string, units []string) ([]JournalEntry, error) {
+	args := []string{"-p", priority, "-o", "json", "-q", "--no-pager"}
+	if afterCursor != "" {
+		args = append(args, "--after-cursor", afterCursor)
+	}
+	for _, unit := range units {
+		args = append(args, "-u", unit)
+	}
+	out, err := utils.Output(ctx, "journalctl", args...)
+	if err != nil {
+		return nil, err
+	}
+	return parseJournalJSON(string(out))
+}
+
+type journalJSON struct {
+	Cursor            string          `json:"__CURSOR"`
+	RealtimeTimestamp string          `json:"__REALTIME_TIMESTAMP"`
+	Priority          string          `json:"PRIORITY"`
+	SystemdUnit       string          `json:"_SYSTEMD_UNIT"`
+	SyslogIdentifier  string          `json:"SYSLOG_IDENTIFIER"`
+	Message           json.RawMessage `json:"MESSAGE"`
+}
+
+// parseJournalJSON parses the output of journalctl -o json, one JSON object per line.
+func parseJournalJSON(out string) ([]JournalEntry, error) {
+	entries := make([]JournalEntry, 0)
+	scanner := bufio.NewScanner(strings.NewReader(out))
+	scanner.Buffer(make([]byte, 64*1024), 4*1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		var raw journalJSON
+		if err := json.Unmarshal([]byte(line), &raw); err != nil {
+			return nil, fmt.Errorf("failed to parse journal entry: %w", err)
+		}
+		entry := JournalEntry{
+			Cursor:  raw.Cursor,
+			Unit:    raw.SystemdUnit,
+			Message: decodeJournalMessage(raw.Message),
+		}
+		// Kernel messages and some daemons don't have a unit, fall back to the syslog identifier
+		if entry.Unit == "" {
+			entry.Unit = raw.SyslogIdentifier
+		}
+		if priority, err := strconv.Atoi(raw.Priority); err == nil {
+			entry.Priority = priority
+		}
+		if usec, err := strconv.ParseInt(raw.RealtimeTimestamp, 10, 64); err == nil {
+			entry.Time = time.UnixMicro(usec)
+		}
+		entries = append(entries, entry)
+	}
+	return entries, scanner.Err()
+}
+
+// decodeJournalMessage handles both representations of MESSAGE, a string or an array of bytes for non UTF-8 data.
+func decodeJournalMessage(raw json.RawMessage) string {
+	var message string
+	if err := json.Unmarshal(raw, &message); err == nil {
+		return message
+	}
+	var data []byte
+	var ints []int
+	if err := json.Unmarshal(raw, &ints); err == nil {
+		data = make([]byte, len(ints))
+		for i, v := range ints {
+			data[i] = byte(v)
+		}
+	}
+	return string(data)
+}
@@ -0,0 +1,32 @@
+package linux
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseJournalJSON(t *testing.T) {
+	b, err := os.ReadFile("testdata/journal.json")
+	require.NoError(t, err)
+	entries, err := parseJournalJSON(string(b))
+	require.NoError(t, err)
+	require.Len(t, entries, 3)
+
+	assert.Equal(t, "s=abc;i=1", entries[0].Cursor)
+	assert.Equal(t, "viam-agent.service", entries[0].Unit)
+	assert.Equal(t, 3, entries[0].Priority)
+	assert.Equal(t, "failed to connect to app.viam.com", entries[0].Message)
+	assert.Equal(t, time.Unix(1715000000, 0), entries[0].Time)
+
+	assert.Equal(t, "kernel", entries[1].Unit)
+	assert.Equal(t, 2, entries[1].Priority)
+
+	assert.Equal(t, "bad\xff", entries[2].Message)
+
+	_, err = parseJournalJSON("not json")
+	assert.Error(t, err)
+}
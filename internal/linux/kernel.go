@@ -0,0 +1,141 @@
+package linux
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+// TaintFlag is a bit of /proc/sys/kernel/tainted, see Documentation/admin-guide/tainted-kernels.rst.
+type TaintFlag struct {
+	Bit    uint
+	Letter string
+	Name   string
+}
+
+var taintFlags = []TaintFlag{
+	{0, "P", "proprietary_module"},
+	{1, "F", "forced_module_load"},
+	{2, "S", "cpu_out_of_spec"},
+	{3, "R", "forced_module_unload"},
+	{4, "M", "machine_check"},
+	{5, "B", "bad_page"},
+	{6, "U", "user_request"},
+	{7, "D", "kernel_died"},
+	{8, "A", "acpi_table_overridden"},
+	{9, "W", "kernel_warning"},
+	{10, "C", "staging_driver"},
+	{11, "I", "firmware_workaround"},
+	{12, "O", "out_of_tree_module"},
+	{13, "E", "unsigned_module"},
+	{14, "L", "soft_lockup"},
+	{15, "K", "live_patched"},
+	{16, "X", "auxiliary"},
+	{17, "T", "randstruct"},
+	{18, "N", "test_module"},
+}
+
+// ReadKernelTaint returns the raw value of /proc/sys/kernel/tainted.
+func ReadKernelTaint(ctx context.Context) (uint64, error) {
+	value, err := utils.ReadInt64FromFileWithContext(ctx, "/proc/sys/kernel/tainted")
+	if err != nil {
+		return 0, err
+	}
+	return uint64(value), nil
+}
+
+// DecodeTaint returns the flags set in a taint value, bits the kernel added after this list was written are
+// returned with a name of bit_<n>.
+func DecodeTaint(value uint64) []TaintFlag {
+	var ret []TaintFlag
+	for bit := uint(0); bit < 64; bit++ {
+		if value&(1<<bit) == 0 {
+			continue
+		}
+		if int(bit) < len(taintFlags) {
+			ret = append(ret, taintFlags[bit])
+		} else {
+			ret = append(ret, TaintFlag{Bit: bit, Letter: "?", Name: fmt.Sprintf("bit_%d", bit)})
+		}
+	}
+	return ret
+}
+
+// ReadKernelRelease returns the running kernel release, e.g. 6.6.31+rpt-rpi-v8.
+func ReadKernelRelease(ctx context.Context) (string, error) {
+	return utils.ReadFileWithContext(ctx, "/proc/sys/kernel/osrelease")
+}
+
+// KernelModules lists the modules that are loaded and the modules that are built into the running kernel.
+type KernelModules struct {
+	Loaded  map[string]bool
+	Builtin map[string]bool
+}
+
+// Available returns true when the module is loaded or built in, dashes and underscores are interchangeable
+// in module names.
+func (m *KernelModules) Available(name string) bool {
+	name = normalizeModuleName(name)
+	return m.Loaded[name] || m.Builtin[name]
+}
+
+// GetKernelModules reads /proc/modules and the modules.builtin of the running kernel.
+func GetKernelModules(ctx context.Context) (*KernelModules, error) {
+	release, err := ReadKernelRelease(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return getKernelModules(ctx, "/proc/modules", filepath.Join("/lib/modules", release, "modules.builtin"))
+}
+
+func getKernelModules(ctx context.Context, procModulesPath, builtinPath string) (*KernelModules, error) {
+	data, err := utils.ReadFileWithContext(ctx, procModulesPath)
+	if err != nil {
+		return nil, err
+	}
+	modules := &KernelModules{Loaded: parseProcModules(data), Builtin: map[string]bool{}}
+
+	// modules.builtin is missing on some vendor kernels, fall back to only reporting loadable modules
+	data, err = utils.ReadFileWithContext(ctx, builtinPath)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	if err == nil {
+		modules.Builtin = parseModulesBuiltin(data)
+	}
+	return modules, nil
+}
+
+// parseProcModules parses lines like "spidev 20480 0 - Live 0xffffffc0011d4000".
+func parseProcModules(data string) map[string]bool {
+	ret := make(map[string]bool)
+	for _, line := range strings.Split(data, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		ret[normalizeModuleName(fields[0])] = true
+	}
+	return ret
+}
+
+// parseModulesBuiltin parses lines like "kernel/drivers/spi/spidev.ko".
+func parseModulesBuiltin(data string) map[string]bool {
+	ret := make(map[string]bool)
+	for _, line := range strings.Split(data, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		ret[normalizeModuleName(strings.TrimSuffix(filepath.Base(line), ".ko"))] = true
+	}
+	return ret
+}
+
+func normalizeModuleName(name string) string {
+	return strings.ReplaceAll(name, "-", "_")
+}
@@ -0,0 +1,42 @@
+package linux
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeTaint(t *testing.T) {
+	assert.Empty(t, DecodeTaint(0))
+
+	// P and O, typical for a board running a proprietary GPU driver and an out of tree module
+	flags := DecodeTaint(1<<0 | 1<<12)
+	require.Len(t, flags, 2)
+	assert.Equal(t, "P", flags[0].Letter)
+	assert.Equal(t, "proprietary_module", flags[0].Name)
+	assert.Equal(t, "O", flags[1].Letter)
+	assert.Equal(t, uint(12), flags[1].Bit)
+
+	flags = DecodeTaint(1 << 40)
+	require.Len(t, flags, 1)
+	assert.Equal(t, "bit_40", flags[0].Name)
+}
+
+func TestGetKernelModules(t *testing.T) {
+	modules, err := getKernelModules(context.Background(), "testdata/proc_modules.txt", "testdata/modules.builtin")
+	require.NoError(t, err)
+	assert.Len(t, modules.Loaded, 6)
+	assert.Len(t, modules.Builtin, 3)
+	assert.True(t, modules.Available("can"))
+	assert.True(t, modules.Available("spidev"))
+	assert.True(t, modules.Available("i2c-dev"))
+	assert.True(t, modules.Available("i2c_bcm2835"))
+	assert.False(t, modules.Available("gs_usb"))
+
+	modules, err = getKernelModules(context.Background(), "testdata/proc_modules.txt", "testdata/missing.builtin")
+	require.NoError(t, err)
+	assert.Empty(t, modules.Builtin)
+	assert.True(t, modules.Available("videodev"))
+}
@@ -0,0 +1,77 @@
+package linux
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+const (
+	kmsgPath = "/dev/kmsg"
+	// dmesgRestrictPath is 1 when reading the kernel log needs CAP_SYSLOG
+	dmesgRestrictPath = "/proc/sys/kernel/dmesg_restrict"
+)
+
+// KmsgPermissions returns the permissions to read /dev/kmsg, which needs CAP_SYSLOG when the kernel restricts dmesg to
+// it, as many distributions do.
+func KmsgPermissions(ctx context.Context) utils.Permissions {
+	perms := utils.Permissions{Readable: []string{kmsgPath}}
+	if restrict, err := utils.ReadInt64FromFileWithContext(ctx, dmesgRestrictPath); err == nil && restrict != 0 {
+		perms.Capabilities = []string{"CAP_SYSLOG"}
+	}
+	return perms
+}
+
+// KmsgRecord is a single record read from /dev/kmsg.
+type KmsgRecord struct {
+	Priority int
+	Sequence uint64
+	// SinceBoot is the kernel monotonic timestamp of the record.
+	SinceBoot time.Duration
+	Message   string
+}
+
+// Time converts the monotonic kernel timestamp into wall clock time using the given boot time.
+func (r KmsgRecord) Time(bootTime time.Time) time.Time {
+	return bootTime.Add(r.SinceBoot)
+}
+
+// parseKmsgRecord parses a record in the format documented in Documentation/ABI/testing/dev-kmsg:
+//
+//	<prefix>,<sequence>,<timestamp>,<flags>[,additional];<message>\n[ KEY=value\n...]
+func parseKmsgRecord(data string) (KmsgRecord, error) {
+	header, body, found := strings.Cut(data, ";")
+	if !found {
+		return KmsgRecord{}, errors.New("kmsg record is missing the header separator")
+	}
+	fields := strings.Split(header, ",")
+	if len(fields) < 3 {
+		return KmsgRecord{}, fmt.Errorf("unexpected kmsg header %q", header)
+	}
+	prefix, err := strconv.Atoi(fields[0])
+	if err != nil {
+		return KmsgRecord{}, fmt.Errorf("failed to parse kmsg priority: %w", err)
+	}
+	sequence, err := strconv.ParseUint(fields[1], 10, 64)
+	if err != nil {
+		return KmsgRecord{}, fmt.Errorf("failed to parse kmsg sequence: %w", err)
+	}
+	usec, err := strconv.ParseInt(fields[2], 10, 64)
+	if err != nil {
+		return KmsgRecord{}, fmt.Errorf("failed to parse kmsg timestamp: %w", err)
+	}
+	// The message is the first line, continuation lines hold the device dictionary
+	message, _, _ := strings.Cut(body, "\n")
+	return KmsgRecord{
+		// The lower 3 bits are the syslog priority, the rest is the facility
+		Priority:  prefix & 0x7,
+		Sequence:  sequence,
+		SinceBoot: time.Duration(usec) * time.Microsecond,
+		Message:   message,
+	}, nil
+}
@@ -0,0 +1,66 @@
+package linux
+
+import (
+	"errors"
+	"sync"
+	"syscall"
+)
+
+// KmsgReader reads records from /dev/kmsg without blocking.
+type KmsgReader struct {
+	mu  sync.Mutex
+	fd  int
+	buf []byte
+}
+
+// OpenKmsg opens /dev/kmsg. When fromEnd is true, records already in the ring buffer are skipped.
+func OpenKmsg(fromEnd bool) (*KmsgReader, error) {
+	fd, err := syscall.Open(kmsgPath, syscall.O_RDONLY|syscall.O_NONBLOCK|syscall.O_CLOEXEC, 0)
+	if err != nil {
+		return nil, err
+	}
+	if fromEnd {
+		if _, err := syscall.Seek(fd, 0, 2); err != nil {
+			syscall.Close(fd)
+			return nil, err
+		}
+	}
+	return &KmsgReader{fd: fd, buf: make([]byte, 8192)}, nil
+}
+
+// ReadAvailable returns all records that are currently available, it never blocks.
+func (k *KmsgReader) ReadAvailable() ([]KmsgRecord, error) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	records := make([]KmsgRecord, 0)
+	for {
+		n, err := syscall.Read(k.fd, k.buf)
+		if err != nil {
+			if errors.Is(err, syscall.EAGAIN) {
+				return records, nil
+			}
+			if errors.Is(err, syscall.EPIPE) {
+				// Records were overwritten before we could read them, the next read continues with the oldest available record
+				continue
+			}
+			if errors.Is(err, syscall.EINTR) {
+				continue
+			}
+			return records, err
+		}
+		if n == 0 {
+			return records, nil
+		}
+		record, err := parseKmsgRecord(string(k.buf[:n]))
+		if err != nil {
+			continue
+		}
+		records = append(records, record)
+	}
+}
+
+func (k *KmsgReader) Close() error {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	return syscall.Close(k.fd)
+}
@@ -0,0 +1,17 @@
+package linux
+
+import "github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+
+type KmsgReader struct{}
+
+func OpenKmsg(fromEnd bool) (*KmsgReader, error) {
+	return nil, utils.ErrPlatformNotSupported
+}
+
+func (k *KmsgReader) ReadAvailable() ([]KmsgRecord, error) {
+	return nil, utils.ErrPlatformNotSupported
+}
+
+func (k *KmsgReader) Close() error {
+	return nil
+}
@@ -0,0 +1,125 @@
+package linux
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+const LEDsRoot = "/sys/class/leds"
+
+// LED is an LED the kernel controls, e.g. the Raspberry Pi's ACT and PWR LEDs or a BeagleBone's usr LEDs.
+type LED struct {
+	// Name is the LED's sysfs name, often device:color:function, e.g. beaglebone:green:usr0
+	Name          string
+	Brightness    int64
+	MaxBrightness int64
+	// Trigger is the kernel event driving the LED, e.g. mmc0 or heartbeat, none when it's set by hand
+	Trigger string
+	// Triggers are the triggers the LED can be set to
+	Triggers []string
+	path     string
+}
+
+// ReadLEDs reads the LEDs under root, normally LEDsRoot.
+func ReadLEDs(ctx context.Context, root string) ([]LED, error) {
+	entries, err := utils.ReadDir(root)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	leds := make([]LED, 0, len(entries))
+	for _, entry := range entries {
+		path := filepath.Join(root, entry.Name())
+		brightness, err := utils.ReadInt64FromFileWithContext(ctx, filepath.Join(path, "brightness"))
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			continue
+		}
+		led := LED{Name: entry.Name(), Brightness: brightness, path: path}
+		led.MaxBrightness, _ = utils.ReadInt64FromFileWithContext(ctx, filepath.Join(path, "max_brightness"))
+		if triggers, err := utils.ReadFileWithContext(ctx, filepath.Join(path, "trigger")); err == nil {
+			led.Trigger, led.Triggers = parseLEDTriggers(triggers)
+		}
+		leds = append(leds, led)
+	}
+	sort.Slice(leds, func(i, j int) bool { return leds[i].Name < leds[j].Name })
+	return leds, nil
+}
+
+// parseLEDTriggers parses an LED's trigger attribute, the available triggers with the current one in brackets, e.g.
+// "none timer [heartbeat] mmc0".
+func parseLEDTriggers(data string) (string, []string) {
+	current := ""
+	triggers := make([]string, 0)
+	for _, field := range strings.Fields(data) {
+		if strings.HasPrefix(field, "[") && strings.HasSuffix(field, "]") {
+			field = strings.Trim(field, "[]")
+			current = field
+		}
+		triggers = append(triggers, field)
+	}
+	return current, triggers
+}
+
+// FindLED finds an LED by name.
+func FindLED(leds []LED, name string) (*LED, error) {
+	for i, led := range leds {
+		if led.Name == name {
+			return &leds[i], nil
+		}
+	}
+	return nil, fmt.Errorf("LED %s not found", name)
+}
+
+// SetBrightness sets the LED's brightness, which also sets its trigger to none.
+func (l *LED) SetBrightness(brightness int64) error {
+	if brightness < 0 || brightness > l.MaxBrightness {
+		return fmt.Errorf("brightness for LED %s must be between 0 and %d", l.Name, l.MaxBrightness)
+	}
+	if err := os.WriteFile(filepath.Join(l.path, "brightness"), []byte(strconv.FormatInt(brightness, 10)), 0o644); err != nil {
+		return err
+	}
+	l.Brightness = brightness
+	l.Trigger = "none"
+	return nil
+}
+
+// SetTrigger sets the kernel event driving the LED.
+func (l *LED) SetTrigger(trigger string) error {
+	found := false
+	for _, t := range l.Triggers {
+		found = found || t == trigger
+	}
+	if !found {
+		return fmt.Errorf("LED %s doesn't support trigger %s", l.Name, trigger)
+	}
+	if err := os.WriteFile(filepath.Join(l.path, "trigger"), []byte(trigger), 0o644); err != nil {
+		return err
+	}
+	l.Trigger = trigger
+	return nil
+}
+
+// Blink makes the LED blink with the timer trigger, which needs the ledtrig-timer module.
+func (l *LED) Blink(on, off time.Duration) error {
+	if err := l.SetTrigger("timer"); err != nil {
+		return err
+	}
+	// The timer trigger creates its delay attributes when it is set
+	if err := os.WriteFile(filepath.Join(l.path, "delay_on"), []byte(strconv.FormatInt(on.Milliseconds(), 10)), 0o644); err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(l.path, "delay_off"), []byte(strconv.FormatInt(off.Milliseconds(), 10)), 0o644)
+}
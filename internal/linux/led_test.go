@@ -0,0 +1,67 @@
+package linux
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadLEDs(t *testing.T) {
+	// LED names contain colons, so the tree is built here rather than kept in testdata
+	root := t.TempDir()
+	for led, attrs := range map[string]map[string]string{
+		"ACT":                   {"brightness": "0", "max_brightness": "255", "trigger": "none timer heartbeat [mmc0] default-on"},
+		"PWR":                   {"brightness": "255", "max_brightness": "255", "trigger": "[none] timer heartbeat mmc0 default-on"},
+		"beaglebone:green:usr0": {"brightness": "1", "max_brightness": "1", "trigger": "none [heartbeat]"},
+		"not-an-led":            {},
+	} {
+		require.NoError(t, os.MkdirAll(filepath.Join(root, led), 0o755))
+		for attr, value := range attrs {
+			require.NoError(t, os.WriteFile(filepath.Join(root, led, attr), []byte(value+"\n"), 0o644))
+		}
+	}
+
+	leds, err := ReadLEDs(context.Background(), root)
+	require.NoError(t, err)
+	require.Len(t, leds, 3)
+	assert.Equal(t, "ACT", leds[0].Name)
+	assert.Equal(t, int64(0), leds[0].Brightness)
+	assert.Equal(t, int64(255), leds[0].MaxBrightness)
+	assert.Equal(t, "mmc0", leds[0].Trigger)
+	assert.Equal(t, []string{"none", "timer", "heartbeat", "mmc0", "default-on"}, leds[0].Triggers)
+	assert.Equal(t, "none", leds[1].Trigger)
+	assert.Equal(t, "beaglebone:green:usr0", leds[2].Name)
+	assert.Equal(t, "heartbeat", leds[2].Trigger)
+
+	led, err := FindLED(leds, "ACT")
+	require.NoError(t, err)
+	require.NoError(t, led.Blink(100*time.Millisecond, 400*time.Millisecond))
+	assertFileContents(t, filepath.Join(root, "ACT", "trigger"), "timer")
+	assertFileContents(t, filepath.Join(root, "ACT", "delay_on"), "100")
+	assertFileContents(t, filepath.Join(root, "ACT", "delay_off"), "400")
+
+	require.NoError(t, led.SetBrightness(128))
+	assertFileContents(t, filepath.Join(root, "ACT", "brightness"), "128")
+	assert.Equal(t, "none", led.Trigger)
+	assert.Error(t, led.SetBrightness(256))
+
+	led, err = FindLED(leds, "beaglebone:green:usr0")
+	require.NoError(t, err)
+	// Without ledtrig-timer loaded the LED can't blink
+	assert.Error(t, led.Blink(100*time.Millisecond, 100*time.Millisecond))
+
+	_, err = FindLED(leds, "missing")
+	assert.Error(t, err)
+}
+
+func assertFileContents(t *testing.T, path, expected string) {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, expected, string(data))
+}
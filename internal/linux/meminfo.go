@@ -0,0 +1,136 @@
+package linux
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+type SlabCache struct {
+	Name       string
+	ActiveObjs uint64
+	NumObjs    uint64
+	ObjSize    uint64
+	// Size is the memory held by the cache in bytes, computed the same way slabtop does
+	Size uint64
+}
+
+type HugepagePool struct {
+	// PageSize is the size of a single page in the pool in kB
+	PageSize uint64
+	Total    uint64
+	Free     uint64
+	Reserved uint64
+	Surplus  uint64
+}
+
+// ReadMeminfo returns the values of /proc/meminfo, values with a kB suffix are converted to bytes.
+func ReadMeminfo(ctx context.Context) (map[string]uint64, error) {
+	data, err := utils.ReadFileWithContext(ctx, "/proc/meminfo")
+	if err != nil {
+		return nil, err
+	}
+	return parseMeminfo(data)
+}
+
+func parseMeminfo(data string) (map[string]uint64, error) {
+	ret := make(map[string]uint64)
+	for _, line := range strings.Split(data, "\n") {
+		key, value, found := strings.Cut(line, ":")
+		if !found {
+			continue
+		}
+		fields := strings.Fields(value)
+		if len(fields) == 0 {
+			continue
+		}
+		v, err := strconv.ParseUint(fields[0], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", key, err)
+		}
+		if len(fields) > 1 && fields[1] == "kB" {
+			v *= 1024
+		}
+		ret[key] = v
+	}
+	return ret, nil
+}
+
+// ReadSlabCaches returns the slab caches sorted by size, largest first. /proc/slabinfo is only readable by root.
+func ReadSlabCaches(ctx context.Context, pageSize uint64) ([]SlabCache, error) {
+	data, err := utils.ReadFileWithContext(ctx, "/proc/slabinfo")
+	if err != nil {
+		return nil, err
+	}
+	return parseSlabinfo(data, pageSize)
+}
+
+func parseSlabinfo(data string, pageSize uint64) ([]SlabCache, error) {
+	caches := make([]SlabCache, 0)
+	for _, line := range strings.Split(data, "\n") {
+		if strings.HasPrefix(line, "slabinfo") || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		// name active_objs num_objs objsize objperslab pagesperslab : tunables x x x : slabdata active_slabs num_slabs sharedavail
+		if len(fields) < 15 {
+			return nil, fmt.Errorf("unexpected slabinfo line %q", line)
+		}
+		values := make([]uint64, 0, 6)
+		for _, idx := range []int{1, 2, 3, 5, 14} {
+			v, err := strconv.ParseUint(fields[idx], 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse slabinfo line %q: %w", line, err)
+			}
+			values = append(values, v)
+		}
+		caches = append(caches, SlabCache{
+			Name:       fields[0],
+			ActiveObjs: values[0],
+			NumObjs:    values[1],
+			ObjSize:    values[2],
+			Size:       values[4] * values[3] * pageSize,
+		})
+	}
+	sort.SliceStable(caches, func(i, j int) bool {
+		return caches[i].Size > caches[j].Size
+	})
+	return caches, nil
+}
+
+// ReadHugepagePools returns the state of every hugepage pool in /sys/kernel/mm/hugepages.
+func ReadHugepagePools(ctx context.Context) ([]HugepagePool, error) {
+	paths, err := utils.Glob("/sys/kernel/mm/hugepages/hugepages-*kB")
+	if err != nil {
+		return nil, err
+	}
+	pools := make([]HugepagePool, 0, len(paths))
+	for _, path := range paths {
+		var pool HugepagePool
+		if _, err := fmt.Sscanf(filepath.Base(path), "hugepages-%dkB", &pool.PageSize); err != nil {
+			return nil, err
+		}
+		for file, dest := range map[string]*uint64{
+			"nr_hugepages":      &pool.Total,
+			"free_hugepages":    &pool.Free,
+			"resv_hugepages":    &pool.Reserved,
+			"surplus_hugepages": &pool.Surplus,
+		} {
+			v, err := utils.ReadInt64FromFileWithContext(ctx, filepath.Join(path, file))
+			if err != nil {
+				return nil, err
+			}
+			*dest = uint64(v)
+		}
+		pools = append(pools, pool)
+	}
+	return pools, nil
+}
@@ -0,0 +1,35 @@
+package linux
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMeminfo(t *testing.T) {
+	b, err := os.ReadFile("testdata/meminfo.txt")
+	require.NoError(t, err)
+	meminfo, err := parseMeminfo(string(b))
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3884412*1024), meminfo["MemTotal"])
+	assert.Equal(t, uint64(9472*1024), meminfo["KernelStack"])
+	// Page counts don't have a unit
+	assert.Equal(t, uint64(4), meminfo["HugePages_Total"])
+}
+
+func TestParseSlabinfo(t *testing.T) {
+	b, err := os.ReadFile("testdata/slabinfo.txt")
+	require.NoError(t, err)
+	caches, err := parseSlabinfo(string(b), 4096)
+	require.NoError(t, err)
+	require.Len(t, caches, 4)
+	assert.Equal(t, "v4l2_buffer", caches[0].Name)
+	assert.Equal(t, uint64(2813*4*4096), caches[0].Size)
+	assert.Equal(t, uint64(90000), caches[0].ActiveObjs)
+	assert.Equal(t, uint64(512), caches[0].ObjSize)
+	assert.Equal(t, "ext4_inode_cache", caches[1].Name)
+	assert.Equal(t, "kmalloc-4k", caches[2].Name)
+	assert.Equal(t, "dentry", caches[3].Name)
+}
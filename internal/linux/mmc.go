@@ -0,0 +1,213 @@
+package linux
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+// MMCDevice describes an SD card or eMMC from the attributes in /sys/block/mmcblk*/device.
+type MMCDevice struct {
+	Name string
+	// Type is "SD", "MMC" or "SDIO"
+	Type             string
+	CID              string
+	ManufacturerID   uint64
+	Manufacturer     string
+	OEMID            string
+	ProductName      string
+	Serial           string
+	ManufactureDate  string
+	HardwareRevision string
+	FirmwareRevision string
+	SizeBytes        uint64
+	// Health is only reported by eMMC 5.0 and newer, it is nil for SD cards
+	Health *MMCHealth
+}
+
+// MMCHealth holds the device life time estimates from the EXT_CSD register.
+type MMCHealth struct {
+	// LifeTimeEstimateA and B are the upper bound of the life used in percent for the SLC and MLC areas,
+	// the device reports them in steps of 10%, 110 means the estimated life time was exceeded
+	LifeTimeEstimateA int
+	LifeTimeEstimateB int
+	// PreEOLInfo is 1 (normal), 2 (warning, 80% of the reserved blocks consumed) or 3 (urgent, 90%)
+	PreEOLInfo int
+}
+
+// LifeUsedPercent returns the worst of the two life time estimates.
+func (h *MMCHealth) LifeUsedPercent() int {
+	return max(h.LifeTimeEstimateA, h.LifeTimeEstimateB)
+}
+
+// PreEOLState returns a readable name for PreEOLInfo.
+func (h *MMCHealth) PreEOLState() string {
+	switch h.PreEOLInfo {
+	case 1:
+		return "normal"
+	case 2:
+		return "warning"
+	case 3:
+		return "urgent"
+	default:
+		return "undefined"
+	}
+}
+
+// The manufacturer ids are assigned by the SD Association and JEDEC respectively and aren't published,
+// these are the commonly known ones. Counterfeit cards frequently carry an id that isn't on this list.
+var (
+	sdManufacturers = map[uint64]string{
+		0x01: "Panasonic",
+		0x02: "Toshiba",
+		0x03: "SanDisk",
+		0x09: "ATP",
+		0x12: "Patriot",
+		0x13: "Sony",
+		0x1b: "Samsung",
+		0x1d: "ADATA",
+		0x27: "Phison",
+		0x28: "Lexar",
+		0x31: "Silicon Power",
+		0x41: "Kingston",
+		0x6f: "STEC",
+		0x74: "Transcend",
+		0x76: "Patriot",
+		0x82: "Sony",
+		0x89: "Intenso",
+		0x9c: "Angelbird",
+		0x9f: "Kingston",
+		0xad: "Longsys",
+	}
+	emmcManufacturers = map[uint64]string{
+		0x11: "Toshiba",
+		0x13: "Micron",
+		0x15: "Samsung",
+		0x45: "SanDisk",
+		0x70: "Kingston",
+		0x88: "Foresee",
+		0x90: "SK Hynix",
+		0x9b: "YMTC",
+		0xd6: "Foresee",
+		0xfe: "Micron",
+	}
+)
+
+// MMCManufacturerName returns the name for a manufacturer id, or an empty string if the id is unknown.
+func MMCManufacturerName(cardType string, manufacturerID uint64) string {
+	if cardType == "MMC" {
+		return emmcManufacturers[manufacturerID]
+	}
+	return sdManufacturers[manufacturerID]
+}
+
+// GetMMCDevices returns every SD card and eMMC block device, SDIO devices such as wifi chips are skipped.
+func GetMMCDevices(ctx context.Context) ([]*MMCDevice, error) {
+	return getMMCDevices(ctx, "/sys/block")
+}
+
+func getMMCDevices(ctx context.Context, sysBlockPath string) ([]*MMCDevice, error) {
+	paths, err := utils.Glob(filepath.Join(sysBlockPath, "mmcblk*"))
+	if err != nil {
+		return nil, err
+	}
+	devices := make([]*MMCDevice, 0, len(paths))
+	for _, path := range paths {
+		name := filepath.Base(path)
+		// Boot partitions and RPMB of eMMC show up as mmcblk0boot0 and mmcblk0rpmb
+		if strings.Contains(name, "boot") || strings.Contains(name, "rpmb") {
+			continue
+		}
+		device, err := readMMCDevice(ctx, path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", name, err)
+		}
+		devices = append(devices, device)
+	}
+	return devices, nil
+}
+
+func readMMCDevice(ctx context.Context, path string) (*MMCDevice, error) {
+	deviceDir := filepath.Join(path, "device")
+	read := func(name string) string {
+		// Not every attribute exists for every card type, a missing one is reported as empty
+		value, _ := utils.ReadFileWithContext(ctx, filepath.Join(deviceDir, name))
+		return value
+	}
+	sectors, err := utils.ReadInt64FromFileWithContext(ctx, filepath.Join(path, "size"))
+	if err != nil {
+		return nil, err
+	}
+	device := &MMCDevice{
+		Name:             filepath.Base(path),
+		Type:             read("type"),
+		CID:              read("cid"),
+		OEMID:            decodeOEMID(read("oemid")),
+		ProductName:      read("name"),
+		Serial:           read("serial"),
+		ManufactureDate:  read("date"),
+		HardwareRevision: read("hwrev"),
+		FirmwareRevision: read("fwrev"),
+		SizeBytes:        uint64(sectors) * DiskSectorSize,
+	}
+	if manfid := read("manfid"); manfid != "" {
+		id, err := strconv.ParseUint(manfid, 0, 64)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse manfid: %w", err)
+		}
+		device.ManufacturerID = id
+		device.Manufacturer = MMCManufacturerName(device.Type, id)
+	}
+	if lifeTime := read("life_time"); lifeTime != "" {
+		health, err := parseMMCHealth(lifeTime, read("pre_eol_info"))
+		if err != nil {
+			return nil, err
+		}
+		device.Health = health
+	}
+	return device, nil
+}
+
+// parseMMCHealth parses the life_time ("0x01 0x02") and pre_eol_info ("0x01") attributes. The life time
+// estimates are encoded as 0x01 for 0-10% used up to 0x0a for 90-100% and 0x0b when exceeded.
+func parseMMCHealth(lifeTime, preEOLInfo string) (*MMCHealth, error) {
+	fields := strings.Fields(lifeTime)
+	if len(fields) != 2 {
+		return nil, fmt.Errorf("unexpected life_time %q", lifeTime)
+	}
+	estimates := make([]int, 2)
+	for i, field := range fields {
+		value, err := strconv.ParseUint(field, 0, 8)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse life_time: %w", err)
+		}
+		estimates[i] = int(value) * 10
+	}
+	health := &MMCHealth{LifeTimeEstimateA: estimates[0], LifeTimeEstimateB: estimates[1]}
+	if preEOLInfo != "" {
+		value, err := strconv.ParseUint(preEOLInfo, 0, 8)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse pre_eol_info: %w", err)
+		}
+		health.PreEOLInfo = int(value)
+	}
+	return health, nil
+}
+
+// decodeOEMID turns the SD OEM id, two ASCII characters such as 0x5344 ("SD"), into text.
+// eMMC uses a plain number, which is returned unchanged.
+func decodeOEMID(oemid string) string {
+	id, err := strconv.ParseUint(oemid, 0, 16)
+	if err != nil {
+		return oemid
+	}
+	high, low := byte(id>>8), byte(id)
+	if high < 0x20 || high > 0x7e || low < 0x20 || low > 0x7e {
+		return oemid
+	}
+	return string([]byte{high, low})
+}
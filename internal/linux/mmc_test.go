@@ -0,0 +1,59 @@
+package linux
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetMMCDevices(t *testing.T) {
+	devices, err := getMMCDevices(context.Background(), "testdata/sys_block")
+	require.NoError(t, err)
+	require.Len(t, devices, 2)
+
+	sd := devices[0]
+	assert.Equal(t, "mmcblk0", sd.Name)
+	assert.Equal(t, "SD", sd.Type)
+	assert.Equal(t, uint64(3), sd.ManufacturerID)
+	assert.Equal(t, "SanDisk", sd.Manufacturer)
+	assert.Equal(t, "SD", sd.OEMID)
+	assert.Equal(t, "SC64G", sd.ProductName)
+	assert.Equal(t, "03/2019", sd.ManufactureDate)
+	assert.Equal(t, uint64(124735488*512), sd.SizeBytes)
+	assert.Nil(t, sd.Health)
+
+	emmc := devices[1]
+	assert.Equal(t, "mmcblk1", emmc.Name)
+	assert.Equal(t, "Samsung", emmc.Manufacturer)
+	// eMMC OEM ids aren't ASCII
+	assert.Equal(t, "0x0100", emmc.OEMID)
+	assert.Empty(t, emmc.HardwareRevision)
+	require.NotNil(t, emmc.Health)
+	assert.Equal(t, 10, emmc.Health.LifeTimeEstimateA)
+	assert.Equal(t, 30, emmc.Health.LifeTimeEstimateB)
+	assert.Equal(t, 30, emmc.Health.LifeUsedPercent())
+	assert.Equal(t, "normal", emmc.Health.PreEOLState())
+}
+
+func TestParseMMCHealth(t *testing.T) {
+	health, err := parseMMCHealth("0x0b 0x0a", "0x03")
+	require.NoError(t, err)
+	assert.Equal(t, 110, health.LifeUsedPercent())
+	assert.Equal(t, "urgent", health.PreEOLState())
+
+	// Some controllers don't implement pre_eol_info
+	health, err = parseMMCHealth("0x02 0x01", "")
+	require.NoError(t, err)
+	assert.Equal(t, "undefined", health.PreEOLState())
+
+	_, err = parseMMCHealth("0x01", "0x01")
+	assert.Error(t, err)
+}
+
+func TestMMCManufacturerName(t *testing.T) {
+	assert.Equal(t, "Samsung", MMCManufacturerName("SD", 0x1b))
+	assert.Equal(t, "Samsung", MMCManufacturerName("MMC", 0x15))
+	assert.Empty(t, MMCManufacturerName("SD", 0xee))
+}
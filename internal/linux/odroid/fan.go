@@ -0,0 +1,59 @@
+package odroid
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/linux"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+// The XU4 vendor kernels drive the fan with the odroid_fan driver instead of pwm-fan, it has no hwmon device and
+// reports its attributes as "<name> : <value>".
+const odroidFanGlob = "/sys/devices/platform/odroid_fan*"
+
+// GetFans returns the fans on the board. The N2, M1 and mainline XU4 kernels use the pwm-fan driver, which is
+// reported as fan, the XU4 vendor kernels use the odroid_fan driver.
+func GetFans(ctx context.Context) ([]linux.Fan, error) {
+	return getFans(ctx, linux.HwmonRoot, odroidFanGlob)
+}
+
+func getFans(ctx context.Context, hwmonRoot, odroidFanPattern string) ([]linux.Fan, error) {
+	fans, err := linux.ReadHwmonFans(ctx, hwmonRoot)
+	if err != nil {
+		return nil, err
+	}
+	for i := range fans {
+		if fans[i].Name == "pwmfan" {
+			fans[i].Name = "fan"
+		}
+	}
+
+	matches, err := utils.Glob(odroidFanPattern)
+	if err != nil {
+		return nil, err
+	}
+	for _, path := range matches {
+		fan := linux.Fan{Name: "fan"}
+		if mode, err := utils.ReadFileWithContext(ctx, filepath.Join(path, "fan_mode")); err == nil {
+			fan.Mode = strings.ToLower(odroidFanValue(mode))
+		}
+		if duty, err := utils.ReadFileWithContext(ctx, filepath.Join(path, "pwm_duty")); err == nil {
+			if value, err := utils.ParseFloat64(odroidFanValue(duty)); err == nil {
+				// pwm_duty is 0 to 255 like the hwmon pwm attributes
+				value = utils.RoundValue(value/255*100, 1)
+				fan.Duty = &value
+			}
+		}
+		fans = append(fans, fan)
+	}
+	return fans, nil
+}
+
+func odroidFanValue(data string) string {
+	if _, value, found := strings.Cut(data, ":"); found {
+		return strings.TrimSpace(value)
+	}
+	return strings.TrimSpace(data)
+}
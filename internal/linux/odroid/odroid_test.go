@@ -0,0 +1,40 @@
+package odroid
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/linux"
+)
+
+func TestGetTemperatures(t *testing.T) {
+	temps, err := getTemperatures(context.Background(), "testdata/n2/thermal")
+	require.NoError(t, err)
+	require.NotNil(t, temps.CPU)
+	assert.Equal(t, 41.0, *temps.CPU)
+	assert.Nil(t, temps.GPU)
+	assert.Equal(t, map[string]float64{"DDR": 39.5}, temps.Extra)
+
+	temps, err = getTemperatures(context.Background(), "testdata/xu4/thermal")
+	require.NoError(t, err)
+	require.NotNil(t, temps.CPU)
+	assert.Equal(t, 58.0, *temps.CPU)
+	require.NotNil(t, temps.GPU)
+	assert.Equal(t, 52.0, *temps.GPU)
+	assert.Equal(t, map[string]float64{"CPU0": 55, "CPU1": 58, "CPU2": 57, "CPU3": 56}, temps.Extra)
+}
+
+func TestGetFans(t *testing.T) {
+	fans, err := getFans(context.Background(), "testdata/n2/hwmon", "testdata/n2/odroid_fan*")
+	require.NoError(t, err)
+	duty := 40.0
+	assert.Equal(t, []linux.Fan{{Name: "fan", Duty: &duty, Mode: "manual"}}, fans)
+
+	fans, err = getFans(context.Background(), "testdata/xu4/hwmon", "testdata/xu4/odroid_fan*")
+	require.NoError(t, err)
+	duty = 47.1
+	assert.Equal(t, []linux.Fan{{Name: "fan", Duty: &duty, Mode: "auto"}}, fans)
+}
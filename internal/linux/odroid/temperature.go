@@ -0,0 +1,58 @@
+package odroid
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/linux"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/sensors"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+// The XU4 (Exynos 5422) has a zone per big core, cpu0-thermal to cpu3-thermal, and reports the hottest as the CPU.
+var perCoreZoneRegex = regexp.MustCompile(`^cpu\d+$`)
+
+// odroidThermalZones maps the zone names of the N2/N2+ (Amlogic S922X), M1 (Rockchip RK3568) and XU4 to reading
+// names. The Hardkernel vendor kernels use soc_thermal and ddr_thermal where mainline uses cpu-thermal and
+// ddr-thermal, so the zone types are normalized before matching.
+var odroidThermalZones = map[string]string{
+	"cpu": "CPU",
+	"soc": "CPU",
+	"gpu": "GPU",
+	"ddr": "DDR",
+}
+
+func GetTemperatures(ctx context.Context) (*sensors.SystemTemperatures, error) {
+	return getTemperatures(ctx, linux.ThermalZonesRoot)
+}
+
+func getTemperatures(ctx context.Context, root string) (*sensors.SystemTemperatures, error) {
+	zones, err := linux.ReadThermalZones(ctx, root)
+	if err != nil {
+		return nil, err
+	}
+	systemTemps := &sensors.SystemTemperatures{Extra: make(map[string]float64)}
+	for zoneType, temp := range zones {
+		base := strings.TrimSuffix(strings.ReplaceAll(zoneType, "_", "-"), "-thermal")
+		temp = utils.RoundValue(temp, 2)
+		if perCoreZoneRegex.MatchString(base) {
+			if systemTemps.CPU == nil || temp > *systemTemps.CPU {
+				systemTemps.CPU = &temp
+			}
+			systemTemps.Extra[strings.ToUpper(base)] = temp
+			continue
+		}
+		switch odroidThermalZones[base] {
+		case "CPU":
+			systemTemps.CPU = &temp
+		case "GPU":
+			systemTemps.GPU = &temp
+		case "":
+			systemTemps.Extra[strings.ToUpper(base)] = temp
+		default:
+			systemTemps.Extra[odroidThermalZones[base]] = temp
+		}
+	}
+	return systemTemps, nil
+}
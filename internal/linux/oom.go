@@ -0,0 +1,55 @@
+package linux
+
+import (
+	"context"
+	"path/filepath"
+	"regexp"
+	"strconv"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+// Matches both the global and the memory cgroup OOM killer messages, e.g.
+// "Out of memory: Killed process 1234 (chrome) total-vm:..." and
+// "Memory cgroup out of memory: Killed process 1234 (chrome) total-vm:..."
+var oomKillRegex = regexp.MustCompile(`Killed process (\d+) \(([^)]*)\)`)
+
+type OOMKill struct {
+	PID  int
+	Name string
+}
+
+// ParseOOMKill returns the victim of an OOM kill if the kernel message describes one.
+func ParseOOMKill(message string) (*OOMKill, bool) {
+	matches := oomKillRegex.FindStringSubmatch(message)
+	if len(matches) != 3 {
+		return nil, false
+	}
+	pid, err := strconv.Atoi(matches[1])
+	if err != nil {
+		return nil, false
+	}
+	return &OOMKill{PID: pid, Name: matches[2]}, true
+}
+
+// ReadOOMKillCount returns the number of OOM kills since boot from /proc/vmstat, requires kernel 4.13 or newer.
+func ReadOOMKillCount(ctx context.Context) (uint64, error) {
+	vmstat, err := ReadVmstat(ctx)
+	if err != nil {
+		return 0, err
+	}
+	count, ok := vmstat["oom_kill"]
+	if !ok {
+		return 0, utils.ErrPlatformNotSupported
+	}
+	return count, nil
+}
+
+// ReadCgroupMemoryEvents returns the counters from memory.events for a cgroup v2 path, either absolute or relative to /sys/fs/cgroup.
+func ReadCgroupMemoryEvents(ctx context.Context, cgroup string) (map[string]uint64, error) {
+	data, err := utils.ReadFileWithContext(ctx, filepath.Join(CgroupPath(cgroup), "memory.events"))
+	if err != nil {
+		return nil, err
+	}
+	return parseFlatKeyedFile(data)
+}
@@ -0,0 +1,61 @@
+package linux
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseOOMKill(t *testing.T) {
+	tests := []struct {
+		name     string
+		message  string
+		expected *OOMKill
+	}{
+		{"Global", "Out of memory: Killed process 1234 (viam-server) total-vm:2123456kB, anon-rss:812345kB, file-rss:0kB, shmem-rss:0kB, UID:0 pgtables:1234kB oom_score_adj:0", &OOMKill{PID: 1234, Name: "viam-server"}},
+		{"Cgroup", "Memory cgroup out of memory: Killed process 42 (python3) total-vm:123kB, anon-rss:100kB", &OOMKill{PID: 42, Name: "python3"}},
+		{"Legacy", "Killed process 99 (my app) total-vm:1kB, anon-rss:1kB, file-rss:0kB", &OOMKill{PID: 99, Name: "my app"}},
+		{"Unrelated", "usb 1-1.2: USB disconnect, device number 5", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kill, ok := ParseOOMKill(tt.message)
+			if tt.expected == nil {
+				assert.False(t, ok)
+				return
+			}
+			require.True(t, ok)
+			assert.Equal(t, tt.expected, kill)
+		})
+	}
+}
+
+func TestParseKmsgRecord(t *testing.T) {
+	record, err := parseKmsgRecord("3,1234,5678901,-;Out of memory: Killed process 1 (init)\n SUBSYSTEM=mem\n")
+	require.NoError(t, err)
+	assert.Equal(t, 3, record.Priority)
+	assert.Equal(t, uint64(1234), record.Sequence)
+	assert.Equal(t, 5678901*time.Microsecond, record.SinceBoot)
+	assert.Equal(t, "Out of memory: Killed process 1 (init)", record.Message)
+
+	// facility 1 (user), priority 6 (info)
+	record, err = parseKmsgRecord("14,1,2,-;hello")
+	require.NoError(t, err)
+	assert.Equal(t, 6, record.Priority)
+
+	_, err = parseKmsgRecord("garbage")
+	assert.Error(t, err)
+}
+
+func TestParseMemoryEvents(t *testing.T) {
+	b, err := os.ReadFile("testdata/memory.events")
+	require.NoError(t, err)
+	events, err := parseFlatKeyedFile(string(b))
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), events["oom_kill"])
+	assert.Equal(t, uint64(12), events["high"])
+	assert.Len(t, events, 6)
+}
@@ -0,0 +1,125 @@
+package linux
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+// PCIeDevice is a PCI Express function and the state of its link.
+type PCIeDevice struct {
+	Address  string
+	VendorID string
+	DeviceID string
+	Class    string
+	Driver   string
+	// Link speeds are in GT/s, 2.5 for gen 1, 5 for gen 2, 8 for gen 3
+	LinkSpeed    float64
+	LinkWidth    int64
+	MaxLinkSpeed float64
+	MaxLinkWidth int64
+	// AER is nil when the kernel doesn't report Advanced Error Reporting counters for the device
+	AER *PCIeAERCounters
+}
+
+// PCIeAERCounters are the Advanced Error Reporting totals since boot. Correctable errors are retried by the link and
+// only cost bandwidth, a steady rise points to signal integrity problems. Non-fatal errors lose a transaction and
+// fatal errors take the link down.
+type PCIeAERCounters struct {
+	Correctable int64
+	NonFatal    int64
+	Fatal       int64
+}
+
+// Downgraded returns true when the link trained below what both ends support, usually a bad riser or cable.
+func (d *PCIeDevice) Downgraded() bool {
+	return d.LinkSpeed < d.MaxLinkSpeed || d.LinkWidth < d.MaxLinkWidth
+}
+
+// GetPCIeDevices returns the PCI devices that have a PCI Express link.
+func GetPCIeDevices(ctx context.Context) ([]*PCIeDevice, error) {
+	return getPCIeDevices(ctx, "/sys/bus/pci/devices")
+}
+
+func getPCIeDevices(ctx context.Context, root string) ([]*PCIeDevice, error) {
+	entries, err := utils.ReadDir(root)
+	if err != nil {
+		// Boards without a PCIe controller don't have the pci bus at all
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	devices := make([]*PCIeDevice, 0, len(entries))
+	for _, entry := range entries {
+		path := filepath.Join(root, entry.Name())
+		readString := func(name string) string {
+			value, _ := utils.ReadFileWithContext(ctx, filepath.Join(path, name))
+			return value
+		}
+		speed := readString("current_link_speed")
+		if speed == "" {
+			// Conventional PCI devices and some virtual functions have no link
+			continue
+		}
+		device := &PCIeDevice{
+			Address:      entry.Name(),
+			VendorID:     readString("vendor"),
+			DeviceID:     readString("device"),
+			Class:        readString("class"),
+			LinkSpeed:    parseLinkSpeed(speed),
+			MaxLinkSpeed: parseLinkSpeed(readString("max_link_speed")),
+		}
+		device.LinkWidth, _ = strconv.ParseInt(readString("current_link_width"), 10, 64)
+		device.MaxLinkWidth, _ = strconv.ParseInt(readString("max_link_width"), 10, 64)
+		if driver, err := utils.Readlink(filepath.Join(path, "driver")); err == nil {
+			device.Driver = filepath.Base(driver)
+		}
+		device.AER = readAERCounters(ctx, path)
+		devices = append(devices, device)
+	}
+	return devices, nil
+}
+
+// parseLinkSpeed parses speeds like "5.0 GT/s PCIe" or "5 GT/s" from older kernels, an unknown speed is 0.
+func parseLinkSpeed(value string) float64 {
+	fields := strings.Fields(value)
+	if len(fields) == 0 {
+		return 0
+	}
+	speed, err := strconv.ParseFloat(fields[0], 64)
+	if err != nil {
+		return 0
+	}
+	return speed
+}
+
+// readAERCounters reads the aer_dev_* attributes, each lists the counts per error type followed by a total, e.g.
+// TOTAL_ERR_COR 3.
+func readAERCounters(ctx context.Context, path string) *PCIeAERCounters {
+	readTotal := func(name, key string) (int64, bool) {
+		data, err := utils.ReadFileWithContext(ctx, filepath.Join(path, name))
+		if err != nil {
+			return 0, false
+		}
+		for _, line := range strings.Split(data, "\n") {
+			if value, ok := strings.CutPrefix(line, key+" "); ok {
+				total, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+				return total, err == nil
+			}
+		}
+		return 0, false
+	}
+	correctable, ok := readTotal("aer_dev_correctable", "TOTAL_ERR_COR")
+	if !ok {
+		return nil
+	}
+	aer := &PCIeAERCounters{Correctable: correctable}
+	aer.NonFatal, _ = readTotal("aer_dev_nonfatal", "TOTAL_ERR_NONFATAL")
+	aer.Fatal, _ = readTotal("aer_dev_fatal", "TOTAL_ERR_FATAL")
+	return aer
+}
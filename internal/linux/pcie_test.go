@@ -0,0 +1,42 @@
+package linux
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetPCIeDevices(t *testing.T) {
+	// The testdata uses _ instead of : in the addresses so the repository can be checked out on Windows
+	devices, err := getPCIeDevices(context.Background(), "testdata/sys_bus_pci/devices")
+	require.NoError(t, err)
+	require.Len(t, devices, 3)
+
+	bridge := devices[0]
+	assert.Equal(t, "0x060400", bridge.Class)
+	assert.Equal(t, "pcieport", bridge.Driver)
+	assert.False(t, bridge.Downgraded())
+
+	nvme := devices[1]
+	assert.Equal(t, "0000_01_00.0", nvme.Address)
+	assert.Equal(t, "0x144d", nvme.VendorID)
+	assert.Equal(t, "nvme", nvme.Driver)
+	assert.Equal(t, 5.0, nvme.LinkSpeed)
+	assert.Equal(t, int64(1), nvme.LinkWidth)
+	assert.Equal(t, 16.0, nvme.MaxLinkSpeed)
+	assert.Equal(t, int64(4), nvme.MaxLinkWidth)
+	assert.True(t, nvme.Downgraded())
+	assert.Equal(t, &PCIeAERCounters{Correctable: 3, NonFatal: 1, Fatal: 0}, nvme.AER)
+
+	rp1 := devices[2]
+	assert.Equal(t, "rp1", rp1.Driver)
+	assert.Equal(t, 5.0, rp1.LinkSpeed)
+	assert.False(t, rp1.Downgraded())
+	assert.Nil(t, rp1.AER)
+
+	devices, err = getPCIeDevices(context.Background(), "testdata/missing")
+	require.NoError(t, err)
+	assert.Empty(t, devices)
+}
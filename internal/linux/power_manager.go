@@ -1,6 +1,7 @@
 package linux
 
 import (
+	"context"
 	"errors"
 
 	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
@@ -20,10 +21,10 @@ func NewPowerManager(config *LinuxConfig, logger logging.Logger) (*linuxPowerMan
 	return nil, utils.ErrPlatformNotSupported
 }
 
-func (pm *linuxPowerManager) ApplyPowerMode() (rebootRequired bool, err error) {
+func (pm *linuxPowerManager) ApplyPowerMode(ctx context.Context) (rebootRequired bool, err error) {
 	return false, utils.ErrPlatformNotSupported
 }
 
-func (pm *linuxPowerManager) GetCurrentPowerMode() (interface{}, error) {
+func (pm *linuxPowerManager) GetCurrentPowerMode(ctx context.Context) (interface{}, error) {
 	return nil, utils.ErrPlatformNotSupported
 }
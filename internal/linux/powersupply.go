@@ -0,0 +1,71 @@
+package linux
+
+import (
+	"context"
+	"path/filepath"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+const PowerSupplyRoot = "/sys/class/power_supply"
+
+// PowerSupply is a device under /sys/class/power_supply, e.g. a PMIC's battery charger or USB input. Attributes the
+// driver doesn't provide are nil.
+type PowerSupply struct {
+	Name   string
+	Type   string
+	Status string
+	Health string
+	Online *bool
+	// Capacity is the battery charge in percent
+	Capacity *int64
+	// Voltage is in volts
+	Voltage *float64
+	// Current is in amps
+	Current *float64
+}
+
+// ReadPowerSupplies reads every power supply under root, normally PowerSupplyRoot.
+func ReadPowerSupplies(ctx context.Context, root string) ([]PowerSupply, error) {
+	entries, err := utils.ReadDir(root)
+	if err != nil {
+		return nil, err
+	}
+	supplies := make([]PowerSupply, 0, len(entries))
+	for _, entry := range entries {
+		supply, err := ReadPowerSupply(ctx, root, entry.Name())
+		if err != nil {
+			return nil, err
+		}
+		supplies = append(supplies, *supply)
+	}
+	return supplies, nil
+}
+
+// ReadPowerSupply reads the power supply with the given name under root, normally PowerSupplyRoot.
+func ReadPowerSupply(ctx context.Context, root, name string) (*PowerSupply, error) {
+	path := filepath.Join(root, name)
+	supplyType, err := utils.ReadFileWithContext(ctx, filepath.Join(path, "type"))
+	if err != nil {
+		return nil, err
+	}
+	supply := &PowerSupply{Name: name, Type: supplyType}
+	supply.Status, _ = utils.ReadFileWithContext(ctx, filepath.Join(path, "status"))
+	supply.Health, _ = utils.ReadFileWithContext(ctx, filepath.Join(path, "health"))
+	if online, err := utils.ReadBoolFromFileWithContext(ctx, filepath.Join(path, "online")); err == nil {
+		supply.Online = &online
+	}
+	if capacity, err := utils.ReadInt64FromFileWithContext(ctx, filepath.Join(path, "capacity")); err == nil {
+		supply.Capacity = &capacity
+	}
+	// Voltages and currents are reported in micro units
+	if voltage, err := utils.ReadInt64FromFileWithContext(ctx, filepath.Join(path, "voltage_now")); err == nil {
+		value := float64(voltage) / 1e6
+		supply.Voltage = &value
+	}
+	if current, err := utils.ReadInt64FromFileWithContext(ctx, filepath.Join(path, "current_now")); err == nil {
+		value := float64(current) / 1e6
+		supply.Current = &value
+	}
+	return supply, nil
+}
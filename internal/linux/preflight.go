@@ -0,0 +1,73 @@
+package linux
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+// The checks here are for config validation, they say how to fix what's missing rather than failing on the first
+// poll with a generic error.
+
+// CheckI2CBus checks that /dev/i2c-<bus> under devRoot, normally /dev, exists and can be opened.
+func CheckI2CBus(devRoot string, bus int) error {
+	path := filepath.Join(devRoot, fmt.Sprintf("i2c-%d", bus))
+	f, err := os.OpenFile(path, os.O_RDWR, 0)
+	if err == nil {
+		return f.Close()
+	}
+	if errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("%s does not exist, enable the I2C bus (e.g. dtparam=i2c_arm=on in config.txt on a Raspberry Pi) and load the i2c-dev module with modprobe i2c-dev", path)
+	}
+	if errors.Is(err, os.ErrPermission) {
+		return fmt.Errorf("permission denied opening %s, run viam-server as root or add its user to the i2c group", path)
+	}
+	return err
+}
+
+// CheckNetworkAdapter checks that the network adapter exists under netRoot, normally NetClassRoot. The error lists the
+// wireless adapters that do.
+func CheckNetworkAdapter(netRoot, name string) error {
+	if _, err := utils.Stat(filepath.Join(netRoot, name)); err == nil {
+		return nil
+	}
+	adapters, _ := ListNetworkAdapters(netRoot)
+	wireless := make([]string, 0, len(adapters))
+	for _, adapter := range adapters {
+		if adapter.Wireless {
+			wireless = append(wireless, adapter.Name)
+		}
+	}
+	if len(wireless) == 0 {
+		return fmt.Errorf("adapter %s not found and there are no wireless adapters, check the driver is loaded with ip link", name)
+	}
+	return fmt.Errorf("adapter %s not found, the wireless adapters are %s", name, strings.Join(wireless, ", "))
+}
+
+// CheckW1Bus checks that the 1-Wire bus is enabled, root is normally W1DevicesRoot.
+func CheckW1Bus(root string) error {
+	if _, err := utils.Stat(root); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return errors.New("the 1-Wire bus is not enabled, add dtoverlay=w1-gpio to config.txt on a Raspberry Pi or load the w1-gpio and w1-therm modules")
+		}
+		return err
+	}
+	return nil
+}
+
+// CheckBlockDevice checks that the disk or partition exists under classRoot, normally BlockClassRoot. The error lists
+// the disks under diskRoot, normally BlockDevicesRoot.
+func CheckBlockDevice(classRoot, diskRoot, name string) error {
+	if _, err := utils.Stat(filepath.Join(classRoot, name)); err == nil {
+		return nil
+	}
+	disks, _ := ListBlockDevices(diskRoot)
+	if len(disks) == 0 {
+		return fmt.Errorf("device %s not found", name)
+	}
+	return fmt.Errorf("device %s not found, the disks are %s", name, strings.Join(disks, ", "))
+}
@@ -0,0 +1,45 @@
+package linux
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckI2CBus(t *testing.T) {
+	root := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(root, "i2c-1"), nil, 0o600))
+	assert.NoError(t, CheckI2CBus(root, 1))
+	assert.ErrorContains(t, CheckI2CBus(root, 0), "modprobe i2c-dev")
+}
+
+func TestCheckNetworkAdapter(t *testing.T) {
+	root := t.TempDir()
+	assert.ErrorContains(t, CheckNetworkAdapter(root, "wlan0"), "no wireless adapters")
+
+	for _, path := range []string{"eth0/device", "wlx00c0ca/device", "wlx00c0ca/wireless"} {
+		require.NoError(t, os.MkdirAll(filepath.Join(root, path), 0o755))
+	}
+	assert.NoError(t, CheckNetworkAdapter(root, "wlx00c0ca"))
+	assert.EqualError(t, CheckNetworkAdapter(root, "wlan0"), "adapter wlan0 not found, the wireless adapters are wlx00c0ca")
+}
+
+func TestCheckW1Bus(t *testing.T) {
+	root := t.TempDir()
+	assert.NoError(t, CheckW1Bus(root))
+	assert.ErrorContains(t, CheckW1Bus(filepath.Join(root, "missing")), "dtoverlay=w1-gpio")
+}
+
+func TestCheckBlockDevice(t *testing.T) {
+	classRoot := t.TempDir()
+	diskRoot := t.TempDir()
+	for _, path := range []string{"mmcblk0", "mmcblk0p1"} {
+		require.NoError(t, os.MkdirAll(filepath.Join(classRoot, path), 0o755))
+	}
+	require.NoError(t, os.MkdirAll(filepath.Join(diskRoot, "mmcblk0", "device"), 0o755))
+	assert.NoError(t, CheckBlockDevice(classRoot, diskRoot, "mmcblk0p1"))
+	assert.EqualError(t, CheckBlockDevice(classRoot, diskRoot, "sda"), "device sda not found, the disks are mmcblk0")
+}
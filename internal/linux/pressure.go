@@ -0,0 +1,89 @@
+package linux
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+const procPressurePath = "/proc/pressure"
+
+var (
+	ErrPressureNotSupported = utils.NewError(utils.ErrBackendUnavailable, "pressure stall information is not available, kernel must be built with CONFIG_PSI=y")
+)
+
+// PressureStats holds a single "some" or "full" line from /proc/pressure/<resource>.
+// Averages are percentages, Total is the cumulative stall time in microseconds.
+type PressureStats struct {
+	Avg10  float64
+	Avg60  float64
+	Avg300 float64
+	Total  uint64
+}
+
+// Pressure holds the pressure stall information for a single resource.
+// Full is nil for resources that don't report it (cpu on older kernels).
+type Pressure struct {
+	Some *PressureStats
+	Full *PressureStats
+}
+
+func ReadPressure(ctx context.Context, resource string) (*Pressure, error) {
+	data, err := utils.ReadFileWithContext(ctx, filepath.Join(procPressurePath, resource))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, ErrPressureNotSupported
+		}
+		return nil, err
+	}
+	return parsePressure(data)
+}
+
+func parsePressure(data string) (*Pressure, error) {
+	pressure := &Pressure{}
+	for _, line := range strings.Split(strings.TrimSpace(data), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		stats := &PressureStats{}
+		for _, field := range fields[1:] {
+			key, value, found := strings.Cut(field, "=")
+			if !found {
+				return nil, fmt.Errorf("unexpected pressure field %q", field)
+			}
+			var err error
+			switch key {
+			case "avg10":
+				stats.Avg10, err = strconv.ParseFloat(value, 64)
+			case "avg60":
+				stats.Avg60, err = strconv.ParseFloat(value, 64)
+			case "avg300":
+				stats.Avg300, err = strconv.ParseFloat(value, 64)
+			case "total":
+				stats.Total, err = strconv.ParseUint(value, 10, 64)
+			}
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse pressure field %q: %w", field, err)
+			}
+		}
+		switch fields[0] {
+		case "some":
+			pressure.Some = stats
+		case "full":
+			pressure.Full = stats
+		default:
+			return nil, fmt.Errorf("unexpected pressure line %q", line)
+		}
+	}
+	if pressure.Some == nil {
+		return nil, errors.New("pressure data did not contain a some line")
+	}
+	return pressure, nil
+}
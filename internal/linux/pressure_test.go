@@ -0,0 +1,41 @@
+package linux
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePressure(t *testing.T) {
+	b, err := os.ReadFile("testdata/pressure_memory.txt")
+	require.NoError(t, err)
+	pressure, err := parsePressure(string(b))
+	require.NoError(t, err)
+	require.NotNil(t, pressure.Some)
+	require.NotNil(t, pressure.Full)
+	assert.Equal(t, 1.53, pressure.Some.Avg10)
+	assert.Equal(t, 0.87, pressure.Some.Avg60)
+	assert.Equal(t, 0.21, pressure.Some.Avg300)
+	assert.Equal(t, uint64(4815162), pressure.Some.Total)
+	assert.Equal(t, 0.42, pressure.Full.Avg10)
+	assert.Equal(t, uint64(1234567), pressure.Full.Total)
+}
+
+func TestParsePressureWithoutFull(t *testing.T) {
+	b, err := os.ReadFile("testdata/pressure_cpu.txt")
+	require.NoError(t, err)
+	pressure, err := parsePressure(string(b))
+	require.NoError(t, err)
+	require.NotNil(t, pressure.Some)
+	assert.Nil(t, pressure.Full)
+	assert.Equal(t, 12.04, pressure.Some.Avg10)
+}
+
+func TestParsePressureInvalid(t *testing.T) {
+	_, err := parsePressure("some avg10=abc")
+	assert.Error(t, err)
+	_, err = parsePressure("")
+	assert.Error(t, err)
+}
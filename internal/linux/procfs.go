@@ -0,0 +1,39 @@
+package linux
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+// ReadVmstat returns the counters from /proc/vmstat.
+func ReadVmstat(ctx context.Context) (map[string]uint64, error) {
+	data, err := utils.ReadFileWithContext(ctx, "/proc/vmstat")
+	if err != nil {
+		return nil, err
+	}
+	return parseFlatKeyedFile(data)
+}
+
+// parseFlatKeyedFile parses files made up of "<key> <value>" lines such as /proc/vmstat and cgroup memory.events.
+func parseFlatKeyedFile(data string) (map[string]uint64, error) {
+	ret := make(map[string]uint64)
+	for _, line := range strings.Split(data, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("unexpected line %q", line)
+		}
+		value, err := strconv.ParseUint(fields[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse value for %s: %w", fields[0], err)
+		}
+		ret[fields[0]] = value
+	}
+	return ret, nil
+}
@@ -0,0 +1,62 @@
+package linux
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+const PWMRoot = "/sys/class/pwm"
+
+// PWMChannel is the configuration of a PWM output. A channel only has a configuration once it has been exported,
+// either from userspace or by a kernel driver such as pwm-fan.
+type PWMChannel struct {
+	Chip     string
+	Channel  int
+	Exported bool
+	Enabled  bool
+	// Period and DutyCycle are in nanoseconds
+	Period    int64
+	DutyCycle int64
+	// Polarity is normal or inversed
+	Polarity string
+}
+
+// DutyPercent returns the duty cycle as a percentage of the period.
+func (c *PWMChannel) DutyPercent() float64 {
+	if c.Period == 0 {
+		return 0
+	}
+	return utils.RoundValue(float64(c.DutyCycle)/float64(c.Period)*100, 2)
+}
+
+// ReadPWMChannel reads a channel of a chip under root, normally PWMRoot. A channel that hasn't been exported is not
+// an error, it is returned with Exported false.
+func ReadPWMChannel(ctx context.Context, root, chip string, channel int) (*PWMChannel, error) {
+	chipPath := filepath.Join(root, chip)
+	if _, err := utils.Stat(chipPath); err != nil {
+		return nil, err
+	}
+	pwm := &PWMChannel{Chip: chip, Channel: channel}
+	path := filepath.Join(chipPath, fmt.Sprintf("pwm%d", channel))
+	if _, err := utils.Stat(path); os.IsNotExist(err) {
+		return pwm, nil
+	}
+	pwm.Exported = true
+	var err error
+	if pwm.Enabled, err = utils.ReadBoolFromFileWithContext(ctx, filepath.Join(path, "enable")); err != nil {
+		return nil, err
+	}
+	if pwm.Period, err = utils.ReadInt64FromFileWithContext(ctx, filepath.Join(path, "period")); err != nil {
+		return nil, err
+	}
+	if pwm.DutyCycle, err = utils.ReadInt64FromFileWithContext(ctx, filepath.Join(path, "duty_cycle")); err != nil {
+		return nil, err
+	}
+	// Controllers that can't invert the output don't have a polarity attribute
+	pwm.Polarity, _ = utils.ReadFileWithContext(ctx, filepath.Join(path, "polarity"))
+	return pwm, nil
+}
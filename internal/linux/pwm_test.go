@@ -0,0 +1,32 @@
+package linux
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadPWMChannel(t *testing.T) {
+	root := t.TempDir()
+	channel := filepath.Join(root, "pwmchip0", "pwm1")
+	require.NoError(t, os.MkdirAll(channel, 0o755))
+	for attr, value := range map[string]string{"enable": "1", "period": "40000", "duty_cycle": "10000", "polarity": "normal"} {
+		require.NoError(t, os.WriteFile(filepath.Join(channel, attr), []byte(value+"\n"), 0o644))
+	}
+
+	pwm, err := ReadPWMChannel(context.Background(), root, "pwmchip0", 1)
+	require.NoError(t, err)
+	assert.Equal(t, &PWMChannel{Chip: "pwmchip0", Channel: 1, Exported: true, Enabled: true, Period: 40000, DutyCycle: 10000, Polarity: "normal"}, pwm)
+	assert.Equal(t, 25.0, pwm.DutyPercent())
+
+	pwm, err = ReadPWMChannel(context.Background(), root, "pwmchip0", 0)
+	require.NoError(t, err)
+	assert.False(t, pwm.Exported)
+
+	_, err = ReadPWMChannel(context.Background(), root, "pwmchip2", 0)
+	assert.Error(t, err)
+}
@@ -3,7 +3,7 @@ package raspberrypi
 import (
 	"context"
 	"errors"
-	"os/exec"
+	"fmt"
 	"path/filepath"
 	"strconv"
 	"strings"
@@ -48,22 +48,28 @@ type raspberryPiClockSensor struct {
 	name       string
 	sensorType string
 	path       string
-	cancelCtx  context.Context
-	cancelFunc context.CancelFunc
+	// batch measures every clock of the board at once, so the first clock read in a poll cycle reads them all
+	batch [][]string
 }
 
-func (s *raspberryPiClockSensor) readVcgencmdClock() (int64, error) {
-	cmd := exec.CommandContext(s.cancelCtx, "vcgencmd", "measure_clock", s.name)
-	output, err := cmd.Output()
+func (s *raspberryPiClockSensor) readVcgencmdClock(ctx context.Context) (int64, error) {
+	results, err := vcgencmdBatch(ctx, s.batch)
 	if err != nil {
-		s.logger.Errorw("failed to measure clock", "sensor", s.name, "error", err)
 		return 0, err
 	}
-	outputStr := string(output)
+	result := results["measure_clock "+s.name]
+	if result.err != nil {
+		if frequency, fallbackErr := readFallbackClock(ctx, s.name); fallbackErr == nil {
+			return frequency, nil
+		}
+		s.logger.Errorw("failed to measure clock", "sensor", s.name, "error", result.err)
+		return 0, result.err
+	}
+	outputStr := result.output
 	parts := strings.Split(outputStr, "=")
 	if len(parts) != 2 {
 		s.logger.Errorw("unexpected output format", "sensor", s.name, "output", outputStr)
-		return 0, err
+		return 0, fmt.Errorf("unexpected output from vcgencmd %s", outputStr)
 	}
 	frequency, err := strconv.ParseInt(strings.TrimSpace(parts[1]), 10, 64)
 	if err != nil {
@@ -74,8 +80,8 @@ func (s *raspberryPiClockSensor) readVcgencmdClock() (int64, error) {
 	return frequency, nil
 }
 
-func (s *raspberryPiClockSensor) readSysfsClock() (int64, error) {
-	current, err := sensors.GetSysFsClock(s.cancelCtx, s.path)
+func (s *raspberryPiClockSensor) readSysfsClock(ctx context.Context) (int64, error) {
+	current, err := sensors.GetSysFsClock(ctx, s.path)
 	if err != nil {
 		s.logger.Errorw("failed to read sysfs clock", "sensor", s.name, "error", err)
 		return 0, err
@@ -85,20 +91,19 @@ func (s *raspberryPiClockSensor) readSysfsClock() (int64, error) {
 }
 
 func (s *raspberryPiClockSensor) Close() error {
-	s.cancelFunc()
 	return nil
 }
 
-func (s *raspberryPiClockSensor) GetReadingMap() (map[string]interface{}, error) {
+func (s *raspberryPiClockSensor) GetReadingMap(ctx context.Context) (map[string]interface{}, error) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 	var frequency int64
 	var err error
 	switch s.sensorType {
 	case "vcgencmd":
-		frequency, err = s.readVcgencmdClock()
+		frequency, err = s.readVcgencmdClock(ctx)
 	case "sysfs":
-		frequency, err = s.readSysfsClock()
+		frequency, err = s.readSysfsClock(ctx)
 	default:
 		return nil, errors.New("unknown sensor type")
 	}
@@ -112,42 +117,40 @@ func (s *raspberryPiClockSensor) Name() string {
 }
 
 func getRaspberryPi4ClockSensors(ctx context.Context, logger logging.Logger) []sensors.ClockSensor {
-	sensors := make([]sensors.ClockSensor, 0)
-	for _, name := range raspi4Clocks {
-		sensor := newRaspberryPiVcgencmdSensor(ctx, logger, name)
-		sensors = append(sensors, sensor)
-	}
-	return sensors
+	return getVcgencmdClockSensors(ctx, logger, raspi4Clocks)
 }
 
 func getRaspberryPi5ClockSensors(ctx context.Context, logger logging.Logger) []sensors.ClockSensor {
+	return getVcgencmdClockSensors(ctx, logger, raspi5Clocks)
+}
+
+func getVcgencmdClockSensors(ctx context.Context, logger logging.Logger, names []string) []sensors.ClockSensor {
+	batch := make([][]string, 0, len(names))
+	for _, name := range names {
+		batch = append(batch, []string{"measure_clock", name})
+	}
 	sensors := make([]sensors.ClockSensor, 0)
-	for _, name := range raspi5Clocks {
-		sensor := newRaspberryPiVcgencmdSensor(ctx, logger, name)
+	for _, name := range names {
+		sensor := newRaspberryPiVcgencmdSensor(ctx, logger, name, batch)
 		sensors = append(sensors, sensor)
 	}
 	return sensors
 }
 
-func newRaspberryPiVcgencmdSensor(ctx context.Context, logger logging.Logger, name string) *raspberryPiClockSensor {
-	cancelCtx, cancelFunc := context.WithCancel(ctx)
+func newRaspberryPiVcgencmdSensor(ctx context.Context, logger logging.Logger, name string, batch [][]string) *raspberryPiClockSensor {
 	return &raspberryPiClockSensor{
 		logger:     logger,
 		name:       name,
-		cancelCtx:  cancelCtx,
-		cancelFunc: cancelFunc,
 		sensorType: "vcgencmd",
+		batch:      batch,
 	}
 }
 
 func newRaspberryPiSysFsSensor(ctx context.Context, logger logging.Logger, path string) *raspberryPiClockSensor {
-	cancelCtx, cancelFunc := context.WithCancel(ctx)
 	parts := strings.Split(path, "/")
 	sensorName := parts[len(parts)-1]
 	return &raspberryPiClockSensor{
 		logger:     logger,
-		cancelCtx:  cancelCtx,
-		cancelFunc: cancelFunc,
 		name:       sensorName,
 		sensorType: "sysfs",
 		path:       filepath.Join(path, "cpufreq/cpuinfo_cur_freq"),
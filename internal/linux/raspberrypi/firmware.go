@@ -0,0 +1,148 @@
+package raspberrypi
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+// FirmwareVersion is the VideoCore firmware or bootloader EEPROM version reported by vcgencmd.
+type FirmwareVersion struct {
+	Version string
+	Date    time.Time
+}
+
+// BootloaderStatus is the result of rpi-eeprom-update.
+type BootloaderStatus struct {
+	UpdateAvailable bool
+	Current         time.Time
+	Latest          time.Time
+}
+
+// GetFirmwareVersion returns the version of the VideoCore firmware (start*.elf) the board booted.
+func GetFirmwareVersion(ctx context.Context) (*FirmwareVersion, error) {
+	output, err := vcgencmd(ctx, "version")
+	if err != nil {
+		return nil, err
+	}
+	return parseFirmwareVersion(output)
+}
+
+// GetBootloaderVersion returns the version of the bootloader EEPROM, only boards with an EEPROM (Pi 4, Pi 400, CM4 and Pi 5) support this.
+func GetBootloaderVersion(ctx context.Context) (*FirmwareVersion, error) {
+	output, err := vcgencmd(ctx, "bootloader_version")
+	if err != nil {
+		return nil, err
+	}
+	return parseBootloaderVersion(output)
+}
+
+// GetBootloaderStatus compares the bootloader EEPROM against the newest image installed by the rpi-eeprom package.
+func GetBootloaderStatus(ctx context.Context) (*BootloaderStatus, error) {
+	// rpi-eeprom-update exits with a non zero status when an update is available, the output is still valid
+	output, err := utils.Output(ctx, "rpi-eeprom-update")
+	if err != nil && len(output) == 0 {
+		return nil, err
+	}
+	return parseBootloaderStatus(string(output))
+}
+
+// parseFirmwareVersion parses output like:
+//
+//	Mar 17 2023 10:52:00
+//	Copyright (c) 2012 Broadcom
+//	version 82f3750a65fadae9a38077e3c2e217ad158c8d54 (clean) (release) (start)
+func parseFirmwareVersion(output string) (*FirmwareVersion, error) {
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+	ret := &FirmwareVersion{}
+	if date, err := time.Parse("Jan _2 2006 15:04:05", strings.TrimSpace(lines[0])); err == nil {
+		ret.Date = date
+	}
+	for _, line := range lines {
+		if version, found := strings.CutPrefix(strings.TrimSpace(line), "version "); found {
+			ret.Version = strings.Fields(version)[0]
+		}
+	}
+	if ret.Version == "" {
+		return nil, errors.New("unexpected output from vcgencmd version")
+	}
+	return ret, nil
+}
+
+// parseBootloaderVersion parses output like:
+//
+//	2023/01/11 17:40:52
+//	version 8ba17717fbcedd4c3b6d4bce7e50c7af4155cba9 (release)
+//	timestamp 1673458852
+//	update-time 1676541346
+//	capabilities 0x0000007f
+func parseBootloaderVersion(output string) (*FirmwareVersion, error) {
+	ret := &FirmwareVersion{}
+	for _, line := range strings.Split(output, "\n") {
+		key, value, found := strings.Cut(strings.TrimSpace(line), " ")
+		if !found {
+			continue
+		}
+		switch key {
+		case "version":
+			ret.Version = strings.Fields(value)[0]
+		case "timestamp":
+			if seconds, err := strconv.ParseInt(value, 10, 64); err == nil {
+				ret.Date = time.Unix(seconds, 0).UTC()
+			}
+		}
+	}
+	if ret.Version == "" {
+		return nil, errors.New("unexpected output from vcgencmd bootloader_version")
+	}
+	return ret, nil
+}
+
+// parseBootloaderStatus parses output like:
+//
+//	BOOTLOADER: update available
+//	   CURRENT: Wed 11 Jan 17:40:52 UTC 2023 (1673458852)
+//	    LATEST: Thu  6 Jun 10:37:03 UTC 2024 (1717670223)
+//	   RELEASE: default (/lib/firmware/raspberrypi/bootloader-2711/default)
+func parseBootloaderStatus(output string) (*BootloaderStatus, error) {
+	ret := &BootloaderStatus{}
+	found := false
+	for _, line := range strings.Split(output, "\n") {
+		key, value, ok := strings.Cut(strings.TrimSpace(line), ":")
+		if !ok {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		switch key {
+		case "BOOTLOADER":
+			found = true
+			ret.UpdateAvailable = value == "update available" || value == "update required"
+		case "CURRENT":
+			ret.Current = parseEepromTimestamp(value)
+		case "LATEST":
+			ret.Latest = parseEepromTimestamp(value)
+		}
+	}
+	if !found {
+		return nil, errors.New("unexpected output from rpi-eeprom-update")
+	}
+	return ret, nil
+}
+
+// parseEepromTimestamp returns the unix timestamp in parentheses at the end of a rpi-eeprom-update line.
+func parseEepromTimestamp(value string) time.Time {
+	start := strings.LastIndex(value, "(")
+	end := strings.LastIndex(value, ")")
+	if start < 0 || end < start {
+		return time.Time{}
+	}
+	seconds, err := strconv.ParseInt(value[start+1:end], 10, 64)
+	if err != nil {
+		return time.Time{}
+	}
+	return time.Unix(seconds, 0).UTC()
+}
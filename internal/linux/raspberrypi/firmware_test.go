@@ -0,0 +1,38 @@
+package raspberrypi
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFirmwareVersion(t *testing.T) {
+	version, err := parseFirmwareVersion("Mar  7 2023 10:52:00 \nCopyright (c) 2012 Broadcom\nversion 82f3750a65fadae9a38077e3c2e217ad158c8d54 (clean) (release) (start)\n")
+	require.NoError(t, err)
+	assert.Equal(t, "82f3750a65fadae9a38077e3c2e217ad158c8d54", version.Version)
+	assert.Equal(t, time.Date(2023, time.March, 7, 10, 52, 0, 0, time.UTC), version.Date)
+
+	_, err = parseFirmwareVersion("VCHI initialization failed\n")
+	assert.Error(t, err)
+}
+
+func TestParseBootloaderVersion(t *testing.T) {
+	version, err := parseBootloaderVersion("2023/01/11 17:40:52\nversion 8ba17717fbcedd4c3b6d4bce7e50c7af4155cba9 (release)\ntimestamp 1673458852\nupdate-time 1676541346\ncapabilities 0x0000007f\n")
+	require.NoError(t, err)
+	assert.Equal(t, "8ba17717fbcedd4c3b6d4bce7e50c7af4155cba9", version.Version)
+	assert.Equal(t, time.Unix(1673458852, 0).UTC(), version.Date)
+}
+
+func TestParseBootloaderStatus(t *testing.T) {
+	status, err := parseBootloaderStatus("BOOTLOADER: update available\n   CURRENT: Wed 11 Jan 17:40:52 UTC 2023 (1673458852)\n    LATEST: Thu  6 Jun 10:37:03 UTC 2024 (1717670223)\n   RELEASE: default (/lib/firmware/raspberrypi/bootloader-2711/default)\n")
+	require.NoError(t, err)
+	assert.True(t, status.UpdateAvailable)
+	assert.Equal(t, time.Unix(1673458852, 0).UTC(), status.Current)
+	assert.Equal(t, time.Unix(1717670223, 0).UTC(), status.Latest)
+
+	status, err = parseBootloaderStatus("BOOTLOADER: up to date\n   CURRENT: Thu  6 Jun 10:37:03 UTC 2024 (1717670223)\n    LATEST: Thu  6 Jun 10:37:03 UTC 2024 (1717670223)\n")
+	require.NoError(t, err)
+	assert.False(t, status.UpdateAvailable)
+}
@@ -0,0 +1,49 @@
+package raspberrypi
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/linux"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+var ErrNoHAT = utils.NewError(utils.ErrHardwareAbsent, "no HAT EEPROM found")
+
+// The firmware reads the HAT EEPROM at boot and publishes its vendor info in the device tree, so a HAT that is
+// attached after boot or has a blank EEPROM isn't detected.
+const hatDeviceTreePath = "/proc/device-tree/hat"
+
+type HAT struct {
+	Vendor         string
+	Product        string
+	ProductID      string
+	ProductVersion string
+	UUID           string
+}
+
+func ReadHAT(ctx context.Context) (*HAT, error) {
+	return readHAT(ctx, hatDeviceTreePath)
+}
+
+func readHAT(ctx context.Context, path string) (*HAT, error) {
+	if _, err := utils.Stat(path); os.IsNotExist(err) {
+		return nil, ErrNoHAT
+	}
+	hat := &HAT{}
+	for property, value := range map[string]*string{
+		"vendor":      &hat.Vendor,
+		"product":     &hat.Product,
+		"product_id":  &hat.ProductID,
+		"product_ver": &hat.ProductVersion,
+		"uuid":        &hat.UUID,
+	} {
+		v, err := linux.ReadDeviceTreeString(ctx, filepath.Join(path, property))
+		if err != nil && !os.IsNotExist(err) {
+			return nil, err
+		}
+		*value = v
+	}
+	return hat, nil
+}
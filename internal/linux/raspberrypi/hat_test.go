@@ -0,0 +1,24 @@
+package raspberrypi
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadHAT(t *testing.T) {
+	hat, err := readHAT(context.Background(), "testdata/hat")
+	require.NoError(t, err)
+	assert.Equal(t, &HAT{
+		Vendor:         "Pimoroni Ltd.",
+		Product:        "Fan SHIM",
+		ProductID:      "0x0007",
+		ProductVersion: "0x0001",
+		UUID:           "a3c7e2b8-5f1d-4b9c-9e3a-0d4b6c8f1a22",
+	}, hat)
+
+	_, err = readHAT(context.Background(), "testdata/missing")
+	assert.ErrorIs(t, err, ErrNoHAT)
+}
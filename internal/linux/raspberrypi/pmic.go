@@ -0,0 +1,156 @@
+package raspberrypi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/sensors"
+	"go.viam.com/rdk/logging"
+)
+
+// e.g. "       VDD_CORE_A current(7)=0.76000000A" or "        EXT5V_V volt(24)=5.14794000V"
+var pmicADCRegex = regexp.MustCompile(`^\s*(\S+)_([AV]) (?:current|volt)\(\d+\)=([-\d.]+)[AV]\s*$`)
+
+// pmicCacheTime keeps every rail sensor of a reading from running vcgencmd again.
+const pmicCacheTime = 500 * time.Millisecond
+
+type pmicRail struct {
+	Voltage    float64
+	Current    float64
+	HasVoltage bool
+	HasCurrent bool
+}
+
+// pmicReader reads all rails of the Raspberry Pi 5 PMIC at once with vcgencmd pmic_read_adc.
+type pmicReader struct {
+	mu       sync.Mutex
+	rails    map[string]*pmicRail
+	lastRead time.Time
+}
+
+func (r *pmicReader) read(ctx context.Context) (map[string]*pmicRail, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.rails != nil && time.Since(r.lastRead) < pmicCacheTime {
+		return r.rails, nil
+	}
+	output, err := vcgencmd(ctx, "pmic_read_adc")
+	if err != nil {
+		return nil, err
+	}
+	rails, err := parsePMICReadADC(output)
+	if err != nil {
+		return nil, err
+	}
+	r.rails = rails
+	r.lastRead = time.Now()
+	return rails, nil
+}
+
+func parsePMICReadADC(output string) (map[string]*pmicRail, error) {
+	rails := make(map[string]*pmicRail)
+	for _, line := range strings.Split(output, "\n") {
+		matches := pmicADCRegex.FindStringSubmatch(line)
+		if matches == nil {
+			continue
+		}
+		value, err := strconv.ParseFloat(matches[3], 64)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", line, err)
+		}
+		name := strings.ToLower(matches[1])
+		rail, ok := rails[name]
+		if !ok {
+			rail = &pmicRail{}
+			rails[name] = rail
+		}
+		if matches[2] == "V" {
+			rail.Voltage, rail.HasVoltage = value, true
+		} else {
+			rail.Current, rail.HasCurrent = value, true
+		}
+	}
+	if len(rails) == 0 {
+		return nil, errors.New("unexpected output from vcgencmd pmic_read_adc")
+	}
+	return rails, nil
+}
+
+type raspberryPi5PowerSensor struct {
+	logger logging.Logger
+	pmic   *pmicReader
+	name   string
+}
+
+func (s *raspberryPi5PowerSensor) Close() error {
+	return nil
+}
+
+func (s *raspberryPi5PowerSensor) rail(ctx context.Context) (*pmicRail, error) {
+	rails, err := s.pmic.read(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rail, ok := rails[s.name]
+	if !ok {
+		return nil, fmt.Errorf("rail %s not reported by the PMIC", s.name)
+	}
+	return rail, nil
+}
+
+func (s *raspberryPi5PowerSensor) GetReading(ctx context.Context) (voltage, current, power float64, err error) {
+	rail, err := s.rail(ctx)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	return rail.Voltage, rail.Current, rail.Voltage * rail.Current, nil
+}
+
+func (s *raspberryPi5PowerSensor) GetReadingMap(ctx context.Context) (map[string]interface{}, error) {
+	rail, err := s.rail(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ret := make(map[string]interface{})
+	if rail.HasVoltage {
+		ret["voltage"] = rail.Voltage
+	}
+	if rail.HasCurrent {
+		ret["current"] = rail.Current
+	}
+	if rail.HasVoltage && rail.HasCurrent {
+		ret["power"] = rail.Voltage * rail.Current
+	}
+	return ret, nil
+}
+
+func (s *raspberryPi5PowerSensor) GetName() string {
+	return s.name
+}
+
+// getRaspberryPi5PowerSensors returns a sensor per PMIC rail, on the Pi 5 measure_volts only covers a few of them.
+func getRaspberryPi5PowerSensors(ctx context.Context, logger logging.Logger) ([]sensors.PowerSensor, error) {
+	pmic := &pmicReader{}
+	rails, err := pmic.read(ctx)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(rails))
+	for name := range rails {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	ret := make([]sensors.PowerSensor, 0, len(names))
+	for _, name := range names {
+		logger.Infof("Creating Raspberry Pi 5 power sensor for %s", name)
+		ret = append(ret, &raspberryPi5PowerSensor{logger: logger, pmic: pmic, name: name})
+	}
+	return ret, nil
+}
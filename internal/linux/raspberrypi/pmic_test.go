@@ -0,0 +1,31 @@
+package raspberrypi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePMICReadADC(t *testing.T) {
+	output := `     3V7_WL_SW_A current(0)=0.00000000A
+       3V3_SYS_A current(1)=0.05172414A
+      VDD_CORE_A current(7)=0.76000000A
+     3V7_WL_SW_V volt(8)=3.74713900V
+       3V3_SYS_V volt(9)=3.31240400V
+      VDD_CORE_V volt(15)=0.72000000V
+         EXT5V_V volt(24)=5.14794000V
+          BATT_V volt(25)=0.00000000V
+`
+	rails, err := parsePMICReadADC(output)
+	require.NoError(t, err)
+	assert.Len(t, rails, 5)
+	require.Contains(t, rails, "vdd_core")
+	assert.Equal(t, &pmicRail{Voltage: 0.72, Current: 0.76, HasVoltage: true, HasCurrent: true}, rails["vdd_core"])
+	require.Contains(t, rails, "ext5v")
+	assert.True(t, rails["ext5v"].HasVoltage)
+	assert.False(t, rails["ext5v"].HasCurrent)
+
+	_, err = parsePMICReadADC("error=1 error_msg=\"Command not registered\"\n")
+	assert.Error(t, err)
+}
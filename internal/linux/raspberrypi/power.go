@@ -3,11 +3,12 @@ package raspberrypi
 import (
 	"context"
 	"errors"
-	"os/exec"
 	"strconv"
 	"strings"
 	"sync"
 
+	"github.com/rinzlerlabs/sbcidentify"
+	"github.com/rinzlerlabs/sbcidentify/boardtype"
 	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/sensors"
 	"go.viam.com/rdk/logging"
 )
@@ -22,17 +23,17 @@ func (s *raspberryPiPowerSensor) Close() error {
 	return nil
 }
 
-func (s *raspberryPiPowerSensor) GetReading() (voltage, current, power float64, err error) {
+func (s *raspberryPiPowerSensor) GetReading(ctx context.Context) (voltage, current, power float64, err error) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
-	voltage, err = getRaspberryPiComponentVoltage(s.name)
+	voltage, err = getRaspberryPiComponentVoltage(ctx, s.name)
 	return
 }
 
-func (s *raspberryPiPowerSensor) GetReadingMap() (map[string]interface{}, error) {
+func (s *raspberryPiPowerSensor) GetReadingMap(ctx context.Context) (map[string]interface{}, error) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
-	voltage, err := getRaspberryPiComponentVoltage(s.name)
+	voltage, err := getRaspberryPiComponentVoltage(ctx, s.name)
 	return map[string]interface{}{
 		"voltage": voltage,
 	}, err
@@ -52,6 +53,9 @@ func newRaspberryPiPowerSensor(ctx context.Context, logger logging.Logger, name
 }
 
 func GetPowerSensors(ctx context.Context, logger logging.Logger) ([]sensors.PowerSensor, error) {
+	if sbcidentify.IsBoardType(boardtype.RaspberryPi5) {
+		return getRaspberryPi5PowerSensors(ctx, logger)
+	}
 	components := []string{"core", "sdram_c", "sdram_i", "sdram_p"}
 	sensors := make([]sensors.PowerSensor, 0)
 	for _, component := range components {
@@ -69,13 +73,11 @@ func GetPowerSensors(ctx context.Context, logger logging.Logger) ([]sensors.Powe
 	return sensors, nil
 }
 
-func getRaspberryPiComponentVoltage(component string) (Voltage float64, Err error) {
-	proc := exec.Command("vcgencmd", "measure_volts", component)
-	outputBytes, err := proc.Output()
+func getRaspberryPiComponentVoltage(ctx context.Context, component string) (Voltage float64, Err error) {
+	output, err := vcgencmd(ctx, "measure_volts", component)
 	if err != nil {
 		return 0, err
 	}
-	output := string(outputBytes)
 	return parseVcgencmdVoltage(output)
 }
 
@@ -1,11 +1,13 @@
 package raspberrypi
 
 import (
+	"context"
 	"errors"
-	"os/exec"
 	"strconv"
 
 	"go.viam.com/rdk/logging"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
 )
 
 type PowerManagerConfig struct {
@@ -31,7 +33,7 @@ func NewPowerManager(config *PowerManagerConfig, logger logging.Logger) (*raspiP
 	}, nil
 }
 
-func (pm *raspiPowerManager) ApplyPowerMode() (bool, error) {
+func (pm *raspiPowerManager) ApplyPowerMode(ctx context.Context) (bool, error) {
 	args := make([]string, 0)
 	if pm.config.Governor != "" {
 		args = append(args, "--governor", pm.config.Governor)
@@ -47,9 +49,7 @@ func (pm *raspiPowerManager) ApplyPowerMode() (bool, error) {
 	}
 
 	if len(args) > 0 {
-		proc := exec.Command("cpufreq-set", args...)
-
-		outputBytes, err := proc.Output()
+		outputBytes, err := utils.Output(ctx, "cpufreq-set", args...)
 		if err != nil {
 			pm.logger.Errorf("Error configuring CPU: %s", err)
 		}
@@ -60,6 +60,6 @@ func (pm *raspiPowerManager) ApplyPowerMode() (bool, error) {
 	return false, nil
 }
 
-func (pm *raspiPowerManager) GetCurrentPowerMode() (interface{}, error) {
+func (pm *raspiPowerManager) GetCurrentPowerMode(ctx context.Context) (interface{}, error) {
 	return nil, nil
 }
@@ -26,7 +26,7 @@ func TestRaspberryPiPowerSensors(t *testing.T) {
 	waitForValues(t, sensors)
 	for _, s := range sensors {
 		assert.NotNil(t, s)
-		m, err := s.GetReadingMap()
+		m, err := s.GetReadingMap(ctx)
 		require.NoError(t, err)
 		assert.NotNil(t, m)
 		for k, v := range m {
@@ -46,7 +46,7 @@ func waitForValues(t *testing.T, sensors []sensors.PowerSensor) {
 		}
 		allHaveValues := true
 		for _, s := range sensors {
-			m, err := s.GetReadingMap()
+			m, err := s.GetReadingMap(ctx)
 			require.NoError(t, err)
 			if len(m) == 0 {
 				allHaveValues = false
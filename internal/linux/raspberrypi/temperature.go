@@ -2,16 +2,19 @@ package raspberrypi
 
 import (
 	"context"
-	"os/exec"
+	"path/filepath"
 	"strconv"
 	"strings"
 
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/linux"
 	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/sensors"
 )
 
 var raspberryPiTemperatureSensors = []sensors.TemperatureReader{
 	NewVcgencmdSensor("CPU", ""),
 	NewVcgencmdSensor("PMIC", "pmic"),
+	// Only the Pi 5 has the RP1 southbridge, the read fails and is skipped on older boards
+	NewHwmonSensor("RP1", "rp1_adc"),
 }
 
 func GetTemperatures(ctx context.Context) (*sensors.SystemTemperatures, error) {
@@ -43,13 +46,17 @@ type VcgencmdSensor struct {
 	subcommand string
 }
 
+// Read measures the temperature with vcgencmd, or reads it from sysfs when vcgencmd fails and it has a sysfs
+// equivalent.
 func (t *VcgencmdSensor) Read(ctx context.Context) (float64, error) {
-	proc := exec.CommandContext(ctx, "vcgencmd", "measure_temp", t.subcommand)
-	outputBytes, err := proc.Output()
+	output, err := vcgencmd(ctx, "measure_temp", t.subcommand)
 	if err != nil {
+		if temp, fallbackErr := readFallbackTemperature(ctx, t.subcommand); fallbackErr == nil {
+			return temp, nil
+		}
 		return 0, err
 	}
-	return parseTemperature(string(outputBytes))
+	return parseTemperature(output)
 }
 
 func (t *VcgencmdSensor) Name() string {
@@ -61,3 +68,25 @@ func parseTemperature(output string) (Temperature float64, Err error) {
 	t1 := strings.TrimSuffix(t[1], "'C\n")
 	return strconv.ParseFloat(strings.TrimSpace(t1), 64)
 }
+
+// NewHwmonSensor reads temp1_input of the hwmon device with the given name.
+func NewHwmonSensor(name, hwmonName string) sensors.TemperatureReader {
+	return &HwmonSensor{name: name, hwmonName: hwmonName}
+}
+
+type HwmonSensor struct {
+	name      string
+	hwmonName string
+}
+
+func (t *HwmonSensor) Read(ctx context.Context) (float64, error) {
+	path, err := linux.FindHwmon(ctx, t.hwmonName)
+	if err != nil {
+		return 0, err
+	}
+	return linux.ReadHwmonTemperature(ctx, filepath.Join(path, "temp1_input"))
+}
+
+func (t *HwmonSensor) Name() string {
+	return t.name
+}
@@ -0,0 +1,110 @@
+package raspberrypi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/linux"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+const (
+	// throttledPath is where newer kernels expose the firmware's throttled flags, in hex without the 0x
+	throttledPath = "/sys/devices/platform/soc/soc:firmware/get_throttled"
+	// cpuThermalPath is the SoC's temperature in millidegrees, which vcgencmd measure_temp reads too
+	cpuThermalPath = "/sys/class/thermal/thermal_zone0/temp"
+	// armClockPath is the first core's frequency in kHz, which is the arm clock vcgencmd measures
+	armClockPath = "/sys/devices/system/cpu/cpu0/cpufreq/scaling_cur_freq"
+)
+
+// vcgencmdRunner makes vcgencmd run one command at a time. The firmware answers one request at a time, so running
+// them at once only has them wait their turn in the kernel, each holding a command worker.
+var vcgencmdRunner sync.Mutex
+
+// vcgencmdResult is the output of a vcgencmd command, or why it failed.
+type vcgencmdResult struct {
+	output string
+	err    error
+}
+
+// vcgencmd runs vcgencmd with args, sharing its output with the sensors running the same command in the same poll
+// cycle.
+func vcgencmd(ctx context.Context, args ...string) (string, error) {
+	results, err := vcgencmdBatch(ctx, [][]string{args})
+	if err != nil {
+		return "", err
+	}
+	result := results[strings.Join(args, " ")]
+	return result.output, result.err
+}
+
+// vcgencmdBatch runs every command of batch, e.g. measure_clock of every clock, one after the other as one read, so the
+// sensors reading in the same poll cycle share it, see utils.SharedReadAge, and it takes the runner once rather than
+// once per command. The results are keyed by the command's arguments joined with spaces, a command that failed doesn't
+// fail the others.
+func vcgencmdBatch(ctx context.Context, batch [][]string) (map[string]vcgencmdResult, error) {
+	keys := make([]string, 0, len(batch))
+	for _, args := range batch {
+		keys = append(keys, strings.Join(args, " "))
+	}
+	return utils.ReadShared(ctx, "vcgencmd:"+strings.Join(keys, ";"), utils.SharedReadAge, func(ctx context.Context) (map[string]vcgencmdResult, error) {
+		vcgencmdRunner.Lock()
+		defer vcgencmdRunner.Unlock()
+		results := make(map[string]vcgencmdResult, len(batch))
+		for i, args := range batch {
+			output, err := utils.Output(ctx, "vcgencmd", args...)
+			results[keys[i]] = vcgencmdResult{output: string(output), err: err}
+		}
+		return results, nil
+	})
+}
+
+// ReadThrottled returns the firmware's throttled flags from vcgencmd get_throttled, or from sysfs when vcgencmd fails,
+// e.g. in a container without /dev/vchiq.
+func ReadThrottled(ctx context.Context) (int64, error) {
+	output, err := vcgencmd(ctx, "get_throttled")
+	if err == nil {
+		return ParseThrottled(output)
+	}
+	data, sysfsErr := utils.ReadFileWithContext(ctx, throttledPath)
+	if sysfsErr != nil {
+		return 0, errors.Join(err, sysfsErr)
+	}
+	return strconv.ParseInt(strings.TrimSpace(data), 16, 64)
+}
+
+// ParseThrottled parses the output of vcgencmd get_throttled, e.g. throttled=0x50005.
+func ParseThrottled(output string) (int64, error) {
+	parts := strings.Split(output, "=")
+	if len(parts) != 2 {
+		return 0, fmt.Errorf("unexpected output from vcgencmd %s", output)
+	}
+	hex := strings.TrimSpace(strings.Replace(parts[1], "0x", "", 1))
+	return strconv.ParseInt(hex, 16, 64)
+}
+
+// readFallbackTemperature reads the temperature vcgencmd measure_temp subcommand measures from sysfs, only the SoC's
+// has a sysfs equivalent.
+func readFallbackTemperature(ctx context.Context, subcommand string) (float64, error) {
+	if subcommand != "" {
+		return 0, fmt.Errorf("measure_temp %s has no sysfs equivalent", subcommand)
+	}
+	return linux.ReadHwmonTemperature(ctx, cpuThermalPath)
+}
+
+// readFallbackClock reads the clock vcgencmd measure_clock measures from sysfs in Hz, only the arm clock has a sysfs
+// equivalent.
+func readFallbackClock(ctx context.Context, name string) (int64, error) {
+	if name != "arm" {
+		return 0, fmt.Errorf("measure_clock %s has no sysfs equivalent", name)
+	}
+	khz, err := utils.ReadInt64FromFileWithContext(ctx, armClockPath)
+	if err != nil {
+		return 0, err
+	}
+	return khz * 1000, nil
+}
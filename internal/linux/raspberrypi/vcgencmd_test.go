@@ -0,0 +1,67 @@
+package raspberrypi
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+func TestVcgencmdBatch(t *testing.T) {
+	t.Cleanup(func() { utils.SetCommandRunner(utils.DefaultCommandRunner()) })
+
+	var ran []string
+	utils.SetCommandRunner(utils.CommandRunnerFunc(func(ctx context.Context, cmd utils.Command) ([]byte, error) {
+		args := strings.Join(cmd.Args, " ")
+		ran = append(ran, args)
+		if args == "measure_clock dpi" {
+			return nil, errors.New("exit status 2")
+		}
+		return []byte("frequency(0)=" + cmd.Args[1] + "\n"), nil
+	}))
+	batch := [][]string{{"measure_clock", "arm"}, {"measure_clock", "core"}, {"measure_clock", "dpi"}}
+
+	// Every sensor of the poll cycle shares one run of the batch, and a command that fails doesn't fail the others
+	for range 3 {
+		results, err := vcgencmdBatch(context.Background(), batch)
+		require.NoError(t, err)
+		assert.Equal(t, "frequency(0)=core\n", results["measure_clock core"].output)
+		assert.Error(t, results["measure_clock dpi"].err)
+	}
+	assert.Equal(t, []string{"measure_clock arm", "measure_clock core", "measure_clock dpi"}, ran)
+}
+
+func TestReadThrottledFallsBackToSysfs(t *testing.T) {
+	t.Cleanup(func() {
+		utils.SetCommandRunner(utils.DefaultCommandRunner())
+		utils.SetFS(utils.OSFS{})
+	})
+	utils.SetCommandRunner(utils.CommandRunnerFunc(func(ctx context.Context, cmd utils.Command) ([]byte, error) {
+		return nil, exec.ErrNotFound
+	}))
+	board := t.TempDir()
+	path := filepath.Join(board, throttledPath)
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	require.NoError(t, os.WriteFile(path, []byte("50005\n"), 0o644))
+	utils.SetFS(utils.RootFS{Root: board})
+
+	throttled, err := ReadThrottled(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, int64(0x50005), throttled)
+}
+
+func TestParseThrottled(t *testing.T) {
+	throttled, err := ParseThrottled("throttled=0x50005\n")
+	require.NoError(t, err)
+	assert.Equal(t, int64(0x50005), throttled)
+	_, err = ParseThrottled("VCHI initialization failed")
+	assert.Error(t, err)
+}
@@ -0,0 +1,150 @@
+package linux
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"go.viam.com/rdk/logging"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/sensors"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+const RegulatorRoot = "/sys/class/regulator"
+
+// Regulator is a voltage regulator under /sys/class/regulator, e.g. a PMIC rail. Fixed regulators don't report a
+// voltage.
+type Regulator struct {
+	// Device is the sysfs name, e.g. regulator.3, Name is the name from the device tree, e.g. vdd_mpu
+	Device string
+	Name   string
+	// State is enabled, disabled or unknown
+	State string
+	Users int64
+	// Voltage is in volts, nil when the regulator doesn't report it
+	Voltage *float64
+	// Current is in amps, nil when the regulator doesn't report it
+	Current *float64
+}
+
+// ReadRegulators reads every regulator under root, normally RegulatorRoot.
+func ReadRegulators(ctx context.Context, root string) ([]Regulator, error) {
+	entries, err := utils.ReadDir(root)
+	if err != nil {
+		return nil, err
+	}
+	regulators := make([]Regulator, 0, len(entries))
+	for _, entry := range entries {
+		regulator, err := ReadRegulator(ctx, root, entry.Name())
+		if err != nil {
+			return nil, err
+		}
+		regulators = append(regulators, *regulator)
+	}
+	return regulators, nil
+}
+
+// ReadRegulator reads the regulator with the given sysfs name under root, normally RegulatorRoot.
+func ReadRegulator(ctx context.Context, root, device string) (*Regulator, error) {
+	path := filepath.Join(root, device)
+	name, err := utils.ReadFileWithContext(ctx, filepath.Join(path, "name"))
+	if err != nil {
+		return nil, err
+	}
+	regulator := &Regulator{Device: device, Name: name}
+	regulator.State, _ = utils.ReadFileWithContext(ctx, filepath.Join(path, "state"))
+	regulator.Users, _ = utils.ReadInt64FromFileWithContext(ctx, filepath.Join(path, "num_users"))
+	if microvolts, err := utils.ReadInt64FromFileWithContext(ctx, filepath.Join(path, "microvolts")); err == nil {
+		value := float64(microvolts) / 1e6
+		regulator.Voltage = &value
+	}
+	if microamps, err := utils.ReadInt64FromFileWithContext(ctx, filepath.Join(path, "microamps")); err == nil {
+		value := float64(microamps) / 1e6
+		regulator.Current = &value
+	}
+	return regulator, nil
+}
+
+// Regulator names come from the device tree and can contain commas, e.g. vio,vrtc,vdds on the TPS65217.
+var regulatorNameRegex = regexp.MustCompile(`[^a-z0-9_]+`)
+
+// regulatorPowerSensor reads a PMIC rail through the regulator class. Regulators only report the voltage they're set
+// to, not a measurement.
+type regulatorPowerSensor struct {
+	logger logging.Logger
+	root   string
+	device string
+	name   string
+}
+
+func (s *regulatorPowerSensor) Close() error {
+	return nil
+}
+
+func (s *regulatorPowerSensor) GetName() string {
+	return s.name
+}
+
+func (s *regulatorPowerSensor) GetReading(ctx context.Context) (voltage, current, power float64, err error) {
+	regulator, err := ReadRegulator(ctx, s.root, s.device)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	if regulator.Voltage == nil {
+		return 0, 0, 0, fmt.Errorf("regulator %s does not report its voltage", regulator.Name)
+	}
+	if regulator.Current != nil {
+		current = *regulator.Current
+	}
+	return *regulator.Voltage, current, *regulator.Voltage * current, nil
+}
+
+func (s *regulatorPowerSensor) GetReadingMap(ctx context.Context) (map[string]interface{}, error) {
+	regulator, err := ReadRegulator(ctx, s.root, s.device)
+	if err != nil {
+		return nil, err
+	}
+	ret := map[string]interface{}{
+		"enabled": regulator.State == "enabled",
+	}
+	if regulator.Voltage != nil {
+		ret["voltage"] = *regulator.Voltage
+	}
+	if regulator.Current != nil {
+		ret["current"] = *regulator.Current
+	}
+	if regulator.Voltage != nil && regulator.Current != nil {
+		ret["power"] = *regulator.Voltage * *regulator.Current
+	}
+	return ret, nil
+}
+
+func regulatorSensorName(name string) string {
+	return strings.Trim(regulatorNameRegex.ReplaceAllString(strings.ToLower(name), "_"), "_")
+}
+
+// GetRegulatorPowerSensors returns a power sensor for every regulator under root, normally RegulatorRoot, that reports
+// its voltage. Boards without a current sensing PMIC, e.g. the BeagleBones and i.MX8 SoMs, use these for their rails.
+func GetRegulatorPowerSensors(ctx context.Context, logger logging.Logger, root string) ([]sensors.PowerSensor, error) {
+	regulators, err := ReadRegulators(ctx, root)
+	if err != nil {
+		return nil, err
+	}
+	ret := make([]sensors.PowerSensor, 0)
+	for _, regulator := range regulators {
+		if regulator.Name == "regulator-dummy" || regulator.Voltage == nil {
+			continue
+		}
+		logger.Infof("Creating regulator power sensor for %s", regulator.Name)
+		ret = append(ret, &regulatorPowerSensor{
+			logger: logger,
+			root:   root,
+			device: regulator.Device,
+			name:   regulatorSensorName(regulator.Name),
+		})
+	}
+	return ret, nil
+}
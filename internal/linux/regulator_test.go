@@ -0,0 +1,22 @@
+package linux
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.viam.com/rdk/logging"
+)
+
+func TestGetRegulatorPowerSensors(t *testing.T) {
+	rails, err := GetRegulatorPowerSensors(context.Background(), logging.NewTestLogger(t), "testdata/sys_class_regulator")
+	require.NoError(t, err)
+	require.Len(t, rails, 2)
+	assert.Equal(t, "vdd_mpu", rails[0].GetName())
+	assert.Equal(t, "vio_vrtc_vdds", rails[1].GetName())
+
+	readings, err := rails[0].GetReadingMap(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"enabled": true, "voltage": 1.325}, readings)
+}
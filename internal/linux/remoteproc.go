@@ -0,0 +1,44 @@
+package linux
+
+import (
+	"context"
+	"path/filepath"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+const RemoteprocRoot = "/sys/class/remoteproc"
+
+// Remoteproc is a coprocessor managed by the kernel's remoteproc framework, e.g. a PRU on a BeagleBone or a
+// Cortex-M core on an i.MX8.
+type Remoteproc struct {
+	// Device is the sysfs name, e.g. remoteproc1, Name is the processor, e.g. 4a334000.pru
+	Device string
+	Name   string
+	// State is offline, suspended, running, crashed or invalid
+	State    string
+	Firmware string
+}
+
+// ReadRemoteprocs reads every remote processor under root, normally RemoteprocRoot.
+func ReadRemoteprocs(ctx context.Context, root string) ([]Remoteproc, error) {
+	entries, err := utils.ReadDir(root)
+	if err != nil {
+		return nil, err
+	}
+	procs := make([]Remoteproc, 0, len(entries))
+	for _, entry := range entries {
+		path := filepath.Join(root, entry.Name())
+		name, err := utils.ReadFileWithContext(ctx, filepath.Join(path, "name"))
+		if err != nil {
+			return nil, err
+		}
+		proc := Remoteproc{Device: entry.Name(), Name: name}
+		if proc.State, err = utils.ReadFileWithContext(ctx, filepath.Join(path, "state")); err != nil {
+			return nil, err
+		}
+		proc.Firmware, _ = utils.ReadFileWithContext(ctx, filepath.Join(path, "firmware"))
+		procs = append(procs, proc)
+	}
+	return procs, nil
+}
@@ -0,0 +1,31 @@
+package linux
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadRemoteprocs(t *testing.T) {
+	root := t.TempDir()
+	for device, attrs := range map[string]map[string]string{
+		"remoteproc0": {"name": "wkup_m3", "state": "running", "firmware": "am335x-pm-firmware.elf"},
+		"remoteproc1": {"name": "4a334000.pru", "state": "offline", "firmware": "am335x-pru0-fw"},
+	} {
+		require.NoError(t, os.MkdirAll(filepath.Join(root, device), 0o755))
+		for attr, value := range attrs {
+			require.NoError(t, os.WriteFile(filepath.Join(root, device, attr), []byte(value+"\n"), 0o644))
+		}
+	}
+
+	procs, err := ReadRemoteprocs(context.Background(), root)
+	require.NoError(t, err)
+	assert.Equal(t, []Remoteproc{
+		{Device: "remoteproc0", Name: "wkup_m3", State: "running", Firmware: "am335x-pm-firmware.elf"},
+		{Device: "remoteproc1", Name: "4a334000.pru", State: "offline", Firmware: "am335x-pru0-fw"},
+	}, procs)
+}
@@ -0,0 +1,82 @@
+package linux
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+// RemovableDisk is a block device that is either marked removable or attached over USB.
+type RemovableDisk struct {
+	Name       string
+	Vendor     string
+	Model      string
+	SizeBytes  uint64
+	USB        bool
+	Partitions []string
+}
+
+// GetRemovableDisks returns the removable disks that are currently attached.
+func GetRemovableDisks(ctx context.Context) ([]*RemovableDisk, error) {
+	return getRemovableDisks(ctx, "/sys/block")
+}
+
+func getRemovableDisks(ctx context.Context, sysBlockPath string) ([]*RemovableDisk, error) {
+	entries, err := utils.ReadDir(sysBlockPath)
+	if err != nil {
+		return nil, err
+	}
+	disks := make([]*RemovableDisk, 0)
+	for _, entry := range entries {
+		path := filepath.Join(sysBlockPath, entry.Name())
+		removable, _ := utils.ReadBoolFromFileWithContext(ctx, filepath.Join(path, "removable"))
+		// /sys/block entries link into the device tree, USB disks sit below a usb controller
+		resolved, err := filepath.EvalSymlinks(path)
+		usb := err == nil && strings.Contains(resolved, "/usb")
+		if !removable && !usb {
+			continue
+		}
+		sectors, err := utils.ReadInt64FromFileWithContext(ctx, filepath.Join(path, "size"))
+		if err != nil {
+			return nil, err
+		}
+		// Card readers without a card report a size of 0
+		if sectors == 0 {
+			continue
+		}
+		disk := &RemovableDisk{
+			Name:       entry.Name(),
+			SizeBytes:  uint64(sectors) * DiskSectorSize,
+			USB:        usb,
+			Partitions: make([]string, 0),
+		}
+		disk.Vendor, _ = utils.ReadFileWithContext(ctx, filepath.Join(path, "device", "vendor"))
+		disk.Model, _ = utils.ReadFileWithContext(ctx, filepath.Join(path, "device", "model"))
+		partitions, _ := utils.Glob(filepath.Join(path, entry.Name()+"*", "partition"))
+		for _, partition := range partitions {
+			disk.Partitions = append(disk.Partitions, filepath.Base(filepath.Dir(partition)))
+		}
+		disks = append(disks, disk)
+	}
+	return disks, nil
+}
+
+// IsRemovableDiskEvent reports whether a uevent is for a whole disk that GetRemovableDisks would report, so internal
+// eMMC, loop and zram devices are left out. A removed disk is already gone from /sys/block, so only the USB check in
+// its device path works for remove events.
+func IsRemovableDiskEvent(ctx context.Context, event *Uevent) bool {
+	return isRemovableDiskEvent(ctx, event, "/sys/block")
+}
+
+func isRemovableDiskEvent(ctx context.Context, event *Uevent, sysBlockPath string) bool {
+	if event == nil || event.Subsystem != "block" || event.DevType != "disk" || event.DevName == "" {
+		return false
+	}
+	if strings.Contains(event.DevPath, "/usb") {
+		return true
+	}
+	removable, _ := utils.ReadBoolFromFileWithContext(ctx, filepath.Join(sysBlockPath, filepath.Base(event.DevName), "removable"))
+	return removable
+}
@@ -0,0 +1,152 @@
+package rockchip
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"sync"
+
+	"go.viam.com/rdk/logging"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/linux"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/sensors"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+// rockchipDevfreqClocks maps devfreq device patterns to clock names, e.g. fb000000.gpu on the RK3588 and
+// fde60000.gpu on the RK3566.
+var rockchipDevfreqClocks = []struct {
+	pattern string
+	name    string
+}{
+	{"*.gpu", "gpu"},
+	{"*.npu", "npu"},
+	{"dmc", "dmc"},
+}
+
+type rockchipClockSensor struct {
+	logger     logging.Logger
+	mu         sync.RWMutex
+	name       string
+	sensorType string
+	path       string
+	pvtm       *int
+}
+
+func (s *rockchipClockSensor) Close() error {
+	return nil
+}
+
+func (s *rockchipClockSensor) Name() string {
+	return s.name
+}
+
+func (s *rockchipClockSensor) GetReadingMap(ctx context.Context) (map[string]interface{}, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	frequency, err := utils.ReadInt64FromFileWithContext(ctx, s.path)
+	if err != nil {
+		s.logger.Errorw("failed to read clock", "sensor", s.name, "error", err)
+		return nil, err
+	}
+	// cpufreq reports kHz, devfreq reports Hz
+	if s.sensorType == "cpufreq" {
+		frequency *= 1000
+	}
+	ret := map[string]interface{}{
+		s.name: frequency,
+	}
+	if s.pvtm != nil {
+		ret[s.name+"_pvtm"] = *s.pvtm
+	}
+	return ret, nil
+}
+
+func newRockchipClockSensor(ctx context.Context, logger logging.Logger, name, sensorType, path string, pvtm *int) *rockchipClockSensor {
+	logger.Debugf("Initializing Rockchip %s clock sensor: %v", sensorType, path)
+	return &rockchipClockSensor{
+		logger:     logger.Sublogger(name),
+		name:       name,
+		sensorType: sensorType,
+		path:       path,
+		pvtm:       pvtm,
+	}
+}
+
+// clusterNames names the cpufreq policies of big.LITTLE SoCs, the RK3588 has a little cluster and two big clusters,
+// the RK356x has a single cluster.
+func clusterNames(policies []linux.CPUFreqPolicy) map[string]string {
+	names := make(map[string]string)
+	if len(policies) == 1 {
+		names[policies[0].Name] = "cpu"
+		return names
+	}
+	slowest := slices.MinFunc(policies, func(a, b linux.CPUFreqPolicy) int {
+		return cmp.Compare(a.MaxFrequency, b.MaxFrequency)
+	}).MaxFrequency
+	big := 0
+	for _, policy := range policies {
+		if policy.MaxFrequency == slowest {
+			names[policy.Name] = "cpu_little"
+			continue
+		}
+		names[policy.Name] = fmt.Sprintf("cpu_big%d", big)
+		big++
+	}
+	return names
+}
+
+// pvtmForClock looks up the PVTM value of a clock. CPU values are logged for the first CPU of the cluster, e.g.
+// cpu4, devices are logged by name, e.g. fb000000.gpu.
+func pvtmForClock(pvtm map[string]int, policy *linux.CPUFreqPolicy, device string) *int {
+	key := device
+	if policy != nil {
+		if len(policy.CPUs) == 0 {
+			return nil
+		}
+		key = "cpu" + strconv.Itoa(policy.CPUs[0])
+	}
+	if value, ok := pvtm[key]; ok {
+		return &value
+	}
+	return nil
+}
+
+func GetClockSensors(ctx context.Context, logger logging.Logger) ([]sensors.ClockSensor, error) {
+	return getClockSensors(ctx, logger, linux.CPUFreqRoot, linux.DevfreqRoot)
+}
+
+func getClockSensors(ctx context.Context, logger logging.Logger, cpufreqRoot, devfreqRoot string) ([]sensors.ClockSensor, error) {
+	pvtm, err := ReadPVTM()
+	if err != nil {
+		logger.Debugf("PVTM values are not available: %v", err)
+	}
+
+	s := make([]sensors.ClockSensor, 0)
+	policies, err := linux.ReadCPUFreqPolicies(ctx, cpufreqRoot)
+	if err != nil {
+		return nil, err
+	}
+	names := clusterNames(policies)
+	for i := range policies {
+		policy := &policies[i]
+		path := filepath.Join(cpufreqRoot, policy.Name, "scaling_cur_freq")
+		s = append(s, newRockchipClockSensor(ctx, logger, names[policy.Name], "cpufreq", path, pvtmForClock(pvtm, policy, "")))
+	}
+
+	for _, clock := range rockchipDevfreqClocks {
+		devices, err := linux.FindDevfreq(devfreqRoot, clock.pattern)
+		if err != nil {
+			return nil, err
+		}
+		if len(devices) == 0 {
+			continue
+		}
+		path := filepath.Join(devfreqRoot, devices[0], "cur_freq")
+		s = append(s, newRockchipClockSensor(ctx, logger, clock.name, "devfreq", path, pvtmForClock(pvtm, nil, devices[0])))
+	}
+	return s, nil
+}
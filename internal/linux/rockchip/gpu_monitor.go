@@ -0,0 +1,57 @@
+package rockchip
+
+import (
+	"context"
+
+	"go.viam.com/rdk/logging"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/linux"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/sensors"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+var ErrGpuNotFound = utils.NewError(utils.ErrHardwareAbsent, "no Mali GPU devfreq device found")
+
+// rockchipGpuMonitor reads the Mali GPU through devfreq, the Mali driver reports its utilization in the load
+// attribute.
+type rockchipGpuMonitor struct {
+	logger logging.Logger
+	device string
+}
+
+func NewRockchipGpuMonitor(logger logging.Logger) (*rockchipGpuMonitor, error) {
+	devices, err := linux.FindDevfreq(linux.DevfreqRoot, "*.gpu")
+	if err != nil {
+		return nil, err
+	}
+	if len(devices) == 0 {
+		return nil, ErrGpuNotFound
+	}
+	return &rockchipGpuMonitor{logger: logger, device: devices[0]}, nil
+}
+
+func (m *rockchipGpuMonitor) GetGPUStats(ctx context.Context) (map[string][]sensors.GPUSensorReading, error) {
+	device, err := linux.ReadDevfreq(ctx, linux.DevfreqRoot, m.device)
+	if err != nil {
+		return nil, err
+	}
+	return map[string][]sensors.GPUSensorReading{
+		"gpu0": gpuReadings(device),
+	}, nil
+}
+
+func gpuReadings(device *linux.DevfreqDevice) []sensors.GPUSensorReading {
+	stats := []sensors.GPUSensorReading{
+		{Type: sensors.GPUReadingTypeClocksGraphics, Value: float64(device.CurrentFrequency)},
+		{Type: sensors.GPUReadingTypeClocksGraphicsMax, Value: float64(device.MaxFrequency)},
+	}
+	if device.Load >= 0 {
+		stats = append(stats, sensors.GPUSensorReading{Type: sensors.GPUReadingTypeUtilizationGPU, Value: device.Load})
+	}
+	return stats
+}
+
+func (m *rockchipGpuMonitor) Close() error {
+	// No resources to clean up for Rockchip GPU monitor
+	return nil
+}
@@ -0,0 +1,48 @@
+package rockchip
+
+import (
+	"regexp"
+	"strconv"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/linux"
+)
+
+// The PVTM (process, voltage, temperature monitor) measures how fast the silicon is at boot and the kernel uses it to
+// select the voltage table, so it's a rough measure of the chip's quality. It's only available in the kernel log, e.g.
+//
+//	cpu cpu4: pvtm=1750
+//	mali fb000000.gpu: pvtm=869
+var pvtmRegex = regexp.MustCompile(`^\S+ (\S+): pvtm=(\d+)`)
+
+// ReadPVTM returns the PVTM values logged at boot keyed by device, e.g. cpu4 or fb000000.gpu. The values are lost
+// once the kernel log wraps, so the result should be read once and kept.
+func ReadPVTM() (map[string]int, error) {
+	kmsg, err := linux.OpenKmsg(false)
+	if err != nil {
+		return nil, err
+	}
+	defer kmsg.Close()
+	records, err := kmsg.ReadAvailable()
+	if err != nil {
+		return nil, err
+	}
+	messages := make([]string, 0, len(records))
+	for _, record := range records {
+		messages = append(messages, record.Message)
+	}
+	return parsePVTM(messages), nil
+}
+
+func parsePVTM(messages []string) map[string]int {
+	values := make(map[string]int)
+	for _, message := range messages {
+		match := pvtmRegex.FindStringSubmatch(message)
+		if match == nil {
+			continue
+		}
+		if value, err := strconv.Atoi(match[2]); err == nil {
+			values[match[1]] = value
+		}
+	}
+	return values
+}
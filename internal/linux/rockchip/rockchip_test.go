@@ -0,0 +1,84 @@
+package rockchip
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.viam.com/rdk/logging"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/linux"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/sensors"
+)
+
+func TestGetTemperatures(t *testing.T) {
+	temps, err := getTemperatures(context.Background(), "testdata/rk3588/thermal")
+	require.NoError(t, err)
+	require.NotNil(t, temps.CPU)
+	assert.Equal(t, 48.13, *temps.CPU)
+	require.NotNil(t, temps.GPU)
+	assert.Equal(t, 44.38, *temps.GPU)
+	assert.Equal(t, map[string]float64{
+		"SOC":         46.25,
+		"CENTER":      45.31,
+		"BIG_CORE0":   47.19,
+		"BIG_CORE1":   47.19,
+		"LITTLE_CORE": 48.13,
+		"NPU":         45.31,
+	}, temps.Extra)
+}
+
+func TestClusterNames(t *testing.T) {
+	policies, err := linux.ReadCPUFreqPolicies(context.Background(), "testdata/rk3588/cpufreq")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"policy0": "cpu_little", "policy4": "cpu_big0", "policy6": "cpu_big1"}, clusterNames(policies))
+
+	assert.Equal(t, map[string]string{"policy0": "cpu"}, clusterNames(policies[:1]))
+}
+
+func TestGetClockSensors(t *testing.T) {
+	clocks, err := getClockSensors(context.Background(), logging.NewTestLogger(t), "testdata/rk3588/cpufreq", "testdata/rk3588/devfreq")
+	require.NoError(t, err)
+	readings := make(map[string]interface{})
+	for _, clock := range clocks {
+		reading, err := clock.GetReadingMap(context.Background())
+		require.NoError(t, err)
+		for k, v := range reading {
+			readings[k] = v
+		}
+	}
+	assert.Equal(t, int64(1800000000), readings["cpu_little"])
+	assert.Equal(t, int64(2256000000), readings["cpu_big0"])
+	assert.Equal(t, int64(1416000000), readings["cpu_big1"])
+	assert.Equal(t, int64(300000000), readings["gpu"])
+	assert.Equal(t, int64(1000000000), readings["npu"])
+	assert.Equal(t, int64(2112000000), readings["dmc"])
+}
+
+func TestParsePVTM(t *testing.T) {
+	pvtm := parsePVTM([]string{
+		"cpu cpu0: pvtm=1497",
+		"cpu cpu0: pvtm-volt-sel=3",
+		"cpu cpu4: pvtm=1750",
+		"mali fb000000.gpu: pvtm=869",
+		"RKNPU fdab0000.npu: pvtm=870",
+		"rockchip-pvtm fda40000.pvtm: pvtm@0 probed",
+	})
+	assert.Equal(t, map[string]int{"cpu0": 1497, "cpu4": 1750, "fb000000.gpu": 869, "fdab0000.npu": 870}, pvtm)
+
+	policy := &linux.CPUFreqPolicy{Name: "policy4", CPUs: []int{4, 5}}
+	assert.Equal(t, 1750, *pvtmForClock(pvtm, policy, ""))
+	assert.Equal(t, 869, *pvtmForClock(pvtm, nil, "fb000000.gpu"))
+	assert.Nil(t, pvtmForClock(pvtm, nil, "dmc"))
+}
+
+func TestGpuReadings(t *testing.T) {
+	device, err := linux.ReadDevfreq(context.Background(), "testdata/rk3588/devfreq", "fb000000.gpu")
+	require.NoError(t, err)
+	assert.Equal(t, []sensors.GPUSensorReading{
+		{Type: sensors.GPUReadingTypeClocksGraphics, Value: float64(300000000)},
+		{Type: sensors.GPUReadingTypeClocksGraphicsMax, Value: float64(1000000000)},
+		{Type: sensors.GPUReadingTypeUtilizationGPU, Value: float64(12)},
+	}, gpuReadings(device))
+}
@@ -0,0 +1,63 @@
+package rockchip
+
+import (
+	"context"
+	"strings"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/linux"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/sensors"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+// rockchipThermalZones maps the thermal zone types of the RK3588 and RK356x to reading names. The RK356x only has
+// soc-thermal and gpu-thermal.
+var rockchipThermalZones = map[string]string{
+	"soc-thermal":        "SOC",
+	"center-thermal":     "CENTER",
+	"bigcore0-thermal":   "BIG_CORE0",
+	"bigcore1-thermal":   "BIG_CORE1",
+	"littlecore-thermal": "LITTLE_CORE",
+	"gpu-thermal":        "GPU",
+	"npu-thermal":        "NPU",
+}
+
+func GetTemperatures(ctx context.Context) (*sensors.SystemTemperatures, error) {
+	return getTemperatures(ctx, linux.ThermalZonesRoot)
+}
+
+func getTemperatures(ctx context.Context, root string) (*sensors.SystemTemperatures, error) {
+	zones, err := linux.ReadThermalZones(ctx, root)
+	if err != nil {
+		return nil, err
+	}
+	systemTemps := &sensors.SystemTemperatures{Extra: make(map[string]float64)}
+	var cpu *float64
+	for zoneType, temp := range zones {
+		name, ok := rockchipThermalZones[zoneType]
+		if !ok {
+			continue
+		}
+		temp = utils.RoundValue(temp, 2)
+		switch {
+		case name == "GPU":
+			systemTemps.GPU = &temp
+		case strings.Contains(name, "CORE"):
+			// The RK3588 measures every CPU cluster, report the hottest one as the CPU temperature
+			if cpu == nil || temp > *cpu {
+				cpu = &temp
+			}
+			systemTemps.Extra[name] = temp
+		default:
+			systemTemps.Extra[name] = temp
+		}
+	}
+	if cpu == nil {
+		// On the RK356x the SoC sensor sits next to the CPU cluster
+		if soc, ok := systemTemps.Extra["SOC"]; ok {
+			delete(systemTemps.Extra, "SOC")
+			cpu = &soc
+		}
+	}
+	systemTemps.CPU = cpu
+	return systemTemps, nil
+}
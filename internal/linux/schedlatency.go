@@ -0,0 +1,326 @@
+package linux
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+const (
+	// SchedTraceWindow is how long each run of bpftrace traces for, the latency of a process is over the last window
+	SchedTraceWindow = 10 * time.Second
+	// bpftraceStartup is how long bpftrace has to compile and attach its probes, on top of the window
+	bpftraceStartup = 20 * time.Second
+	// btfPath is the kernel's BTF, bpftrace needs it to read the tgid of the task being switched out
+	btfPath = "/sys/kernel/btf/vmlinux"
+)
+
+// schedTraceScript measures, for the processes whose tgids match the filter, how long their threads waited on a run
+// queue from being woken or preempted until they ran, and how long they were off a CPU from being switched out until
+// they ran again. The maps only needed while tracing are cleared before exiting, so bpftrace only prints the results.
+// args-> rather than args. works on the bpftrace shipped by Debian 12 and Ubuntu 22.04.
+const schedTraceScript = `tracepoint:sched:sched_switch
+{
+	if (%s) {
+		@tgid[args->prev_pid] = curtask->tgid;
+		if (args->prev_state == 0) {
+			@queued[args->prev_pid] = nsecs;
+		} else {
+			@blocked[args->prev_pid] = nsecs;
+		}
+	}
+	$tgid = @tgid[args->next_pid];
+	if ($tgid != 0) {
+		$queued = @queued[args->next_pid];
+		if ($queued != 0) {
+			@runq_us[$tgid] = hist((nsecs - $queued) / 1000);
+			delete(@queued[args->next_pid]);
+		}
+		$blocked = @blocked[args->next_pid];
+		if ($blocked != 0) {
+			@offcpu_us[$tgid] = sum((nsecs - $blocked) / 1000);
+			delete(@blocked[args->next_pid]);
+		}
+	}
+}
+
+tracepoint:sched:sched_wakeup,
+tracepoint:sched:sched_wakeup_new
+{
+	if (@tgid[args->pid] != 0) {
+		@queued[args->pid] = nsecs;
+	}
+}
+
+interval:s:%d
+{
+	clear(@tgid);
+	clear(@queued);
+	clear(@blocked);
+	exit();
+}
+`
+
+// SchedLatency is a process's scheduling latency over a trace window.
+type SchedLatency struct {
+	// RunQueue is the microseconds its threads waited to run each time they were woken or preempted
+	RunQueue Histogram
+	// OffCPU is how long its threads were off a CPU, counted when they run again
+	OffCPU time.Duration
+	Window time.Duration
+}
+
+// HistogramBucket counts the values from Low up to but not including High.
+type HistogramBucket struct {
+	Low   uint64
+	High  uint64
+	Count uint64
+}
+
+// Histogram is a bpftrace log2 histogram, its buckets in ascending order.
+type Histogram []HistogramBucket
+
+// Count returns the number of values in the histogram.
+func (h Histogram) Count() uint64 {
+	var count uint64
+	for _, b := range h {
+		count += b.Count
+	}
+	return count
+}
+
+// Percentile returns the upper bound of the bucket the p-th percentile, 0 to 100, falls in. The buckets double in
+// size, so it's at most twice the value.
+func (h Histogram) Percentile(p float64) uint64 {
+	total := h.Count()
+	if total == 0 {
+		return 0
+	}
+	rank := uint64(float64(total)*p/100 + 0.5)
+	var count uint64
+	for _, b := range h {
+		count += b.Count
+		if count >= max(rank, 1) {
+			return b.High
+		}
+	}
+	return h[len(h)-1].High
+}
+
+// Max returns the upper bound of the highest bucket with values.
+func (h Histogram) Max() uint64 {
+	for i := len(h) - 1; i >= 0; i-- {
+		if h[i].Count > 0 {
+			return h[i].High
+		}
+	}
+	return 0
+}
+
+// SchedTracingAvailable returns why the processes' scheduling can't be traced with bpftrace, or nil if it can.
+func SchedTracingAvailable() error {
+	if _, err := utils.Stat(btfPath); err != nil {
+		return utils.NewError(utils.ErrBackendUnavailable, "the kernel has no BTF, it needs CONFIG_DEBUG_INFO_BTF")
+	}
+	if _, err := exec.LookPath("bpftrace"); err != nil {
+		return utils.WithKind(utils.ErrBackendUnavailable, fmt.Errorf("bpftrace isn't installed: %w", err))
+	}
+	return nil
+}
+
+// SchedTracer traces the scheduling latency of the processes it's asked to watch with bpftrace. One tracer is shared
+// by every component, so a single bpftrace traces all of their processes.
+type SchedTracer struct {
+	mu sync.Mutex
+	// watched is when each process was last asked for, a process that isn't for schedWatchExpiry stops being traced
+	watched map[int32]time.Time
+	results map[int32]SchedLatency
+	err     error
+	task    *utils.Task
+}
+
+// schedWatchExpiry is how long a process is traced after it was last asked for.
+const schedWatchExpiry = 3 * SchedTraceWindow
+
+var schedTracer = &SchedTracer{watched: make(map[int32]time.Time), results: make(map[int32]SchedLatency)}
+
+// DefaultSchedTracer returns the tracer shared by every component.
+func DefaultSchedTracer() *SchedTracer {
+	return schedTracer
+}
+
+// Latency returns the scheduling latency of the process over the last window it was traced in, and makes sure it's
+// traced in the next one. It's false until the process has been traced in a window, with the error of the last run of
+// bpftrace if it failed.
+func (t *SchedTracer) Latency(pid int32) (SchedLatency, bool, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.watched[pid] = time.Now()
+	if t.task == nil {
+		t.task = utils.Schedule(SchedTraceWindow, t.trace)
+	}
+	latency, ok := t.results[pid]
+	return latency, ok, t.err
+}
+
+// trace runs bpftrace for a window over the processes being watched, the scheduler runs it every window and skips a
+// run while the previous one is still tracing.
+func (t *SchedTracer) trace(ctx context.Context) {
+	t.mu.Lock()
+	var pids []int32
+	for pid, at := range t.watched {
+		if time.Since(at) > schedWatchExpiry {
+			delete(t.watched, pid)
+			delete(t.results, pid)
+			continue
+		}
+		pids = append(pids, pid)
+	}
+	t.mu.Unlock()
+	if len(pids) == 0 {
+		return
+	}
+	results, err := traceSchedLatency(ctx, pids, SchedTraceWindow)
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.err = err
+	if err != nil {
+		// The results of earlier windows aren't reported as the latest
+		clear(t.results)
+		return
+	}
+	for _, pid := range pids {
+		// A process that didn't run in the window has none rather than the previous window's
+		latency := results[pid]
+		latency.Window = SchedTraceWindow
+		t.results[pid] = latency
+	}
+}
+
+// traceSchedLatency traces the processes with bpftrace for window. It holds one of the command runner's workers for the
+// window, which is why there's one tracer for every component.
+func traceSchedLatency(ctx context.Context, pids []int32, window time.Duration) (map[int32]SchedLatency, error) {
+	out, err := utils.RunCommand(ctx, utils.Command{
+		Name:    "bpftrace",
+		Args:    []string{"-e", schedTraceProgram(pids, window)},
+		Timeout: window + bpftraceStartup,
+	})
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && len(exitErr.Stderr) > 0 {
+			err = fmt.Errorf("%w: %s", err, strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		if msg := err.Error(); strings.Contains(msg, "root user") || strings.Contains(msg, "Operation not permitted") {
+			return nil, utils.WithKind(utils.ErrPermissionDenied, fmt.Errorf("bpftrace failed, it needs root or CAP_BPF and CAP_PERFMON: %w", err))
+		}
+		return nil, fmt.Errorf("bpftrace failed: %w", err)
+	}
+	return parseSchedTrace(string(out), window)
+}
+
+// schedTraceProgram returns the bpftrace program tracing the processes for window.
+func schedTraceProgram(pids []int32, window time.Duration) string {
+	sorted := append([]int32(nil), pids...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	conditions := make([]string, 0, len(sorted))
+	for _, pid := range sorted {
+		conditions = append(conditions, fmt.Sprintf("curtask->tgid == %d", pid))
+	}
+	return fmt.Sprintf(schedTraceScript, strings.Join(conditions, " || "), max(int(window.Seconds()), 1))
+}
+
+var (
+	// mapLine is a map entry bpftrace prints, e.g. @offcpu_us[1234]: 5678, histograms have no value on the line
+	mapLine = regexp.MustCompile(`^@(\w+)\[(\d+)\]:\s*(\d*)$`)
+	// bucketLine is a histogram bucket, e.g. [4, 8)   20 |@@@   |, the buckets below 2 are a single value, e.g. [1]
+	bucketLine = regexp.MustCompile(`^\[(\w+)(?:, (\w+))?[\])]\s+(\d+)`)
+)
+
+// parseSchedTrace parses what bpftrace prints of the maps on exit.
+func parseSchedTrace(out string, window time.Duration) (map[int32]SchedLatency, error) {
+	ret := make(map[int32]SchedLatency)
+	var hist *Histogram
+	var histPid int32
+	flush := func() {
+		if hist != nil {
+			latency := ret[histPid]
+			latency.RunQueue = *hist
+			ret[histPid] = latency
+			hist = nil
+		}
+	}
+	for _, line := range strings.Split(out, "\n") {
+		line = strings.TrimSpace(line)
+		if m := mapLine.FindStringSubmatch(line); m != nil {
+			flush()
+			pid, err := strconv.ParseInt(m[2], 10, 32)
+			if err != nil {
+				return nil, err
+			}
+			latency := ret[int32(pid)]
+			latency.Window = window
+			switch m[1] {
+			case "runq_us":
+				hist, histPid = &Histogram{}, int32(pid)
+			case "offcpu_us":
+				us, err := strconv.ParseUint(m[3], 10, 64)
+				if err != nil {
+					return nil, fmt.Errorf("unexpected line %q", line)
+				}
+				latency.OffCPU = time.Duration(us) * time.Microsecond
+			}
+			ret[int32(pid)] = latency
+			continue
+		}
+		if hist == nil {
+			continue
+		}
+		m := bucketLine.FindStringSubmatch(line)
+		if m == nil {
+			flush()
+			continue
+		}
+		low, err := parseHistogramBound(m[1])
+		if err != nil {
+			return nil, err
+		}
+		high := low + 1
+		if m[2] != "" {
+			if high, err = parseHistogramBound(m[2]); err != nil {
+				return nil, err
+			}
+		}
+		count, err := strconv.ParseUint(m[3], 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		*hist = append(*hist, HistogramBucket{Low: low, High: high, Count: count})
+	}
+	flush()
+	return ret, nil
+}
+
+// parseHistogramBound parses a histogram bucket's bound, bpftrace prints powers of 1024 with a suffix, e.g. 16K.
+func parseHistogramBound(s string) (uint64, error) {
+	multiplier := uint64(1)
+	for i, suffix := range []string{"K", "M", "G", "T"} {
+		if number, ok := strings.CutSuffix(s, suffix); ok {
+			s, multiplier = number, 1<<(10*(i+1))
+			break
+		}
+	}
+	value, err := strconv.ParseUint(s, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("unexpected histogram bound %q", s)
+	}
+	return value * multiplier, nil
+}
@@ -0,0 +1,79 @@
+package linux
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+func TestParseSchedTrace(t *testing.T) {
+	b, err := os.ReadFile("testdata/bpftrace_schedlat.txt")
+	require.NoError(t, err)
+	results, err := parseSchedTrace(string(b), 10*time.Second)
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+
+	latency := results[812]
+	assert.Equal(t, 8412650*time.Microsecond, latency.OffCPU)
+	assert.Equal(t, 10*time.Second, latency.Window)
+	require.Len(t, latency.RunQueue, 8)
+	assert.Equal(t, HistogramBucket{Low: 0, High: 1, Count: 42}, latency.RunQueue[0])
+	assert.Equal(t, HistogramBucket{Low: 2, High: 4, Count: 630}, latency.RunQueue[2])
+	assert.Equal(t, HistogramBucket{Low: 16384, High: 32768, Count: 1}, latency.RunQueue[7])
+	assert.Equal(t, uint64(1000), latency.RunQueue.Count())
+	assert.Equal(t, uint64(4), latency.RunQueue.Percentile(50))
+	assert.Equal(t, uint64(8), latency.RunQueue.Percentile(99))
+	assert.Equal(t, uint64(32768), latency.RunQueue.Max())
+
+	assert.Equal(t, uint64(3), results[1377].RunQueue.Count())
+	assert.Equal(t, 950*time.Millisecond, results[1377].OffCPU)
+}
+
+func TestHistogramEmpty(t *testing.T) {
+	var h Histogram
+	assert.Equal(t, uint64(0), h.Percentile(99))
+	assert.Equal(t, uint64(0), h.Max())
+}
+
+func TestSchedTraceProgram(t *testing.T) {
+	program := schedTraceProgram([]int32{1377, 812}, 10*time.Second)
+	assert.Contains(t, program, "if (curtask->tgid == 812 || curtask->tgid == 1377) {")
+	assert.Contains(t, program, "interval:s:10\n")
+}
+
+func TestTraceSchedLatency(t *testing.T) {
+	t.Cleanup(func() { utils.SetCommandRunner(utils.DefaultCommandRunner()) })
+	b, err := os.ReadFile("testdata/bpftrace_schedlat.txt")
+	require.NoError(t, err)
+	utils.SetCommandRunner(utils.CommandRunnerFunc(func(ctx context.Context, cmd utils.Command) ([]byte, error) {
+		assert.Equal(t, "bpftrace", cmd.Name)
+		assert.Equal(t, 10*time.Second+bpftraceStartup, cmd.Timeout)
+		return b, nil
+	}))
+	results, err := traceSchedLatency(context.Background(), []int32{812}, 10*time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1000), results[812].RunQueue.Count())
+
+	// Not running as root is reported as a permission error with what bpftrace said
+	utils.SetCommandRunner(utils.CommandRunnerFunc(func(ctx context.Context, cmd utils.Command) ([]byte, error) {
+		return nil, &exec.ExitError{Stderr: []byte("ERROR: bpftrace currently only supports running as the root user.\n")}
+	}))
+	_, err = traceSchedLatency(context.Background(), []int32{812}, 10*time.Second)
+	assert.Equal(t, utils.ErrorKindPermissionDenied, utils.ErrorKind(err))
+	assert.True(t, strings.Contains(err.Error(), "root user"), err.Error())
+
+	utils.SetCommandRunner(utils.CommandRunnerFunc(func(ctx context.Context, cmd utils.Command) ([]byte, error) {
+		return nil, errors.New("exit status 1")
+	}))
+	_, err = traceSchedLatency(context.Background(), []int32{812}, 10*time.Second)
+	assert.ErrorContains(t, err, "bpftrace failed")
+}
@@ -0,0 +1,64 @@
+package linux
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+// SchedStat is the time a process's threads spent on a CPU and waiting on a run queue for one, and how many times they
+// were scheduled, from /proc/<pid>/task/<tid>/schedstat.
+type SchedStat struct {
+	Running    time.Duration
+	Waiting    time.Duration
+	Timeslices uint64
+}
+
+// ReadSchedStat returns the scheduler stats of the process's threads added up. The threads that exited since the
+// previous read are no longer counted, so the totals can go down.
+func ReadSchedStat(ctx context.Context, pid int32) (SchedStat, error) {
+	tasks, err := utils.Glob(fmt.Sprintf("/proc/%d/task/*/schedstat", pid))
+	if err != nil {
+		return SchedStat{}, err
+	}
+	if len(tasks) == 0 {
+		return SchedStat{}, fmt.Errorf("no schedstat for process %d, the kernel needs CONFIG_SCHED_INFO", pid)
+	}
+	var ret SchedStat
+	for _, task := range tasks {
+		data, err := utils.ReadFileWithContext(ctx, task)
+		if err != nil {
+			// The thread exited since the directory was listed
+			continue
+		}
+		stat, err := parseSchedStat(data)
+		if err != nil {
+			return SchedStat{}, fmt.Errorf("%s: %w", task, err)
+		}
+		ret.Running += stat.Running
+		ret.Waiting += stat.Waiting
+		ret.Timeslices += stat.Timeslices
+	}
+	return ret, nil
+}
+
+// parseSchedStat parses a schedstat file, the nanoseconds on a CPU, the nanoseconds waiting and the timeslices.
+func parseSchedStat(data string) (SchedStat, error) {
+	fields := strings.Fields(data)
+	if len(fields) != 3 {
+		return SchedStat{}, fmt.Errorf("unexpected schedstat %q", strings.TrimSpace(data))
+	}
+	var values [3]uint64
+	for i, field := range fields {
+		value, err := strconv.ParseUint(field, 10, 64)
+		if err != nil {
+			return SchedStat{}, err
+		}
+		values[i] = value
+	}
+	return SchedStat{Running: time.Duration(values[0]), Waiting: time.Duration(values[1]), Timeslices: values[2]}, nil
+}
@@ -0,0 +1,39 @@
+package linux
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+func TestReadSchedStat(t *testing.T) {
+	t.Cleanup(func() { utils.SetFS(utils.OSFS{}) })
+	root := t.TempDir()
+	for tid, stat := range map[string]string{"812": "5000000 200000 40\n", "815": "1000000 50000 10\n"} {
+		dir := filepath.Join(root, "proc/812/task", tid)
+		require.NoError(t, os.MkdirAll(dir, 0o755))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "schedstat"), []byte(stat), 0o644))
+	}
+	utils.SetFS(utils.RootFS{Root: root})
+
+	stat, err := ReadSchedStat(context.Background(), 812)
+	require.NoError(t, err)
+	assert.Equal(t, SchedStat{Running: 6 * time.Millisecond, Waiting: 250 * time.Microsecond, Timeslices: 50}, stat)
+
+	_, err = ReadSchedStat(context.Background(), 999)
+	assert.Error(t, err)
+}
+
+func TestParseSchedStatInvalid(t *testing.T) {
+	_, err := parseSchedStat("1 2")
+	assert.Error(t, err)
+	_, err = parseSchedStat("1 2 x")
+	assert.Error(t, err)
+}
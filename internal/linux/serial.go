@@ -0,0 +1,100 @@
+package linux
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+const TTYClassRoot = "/sys/class/tty"
+
+// SerialDevice is a serial port, either a UART on the board or a USB serial adapter.
+type SerialDevice struct {
+	// Name is the tty name, e.g. ttyUSB0
+	Name   string
+	Device string
+	// Driver is the serial driver, e.g. ftdi_sio, cp210x or cdc_acm for USB adapters, pl011 for a Raspberry Pi UART
+	Driver string
+	// USB is the adapter's USB device, nil for a UART on the board. Its Drivers aren't read.
+	USB *USBDevice
+}
+
+// ReadSerialDevices reads the serial ports under root, normally TTYClassRoot, with their nodes under devRoot. Virtual
+// terminals and ptys have no parent device and are skipped, as are the legacy 8250 ports the kernel always creates
+// whether or not the hardware exists.
+func ReadSerialDevices(ctx context.Context, root, devRoot string) ([]SerialDevice, error) {
+	entries, err := utils.ReadDir(root)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	devices := make([]SerialDevice, 0)
+	for _, entry := range entries {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		parent, err := filepath.EvalSymlinks(filepath.Join(root, entry.Name(), "device"))
+		if err != nil {
+			continue
+		}
+		driver := ""
+		if link, err := utils.Readlink(filepath.Join(parent, "driver")); err == nil {
+			driver = filepath.Base(link)
+		}
+		if driver == "serial8250" {
+			continue
+		}
+		devices = append(devices, SerialDevice{
+			Name:   entry.Name(),
+			Device: filepath.Join(devRoot, entry.Name()),
+			Driver: driver,
+			USB:    readUSBAncestor(ctx, parent),
+		})
+	}
+	sort.Slice(devices, func(i, j int) bool { return devices[i].Name < devices[j].Name })
+	return devices, nil
+}
+
+// readUSBAncestor finds the USB device a device belongs to by walking up its sysfs path to the directory with the
+// USB IDs, e.g. from 1-1.2:1.0/ttyUSB0 to 1-1.2.
+func readUSBAncestor(ctx context.Context, path string) *USBDevice {
+	for dir := path; dir != filepath.Dir(dir); dir = filepath.Dir(dir) {
+		vendorID, err := utils.ReadFileWithContext(ctx, filepath.Join(dir, "idVendor"))
+		if err != nil {
+			continue
+		}
+		read := func(attr string) string {
+			value, _ := utils.ReadFileWithContext(ctx, filepath.Join(dir, attr))
+			return value
+		}
+		return &USBDevice{
+			Path:         filepath.Base(dir),
+			VendorID:     vendorID,
+			ProductID:    read("idProduct"),
+			Manufacturer: read("manufacturer"),
+			Product:      read("product"),
+			Serial:       read("serial"),
+		}
+	}
+	return nil
+}
+
+// ListSPIDevices returns the spidev nodes under devRoot, e.g. spidev0.0 for chip select 0 of SPI bus 0. Only SPI
+// devices bound to the spidev driver have a node, those with a kernel driver don't.
+func ListSPIDevices(devRoot string) ([]string, error) {
+	paths, err := utils.Glob(filepath.Join(devRoot, "spidev*"))
+	if err != nil {
+		return nil, err
+	}
+	devices := make([]string, 0, len(paths))
+	for _, path := range paths {
+		devices = append(devices, filepath.Base(path))
+	}
+	sort.Strings(devices)
+	return devices, nil
+}
@@ -0,0 +1,81 @@
+package linux
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadSerialDevices(t *testing.T) {
+	tree := newSysfsTree(t)
+	usb := "devices/usb1/1-1"
+	for _, dir := range []string{
+		"class/tty",
+		"dev",
+		"drivers/ftdi_sio",
+		"drivers/cdc_acm",
+		"drivers/pl011",
+		"drivers/serial8250",
+		usb + "/1-1.2/1-1.2:1.0/ttyUSB0",
+		usb + "/1-1.3/1-1.3:1.0",
+		"devices/fe201000.serial",
+		"devices/serial8250",
+		"devices/virtual/tty/tty0",
+	} {
+		tree.mkdir(dir)
+	}
+	tree.attrs(usb+"/1-1.2", map[string]string{"idVendor": "0403", "idProduct": "6001", "product": "FT232R USB UART", "serial": "A10KZ3PJ"})
+	tree.attrs(usb+"/1-1.3", map[string]string{"idVendor": "1546", "idProduct": "01a8", "product": "u-blox GNSS receiver"})
+	tree.link("drivers/ftdi_sio", usb+"/1-1.2/1-1.2:1.0/ttyUSB0/driver")
+	tree.link("drivers/cdc_acm", usb+"/1-1.3/1-1.3:1.0/driver")
+	tree.link("drivers/pl011", "devices/fe201000.serial/driver")
+	tree.link("drivers/serial8250", "devices/serial8250/driver")
+	for tty, device := range map[string]string{
+		"ttyUSB0": usb + "/1-1.2/1-1.2:1.0/ttyUSB0",
+		"ttyACM0": usb + "/1-1.3/1-1.3:1.0",
+		"ttyAMA0": "devices/fe201000.serial",
+		"ttyS0":   "devices/serial8250",
+		"tty0":    "",
+	} {
+		tree.mkdir("class/tty/" + tty)
+		if device != "" {
+			tree.link(device, "class/tty/"+tty+"/device")
+		}
+	}
+
+	devices, err := ReadSerialDevices(context.Background(), tree.path("class/tty"), "/dev")
+	require.NoError(t, err)
+	assert.Equal(t, []SerialDevice{
+		{
+			Name:   "ttyACM0",
+			Device: "/dev/ttyACM0",
+			Driver: "cdc_acm",
+			USB:    &USBDevice{Path: "1-1.3", VendorID: "1546", ProductID: "01a8", Product: "u-blox GNSS receiver"},
+		},
+		{Name: "ttyAMA0", Device: "/dev/ttyAMA0", Driver: "pl011"},
+		{
+			Name:   "ttyUSB0",
+			Device: "/dev/ttyUSB0",
+			Driver: "ftdi_sio",
+			USB:    &USBDevice{Path: "1-1.2", VendorID: "0403", ProductID: "6001", Product: "FT232R USB UART", Serial: "A10KZ3PJ"},
+		},
+	}, devices)
+
+	devices, err = ReadSerialDevices(context.Background(), tree.path("missing"), "/dev")
+	require.NoError(t, err)
+	assert.Empty(t, devices)
+}
+
+func TestListSPIDevices(t *testing.T) {
+	root := t.TempDir()
+	for _, name := range []string{"spidev0.1", "spidev0.0", "spidev1.0", "ttyAMA0"} {
+		require.NoError(t, os.WriteFile(filepath.Join(root, name), nil, 0o644))
+	}
+	devices, err := ListSPIDevices(root)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"spidev0.0", "spidev0.1", "spidev1.0"}, devices)
+}
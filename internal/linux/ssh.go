@@ -0,0 +1,42 @@
+package linux
+
+import "regexp"
+
+type SSHAuthResult string
+
+const (
+	SSHAuthAccepted    SSHAuthResult = "accepted"
+	SSHAuthFailed      SSHAuthResult = "failed"
+	SSHAuthInvalidUser SSHAuthResult = "invalid_user"
+)
+
+// SSHAuthEvent is an authentication attempt logged by sshd.
+type SSHAuthEvent struct {
+	Result  SSHAuthResult
+	Method  string
+	User    string
+	Address string
+}
+
+var (
+	// e.g. "Accepted publickey for pi from 192.168.1.10 port 51234 ssh2: ED25519 SHA256:..."
+	sshAcceptedRegex = regexp.MustCompile(`^Accepted (\S+) for (\S+) from (\S+) port \d+`)
+	// e.g. "Failed password for invalid user admin from 10.0.0.5 port 40022 ssh2"
+	sshFailedRegex = regexp.MustCompile(`^Failed (\S+) for (?:invalid user )?(\S*) from (\S+) port \d+`)
+	// e.g. "Invalid user admin from 10.0.0.5 port 40022", logged before any authentication method is tried
+	sshInvalidUserRegex = regexp.MustCompile(`^Invalid user (\S*) from (\S+)`)
+)
+
+// ParseSSHAuthEvent returns the authentication attempt if the sshd message describes one.
+func ParseSSHAuthEvent(message string) (*SSHAuthEvent, bool) {
+	if matches := sshAcceptedRegex.FindStringSubmatch(message); matches != nil {
+		return &SSHAuthEvent{Result: SSHAuthAccepted, Method: matches[1], User: matches[2], Address: matches[3]}, true
+	}
+	if matches := sshFailedRegex.FindStringSubmatch(message); matches != nil {
+		return &SSHAuthEvent{Result: SSHAuthFailed, Method: matches[1], User: matches[2], Address: matches[3]}, true
+	}
+	if matches := sshInvalidUserRegex.FindStringSubmatch(message); matches != nil {
+		return &SSHAuthEvent{Result: SSHAuthInvalidUser, User: matches[1], Address: matches[2]}, true
+	}
+	return nil, false
+}
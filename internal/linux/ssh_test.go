@@ -0,0 +1,34 @@
+package linux
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSSHAuthEvent(t *testing.T) {
+	tests := []struct {
+		name     string
+		message  string
+		expected *SSHAuthEvent
+	}{
+		{"Accepted", "Accepted publickey for pi from 192.168.1.10 port 51234 ssh2: ED25519 SHA256:abc", &SSHAuthEvent{Result: SSHAuthAccepted, Method: "publickey", User: "pi", Address: "192.168.1.10"}},
+		{"Failed", "Failed password for root from 10.0.0.5 port 40022 ssh2", &SSHAuthEvent{Result: SSHAuthFailed, Method: "password", User: "root", Address: "10.0.0.5"}},
+		{"FailedInvalidUser", "Failed password for invalid user admin from 10.0.0.5 port 40022 ssh2", &SSHAuthEvent{Result: SSHAuthFailed, Method: "password", User: "admin", Address: "10.0.0.5"}},
+		{"FailedIPv6", "Failed publickey for ubuntu from fe80::1%eth0 port 22 ssh2", &SSHAuthEvent{Result: SSHAuthFailed, Method: "publickey", User: "ubuntu", Address: "fe80::1%eth0"}},
+		{"InvalidUser", "Invalid user oracle from 203.0.113.7 port 55555", &SSHAuthEvent{Result: SSHAuthInvalidUser, User: "oracle", Address: "203.0.113.7"}},
+		{"Unrelated", "Connection closed by 10.0.0.5 port 40022 [preauth]", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event, ok := ParseSSHAuthEvent(tt.message)
+			if tt.expected == nil {
+				assert.False(t, ok)
+				return
+			}
+			require.True(t, ok)
+			assert.Equal(t, tt.expected, event)
+		})
+	}
+}
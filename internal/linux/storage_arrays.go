@@ -0,0 +1,228 @@
+package linux
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+const btrfsSysfsPath = "/sys/fs/btrfs"
+
+// MDArray is a software RAID array from /proc/mdstat.
+type MDArray struct {
+	Name  string
+	State string
+	Level string
+	// DevicesTotal and DevicesActive come from the "[2/1]" status, they are equal for a healthy array
+	DevicesTotal  int
+	DevicesActive int
+	FailedDevices []string
+	// SyncAction is resync, recovery, check, reshape or empty if the array is idle
+	SyncAction       string
+	SyncProgress     float64
+	SyncDelayed      bool
+	SyncFinishMinute float64
+}
+
+// Degraded returns true when the array is missing members.
+func (a *MDArray) Degraded() bool {
+	return a.DevicesActive < a.DevicesTotal || len(a.FailedDevices) > 0
+}
+
+var (
+	mdStatusRegex = regexp.MustCompile(`\[(\d+)/(\d+)\]`)
+	mdSyncRegex   = regexp.MustCompile(`(resync|recovery|check|reshape|repair)\s*=\s*([\d.]+)%`)
+	mdFinishRegex = regexp.MustCompile(`finish=([\d.]+)min`)
+)
+
+// ReadMDArrays returns the software RAID arrays, no arrays are returned when the md driver isn't loaded.
+func ReadMDArrays(ctx context.Context) ([]*MDArray, error) {
+	data, err := utils.ReadFileWithContext(ctx, "/proc/mdstat")
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return parseMdstat(data)
+}
+
+// parseMdstat parses /proc/mdstat, which looks like this:
+//
+//	md0 : active raid1 sdb1[1](F) sda1[0]
+//	      976630464 blocks super 1.2 [2/1] [U_]
+//	      [==>..................]  recovery = 12.6% (123456/976630464) finish=81.2min speed=159424K/sec
+func parseMdstat(data string) ([]*MDArray, error) {
+	arrays := make([]*MDArray, 0)
+	var current *MDArray
+	for _, line := range strings.Split(data, "\n") {
+		if strings.HasPrefix(line, "md") {
+			name, rest, found := strings.Cut(line, " : ")
+			if !found {
+				return nil, fmt.Errorf("unexpected mdstat line %q", line)
+			}
+			fields := strings.Fields(rest)
+			if len(fields) == 0 {
+				return nil, fmt.Errorf("unexpected mdstat line %q", line)
+			}
+			current = &MDArray{Name: strings.TrimSpace(name), State: fields[0], FailedDevices: make([]string, 0)}
+			members := fields[1:]
+			// Inactive arrays don't list a level
+			if len(members) > 0 && !strings.Contains(members[0], "[") {
+				current.Level = members[0]
+				members = members[1:]
+			}
+			for _, member := range members {
+				if strings.HasSuffix(member, "(F)") {
+					device, _, _ := strings.Cut(member, "[")
+					current.FailedDevices = append(current.FailedDevices, device)
+				}
+			}
+			arrays = append(arrays, current)
+			continue
+		}
+		if current == nil {
+			continue
+		}
+		if match := mdStatusRegex.FindStringSubmatch(line); match != nil && current.DevicesTotal == 0 {
+			current.DevicesTotal, _ = strconv.Atoi(match[1])
+			current.DevicesActive, _ = strconv.Atoi(match[2])
+		}
+		if match := mdSyncRegex.FindStringSubmatch(line); match != nil {
+			current.SyncAction = match[1]
+			current.SyncProgress, _ = strconv.ParseFloat(match[2], 64)
+			if finish := mdFinishRegex.FindStringSubmatch(line); finish != nil {
+				current.SyncFinishMinute, _ = strconv.ParseFloat(finish[1], 64)
+			}
+		}
+		if strings.Contains(line, "resync=DELAYED") || strings.Contains(line, "resync=PENDING") {
+			current.SyncAction = "resync"
+			current.SyncDelayed = true
+		}
+		if strings.TrimSpace(line) == "" {
+			current = nil
+		}
+	}
+	return arrays, nil
+}
+
+// BtrfsFilesystem is a mounted btrfs filesystem from /sys/fs/btrfs.
+type BtrfsFilesystem struct {
+	UUID           string
+	Label          string
+	Devices        int
+	MissingDevices int
+	// Errors holds the sum of every device's error counters, e.g. write_errs and corruption_errs
+	Errors map[string]uint64
+}
+
+// ReadBtrfsFilesystems returns every mounted btrfs filesystem.
+func ReadBtrfsFilesystems(ctx context.Context) ([]*BtrfsFilesystem, error) {
+	return readBtrfsFilesystems(ctx, btrfsSysfsPath)
+}
+
+func readBtrfsFilesystems(ctx context.Context, root string) ([]*BtrfsFilesystem, error) {
+	devinfos, err := utils.Glob(filepath.Join(root, "*", "devinfo"))
+	if err != nil {
+		return nil, err
+	}
+	filesystems := make([]*BtrfsFilesystem, 0, len(devinfos))
+	for _, devinfo := range devinfos {
+		fsPath := filepath.Dir(devinfo)
+		fs := &BtrfsFilesystem{UUID: filepath.Base(fsPath), Errors: make(map[string]uint64)}
+		// The label file is empty for unlabeled filesystems
+		fs.Label, _ = utils.ReadFileWithContext(ctx, filepath.Join(fsPath, "label"))
+		devices, err := utils.ReadDir(devinfo)
+		if err != nil {
+			return nil, err
+		}
+		for _, device := range devices {
+			fs.Devices++
+			devicePath := filepath.Join(devinfo, device.Name())
+			if missing, err := utils.ReadBoolFromFileWithContext(ctx, filepath.Join(devicePath, "missing")); err == nil && missing {
+				fs.MissingDevices++
+			}
+			// error_stats was added in 5.14, older kernels only have the counters via the btrfs tool
+			data, err := utils.ReadFileWithContext(ctx, filepath.Join(devicePath, "error_stats"))
+			if err != nil {
+				continue
+			}
+			stats, err := parseFlatKeyedFile(data)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse error_stats of %s: %w", fs.UUID, err)
+			}
+			for k, v := range stats {
+				fs.Errors[k] += v
+			}
+		}
+		filesystems = append(filesystems, fs)
+	}
+	return filesystems, nil
+}
+
+// ZFSPool is an imported ZFS pool as reported by zpool list.
+type ZFSPool struct {
+	Name          string
+	Health        string
+	Size          uint64
+	Allocated     uint64
+	Free          uint64
+	Fragmentation uint64
+	Capacity      uint64
+}
+
+// ReadZFSPools returns the imported ZFS pools, no pools are returned when ZFS isn't installed.
+func ReadZFSPools(ctx context.Context) ([]*ZFSPool, error) {
+	if _, err := exec.LookPath("zpool"); err != nil {
+		return nil, nil
+	}
+	out, err := utils.Output(ctx, "zpool", "list", "-Hp", "-o", "name,health,size,alloc,free,frag,cap")
+	if err != nil {
+		return nil, err
+	}
+	return parseZpoolList(string(out))
+}
+
+// parseZpoolList parses the tab separated, script friendly output of zpool list -Hp.
+func parseZpoolList(out string) ([]*ZFSPool, error) {
+	pools := make([]*ZFSPool, 0)
+	for _, line := range strings.Split(strings.TrimSpace(out), "\n") {
+		if line == "" {
+			continue
+		}
+		fields := strings.Split(line, "\t")
+		if len(fields) != 7 {
+			return nil, fmt.Errorf("unexpected zpool list line %q", line)
+		}
+		values := make([]uint64, 5)
+		for i, field := range fields[2:] {
+			// frag is "-" for pools that can't report it
+			if field == "-" {
+				continue
+			}
+			value, err := strconv.ParseUint(strings.TrimSuffix(field, "%"), 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse zpool list for %s: %w", fields[0], err)
+			}
+			values[i] = value
+		}
+		pools = append(pools, &ZFSPool{
+			Name:          fields[0],
+			Health:        fields[1],
+			Size:          values[0],
+			Allocated:     values[1],
+			Free:          values[2],
+			Fragmentation: values[3],
+			Capacity:      values[4],
+		})
+	}
+	return pools, nil
+}
@@ -0,0 +1,69 @@
+package linux
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMdstat(t *testing.T) {
+	b, err := os.ReadFile("testdata/mdstat.txt")
+	require.NoError(t, err)
+	arrays, err := parseMdstat(string(b))
+	require.NoError(t, err)
+	require.Len(t, arrays, 4)
+
+	degraded := arrays[0]
+	assert.Equal(t, "md0", degraded.Name)
+	assert.Equal(t, "active", degraded.State)
+	assert.Equal(t, "raid1", degraded.Level)
+	assert.Equal(t, 2, degraded.DevicesTotal)
+	assert.Equal(t, 1, degraded.DevicesActive)
+	assert.Equal(t, []string{"sdb1"}, degraded.FailedDevices)
+	assert.True(t, degraded.Degraded())
+	assert.Empty(t, degraded.SyncAction)
+
+	resyncing := arrays[1]
+	assert.False(t, resyncing.Degraded())
+	assert.Equal(t, "resync", resyncing.SyncAction)
+	assert.Equal(t, 12.6, resyncing.SyncProgress)
+	assert.Equal(t, 81.2, resyncing.SyncFinishMinute)
+
+	delayed := arrays[2]
+	assert.Equal(t, "raid5", delayed.Level)
+	assert.True(t, delayed.SyncDelayed)
+
+	inactive := arrays[3]
+	assert.Equal(t, "md127", inactive.Name)
+	assert.Equal(t, "inactive", inactive.State)
+	assert.Empty(t, inactive.Level)
+}
+
+func TestReadBtrfsFilesystems(t *testing.T) {
+	filesystems, err := readBtrfsFilesystems(context.Background(), "testdata/sys_fs_btrfs")
+	require.NoError(t, err)
+	require.Len(t, filesystems, 1)
+	fs := filesystems[0]
+	assert.Equal(t, "data", fs.Label)
+	assert.Equal(t, 2, fs.Devices)
+	assert.Equal(t, 1, fs.MissingDevices)
+	assert.Equal(t, uint64(8), fs.Errors["write_errs"])
+	assert.Equal(t, uint64(2), fs.Errors["corruption_errs"])
+}
+
+func TestParseZpoolList(t *testing.T) {
+	pools, err := parseZpoolList("tank\tDEGRADED\t1992864825344\t512000000000\t1480864825344\t3\t25\nboot\tONLINE\t1073741824\t104857600\t968884224\t-\t9\n")
+	require.NoError(t, err)
+	require.Len(t, pools, 2)
+	assert.Equal(t, "tank", pools[0].Name)
+	assert.Equal(t, "DEGRADED", pools[0].Health)
+	assert.Equal(t, uint64(512000000000), pools[0].Allocated)
+	assert.Equal(t, uint64(25), pools[0].Capacity)
+	assert.Equal(t, uint64(0), pools[1].Fragmentation)
+
+	_, err = parseZpoolList("tank\tONLINE")
+	assert.Error(t, err)
+}
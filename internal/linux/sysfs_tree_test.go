@@ -0,0 +1,42 @@
+package linux
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// sysfsTree is a fake sysfs tree in a temporary directory, for the tests of USB devices. USB interface names contain a
+// colon, so these trees are built by the tests rather than kept in testdata.
+type sysfsTree struct {
+	t    *testing.T
+	root string
+}
+
+func newSysfsTree(t *testing.T) *sysfsTree {
+	return &sysfsTree{t: t, root: t.TempDir()}
+}
+
+// path returns the absolute path of a path in the tree.
+func (s *sysfsTree) path(path string) string {
+	return filepath.Join(s.root, path)
+}
+
+func (s *sysfsTree) mkdir(path string) {
+	require.NoError(s.t, os.MkdirAll(s.path(path), 0o755))
+}
+
+// attrs creates dir with an attribute file for each of attrs, the values end in a newline like the kernel's.
+func (s *sysfsTree) attrs(dir string, attrs map[string]string) {
+	s.mkdir(dir)
+	for attr, value := range attrs {
+		require.NoError(s.t, os.WriteFile(filepath.Join(s.path(dir), attr), []byte(value+"\n"), 0o644))
+	}
+}
+
+// link creates path as a symlink to target, both in the tree.
+func (s *sysfsTree) link(target, path string) {
+	require.NoError(s.t, os.Symlink(s.path(target), s.path(path)))
+}
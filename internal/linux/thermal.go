@@ -0,0 +1,91 @@
+package linux
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+const ThermalZonesRoot = "/sys/class/thermal"
+
+// ReadThermalZones returns the temperature of every readable thermal zone under root, normally ThermalZonesRoot,
+// keyed by the zone type, e.g. soc-thermal. Zone numbers depend on probe order, the types don't.
+func ReadThermalZones(ctx context.Context, root string) (map[string]float64, error) {
+	zones, err := utils.Glob(filepath.Join(root, "thermal_zone*"))
+	if err != nil {
+		return nil, err
+	}
+	temperatures := make(map[string]float64)
+	for _, zone := range zones {
+		zoneType, err := utils.ReadFileWithContext(ctx, filepath.Join(zone, "type"))
+		if err != nil {
+			continue
+		}
+		// Zones for blocks that are powered off fail to read
+		temp, err := ReadHwmonTemperature(ctx, filepath.Join(zone, "temp"))
+		if err != nil {
+			continue
+		}
+		temperatures[zoneType] = temp
+	}
+	return temperatures, nil
+}
+
+// ThermalTrips are the temperatures in degrees celsius where the kernel acts on a thermal zone, 0 when the zone has no
+// trip point of the type.
+type ThermalTrips struct {
+	// Passive is the lowest passive trip point, where the zone's cooling devices start throttling
+	Passive float64
+	// Critical is the lowest critical trip point, where the kernel shuts down
+	Critical float64
+}
+
+// ReadThermalTrips returns the passive and critical trip points of every thermal zone under root, normally
+// ThermalZonesRoot, keyed by the zone type. Zones with neither are left out, active trip points only turn on fans.
+func ReadThermalTrips(ctx context.Context, root string) (map[string]ThermalTrips, error) {
+	zones, err := utils.Glob(filepath.Join(root, "thermal_zone*"))
+	if err != nil {
+		return nil, err
+	}
+	ret := make(map[string]ThermalTrips)
+	for _, zone := range zones {
+		zoneType, err := utils.ReadFileWithContext(ctx, filepath.Join(zone, "type"))
+		if err != nil {
+			continue
+		}
+		types, err := utils.Glob(filepath.Join(zone, "trip_point_*_type"))
+		if err != nil {
+			return nil, err
+		}
+		var trips ThermalTrips
+		for _, typePath := range types {
+			tripType, err := utils.ReadFileWithContext(ctx, typePath)
+			if err != nil {
+				continue
+			}
+			var trip *float64
+			switch tripType {
+			case "passive":
+				trip = &trips.Passive
+			case "critical":
+				trip = &trips.Critical
+			default:
+				continue
+			}
+			temp, err := ReadHwmonTemperature(ctx, strings.TrimSuffix(typePath, "_type")+"_temp")
+			// Disabled trip points are set far below zero
+			if err != nil || temp <= 0 {
+				continue
+			}
+			if *trip == 0 || temp < *trip {
+				*trip = temp
+			}
+		}
+		if trips.Passive > 0 || trips.Critical > 0 {
+			ret[zoneType] = trips
+		}
+	}
+	return ret, nil
+}
@@ -0,0 +1,39 @@
+package linux
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadThermalZones(t *testing.T) {
+	zones, err := ReadThermalZones(context.Background(), "testdata/sys_class_thermal")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]float64{"cpu-thermal": 62.35, "gpu-thermal": 55.1, "battery": 31}, zones)
+}
+
+func TestReadThermalTrips(t *testing.T) {
+	trips, err := ReadThermalTrips(context.Background(), "testdata/sys_class_thermal")
+	require.NoError(t, err)
+	// The active trip point is left out, the disabled passive one of the GPU and the battery, which has none, too
+	assert.Equal(t, map[string]ThermalTrips{
+		"cpu-thermal": {Passive: 85, Critical: 110},
+		"gpu-thermal": {Critical: 105},
+	}, trips)
+}
+
+func TestReadCoolingDevices(t *testing.T) {
+	devices, err := ReadCoolingDevices(context.Background(), "testdata/sys_class_thermal", "testdata/sys_class_devfreq")
+	require.NoError(t, err)
+	assert.Equal(t, []CoolingDevice{
+		{Name: "cooling_device0", Type: "pwm-fan", Kind: CoolingKindFan, CurState: 2, MaxState: 4},
+		{Name: "cooling_device1", Type: "cpufreq-cpu0", Kind: CoolingKindCpufreq, CurState: 0, MaxState: 14},
+		{Name: "cooling_device2", Type: "cpufreq-cpu4", Kind: CoolingKindCpufreq, CurState: 3, MaxState: 16},
+		{Name: "cooling_device3", Type: "fb000000.gpu", Kind: CoolingKindDevfreq, CurState: 1, MaxState: 4},
+		{Name: "cooling_device4", Type: "Processor", Kind: CoolingKindOther, CurState: 0, MaxState: 3},
+	}, devices)
+	assert.Equal(t, CoolingKindCpufreq, coolingKind("thermal-cpufreq-0", "testdata/sys_class_devfreq"))
+	assert.Equal(t, CoolingKindDevfreq, coolingKind("thermal-devfreq-0", "testdata/sys_class_devfreq"))
+}
@@ -0,0 +1,155 @@
+package linux
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+var ErrNoTimeSyncDaemon = utils.NewError(utils.ErrBackendUnavailable, "no supported time synchronization daemon found")
+
+// TimeSyncStatus is the state of the time synchronization daemon.
+type TimeSyncStatus struct {
+	// Source is the daemon the status came from, chrony, timesyncd or ntpd
+	Source       string
+	Synchronized bool
+	// Offset is the estimated difference between the system clock and the reference, positive when the system clock is fast
+	Offset  time.Duration
+	Stratum int
+	Server  string
+}
+
+// GetTimeSyncStatus queries chrony, systemd-timesyncd or ntpd, whichever is installed.
+func GetTimeSyncStatus(ctx context.Context) (*TimeSyncStatus, error) {
+	if _, err := exec.LookPath("chronyc"); err == nil {
+		out, err := utils.Output(ctx, "chronyc", "-c", "tracking")
+		if err == nil {
+			return parseChronyTracking(string(out))
+		}
+	}
+	if _, err := exec.LookPath("timedatectl"); err == nil {
+		// timesync-status fails when timesyncd isn't the active daemon
+		out, err := utils.Output(ctx, "timedatectl", "timesync-status")
+		if err == nil {
+			return parseTimesyncStatus(string(out))
+		}
+	}
+	if _, err := exec.LookPath("ntpq"); err == nil {
+		out, err := utils.Output(ctx, "ntpq", "-c", "rv")
+		if err == nil {
+			return parseNtpqReadvar(string(out))
+		}
+	}
+	return nil, ErrNoTimeSyncDaemon
+}
+
+// parseChronyTracking parses the CSV output of chronyc -c tracking:
+//
+//	A9FEA97B,169.254.169.123,4,1715000000.123,0.000012345,-0.000001,0.00002,-12.3,0.001,0.02,0.0004,0.0003,64.1,Normal
+func parseChronyTracking(out string) (*TimeSyncStatus, error) {
+	fields := strings.Split(strings.TrimSpace(out), ",")
+	if len(fields) < 14 {
+		return nil, fmt.Errorf("unexpected chronyc tracking output %q", out)
+	}
+	stratum, err := strconv.Atoi(fields[2])
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse stratum: %w", err)
+	}
+	// System time is how far the system clock is from NTP time, positive when it is slow
+	systemTime, err := strconv.ParseFloat(fields[4], 64)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse system time offset: %w", err)
+	}
+	return &TimeSyncStatus{
+		Source: "chrony",
+		// Stratum 0 means chrony hasn't selected a source yet
+		Synchronized: stratum > 0 && fields[13] != "Not synchronised",
+		Offset:       -time.Duration(systemTime * float64(time.Second)),
+		Stratum:      stratum,
+		Server:       fields[1],
+	}, nil
+}
+
+// parseTimesyncStatus parses the output of timedatectl timesync-status, lines of "Key: value".
+func parseTimesyncStatus(out string) (*TimeSyncStatus, error) {
+	status := &TimeSyncStatus{Source: "timesyncd"}
+	found := false
+	for _, line := range strings.Split(out, "\n") {
+		key, value, ok := strings.Cut(strings.TrimSpace(line), ":")
+		if !ok {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		switch key {
+		case "Server":
+			status.Server = value
+		case "Stratum":
+			stratum, err := strconv.Atoi(value)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse stratum: %w", err)
+			}
+			status.Stratum = stratum
+		case "Offset":
+			offset, err := time.ParseDuration(strings.TrimPrefix(value, "+"))
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse offset: %w", err)
+			}
+			status.Offset = offset
+			found = true
+		}
+	}
+	if !found {
+		return nil, errors.New("timesyncd has not received a response yet")
+	}
+	// timesyncd only reports an offset once it got a response, stratum 16 means the server itself is unsynchronized
+	status.Synchronized = status.Stratum > 0 && status.Stratum < 16
+	return status, nil
+}
+
+// parseNtpqReadvar parses the system variables printed by ntpq -c rv, a comma separated list of key=value pairs.
+func parseNtpqReadvar(out string) (*TimeSyncStatus, error) {
+	status := &TimeSyncStatus{Source: "ntpd"}
+	// Quoted values like version may contain spaces, none of the variables used here do
+	fields := strings.FieldsFunc(out, func(r rune) bool { return r == ',' || unicode.IsSpace(r) })
+	for _, field := range fields {
+		key, value, ok := strings.Cut(field, "=")
+		if !ok {
+			continue
+		}
+		value = strings.Trim(value, `"`)
+		switch key {
+		case "stratum":
+			stratum, err := strconv.Atoi(value)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse stratum: %w", err)
+			}
+			status.Stratum = stratum
+		case "offset":
+			// ntpd reports the offset in milliseconds
+			offset, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse offset: %w", err)
+			}
+			status.Offset = time.Duration(offset * float64(time.Millisecond))
+		case "refid":
+			status.Server = value
+		case "status":
+			// The leap indicator 11 in the top bits of the status word means unsynchronized
+			word, err := strconv.ParseUint(strings.TrimPrefix(value, "0x"), 16, 16)
+			if err == nil {
+				status.Synchronized = word>>14 != 3
+			}
+		}
+	}
+	if status.Stratum >= 16 {
+		status.Synchronized = false
+	}
+	return status, nil
+}
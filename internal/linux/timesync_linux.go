@@ -0,0 +1,18 @@
+package linux
+
+import (
+	"syscall"
+	"time"
+)
+
+// staUnsync is set in the kernel clock status while the clock isn't synchronized
+const staUnsync = 0x0040
+
+// KernelClockStatus returns whether the kernel considers the clock synchronized and its maximum error.
+func KernelClockStatus() (synchronized bool, maxError time.Duration, err error) {
+	var buf syscall.Timex
+	if _, err := syscall.Adjtimex(&buf); err != nil {
+		return false, 0, err
+	}
+	return buf.Status&staUnsync == 0, time.Duration(buf.Maxerror) * time.Microsecond, nil
+}
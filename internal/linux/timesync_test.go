@@ -0,0 +1,69 @@
+package linux
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseChronyTracking(t *testing.T) {
+	status, err := parseChronyTracking("A9FEA97B,169.254.169.123,4,1715000000.123,0.000250000,-0.000001,0.00002,-12.3,0.001,0.02,0.0004,0.0003,64.1,Normal\n")
+	require.NoError(t, err)
+	assert.Equal(t, "chrony", status.Source)
+	assert.True(t, status.Synchronized)
+	assert.Equal(t, 4, status.Stratum)
+	assert.Equal(t, "169.254.169.123", status.Server)
+	assert.Equal(t, -250*time.Microsecond, status.Offset)
+
+	status, err = parseChronyTracking("00000000,,0,0.000,0.000000000,0.000000000,0.000000000,0.000,0.000,0.000,1.000000000,1.000000000,0.0,Not synchronised\n")
+	require.NoError(t, err)
+	assert.False(t, status.Synchronized)
+}
+
+func TestParseTimesyncStatus(t *testing.T) {
+	out := `       Server: 162.159.200.1 (time.cloudflare.com)
+Poll interval: 34min 8s (min: 32s; max 34min 8s)
+         Leap: normal
+      Version: 4
+      Stratum: 3
+    Reference: A29FC87B
+    Precision: 1us (-25)
+Root distance: 10.857ms (max: 5s)
+       Offset: -1.282ms
+        Delay: 17.553ms
+       Jitter: 1.130ms
+ Packet count: 42
+    Frequency: -8.123ppm
+`
+	status, err := parseTimesyncStatus(out)
+	require.NoError(t, err)
+	assert.Equal(t, "timesyncd", status.Source)
+	assert.True(t, status.Synchronized)
+	assert.Equal(t, 3, status.Stratum)
+	assert.Equal(t, "162.159.200.1 (time.cloudflare.com)", status.Server)
+	assert.Equal(t, -1282*time.Microsecond, status.Offset)
+
+	_, err = parseTimesyncStatus("       Server: n/a (ntp.ubuntu.com)\nPoll interval: 0 (min: 32s; max 34min 8s)\n Packet count: 0\n")
+	assert.Error(t, err)
+}
+
+func TestParseNtpqReadvar(t *testing.T) {
+	out := `associd=0 status=0615 leap_none, sync_ntp, 1 event, clock_sync,
+version="ntpd 4.2.8p15@1.3728-o", processor="aarch64",
+system="Linux/6.1.21-v8+", leap=00, stratum=2, precision=-23,
+rootdelay=1.567, rootdisp=22.316, refid=192.168.1.1,
+offset=-0.512, frequency=-7.141, sys_jitter=0.180`
+	status, err := parseNtpqReadvar(out)
+	require.NoError(t, err)
+	assert.Equal(t, "ntpd", status.Source)
+	assert.True(t, status.Synchronized)
+	assert.Equal(t, 2, status.Stratum)
+	assert.Equal(t, "192.168.1.1", status.Server)
+	assert.Equal(t, -512*time.Microsecond, status.Offset)
+
+	status, err = parseNtpqReadvar("associd=0 status=c016 leap_alarm, sync_unspec, 1 event, restart,\nstratum=16, offset=0.000")
+	require.NoError(t, err)
+	assert.False(t, status.Synchronized)
+}
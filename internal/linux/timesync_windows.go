@@ -0,0 +1,11 @@
+package linux
+
+import (
+	"time"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+func KernelClockStatus() (synchronized bool, maxError time.Duration, err error) {
+	return false, 0, utils.ErrPlatformNotSupported
+}
@@ -0,0 +1,41 @@
+package linux
+
+import (
+	"strings"
+)
+
+// Uevent is a kernel object event, as broadcast over netlink when devices are added or removed.
+type Uevent struct {
+	Action    string
+	DevPath   string
+	Subsystem string
+	DevName   string
+	DevType   string
+	Env       map[string]string
+}
+
+// parseUevent parses a kernel uevent message, a "<action>@<devpath>" header followed by NUL separated KEY=value pairs.
+// Messages from udev itself start with "libudev" and are ignored.
+func parseUevent(data []byte) (*Uevent, bool) {
+	parts := strings.Split(string(data), "\x00")
+	if len(parts) < 2 || !strings.Contains(parts[0], "@") {
+		return nil, false
+	}
+	event := &Uevent{Env: make(map[string]string)}
+	for _, part := range parts[1:] {
+		key, value, found := strings.Cut(part, "=")
+		if !found {
+			continue
+		}
+		event.Env[key] = value
+	}
+	event.Action = event.Env["ACTION"]
+	event.DevPath = event.Env["DEVPATH"]
+	event.Subsystem = event.Env["SUBSYSTEM"]
+	event.DevName = event.Env["DEVNAME"]
+	event.DevType = event.Env["DEVTYPE"]
+	if event.Action == "" {
+		return nil, false
+	}
+	return event, true
+}
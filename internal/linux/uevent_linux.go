@@ -0,0 +1,53 @@
+package linux
+
+import (
+	"errors"
+	"syscall"
+	"time"
+)
+
+// UeventReader receives kernel uevents from the NETLINK_KOBJECT_UEVENT socket.
+type UeventReader struct {
+	fd  int
+	buf []byte
+}
+
+// OpenUevents subscribes to kernel uevents. Read returns after timeout even if no event arrived, so callers can
+// check for cancellation.
+func OpenUevents(timeout time.Duration) (*UeventReader, error) {
+	fd, err := syscall.Socket(syscall.AF_NETLINK, syscall.SOCK_DGRAM|syscall.SOCK_CLOEXEC, syscall.NETLINK_KOBJECT_UEVENT)
+	if err != nil {
+		return nil, err
+	}
+	// Group 1 carries the kernel events, udev rebroadcasts on group 2 after processing its rules
+	if err := syscall.Bind(fd, &syscall.SockaddrNetlink{Family: syscall.AF_NETLINK, Groups: 1}); err != nil {
+		syscall.Close(fd)
+		return nil, err
+	}
+	tv := syscall.NsecToTimeval(timeout.Nanoseconds())
+	if err := syscall.SetsockoptTimeval(fd, syscall.SOL_SOCKET, syscall.SO_RCVTIMEO, &tv); err != nil {
+		syscall.Close(fd)
+		return nil, err
+	}
+	return &UeventReader{fd: fd, buf: make([]byte, 16384)}, nil
+}
+
+// Read waits for the next event, it returns nil without an error when the timeout expired.
+func (u *UeventReader) Read() (*Uevent, error) {
+	for {
+		n, _, err := syscall.Recvfrom(u.fd, u.buf, 0)
+		if err != nil {
+			if errors.Is(err, syscall.EAGAIN) || errors.Is(err, syscall.EINTR) {
+				return nil, nil
+			}
+			return nil, err
+		}
+		if event, ok := parseUevent(u.buf[:n]); ok {
+			return event, nil
+		}
+	}
+}
+
+func (u *UeventReader) Close() error {
+	return syscall.Close(u.fd)
+}
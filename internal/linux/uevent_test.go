@@ -0,0 +1,67 @@
+package linux
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseUevent(t *testing.T) {
+	message := strings.Join([]string{
+		"add@/devices/platform/scb/fd500000.pcie/pci0000:00/0000:00:00.0/0000:01:00.0/usb2/2-1/2-1:1.0/host0/target0:0:0/0:0:0:0/block/sda",
+		"ACTION=add",
+		"DEVPATH=/devices/platform/scb/fd500000.pcie/pci0000:00/0000:00:00.0/0000:01:00.0/usb2/2-1/2-1:1.0/host0/target0:0:0/0:0:0:0/block/sda",
+		"SUBSYSTEM=block",
+		"MAJOR=8",
+		"MINOR=0",
+		"DEVNAME=sda",
+		"DEVTYPE=disk",
+		"SEQNUM=4242",
+	}, "\x00")
+	event, ok := parseUevent([]byte(message))
+	require.True(t, ok)
+	assert.Equal(t, "add", event.Action)
+	assert.Equal(t, "block", event.Subsystem)
+	assert.Equal(t, "sda", event.DevName)
+	assert.Equal(t, "disk", event.DevType)
+	assert.Equal(t, "4242", event.Env["SEQNUM"])
+
+	_, ok = parseUevent([]byte("libudev\x00garbage"))
+	assert.False(t, ok)
+}
+
+func TestGetRemovableDisks(t *testing.T) {
+	disks, err := getRemovableDisks(context.Background(), "testdata/sys_block")
+	require.NoError(t, err)
+	// sdb is an empty card reader and the mmc devices aren't removable
+	require.Len(t, disks, 1)
+	assert.Equal(t, "sda", disks[0].Name)
+	assert.Equal(t, "SanDisk", disks[0].Vendor)
+	assert.Equal(t, "Ultra Fit", disks[0].Model)
+	assert.Equal(t, uint64(61440000*512), disks[0].SizeBytes)
+	assert.Equal(t, []string{"sda1"}, disks[0].Partitions)
+}
+
+func TestIsRemovableDiskEvent(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name      string
+		event     *Uevent
+		removable bool
+	}{
+		{"usb disk", &Uevent{Action: "remove", Subsystem: "block", DevType: "disk", DevName: "sda", DevPath: "/devices/platform/scb/usb2/2-1/2-1:1.0/host0/target0:0:0/0:0:0:0/block/sda"}, true},
+		{"removable attribute", &Uevent{Action: "add", Subsystem: "block", DevType: "disk", DevName: "sdb", DevPath: "/devices/platform/ahci/ata1/host1/block/sdb"}, true},
+		{"internal emmc", &Uevent{Action: "change", Subsystem: "block", DevType: "disk", DevName: "mmcblk0", DevPath: "/devices/platform/emmc2bus/fe340000.mmc/mmc_host/mmc0/mmc0:0001/block/mmcblk0"}, false},
+		{"emmc partition", &Uevent{Action: "add", Subsystem: "block", DevType: "partition", DevName: "mmcblk0p1", DevPath: "/devices/platform/emmc2bus/fe340000.mmc/mmc_host/mmc0/mmc0:0001/block/mmcblk0/mmcblk0p1"}, false},
+		{"loop device", &Uevent{Action: "change", Subsystem: "block", DevType: "disk", DevName: "loop0", DevPath: "/devices/virtual/block/loop0"}, false},
+		{"zram device", &Uevent{Action: "add", Subsystem: "block", DevType: "disk", DevName: "zram0", DevPath: "/devices/virtual/block/zram0"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.removable, isRemovableDiskEvent(ctx, tt.event, "testdata/sys_block"))
+		})
+	}
+}
@@ -0,0 +1,21 @@
+package linux
+
+import (
+	"time"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+type UeventReader struct{}
+
+func OpenUevents(timeout time.Duration) (*UeventReader, error) {
+	return nil, utils.ErrPlatformNotSupported
+}
+
+func (u *UeventReader) Read() (*Uevent, error) {
+	return nil, utils.ErrPlatformNotSupported
+}
+
+func (u *UeventReader) Close() error {
+	return nil
+}
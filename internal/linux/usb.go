@@ -0,0 +1,98 @@
+package linux
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+const USBDevicesRoot = "/sys/bus/usb/devices"
+
+// USBDevice is a device on a USB bus, hubs included.
+type USBDevice struct {
+	// Path is the sysfs name, the bus and the port chain, e.g. 1-1.2 is port 2 of the hub on port 1 of bus 1
+	Path         string
+	Bus          int64
+	VendorID     string
+	ProductID    string
+	Manufacturer string
+	Product      string
+	Serial       string
+	// Speed is the negotiated speed in Mbit/s, e.g. 480 for high speed and 5000 for SuperSpeed
+	Speed float64
+	// Drivers are the drivers bound to the device's interfaces, e.g. uvcvideo for a camera
+	Drivers []string
+}
+
+// ID returns the vendor and product ID as lsusb shows them, e.g. 046d:0825.
+func (d *USBDevice) ID() string {
+	return d.VendorID + ":" + d.ProductID
+}
+
+// ReadUSBDevices reads the devices under root, normally USBDevicesRoot. The root hubs, usb1 and so on, are the host
+// controllers and are skipped.
+func ReadUSBDevices(ctx context.Context, root string) ([]USBDevice, error) {
+	entries, err := utils.ReadDir(root)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	devices := make([]USBDevice, 0)
+	for _, entry := range entries {
+		name := entry.Name()
+		// Interfaces are named <device>:<config>.<interface>
+		if strings.HasPrefix(name, "usb") || strings.Contains(name, ":") {
+			continue
+		}
+		path := filepath.Join(root, name)
+		read := func(attr string) string {
+			value, _ := utils.ReadFileWithContext(ctx, filepath.Join(path, attr))
+			return value
+		}
+		device := USBDevice{
+			Path:         name,
+			VendorID:     read("idVendor"),
+			ProductID:    read("idProduct"),
+			Manufacturer: read("manufacturer"),
+			Product:      read("product"),
+			Serial:       read("serial"),
+		}
+		if device.VendorID == "" {
+			continue
+		}
+		device.Bus, _ = strconv.ParseInt(read("busnum"), 10, 64)
+		device.Speed, _ = strconv.ParseFloat(read("speed"), 64)
+		device.Drivers = readUSBInterfaceDrivers(root, name)
+		devices = append(devices, device)
+	}
+	sort.Slice(devices, func(i, j int) bool { return devices[i].Path < devices[j].Path })
+	return devices, nil
+}
+
+func readUSBInterfaceDrivers(root, device string) []string {
+	interfaces, err := utils.Glob(filepath.Join(root, device+":*"))
+	if err != nil {
+		return nil
+	}
+	drivers := make([]string, 0)
+	for _, iface := range interfaces {
+		driver, err := utils.Readlink(filepath.Join(iface, "driver"))
+		if err != nil {
+			continue
+		}
+		name := filepath.Base(driver)
+		if !slices.Contains(drivers, name) {
+			drivers = append(drivers, name)
+		}
+	}
+	sort.Strings(drivers)
+	return drivers
+}
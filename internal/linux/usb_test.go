@@ -0,0 +1,45 @@
+package linux
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadUSBDevices(t *testing.T) {
+	tree := newSysfsTree(t)
+	for device, attrs := range map[string]map[string]string{
+		"usb1":      {"idVendor": "1d6b", "idProduct": "0002", "busnum": "1", "speed": "480"},
+		"1-1":       {"idVendor": "2109", "idProduct": "3431", "product": "USB2.0 Hub", "busnum": "1", "speed": "480"},
+		"1-1.2":     {"idVendor": "046d", "idProduct": "0825", "manufacturer": "Logitech", "product": "Webcam C270", "serial": "A1B2C3D4", "busnum": "1", "speed": "480"},
+		"2-1":       {"idVendor": "0bda", "idProduct": "9210", "product": "RTL9210", "busnum": "2", "speed": "5000"},
+		"1-1.2:1.0": {},
+		"1-1.2:1.1": {},
+		"1-1.2:1.2": {},
+	} {
+		tree.attrs(device, attrs)
+	}
+	tree.link("bus/usb/drivers/uvcvideo", "1-1.2:1.0/driver")
+	tree.link("bus/usb/drivers/uvcvideo", "1-1.2:1.1/driver")
+	tree.link("bus/usb/drivers/snd-usb-audio", "1-1.2:1.2/driver")
+
+	devices, err := ReadUSBDevices(context.Background(), tree.root)
+	require.NoError(t, err)
+	require.Len(t, devices, 3)
+	assert.Equal(t, "1-1", devices[0].Path)
+	assert.Equal(t, USBDevice{
+		Path:         "1-1.2",
+		Bus:          1,
+		VendorID:     "046d",
+		ProductID:    "0825",
+		Manufacturer: "Logitech",
+		Product:      "Webcam C270",
+		Serial:       "A1B2C3D4",
+		Speed:        480,
+		Drivers:      []string{"snd-usb-audio", "uvcvideo"},
+	}, devices[1])
+	assert.Equal(t, "046d:0825", devices[1].ID())
+	assert.Equal(t, 5000.0, devices[2].Speed)
+}
@@ -0,0 +1,73 @@
+package linux
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+// USBPort is a downstream port of a USB hub, root hubs included.
+type USBPort struct {
+	// Name is the port's sysfs name, the hub and port number, e.g. 1-1-port2, or usb1-port1 on a root hub
+	Name string
+	// Device is the path of the attached device, e.g. 1-1.2, or empty when nothing is attached
+	Device string
+	// Disabled is true when the port has been powered off through its disable attribute
+	Disabled         bool
+	OverCurrentCount int64
+	// ConnectType is hotplug, hardwired or not used, as described by the platform firmware
+	ConnectType string
+	path        string
+}
+
+// ReadUSBPorts reads the ports of every hub under root, normally USBDevicesRoot. The ports live under the hub's
+// interface, e.g. 1-1:1.0/1-1-port2.
+func ReadUSBPorts(ctx context.Context, root string) ([]USBPort, error) {
+	paths, err := utils.Glob(filepath.Join(root, "*:1.0", "*-port*"))
+	if err != nil {
+		return nil, err
+	}
+	ports := make([]USBPort, 0, len(paths))
+	for _, path := range paths {
+		port := USBPort{Name: filepath.Base(path), path: path}
+		if device, err := utils.Readlink(filepath.Join(path, "device")); err == nil {
+			port.Device = filepath.Base(device)
+		}
+		// The disable attribute was added in 5.x kernels, older kernels can't power off ports
+		port.Disabled, _ = utils.ReadBoolFromFileWithContext(ctx, filepath.Join(path, "disable"))
+		port.OverCurrentCount, _ = utils.ReadInt64FromFileWithContext(ctx, filepath.Join(path, "over_current_count"))
+		port.ConnectType, _ = utils.ReadFileWithContext(ctx, filepath.Join(path, "connect_type"))
+		ports = append(ports, port)
+	}
+	sort.Slice(ports, func(i, j int) bool { return ports[i].Name < ports[j].Name })
+	return ports, nil
+}
+
+// FindUSBPort finds a port by its name, or by the path of the device attached to it.
+func FindUSBPort(ports []USBPort, nameOrDevice string) (*USBPort, error) {
+	for i, port := range ports {
+		if port.Name == nameOrDevice || (port.Device != "" && port.Device == nameOrDevice) {
+			return &ports[i], nil
+		}
+	}
+	return nil, fmt.Errorf("USB port %s not found", nameOrDevice)
+}
+
+// SetPower switches the port's power, the same way uhubctl does on kernels with the disable attribute. Hubs without
+// per-port power switching, which includes the Raspberry Pi 4's onboard hub, switch all their ports together or only
+// disable the data connection.
+func (p *USBPort) SetPower(on bool) error {
+	value := "1"
+	if on {
+		value = "0"
+	}
+	if err := os.WriteFile(filepath.Join(p.path, "disable"), []byte(value), 0o644); err != nil {
+		return err
+	}
+	p.Disabled = !on
+	return nil
+}
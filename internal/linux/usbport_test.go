@@ -0,0 +1,45 @@
+package linux
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadUSBPorts(t *testing.T) {
+	tree := newSysfsTree(t)
+	for port, attrs := range map[string]map[string]string{
+		"1-0:1.0/usb1-port1": {"disable": "0", "over_current_count": "0", "connect_type": "hardwired"},
+		"1-1:1.0/1-1-port1":  {"disable": "1", "over_current_count": "0", "connect_type": "hotplug"},
+		"1-1:1.0/1-1-port2":  {"disable": "0", "over_current_count": "3", "connect_type": "hotplug"},
+	} {
+		tree.attrs(port, attrs)
+	}
+	tree.link("1-1", "1-0:1.0/usb1-port1/device")
+	tree.link("1-1.2", "1-1:1.0/1-1-port2/device")
+
+	ports, err := ReadUSBPorts(context.Background(), tree.root)
+	require.NoError(t, err)
+	require.Len(t, ports, 3)
+	assert.Equal(t, "1-1-port1", ports[0].Name)
+	assert.True(t, ports[0].Disabled)
+	assert.Equal(t, "", ports[0].Device)
+	assert.Equal(t, int64(3), ports[1].OverCurrentCount)
+	assert.Equal(t, "usb1-port1", ports[2].Name)
+	assert.Equal(t, "hardwired", ports[2].ConnectType)
+
+	port, err := FindUSBPort(ports, "1-1.2")
+	require.NoError(t, err)
+	assert.Equal(t, "1-1-port2", port.Name)
+	require.NoError(t, port.SetPower(false))
+	assert.True(t, port.Disabled)
+	data, err := os.ReadFile(tree.path("1-1:1.0/1-1-port2/disable"))
+	require.NoError(t, err)
+	assert.Equal(t, "1", string(data))
+
+	_, err = FindUSBPort(ports, "2-1")
+	assert.Error(t, err)
+}
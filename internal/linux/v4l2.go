@@ -0,0 +1,74 @@
+package linux
+
+import (
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+// V4L2 capability flags from linux/videodev2.h
+const (
+	v4l2CapVideoCapture       = 0x00000001
+	v4l2CapVideoCaptureMplane = 0x00001000
+	v4l2CapDeviceCaps         = 0x80000000
+
+	v4l2BufTypeVideoCapture       = 1
+	v4l2BufTypeVideoCaptureMplane = 9
+)
+
+// V4L2Device is a /dev/video node and what its driver reports about it.
+type V4L2Device struct {
+	Node    string
+	Driver  string
+	Card    string
+	BusInfo string
+	// Capture is true for nodes that capture video, UVC cameras also create a metadata node that doesn't
+	Capture bool
+	// Formats are the FourCC codes of the capture formats, e.g. YUYV and MJPG
+	Formats []string
+	// OpenError is why the node couldn't be opened or queried, nil when it could
+	OpenError error
+}
+
+// V4L2Permissions returns the permissions to open the /dev/video nodes, which are opened read-write to be queried.
+func V4L2Permissions() utils.Permissions {
+	return utils.Permissions{
+		Groups:   []string{"video"},
+		Readable: []string{"/dev/video[0-9]*"},
+		Writable: []string{"/dev/video[0-9]*"},
+	}
+}
+
+// ListVideoNodes returns the /dev/video nodes under devRoot in numeric order.
+func ListVideoNodes(devRoot string) ([]string, error) {
+	nodes, err := utils.Glob(filepath.Join(devRoot, "video[0-9]*"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(nodes, func(i, j int) bool {
+		return videoNodeNumber(nodes[i]) < videoNodeNumber(nodes[j])
+	})
+	return nodes, nil
+}
+
+func videoNodeNumber(node string) int {
+	n, _ := strconv.Atoi(strings.TrimPrefix(filepath.Base(node), "video"))
+	return n
+}
+
+// deviceCaps returns the capabilities of the node rather than the whole device, which older drivers don't report.
+func deviceCaps(capabilities, deviceCaps uint32) uint32 {
+	if capabilities&v4l2CapDeviceCaps != 0 {
+		return deviceCaps
+	}
+	return capabilities
+}
+
+// fourCC converts a V4L2 pixel format to its four character code.
+func fourCC(format uint32) string {
+	code := []byte{byte(format), byte(format >> 8), byte(format >> 16), byte(format >> 24)}
+	return strings.TrimRight(string(code), " \x00")
+}
@@ -0,0 +1,76 @@
+package linux
+
+import (
+	"syscall"
+	"unsafe"
+)
+
+// ioctl numbers from linux/videodev2.h
+const (
+	vidiocQueryCap = 0x80685600 // _IOR('V', 0, struct v4l2_capability)
+	vidiocEnumFmt  = 0xc0405602 // _IOWR('V', 2, struct v4l2_fmtdesc)
+)
+
+type v4l2Capability struct {
+	driver       [16]byte
+	card         [32]byte
+	busInfo      [32]byte
+	version      uint32
+	capabilities uint32
+	deviceCaps   uint32
+	reserved     [3]uint32
+}
+
+type v4l2FmtDesc struct {
+	index       uint32
+	bufType     uint32
+	flags       uint32
+	description [32]byte
+	pixelFormat uint32
+	mbusCode    uint32
+	reserved    [3]uint32
+}
+
+// QueryV4L2Device opens a node and queries its capabilities and capture formats. A node that can't be opened or
+// queried is returned with OpenError set rather than as an error.
+func QueryV4L2Device(node string) *V4L2Device {
+	device := &V4L2Device{Node: node}
+	// Opening a node doesn't interfere with another process streaming from it
+	fd, err := syscall.Open(node, syscall.O_RDWR|syscall.O_NONBLOCK|syscall.O_CLOEXEC, 0)
+	if err != nil {
+		device.OpenError = err
+		return device
+	}
+	defer syscall.Close(fd)
+
+	var capability v4l2Capability
+	if err := ioctl(fd, vidiocQueryCap, unsafe.Pointer(&capability)); err != nil {
+		device.OpenError = err
+		return device
+	}
+	device.Driver = cString(capability.driver[:])
+	device.Card = cString(capability.card[:])
+	device.BusInfo = cString(capability.busInfo[:])
+
+	caps := deviceCaps(capability.capabilities, capability.deviceCaps)
+	var bufType uint32
+	switch {
+	case caps&v4l2CapVideoCapture != 0:
+		bufType = v4l2BufTypeVideoCapture
+	case caps&v4l2CapVideoCaptureMplane != 0:
+		bufType = v4l2BufTypeVideoCaptureMplane
+	default:
+		return device
+	}
+	device.Capture = true
+	device.Formats = make([]string, 0)
+	for index := uint32(0); ; index++ {
+		desc := v4l2FmtDesc{index: index, bufType: bufType}
+		// The driver returns EINVAL past the last format
+		if err := ioctl(fd, vidiocEnumFmt, unsafe.Pointer(&desc)); err != nil {
+			break
+		}
+		device.Formats = append(device.Formats, fourCC(desc.pixelFormat))
+	}
+	return device
+}
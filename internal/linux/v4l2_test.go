@@ -0,0 +1,39 @@
+package linux
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListVideoNodes(t *testing.T) {
+	root := t.TempDir()
+	for _, name := range []string{"video10", "video2", "video0", "video1", "vcsm-cma", "media0"} {
+		require.NoError(t, os.WriteFile(filepath.Join(root, name), nil, 0o644))
+	}
+
+	nodes, err := ListVideoNodes(root)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		filepath.Join(root, "video0"),
+		filepath.Join(root, "video1"),
+		filepath.Join(root, "video2"),
+		filepath.Join(root, "video10"),
+	}, nodes)
+}
+
+func TestDeviceCaps(t *testing.T) {
+	// A UVC metadata node reports the capture capability for the device but not for the node
+	assert.Equal(t, uint32(0x04800000), deviceCaps(0x84a00001, 0x04800000))
+	// Without V4L2_CAP_DEVICE_CAPS the device capabilities are all there is
+	assert.Equal(t, uint32(0x05000001), deviceCaps(0x05000001, 0))
+}
+
+func TestFourCC(t *testing.T) {
+	assert.Equal(t, "YUYV", fourCC(0x56595559))
+	assert.Equal(t, "MJPG", fourCC(0x47504a4d))
+	assert.Equal(t, "Y10", fourCC(0x20303159))
+}
@@ -0,0 +1,9 @@
+package linux
+
+import (
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+func QueryV4L2Device(node string) *V4L2Device {
+	return &V4L2Device{Node: node, OpenError: utils.ErrPlatformNotSupported}
+}
@@ -0,0 +1,97 @@
+package linux
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+const W1DevicesRoot = "/sys/bus/w1/devices"
+
+var (
+	ErrW1CRC = errors.New("1-Wire CRC check failed")
+	// A DS18B20 that lost power mid conversion, or whose conversion was never started, returns its power-on value
+	ErrW1PowerOnReset = errors.New("1-Wire thermometer returned its power-on reset value")
+)
+
+// The family codes of the thermometers handled by the w1_therm driver
+var w1ThermFamilies = map[string]string{
+	"10": "DS18S20",
+	"22": "DS1822",
+	"28": "DS18B20",
+	"3b": "DS1825",
+	"42": "DS28EA00",
+}
+
+// W1Thermometer is a 1-Wire thermometer, ID is the sysfs name, the family code and serial number, e.g. 28-0316a2794bff.
+type W1Thermometer struct {
+	ID    string
+	Model string
+}
+
+// ListW1Thermometers returns the thermometers under root, normally W1DevicesRoot.
+func ListW1Thermometers(root string) ([]W1Thermometer, error) {
+	entries, err := utils.ReadDir(root)
+	if err != nil {
+		// The w1 bus only exists once a master driver such as w1-gpio is loaded
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	thermometers := make([]W1Thermometer, 0)
+	for _, entry := range entries {
+		family, _, ok := strings.Cut(entry.Name(), "-")
+		if !ok {
+			continue
+		}
+		if model, ok := w1ThermFamilies[family]; ok {
+			thermometers = append(thermometers, W1Thermometer{ID: entry.Name(), Model: model})
+		}
+	}
+	return thermometers, nil
+}
+
+// ReadW1Temperature reads a thermometer's temperature in °C. Reading starts a conversion, which takes up to 750ms
+// at the default 12 bit resolution.
+func ReadW1Temperature(ctx context.Context, root, id string) (float64, error) {
+	data, err := utils.ReadFileWithContext(ctx, filepath.Join(root, id, "w1_slave"))
+	if err != nil {
+		return 0, err
+	}
+	return parseW1Slave(data)
+}
+
+// parseW1Slave parses the w1_slave attribute, the scratchpad and the result of its CRC check on the first line and
+// the scratchpad and temperature in millidegrees on the second:
+//
+//	72 01 4b 46 7f ff 0e 10 57 : crc=57 YES
+//	72 01 4b 46 7f ff 0e 10 57 t=23125
+func parseW1Slave(data string) (float64, error) {
+	lines := strings.Split(strings.TrimSpace(data), "\n")
+	if len(lines) < 2 {
+		return 0, fmt.Errorf("unexpected w1_slave format: %q", data)
+	}
+	if !strings.HasSuffix(strings.TrimSpace(lines[0]), "YES") {
+		return 0, ErrW1CRC
+	}
+	_, value, ok := strings.Cut(lines[1], "t=")
+	if !ok {
+		return 0, fmt.Errorf("unexpected w1_slave format: %q", data)
+	}
+	millidegrees, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	// 85°C is also a valid temperature, it is only the reset value when the scratchpad still holds the defaults
+	if millidegrees == 85000 && strings.HasPrefix(lines[1], "50 05 4b 46 7f ff") {
+		return 0, ErrW1PowerOnReset
+	}
+	return float64(millidegrees) / 1000, nil
+}
@@ -0,0 +1,34 @@
+package linux
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListW1Thermometers(t *testing.T) {
+	thermometers, err := ListW1Thermometers("testdata/sys_bus_w1")
+	require.NoError(t, err)
+	assert.Equal(t, []W1Thermometer{{ID: "28-0316a2794bff", Model: "DS18B20"}, {ID: "28-0417c1b2d4ff", Model: "DS18B20"}}, thermometers)
+
+	temperature, err := ReadW1Temperature(context.Background(), "testdata/sys_bus_w1", "28-0417c1b2d4ff")
+	require.NoError(t, err)
+	assert.Equal(t, -10.125, temperature)
+}
+
+func TestParseW1Slave(t *testing.T) {
+	temperature, err := parseW1Slave("72 01 4b 46 7f ff 0e 10 57 : crc=57 YES\n72 01 4b 46 7f ff 0e 10 57 t=23125\n")
+	require.NoError(t, err)
+	assert.Equal(t, 23.125, temperature)
+
+	_, err = parseW1Slave("72 01 4b 46 7f ff 0e 10 ff : crc=57 NO\n72 01 4b 46 7f ff 0e 10 ff t=23125\n")
+	assert.ErrorIs(t, err, ErrW1CRC)
+
+	_, err = parseW1Slave("50 05 4b 46 7f ff 0c 10 1c : crc=1c YES\n50 05 4b 46 7f ff 0c 10 1c t=85000\n")
+	assert.ErrorIs(t, err, ErrW1PowerOnReset)
+
+	_, err = parseW1Slave("")
+	assert.Error(t, err)
+}
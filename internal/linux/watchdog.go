@@ -0,0 +1,66 @@
+package linux
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+// Watchdog describes a hardware watchdog from /sys/class/watchdog.
+type Watchdog struct {
+	Name     string
+	Identity string
+	// State is "active" while the watchdog is armed and "inactive" otherwise
+	State              string
+	Timeout            time.Duration
+	Pretimeout         time.Duration
+	TimeLeft           time.Duration
+	Nowayout           bool
+	PretimeoutGovernor string
+	// BootStatus is non zero when the last reboot was caused by the watchdog, on drivers that support it
+	BootStatus int64
+}
+
+// GetWatchdogs returns every hardware watchdog.
+func GetWatchdogs(ctx context.Context) ([]*Watchdog, error) {
+	return getWatchdogs(ctx, "/sys/class/watchdog")
+}
+
+func getWatchdogs(ctx context.Context, root string) ([]*Watchdog, error) {
+	entries, err := utils.ReadDir(root)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	watchdogs := make([]*Watchdog, 0, len(entries))
+	for _, entry := range entries {
+		path := filepath.Join(root, entry.Name())
+		// Most attributes are only readable by root, and not every driver implements all of them
+		readString := func(name string) string {
+			value, _ := utils.ReadFileWithContext(ctx, filepath.Join(path, name))
+			return value
+		}
+		readSeconds := func(name string) time.Duration {
+			value, _ := utils.ReadInt64FromFileWithContext(ctx, filepath.Join(path, name))
+			return time.Duration(value) * time.Second
+		}
+		watchdog := &Watchdog{
+			Name:               entry.Name(),
+			Identity:           readString("identity"),
+			State:              readString("state"),
+			Timeout:            readSeconds("timeout"),
+			Pretimeout:         readSeconds("pretimeout"),
+			TimeLeft:           readSeconds("timeleft"),
+			PretimeoutGovernor: readString("pretimeout_governor"),
+		}
+		watchdog.Nowayout, _ = utils.ReadBoolFromFileWithContext(ctx, filepath.Join(path, "nowayout"))
+		watchdog.BootStatus, _ = utils.ReadInt64FromFileWithContext(ctx, filepath.Join(path, "bootstatus"))
+		watchdogs = append(watchdogs, watchdog)
+	}
+	return watchdogs, nil
+}
@@ -0,0 +1,44 @@
+package linux
+
+import (
+	"os"
+	"sync"
+)
+
+// WatchdogDevice is an open watchdog device, the watchdog is armed as long as it is open.
+type WatchdogDevice struct {
+	mu   sync.Mutex
+	file *os.File
+}
+
+// OpenWatchdog opens and thereby arms the watchdog. Only one process can have it open, this fails with EBUSY
+// when systemd or a watchdog daemon already feeds it.
+func OpenWatchdog(path string) (*WatchdogDevice, error) {
+	file, err := os.OpenFile(path, os.O_WRONLY, 0)
+	if err != nil {
+		return nil, err
+	}
+	return &WatchdogDevice{file: file}, nil
+}
+
+// Feed resets the watchdog timer.
+func (w *WatchdogDevice) Feed() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	_, err := w.file.Write([]byte{0})
+	return err
+}
+
+// Close closes the device. When disarm is true the magic close character is written first, so the watchdog is
+// stopped instead of rebooting the system, unless the driver was built with nowayout.
+func (w *WatchdogDevice) Close(disarm bool) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if disarm {
+		if _, err := w.file.Write([]byte("V")); err != nil {
+			w.file.Close()
+			return err
+		}
+	}
+	return w.file.Close()
+}
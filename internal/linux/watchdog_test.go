@@ -0,0 +1,29 @@
+package linux
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetWatchdogs(t *testing.T) {
+	watchdogs, err := getWatchdogs(context.Background(), "testdata/sys_class_watchdog")
+	require.NoError(t, err)
+	require.Len(t, watchdogs, 1)
+	watchdog := watchdogs[0]
+	assert.Equal(t, "watchdog0", watchdog.Name)
+	assert.Equal(t, "Broadcom BCM2835 Watchdog timer", watchdog.Identity)
+	assert.Equal(t, "active", watchdog.State)
+	assert.Equal(t, 15*time.Second, watchdog.Timeout)
+	assert.Equal(t, 13*time.Second, watchdog.TimeLeft)
+	// The bcm2835 driver has no pretimeout support
+	assert.Equal(t, time.Duration(0), watchdog.Pretimeout)
+	assert.False(t, watchdog.Nowayout)
+
+	watchdogs, err = getWatchdogs(context.Background(), "testdata/missing")
+	require.NoError(t, err)
+	assert.Empty(t, watchdogs)
+}
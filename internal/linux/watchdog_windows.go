@@ -0,0 +1,17 @@
+package linux
+
+import "github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+
+type WatchdogDevice struct{}
+
+func OpenWatchdog(path string) (*WatchdogDevice, error) {
+	return nil, utils.ErrPlatformNotSupported
+}
+
+func (w *WatchdogDevice) Feed() error {
+	return utils.ErrPlatformNotSupported
+}
+
+func (w *WatchdogDevice) Close(disarm bool) error {
+	return nil
+}
@@ -0,0 +1,167 @@
+package linux
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+const (
+	zswapParametersPath = "/sys/module/zswap/parameters"
+	zswapDebugfsPath    = "/sys/kernel/debug/zswap"
+)
+
+type ZramDevice struct {
+	Name      string
+	Algorithm string
+	DiskSize  uint64
+	// The fields below come from mm_stat, see Documentation/admin-guide/blockdev/zram.rst
+	OrigDataSize   uint64
+	ComprDataSize  uint64
+	MemUsedTotal   uint64
+	MemLimit       uint64
+	MemUsedMax     uint64
+	SamePages      uint64
+	PagesCompacted uint64
+	HugePages      uint64
+}
+
+// CompressionRatio returns the ratio of uncompressed to compressed data, 0 if nothing is stored.
+func (z *ZramDevice) CompressionRatio() float64 {
+	if z.ComprDataSize == 0 {
+		return 0
+	}
+	return utils.RoundValue(float64(z.OrigDataSize)/float64(z.ComprDataSize), 2)
+}
+
+// UsedPercent returns how much of the configured disk size holds (uncompressed) data.
+func (z *ZramDevice) UsedPercent() float64 {
+	if z.DiskSize == 0 {
+		return 0
+	}
+	return utils.RoundValue(float64(z.OrigDataSize)/float64(z.DiskSize)*100, 2)
+}
+
+type ZswapStats struct {
+	Enabled        bool
+	Compressor     string
+	MaxPoolPercent int64
+	// Debugfs stats, only available when running as root with debugfs mounted
+	Stats map[string]uint64
+}
+
+func GetZramDevices(ctx context.Context) ([]*ZramDevice, error) {
+	paths, err := utils.Glob("/sys/block/zram*")
+	if err != nil {
+		return nil, err
+	}
+	devices := make([]*ZramDevice, 0, len(paths))
+	for _, path := range paths {
+		device, err := readZramDevice(ctx, path)
+		if err != nil {
+			return nil, err
+		}
+		devices = append(devices, device)
+	}
+	return devices, nil
+}
+
+func readZramDevice(ctx context.Context, path string) (*ZramDevice, error) {
+	diskSize, err := utils.ReadInt64FromFileWithContext(ctx, filepath.Join(path, "disksize"))
+	if err != nil {
+		return nil, err
+	}
+	mmStat, err := utils.ReadFileWithContext(ctx, filepath.Join(path, "mm_stat"))
+	if err != nil {
+		return nil, err
+	}
+	device, err := parseZramMmStat(mmStat)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse mm_stat for %s: %w", path, err)
+	}
+	device.Name = filepath.Base(path)
+	device.DiskSize = uint64(diskSize)
+	algorithm, err := utils.ReadFileWithContext(ctx, filepath.Join(path, "comp_algorithm"))
+	if err == nil {
+		device.Algorithm = parseSelectedOption(algorithm)
+	}
+	return device, nil
+}
+
+func parseZramMmStat(data string) (*ZramDevice, error) {
+	fields := strings.Fields(data)
+	if len(fields) < 7 {
+		return nil, fmt.Errorf("expected at least 7 fields, got %d", len(fields))
+	}
+	values := make([]uint64, len(fields))
+	for i, field := range fields {
+		value, err := strconv.ParseUint(field, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		values[i] = value
+	}
+	device := &ZramDevice{
+		OrigDataSize:   values[0],
+		ComprDataSize:  values[1],
+		MemUsedTotal:   values[2],
+		MemLimit:       values[3],
+		MemUsedMax:     values[4],
+		SamePages:      values[5],
+		PagesCompacted: values[6],
+	}
+	// huge_pages was added in 4.19
+	if len(values) > 7 {
+		device.HugePages = values[7]
+	}
+	return device, nil
+}
+
+// parseSelectedOption returns the bracketed entry of a sysfs option list, e.g. "lzo [lz4] zstd" returns "lz4".
+func parseSelectedOption(data string) string {
+	for _, option := range strings.Fields(data) {
+		if strings.HasPrefix(option, "[") && strings.HasSuffix(option, "]") {
+			return strings.Trim(option, "[]")
+		}
+	}
+	return strings.TrimSpace(data)
+}
+
+func GetZswapStats(ctx context.Context) (*ZswapStats, error) {
+	enabled, err := utils.ReadFileWithContext(ctx, filepath.Join(zswapParametersPath, "enabled"))
+	if err != nil {
+		return nil, err
+	}
+	stats := &ZswapStats{
+		Enabled: enabled == "Y" || enabled == "1",
+		Stats:   make(map[string]uint64),
+	}
+	if compressor, err := utils.ReadFileWithContext(ctx, filepath.Join(zswapParametersPath, "compressor")); err == nil {
+		stats.Compressor = compressor
+	}
+	if maxPool, err := utils.ReadInt64FromFileWithContext(ctx, filepath.Join(zswapParametersPath, "max_pool_percent")); err == nil {
+		stats.MaxPoolPercent = maxPool
+	}
+
+	files, err := utils.ReadDir(zswapDebugfsPath)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) || errors.Is(err, os.ErrPermission) {
+			return stats, nil
+		}
+		return nil, err
+	}
+	for _, file := range files {
+		value, err := utils.ReadInt64FromFileWithContext(ctx, filepath.Join(zswapDebugfsPath, file.Name()))
+		if err != nil {
+			continue
+		}
+		stats.Stats[file.Name()] = uint64(value)
+	}
+	return stats, nil
+}
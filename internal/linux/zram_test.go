@@ -0,0 +1,31 @@
+package linux
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseZramMmStat(t *testing.T) {
+	b, err := os.ReadFile("testdata/zram_mm_stat.txt")
+	require.NoError(t, err)
+	device, err := parseZramMmStat(string(b))
+	require.NoError(t, err)
+	device.DiskSize = 1073741824
+	assert.Equal(t, uint64(268435456), device.OrigDataSize)
+	assert.Equal(t, uint64(67108864), device.ComprDataSize)
+	assert.Equal(t, uint64(71303168), device.MemUsedTotal)
+	assert.Equal(t, uint64(0), device.MemLimit)
+	assert.Equal(t, uint64(83886080), device.MemUsedMax)
+	assert.Equal(t, uint64(12345), device.SamePages)
+	assert.Equal(t, uint64(5), device.HugePages)
+	assert.Equal(t, 4.0, device.CompressionRatio())
+	assert.Equal(t, 25.0, device.UsedPercent())
+}
+
+func TestParseSelectedOption(t *testing.T) {
+	assert.Equal(t, "lz4", parseSelectedOption("lzo lzo-rle [lz4] zstd"))
+	assert.Equal(t, "zstd", parseSelectedOption("zstd"))
+}
@@ -0,0 +1,418 @@
+package mqtt
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// Packet types from the MQTT 3.1.1 specification
+const (
+	packetConnect    = 1
+	packetConnAck    = 2
+	packetPublish    = 3
+	packetPubAck     = 4
+	packetPingReq    = 12
+	packetPingResp   = 13
+	packetDisconnect = 14
+)
+
+const defaultKeepAlive = 30 * time.Second
+
+var (
+	ErrClosed         = errors.New("mqtt connection closed")
+	ErrUnsupportedQoS = errors.New("only QoS 0 and 1 are supported")
+	ErrPingTimeout    = errors.New("broker didn't answer a ping within the keepalive interval")
+	ErrAckTimeout     = errors.New("broker didn't acknowledge a publish in time")
+)
+
+// connectErrors are the CONNACK return codes
+var connectErrors = map[byte]string{
+	1: "unacceptable protocol version",
+	2: "client identifier rejected",
+	3: "server unavailable",
+	4: "bad user name or password",
+	5: "not authorized",
+}
+
+// Message is a message to publish.
+type Message struct {
+	Topic   string
+	Payload []byte
+	QoS     byte
+	Retain  bool
+}
+
+// Options configure the connection to the broker.
+type Options struct {
+	// Broker is the broker's URL, tcp://host:1883, or ssl://host:8883 for TLS. mqtt:// and mqtts:// work too.
+	Broker   string
+	ClientID string
+	Username string
+	Password string
+	// TLSConfig is used for ssl:// and mqtts:// brokers, the system roots are used when it's nil
+	TLSConfig *tls.Config
+	// KeepAlive is how often the broker expects to hear from the client, defaults to 30 seconds
+	KeepAlive time.Duration
+	// Will is published by the broker if the client disappears without disconnecting
+	Will *Message
+}
+
+// Client is a publish only MQTT 3.1.1 client. It doesn't reconnect, once Done is closed a new client is needed.
+type Client struct {
+	conn      net.Conn
+	writeLock sync.Mutex
+	ackLock   sync.Mutex
+	nextID    uint16
+	acks      map[uint16]chan struct{}
+	done      chan struct{}
+	closeOnce sync.Once
+	err       error
+	// pingLock guards when the last PINGREQ was sent and the last PINGRESP arrived
+	pingLock     sync.Mutex
+	lastPingReq  time.Time
+	lastPingResp time.Time
+}
+
+// Connect dials the broker and completes the MQTT handshake.
+func Connect(ctx context.Context, opts Options) (*Client, error) {
+	conn, err := dial(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+	keepAlive := opts.KeepAlive
+	if keepAlive <= 0 {
+		keepAlive = defaultKeepAlive
+	}
+	if _, err := conn.Write(encodeConnect(opts, keepAlive)); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	reader := bufio.NewReader(conn)
+	packetType, body, err := readPacket(reader)
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to read CONNACK: %w", err)
+	}
+	if packetType != packetConnAck || len(body) != 2 {
+		conn.Close()
+		return nil, fmt.Errorf("expected CONNACK, got packet type %d", packetType)
+	}
+	if body[1] != 0 {
+		conn.Close()
+		if reason, ok := connectErrors[body[1]]; ok {
+			return nil, fmt.Errorf("broker refused connection: %s", reason)
+		}
+		return nil, fmt.Errorf("broker refused connection: return code %d", body[1])
+	}
+	conn.SetDeadline(time.Time{})
+
+	c := &Client{
+		conn: conn,
+		acks: make(map[uint16]chan struct{}),
+		done: make(chan struct{}),
+	}
+	go c.readLoop(reader)
+	go c.keepAlive(keepAlive)
+	return c, nil
+}
+
+func dial(ctx context.Context, opts Options) (net.Conn, error) {
+	broker, err := url.Parse(opts.Broker)
+	if err != nil {
+		return nil, fmt.Errorf("invalid broker URL: %w", err)
+	}
+	useTLS := false
+	port := "1883"
+	switch broker.Scheme {
+	case "tcp", "mqtt":
+	case "ssl", "tls", "mqtts":
+		useTLS = true
+		port = "8883"
+	default:
+		return nil, fmt.Errorf("unsupported broker scheme: %s", broker.Scheme)
+	}
+	if broker.Port() != "" {
+		port = broker.Port()
+	}
+	address := net.JoinHostPort(broker.Hostname(), port)
+	if !useTLS {
+		var dialer net.Dialer
+		return dialer.DialContext(ctx, "tcp", address)
+	}
+	config := opts.TLSConfig
+	if config == nil {
+		config = &tls.Config{}
+	}
+	if config.ServerName == "" {
+		config = config.Clone()
+		config.ServerName = broker.Hostname()
+	}
+	dialer := tls.Dialer{Config: config}
+	return dialer.DialContext(ctx, "tcp", address)
+}
+
+// Publish sends a message. A QoS 1 message waits for the broker's acknowledgement until ctx is done.
+func (c *Client) Publish(ctx context.Context, msg Message) error {
+	if msg.QoS > 1 {
+		return ErrUnsupportedQoS
+	}
+	var id uint16
+	var ack chan struct{}
+	if msg.QoS == 1 {
+		c.ackLock.Lock()
+		c.nextID++
+		// Packet identifiers must not be 0
+		if c.nextID == 0 {
+			c.nextID = 1
+		}
+		id = c.nextID
+		ack = make(chan struct{})
+		c.acks[id] = ack
+		c.ackLock.Unlock()
+		defer func() {
+			c.ackLock.Lock()
+			delete(c.acks, id)
+			c.ackLock.Unlock()
+		}()
+	}
+	if err := c.write(ctx, encodePublish(msg, id)); err != nil {
+		return err
+	}
+	if ack == nil {
+		return nil
+	}
+	select {
+	case <-ack:
+		return nil
+	case <-c.done:
+		return c.Err()
+	case <-ctx.Done():
+		// The broker may never acknowledge it, a connection that stopped acknowledging is as good as lost, so the
+		// caller reconnects rather than waiting on it again
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			c.shutdown(ErrAckTimeout)
+		}
+		return ctx.Err()
+	}
+}
+
+// Done is closed when the connection is lost or closed.
+func (c *Client) Done() <-chan struct{} {
+	return c.done
+}
+
+// Err returns why the connection was lost, or nil while it's up.
+func (c *Client) Err() error {
+	select {
+	case <-c.done:
+		return c.err
+	default:
+		return nil
+	}
+}
+
+// Close disconnects from the broker. Disconnecting cleanly means the broker won't publish the will.
+func (c *Client) Close() error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	// The connection may already be gone, in which case there's nothing to tell the broker
+	_ = c.write(ctx, []byte{packetDisconnect << 4, 0})
+	c.shutdown(ErrClosed)
+	return nil
+}
+
+func (c *Client) write(ctx context.Context, packet []byte) error {
+	c.writeLock.Lock()
+	defer c.writeLock.Unlock()
+	if err := c.Err(); err != nil {
+		return err
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		c.conn.SetWriteDeadline(deadline)
+		defer c.conn.SetWriteDeadline(time.Time{})
+	}
+	if _, err := c.conn.Write(packet); err != nil {
+		c.shutdown(err)
+		return err
+	}
+	return nil
+}
+
+func (c *Client) shutdown(err error) {
+	c.closeOnce.Do(func() {
+		c.err = err
+		close(c.done)
+		c.conn.Close()
+	})
+}
+
+func (c *Client) readLoop(reader *bufio.Reader) {
+	for {
+		packetType, body, err := readPacket(reader)
+		if err != nil {
+			c.shutdown(err)
+			return
+		}
+		switch packetType {
+		case packetPubAck:
+			if len(body) < 2 {
+				continue
+			}
+			id := binary.BigEndian.Uint16(body)
+			c.ackLock.Lock()
+			if ack, ok := c.acks[id]; ok {
+				close(ack)
+				delete(c.acks, id)
+			}
+			c.ackLock.Unlock()
+		case packetPingResp:
+			c.pingLock.Lock()
+			c.lastPingResp = time.Now()
+			c.pingLock.Unlock()
+		default:
+			// Nothing is subscribed to, so nothing else is expected
+		}
+	}
+}
+
+// keepAlive pings the broker so it doesn't drop the connection while nothing is being published. A broker that doesn't
+// answer a ping within the interval is gone even if the connection looks up, e.g. after a NAT timeout, so the
+// connection is shut down.
+func (c *Client) keepAlive(interval time.Duration) {
+	ticker := time.NewTicker(interval / 2)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-c.done:
+			return
+		case now := <-ticker.C:
+			c.pingLock.Lock()
+			sent := c.lastPingReq
+			waiting := !sent.IsZero() && c.lastPingResp.Before(sent)
+			c.pingLock.Unlock()
+			if waiting {
+				if now.Sub(sent) >= interval {
+					c.shutdown(ErrPingTimeout)
+					return
+				}
+				continue
+			}
+			c.pingLock.Lock()
+			c.lastPingReq = now
+			c.pingLock.Unlock()
+			ctx, cancel := context.WithTimeout(context.Background(), interval/2)
+			err := c.write(ctx, []byte{packetPingReq << 4, 0})
+			cancel()
+			if err != nil {
+				return
+			}
+		}
+	}
+}
+
+func encodeConnect(opts Options, keepAlive time.Duration) []byte {
+	body := appendString(nil, "MQTT")
+	// Protocol level 4 is MQTT 3.1.1
+	body = append(body, 4)
+	flags := byte(0x02) // Clean session
+	if opts.Will != nil {
+		flags |= 0x04 | opts.Will.QoS<<3
+		if opts.Will.Retain {
+			flags |= 0x20
+		}
+	}
+	if opts.Username != "" {
+		flags |= 0x80
+		if opts.Password != "" {
+			flags |= 0x40
+		}
+	}
+	body = append(body, flags)
+	body = binary.BigEndian.AppendUint16(body, uint16(keepAlive/time.Second))
+	body = appendString(body, opts.ClientID)
+	if opts.Will != nil {
+		body = appendString(body, opts.Will.Topic)
+		body = appendString(body, string(opts.Will.Payload))
+	}
+	if opts.Username != "" {
+		body = appendString(body, opts.Username)
+		if opts.Password != "" {
+			body = appendString(body, opts.Password)
+		}
+	}
+	return appendPacket(packetConnect<<4, body)
+}
+
+func encodePublish(msg Message, id uint16) []byte {
+	header := byte(packetPublish<<4) | msg.QoS<<1
+	if msg.Retain {
+		header |= 0x01
+	}
+	body := appendString(nil, msg.Topic)
+	if msg.QoS > 0 {
+		body = binary.BigEndian.AppendUint16(body, id)
+	}
+	body = append(body, msg.Payload...)
+	return appendPacket(header, body)
+}
+
+func appendString(data []byte, s string) []byte {
+	data = binary.BigEndian.AppendUint16(data, uint16(len(s)))
+	return append(data, s...)
+}
+
+// appendPacket prefixes a packet's body with its fixed header, the remaining length is encoded 7 bits per byte.
+func appendPacket(header byte, body []byte) []byte {
+	packet := []byte{header}
+	length := len(body)
+	for {
+		b := byte(length % 128)
+		length /= 128
+		if length > 0 {
+			b |= 0x80
+		}
+		packet = append(packet, b)
+		if length == 0 {
+			break
+		}
+	}
+	return append(packet, body...)
+}
+
+func readPacket(reader *bufio.Reader) (byte, []byte, error) {
+	header, err := reader.ReadByte()
+	if err != nil {
+		return 0, nil, err
+	}
+	length := 0
+	for shift := 0; ; shift += 7 {
+		if shift > 21 {
+			return 0, nil, errors.New("malformed remaining length")
+		}
+		b, err := reader.ReadByte()
+		if err != nil {
+			return 0, nil, err
+		}
+		length |= int(b&0x7f) << shift
+		if b&0x80 == 0 {
+			break
+		}
+	}
+	body := make([]byte, length)
+	if _, err := io.ReadFull(reader, body); err != nil {
+		return 0, nil, err
+	}
+	return header >> 4, body, nil
+}
@@ -0,0 +1,177 @@
+package mqtt
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type packet struct {
+	header byte
+	body   []byte
+}
+
+// fakeBroker accepts one connection and answers CONNACK with returnCode. If answer is set it acknowledges QoS 1 publishes
+// and answers pings, otherwise it goes quiet after the CONNACK. Every packet it receives is sent on packets.
+func fakeBroker(t *testing.T, returnCode byte, answer bool) (string, chan packet) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+	packets := make(chan packet, 10)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		reader := bufio.NewReader(conn)
+		for {
+			header, err := reader.ReadByte()
+			if err != nil {
+				close(packets)
+				return
+			}
+			reader.UnreadByte()
+			packetType, body, err := readPacket(reader)
+			if err != nil {
+				close(packets)
+				return
+			}
+			select {
+			case packets <- packet{header: header, body: body}:
+			default:
+			}
+			switch packetType {
+			case packetConnect:
+				conn.Write([]byte{packetConnAck << 4, 2, 0, returnCode})
+			case packetPingReq:
+				if answer {
+					conn.Write([]byte{packetPingResp << 4, 0})
+				}
+			case packetPublish:
+				if answer && header&0x06 == 0x02 {
+					topicLength := binary.BigEndian.Uint16(body)
+					id := body[2+topicLength : 4+topicLength]
+					conn.Write([]byte{packetPubAck << 4, 2, id[0], id[1]})
+				}
+			}
+		}
+	}()
+	return "tcp://" + listener.Addr().String(), packets
+}
+
+func TestPublish(t *testing.T) {
+	broker, packets := fakeBroker(t, 0, true)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	client, err := Connect(ctx, Options{
+		Broker:    broker,
+		ClientID:  "robot1",
+		Username:  "user",
+		Password:  "pass",
+		KeepAlive: 10 * time.Second,
+		Will:      &Message{Topic: "status", Payload: []byte("offline"), QoS: 1, Retain: true},
+	})
+	require.NoError(t, err)
+
+	connect := <-packets
+	assert.Equal(t, byte(0x10), connect.header)
+	expected := []byte{0, 4, 'M', 'Q', 'T', 'T', 4, 0x80 | 0x40 | 0x20 | 0x08 | 0x04 | 0x02, 0, 10}
+	expected = append(expected, 0, 6, 'r', 'o', 'b', 'o', 't', '1')
+	expected = append(expected, 0, 6, 's', 't', 'a', 't', 'u', 's')
+	expected = append(expected, 0, 7, 'o', 'f', 'f', 'l', 'i', 'n', 'e')
+	expected = append(expected, 0, 4, 'u', 's', 'e', 'r', 0, 4, 'p', 'a', 's', 's')
+	assert.Equal(t, expected, connect.body)
+
+	require.NoError(t, client.Publish(ctx, Message{Topic: "a/b", Payload: []byte("{}"), Retain: true}))
+	publish := <-packets
+	assert.Equal(t, byte(0x31), publish.header)
+	assert.Equal(t, []byte{0, 3, 'a', '/', 'b', '{', '}'}, publish.body)
+
+	// QoS 1 waits for the PUBACK
+	require.NoError(t, client.Publish(ctx, Message{Topic: "a/b", Payload: []byte("1"), QoS: 1}))
+	publish = <-packets
+	assert.Equal(t, byte(0x32), publish.header)
+	assert.Equal(t, []byte{0, 3, 'a', '/', 'b', 0, 1, '1'}, publish.body)
+
+	assert.ErrorIs(t, client.Publish(ctx, Message{Topic: "a/b", QoS: 2}), ErrUnsupportedQoS)
+
+	require.NoError(t, client.Close())
+	disconnect := <-packets
+	assert.Equal(t, byte(0xe0), disconnect.header)
+	<-client.Done()
+	assert.ErrorIs(t, client.Err(), ErrClosed)
+	assert.ErrorIs(t, client.Publish(ctx, Message{Topic: "a/b"}), ErrClosed)
+}
+
+func TestKeepAlive(t *testing.T) {
+	broker, packets := fakeBroker(t, 0, true)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	client, err := Connect(ctx, Options{Broker: broker, ClientID: "robot1", KeepAlive: 100 * time.Millisecond})
+	require.NoError(t, err)
+	defer client.Close()
+	<-packets
+
+	// A broker that answers its pings keeps the connection up
+	for range 3 {
+		ping := <-packets
+		assert.Equal(t, byte(packetPingReq<<4), ping.header)
+	}
+	assert.NoError(t, client.Err())
+}
+
+func TestKeepAliveTimeout(t *testing.T) {
+	broker, _ := fakeBroker(t, 0, false)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	client, err := Connect(ctx, Options{Broker: broker, ClientID: "robot1", KeepAlive: 100 * time.Millisecond})
+	require.NoError(t, err)
+
+	select {
+	case <-client.Done():
+	case <-ctx.Done():
+		t.Fatal("the connection wasn't shut down when the broker stopped answering pings")
+	}
+	assert.ErrorIs(t, client.Err(), ErrPingTimeout)
+}
+
+func TestPublishAckTimeout(t *testing.T) {
+	broker, _ := fakeBroker(t, 0, false)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	client, err := Connect(ctx, Options{Broker: broker, ClientID: "robot1", KeepAlive: 10 * time.Second})
+	require.NoError(t, err)
+
+	publishCtx, publishCancel := context.WithTimeout(ctx, 100*time.Millisecond)
+	defer publishCancel()
+	assert.ErrorIs(t, client.Publish(publishCtx, Message{Topic: "a/b", Payload: []byte("1"), QoS: 1}), context.DeadlineExceeded)
+	// The publisher reconnects once the connection reports an error
+	assert.ErrorIs(t, client.Err(), ErrAckTimeout)
+}
+
+func TestConnectRefused(t *testing.T) {
+	broker, _ := fakeBroker(t, 5, true)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	_, err := Connect(ctx, Options{Broker: broker, ClientID: "robot1"})
+	assert.ErrorContains(t, err, "not authorized")
+
+	_, err = Connect(ctx, Options{Broker: "http://localhost", ClientID: "robot1"})
+	assert.ErrorContains(t, err, "unsupported broker scheme")
+}
+
+func TestAppendPacket(t *testing.T) {
+	assert.Equal(t, []byte{0xc0, 0}, appendPacket(0xc0, nil))
+	packet := appendPacket(0x30, make([]byte, 321))
+	// 321 is 65 + 2*128
+	assert.Equal(t, []byte{0x30, 0xc1, 0x02}, packet[:3])
+	assert.Len(t, packet, 324)
+}
@@ -0,0 +1,126 @@
+package otlp
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	grpcExportMethod = "/opentelemetry.proto.collector.metrics.v1.MetricsService/Export"
+	httpMetricsPath  = "/v1/metrics"
+)
+
+// Exporter sends encoded requests to a collector.
+type Exporter interface {
+	Export(ctx context.Context, request []byte) error
+	Close() error
+}
+
+type httpExporter struct {
+	client   *http.Client
+	endpoint string
+	headers  map[string]string
+}
+
+// NewHTTPExporter exports to an OTLP/HTTP endpoint, e.g. http://collector:4318. /v1/metrics is added when the
+// endpoint has no path.
+func NewHTTPExporter(endpoint string, headers map[string]string, tlsConfig *tls.Config) (Exporter, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("invalid endpoint: %w", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("OTLP/HTTP endpoint must be http:// or https://, got %s", endpoint)
+	}
+	if u.Path == "" || u.Path == "/" {
+		u.Path = httpMetricsPath
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+	return &httpExporter{
+		client:   &http.Client{Transport: transport},
+		endpoint: u.String(),
+		headers:  headers,
+	}, nil
+}
+
+func (e *httpExporter) Export(ctx context.Context, request []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.endpoint, bytes.NewReader(request))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-protobuf")
+	for key, value := range e.headers {
+		req.Header.Set(key, value)
+	}
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("collector returned %s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+	return nil
+}
+
+func (e *httpExporter) Close() error {
+	e.client.CloseIdleConnections()
+	return nil
+}
+
+// rawCodec passes already encoded protobuf through gRPC, so the OTLP protos don't need to be generated.
+type rawCodec struct{}
+
+func (rawCodec) Marshal(v any) ([]byte, error) {
+	return *v.(*[]byte), nil
+}
+
+func (rawCodec) Unmarshal(data []byte, v any) error {
+	*v.(*[]byte) = data
+	return nil
+}
+
+func (rawCodec) Name() string {
+	return "proto"
+}
+
+type grpcExporter struct {
+	conn    *grpc.ClientConn
+	headers metadata.MD
+}
+
+// NewGRPCExporter exports to an OTLP/gRPC endpoint, e.g. collector:4317. TLS is used unless plaintext is set.
+func NewGRPCExporter(endpoint string, headers map[string]string, tlsConfig *tls.Config, plaintext bool) (Exporter, error) {
+	creds := credentials.NewTLS(tlsConfig)
+	if plaintext {
+		creds = insecure.NewCredentials()
+	}
+	conn, err := grpc.NewClient(endpoint, grpc.WithTransportCredentials(creds))
+	if err != nil {
+		return nil, err
+	}
+	return &grpcExporter{conn: conn, headers: metadata.New(headers)}, nil
+}
+
+func (e *grpcExporter) Export(ctx context.Context, request []byte) error {
+	ctx = metadata.NewOutgoingContext(ctx, e.headers)
+	var response []byte
+	return e.conn.Invoke(ctx, grpcExportMethod, &request, &response, grpc.ForceCodec(rawCodec{}))
+}
+
+func (e *grpcExporter) Close() error {
+	return e.conn.Close()
+}
@@ -0,0 +1,133 @@
+package otlp
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// Field numbers from the OTLP metrics protos, opentelemetry/proto/metrics/v1/metrics.proto and friends
+const (
+	exportRequestResourceMetrics = 1
+
+	resourceMetricsResource     = 1
+	resourceMetricsScopeMetrics = 2
+
+	resourceAttributes = 1
+
+	scopeMetricsScope   = 1
+	scopeMetricsMetrics = 2
+
+	scopeName    = 1
+	scopeVersion = 2
+
+	keyValueKey   = 1
+	keyValueValue = 2
+
+	anyValueString = 1
+
+	metricName  = 1
+	metricGauge = 5
+	metricSum   = 7
+
+	gaugeDataPoints = 1
+
+	sumDataPoints             = 1
+	sumAggregationTemporality = 2
+	sumIsMonotonic            = 3
+
+	dataPointStartTime  = 2
+	dataPointTime       = 3
+	dataPointAsDouble   = 4
+	dataPointAttributes = 7
+
+	aggregationTemporalityCumulative = 2
+)
+
+// Metric is a single value to export.
+type Metric struct {
+	Name  string
+	Value float64
+	// Counter exports the value as a monotonic cumulative sum, otherwise it's a gauge
+	Counter    bool
+	Attributes map[string]string
+}
+
+// Request is an ExportMetricsServiceRequest for a single resource and instrumentation scope.
+type Request struct {
+	Resource     map[string]string
+	ScopeName    string
+	ScopeVersion string
+	// Start is when the counters started counting
+	Start   time.Time
+	Time    time.Time
+	Metrics []Metric
+}
+
+// Marshal encodes the request as protobuf, which both the gRPC and HTTP transports accept.
+func (r *Request) Marshal() []byte {
+	resource := appendAttributes(nil, resourceAttributes, r.Resource)
+
+	scope := protowire.AppendTag(nil, scopeName, protowire.BytesType)
+	scope = protowire.AppendString(scope, r.ScopeName)
+	scope = protowire.AppendTag(scope, scopeVersion, protowire.BytesType)
+	scope = protowire.AppendString(scope, r.ScopeVersion)
+
+	scopeMetrics := appendMessage(nil, scopeMetricsScope, scope)
+	for _, metric := range r.Metrics {
+		scopeMetrics = appendMessage(scopeMetrics, scopeMetricsMetrics, r.marshalMetric(metric))
+	}
+
+	resourceMetrics := appendMessage(nil, resourceMetricsResource, resource)
+	resourceMetrics = appendMessage(resourceMetrics, resourceMetricsScopeMetrics, scopeMetrics)
+	return appendMessage(nil, exportRequestResourceMetrics, resourceMetrics)
+}
+
+func (r *Request) marshalMetric(metric Metric) []byte {
+	point := appendAttributes(nil, dataPointAttributes, metric.Attributes)
+	if metric.Counter {
+		point = protowire.AppendTag(point, dataPointStartTime, protowire.Fixed64Type)
+		point = protowire.AppendFixed64(point, uint64(r.Start.UnixNano()))
+	}
+	point = protowire.AppendTag(point, dataPointTime, protowire.Fixed64Type)
+	point = protowire.AppendFixed64(point, uint64(r.Time.UnixNano()))
+	point = protowire.AppendTag(point, dataPointAsDouble, protowire.Fixed64Type)
+	point = protowire.AppendFixed64(point, math.Float64bits(metric.Value))
+
+	ret := protowire.AppendTag(nil, metricName, protowire.BytesType)
+	ret = protowire.AppendString(ret, metric.Name)
+	if !metric.Counter {
+		return appendMessage(ret, metricGauge, appendMessage(nil, gaugeDataPoints, point))
+	}
+	sum := appendMessage(nil, sumDataPoints, point)
+	sum = protowire.AppendTag(sum, sumAggregationTemporality, protowire.VarintType)
+	sum = protowire.AppendVarint(sum, aggregationTemporalityCumulative)
+	sum = protowire.AppendTag(sum, sumIsMonotonic, protowire.VarintType)
+	sum = protowire.AppendVarint(sum, 1)
+	return appendMessage(ret, metricSum, sum)
+}
+
+func appendMessage(b []byte, num protowire.Number, message []byte) []byte {
+	b = protowire.AppendTag(b, num, protowire.BytesType)
+	return protowire.AppendBytes(b, message)
+}
+
+// appendAttributes appends string KeyValues in key order, so the same attributes always encode the same way.
+func appendAttributes(b []byte, num protowire.Number, attributes map[string]string) []byte {
+	keys := make([]string, 0, len(attributes))
+	for key := range attributes {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		value := protowire.AppendTag(nil, anyValueString, protowire.BytesType)
+		value = protowire.AppendString(value, attributes[key])
+		keyValue := protowire.AppendTag(nil, keyValueKey, protowire.BytesType)
+		keyValue = protowire.AppendString(keyValue, key)
+		keyValue = appendMessage(keyValue, keyValueValue, value)
+		b = appendMessage(b, num, keyValue)
+	}
+	return b
+}
@@ -0,0 +1,159 @@
+package otlp
+
+import (
+	"context"
+	"io"
+	"math"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// decode splits a message into its fields, the values are the raw bytes of length delimited fields and the numbers of
+// the others.
+func decode(t *testing.T, b []byte) map[protowire.Number][]interface{} {
+	t.Helper()
+	fields := make(map[protowire.Number][]interface{})
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		require.GreaterOrEqual(t, n, 0)
+		b = b[n:]
+		switch typ {
+		case protowire.BytesType:
+			value, n := protowire.ConsumeBytes(b)
+			require.GreaterOrEqual(t, n, 0)
+			fields[num] = append(fields[num], value)
+			b = b[n:]
+		case protowire.Fixed64Type:
+			value, n := protowire.ConsumeFixed64(b)
+			require.GreaterOrEqual(t, n, 0)
+			fields[num] = append(fields[num], value)
+			b = b[n:]
+		case protowire.VarintType:
+			value, n := protowire.ConsumeVarint(b)
+			require.GreaterOrEqual(t, n, 0)
+			fields[num] = append(fields[num], value)
+			b = b[n:]
+		default:
+			t.Fatalf("unexpected wire type %d", typ)
+		}
+	}
+	return fields
+}
+
+func decodeAttributes(t *testing.T, values []interface{}) map[string]string {
+	attributes := make(map[string]string)
+	for _, value := range values {
+		keyValue := decode(t, value.([]byte))
+		anyValue := decode(t, keyValue[keyValueValue][0].([]byte))
+		attributes[string(keyValue[keyValueKey][0].([]byte))] = string(anyValue[anyValueString][0].([]byte))
+	}
+	return attributes
+}
+
+func TestMarshal(t *testing.T) {
+	start := time.Unix(1700000000, 0)
+	now := start.Add(time.Minute)
+	request := Request{
+		Resource:     map[string]string{"host.name": "robot1", "board.serial": "10000000abcdef01"},
+		ScopeName:    "hwmonitor",
+		ScopeVersion: "1.2.3",
+		Start:        start,
+		Time:         now,
+		Metrics: []Metric{
+			{Name: "cpu.temperature", Value: 45.5, Attributes: map[string]string{"sensor": "temperatures"}},
+			{Name: "usb.disconnect_events", Value: 3, Counter: true},
+		},
+	}
+
+	exportRequest := decode(t, request.Marshal())
+	require.Len(t, exportRequest[exportRequestResourceMetrics], 1)
+	resourceMetrics := decode(t, exportRequest[exportRequestResourceMetrics][0].([]byte))
+	resource := decode(t, resourceMetrics[resourceMetricsResource][0].([]byte))
+	assert.Equal(t, request.Resource, decodeAttributes(t, resource[resourceAttributes]))
+
+	scopeMetrics := decode(t, resourceMetrics[resourceMetricsScopeMetrics][0].([]byte))
+	scope := decode(t, scopeMetrics[scopeMetricsScope][0].([]byte))
+	assert.Equal(t, "hwmonitor", string(scope[scopeName][0].([]byte)))
+	assert.Equal(t, "1.2.3", string(scope[scopeVersion][0].([]byte)))
+	require.Len(t, scopeMetrics[scopeMetricsMetrics], 2)
+
+	gauge := decode(t, scopeMetrics[scopeMetricsMetrics][0].([]byte))
+	assert.Equal(t, "cpu.temperature", string(gauge[metricName][0].([]byte)))
+	gaugePoints := decode(t, gauge[metricGauge][0].([]byte))
+	point := decode(t, gaugePoints[gaugeDataPoints][0].([]byte))
+	assert.Equal(t, map[string]string{"sensor": "temperatures"}, decodeAttributes(t, point[dataPointAttributes]))
+	assert.Equal(t, uint64(now.UnixNano()), point[dataPointTime][0])
+	assert.Equal(t, 45.5, math.Float64frombits(point[dataPointAsDouble][0].(uint64)))
+	assert.NotContains(t, point, protowire.Number(dataPointStartTime))
+
+	counter := decode(t, scopeMetrics[scopeMetricsMetrics][1].([]byte))
+	assert.Equal(t, "usb.disconnect_events", string(counter[metricName][0].([]byte)))
+	sum := decode(t, counter[metricSum][0].([]byte))
+	assert.Equal(t, uint64(aggregationTemporalityCumulative), sum[sumAggregationTemporality][0])
+	assert.Equal(t, uint64(1), sum[sumIsMonotonic][0])
+	point = decode(t, sum[sumDataPoints][0].([]byte))
+	assert.Equal(t, uint64(start.UnixNano()), point[dataPointStartTime][0])
+	assert.Equal(t, 3.0, math.Float64frombits(point[dataPointAsDouble][0].(uint64)))
+}
+
+func TestHTTPExporter(t *testing.T) {
+	var received []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/metrics", r.URL.Path)
+		assert.Equal(t, "application/x-protobuf", r.Header.Get("Content-Type"))
+		assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+		received, _ = io.ReadAll(r.Body)
+		if string(received) == "bad" {
+			http.Error(w, "invalid request", http.StatusBadRequest)
+		}
+	}))
+	defer server.Close()
+
+	exporter, err := NewHTTPExporter(server.URL, map[string]string{"Authorization": "Bearer token"}, nil)
+	require.NoError(t, err)
+	defer exporter.Close()
+	require.NoError(t, exporter.Export(context.Background(), []byte("request")))
+	assert.Equal(t, []byte("request"), received)
+	assert.ErrorContains(t, exporter.Export(context.Background(), []byte("bad")), "invalid request")
+
+	_, err = NewHTTPExporter("collector:4318", nil, nil)
+	assert.Error(t, err)
+}
+
+func TestGRPCExporter(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	received := make(chan []byte, 1)
+	server := grpc.NewServer(grpc.ForceServerCodec(rawCodec{}), grpc.UnknownServiceHandler(func(srv any, stream grpc.ServerStream) error {
+		method, _ := grpc.MethodFromServerStream(stream)
+		assert.Equal(t, grpcExportMethod, method)
+		md, _ := metadata.FromIncomingContext(stream.Context())
+		assert.Equal(t, []string{"token"}, md.Get("api-key"))
+		var request []byte
+		if err := stream.RecvMsg(&request); err != nil {
+			return err
+		}
+		received <- request
+		response := []byte{}
+		return stream.SendMsg(&response)
+	}))
+	go server.Serve(listener)
+	defer server.Stop()
+
+	exporter, err := NewGRPCExporter(listener.Addr().String(), map[string]string{"api-key": "token"}, nil, true)
+	require.NoError(t, err)
+	defer exporter.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	require.NoError(t, exporter.Export(ctx, []byte("request")))
+	assert.Equal(t, []byte("request"), <-received)
+}
@@ -0,0 +1,232 @@
+package parquet
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+)
+
+// Type is the type of a column's values.
+type Type int
+
+const (
+	// Timestamp columns hold Unix milliseconds in Int64s and are annotated as UTC timestamps
+	Timestamp Type = iota
+	// String columns hold UTF-8 Strings
+	String
+	// Double columns hold Doubles
+	Double
+)
+
+// Column is a named column and its values, only the slice matching its type is used. Every column is required, so
+// there are no nulls.
+type Column struct {
+	Name    string
+	Type    Type
+	Int64s  []int64
+	Strings []string
+	Doubles []float64
+}
+
+func (c *Column) len() int {
+	switch c.Type {
+	case Timestamp:
+		return len(c.Int64s)
+	case String:
+		return len(c.Strings)
+	default:
+		return len(c.Doubles)
+	}
+}
+
+// Parquet enums
+const (
+	typeInt64      = 2
+	typeDouble     = 5
+	typeByteArray  = 6
+	repRequired    = 0
+	convertedUTF8  = 0
+	convertedTSMs  = 9
+	encodingPlain  = 0
+	encodingRLE    = 3
+	codecNone      = 0
+	pageTypeData   = 0
+	formatVersion  = 1
+	fileMagic      = "PAR1"
+	logicalString  = 1
+	logicalTime    = 8
+	timeUnitMillis = 1
+)
+
+// Write writes the columns as a Parquet file with a single row group. Values are PLAIN encoded and uncompressed, so
+// any reader can read the file, but it's larger than one written by a full Parquet library.
+func Write(w io.Writer, columns []Column, createdBy string) error {
+	if len(columns) == 0 {
+		return errors.New("at least one column is required")
+	}
+	rows := columns[0].len()
+	for _, c := range columns {
+		if c.len() != rows {
+			return fmt.Errorf("column %s has %d values, %d were expected", c.Name, c.len(), rows)
+		}
+	}
+
+	offset := int64(len(fileMagic))
+	if _, err := io.WriteString(w, fileMagic); err != nil {
+		return err
+	}
+	chunks := make([]columnChunk, 0, len(columns))
+	var totalSize int64
+	for i := range columns {
+		page := encodeValues(&columns[i])
+		if len(page) > math.MaxInt32 {
+			return fmt.Errorf("column %s is too large for one page", columns[i].Name)
+		}
+		header := pageHeader(len(page), rows)
+		if _, err := w.Write(header); err != nil {
+			return err
+		}
+		if _, err := w.Write(page); err != nil {
+			return err
+		}
+		size := int64(len(header) + len(page))
+		chunks = append(chunks, columnChunk{column: &columns[i], offset: offset, size: size})
+		offset += size
+		totalSize += size
+	}
+
+	footer := fileMetaData(chunks, int64(rows), totalSize, createdBy)
+	footer = binary.LittleEndian.AppendUint32(footer, uint32(len(footer)))
+	footer = append(footer, fileMagic...)
+	_, err := w.Write(footer)
+	return err
+}
+
+type columnChunk struct {
+	column *Column
+	offset int64
+	size   int64
+}
+
+func encodeValues(c *Column) []byte {
+	var buf []byte
+	switch c.Type {
+	case Timestamp:
+		buf = make([]byte, 0, 8*len(c.Int64s))
+		for _, v := range c.Int64s {
+			buf = binary.LittleEndian.AppendUint64(buf, uint64(v))
+		}
+	case String:
+		for _, v := range c.Strings {
+			buf = binary.LittleEndian.AppendUint32(buf, uint32(len(v)))
+			buf = append(buf, v...)
+		}
+	default:
+		buf = make([]byte, 0, 8*len(c.Doubles))
+		for _, v := range c.Doubles {
+			buf = binary.LittleEndian.AppendUint64(buf, math.Float64bits(v))
+		}
+	}
+	return buf
+}
+
+func pageHeader(size, values int) []byte {
+	t := &thriftWriter{}
+	t.structBegin()
+	t.i32(1, pageTypeData)
+	t.i32(2, int32(size)) // uncompressed_page_size
+	t.i32(3, int32(size)) // compressed_page_size
+	t.structField(5)      // data_page_header
+	t.i32(1, int32(values))
+	t.i32(2, encodingPlain)
+	t.i32(3, encodingRLE) // definition_level_encoding, unused by required columns
+	t.i32(4, encodingRLE) // repetition_level_encoding
+	t.structEnd()
+	t.structEnd()
+	return t.buf
+}
+
+func physicalType(c *Column) int32 {
+	switch c.Type {
+	case Timestamp:
+		return typeInt64
+	case String:
+		return typeByteArray
+	default:
+		return typeDouble
+	}
+}
+
+func fileMetaData(chunks []columnChunk, rows, totalSize int64, createdBy string) []byte {
+	t := &thriftWriter{}
+	t.structBegin()
+	t.i32(1, formatVersion)
+
+	t.listField(2, compactStruct, len(chunks)+1) // schema, the root and then the columns
+	t.structBegin()
+	t.string(4, "schema")
+	t.i32(5, int32(len(chunks)))
+	t.structEnd()
+	for _, chunk := range chunks {
+		c := chunk.column
+		t.structBegin()
+		t.i32(1, physicalType(c))
+		t.i32(3, repRequired)
+		t.string(4, c.Name)
+		switch c.Type {
+		case Timestamp:
+			t.i32(6, convertedTSMs)
+			t.structField(10) // logicalType
+			t.structField(logicalTime)
+			t.bool(1, true) // isAdjustedToUTC
+			t.structField(2)
+			t.structField(timeUnitMillis)
+			t.structEnd()
+			t.structEnd()
+			t.structEnd()
+			t.structEnd()
+		case String:
+			t.i32(6, convertedUTF8)
+			t.structField(10)
+			t.structField(logicalString)
+			t.structEnd()
+			t.structEnd()
+		}
+		t.structEnd()
+	}
+
+	t.i64(3, rows)
+
+	t.listField(4, compactStruct, 1) // row_groups
+	t.structBegin()
+	t.listField(1, compactStruct, len(chunks))
+	for _, chunk := range chunks {
+		t.structBegin()
+		t.i64(2, chunk.offset) // file_offset
+		t.structField(3)       // meta_data
+		t.i32(1, physicalType(chunk.column))
+		t.listField(2, compactI32, 2)
+		t.varint(encodingPlain)
+		t.varint(encodingRLE)
+		t.listField(3, compactBinary, 1)
+		t.rawString(chunk.column.Name)
+		t.i32(4, codecNone)
+		t.i64(5, rows)
+		t.i64(6, chunk.size) // total_uncompressed_size
+		t.i64(7, chunk.size) // total_compressed_size
+		t.i64(9, chunk.offset)
+		t.structEnd()
+		t.structEnd()
+	}
+	t.i64(2, totalSize)
+	t.i64(3, rows)
+	t.structEnd()
+
+	if createdBy != "" {
+		t.string(6, createdBy)
+	}
+	t.structEnd()
+	return t.buf
+}
@@ -0,0 +1,156 @@
+package parquet
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// thriftReader decodes the Thrift compact protocol into maps of field id to value, enough to check what Write wrote.
+type thriftReader struct {
+	buf []byte
+}
+
+func (r *thriftReader) byte() byte {
+	b := r.buf[0]
+	r.buf = r.buf[1:]
+	return b
+}
+
+func (r *thriftReader) varint() int64 {
+	v, n := binary.Varint(r.buf)
+	r.buf = r.buf[n:]
+	return v
+}
+
+func (r *thriftReader) uvarint() uint64 {
+	v, n := binary.Uvarint(r.buf)
+	r.buf = r.buf[n:]
+	return v
+}
+
+func (r *thriftReader) value(typ byte) interface{} {
+	switch typ {
+	case compactTrue:
+		return true
+	case compactFalse:
+		return false
+	case compactI32, compactI64:
+		return r.varint()
+	case compactBinary:
+		n := r.uvarint()
+		s := string(r.buf[:n])
+		r.buf = r.buf[n:]
+		return s
+	case compactList:
+		header := r.byte()
+		size, elemType := int(header>>4), header&0x0f
+		if size == 15 {
+			size = int(r.uvarint())
+		}
+		list := make([]interface{}, size)
+		for i := range list {
+			list[i] = r.value(elemType)
+		}
+		return list
+	case compactStruct:
+		return r.structValue()
+	default:
+		panic("unexpected type")
+	}
+}
+
+func (r *thriftReader) structValue() map[int16]interface{} {
+	fields := make(map[int16]interface{})
+	var id int16
+	for {
+		header := r.byte()
+		if header == 0 {
+			return fields
+		}
+		if delta := int16(header >> 4); delta != 0 {
+			id += delta
+		} else {
+			id = int16(r.varint())
+		}
+		fields[id] = r.value(header & 0x0f)
+	}
+}
+
+func TestWrite(t *testing.T) {
+	columns := []Column{
+		{Name: "time", Type: Timestamp, Int64s: []int64{1700000000000, 1700000001000}},
+		{Name: "key", Type: String, Strings: []string{"usage", "temp"}},
+		{Name: "value", Type: Double, Doubles: []float64{12.5, -3}},
+	}
+	var buf bytes.Buffer
+	require.NoError(t, Write(&buf, columns, "hwmonitor"))
+	file := buf.Bytes()
+
+	assert.Equal(t, fileMagic, string(file[:4]))
+	assert.Equal(t, fileMagic, string(file[len(file)-4:]))
+	footerLen := int(binary.LittleEndian.Uint32(file[len(file)-8:]))
+	footer := (&thriftReader{buf: file[len(file)-8-footerLen : len(file)-8]}).structValue()
+
+	assert.Equal(t, int64(2), footer[3])
+	assert.Equal(t, "hwmonitor", footer[6])
+	schema := footer[2].([]interface{})
+	require.Len(t, schema, 4)
+	assert.Equal(t, int64(3), schema[0].(map[int16]interface{})[5])
+	timeSchema := schema[1].(map[int16]interface{})
+	assert.Equal(t, "time", timeSchema[4])
+	assert.Equal(t, int64(typeInt64), timeSchema[1])
+	timestamp := timeSchema[10].(map[int16]interface{})[logicalTime].(map[int16]interface{})
+	assert.Equal(t, true, timestamp[1])
+	assert.Contains(t, timestamp[2], int16(timeUnitMillis))
+
+	rowGroups := footer[4].([]interface{})
+	require.Len(t, rowGroups, 1)
+	chunks := rowGroups[0].(map[int16]interface{})[1].([]interface{})
+	require.Len(t, chunks, 3)
+
+	pages := make([][]byte, len(chunks))
+	for i, chunk := range chunks {
+		meta := chunk.(map[int16]interface{})[3].(map[int16]interface{})
+		assert.Equal(t, []interface{}{columns[i].Name}, meta[3])
+		assert.Equal(t, int64(2), meta[5])
+		offset, size := meta[9].(int64), meta[7].(int64)
+		r := &thriftReader{buf: file[offset : offset+size]}
+		header := r.structValue()
+		assert.Equal(t, int64(pageTypeData), header[1])
+		assert.Equal(t, int64(2), header[5].(map[int16]interface{})[1])
+		assert.Equal(t, header[2], int64(len(r.buf)))
+		pages[i] = r.buf
+	}
+
+	assert.Equal(t, uint64(1700000001000), binary.LittleEndian.Uint64(pages[0][8:]))
+	assert.Equal(t, []byte("\x05\x00\x00\x00usage\x04\x00\x00\x00temp"), pages[1])
+	assert.Equal(t, -3.0, math.Float64frombits(binary.LittleEndian.Uint64(pages[2][8:])))
+}
+
+func TestWriteManyColumns(t *testing.T) {
+	// More than 14 list elements use the long list header
+	columns := make([]Column, 20)
+	for i := range columns {
+		columns[i] = Column{Name: string(rune('a' + i)), Type: Double, Doubles: []float64{float64(i)}}
+	}
+	var buf bytes.Buffer
+	require.NoError(t, Write(&buf, columns, ""))
+	file := buf.Bytes()
+	footerLen := int(binary.LittleEndian.Uint32(file[len(file)-8:]))
+	footer := (&thriftReader{buf: file[len(file)-8-footerLen : len(file)-8]}).structValue()
+	assert.Len(t, footer[2], 21)
+}
+
+func TestWriteMismatchedColumns(t *testing.T) {
+	err := Write(&bytes.Buffer{}, []Column{
+		{Name: "time", Type: Timestamp, Int64s: []int64{1}},
+		{Name: "value", Type: Double},
+	}, "")
+	assert.Error(t, err)
+	assert.Error(t, Write(&bytes.Buffer{}, nil, ""))
+}
@@ -0,0 +1,96 @@
+package parquet
+
+import (
+	"encoding/binary"
+)
+
+// Thrift compact protocol types
+const (
+	compactTrue   = 1
+	compactFalse  = 2
+	compactI32    = 5
+	compactI64    = 6
+	compactBinary = 8
+	compactList   = 9
+	compactStruct = 12
+)
+
+// thriftWriter encodes the Thrift compact protocol, which Parquet uses for its page headers and footer. Only what those
+// structs need is implemented.
+type thriftWriter struct {
+	buf     []byte
+	lastIDs []int16 // The last field id of each open struct, field headers are deltas from it
+}
+
+func (w *thriftWriter) fieldHeader(id int16, typ byte) {
+	last := &w.lastIDs[len(w.lastIDs)-1]
+	if delta := id - *last; delta > 0 && delta <= 15 {
+		w.buf = append(w.buf, byte(delta)<<4|typ)
+	} else {
+		w.buf = append(w.buf, typ)
+		w.varint(int64(id))
+	}
+	*last = id
+}
+
+func (w *thriftWriter) varint(v int64) {
+	w.buf = binary.AppendVarint(w.buf, v)
+}
+
+func (w *thriftWriter) structBegin() {
+	w.lastIDs = append(w.lastIDs, 0)
+}
+
+func (w *thriftWriter) structEnd() {
+	w.buf = append(w.buf, 0)
+	w.lastIDs = w.lastIDs[:len(w.lastIDs)-1]
+}
+
+func (w *thriftWriter) i32(id int16, v int32) {
+	w.fieldHeader(id, compactI32)
+	w.varint(int64(v))
+}
+
+func (w *thriftWriter) i64(id int16, v int64) {
+	w.fieldHeader(id, compactI64)
+	w.varint(v)
+}
+
+func (w *thriftWriter) bool(id int16, v bool) {
+	if v {
+		w.fieldHeader(id, compactTrue)
+	} else {
+		w.fieldHeader(id, compactFalse)
+	}
+}
+
+func (w *thriftWriter) string(id int16, v string) {
+	w.fieldHeader(id, compactBinary)
+	w.rawString(v)
+}
+
+func (w *thriftWriter) rawString(v string) {
+	w.buf = binary.AppendUvarint(w.buf, uint64(len(v)))
+	w.buf = append(w.buf, v...)
+}
+
+// structField starts a struct valued field, it's ended with structEnd.
+func (w *thriftWriter) structField(id int16) {
+	w.fieldHeader(id, compactStruct)
+	w.structBegin()
+}
+
+// listField starts a list valued field, its elements are written right after.
+func (w *thriftWriter) listField(id int16, elemType byte, size int) {
+	w.fieldHeader(id, compactList)
+	w.listHeader(elemType, size)
+}
+
+func (w *thriftWriter) listHeader(elemType byte, size int) {
+	if size < 15 {
+		w.buf = append(w.buf, byte(size)<<4|elemType)
+		return
+	}
+	w.buf = append(w.buf, 0xf0|elemType)
+	w.buf = binary.AppendUvarint(w.buf, uint64(size))
+}
@@ -2,8 +2,8 @@ package sensors
 
 import (
 	"context"
+	"errors"
 	"os"
-	"path/filepath"
 	"strconv"
 	"strings"
 	"time"
@@ -13,7 +13,7 @@ import (
 
 type ClockSensor interface {
 	Close() error
-	GetReadingMap() (map[string]interface{}, error)
+	GetReadingMap(ctx context.Context) (map[string]interface{}, error)
 	Name() string
 }
 
@@ -22,13 +22,13 @@ func GetSysFsClock(ctx context.Context, path string) (int64, error) {
 }
 
 func GetSysFsCpuPaths() ([]string, error) {
-	paths, err := filepath.Glob("/sys/devices/system/cpu/cpu[0-9]*")
+	paths, err := utils.Glob("/sys/devices/system/cpu/cpu[0-9]*")
 	if err != nil {
 		return nil, err
 	}
 	validPaths := make([]string, 0)
 	for _, path := range paths {
-		if _, err := os.Stat(path); os.IsNotExist(err) {
+		if _, err := utils.Stat(path); errors.Is(err, os.ErrNotExist) {
 			continue
 		}
 		validPaths = append(validPaths, path)
@@ -3,7 +3,7 @@ package sensors
 import (
 	"context"
 	"errors"
-	"os"
+	"maps"
 	"path/filepath"
 	"slices"
 	"strconv"
@@ -70,8 +70,8 @@ func (p *Process) Cmdline() (string, error) {
 	return cmdline, nil
 }
 
-func ReadCPUStats() (map[string]CPUCoreStats, error) {
-	rawStats, err := cpu.Times(true)
+func ReadCPUStats(ctx context.Context) (map[string]CPUCoreStats, error) {
+	rawStats, err := cpu.TimesWithContext(ctx, true)
 	if err != nil {
 		return nil, err
 	}
@@ -106,6 +106,22 @@ func ReadCPUStats() (map[string]CPUCoreStats, error) {
 	return stats, nil
 }
 
+// cpuStatsAge is how long a read of the CPU stats is shared, it's well under the intervals usage is calculated over so
+// a monitor never calculates usage from the same read twice.
+const cpuStatsAge = 50 * time.Millisecond
+
+// ReadSharedCPUStats is ReadCPUStats shared with the other monitors reading them within cpuStatsAge, the stats returned
+// are the caller's own.
+func ReadSharedCPUStats(ctx context.Context) (map[string]CPUCoreStats, error) {
+	stats, err := utils.ReadShared(ctx, "cpu_stats", cpuStatsAge, func(ctx context.Context) (map[string]CPUCoreStats, error) {
+		return ReadCPUStats(ctx)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return maps.Clone(stats), nil
+}
+
 type ProcessMonitor struct {
 	Processes         utils.OrderedMap[int32, *Process] // List of processes to monitor
 	lastSync          time.Time
@@ -198,25 +214,25 @@ func (p *ProcessMonitor) GetProcessesWithContext(ctx context.Context) (utils.Ord
 		}
 	}
 
-	process.EnableBootTimeCache(true)
 	ret := utils.NewOrderedMap[int32, *Process]()
-	procs, err := process.ProcessesWithContext(ctx)
+	procs, err := listProcesses(ctx)
 	if err != nil {
 		p.logger.Debugf("Failed to get processes: %v", err)
 		return nil, errors.Join(errors.New("failed to get processes"), err)
 	}
-	for _, proc := range procs {
+	for _, entry := range procs {
+		// Each monitor gets its own process, so their CPU percentages are calculated from their own previous samples
+		proc := &process.Process{Pid: entry.pid}
 		// The linux kernel seems to limit the contents of /proc/<pid>/comm to 15 bytes,
 		// if the process name is longer than that we need to fall back to /proc/<pid>/cmdline
 		if len(p.name) <= 15 {
-			procName, err := getProcName(proc) // Get the process name
-			if err != nil {
-				p.logger.Debugf("Failed to get process name for PID %d: %v", proc.Pid, err)
+			if entry.commErr != nil {
+				p.logger.Debugf("Failed to get process name for PID %d: %v", proc.Pid, entry.commErr)
 				continue
 			}
-			if procName == p.name {
-				p.logger.Debugf("Found process %s with PID %d", procName, proc.Pid)
-				ret.Set(proc.Pid, &Process{Process: proc, PID: proc.Pid, Name: procName}) // Store the process in the ordered map
+			if entry.comm == p.name {
+				p.logger.Debugf("Found process %s with PID %d", entry.comm, proc.Pid)
+				ret.Set(proc.Pid, &Process{Process: proc, PID: proc.Pid, Name: entry.comm}) // Store the process in the ordered map
 				continue
 			}
 		} else {
@@ -246,10 +262,38 @@ func (p *ProcessMonitor) GetProcessesWithContext(ctx context.Context) (utils.Ord
 	return ret, nil
 }
 
-func getProcName(proc *process.Process) (string, error) {
-	pid := proc.Pid
+// processListAge is how long the process list is shared, the monitors of different processes that sync at about the same
+// time enumerate the processes once between them. Monitors with the same interval are scheduled at the same phase, so
+// they sync within it of each other.
+const processListAge = time.Second
+
+type processEntry struct {
+	pid     int32
+	comm    string
+	commErr error
+}
+
+// listProcesses returns every process with its name, the list is shared by the monitors that sync within
+// processListAge of each other.
+func listProcesses(ctx context.Context) ([]processEntry, error) {
+	return utils.ReadShared(ctx, "processes", processListAge, func(ctx context.Context) ([]processEntry, error) {
+		process.EnableBootTimeCache(true)
+		pids, err := process.PidsWithContext(ctx)
+		if err != nil {
+			return nil, err
+		}
+		entries := make([]processEntry, 0, len(pids))
+		for _, pid := range pids {
+			comm, err := getProcName(pid)
+			entries = append(entries, processEntry{pid: pid, comm: comm, commErr: err})
+		}
+		return entries, nil
+	})
+}
+
+func getProcName(pid int32) (string, error) {
 	statPath := filepath.Join("/proc", strconv.Itoa(int(pid)), "comm")
-	contents, err := os.ReadFile(statPath)
+	contents, err := utils.ReadFile(statPath)
 	if err != nil {
 		return "", err
 	}
@@ -258,7 +302,7 @@ func getProcName(proc *process.Process) (string, error) {
 
 func getProcCmdline(proc *process.Process) (string, error) {
 	cmdlinePath := filepath.Join("/proc", strconv.Itoa(int(proc.Pid)), "cmdline")
-	data, err := os.ReadFile(cmdlinePath)
+	data, err := utils.ReadFile(cmdlinePath)
 	if err != nil {
 		return "", err
 	}
@@ -1,7 +1,7 @@
 package sensors
 
 import (
-	"os/exec"
+	"context"
 
 	"go.viam.com/rdk/logging"
 
@@ -9,8 +9,7 @@ import (
 )
 
 func HasNvidiaSmiCommand(logger logging.Logger) bool {
-	cmd := exec.Command("which", "nvidia-smi")
-	stdOut, stdErr := cmd.CombinedOutput()
+	stdOut, stdErr := utils.CombinedOutput(context.Background(), "which", "nvidia-smi")
 	logger.Debugf("which nvidia-smi command output: %s", stdOut)
 	if stdErr != nil {
 		logger.Debugf("nvidia-smi command not found: %v", stdErr)
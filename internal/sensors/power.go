@@ -1,8 +1,10 @@
 package sensors
 
+import "context"
+
 type PowerSensor interface {
 	Close() error
-	GetReading() (voltage, current, power float64, err error)
-	GetReadingMap() (map[string]interface{}, error)
+	GetReading(ctx context.Context) (voltage, current, power float64, err error)
+	GetReadingMap(ctx context.Context) (map[string]interface{}, error)
 	GetName() string
 }
@@ -0,0 +1,267 @@
+package snmp
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// Value types beyond the int, string and OID Go already has
+type (
+	Counter32 uint32
+	Gauge32   uint32
+	TimeTicks uint32
+	Counter64 uint64
+)
+
+// exception is one of the SNMPv2 varbind exceptions, sent in place of a value.
+type exception byte
+
+const (
+	version2c = 1
+
+	pduGetRequest     = 0xa0
+	pduGetNextRequest = 0xa1
+	pduResponse       = 0xa2
+	pduSetRequest     = 0xa3
+	pduGetBulkRequest = 0xa5
+
+	errorTooBig      = 1
+	errorNotWritable = 17
+
+	// The largest response that fits in an Ethernet frame, the size most managers expect
+	maxResponseSize = 1472
+	// Space for everything in a response other than the varbinds
+	responseOverhead = 64
+)
+
+var (
+	// ErrBadCommunity is returned for requests with the wrong community, which go unanswered.
+	ErrBadCommunity = errors.New("unknown community")
+	// ErrUnsupportedVersion is returned for anything other than SNMPv2c, which goes unanswered.
+	ErrUnsupportedVersion = errors.New("only SNMPv2c is supported")
+)
+
+// Variable is an OID and its value: an int, string, OID, Counter32, Gauge32, TimeTicks or Counter64.
+type Variable struct {
+	OID   OID
+	Value interface{}
+}
+
+// Table is a read only MIB, sorted so it can be walked.
+type Table []Variable
+
+func NewTable(variables []Variable) Table {
+	t := append(Table{}, variables...)
+	sort.Slice(t, func(i, j int) bool { return t[i].OID.Compare(t[j].OID) < 0 })
+	return t
+}
+
+func (t Table) get(oid OID) Variable {
+	i := sort.Search(len(t), func(i int) bool { return t[i].OID.Compare(oid) >= 0 })
+	if i < len(t) && t[i].OID.Compare(oid) == 0 {
+		return t[i]
+	}
+	// An instance of an object that exists, e.g. .1 of a scalar whose only instance is .0
+	if len(oid) > 1 {
+		for _, v := range t {
+			if len(v.OID) == len(oid) && v.OID[:len(v.OID)-1].Compare(oid[:len(oid)-1]) == 0 {
+				return Variable{OID: oid, Value: exception(tagNoSuchInstance)}
+			}
+		}
+	}
+	return Variable{OID: oid, Value: exception(tagNoSuchObject)}
+}
+
+func (t Table) next(oid OID) Variable {
+	i := sort.Search(len(t), func(i int) bool { return t[i].OID.Compare(oid) > 0 })
+	if i < len(t) {
+		return t[i]
+	}
+	return Variable{OID: oid, Value: exception(tagEndOfMibView)}
+}
+
+// Handle answers an SNMPv2c request from table. Set requests are refused, the table is read only.
+func Handle(request []byte, community string, table Table) ([]byte, error) {
+	message, _, err := readTLV(request)
+	if err != nil {
+		return nil, err
+	}
+	if message.tag != tagSequence {
+		return nil, errors.New("not an SNMP message")
+	}
+	versionTLV, rest, err := readTLV(message.value)
+	if err != nil {
+		return nil, err
+	}
+	version, err := decodeInt(versionTLV.value)
+	if err != nil {
+		return nil, err
+	}
+	if version != version2c {
+		return nil, ErrUnsupportedVersion
+	}
+	communityTLV, rest, err := readTLV(rest)
+	if err != nil {
+		return nil, err
+	}
+	if string(communityTLV.value) != community {
+		return nil, ErrBadCommunity
+	}
+	pdu, _, err := readTLV(rest)
+	if err != nil {
+		return nil, err
+	}
+
+	requestID, oids, nonRepeaters, maxRepetitions, err := decodePDU(pdu.value)
+	if err != nil {
+		return nil, err
+	}
+	var variables []Variable
+	errorStatus, errorIndex := 0, 0
+	switch pdu.tag {
+	case pduGetRequest:
+		for _, oid := range oids {
+			variables = append(variables, table.get(oid))
+		}
+	case pduGetNextRequest:
+		for _, oid := range oids {
+			variables = append(variables, table.next(oid))
+		}
+	case pduGetBulkRequest:
+		variables = table.bulk(oids, nonRepeaters, maxRepetitions)
+	case pduSetRequest:
+		errorStatus, errorIndex = errorNotWritable, 1
+		for _, oid := range oids {
+			variables = append(variables, Variable{OID: oid})
+		}
+	default:
+		return nil, fmt.Errorf("unsupported PDU 0x%x", pdu.tag)
+	}
+
+	varbinds := encodeVarbinds(variables)
+	if len(varbinds)+responseOverhead+len(community) > maxResponseSize {
+		errorStatus, errorIndex, varbinds = errorTooBig, 0, nil
+	}
+	return encodeResponse(community, requestID, errorStatus, errorIndex, varbinds), nil
+}
+
+// bulk walks the non repeaters once and the rest maxRepetitions times, stopping short when the response would be too
+// large or every walk has run off the end.
+func (t Table) bulk(oids []OID, nonRepeaters, maxRepetitions int) []Variable {
+	nonRepeaters = min(max(nonRepeaters, 0), len(oids))
+	var variables []Variable
+	size := 0
+	add := func(v Variable) bool {
+		size += len(encodeVarbind(v))
+		if size+responseOverhead > maxResponseSize {
+			return false
+		}
+		variables = append(variables, v)
+		return true
+	}
+	for _, oid := range oids[:nonRepeaters] {
+		if !add(t.next(oid)) {
+			return variables
+		}
+	}
+	repeaters := append([]OID{}, oids[nonRepeaters:]...)
+	for range max(maxRepetitions, 0) {
+		ended := true
+		for i, oid := range repeaters {
+			v := t.next(oid)
+			if !add(v) {
+				return variables
+			}
+			if _, ok := v.Value.(exception); !ok {
+				ended = false
+			}
+			repeaters[i] = v.OID
+		}
+		if ended {
+			break
+		}
+	}
+	return variables
+}
+
+// decodePDU decodes a request PDU, for GetBulk the error status and index fields are the non repeaters and max
+// repetitions.
+func decodePDU(b []byte) (requestID int64, oids []OID, nonRepeaters, maxRepetitions int, err error) {
+	var fields [3]int64
+	for i := range fields {
+		var field tlv
+		if field, b, err = readTLV(b); err != nil {
+			return
+		}
+		if fields[i], err = decodeInt(field.value); err != nil {
+			return
+		}
+	}
+	varbinds, _, err := readTLV(b)
+	if err != nil {
+		return
+	}
+	for rest := varbinds.value; len(rest) > 0; {
+		var varbind, name tlv
+		if varbind, rest, err = readTLV(rest); err != nil {
+			return
+		}
+		if name, _, err = readTLV(varbind.value); err != nil {
+			return
+		}
+		var oid OID
+		if oid, err = decodeOID(name.value); err != nil {
+			return
+		}
+		oids = append(oids, oid)
+	}
+	return fields[0], oids, int(fields[1]), int(fields[2]), nil
+}
+
+func encodeVarbinds(variables []Variable) []byte {
+	var b []byte
+	for _, v := range variables {
+		b = append(b, encodeVarbind(v)...)
+	}
+	return b
+}
+
+func encodeVarbind(v Variable) []byte {
+	b := appendTLV(nil, tagOID, encodeOID(v.OID))
+	switch value := v.Value.(type) {
+	case int:
+		b = appendTLV(b, tagInteger, encodeInt(int64(value)))
+	case string:
+		b = appendTLV(b, tagOctetString, []byte(value))
+	case OID:
+		b = appendTLV(b, tagOID, encodeOID(value))
+	case Counter32:
+		b = appendTLV(b, tagCounter32, encodeUint(uint64(value)))
+	case Gauge32:
+		b = appendTLV(b, tagGauge32, encodeUint(uint64(value)))
+	case TimeTicks:
+		b = appendTLV(b, tagTimeTicks, encodeUint(uint64(value)))
+	case Counter64:
+		b = appendTLV(b, tagCounter64, encodeUint(uint64(value)))
+	case exception:
+		b = appendTLV(b, byte(value), nil)
+	default:
+		b = appendTLV(b, tagNull, nil)
+	}
+	return appendTLV(nil, tagSequence, b)
+}
+
+func encodeResponse(community string, requestID int64, errorStatus, errorIndex int, varbinds []byte) []byte {
+	var pdu []byte
+	pdu = appendTLV(pdu, tagInteger, encodeInt(requestID))
+	pdu = appendTLV(pdu, tagInteger, encodeInt(int64(errorStatus)))
+	pdu = appendTLV(pdu, tagInteger, encodeInt(int64(errorIndex)))
+	pdu = appendTLV(pdu, tagSequence, varbinds)
+
+	var message []byte
+	message = appendTLV(message, tagInteger, encodeInt(version2c))
+	message = appendTLV(message, tagOctetString, []byte(community))
+	message = appendTLV(message, pduResponse, pdu)
+	return appendTLV(nil, tagSequence, message)
+}
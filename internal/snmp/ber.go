@@ -0,0 +1,209 @@
+package snmp
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// BER tags of the types SNMP uses
+const (
+	tagInteger        = 0x02
+	tagOctetString    = 0x04
+	tagNull           = 0x05
+	tagOID            = 0x06
+	tagSequence       = 0x30
+	tagCounter32      = 0x41
+	tagGauge32        = 0x42
+	tagTimeTicks      = 0x43
+	tagCounter64      = 0x46
+	tagNoSuchObject   = 0x80
+	tagNoSuchInstance = 0x81
+	tagEndOfMibView   = 0x82
+)
+
+var errTruncated = errors.New("truncated message")
+
+// OID is an object identifier, e.g. 1.3.6.1.2.1.1.5.0
+type OID []uint32
+
+func ParseOID(s string) (OID, error) {
+	parts := strings.Split(strings.TrimPrefix(s, "."), ".")
+	if len(parts) < 2 {
+		return nil, fmt.Errorf("invalid OID %q", s)
+	}
+	oid := make(OID, 0, len(parts))
+	for _, part := range parts {
+		arc, err := strconv.ParseUint(part, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid OID %q", s)
+		}
+		oid = append(oid, uint32(arc))
+	}
+	return oid, nil
+}
+
+func (o OID) String() string {
+	parts := make([]string, len(o))
+	for i, arc := range o {
+		parts[i] = strconv.FormatUint(uint64(arc), 10)
+	}
+	return strings.Join(parts, ".")
+}
+
+// Compare orders OIDs lexicographically, the order GetNext walks them in.
+func (o OID) Compare(other OID) int {
+	for i := 0; i < len(o) && i < len(other); i++ {
+		if o[i] != other[i] {
+			if o[i] < other[i] {
+				return -1
+			}
+			return 1
+		}
+	}
+	return len(o) - len(other)
+}
+
+// Append returns a new OID with arcs added, o itself is never changed.
+func (o OID) Append(arcs ...uint32) OID {
+	return append(append(OID{}, o...), arcs...)
+}
+
+// HasPrefix reports whether o is prefix or below it.
+func (o OID) HasPrefix(prefix OID) bool {
+	return len(o) >= len(prefix) && o[:len(prefix)].Compare(prefix) == 0
+}
+
+// tlv is a decoded BER element.
+type tlv struct {
+	tag   byte
+	value []byte
+}
+
+// readTLV decodes the element at the start of b and returns it with the rest of b.
+func readTLV(b []byte) (tlv, []byte, error) {
+	if len(b) < 2 {
+		return tlv{}, nil, errTruncated
+	}
+	tag := b[0]
+	length := int(b[1])
+	b = b[2:]
+	if length&0x80 != 0 {
+		n := length & 0x7f
+		if n == 0 || n > 4 || len(b) < n {
+			return tlv{}, nil, errors.New("invalid length")
+		}
+		length = 0
+		for _, c := range b[:n] {
+			length = length<<8 | int(c)
+		}
+		b = b[n:]
+	}
+	if length < 0 || len(b) < length {
+		return tlv{}, nil, errTruncated
+	}
+	return tlv{tag: tag, value: b[:length]}, b[length:], nil
+}
+
+func appendTLV(b []byte, tag byte, value []byte) []byte {
+	b = append(b, tag)
+	switch n := len(value); {
+	case n < 0x80:
+		b = append(b, byte(n))
+	case n <= 0xff:
+		b = append(b, 0x81, byte(n))
+	case n <= 0xffff:
+		b = append(b, 0x82, byte(n>>8), byte(n))
+	default:
+		b = append(b, 0x83, byte(n>>16), byte(n>>8), byte(n))
+	}
+	return append(b, value...)
+}
+
+func decodeInt(value []byte) (int64, error) {
+	if len(value) == 0 || len(value) > 8 {
+		return 0, errors.New("invalid integer")
+	}
+	// Sign extend from the first byte
+	n := int64(int8(value[0]))
+	for _, c := range value[1:] {
+		n = n<<8 | int64(c)
+	}
+	return n, nil
+}
+
+func encodeInt(n int64) []byte {
+	b := []byte{byte(n)}
+	for n > 127 || n < -128 {
+		n >>= 8
+		b = append([]byte{byte(n)}, b...)
+	}
+	return b
+}
+
+func encodeUint(n uint64) []byte {
+	b := []byte{byte(n)}
+	for n > 0xff {
+		n >>= 8
+		b = append([]byte{byte(n)}, b...)
+	}
+	// A leading 1 bit would make it negative
+	if b[0]&0x80 != 0 {
+		b = append([]byte{0}, b...)
+	}
+	return b
+}
+
+func decodeOID(value []byte) (OID, error) {
+	if len(value) == 0 {
+		return nil, errors.New("invalid OID")
+	}
+	var arcs []uint32
+	var arc uint64
+	for i, c := range value {
+		arc = arc<<7 | uint64(c&0x7f)
+		if arc > 0xffffffff {
+			return nil, errors.New("invalid OID")
+		}
+		if c&0x80 != 0 {
+			if i == len(value)-1 {
+				return nil, errors.New("invalid OID")
+			}
+			continue
+		}
+		if len(arcs) == 0 {
+			// The first byte holds the first two arcs as 40*x+y
+			first := min(arc/40, 2)
+			arcs = append(arcs, uint32(first), uint32(arc-first*40))
+		} else {
+			arcs = append(arcs, uint32(arc))
+		}
+		arc = 0
+	}
+	return arcs, nil
+}
+
+func encodeOID(oid OID) []byte {
+	if len(oid) < 2 {
+		return []byte{0}
+	}
+	b := appendBase128(nil, uint64(oid[0])*40+uint64(oid[1]))
+	for _, arc := range oid[2:] {
+		b = appendBase128(b, uint64(arc))
+	}
+	return b
+}
+
+func appendBase128(b []byte, n uint64) []byte {
+	start := len(b)
+	b = append(b, byte(n&0x7f))
+	for n >>= 7; n > 0; n >>= 7 {
+		b = append(b, byte(n&0x7f)|0x80)
+	}
+	// The bytes were appended least significant first
+	for i, j := start, len(b)-1; i < j; i, j = i+1, j-1 {
+		b[i], b[j] = b[j], b[i]
+	}
+	return b
+}
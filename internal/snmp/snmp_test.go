@@ -0,0 +1,178 @@
+package snmp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// getSysName is what snmpget -v2c -c public sends for 1.3.6.1.2.1.1.5.0
+var getSysName = []byte{
+	0x30, 0x29, 0x02, 0x01, 0x01, 0x04, 0x06, 'p', 'u', 'b', 'l', 'i', 'c',
+	0xa0, 0x1c, 0x02, 0x04, 0x12, 0x34, 0x56, 0x78, 0x02, 0x01, 0x00, 0x02, 0x01, 0x00,
+	0x30, 0x0e, 0x30, 0x0c, 0x06, 0x08, 0x2b, 0x06, 0x01, 0x02, 0x01, 0x01, 0x05, 0x00, 0x05, 0x00,
+}
+
+var testTable = NewTable([]Variable{
+	{OID: OID{1, 3, 6, 1, 2, 1, 1, 5, 0}, Value: "robot1"},
+	{OID: OID{1, 3, 6, 1, 2, 1, 1, 3, 0}, Value: TimeTicks(4200)},
+	{OID: OID{1, 3, 6, 1, 4, 1, 8072, 9999, 9999, 1, 1, 0}, Value: -5},
+	{OID: OID{1, 3, 6, 1, 4, 1, 8072, 9999, 9999, 1, 2, 0}, Value: Counter64(1 << 40)},
+})
+
+func request(pduType byte, community string, fields [3]int64, oids ...OID) []byte {
+	var varbinds []byte
+	for _, oid := range oids {
+		varbinds = append(varbinds, encodeVarbind(Variable{OID: oid})...)
+	}
+	var pdu []byte
+	for _, field := range fields {
+		pdu = appendTLV(pdu, tagInteger, encodeInt(field))
+	}
+	pdu = appendTLV(pdu, tagSequence, varbinds)
+	message := appendTLV(nil, tagInteger, encodeInt(version2c))
+	message = appendTLV(message, tagOctetString, []byte(community))
+	message = appendTLV(message, pduType, pdu)
+	return appendTLV(nil, tagSequence, message)
+}
+
+type response struct {
+	requestID   int64
+	errorStatus int64
+	variables   []Variable
+}
+
+func decodeResponse(t *testing.T, b []byte) response {
+	t.Helper()
+	message, _, err := readTLV(b)
+	require.NoError(t, err)
+	_, rest, err := readTLV(message.value)
+	require.NoError(t, err)
+	_, rest, err = readTLV(rest)
+	require.NoError(t, err)
+	pdu, _, err := readTLV(rest)
+	require.NoError(t, err)
+	require.Equal(t, byte(pduResponse), pdu.tag)
+
+	var ret response
+	var fields [3]int64
+	rest = pdu.value
+	for i := range fields {
+		var field tlv
+		field, rest, err = readTLV(rest)
+		require.NoError(t, err)
+		fields[i], err = decodeInt(field.value)
+		require.NoError(t, err)
+	}
+	ret.requestID, ret.errorStatus = fields[0], fields[1]
+	varbinds, _, err := readTLV(rest)
+	require.NoError(t, err)
+	for rest := varbinds.value; len(rest) > 0; {
+		var varbind tlv
+		varbind, rest, err = readTLV(rest)
+		require.NoError(t, err)
+		name, valueBytes, err := readTLV(varbind.value)
+		require.NoError(t, err)
+		oid, err := decodeOID(name.value)
+		require.NoError(t, err)
+		value, _, err := readTLV(valueBytes)
+		require.NoError(t, err)
+		var v interface{}
+		switch value.tag {
+		case tagInteger:
+			n, _ := decodeInt(value.value)
+			v = int(n)
+		case tagOctetString:
+			v = string(value.value)
+		case tagTimeTicks:
+			n, _ := decodeInt(append([]byte{0}, value.value...))
+			v = TimeTicks(n)
+		case tagCounter64:
+			n, _ := decodeInt(append([]byte{0}, value.value...))
+			v = Counter64(n)
+		default:
+			v = exception(value.tag)
+		}
+		ret.variables = append(ret.variables, Variable{OID: oid, Value: v})
+	}
+	return ret
+}
+
+func TestOID(t *testing.T) {
+	oid, err := ParseOID(".1.3.6.1.4.1.8072.9999.9999")
+	require.NoError(t, err)
+	assert.Equal(t, OID{1, 3, 6, 1, 4, 1, 8072, 9999, 9999}, oid)
+	assert.Equal(t, "1.3.6.1.4.1.8072.9999.9999", oid.String())
+	assert.Equal(t, []byte{0x2b, 0x06, 0x01, 0x04, 0x01, 0xbf, 0x08, 0xce, 0x0f, 0xce, 0x0f}, encodeOID(oid))
+	decoded, err := decodeOID(encodeOID(oid))
+	require.NoError(t, err)
+	assert.Equal(t, oid, decoded)
+
+	assert.Negative(t, OID{1, 3, 6}.Compare(OID{1, 3, 6, 1}))
+	assert.Positive(t, OID{1, 3, 7}.Compare(OID{1, 3, 6, 1}))
+	assert.True(t, OID{1, 3, 6, 1}.HasPrefix(OID{1, 3, 6}))
+
+	for _, s := range []string{"", "1", "1.3.x", "1.3.-1"} {
+		_, err := ParseOID(s)
+		assert.Error(t, err, s)
+	}
+}
+
+func TestIntegers(t *testing.T) {
+	for n, b := range map[int64][]byte{0: {0}, 127: {0x7f}, 128: {0x00, 0x80}, -1: {0xff}, -129: {0xff, 0x7f}, 256: {0x01, 0x00}} {
+		assert.Equal(t, b, encodeInt(n), n)
+		decoded, err := decodeInt(b)
+		require.NoError(t, err)
+		assert.Equal(t, n, decoded)
+	}
+	assert.Equal(t, []byte{0x00, 0xff, 0xff, 0xff, 0xff}, encodeUint(0xffffffff))
+}
+
+func TestGet(t *testing.T) {
+	b, err := Handle(getSysName, "public", testTable)
+	require.NoError(t, err)
+	r := decodeResponse(t, b)
+	assert.Equal(t, int64(0x12345678), r.requestID)
+	assert.Equal(t, []Variable{{OID: OID{1, 3, 6, 1, 2, 1, 1, 5, 0}, Value: "robot1"}}, r.variables)
+
+	b, err = Handle(request(pduGetRequest, "public", [3]int64{1, 0, 0}, OID{1, 3, 6, 1, 2, 1, 1, 5, 1}, OID{1, 3, 6, 1, 2, 1, 2}), "public", testTable)
+	require.NoError(t, err)
+	r = decodeResponse(t, b)
+	assert.Equal(t, exception(tagNoSuchInstance), r.variables[0].Value)
+	assert.Equal(t, exception(tagNoSuchObject), r.variables[1].Value)
+
+	_, err = Handle(getSysName, "private", testTable)
+	assert.ErrorIs(t, err, ErrBadCommunity)
+	_, err = Handle(getSysName[:20], "public", testTable)
+	assert.Error(t, err)
+}
+
+func TestGetNext(t *testing.T) {
+	b, err := Handle(request(pduGetNextRequest, "public", [3]int64{2, 0, 0}, OID{1, 3, 6, 1}, OID{1, 3, 6, 1, 4, 1, 8072, 9999, 9999, 1, 2, 0}), "public", testTable)
+	require.NoError(t, err)
+	r := decodeResponse(t, b)
+	assert.Equal(t, []Variable{
+		{OID: OID{1, 3, 6, 1, 2, 1, 1, 3, 0}, Value: TimeTicks(4200)},
+		{OID: OID{1, 3, 6, 1, 4, 1, 8072, 9999, 9999, 1, 2, 0}, Value: exception(tagEndOfMibView)},
+	}, r.variables)
+}
+
+func TestGetBulk(t *testing.T) {
+	// One non repeater, then walk the private subtree up to 10 times
+	b, err := Handle(request(pduGetBulkRequest, "public", [3]int64{3, 1, 10}, OID{1, 3, 6, 1, 2, 1, 1, 5}, OID{1, 3, 6, 1, 4, 1, 8072}), "public", testTable)
+	require.NoError(t, err)
+	r := decodeResponse(t, b)
+	assert.Equal(t, []Variable{
+		{OID: OID{1, 3, 6, 1, 2, 1, 1, 5, 0}, Value: "robot1"},
+		{OID: OID{1, 3, 6, 1, 4, 1, 8072, 9999, 9999, 1, 1, 0}, Value: -5},
+		{OID: OID{1, 3, 6, 1, 4, 1, 8072, 9999, 9999, 1, 2, 0}, Value: Counter64(1 << 40)},
+		{OID: OID{1, 3, 6, 1, 4, 1, 8072, 9999, 9999, 1, 2, 0}, Value: exception(tagEndOfMibView)},
+	}, r.variables)
+}
+
+func TestSet(t *testing.T) {
+	b, err := Handle(request(pduSetRequest, "public", [3]int64{4, 0, 0}, OID{1, 3, 6, 1, 2, 1, 1, 5, 0}), "public", testTable)
+	require.NoError(t, err)
+	assert.Equal(t, int64(errorNotWritable), decodeResponse(t, b).errorStatus)
+}
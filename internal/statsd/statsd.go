@@ -0,0 +1,118 @@
+package statsd
+
+import (
+	"errors"
+	"math"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	TypeGauge   = "g"
+	TypeCounter = "c"
+)
+
+var (
+	// StatsD uses : | @ and # as separators, names and tags with them would be misread
+	nameSanitizer = strings.NewReplacer(":", "_", "|", "_", "@", "_", "#", "_", "\n", "_")
+	tagSanitizer  = strings.NewReplacer("|", "_", ",", "_", "#", "_", "\n", "_")
+)
+
+// Metric is a single StatsD line.
+type Metric struct {
+	Name  string
+	Value float64
+	// Type is TypeGauge or TypeCounter, gauges are the default
+	Type string
+	// Tags are sent as DogStatsD tags, which the Datadog agent and Telegraf (with datadog_extensions) understand
+	Tags map[string]string
+}
+
+// Line encodes the metric, e.g. hwmonitor.cpu.usage:12.5|g|#sensor:cpu,site:plant1
+// It returns an empty string for values StatsD can't represent.
+func (m *Metric) Line() string {
+	if math.IsNaN(m.Value) || math.IsInf(m.Value, 0) {
+		return ""
+	}
+	typ := m.Type
+	if typ == "" {
+		typ = TypeGauge
+	}
+
+	var line strings.Builder
+	line.WriteString(nameSanitizer.Replace(m.Name))
+	line.WriteString(":" + strconv.FormatFloat(m.Value, 'f', -1, 64))
+	line.WriteString("|" + typ)
+	keys := make([]string, 0, len(m.Tags))
+	for key := range m.Tags {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for i, key := range keys {
+		if i == 0 {
+			line.WriteString("|#")
+		} else {
+			line.WriteString(",")
+		}
+		line.WriteString(tagSanitizer.Replace(key))
+		if value := m.Tags[key]; value != "" {
+			line.WriteString(":" + tagSanitizer.Replace(value))
+		}
+	}
+	return line.String()
+}
+
+// Client sends lines to a StatsD server over UDP, packing as many as fit in each packet.
+type Client struct {
+	conn          net.Conn
+	maxPacketSize int
+}
+
+// Dial resolves address once, e.g. 127.0.0.1:8125. Nothing is sent, so this succeeds whether or not anything is
+// listening.
+func Dial(address string, maxPacketSize int) (*Client, error) {
+	conn, err := net.Dial("udp", address)
+	if err != nil {
+		return nil, err
+	}
+	return &Client{conn: conn, maxPacketSize: maxPacketSize}, nil
+}
+
+// Send writes lines in as few packets as possible and returns how many packets were sent. A line longer than the
+// packet size is sent in a packet of its own.
+func (c *Client) Send(lines []string) (int, error) {
+	packets := 0
+	var errs []error
+	var packet []byte
+	flush := func() {
+		if len(packet) == 0 {
+			return
+		}
+		if _, err := c.conn.Write(packet); err != nil {
+			errs = append(errs, err)
+		} else {
+			packets++
+		}
+		packet = packet[:0]
+	}
+	for _, line := range lines {
+		if line == "" {
+			continue
+		}
+		if len(packet) > 0 && len(packet)+1+len(line) > c.maxPacketSize {
+			flush()
+		}
+		if len(packet) > 0 {
+			packet = append(packet, '\n')
+		}
+		packet = append(packet, line...)
+	}
+	flush()
+	return packets, errors.Join(errs...)
+}
+
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
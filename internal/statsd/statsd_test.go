@@ -0,0 +1,51 @@
+package statsd
+
+import (
+	"math"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLine(t *testing.T) {
+	m := Metric{Name: "hwmonitor.cpu:usage", Value: 12.5, Tags: map[string]string{"sensor": "cpu", "site": "plant|1", "canary": ""}}
+	assert.Equal(t, "hwmonitor.cpu_usage:12.5|g|#canary,sensor:cpu,site:plant_1", m.Line())
+
+	m = Metric{Name: "hwmonitor.disconnect_events", Value: 2, Type: TypeCounter}
+	assert.Equal(t, "hwmonitor.disconnect_events:2|c", m.Line())
+
+	m = Metric{Name: "hwmonitor.big", Value: 1e21}
+	assert.Equal(t, "hwmonitor.big:1000000000000000000000|g", m.Line())
+
+	m = Metric{Name: "hwmonitor.nan", Value: math.NaN()}
+	assert.Equal(t, "", m.Line())
+}
+
+func TestSend(t *testing.T) {
+	server, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer server.Close()
+
+	client, err := Dial(server.LocalAddr().String(), 20)
+	require.NoError(t, err)
+	defer client.Close()
+
+	lines := []string{"a:1|g", "b:2|g", "", "c:3|g", strings.Repeat("d", 30) + ":4|g"}
+	packets, err := client.Send(lines)
+	require.NoError(t, err)
+	assert.Equal(t, 2, packets)
+
+	var received []string
+	buf := make([]byte, 1024)
+	require.NoError(t, server.SetReadDeadline(time.Now().Add(5*time.Second)))
+	for range packets {
+		n, _, err := server.ReadFrom(buf)
+		require.NoError(t, err)
+		received = append(received, string(buf[:n]))
+	}
+	assert.Equal(t, []string{"a:1|g\nb:2|g\nc:3|g", lines[4]}, received)
+}
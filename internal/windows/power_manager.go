@@ -1,6 +1,7 @@
 package windows
 
 import (
+	"context"
 	"errors"
 
 	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
@@ -22,10 +23,10 @@ func NewPowerManager(config *WindowsConfig, logger logging.Logger) (*windowsPowe
 	return nil, utils.ErrPlatformNotSupported
 }
 
-func (pm *windowsPowerManager) ApplyPowerMode() (rebootRequired bool, err error) {
+func (pm *windowsPowerManager) ApplyPowerMode(ctx context.Context) (rebootRequired bool, err error) {
 	return false, utils.ErrPlatformNotSupported
 }
 
-func (pm *windowsPowerManager) GetCurrentPowerMode() (interface{}, error) {
+func (pm *windowsPowerManager) GetCurrentPowerMode(ctx context.Context) (interface{}, error) {
 	return nil, utils.ErrPlatformNotSupported
 }
@@ -0,0 +1,10 @@
+package jetsonpowermode
+
+type ComponentConfig struct {
+	// ExpectedPowerMode is the nvpmodel mode name the robot should be running in, e.g. MAXN
+	ExpectedPowerMode string `json:"expected_power_mode,omitempty"`
+}
+
+func (conf *ComponentConfig) Validate(path string) ([]string, error) {
+	return nil, nil
+}
@@ -0,0 +1,187 @@
+package jetsonpowermode
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"go.viam.com/rdk/components/sensor"
+	"go.viam.com/rdk/logging"
+	"go.viam.com/rdk/resource"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/linux"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/linux/jetson"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+var (
+	Model       = resource.NewModel(utils.Namespace, "hwmonitor", "jetson_power_mode")
+	API         = sensor.API
+	PrettyName  = "Jetson Power Mode Sensor"
+	Description = "A sensor that reports the nvpmodel power mode and jetson_clocks state, and can switch between them"
+	Version     = utils.Version
+)
+
+type Config struct {
+	resource.Named
+	mu                sync.RWMutex
+	logger            logging.Logger
+	cancelCtx         context.Context
+	cancelFunc        func()
+	expectedPowerMode string
+}
+
+func init() {
+	resource.RegisterComponent(
+		API,
+		Model,
+		resource.Registration[sensor.Sensor, *ComponentConfig]{Constructor: utils.FilterReadings(NewSensor)})
+}
+
+func NewSensor(ctx context.Context, deps resource.Dependencies, conf resource.Config, logger logging.Logger) (sensor.Sensor, error) {
+	logger.Infof("Starting %s %s", PrettyName, Version)
+	if !linux.IsFamily(linux.BoardFamilyJetson) {
+		return nil, jetson.ErrNotJetson
+	}
+	cancelCtx, cancelFunc := context.WithCancel(context.Background())
+
+	b := Config{
+		Named:      conf.ResourceName().AsNamed(),
+		logger:     logger,
+		cancelCtx:  cancelCtx,
+		cancelFunc: cancelFunc,
+		mu:         sync.RWMutex{},
+	}
+
+	if err := b.Reconfigure(ctx, deps, conf); err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
+
+func (c *Config) Reconfigure(ctx context.Context, _ resource.Dependencies, conf resource.Config) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.logger.Debugf("Reconfiguring %s", PrettyName)
+
+	newConf, err := resource.NativeConfig[*ComponentConfig](conf)
+	if err != nil {
+		return err
+	}
+
+	// In case the module has changed name
+	c.Named = conf.ResourceName().AsNamed()
+	c.expectedPowerMode = newConf.ExpectedPowerMode
+
+	return nil
+}
+
+func (c *Config) Readings(ctx context.Context, extra map[string]interface{}) (map[string]interface{}, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	mode, err := jetson.GetPowerMode(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read power mode: %w", err)
+	}
+	clocks, err := jetson.GetClockState(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read clock state: %w", err)
+	}
+
+	ret := map[string]interface{}{
+		"power_mode_id":         mode.ID,
+		"power_mode_name":       mode.Name,
+		"jetson_clocks_enabled": clocks.Locked,
+	}
+	if c.expectedPowerMode != "" {
+		ret["expected_power_mode"] = c.expectedPowerMode
+		ret["power_mode_as_expected"] = strings.EqualFold(mode.Name, c.expectedPowerMode)
+	}
+	return ret, nil
+}
+
+func (c *Config) DoCommand(ctx context.Context, cmd map[string]interface{}) (map[string]interface{}, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	command, ok := cmd["command"].(string)
+	if !ok {
+		return nil, errors.New("missing or invalid 'command' field")
+	}
+
+	switch command {
+	case "list_power_modes":
+		return c.handleListPowerModes(ctx)
+	case "set_power_mode":
+		return c.handleSetPowerMode(ctx, cmd)
+	case "set_jetson_clocks":
+		return c.handleSetJetsonClocks(ctx, cmd)
+	default:
+		return nil, fmt.Errorf("unknown command: %s", command)
+	}
+}
+
+func (c *Config) handleListPowerModes(ctx context.Context) (map[string]interface{}, error) {
+	modes, err := jetson.ListPowerModes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ret := make([]interface{}, 0, len(modes))
+	for _, mode := range modes {
+		ret = append(ret, map[string]interface{}{"id": mode.ID, "name": mode.Name})
+	}
+	return map[string]interface{}{"power_modes": ret}, nil
+}
+
+func (c *Config) handleSetPowerMode(ctx context.Context, cmd map[string]interface{}) (map[string]interface{}, error) {
+	var id int
+	switch mode := cmd["mode"].(type) {
+	case float64:
+		id = int(mode)
+	case string:
+		modes, err := jetson.ListPowerModes(ctx)
+		if err != nil {
+			return nil, err
+		}
+		powerMode, err := jetson.FindPowerMode(modes, mode)
+		if err != nil {
+			return nil, err
+		}
+		id = powerMode.ID
+	default:
+		return nil, errors.New("missing or invalid 'mode' parameter for set_power_mode command, expected a mode id or name")
+	}
+
+	c.logger.Infof("Setting power mode to %d", id)
+	rebootRequired, err := jetson.SetPowerMode(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if rebootRequired {
+		c.logger.Warnf("Power mode %d takes effect after a reboot", id)
+	}
+	return map[string]interface{}{"status": "ok", "power_mode_id": id, "reboot_required": rebootRequired}, nil
+}
+
+func (c *Config) handleSetJetsonClocks(ctx context.Context, cmd map[string]interface{}) (map[string]interface{}, error) {
+	enable, ok := cmd["enable"].(bool)
+	if !ok {
+		return nil, errors.New("missing or invalid 'enable' parameter for set_jetson_clocks command")
+	}
+	c.logger.Infof("Setting jetson_clocks enabled to %v", enable)
+	if err := jetson.SetJetsonClocks(ctx, enable); err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{"status": "ok", "jetson_clocks_enabled": enable}, nil
+}
+
+func (c *Config) Close(ctx context.Context) error {
+	c.logger.Infof("Shutting down %s", PrettyName)
+	return nil
+}
+
+func (c *Config) Ready(ctx context.Context, extra map[string]interface{}) (bool, error) {
+	return false, nil
+}
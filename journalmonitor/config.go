@@ -0,0 +1,22 @@
+package journalmonitor
+
+import "fmt"
+
+var priorities = []string{"emerg", "alert", "crit", "err", "warning", "notice", "info", "debug"}
+
+type ComponentConfig struct {
+	Priority string   `json:"priority"` // Lowest priority that is counted, defaults to err
+	Units    []string `json:"units"`    // Only count entries of these units, defaults to every unit
+}
+
+func (conf *ComponentConfig) Validate(path string) ([]string, error) {
+	if conf.Priority == "" {
+		return nil, nil
+	}
+	for _, priority := range priorities {
+		if conf.Priority == priority {
+			return nil, nil
+		}
+	}
+	return nil, fmt.Errorf("invalid priority %s, must be one of %v", conf.Priority, priorities)
+}
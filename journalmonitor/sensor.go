@@ -0,0 +1,151 @@
+package journalmonitor
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"go.viam.com/rdk/components/sensor"
+	"go.viam.com/rdk/logging"
+	"go.viam.com/rdk/resource"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/linux"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+var (
+	Model       = resource.NewModel(utils.Namespace, "hwmonitor", "journal_monitor")
+	API         = sensor.API
+	PrettyName  = "Journal Error Rate Monitor"
+	Description = "A sensor that reports the rate of systemd journal entries at error priority or above"
+	Version     = utils.Version
+)
+
+type Config struct {
+	resource.Named
+	mu         sync.RWMutex
+	logger     logging.Logger
+	cancelCtx  context.Context
+	cancelFunc func()
+	priority   string
+	units      []string
+	cursor     string
+	lastPoll   time.Time
+}
+
+func init() {
+	resource.RegisterComponent(
+		API,
+		Model,
+		resource.Registration[sensor.Sensor, *ComponentConfig]{Constructor: utils.FilterReadings(NewSensor)})
+	utils.RegisterSimulator(Model, simulate)
+}
+
+func NewSensor(ctx context.Context, deps resource.Dependencies, conf resource.Config, logger logging.Logger) (sensor.Sensor, error) {
+	logger.Infof("Starting %s %s", PrettyName, Version)
+	cancelCtx, cancelFunc := context.WithCancel(context.Background())
+
+	b := Config{
+		Named:      conf.ResourceName().AsNamed(),
+		logger:     logger,
+		cancelCtx:  cancelCtx,
+		cancelFunc: cancelFunc,
+		mu:         sync.RWMutex{},
+	}
+
+	if err := b.Reconfigure(ctx, deps, conf); err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
+
+func (c *Config) Reconfigure(ctx context.Context, _ resource.Dependencies, conf resource.Config) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.logger.Debugf("Reconfiguring %s", PrettyName)
+
+	newConf, err := resource.NativeConfig[*ComponentConfig](conf)
+	if err != nil {
+		return err
+	}
+	c.priority = newConf.Priority
+	if c.priority == "" {
+		c.priority = "err"
+	}
+	c.units = newConf.Units
+
+	// Only entries logged after the sensor started are counted, a reconfigure carries on from the last entry read so
+	// none are missed
+	if c.cursor == "" {
+		cursor, err := linux.JournalCursor(ctx)
+		if err != nil {
+			return err
+		}
+		c.cursor = cursor
+		c.lastPoll = time.Now()
+	}
+
+	// In case the module has changed name
+	c.Named = conf.ResourceName().AsNamed()
+
+	return nil
+}
+
+func (c *Config) Readings(ctx context.Context, extra map[string]interface{}) (map[string]interface{}, error) {
+	// The cursor moves on every reading, so this needs the write lock
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entries, err := linux.ReadJournal(ctx, c.cursor, c.priority, c.units)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	elapsed := now.Sub(c.lastPoll)
+	c.lastPoll = now
+	if len(entries) > 0 {
+		c.cursor = entries[len(entries)-1].Cursor
+	}
+
+	ret := make(map[string]interface{})
+	perUnit := make(map[string]int)
+	for _, unit := range c.units {
+		// Configured units are always reported so a quiet unit reads 0 instead of disappearing
+		perUnit[unit] = 0
+	}
+	for _, entry := range entries {
+		perUnit[entry.Unit]++
+	}
+	ret["error_count"] = len(entries)
+	ret["errors_per_minute"] = 0.0
+	if elapsed > 0 {
+		ret["errors_per_minute"] = utils.RoundValue(float64(len(entries))/elapsed.Minutes(), 2)
+	}
+	for unit, count := range perUnit {
+		if unit == "" {
+			unit = "unknown"
+		}
+		ret[unitName(unit)+"_error_count"] = count
+	}
+	if len(entries) > 0 {
+		last := entries[len(entries)-1]
+		ret["last_error_unit"] = last.Unit
+		ret["last_error_message"] = last.Message
+		ret["last_error_time"] = last.Time.Format(time.RFC3339)
+	}
+	return ret, nil
+}
+
+// unitName converts a unit into a reading key prefix, e.g. viam-agent.service becomes viam_agent_service.
+func unitName(unit string) string {
+	return strings.NewReplacer("-", "_", ".", "_", "@", "_", "/", "_").Replace(unit)
+}
+
+func (c *Config) Close(ctx context.Context) error {
+	c.logger.Infof("Shutting down %s", PrettyName)
+	return nil
+}
+
+func (c *Config) Ready(ctx context.Context, extra map[string]interface{}) (bool, error) {
+	return false, nil
+}
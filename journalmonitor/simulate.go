@@ -0,0 +1,17 @@
+package journalmonitor
+
+import "github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+
+// simulate returns a journal without errors, the configured units are reported as quiet.
+func simulate(sim *utils.Simulation) map[string]interface{} {
+	ret := map[string]interface{}{
+		"error_count":       0,
+		"errors_per_minute": 0.0,
+	}
+	if conf, ok := sim.Config().(*ComponentConfig); ok {
+		for _, unit := range conf.Units {
+			ret[unitName(unit)+"_error_count"] = 0
+		}
+	}
+	return ret
+}
@@ -0,0 +1,31 @@
+package kernellogmonitor
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/linux"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+type ComponentConfig struct {
+	SleepTimeMs int `json:"sleep_time_ms"`
+	// Patterns maps a reading name to a regular expression matched against every kernel log message,
+	// defaults to patterns for common SBC problems
+	Patterns map[string]string `json:"patterns"`
+}
+
+func (conf *ComponentConfig) Validate(path string) ([]string, error) {
+	for name, pattern := range conf.Patterns {
+		if _, err := regexp.Compile(pattern); err != nil {
+			return nil, fmt.Errorf("invalid pattern for %s: %w", name, err)
+		}
+	}
+	return nil, nil
+}
+
+// Permissions implements utils.PermissionReporter, the kernel log is read from /dev/kmsg.
+func (conf *ComponentConfig) Permissions(ctx context.Context) utils.Permissions {
+	return linux.KmsgPermissions(ctx)
+}
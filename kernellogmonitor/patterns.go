@@ -0,0 +1,59 @@
+package kernellogmonitor
+
+import (
+	"regexp"
+	"time"
+)
+
+var defaultPatterns = map[string]string{
+	"mmc_error":      `(?i)mmc\d+: .*(error|timeout|timed out)`,
+	"usb_disconnect": `usb \S+: USB disconnect`,
+	"undervoltage":   `(?i)under-?voltage`,
+	"oops":           `Oops|BUG:|Kernel panic|Call trace:|Unable to handle kernel`,
+	"io_error":       `(I/O|critical medium|Buffer I/O) error`,
+	"fs_error":       `(EXT4-fs|FAT-fs|BTRFS) (error|warning)`,
+	"hung_task":      `blocked for more than \d+ seconds`,
+}
+
+type patternStats struct {
+	Count       int
+	LastTime    time.Time
+	LastMessage string
+}
+
+type patternMatcher struct {
+	patterns map[string]*regexp.Regexp
+	stats    map[string]*patternStats
+}
+
+func newPatternMatcher(patterns map[string]string) (*patternMatcher, error) {
+	m := &patternMatcher{
+		patterns: make(map[string]*regexp.Regexp),
+		stats:    make(map[string]*patternStats),
+	}
+	for name, pattern := range patterns {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, err
+		}
+		m.patterns[name] = re
+		m.stats[name] = &patternStats{}
+	}
+	return m, nil
+}
+
+// match checks the message against every pattern, a message can count towards several patterns.
+func (m *patternMatcher) match(message string, at time.Time) []string {
+	matched := make([]string, 0)
+	for name, re := range m.patterns {
+		if !re.MatchString(message) {
+			continue
+		}
+		stats := m.stats[name]
+		stats.Count++
+		stats.LastTime = at
+		stats.LastMessage = message
+		matched = append(matched, name)
+	}
+	return matched
+}
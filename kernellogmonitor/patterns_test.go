@@ -0,0 +1,51 @@
+package kernellogmonitor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefaultPatterns(t *testing.T) {
+	matcher, err := newPatternMatcher(defaultPatterns)
+	require.NoError(t, err)
+	tests := []struct {
+		message  string
+		expected []string
+	}{
+		{"mmc0: Timeout waiting for hardware cmd interrupt.", []string{"mmc_error"}},
+		{"mmc0: error -110 whilst initialising SD card", []string{"mmc_error"}},
+		{"usb 1-1.3: USB disconnect, device number 4", []string{"usb_disconnect"}},
+		{"hwmon hwmon1: Undervoltage detected!", []string{"undervoltage"}},
+		{"Unable to handle kernel NULL pointer dereference at virtual address 0000000000000008", []string{"oops"}},
+		{"blk_update_request: I/O error, dev mmcblk0, sector 1234 op 0x1:(WRITE)", []string{"io_error"}},
+		{"EXT4-fs error (device mmcblk0p2): ext4_find_entry:1455: inode #2: comm systemd: reading directory lblock 0", []string{"fs_error"}},
+		{"INFO: task jbd2/mmcblk0p2:123 blocked for more than 120 seconds.", []string{"hung_task"}},
+		{"brcmfmac: brcmf_cfg80211_set_power_mgmt: power save enabled", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.message, func(t *testing.T) {
+			matched := matcher.match(tt.message, time.Now())
+			if tt.expected == nil {
+				assert.Empty(t, matched)
+			} else {
+				assert.ElementsMatch(t, tt.expected, matched)
+			}
+		})
+	}
+}
+
+func TestPatternStats(t *testing.T) {
+	matcher, err := newPatternMatcher(map[string]string{"usb": "USB disconnect", "any_usb": "^usb "})
+	require.NoError(t, err)
+	first := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	matcher.match("usb 1-1: USB disconnect, device number 2", first)
+	matcher.match("usb 1-1: new high-speed USB device number 3 using xhci_hcd", first.Add(time.Minute))
+
+	assert.Equal(t, 1, matcher.stats["usb"].Count)
+	assert.Equal(t, first, matcher.stats["usb"].LastTime)
+	assert.Equal(t, 2, matcher.stats["any_usb"].Count)
+	assert.Equal(t, "usb 1-1: new high-speed USB device number 3 using xhci_hcd", matcher.stats["any_usb"].LastMessage)
+}
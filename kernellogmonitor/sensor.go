@@ -0,0 +1,174 @@
+package kernellogmonitor
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/shirou/gopsutil/v4/host"
+	"go.viam.com/rdk/components/sensor"
+	"go.viam.com/rdk/logging"
+	"go.viam.com/rdk/resource"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/linux"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+var (
+	Model       = resource.NewModel(utils.Namespace, "hwmonitor", "kernel_log_monitor")
+	API         = sensor.API
+	PrettyName  = "SBC Kernel Log Monitor"
+	Description = "A sensor that counts kernel log messages matching configurable patterns"
+	Version     = utils.Version
+)
+
+type Config struct {
+	resource.Named
+	configLock   sync.Mutex
+	readingsLock sync.RWMutex
+	logger       logging.Logger
+	sleepTime    time.Duration
+	task         *utils.Task
+	kmsg         *linux.KmsgReader
+	bootTime     time.Time
+	matcher      *patternMatcher
+	freshness    utils.Freshness
+}
+
+func init() {
+	resource.RegisterComponent(
+		API,
+		Model,
+		resource.Registration[sensor.Sensor, *ComponentConfig]{Constructor: utils.FilterReadings(NewSensor)})
+	utils.RegisterSimulator(Model, simulate)
+}
+
+func NewSensor(ctx context.Context, deps resource.Dependencies, conf resource.Config, logger logging.Logger) (sensor.Sensor, error) {
+	logger.Infof("Starting %s %s", PrettyName, Version)
+	b := Config{
+		Named:  conf.ResourceName().AsNamed(),
+		logger: logger,
+	}
+
+	if err := b.Reconfigure(ctx, deps, conf); err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
+
+func (c *Config) Reconfigure(ctx context.Context, _ resource.Dependencies, rawConf resource.Config) error {
+	c.configLock.Lock()
+	defer c.configLock.Unlock()
+	c.logger.Debugf("Reconfiguring %s", PrettyName)
+
+	c.stopPolling()
+
+	conf, err := resource.NativeConfig[*ComponentConfig](rawConf)
+	if err != nil {
+		return err
+	}
+
+	// In case the component has changed name
+	c.Named = rawConf.ResourceName().AsNamed()
+	if conf.SleepTimeMs <= 0 {
+		// Default to 1000ms if no sleep time is provided
+		c.logger.Warnf("Invalid sleep time %d, defaulting to 1000ms", conf.SleepTimeMs)
+		conf.SleepTimeMs = 1000 // Default to 1 second
+	}
+	c.sleepTime = time.Duration(conf.SleepTimeMs * int(time.Millisecond))
+
+	patterns := conf.Patterns
+	if len(patterns) == 0 {
+		patterns = defaultPatterns
+	}
+	matcher, err := newPatternMatcher(patterns)
+	if err != nil {
+		return err
+	}
+	c.readingsLock.Lock()
+	c.matcher = matcher
+	c.readingsLock.Unlock()
+
+	bootTime, err := host.BootTimeWithContext(ctx)
+	if err != nil {
+		return err
+	}
+	c.bootTime = time.Unix(int64(bootTime), 0)
+
+	// The patterns may have changed, so the whole ring buffer is matched again and the counters cover
+	// everything since boot that is still in the buffer
+	kmsg, err := linux.OpenKmsg(false)
+	if err != nil {
+		c.logger.Warnf("Unable to open /dev/kmsg, kernel log patterns will not be reported: %v", err)
+		c.readingsLock.Lock()
+		c.freshness.Failed(fmt.Errorf("unable to open /dev/kmsg: %w", err))
+		c.readingsLock.Unlock()
+	} else {
+		c.kmsg = kmsg
+		c.task = utils.Schedule(c.sleepTime, func(ctx context.Context) {
+			c.readKmsg(kmsg)
+		})
+	}
+
+	return nil
+}
+
+func (c *Config) Readings(ctx context.Context, extra map[string]interface{}) (map[string]interface{}, error) {
+	c.readingsLock.RLock()
+	defer c.readingsLock.RUnlock()
+	ret := make(map[string]interface{})
+	for name, stats := range c.matcher.stats {
+		ret[name+"_count"] = stats.Count
+		if stats.Count > 0 {
+			ret[name+"_last_time"] = stats.LastTime.Format(time.RFC3339)
+			ret[name+"_last_message"] = stats.LastMessage
+		}
+	}
+	return c.freshness.Stamp(ret, c.sleepTime, time.Now()), nil
+}
+
+// stopPolling stops reading the kernel log, configLock must be held.
+func (c *Config) stopPolling() {
+	if c.task == nil {
+		return
+	}
+	c.logger.Debug("Stopping polling")
+	c.task.Stop()
+	c.kmsg.Close()
+	c.task, c.kmsg = nil, nil
+	c.logger.Debugf("Polling stopped")
+}
+
+// readKmsg processes the kernel log records written since the previous poll.
+func (c *Config) readKmsg(kmsg *linux.KmsgReader) {
+	records, err := kmsg.ReadAvailable()
+	if err != nil {
+		c.logger.Warnf("Failed to read kernel log: %v", err)
+	}
+	c.readingsLock.Lock()
+	if err != nil {
+		c.freshness.Failed(err)
+	} else {
+		c.freshness.Succeeded(time.Now())
+	}
+	for _, record := range records {
+		for _, name := range c.matcher.match(record.Message, record.Time(c.bootTime)) {
+			c.logger.Debugf("Kernel log matched %s: %s", name, record.Message)
+		}
+	}
+	c.readingsLock.Unlock()
+}
+
+func (c *Config) Close(ctx context.Context) error {
+	c.configLock.Lock()
+	defer c.configLock.Unlock()
+	c.logger.Infof("Shutting down %s", PrettyName)
+	c.stopPolling()
+	c.logger.Infof("%s Shutdown complete", PrettyName)
+	return nil
+}
+
+func (c *Config) Ready(ctx context.Context, extra map[string]interface{}) (bool, error) {
+	return false, nil
+}
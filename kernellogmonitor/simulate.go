@@ -0,0 +1,16 @@
+package kernellogmonitor
+
+import "github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+
+// simulate returns a kernel log in which none of the patterns have matched.
+func simulate(sim *utils.Simulation) map[string]interface{} {
+	patterns := defaultPatterns
+	if conf, ok := sim.Config().(*ComponentConfig); ok && len(conf.Patterns) > 0 {
+		patterns = conf.Patterns
+	}
+	ret := make(map[string]interface{})
+	for name := range patterns {
+		ret[name+"_count"] = 0
+	}
+	return ret
+}
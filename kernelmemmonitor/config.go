@@ -0,0 +1,14 @@
+package kernelmemmonitor
+
+import "errors"
+
+type ComponentConfig struct {
+	TopSlabCaches int `json:"top_slab_caches"`
+}
+
+func (conf *ComponentConfig) Validate(path string) ([]string, error) {
+	if conf.TopSlabCaches < 0 {
+		return nil, errors.New("top_slab_caches must not be negative")
+	}
+	return nil, nil
+}
@@ -0,0 +1,153 @@
+package kernelmemmonitor
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+
+	"go.viam.com/rdk/components/sensor"
+	"go.viam.com/rdk/logging"
+	"go.viam.com/rdk/resource"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/linux"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+var (
+	Model       = resource.NewModel(utils.Namespace, "hwmonitor", "kernel_memory_monitor")
+	API         = sensor.API
+	PrettyName  = "SBC Kernel Memory Monitor"
+	Description = "A sensor that reports hugepage pools and slab cache usage of the SBC"
+	Version     = utils.Version
+
+	// Kernel side memory from /proc/meminfo, everything else is covered by the memory monitor
+	meminfoKeys = map[string]string{
+		"Slab":          "slab",
+		"SReclaimable":  "slab_reclaimable",
+		"SUnreclaim":    "slab_unreclaimable",
+		"KernelStack":   "kernel_stack",
+		"PageTables":    "page_tables",
+		"VmallocUsed":   "vmalloc_used",
+		"Percpu":        "percpu",
+		"AnonHugePages": "anon_hugepages",
+		"Hugetlb":       "hugetlb",
+	}
+)
+
+const defaultTopSlabCaches = 10
+
+type Config struct {
+	resource.Named
+	mu            sync.RWMutex
+	logger        logging.Logger
+	cancelCtx     context.Context
+	cancelFunc    func()
+	topSlabCaches int
+	slabWarned    bool
+}
+
+func init() {
+	resource.RegisterComponent(
+		API,
+		Model,
+		resource.Registration[sensor.Sensor, *ComponentConfig]{Constructor: utils.FilterReadings(NewSensor)})
+	utils.RegisterSimulator(Model, simulate)
+}
+
+func NewSensor(ctx context.Context, deps resource.Dependencies, conf resource.Config, logger logging.Logger) (sensor.Sensor, error) {
+	logger.Infof("Starting %s %s", PrettyName, Version)
+	cancelCtx, cancelFunc := context.WithCancel(context.Background())
+
+	b := Config{
+		Named:      conf.ResourceName().AsNamed(),
+		logger:     logger,
+		cancelCtx:  cancelCtx,
+		cancelFunc: cancelFunc,
+		mu:         sync.RWMutex{},
+	}
+
+	if err := b.Reconfigure(ctx, deps, conf); err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
+
+func (c *Config) Reconfigure(ctx context.Context, _ resource.Dependencies, conf resource.Config) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.logger.Debugf("Reconfiguring %s", PrettyName)
+
+	newConf, err := resource.NativeConfig[*ComponentConfig](conf)
+	if err != nil {
+		return err
+	}
+
+	// In case the module has changed name
+	c.Named = conf.ResourceName().AsNamed()
+
+	c.topSlabCaches = newConf.TopSlabCaches
+	if c.topSlabCaches == 0 {
+		c.topSlabCaches = defaultTopSlabCaches
+	}
+	c.slabWarned = false
+
+	return nil
+}
+
+func (c *Config) Readings(ctx context.Context, extra map[string]interface{}) (map[string]interface{}, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	ret := make(map[string]interface{})
+
+	meminfo, err := linux.ReadMeminfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for key, name := range meminfoKeys {
+		if v, ok := meminfo[key]; ok {
+			ret[name] = v
+		}
+	}
+
+	pools, err := linux.ReadHugepagePools(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, pool := range pools {
+		prefix := fmt.Sprintf("hugepages_%dkB", pool.PageSize)
+		ret[prefix+"_total"] = pool.Total
+		ret[prefix+"_free"] = pool.Free
+		ret[prefix+"_reserved"] = pool.Reserved
+		ret[prefix+"_surplus"] = pool.Surplus
+	}
+
+	caches, err := linux.ReadSlabCaches(ctx, uint64(os.Getpagesize()))
+	if err != nil {
+		// slabinfo is only readable by root, don't fail the whole reading
+		if !c.slabWarned {
+			c.logger.Warnf("Unable to read /proc/slabinfo, top slab caches will not be reported: %v", err)
+			c.slabWarned = true
+		}
+		return ret, nil
+	}
+	for i, cache := range caches {
+		if i >= c.topSlabCaches {
+			break
+		}
+		ret["slab_cache_"+cache.Name+"_bytes"] = cache.Size
+		ret["slab_cache_"+cache.Name+"_active_objs"] = cache.ActiveObjs
+	}
+
+	return ret, nil
+}
+
+func (c *Config) Close(ctx context.Context) error {
+	c.logger.Infof("Shutting down %s", PrettyName)
+	c.cancelFunc()
+	return nil
+}
+
+func (c *Config) Ready(ctx context.Context, extra map[string]interface{}) (bool, error) {
+	return false, nil
+}
@@ -0,0 +1,54 @@
+package kernelmemmonitor
+
+import "github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+
+// simulatedSlabCaches are the largest slab caches of the simulated board, largest first.
+var simulatedSlabCaches = []struct {
+	name       string
+	bytes      uint64
+	activeObjs uint64
+}{
+	{"dentry", 27_262_976, 138_612},
+	{"inode_cache", 19_922_944, 33_840},
+	{"ext4_inode_cache", 14_680_064, 12_744},
+	{"kernfs_node_cache", 6_291_456, 49_056},
+	{"radix_tree_node", 5_242_880, 9_104},
+	{"buffer_head", 4_194_304, 40_560},
+	{"vm_area_struct", 3_145_728, 18_032},
+	{"kmalloc-1k", 2_621_440, 2_448},
+	{"filp", 2_097_152, 7_872},
+	{"anon_vma_chain", 1_572_864, 23_232},
+}
+
+// simulate returns the kernel's memory on a Raspberry Pi 5, the slab grows with the page cache when the board is busy.
+func simulate(sim *utils.Simulation) map[string]interface{} {
+	reclaimable := uint64(sim.Value(68e6, 110e6, 110e6, 2e6))
+	unreclaimable := uint64(sim.Value(38e6, 41e6, 41e6, 5e5))
+	ret := map[string]interface{}{
+		"slab":                      reclaimable + unreclaimable,
+		"slab_reclaimable":          reclaimable,
+		"slab_unreclaimable":        unreclaimable,
+		"kernel_stack":              uint64(sim.Value(5.4e6, 6.1e6, 6.1e6, 1e5)),
+		"page_tables":               uint64(sim.Value(7.2e6, 9.8e6, 9.8e6, 2e5)),
+		"vmalloc_used":              uint64(19_660_800),
+		"percpu":                    uint64(1_048_576),
+		"anon_hugepages":            uint64(0),
+		"hugetlb":                   uint64(0),
+		"hugepages_2048kB_total":    uint64(0),
+		"hugepages_2048kB_free":     uint64(0),
+		"hugepages_2048kB_reserved": uint64(0),
+		"hugepages_2048kB_surplus":  uint64(0),
+	}
+	top := defaultTopSlabCaches
+	if conf, ok := sim.Config().(*ComponentConfig); ok && conf.TopSlabCaches > 0 {
+		top = conf.TopSlabCaches
+	}
+	for i, cache := range simulatedSlabCaches {
+		if i >= top {
+			break
+		}
+		ret["slab_cache_"+cache.name+"_bytes"] = cache.bytes
+		ret["slab_cache_"+cache.name+"_active_objs"] = cache.activeObjs
+	}
+	return ret
+}
@@ -0,0 +1,19 @@
+package kernelmonitor
+
+import (
+	"errors"
+	"strings"
+)
+
+type ComponentConfig struct {
+	RequiredModules []string `json:"required_modules"` // Kernel modules that must be loaded or built in, e.g. can, videodev, spidev
+}
+
+func (conf *ComponentConfig) Validate(path string) ([]string, error) {
+	for _, module := range conf.RequiredModules {
+		if strings.TrimSpace(module) == "" {
+			return nil, errors.New("required_modules must not contain empty names")
+		}
+	}
+	return nil, nil
+}
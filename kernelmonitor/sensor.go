@@ -0,0 +1,145 @@
+package kernelmonitor
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+
+	"go.viam.com/rdk/components/sensor"
+	"go.viam.com/rdk/logging"
+	"go.viam.com/rdk/resource"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/linux"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+var (
+	Model       = resource.NewModel(utils.Namespace, "hwmonitor", "kernel_monitor")
+	API         = sensor.API
+	PrettyName  = "SBC Kernel Monitor"
+	Description = "A sensor that reports kernel taint flags and whether required kernel modules are loaded"
+	Version     = utils.Version
+)
+
+type Config struct {
+	resource.Named
+	mu              sync.RWMutex
+	logger          logging.Logger
+	cancelCtx       context.Context
+	cancelFunc      func()
+	requiredModules []string
+	missingModules  map[string]bool
+}
+
+func init() {
+	resource.RegisterComponent(
+		API,
+		Model,
+		resource.Registration[sensor.Sensor, *ComponentConfig]{Constructor: utils.FilterReadings(NewSensor)})
+	utils.RegisterSimulator(Model, simulate)
+}
+
+func NewSensor(ctx context.Context, deps resource.Dependencies, conf resource.Config, logger logging.Logger) (sensor.Sensor, error) {
+	logger.Infof("Starting %s %s", PrettyName, Version)
+	cancelCtx, cancelFunc := context.WithCancel(context.Background())
+
+	b := Config{
+		Named:      conf.ResourceName().AsNamed(),
+		logger:     logger,
+		cancelCtx:  cancelCtx,
+		cancelFunc: cancelFunc,
+		mu:         sync.RWMutex{},
+	}
+
+	if err := b.Reconfigure(ctx, deps, conf); err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
+
+func (c *Config) Reconfigure(ctx context.Context, _ resource.Dependencies, rawConf resource.Config) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.logger.Debugf("Reconfiguring %s", PrettyName)
+
+	conf, err := resource.NativeConfig[*ComponentConfig](rawConf)
+	if err != nil {
+		return err
+	}
+
+	// In case the module has changed name
+	c.Named = rawConf.ResourceName().AsNamed()
+	c.requiredModules = conf.RequiredModules
+	c.missingModules = make(map[string]bool)
+
+	return nil
+}
+
+func (c *Config) Readings(ctx context.Context, extra map[string]interface{}) (map[string]interface{}, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	ret := make(map[string]interface{})
+
+	if release, err := linux.ReadKernelRelease(ctx); err == nil {
+		ret["kernel_release"] = release
+	} else {
+		c.logger.Debugf("Failed to read kernel release: %v", err)
+	}
+
+	taint, err := linux.ReadKernelTaint(ctx)
+	if err != nil {
+		return nil, err
+	}
+	flags := linux.DecodeTaint(taint)
+	letters := make([]string, 0, len(flags))
+	names := make([]string, 0, len(flags))
+	for _, flag := range flags {
+		letters = append(letters, flag.Letter)
+		names = append(names, flag.Name)
+	}
+	ret["tainted"] = taint != 0
+	ret["taint_value"] = taint
+	ret["taint_letters"] = strings.Join(letters, "")
+	ret["taint_flags"] = strings.Join(names, ",")
+
+	if len(c.requiredModules) == 0 {
+		return ret, nil
+	}
+
+	modules, err := linux.GetKernelModules(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var missing []string
+	for _, module := range c.requiredModules {
+		available := modules.Available(module)
+		ret["module_"+module+"_loaded"] = available
+		if !available {
+			missing = append(missing, module)
+		}
+		// Only log when a module disappears or comes back, not on every reading
+		if !available && !c.missingModules[module] {
+			c.logger.Warnf("Required kernel module %s is not loaded", module)
+		} else if available && c.missingModules[module] {
+			c.logger.Infof("Required kernel module %s is loaded", module)
+		}
+		c.missingModules[module] = !available
+	}
+	sort.Strings(missing)
+	ret["all_modules_loaded"] = len(missing) == 0
+	ret["missing_module_count"] = len(missing)
+	ret["missing_modules"] = strings.Join(missing, ",")
+
+	return ret, nil
+}
+
+func (c *Config) Close(ctx context.Context) error {
+	c.logger.Infof("Shutting down %s", PrettyName)
+	c.cancelFunc()
+	return nil
+}
+
+func (c *Config) Ready(ctx context.Context, extra map[string]interface{}) (bool, error) {
+	return false, nil
+}
@@ -0,0 +1,25 @@
+package kernelmonitor
+
+import "github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+
+// simulate returns an untainted kernel with every required module loaded.
+func simulate(sim *utils.Simulation) map[string]interface{} {
+	ret := map[string]interface{}{
+		"kernel_release": "6.6.51+rpt-rpi-2712",
+		"tainted":        false,
+		"taint_value":    uint64(0),
+		"taint_letters":  "",
+		"taint_flags":    "",
+	}
+	conf, ok := sim.Config().(*ComponentConfig)
+	if !ok || len(conf.RequiredModules) == 0 {
+		return ret
+	}
+	for _, module := range conf.RequiredModules {
+		ret["module_"+module+"_loaded"] = true
+	}
+	ret["all_modules_loaded"] = true
+	ret["missing_module_count"] = 0
+	ret["missing_modules"] = ""
+	return ret
+}
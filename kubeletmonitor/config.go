@@ -0,0 +1,76 @@
+package kubeletmonitor
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"os"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+// k3s's admin client certificate, only on servers, and the CA of the kubelet's serving certificate, on every node
+const (
+	k3sClientCert = "/var/lib/rancher/k3s/server/tls/client-admin.crt"
+	k3sClientKey  = "/var/lib/rancher/k3s/server/tls/client-admin.key"
+	k3sServerCA   = "/var/lib/rancher/k3s/agent/server-ca.crt"
+)
+
+type ComponentConfig struct {
+	URL                string `json:"url,omitempty"`        // Defaults to https://127.0.0.1:10250
+	TokenFile          string `json:"token_file,omitempty"` // Bearer token, e.g. of a service account that can get nodes/proxy and nodes/stats
+	CAFile             string `json:"ca_file,omitempty"`    // Verifies the kubelet with this CA, defaults to k3s's server CA when it's there
+	CertFile           string `json:"cert_file,omitempty"`  // Client certificate, defaults to k3s's admin certificate on k3s servers
+	KeyFile            string `json:"key_file,omitempty"`
+	InsecureSkipVerify bool   `json:"insecure_skip_verify,omitempty"` // Don't verify the kubelet's certificate
+	TimeoutMs          int    `json:"timeout_ms,omitempty"`           // Defaults to 5000
+}
+
+func (conf *ComponentConfig) Validate(path string) ([]string, error) {
+	if conf.URL != "" {
+		u, err := url.Parse(conf.URL)
+		if err != nil {
+			return nil, err
+		}
+		if u.Scheme != "http" && u.Scheme != "https" {
+			return nil, errors.New("url must be http or https")
+		}
+	}
+	if (conf.CertFile == "") != (conf.KeyFile == "") {
+		return nil, errors.New("cert_file and key_file must be set together")
+	}
+	if conf.TimeoutMs < 0 {
+		return nil, errors.New("timeout_ms must not be negative")
+	}
+	return nil, nil
+}
+
+// credentials returns the client certificate, its key and the CA the kubelet is verified with, falling back to k3s's
+// when neither a certificate nor a token is configured.
+func (conf *ComponentConfig) credentials() (certFile, keyFile, caFile string) {
+	certFile, keyFile, caFile = conf.CertFile, conf.KeyFile, conf.CAFile
+	if certFile == "" && conf.TokenFile == "" && exists(k3sClientCert) {
+		certFile, keyFile = k3sClientCert, k3sClientKey
+	}
+	if caFile == "" && !conf.InsecureSkipVerify && exists(k3sServerCA) {
+		caFile = k3sServerCA
+	}
+	return certFile, keyFile, caFile
+}
+
+// Permissions implements utils.PermissionReporter, k3s's key is only readable by root.
+func (conf *ComponentConfig) Permissions(ctx context.Context) utils.Permissions {
+	var perms utils.Permissions
+	certFile, keyFile, caFile := conf.credentials()
+	for _, file := range []string{conf.TokenFile, certFile, keyFile, caFile} {
+		if file != "" {
+			perms.Readable = append(perms.Readable, file)
+		}
+	}
+	return perms
+}
+
+func exists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
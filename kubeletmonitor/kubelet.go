@@ -0,0 +1,256 @@
+package kubeletmonitor
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+const defaultURL = "https://127.0.0.1:10250"
+
+// maxErrorBody is how much of an error response is read for its message
+const maxErrorBody = 4096
+
+// kubeletConfig is the part of the kubelet's running configuration, from /configz, that's reported.
+type kubeletConfig struct {
+	EvictionHard map[string]string `json:"evictionHard"`
+	EvictionSoft map[string]string `json:"evictionSoft"`
+	MaxPods      int               `json:"maxPods"`
+}
+
+// fsStats is a filesystem in the kubelet's stats summary, the fields the kubelet couldn't read are nil.
+type fsStats struct {
+	AvailableBytes *uint64 `json:"availableBytes"`
+	CapacityBytes  *uint64 `json:"capacityBytes"`
+	InodesFree     *uint64 `json:"inodesFree"`
+	Inodes         *uint64 `json:"inodes"`
+}
+
+// summary is the part of the response to /stats/summary the eviction signals are computed from.
+type summary struct {
+	Node struct {
+		NodeName string `json:"nodeName"`
+		Memory   *struct {
+			AvailableBytes  *uint64 `json:"availableBytes"`
+			WorkingSetBytes *uint64 `json:"workingSetBytes"`
+		} `json:"memory"`
+		Fs      *fsStats `json:"fs"`
+		Runtime *struct {
+			ImageFs *fsStats `json:"imageFs"`
+		} `json:"runtime"`
+		Rlimit *struct {
+			MaxPID  *uint64 `json:"maxpid"`
+			CurProc *uint64 `json:"curproc"`
+		} `json:"rlimit"`
+	} `json:"node"`
+}
+
+// pod is a pod in the response to /pods.
+type pod struct {
+	Status struct {
+		Phase  string `json:"phase"`
+		Reason string `json:"reason"`
+	} `json:"status"`
+}
+
+// observation is what's left of a resource the kubelet evicts pods over, and how much of it there is.
+type observation struct {
+	available uint64
+	capacity  uint64
+}
+
+// signal is an eviction signal, the readings it's reported as and the node condition it sets.
+type signal struct {
+	name      string
+	reading   string
+	threshold string
+	condition string
+}
+
+// signals are the kubelet's eviction signals, see https://kubernetes.io/docs/concepts/scheduling-eviction/node-pressure-eviction/
+var signals = []signal{
+	{"memory.available", "memory_available_bytes", "memory_eviction_threshold_bytes", "memory_pressure"},
+	{"nodefs.available", "nodefs_available_bytes", "nodefs_eviction_threshold_bytes", "disk_pressure"},
+	{"nodefs.inodesFree", "nodefs_inodes_free", "nodefs_inodes_eviction_threshold", "disk_pressure"},
+	{"imagefs.available", "imagefs_available_bytes", "imagefs_eviction_threshold_bytes", "disk_pressure"},
+	{"imagefs.inodesFree", "imagefs_inodes_free", "imagefs_inodes_eviction_threshold", "disk_pressure"},
+	{"pid.available", "pids_available", "pids_eviction_threshold", "pid_pressure"},
+}
+
+// observations returns the eviction signals the summary has, as the kubelet computes them.
+func (s summary) observations() map[string]observation {
+	ret := make(map[string]observation)
+	node := s.Node
+	if m := node.Memory; m != nil && m.AvailableBytes != nil && m.WorkingSetBytes != nil {
+		ret["memory.available"] = observation{*m.AvailableBytes, *m.AvailableBytes + *m.WorkingSetBytes}
+	}
+	addFs := func(prefix string, fs *fsStats) {
+		if fs == nil {
+			return
+		}
+		if fs.AvailableBytes != nil && fs.CapacityBytes != nil {
+			ret[prefix+".available"] = observation{*fs.AvailableBytes, *fs.CapacityBytes}
+		}
+		if fs.InodesFree != nil && fs.Inodes != nil {
+			ret[prefix+".inodesFree"] = observation{*fs.InodesFree, *fs.Inodes}
+		}
+	}
+	addFs("nodefs", node.Fs)
+	if node.Runtime != nil {
+		addFs("imagefs", node.Runtime.ImageFs)
+	}
+	if r := node.Rlimit; r != nil && r.MaxPID != nil && r.CurProc != nil && *r.MaxPID >= *r.CurProc {
+		ret["pid.available"] = observation{*r.MaxPID - *r.CurProc, *r.MaxPID}
+	}
+	return ret
+}
+
+// threshold returns the amount of the signal below which the kubelet reports the node under pressure, the larger of
+// the hard and soft thresholds since it reports the soft one before its grace period has passed. It's false when the
+// signal has no threshold.
+func (c kubeletConfig) threshold(name string, capacity uint64) (uint64, bool, error) {
+	var ret uint64
+	found := false
+	for _, thresholds := range []map[string]string{c.EvictionHard, c.EvictionSoft} {
+		value, ok := thresholds[name]
+		if !ok {
+			continue
+		}
+		amount, err := parseThreshold(value, capacity)
+		if err != nil {
+			return 0, false, fmt.Errorf("%s threshold %s: %w", name, value, err)
+		}
+		ret = max(ret, amount)
+		found = true
+	}
+	return ret, found, nil
+}
+
+// quantitySuffixes are the Kubernetes quantity suffixes a threshold can have.
+var quantitySuffixes = []struct {
+	suffix     string
+	multiplier float64
+}{
+	{"Ki", 1 << 10}, {"Mi", 1 << 20}, {"Gi", 1 << 30}, {"Ti", 1 << 40}, {"Pi", 1 << 50}, {"Ei", 1 << 60},
+	{"k", 1e3}, {"M", 1e6}, {"G", 1e9}, {"T", 1e12}, {"P", 1e15}, {"E", 1e18},
+}
+
+// parseThreshold parses an eviction threshold, either a percentage of capacity, e.g. 10%, or a quantity, e.g. 100Mi.
+func parseThreshold(value string, capacity uint64) (uint64, error) {
+	if percent, ok := strings.CutSuffix(value, "%"); ok {
+		p, err := strconv.ParseFloat(percent, 64)
+		if err != nil {
+			return 0, err
+		}
+		return uint64(float64(capacity) * p / 100), nil
+	}
+	multiplier := 1.0
+	for _, s := range quantitySuffixes {
+		if number, ok := strings.CutSuffix(value, s.suffix); ok {
+			value, multiplier = number, s.multiplier
+			break
+		}
+	}
+	n, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, err
+	}
+	return uint64(n * multiplier), nil
+}
+
+// kubeletClient reads the kubelet's API.
+type kubeletClient struct {
+	url       string
+	tokenFile string
+	http      *http.Client
+}
+
+func newKubeletClient(conf *ComponentConfig, timeout time.Duration) (*kubeletClient, error) {
+	certFile, keyFile, caFile := conf.credentials()
+	tlsConfig, err := utils.NewTLSConfig(caFile, certFile, keyFile, conf.InsecureSkipVerify)
+	if err != nil {
+		return nil, err
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+	url := conf.URL
+	if url == "" {
+		url = defaultURL
+	}
+	return &kubeletClient{
+		url:       strings.TrimSuffix(url, "/"),
+		tokenFile: conf.TokenFile,
+		http:      &http.Client{Transport: transport, Timeout: timeout},
+	}, nil
+}
+
+// get decodes the JSON response to a GET of path into v.
+func (c *kubeletClient) get(ctx context.Context, path string, v interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.url+path, nil)
+	if err != nil {
+		return err
+	}
+	// Service account tokens are rotated, so the file is read every time
+	if c.tokenFile != "" {
+		token, err := os.ReadFile(c.tokenFile)
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Authorization", "Bearer "+strings.TrimSpace(string(token)))
+	}
+	resp, err := c.http.Do(req)
+	if err != nil {
+		if errors.Is(err, syscall.ECONNREFUSED) {
+			return utils.WithKind(utils.ErrBackendUnavailable, fmt.Errorf("the kubelet isn't running at %s: %w", c.url, err))
+		}
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+		err := fmt.Errorf("%s returned %s: %s", path, resp.Status, strings.TrimSpace(string(body)))
+		if resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden {
+			return utils.WithKind(utils.ErrPermissionDenied, fmt.Errorf("%w, set token_file or cert_file to credentials that can get nodes/proxy and nodes/stats", err))
+		}
+		return err
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
+// config returns the kubelet's running configuration.
+func (c *kubeletClient) config(ctx context.Context) (kubeletConfig, error) {
+	var resp struct {
+		KubeletConfig kubeletConfig `json:"kubeletconfig"`
+	}
+	err := c.get(ctx, "/configz", &resp)
+	return resp.KubeletConfig, err
+}
+
+func (c *kubeletClient) summary(ctx context.Context) (summary, error) {
+	var resp summary
+	err := c.get(ctx, "/stats/summary", &resp)
+	return resp, err
+}
+
+// pods returns the pods bound to the node.
+func (c *kubeletClient) pods(ctx context.Context) ([]pod, error) {
+	var resp struct {
+		Items []pod `json:"items"`
+	}
+	err := c.get(ctx, "/pods", &resp)
+	return resp.Items, err
+}
+
+func (c *kubeletClient) close() {
+	c.http.CloseIdleConnections()
+}
@@ -0,0 +1,137 @@
+package kubeletmonitor
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+const testSummary = `{"node":{"nodeName":"robot-1",
+	"memory":{"availableBytes":90000000,"workingSetBytes":3910000000},
+	"fs":{"availableBytes":20000000000,"capacityBytes":100000000000,"inodesFree":900000,"inodes":1000000},
+	"runtime":{"imageFs":{"availableBytes":20000000000,"capacityBytes":100000000000}},
+	"rlimit":{"maxpid":32768,"curproc":400}}}`
+
+const testPods = `{"items":[{"status":{"phase":"Running"}},{"status":{"phase":"Running"}},{"status":{"phase":"Pending"}},
+	{"status":{"phase":"Failed","reason":"Evicted"}},{"status":{"phase":"Failed","reason":"Error"}}]}`
+
+func TestReadKubelet(t *testing.T) {
+	token := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(token, []byte("secret\n"), 0o600))
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+		switch r.URL.Path {
+		case "/configz":
+			w.Write([]byte(`{"kubeletconfig":{"evictionHard":{"memory.available":"100Mi","nodefs.available":"5%","imagefs.available":"25%"},"evictionSoft":{"nodefs.available":"10%"},"maxPods":110}}`))
+		case "/stats/summary":
+			w.Write([]byte(testSummary))
+		case "/pods":
+			w.Write([]byte(testPods))
+		}
+	}))
+	defer server.Close()
+	client, err := newKubeletClient(&ComponentConfig{URL: server.URL, TokenFile: token}, time.Second)
+	require.NoError(t, err)
+	defer client.close()
+
+	readings, err := readKubelet(context.Background(), client)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"node_name":                        "robot-1",
+		"memory_available_bytes":           uint64(90000000),
+		"memory_eviction_threshold_bytes":  uint64(104857600),
+		"memory_pressure":                  true,
+		"nodefs_available_bytes":           uint64(20000000000),
+		"nodefs_eviction_threshold_bytes":  uint64(10000000000),
+		"nodefs_inodes_free":               uint64(900000),
+		"imagefs_available_bytes":          uint64(20000000000),
+		"imagefs_eviction_threshold_bytes": uint64(25000000000),
+		"disk_pressure":                    true,
+		"pids_available":                   uint64(32368),
+		"pid_pressure":                     false,
+		"node_pressure":                    true,
+		"pod_count":                        5,
+		"running_pods":                     2,
+		"pending_pods":                     1,
+		"succeeded_pods":                   0,
+		"failed_pods":                      2,
+		"evicted_pods":                     1,
+		"max_pods":                         110,
+	}, readings)
+}
+
+func TestReadKubeletForbidden(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/pods" {
+			w.Write([]byte(testPods))
+			return
+		}
+		http.Error(w, "Forbidden (user=system:anonymous, verb=get, resource=nodes, subresource=proxy)", http.StatusForbidden)
+	}))
+	defer server.Close()
+	client, err := newKubeletClient(&ComponentConfig{URL: server.URL}, time.Second)
+	require.NoError(t, err)
+	defer client.close()
+
+	// The pods are still reported
+	readings, err := readKubelet(context.Background(), client)
+	require.NoError(t, err)
+	assert.Equal(t, 5, readings["pod_count"])
+	assert.NotContains(t, readings, "node_pressure")
+	assert.Equal(t, utils.ErrorKindPermissionDenied, readings[utils.ReadingErrorKindsKey].(map[string]interface{})["node_stats"])
+	assert.Contains(t, readings[utils.ReadingErrorsKey].(map[string]interface{})["eviction_thresholds"], "set token_file or cert_file")
+
+	// Without the thresholds the signals are still reported
+	server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/configz" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		w.Write([]byte(testSummary))
+	})
+	readings, err = readKubelet(context.Background(), client)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(90000000), readings["memory_available_bytes"])
+	assert.NotContains(t, readings, "memory_pressure")
+}
+
+func TestReadKubeletNotRunning(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	server.Close()
+	client, err := newKubeletClient(&ComponentConfig{URL: server.URL}, time.Second)
+	require.NoError(t, err)
+
+	_, err = readKubelet(context.Background(), client)
+	assert.ErrorContains(t, err, "the kubelet isn't running")
+	assert.Equal(t, utils.ErrorKindBackendUnavailable, utils.ErrorKind(err))
+}
+
+func TestParseThreshold(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected uint64
+	}{
+		{"100Mi", 100 * 1024 * 1024},
+		{"1.5Gi", 1536 * 1024 * 1024},
+		{"500M", 500_000_000},
+		{"1000", 1000},
+		{"10%", 400},
+		{"2.5%", 100},
+	}
+	for _, tt := range tests {
+		threshold, err := parseThreshold(tt.value, 4000)
+		require.NoError(t, err, tt.value)
+		assert.Equal(t, tt.expected, threshold, tt.value)
+	}
+	_, err := parseThreshold("lots", 4000)
+	assert.Error(t, err)
+}
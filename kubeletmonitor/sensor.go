@@ -0,0 +1,196 @@
+package kubeletmonitor
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"go.viam.com/rdk/components/sensor"
+	"go.viam.com/rdk/logging"
+	"go.viam.com/rdk/resource"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+var (
+	Model       = resource.NewModel(utils.Namespace, "hwmonitor", "kubelet_monitor")
+	API         = sensor.API
+	PrettyName  = "Kubelet Monitor"
+	Description = "A sensor that reports the node pressure conditions and pods of a k3s or Kubernetes node from its kubelet"
+	Version     = utils.Version
+)
+
+type Config struct {
+	resource.Named
+	mu     sync.Mutex
+	logger logging.Logger
+	client *kubeletClient
+}
+
+func init() {
+	resource.RegisterComponent(
+		API,
+		Model,
+		resource.Registration[sensor.Sensor, *ComponentConfig]{Constructor: utils.FilterReadings(NewSensor)})
+	utils.RegisterSimulator(Model, simulate)
+}
+
+func NewSensor(ctx context.Context, deps resource.Dependencies, conf resource.Config, logger logging.Logger) (sensor.Sensor, error) {
+	logger.Infof("Starting %s %s", PrettyName, Version)
+
+	b := Config{
+		Named:  conf.ResourceName().AsNamed(),
+		logger: logger,
+	}
+
+	if err := b.Reconfigure(ctx, deps, conf); err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
+
+func (c *Config) Reconfigure(ctx context.Context, _ resource.Dependencies, rawConf resource.Config) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.logger.Debugf("Reconfiguring %s", PrettyName)
+
+	conf, err := resource.NativeConfig[*ComponentConfig](rawConf)
+	if err != nil {
+		return err
+	}
+	timeout := time.Duration(conf.TimeoutMs) * time.Millisecond
+	if timeout == 0 {
+		timeout = 5 * time.Second
+	}
+	client, err := newKubeletClient(conf, timeout)
+	if err != nil {
+		return err
+	}
+	if c.client != nil {
+		c.client.close()
+	}
+	c.client = client
+
+	// In case the module has changed name
+	c.Named = rawConf.ResourceName().AsNamed()
+
+	return nil
+}
+
+func (c *Config) Readings(ctx context.Context, extra map[string]interface{}) (map[string]interface{}, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return readKubelet(ctx, c.client)
+}
+
+// readKubelet reports the node's eviction signals and the pressure conditions they set, from the kubelet's stats and
+// its eviction thresholds, along with the pods on the node.
+func readKubelet(ctx context.Context, client *kubeletClient) (map[string]interface{}, error) {
+	ret := make(map[string]interface{})
+	errs := make(utils.ReadingErrors)
+	config, configErr := client.config(ctx)
+	if errors.Is(configErr, utils.ErrBackendUnavailable) {
+		return nil, configErr
+	}
+	if configErr != nil {
+		errs.Add("eviction_thresholds", configErr)
+	}
+	if stats, err := client.summary(ctx); err != nil {
+		errs.Add("node_stats", err)
+	} else {
+		if stats.Node.NodeName != "" {
+			ret["node_name"] = stats.Node.NodeName
+		}
+		addPressureReadings(ret, errs, stats.observations(), config, configErr == nil)
+	}
+	if pods, err := client.pods(ctx); err != nil {
+		errs.Add("pods", err)
+	} else {
+		addPodReadings(ret, pods, config.MaxPods)
+	}
+	return errs.Readings(ret)
+}
+
+// addPressureReadings adds each eviction signal and, when the kubelet's thresholds were read, the threshold and the
+// pressure condition it sets. A condition is true while any of its signals is below its threshold, the kubelet keeps
+// reporting it for evictionPressureTransitionPeriod, 5 minutes by default, after that.
+func addPressureReadings(ret map[string]interface{}, errs utils.ReadingErrors, observations map[string]observation, config kubeletConfig, hasConfig bool) {
+	conditions := make(map[string]bool)
+	for _, s := range signals {
+		obs, ok := observations[s.name]
+		if !ok {
+			continue
+		}
+		ret[s.reading] = obs.available
+		if !hasConfig {
+			continue
+		}
+		threshold, ok, err := config.threshold(s.name, obs.capacity)
+		if err != nil {
+			errs.Add(s.threshold, err)
+			continue
+		}
+		conditions[s.condition] = conditions[s.condition] || (ok && obs.available < threshold)
+		if ok {
+			ret[s.threshold] = threshold
+		}
+	}
+	if len(conditions) == 0 {
+		return
+	}
+	pressure := false
+	for condition, value := range conditions {
+		ret[condition] = value
+		pressure = pressure || value
+	}
+	ret["node_pressure"] = pressure
+}
+
+// addPodReadings adds the number of pods on the node in each phase, pods the kubelet evicted are failed too.
+func addPodReadings(ret map[string]interface{}, pods []pod, maxPods int) {
+	var running, pending, succeeded, failed, evicted int
+	for _, p := range pods {
+		switch p.Status.Phase {
+		case "Running":
+			running++
+		case "Pending":
+			pending++
+		case "Succeeded":
+			succeeded++
+		case "Failed":
+			failed++
+			if p.Status.Reason == "Evicted" {
+				evicted++
+			}
+		}
+	}
+	ret["pod_count"] = len(pods)
+	ret["running_pods"] = running
+	ret["pending_pods"] = pending
+	ret["succeeded_pods"] = succeeded
+	ret["failed_pods"] = failed
+	ret["evicted_pods"] = evicted
+	if maxPods > 0 {
+		ret["max_pods"] = maxPods
+	}
+}
+
+// Capabilities reports the kubelet the readings come from.
+func (c *Config) Capabilities(ctx context.Context) utils.Capabilities {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return utils.Capabilities{Backend: c.client.url}
+}
+
+func (c *Config) Close(ctx context.Context) error {
+	c.logger.Infof("Shutting down %s", PrettyName)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.client.close()
+	return nil
+}
+
+func (c *Config) Ready(ctx context.Context, extra map[string]interface{}) (bool, error) {
+	return false, nil
+}
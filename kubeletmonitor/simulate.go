@@ -0,0 +1,40 @@
+package kubeletmonitor
+
+import (
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+// The simulated node's capacity, the kubelet's default thresholds apply to it
+const (
+	simulatedMemory = 8 * 1024 * 1024 * 1024
+	simulatedDisk   = 64 * 1000 * 1000 * 1000
+	simulatedInodes = 4_000_000
+	simulatedMaxPID = 4_194_304
+)
+
+// simulate returns a k3s node under no pressure whose free memory follows the board's load.
+func simulate(sim *utils.Simulation) map[string]interface{} {
+	config := kubeletConfig{
+		EvictionHard: map[string]string{"memory.available": "100Mi", "nodefs.available": "10%", "nodefs.inodesFree": "5%", "imagefs.available": "15%"},
+		MaxPods:      110,
+	}
+	ret := map[string]interface{}{"node_name": "sbc"}
+	diskAvailable := uint64(sim.Value(41e9, 40e9, 40e9, 5e7))
+	observations := map[string]observation{
+		"memory.available":   {uint64(sim.Value(6.2e9, 2.5e9, 2.8e9, 5e7)), simulatedMemory},
+		"nodefs.available":   {diskAvailable, simulatedDisk},
+		"nodefs.inodesFree":  {3_600_000, simulatedInodes},
+		"imagefs.available":  {diskAvailable, simulatedDisk},
+		"imagefs.inodesFree": {3_600_000, simulatedInodes},
+		"pid.available":      {uint64(simulatedMaxPID - sim.Value(350, 520, 500, 10)), simulatedMaxPID},
+	}
+	addPressureReadings(ret, make(utils.ReadingErrors), observations, config, true)
+	ret["pod_count"] = 14
+	ret["running_pods"] = 12
+	ret["pending_pods"] = 0
+	ret["succeeded_pods"] = 2
+	ret["failed_pods"] = 0
+	ret["evicted_pods"] = 0
+	ret["max_pods"] = config.MaxPods
+	return ret
+}
@@ -0,0 +1,10 @@
+package ledmonitor
+
+type ComponentConfig struct {
+	LEDs        []string `json:"leds,omitempty"`         // The LEDs to report, all of them when empty
+	IdentifyLED string   `json:"identify_led,omitempty"` // The LED the identify command blinks when none is given, e.g. ACT
+}
+
+func (conf *ComponentConfig) Validate(path string) ([]string, error) {
+	return nil, nil
+}
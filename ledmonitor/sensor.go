@@ -0,0 +1,298 @@
+package ledmonitor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"go.viam.com/rdk/components/sensor"
+	"go.viam.com/rdk/logging"
+	"go.viam.com/rdk/resource"
+	viamutils "go.viam.com/utils"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/linux"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+var (
+	Model       = resource.NewModel(utils.Namespace, "hwmonitor", "led_monitor")
+	API         = sensor.API
+	PrettyName  = "LED Monitor"
+	Description = "A sensor that reports the state of the board's LEDs and sets their brightness and trigger, or blinks one to identify the board"
+	Version     = utils.Version
+)
+
+const (
+	defaultIdentifyDuration = 30 * time.Second
+	identifyBlinkInterval   = 100 * time.Millisecond
+)
+
+type Config struct {
+	resource.Named
+	configLock  sync.Mutex
+	logger      logging.Logger
+	workers     *viamutils.StoppableWorkers
+	leds        map[string]bool
+	identifyLED string
+	// identifyLock guards identifying, which the identify workers update when they finish
+	identifyLock sync.Mutex
+	identifying  map[string]bool
+}
+
+func init() {
+	resource.RegisterComponent(
+		API,
+		Model,
+		resource.Registration[sensor.Sensor, *ComponentConfig]{Constructor: utils.FilterReadings(NewSensor)})
+	utils.RegisterSimulator(Model, simulate)
+}
+
+func NewSensor(ctx context.Context, deps resource.Dependencies, conf resource.Config, logger logging.Logger) (sensor.Sensor, error) {
+	logger.Infof("Starting %s %s", PrettyName, Version)
+	b := Config{
+		Named:       conf.ResourceName().AsNamed(),
+		logger:      logger,
+		identifying: make(map[string]bool),
+	}
+
+	if err := b.Reconfigure(ctx, deps, conf); err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
+
+func (c *Config) Reconfigure(ctx context.Context, _ resource.Dependencies, rawConf resource.Config) error {
+	c.configLock.Lock()
+	defer c.configLock.Unlock()
+	c.logger.Debugf("Reconfiguring %s", PrettyName)
+
+	// Stopping the workers ends any identify and restores its LED
+	if c.workers != nil {
+		c.logger.Debug("Stopping background worker")
+		c.workers.Stop()
+		c.logger.Debugf("Background worker stopped")
+		c.workers = nil
+	}
+
+	conf, err := resource.NativeConfig[*ComponentConfig](rawConf)
+	if err != nil {
+		return err
+	}
+
+	// In case the component has changed name
+	c.Named = rawConf.ResourceName().AsNamed()
+	c.leds = make(map[string]bool)
+	for _, led := range conf.LEDs {
+		c.leds[led] = true
+	}
+	c.identifyLED = conf.IdentifyLED
+	c.workers = viamutils.NewBackgroundStoppableWorkers()
+	return nil
+}
+
+func (c *Config) Readings(ctx context.Context, extra map[string]interface{}) (map[string]interface{}, error) {
+	leds, err := linux.ReadLEDs(ctx, linux.LEDsRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	c.configLock.Lock()
+	filter := c.leds
+	c.configLock.Unlock()
+
+	ret := make(map[string]interface{})
+	for _, led := range leds {
+		if len(filter) > 0 && !filter[led.Name] {
+			continue
+		}
+		key := ledKey(led.Name)
+		ret[key+"_brightness"] = led.Brightness
+		ret[key+"_max_brightness"] = led.MaxBrightness
+		ret[key+"_trigger"] = led.Trigger
+		ret[key+"_on"] = led.Brightness > 0
+	}
+
+	c.identifyLock.Lock()
+	defer c.identifyLock.Unlock()
+	identifying := make([]string, 0, len(c.identifying))
+	for name := range c.identifying {
+		identifying = append(identifying, name)
+	}
+	sort.Strings(identifying)
+	ret["identifying"] = strings.Join(identifying, ",")
+	return ret, nil
+}
+
+// ledKey converts an LED name into a reading key prefix, e.g. beaglebone:green:usr0 becomes beaglebone_green_usr0 and
+// input3::capslock becomes input3_capslock.
+func ledKey(name string) string {
+	parts := strings.FieldsFunc(name, func(r rune) bool { return r == ':' || r == '-' })
+	return strings.Join(parts, "_")
+}
+
+func (c *Config) DoCommand(ctx context.Context, cmd map[string]interface{}) (map[string]interface{}, error) {
+	c.configLock.Lock()
+	defer c.configLock.Unlock()
+
+	command, ok := cmd["command"].(string)
+	if !ok {
+		return nil, errors.New("missing or invalid 'command' field")
+	}
+
+	switch command {
+	case "list_leds":
+		return c.handleListLEDs(ctx)
+	case "set_brightness":
+		return c.handleSetBrightness(ctx, cmd)
+	case "set_trigger":
+		return c.handleSetTrigger(ctx, cmd)
+	case "identify":
+		return c.handleIdentify(ctx, cmd)
+	default:
+		return nil, fmt.Errorf("unknown command: %s", command)
+	}
+}
+
+func (c *Config) handleListLEDs(ctx context.Context) (map[string]interface{}, error) {
+	leds, err := linux.ReadLEDs(ctx, linux.LEDsRoot)
+	if err != nil {
+		return nil, err
+	}
+	list := make([]interface{}, 0, len(leds))
+	for _, led := range leds {
+		triggers := make([]interface{}, 0, len(led.Triggers))
+		for _, trigger := range led.Triggers {
+			triggers = append(triggers, trigger)
+		}
+		list = append(list, map[string]interface{}{
+			"led":            led.Name,
+			"brightness":     led.Brightness,
+			"max_brightness": led.MaxBrightness,
+			"trigger":        led.Trigger,
+			"triggers":       triggers,
+		})
+	}
+	return map[string]interface{}{"leds": list}, nil
+}
+
+// findLED looks up the LED named by the 'led' parameter, or the configured identify LED when allowDefault is set. An
+// LED that is identifying can't be changed, the identify would undo the change when it finishes.
+func (c *Config) findLED(ctx context.Context, cmd map[string]interface{}, allowDefault bool) (*linux.LED, error) {
+	name, _ := cmd["led"].(string)
+	if name == "" && allowDefault {
+		name = c.identifyLED
+	}
+	if name == "" {
+		return nil, errors.New("missing or invalid 'led' parameter")
+	}
+	leds, err := linux.ReadLEDs(ctx, linux.LEDsRoot)
+	if err != nil {
+		return nil, err
+	}
+	led, err := linux.FindLED(leds, name)
+	if err != nil {
+		return nil, err
+	}
+	c.identifyLock.Lock()
+	defer c.identifyLock.Unlock()
+	if c.identifying[led.Name] {
+		return nil, fmt.Errorf("LED %s is identifying", led.Name)
+	}
+	return led, nil
+}
+
+func (c *Config) handleSetBrightness(ctx context.Context, cmd map[string]interface{}) (map[string]interface{}, error) {
+	led, err := c.findLED(ctx, cmd, false)
+	if err != nil {
+		return nil, err
+	}
+	brightness, ok := cmd["brightness"].(float64)
+	if !ok {
+		return nil, errors.New("missing or invalid 'brightness' parameter")
+	}
+	c.logger.Infof("Setting LED %s brightness to %v", led.Name, brightness)
+	if err := led.SetBrightness(int64(brightness)); err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{"status": "ok", "led": led.Name, "brightness": led.Brightness}, nil
+}
+
+func (c *Config) handleSetTrigger(ctx context.Context, cmd map[string]interface{}) (map[string]interface{}, error) {
+	led, err := c.findLED(ctx, cmd, false)
+	if err != nil {
+		return nil, err
+	}
+	trigger, ok := cmd["trigger"].(string)
+	if !ok || trigger == "" {
+		return nil, errors.New("missing or invalid 'trigger' parameter")
+	}
+	c.logger.Infof("Setting LED %s trigger to %s", led.Name, trigger)
+	if err := led.SetTrigger(trigger); err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{"status": "ok", "led": led.Name, "trigger": led.Trigger}, nil
+}
+
+// handleIdentify blinks an LED for a while and then puts it back how it was, so the board can be found in a rack.
+func (c *Config) handleIdentify(ctx context.Context, cmd map[string]interface{}) (map[string]interface{}, error) {
+	led, err := c.findLED(ctx, cmd, true)
+	if err != nil {
+		return nil, err
+	}
+	duration := defaultIdentifyDuration
+	if durationMs, ok := cmd["duration_ms"].(float64); ok && durationMs > 0 {
+		duration = time.Duration(durationMs) * time.Millisecond
+	}
+	previous := *led
+	c.logger.Infof("Identifying with LED %s for %v", led.Name, duration)
+	if err := led.Blink(identifyBlinkInterval, identifyBlinkInterval); err != nil {
+		return nil, err
+	}
+
+	c.identifyLock.Lock()
+	c.identifying[led.Name] = true
+	c.identifyLock.Unlock()
+	c.workers.Add(func(ctx context.Context) {
+		select {
+		case <-ctx.Done():
+		case <-time.After(duration):
+		}
+		c.restoreLED(led, previous)
+	})
+	return map[string]interface{}{"status": "ok", "led": led.Name, "duration_ms": duration.Milliseconds()}, nil
+}
+
+func (c *Config) restoreLED(led *linux.LED, previous linux.LED) {
+	var err error
+	if previous.Trigger != "" && previous.Trigger != "none" {
+		err = led.SetTrigger(previous.Trigger)
+	} else {
+		// Setting the brightness also clears the timer trigger
+		err = led.SetBrightness(previous.Brightness)
+	}
+	if err != nil {
+		c.logger.Warnf("Failed to restore LED %s after identifying: %v", led.Name, err)
+	}
+	c.identifyLock.Lock()
+	delete(c.identifying, led.Name)
+	c.identifyLock.Unlock()
+}
+
+func (c *Config) Close(ctx context.Context) error {
+	c.configLock.Lock()
+	defer c.configLock.Unlock()
+	c.logger.Infof("Shutting down %s", PrettyName)
+	if c.workers != nil {
+		c.workers.Stop()
+	}
+	c.logger.Infof("%s Shutdown complete", PrettyName)
+	return nil
+}
+
+func (c *Config) Ready(ctx context.Context, extra map[string]interface{}) (bool, error) {
+	return false, nil
+}
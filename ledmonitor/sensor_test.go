@@ -0,0 +1,14 @@
+package ledmonitor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLEDKey(t *testing.T) {
+	assert.Equal(t, "ACT", ledKey("ACT"))
+	assert.Equal(t, "beaglebone_green_usr0", ledKey("beaglebone:green:usr0"))
+	assert.Equal(t, "mmc0", ledKey("mmc0::"))
+	assert.Equal(t, "input3_capslock", ledKey("input3::capslock"))
+}
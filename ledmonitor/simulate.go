@@ -0,0 +1,38 @@
+package ledmonitor
+
+import (
+	"slices"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/linux"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+// simulate returns the activity and power LEDs of a Raspberry Pi 5, the activity LED is lit while the SD card is busy,
+// which it is more often when the board is.
+func simulate(sim *utils.Simulation) map[string]interface{} {
+	act := int64(0)
+	if sim.Value(0.1, 0.6, 0.6, 1) >= 0.5 {
+		act = 1
+	}
+	leds := []linux.LED{
+		{Name: "ACT", Brightness: act, MaxBrightness: 1, Trigger: "mmc0"},
+		{Name: "PWR", Brightness: 1, MaxBrightness: 1, Trigger: "default-on"},
+	}
+	var filter []string
+	if conf, ok := sim.Config().(*ComponentConfig); ok {
+		filter = conf.LEDs
+	}
+	ret := make(map[string]interface{})
+	for _, led := range leds {
+		if len(filter) > 0 && !slices.Contains(filter, led.Name) {
+			continue
+		}
+		key := ledKey(led.Name)
+		ret[key+"_brightness"] = led.Brightness
+		ret[key+"_max_brightness"] = led.MaxBrightness
+		ret[key+"_trigger"] = led.Trigger
+		ret[key+"_on"] = led.Brightness > 0
+	}
+	ret["identifying"] = ""
+	return ret
+}
@@ -0,0 +1,33 @@
+package localapi
+
+import (
+	"errors"
+	"path/filepath"
+	"strconv"
+)
+
+type ComponentConfig struct {
+	SocketPath  string   `json:"socket_path,omitempty"`  // Defaults to /run/hwmonitor/api.sock
+	SocketMode  string   `json:"socket_mode,omitempty"`  // Octal permissions of the socket, defaults to 0660
+	Sensors     []string `json:"sensors"`                // The sensors whose readings are served
+	IntervalSec float64  `json:"interval_sec,omitempty"` // How often the sensors are read, defaults to 10
+	HistorySize int      `json:"history_size,omitempty"` // Readings kept per sensor, defaults to 360
+}
+
+func (conf *ComponentConfig) Validate(path string) ([]string, error) {
+	if conf.SocketPath != "" && !filepath.IsAbs(conf.SocketPath) {
+		return nil, errors.New("socket_path must be absolute")
+	}
+	if conf.SocketMode != "" {
+		if _, err := strconv.ParseUint(conf.SocketMode, 8, 32); err != nil {
+			return nil, errors.New("socket_mode must be octal, e.g. 0660")
+		}
+	}
+	if len(conf.Sensors) == 0 {
+		return nil, errors.New("at least one sensor is required")
+	}
+	if conf.IntervalSec < 0 || conf.HistorySize < 0 {
+		return nil, errors.New("interval_sec and history_size must not be negative")
+	}
+	return conf.Sensors, nil
+}
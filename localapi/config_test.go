@@ -0,0 +1,29 @@
+package localapi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidate(t *testing.T) {
+	for _, conf := range []*ComponentConfig{
+		{Sensors: []string{"cpu"}},
+		{SocketPath: "/run/robot/hwmonitor.sock", SocketMode: "0600", Sensors: []string{"cpu"}},
+	} {
+		deps, err := conf.Validate("")
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"cpu"}, deps)
+	}
+
+	for _, conf := range []*ComponentConfig{
+		{SocketPath: "hwmonitor.sock", Sensors: []string{"cpu"}},
+		{SocketMode: "rw", Sensors: []string{"cpu"}},
+		{SocketMode: "0890", Sensors: []string{"cpu"}},
+		{},
+		{Sensors: []string{"cpu"}, HistorySize: -1},
+	} {
+		_, err := conf.Validate("")
+		assert.Error(t, err, conf)
+	}
+}
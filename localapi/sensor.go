@@ -0,0 +1,211 @@
+package localapi
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"go.viam.com/rdk/components/sensor"
+	"go.viam.com/rdk/logging"
+	"go.viam.com/rdk/resource"
+	viamutils "go.viam.com/utils"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+var (
+	Model       = resource.NewModel(utils.Namespace, "hwmonitor", "local_api")
+	API         = sensor.API
+	PrettyName  = "Local API"
+	Description = "A sensor that serves the current and recent readings of other sensors as JSON over a local Unix socket"
+	Version     = utils.Version
+)
+
+const (
+	defaultSocketPath  = "/run/hwmonitor/api.sock"
+	defaultSocketMode  = 0660
+	defaultInterval    = 10 * time.Second
+	defaultHistorySize = 360
+	readTimeout        = 5 * time.Second
+)
+
+type Config struct {
+	resource.Named
+	configLock   sync.Mutex
+	readingsLock sync.RWMutex
+	logger       logging.Logger
+	workers      *viamutils.StoppableWorkers
+	task         *utils.Task
+	socketPath   string
+	sensorCount  int
+	requestCount int
+	lastErr      error
+}
+
+func init() {
+	resource.RegisterComponent(
+		API,
+		Model,
+		resource.Registration[sensor.Sensor, *ComponentConfig]{Constructor: utils.FilterReadings(NewSensor)})
+}
+
+func NewSensor(ctx context.Context, deps resource.Dependencies, conf resource.Config, logger logging.Logger) (sensor.Sensor, error) {
+	logger.Infof("Starting %s %s", PrettyName, Version)
+	b := Config{
+		Named:  conf.ResourceName().AsNamed(),
+		logger: logger,
+	}
+
+	if err := b.Reconfigure(ctx, deps, conf); err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
+
+func (c *Config) Reconfigure(ctx context.Context, deps resource.Dependencies, rawConf resource.Config) error {
+	c.configLock.Lock()
+	defer c.configLock.Unlock()
+	c.logger.Debugf("Reconfiguring %s", PrettyName)
+
+	// Stopping the workers also closes the server, so the socket is free to be listened on again
+	c.stopWorkers()
+
+	conf, err := resource.NativeConfig[*ComponentConfig](rawConf)
+	if err != nil {
+		return err
+	}
+
+	// In case the component has changed name
+	c.Named = rawConf.ResourceName().AsNamed()
+
+	sources, err := utils.ResolveSources(deps, conf.Sensors)
+	if err != nil {
+		return err
+	}
+	interval := time.Duration(conf.IntervalSec * float64(time.Second))
+	if interval <= 0 {
+		interval = defaultInterval
+	}
+	historySize := conf.HistorySize
+	if historySize == 0 {
+		historySize = defaultHistorySize
+	}
+	socketPath := conf.SocketPath
+	if socketPath == "" {
+		socketPath = defaultSocketPath
+	}
+	var socketMode os.FileMode = defaultSocketMode
+	if conf.SocketMode != "" {
+		mode, err := strconv.ParseUint(conf.SocketMode, 8, 32)
+		if err != nil {
+			return err
+		}
+		socketMode = os.FileMode(mode)
+	}
+
+	listener, err := utils.ListenUnix(socketPath, socketMode)
+	if err != nil {
+		return err
+	}
+	s := newStore(conf.Sensors, historySize)
+	server := &http.Server{Handler: c.countRequests(s.handler()), ReadHeaderTimeout: readTimeout}
+
+	c.readingsLock.Lock()
+	c.socketPath = socketPath
+	c.sensorCount = len(sources)
+	c.lastErr = nil
+	c.readingsLock.Unlock()
+
+	c.workers = viamutils.NewBackgroundStoppableWorkers(func(ctx context.Context) {
+		c.serve(ctx, server, listener)
+	})
+	c.task = utils.ScheduleAligned(interval, utils.ReadingsSource, func(ctx context.Context) {
+		c.poll(ctx, s, sources)
+	})
+	return nil
+}
+
+func (c *Config) serve(ctx context.Context, server *http.Server, listener net.Listener) {
+	go func() {
+		<-ctx.Done()
+		server.Close()
+	}()
+	// The listener removes the socket when the server closes it
+	if err := server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		c.logger.Errorf("Local API stopped: %v", err)
+		c.readingsLock.Lock()
+		c.lastErr = err
+		c.readingsLock.Unlock()
+	}
+}
+
+func (c *Config) countRequests(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c.readingsLock.Lock()
+		c.requestCount++
+		c.readingsLock.Unlock()
+		next.ServeHTTP(w, r)
+	})
+}
+
+// stopWorkers stops polling and then the other background workers, configLock must be held.
+func (c *Config) stopWorkers() {
+	if c.task == nil {
+		return
+	}
+	c.logger.Debug("Stopping background workers")
+	c.task.Stop()
+	c.workers.Stop()
+	c.task, c.workers = nil, nil
+	c.logger.Debugf("Background workers stopped")
+}
+
+// poll stores a reading of every source for the API to serve, the scheduler calls it every interval.
+func (c *Config) poll(ctx context.Context, s *store, sources []utils.Source) {
+	for _, src := range sources {
+		readCtx, cancel := context.WithTimeout(ctx, readTimeout)
+		readings, err := src.Sensor.Readings(readCtx, nil)
+		cancel()
+		if ctx.Err() != nil {
+			return
+		}
+		sm := sample{Time: time.Now().UTC(), Readings: readings}
+		if err != nil {
+			c.logger.Debugf("Failed to read %s: %v", src.Name, err)
+			sm = sample{Time: sm.Time, Error: err.Error()}
+		}
+		s.add(src.Name, sm)
+	}
+}
+
+func (c *Config) Readings(ctx context.Context, extra map[string]interface{}) (map[string]interface{}, error) {
+	c.readingsLock.RLock()
+	defer c.readingsLock.RUnlock()
+	ret := map[string]interface{}{
+		"socket_path":   c.socketPath,
+		"sensor_count":  c.sensorCount,
+		"request_count": c.requestCount,
+	}
+	if c.lastErr != nil {
+		ret["last_error"] = c.lastErr.Error()
+	}
+	return ret, nil
+}
+
+func (c *Config) Close(ctx context.Context) error {
+	c.configLock.Lock()
+	defer c.configLock.Unlock()
+	c.logger.Infof("Shutting down %s", PrettyName)
+	c.stopWorkers()
+	c.logger.Infof("%s Shutdown complete", PrettyName)
+	return nil
+}
+
+func (c *Config) Ready(ctx context.Context, extra map[string]interface{}) (bool, error) {
+	return false, nil
+}
@@ -0,0 +1,117 @@
+package localapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+// sample is one read of a sensor, either its readings or why they couldn't be read.
+type sample struct {
+	Time     time.Time              `json:"time"`
+	Readings map[string]interface{} `json:"readings,omitempty"`
+	Error    string                 `json:"error,omitempty"`
+}
+
+// since returns the samples newer than t, oldest first.
+func since(history utils.RingBuffer[sample], t time.Time) []sample {
+	samples := history.Items()
+	ret := make([]sample, 0, len(samples))
+	for _, s := range samples {
+		if s.Time.After(t) {
+			ret = append(ret, s)
+		}
+	}
+	return ret
+}
+
+// store holds the history of every served sensor, the poller adds to it and the handlers read it.
+type store struct {
+	names   []string
+	history map[string]utils.RingBuffer[sample]
+}
+
+func newStore(names []string, size int) *store {
+	history := make(map[string]utils.RingBuffer[sample], len(names))
+	for _, name := range names {
+		history[name] = utils.NewRingBuffer[sample](size)
+	}
+	return &store{names: names, history: history}
+}
+
+func (s *store) add(name string, sm sample) {
+	s.history[name].Push(sm)
+}
+
+// handler serves the store:
+//
+//	GET /v1/sensors                  the names of the served sensors
+//	GET /v1/readings                 the latest sample of every sensor
+//	GET /v1/readings/{name}          the latest sample of one sensor
+//	GET /v1/history/{name}?since=... the kept samples of one sensor, optionally only those after an RFC 3339 time
+func (s *store) handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v1/sensors", s.handleSensors)
+	mux.HandleFunc("GET /v1/readings", s.handleAllReadings)
+	mux.HandleFunc("GET /v1/readings/{name}", s.handleReadings)
+	mux.HandleFunc("GET /v1/history/{name}", s.handleHistory)
+	return mux
+}
+
+func (s *store) handleSensors(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]interface{}{"sensors": s.names})
+}
+
+func (s *store) handleAllReadings(w http.ResponseWriter, r *http.Request) {
+	ret := make(map[string]sample, len(s.history))
+	for name, history := range s.history {
+		if latest, ok := history.Last(); ok {
+			ret[name] = latest
+		}
+	}
+	writeJSON(w, http.StatusOK, ret)
+}
+
+func (s *store) handleReadings(w http.ResponseWriter, r *http.Request) {
+	history, ok := s.history[r.PathValue("name")]
+	if !ok {
+		writeError(w, http.StatusNotFound, "unknown sensor")
+		return
+	}
+	latest, ok := history.Last()
+	if !ok {
+		writeError(w, http.StatusNotFound, "not read yet")
+		return
+	}
+	writeJSON(w, http.StatusOK, latest)
+}
+
+func (s *store) handleHistory(w http.ResponseWriter, r *http.Request) {
+	var from time.Time
+	if value := r.URL.Query().Get("since"); value != "" {
+		var err error
+		if from, err = time.Parse(time.RFC3339Nano, value); err != nil {
+			writeError(w, http.StatusBadRequest, "since must be an RFC 3339 time")
+			return
+		}
+	}
+	name := r.PathValue("name")
+	history, ok := s.history[name]
+	if !ok {
+		writeError(w, http.StatusNotFound, "unknown sensor")
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"sensor": name, "samples": since(history, from)})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]string{"error": message})
+}
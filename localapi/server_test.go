@@ -0,0 +1,57 @@
+package localapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func get(t *testing.T, s *store, path string, v interface{}) int {
+	t.Helper()
+	w := httptest.NewRecorder()
+	s.handler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), v))
+	return w.Code
+}
+
+func TestHandler(t *testing.T) {
+	s := newStore([]string{"cpu", "fan"}, 10)
+	now := time.Now().UTC()
+	s.add("cpu", sample{Time: now.Add(-time.Minute), Readings: map[string]interface{}{"usage": 10.0}})
+	s.add("cpu", sample{Time: now, Readings: map[string]interface{}{"usage": 12.5}})
+
+	var sensors map[string][]string
+	assert.Equal(t, http.StatusOK, get(t, s, "/v1/sensors", &sensors))
+	assert.Equal(t, []string{"cpu", "fan"}, sensors["sensors"])
+
+	var all map[string]sample
+	assert.Equal(t, http.StatusOK, get(t, s, "/v1/readings", &all))
+	assert.Len(t, all, 1)
+	assert.Equal(t, 12.5, all["cpu"].Readings["usage"])
+
+	var latest sample
+	assert.Equal(t, http.StatusOK, get(t, s, "/v1/readings/cpu", &latest))
+	assert.Equal(t, 12.5, latest.Readings["usage"])
+
+	var failure map[string]string
+	assert.Equal(t, http.StatusNotFound, get(t, s, "/v1/readings/fan", &failure))
+	assert.Equal(t, "not read yet", failure["error"])
+	assert.Equal(t, http.StatusNotFound, get(t, s, "/v1/readings/gpu", &failure))
+	assert.Equal(t, "unknown sensor", failure["error"])
+
+	var history struct {
+		Sensor  string   `json:"sensor"`
+		Samples []sample `json:"samples"`
+	}
+	assert.Equal(t, http.StatusOK, get(t, s, "/v1/history/cpu", &history))
+	assert.Equal(t, "cpu", history.Sensor)
+	assert.Len(t, history.Samples, 2)
+	assert.Equal(t, http.StatusOK, get(t, s, "/v1/history/cpu?since="+now.Add(-time.Second).Format(time.RFC3339Nano), &history))
+	assert.Len(t, history.Samples, 1)
+	assert.Equal(t, http.StatusBadRequest, get(t, s, "/v1/history/cpu?since=yesterday", &failure))
+}
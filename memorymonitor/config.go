@@ -1,8 +1,19 @@
 package memorymonitor
 
+import "github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+
 type ComponentConfig struct {
+	Units utils.Units `json:"units,omitempty"`
 }
 
 func (conf *ComponentConfig) Validate(path string) ([]string, error) {
+	if err := conf.Units.Validate(); err != nil {
+		return nil, err
+	}
 	return nil, nil
 }
+
+// ConvertReadings converts simulated readings to the configured unit as the sensor converts its own.
+func (conf *ComponentConfig) ConvertReadings(readings map[string]interface{}) map[string]interface{} {
+	return convertReadings(conf.Units, readings)
+}
@@ -2,14 +2,18 @@ package memorymonitor
 
 import (
 	"context"
+	"fmt"
 	"math"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/shirou/gopsutil/v4/mem"
 	"go.viam.com/rdk/components/sensor"
 	"go.viam.com/rdk/logging"
 	"go.viam.com/rdk/resource"
 
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/linux"
 	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
 )
 
@@ -31,13 +35,16 @@ type Config struct {
 	Frequency  int
 	Minimum    int
 	Maximum    int
+	swapRates  swapRateTracker
+	units      utils.Units
 }
 
 func init() {
 	resource.RegisterComponent(
 		API,
 		Model,
-		resource.Registration[sensor.Sensor, *ComponentConfig]{Constructor: NewSensor})
+		resource.Registration[sensor.Sensor, *ComponentConfig]{Constructor: utils.FilterReadings(NewSensor)})
+	utils.RegisterSimulator(Model, simulate)
 }
 
 func NewSensor(ctx context.Context, deps resource.Dependencies, conf resource.Config, logger logging.Logger) (sensor.Sensor, error) {
@@ -66,6 +73,12 @@ func (c *Config) Reconfigure(ctx context.Context, _ resource.Dependencies, conf
 	// In case the module has changed name
 	c.Named = conf.ResourceName().AsNamed()
 
+	newConf, err := resource.NativeConfig[*ComponentConfig](conf)
+	if err != nil {
+		return err
+	}
+	c.units = newConf.Units
+
 	return nil
 }
 
@@ -73,21 +86,48 @@ func (c *Config) Readings(ctx context.Context, extra map[string]interface{}) (ma
 	c.mu.RLock()
 	defer c.mu.RUnlock()
 	ret := make(map[string]interface{})
-	v, err := mem.VirtualMemory()
-	if err != nil {
-		return nil, err
+	errs := make(utils.ReadingErrors)
+	if v, err := mem.VirtualMemory(); err == nil {
+		addMemoryReadings(ret, v)
+	} else {
+		errs.Add("memory", err)
+	}
+	if swap, err := mem.SwapMemoryWithContext(ctx); err == nil {
+		addSwapReadings(ret, swap)
+	} else {
+		errs.Add("swap", err)
 	}
 
-	swap, err := mem.SwapMemoryWithContext(ctx)
-	if err != nil {
-		return nil, err
+	// The swap counters are cumulative, report the rates so active thrashing is visible
+	if vmstat, err := linux.ReadVmstat(ctx); err == nil {
+		if rates, ok := c.swapRates.update(vmstat["pswpin"], vmstat["pswpout"], time.Now()); ok {
+			ret["swap_in_pages_per_sec"] = rates.PagesInPerSec
+			ret["swap_out_pages_per_sec"] = rates.PagesOutPerSec
+			ret["swap_in_bytes_per_sec"] = rates.BytesInPerSec
+			ret["swap_out_bytes_per_sec"] = rates.BytesOutPerSec
+		}
 	}
 
-	swap_devices, err := mem.SwapDevicesWithContext(ctx)
-	if err != nil {
-		return nil, err
+	if swap_devices, err := mem.SwapDevicesWithContext(ctx); err == nil {
+		for _, device := range swap_devices {
+			total_swap := device.UsedBytes + device.FreeBytes
+			ret["swap_device_"+device.Name+"_used"] = device.UsedBytes
+			ret["swap_device_"+device.Name+"_free"] = device.FreeBytes
+			ret["swap_device_"+device.Name+"_total"] = total_swap
+			ret["swap_device_"+device.Name+"_used_percent"] = math.Round((float64(device.UsedBytes)/float64(total_swap))*100) / 100
+		}
+	} else {
+		errs.Add("swap_devices", err)
 	}
 
+	// Fail rather than report only the unit when nothing could be read
+	if len(ret) == 0 {
+		return errs.Readings(ret)
+	}
+	return errs.Readings(convertReadings(c.units, ret))
+}
+
+func addMemoryReadings(ret map[string]interface{}, v *mem.VirtualMemoryStat) {
 	ret["total_memory"] = v.Total
 	ret["available_memory"] = v.Available
 	ret["used_memory"] = v.Used
@@ -123,7 +163,9 @@ func (c *Config) Readings(ctx context.Context, extra map[string]interface{}) (ma
 	ret["hugepages_surp"] = v.HugePagesSurp
 	ret["hugepages_size"] = v.HugePageSize
 	ret["anonhugepages"] = v.AnonHugePages
+}
 
+func addSwapReadings(ret map[string]interface{}, swap *mem.SwapMemoryStat) {
 	ret["swap_total"] = swap.Total
 	ret["swap_used"] = swap.Used
 	ret["swap_free"] = swap.Free
@@ -134,16 +176,46 @@ func (c *Config) Readings(ctx context.Context, extra map[string]interface{}) (ma
 	ret["swap_page_out"] = swap.PgOut
 	ret["swap_page_fault"] = swap.PgFault
 	ret["swap_page_maj_fault"] = swap.PgMajFault
+}
+
+// convertReadings converts the sizes from bytes to the configured unit.
+func convertReadings(units utils.Units, readings map[string]interface{}) map[string]interface{} {
+	for key, value := range readings {
+		if isDataReading(key) {
+			readings[key] = units.Convert(utils.Data, value)
+		}
+	}
+	units.AddUnit(readings, utils.Data)
+	return readings
+}
 
-	for _, device := range swap_devices {
-		total_swap := device.UsedBytes + device.FreeBytes
-		ret["swap_device_"+device.Name+"_used"] = device.UsedBytes
-		ret["swap_device_"+device.Name+"_free"] = device.FreeBytes
-		ret["swap_device_"+device.Name+"_total"] = total_swap
-		ret["swap_device_"+device.Name+"_used_percent"] = math.Round((float64(device.UsedBytes)/float64(total_swap))*100) / 100
+// Capabilities reports whether the swap rates can be computed, they need /proc/vmstat.
+func (c *Config) Capabilities(ctx context.Context) utils.Capabilities {
+	caps := utils.Capabilities{Backend: "gopsutil", Unavailable: make(map[string]string)}
+	if _, err := linux.ReadVmstat(ctx); err != nil {
+		for _, key := range []string{"swap_in_pages_per_sec", "swap_out_pages_per_sec", "swap_in_bytes_per_sec", "swap_out_bytes_per_sec"} {
+			caps.Unavailable[key] = fmt.Sprintf("failed to read /proc/vmstat: %v", err)
+		}
 	}
+	return caps
+}
+
+// countReadings are the readings that aren't sizes in bytes, so they aren't converted to the configured data unit
+var countReadings = map[string]bool{
+	"swap_page_in":           true,
+	"swap_page_out":          true,
+	"swap_page_fault":        true,
+	"swap_page_maj_fault":    true,
+	"hugepages_total":        true,
+	"hugepages_free":         true,
+	"hugepages_rsvd":         true,
+	"hugepages_surp":         true,
+	"swap_in_pages_per_sec":  true,
+	"swap_out_pages_per_sec": true,
+}
 
-	return ret, nil
+func isDataReading(key string) bool {
+	return !countReadings[key] && !strings.HasSuffix(key, "used_percent")
 }
 
 func (c *Config) Close(ctx context.Context) error {
@@ -6,7 +6,10 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.viam.com/rdk/logging"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
 )
 
 func TestGetMemory(t *testing.T) {
@@ -21,3 +24,14 @@ func TestGetMemory(t *testing.T) {
 	assert.NotNil(t, readings)
 	logger.Infof("Memory readings: %v", readings)
 }
+
+func TestSimulatedReadingsAreConverted(t *testing.T) {
+	sim, err := utils.NewSimulation(utils.ProfileIdle)
+	require.NoError(t, err)
+	conf := &ComponentConfig{Units: utils.Units{Data: "MiB"}}
+	readings := conf.ConvertReadings(simulate(sim))
+	assert.Equal(t, 8044.0, readings["total_memory"])
+	assert.Equal(t, "MiB", readings["data_unit"])
+	// Percentages aren't sizes
+	assert.LessOrEqual(t, readings["used_percent"], 100.0)
+}
@@ -0,0 +1,40 @@
+package memorymonitor
+
+import (
+	"math"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+const (
+	// The simulated board has 8GB of memory and 2GB of zram swap, like a Raspberry Pi 5 running Raspberry Pi OS
+	simulatedMemory = 8434745344
+	simulatedSwap   = 2147467264
+)
+
+// simulate returns the main memory and swap readings, the memory in use and the swapping increase with the load.
+func simulate(sim *utils.Simulation) map[string]interface{} {
+	usedPercent := sim.Percent(14, 58, 63, 3)
+	used := uint64(simulatedMemory * usedPercent / 100)
+	cached := uint64(sim.Value(1.6e9, 2.4e9, 2.4e9, 1e8))
+	if cached > simulatedMemory-used {
+		cached = simulatedMemory - used
+	}
+	free := simulatedMemory - used - cached
+	swapUsed := uint64(sim.Value(0, 1.5e8, 3e8, 2e7))
+	return map[string]interface{}{
+		"total_memory":           uint64(simulatedMemory),
+		"available_memory":       free + cached,
+		"used_memory":            used,
+		"used_percent":           usedPercent,
+		"free_memory":            free,
+		"cached_memory":          cached,
+		"buffers_memory":         uint64(sim.Value(9e7, 1.2e8, 1.2e8, 5e6)),
+		"swap_total":             uint64(simulatedSwap),
+		"swap_used":              swapUsed,
+		"swap_free":              simulatedSwap - swapUsed,
+		"swap_used_percent":      math.Round(float64(swapUsed)/simulatedSwap*10000) / 100,
+		"swap_in_pages_per_sec":  sim.Value(0, 12, 40, 5),
+		"swap_out_pages_per_sec": sim.Value(0, 20, 60, 5),
+	}
+}
@@ -0,0 +1,50 @@
+package memorymonitor
+
+import (
+	"os"
+	"sync"
+	"time"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+// swapRateTracker turns the cumulative pswpin/pswpout counters from /proc/vmstat into per second rates.
+type swapRateTracker struct {
+	mu       sync.Mutex
+	lastIn   uint64
+	lastOut  uint64
+	lastTime time.Time
+}
+
+type swapRates struct {
+	PagesInPerSec  float64
+	PagesOutPerSec float64
+	BytesInPerSec  float64
+	BytesOutPerSec float64
+}
+
+// update records a new sample and returns the rates since the previous one.
+// It returns false on the first sample or when the counters went backwards.
+func (s *swapRateTracker) update(pswpin, pswpout uint64, now time.Time) (*swapRates, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	lastIn, lastOut, lastTime := s.lastIn, s.lastOut, s.lastTime
+	s.lastIn, s.lastOut, s.lastTime = pswpin, pswpout, now
+
+	if lastTime.IsZero() {
+		return nil, false
+	}
+	elapsed := now.Sub(lastTime).Seconds()
+	if elapsed <= 0 || pswpin < lastIn || pswpout < lastOut {
+		return nil, false
+	}
+	pageSize := float64(os.Getpagesize())
+	inRate := float64(pswpin-lastIn) / elapsed
+	outRate := float64(pswpout-lastOut) / elapsed
+	return &swapRates{
+		PagesInPerSec:  utils.RoundValue(inRate, 2),
+		PagesOutPerSec: utils.RoundValue(outRate, 2),
+		BytesInPerSec:  utils.RoundValue(inRate*pageSize, 2),
+		BytesOutPerSec: utils.RoundValue(outRate*pageSize, 2),
+	}, true
+}
@@ -0,0 +1,32 @@
+package memorymonitor
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSwapRateTracker(t *testing.T) {
+	tracker := &swapRateTracker{}
+	now := time.Now()
+	_, ok := tracker.update(100, 200, now)
+	assert.False(t, ok, "first sample should not produce a rate")
+
+	rates, ok := tracker.update(150, 400, now.Add(2*time.Second))
+	require.True(t, ok)
+	assert.Equal(t, 25.0, rates.PagesInPerSec)
+	assert.Equal(t, 100.0, rates.PagesOutPerSec)
+	assert.Equal(t, 25.0*float64(os.Getpagesize()), rates.BytesInPerSec)
+
+	// Counter reset, e.g. after a reboot of a remote host or a wraparound
+	_, ok = tracker.update(10, 20, now.Add(3*time.Second))
+	assert.False(t, ok)
+
+	rates, ok = tracker.update(10, 20, now.Add(4*time.Second))
+	require.True(t, ok)
+	assert.Equal(t, 0.0, rates.PagesInPerSec)
+	assert.Equal(t, 0.0, rates.PagesOutPerSec)
+}
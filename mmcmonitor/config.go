@@ -0,0 +1,15 @@
+package mmcmonitor
+
+import "errors"
+
+type ComponentConfig struct {
+	// Rated write endurance of the card in terabytes written, used to estimate the remaining lifetime
+	EnduranceTBW float64 `json:"endurance_tbw"`
+}
+
+func (conf *ComponentConfig) Validate(path string) ([]string, error) {
+	if conf.EnduranceTBW < 0 {
+		return nil, errors.New("endurance_tbw must not be negative")
+	}
+	return nil, nil
+}
@@ -0,0 +1,139 @@
+package mmcmonitor
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/shirou/gopsutil/v4/host"
+	"go.viam.com/rdk/components/sensor"
+	"go.viam.com/rdk/logging"
+	"go.viam.com/rdk/resource"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/linux"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+var (
+	Model       = resource.NewModel(utils.Namespace, "hwmonitor", "mmc_monitor")
+	API         = sensor.API
+	PrettyName  = "SD Card and eMMC Monitor"
+	Description = "A sensor that identifies SD cards and eMMC and reports their wear"
+	Version     = utils.Version
+)
+
+type Config struct {
+	resource.Named
+	mu           sync.RWMutex
+	logger       logging.Logger
+	cancelCtx    context.Context
+	cancelFunc   func()
+	enduranceTBW float64
+}
+
+func init() {
+	resource.RegisterComponent(
+		API,
+		Model,
+		resource.Registration[sensor.Sensor, *ComponentConfig]{Constructor: utils.FilterReadings(NewSensor)})
+	utils.RegisterSimulator(Model, simulate)
+}
+
+func NewSensor(ctx context.Context, deps resource.Dependencies, conf resource.Config, logger logging.Logger) (sensor.Sensor, error) {
+	logger.Infof("Starting %s %s", PrettyName, Version)
+	cancelCtx, cancelFunc := context.WithCancel(context.Background())
+
+	b := Config{
+		Named:      conf.ResourceName().AsNamed(),
+		logger:     logger,
+		cancelCtx:  cancelCtx,
+		cancelFunc: cancelFunc,
+		mu:         sync.RWMutex{},
+	}
+
+	if err := b.Reconfigure(ctx, deps, conf); err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
+
+func (c *Config) Reconfigure(ctx context.Context, _ resource.Dependencies, conf resource.Config) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.logger.Debugf("Reconfiguring %s", PrettyName)
+
+	newConf, err := resource.NativeConfig[*ComponentConfig](conf)
+	if err != nil {
+		return err
+	}
+	c.enduranceTBW = newConf.EnduranceTBW
+
+	// In case the module has changed name
+	c.Named = conf.ResourceName().AsNamed()
+
+	return nil
+}
+
+func (c *Config) Readings(ctx context.Context, extra map[string]interface{}) (map[string]interface{}, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	devices, err := linux.GetMMCDevices(ctx)
+	if err != nil {
+		return nil, err
+	}
+	diskstats, err := linux.ReadDiskstats(ctx)
+	if err != nil {
+		return nil, err
+	}
+	uptime, err := host.UptimeWithContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ret := make(map[string]interface{})
+	for _, device := range devices {
+		name := device.Name
+		ret[name+"_type"] = device.Type
+		ret[name+"_cid"] = device.CID
+		ret[name+"_manufacturer_id"] = device.ManufacturerID
+		ret[name+"_manufacturer"] = device.Manufacturer
+		// An unknown manufacturer id is the most common sign of a counterfeit card
+		ret[name+"_known_manufacturer"] = device.Manufacturer != ""
+		ret[name+"_oem_id"] = device.OEMID
+		ret[name+"_product_name"] = device.ProductName
+		ret[name+"_serial"] = device.Serial
+		ret[name+"_manufacture_date"] = device.ManufactureDate
+		ret[name+"_hardware_revision"] = device.HardwareRevision
+		ret[name+"_firmware_revision"] = device.FirmwareRevision
+		ret[name+"_size_bytes"] = device.SizeBytes
+		if device.Health != nil {
+			ret[name+"_life_time_estimate_a_percent"] = device.Health.LifeTimeEstimateA
+			ret[name+"_life_time_estimate_b_percent"] = device.Health.LifeTimeEstimateB
+			ret[name+"_life_used_percent"] = device.Health.LifeUsedPercent()
+			ret[name+"_pre_eol_info"] = device.Health.PreEOLState()
+		}
+
+		stats, ok := diskstats[name]
+		if !ok {
+			continue
+		}
+		written := stats.SectorsWritten * linux.DiskSectorSize
+		estimate := estimateWear(written, device.SizeBytes, time.Duration(uptime)*time.Second, c.enduranceTBW)
+		ret[name+"_written_bytes_since_boot"] = written
+		ret[name+"_full_drive_writes_since_boot"] = estimate.FullDriveWrites
+		ret[name+"_write_bytes_per_day"] = estimate.WriteBytesPerDay
+		if c.enduranceTBW > 0 {
+			ret[name+"_estimated_lifetime_years"] = estimate.LifetimeYears
+		}
+	}
+	return ret, nil
+}
+
+func (c *Config) Close(ctx context.Context) error {
+	c.logger.Infof("Shutting down %s", PrettyName)
+	return nil
+}
+
+func (c *Config) Ready(ctx context.Context, extra map[string]interface{}) (bool, error) {
+	return false, nil
+}
@@ -0,0 +1,57 @@
+package mmcmonitor
+
+import (
+	"time"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/linux"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+var simulatedCard = linux.MMCDevice{
+	Name:             "mmc0",
+	Type:             "SD",
+	CID:              "035344534e36344780c3b5d2e4016800",
+	ManufacturerID:   0x03,
+	Manufacturer:     "SanDisk",
+	OEMID:            "0x5344",
+	ProductName:      "SN64G",
+	Serial:           "0xc3b5d2e4",
+	ManufactureDate:  "08/2024",
+	HardwareRevision: "0x8",
+	FirmwareRevision: "0x0",
+	SizeBytes:        63_864_569_856,
+}
+
+// simulate returns a 64GB SD card that has been up a day, written to faster when the board is busy.
+func simulate(sim *utils.Simulation) map[string]interface{} {
+	card := simulatedCard
+	name := card.Name
+	uptime := time.Duration(sim.Counter(86400, 1, 1, 1)) * time.Second
+	written := sim.Counter(2.4e9, 2e4, 1.5e6, 1.5e6)
+	var enduranceTBW float64
+	if conf, ok := sim.Config().(*ComponentConfig); ok {
+		enduranceTBW = conf.EnduranceTBW
+	}
+	estimate := estimateWear(written, card.SizeBytes, uptime, enduranceTBW)
+	ret := map[string]interface{}{
+		name + "_type":                         card.Type,
+		name + "_cid":                          card.CID,
+		name + "_manufacturer_id":              card.ManufacturerID,
+		name + "_manufacturer":                 card.Manufacturer,
+		name + "_known_manufacturer":           true,
+		name + "_oem_id":                       card.OEMID,
+		name + "_product_name":                 card.ProductName,
+		name + "_serial":                       card.Serial,
+		name + "_manufacture_date":             card.ManufactureDate,
+		name + "_hardware_revision":            card.HardwareRevision,
+		name + "_firmware_revision":            card.FirmwareRevision,
+		name + "_size_bytes":                   card.SizeBytes,
+		name + "_written_bytes_since_boot":     written,
+		name + "_full_drive_writes_since_boot": estimate.FullDriveWrites,
+		name + "_write_bytes_per_day":          estimate.WriteBytesPerDay,
+	}
+	if enduranceTBW > 0 {
+		ret[name+"_estimated_lifetime_years"] = estimate.LifetimeYears
+	}
+	return ret
+}
@@ -0,0 +1,35 @@
+package mmcmonitor
+
+import (
+	"time"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+const bytesPerTB = 1000 * 1000 * 1000 * 1000
+
+type wearEstimate struct {
+	// FullDriveWrites is how many times the whole card has been written since boot
+	FullDriveWrites  float64
+	WriteBytesPerDay float64
+	// LifetimeYears is how long the card lasts at the current write rate, 0 if the endurance is unknown
+	LifetimeYears float64
+}
+
+// estimateWear extrapolates the writes since boot, the kernel counters reset on every boot so this is
+// a rate based heuristic rather than the total wear of the card.
+func estimateWear(writtenBytes, sizeBytes uint64, uptime time.Duration, enduranceTBW float64) *wearEstimate {
+	estimate := &wearEstimate{}
+	if sizeBytes > 0 {
+		estimate.FullDriveWrites = utils.RoundValue(float64(writtenBytes)/float64(sizeBytes), 4)
+	}
+	days := uptime.Hours() / 24
+	if days <= 0 {
+		return estimate
+	}
+	estimate.WriteBytesPerDay = utils.RoundValue(float64(writtenBytes)/days, 0)
+	if enduranceTBW > 0 && estimate.WriteBytesPerDay > 0 {
+		estimate.LifetimeYears = utils.RoundValue(enduranceTBW*bytesPerTB/estimate.WriteBytesPerDay/365, 2)
+	}
+	return estimate
+}
@@ -0,0 +1,23 @@
+package mmcmonitor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEstimateWear(t *testing.T) {
+	// 10GB written to a 32GB card in 2 days
+	estimate := estimateWear(10*1000*1000*1000, 32*1000*1000*1000, 48*time.Hour, 36.5)
+	assert.Equal(t, 0.3125, estimate.FullDriveWrites)
+	assert.Equal(t, 5e9, estimate.WriteBytesPerDay)
+	assert.Equal(t, 20.0, estimate.LifetimeYears)
+
+	estimate = estimateWear(10*1000*1000*1000, 32*1000*1000*1000, 48*time.Hour, 0)
+	assert.Equal(t, 0.0, estimate.LifetimeYears)
+
+	estimate = estimateWear(0, 0, 0, 10)
+	assert.Equal(t, 0.0, estimate.FullDriveWrites)
+	assert.Equal(t, 0.0, estimate.WriteBytesPerDay)
+}
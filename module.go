@@ -4,29 +4,112 @@
 package main
 
 import (
+	"os"
+
 	moduleutils "github.com/thegreatco/viamutils/module"
 	"go.viam.com/rdk/module"
 	viamutils "go.viam.com/utils"
 
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/alertmonitor"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/batchreadings"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/boardhealth"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/boardidentity"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/boardinfo"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/bootmonitor"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/cgroupmonitor"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/clockeventmonitor"
 	"github.com/rinzlerlabs/viam-sbc-hwmonitor/clocks"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/containermonitor"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/coolingmonitor"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/coredumpmonitor"
 	"github.com/rinzlerlabs/viam-sbc-hwmonitor/cpumanager"
 	"github.com/rinzlerlabs/viam-sbc-hwmonitor/cpumonitor"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/csicameramonitor"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/derivedmetrics"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/directorymonitor"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/diskiomonitor"
 	"github.com/rinzlerlabs/viam-sbc-hwmonitor/diskmonitor"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/displaymonitor"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/environmentmonitor"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/fanmonitor"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/filesink"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/filesystemmonitor"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/firmwaremonitor"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/gnssmonitor"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/gpiomonitor"
 	"github.com/rinzlerlabs/viam-sbc-hwmonitor/gpumonitor"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/hatmonitor"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/hwdiscovery"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/i2cmonitor"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/iiomonitor"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/influxexporter"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/jetsonpowermode"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/journalmonitor"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/kernellogmonitor"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/kernelmemmonitor"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/kernelmonitor"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/kubeletmonitor"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/ledmonitor"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/localapi"
 	"github.com/rinzlerlabs/viam-sbc-hwmonitor/memorymonitor"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/mmcmonitor"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/mqttpublisher"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/onewiremonitor"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/oommonitor"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/orinsummary"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/otelexporter"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/pciemonitor"
 	"github.com/rinzlerlabs/viam-sbc-hwmonitor/powermanager"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/pressuremonitor"
 	"github.com/rinzlerlabs/viam-sbc-hwmonitor/processmonitor"
 	"github.com/rinzlerlabs/viam-sbc-hwmonitor/pwmfan"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/pwmmonitor"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/readinghistory"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/remoteprocmonitor"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/removablemediamonitor"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/rollingstats"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/selfmonitor"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/serialmonitor"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/sessionmonitor"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/snmpagent"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/sqlitestore"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/statsdexporter"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/storagearraymonitor"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/streamapi"
 	"github.com/rinzlerlabs/viam-sbc-hwmonitor/temperatures"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/thermalevents"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/thermaltrend"
 	"github.com/rinzlerlabs/viam-sbc-hwmonitor/throttling"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/timesyncmonitor"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/usbmonitor"
 	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/v4l2monitor"
 	"github.com/rinzlerlabs/viam-sbc-hwmonitor/voltages"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/watchdogmonitor"
 	"github.com/rinzlerlabs/viam-sbc-hwmonitor/wifimonitor"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/zrammonitor"
 )
 
 func main() {
 	logger := module.NewLoggerFromArgs(utils.LoggerName)
 	logger.Infof("Starting RinzlerLabs SBC Sensors Module %v", utils.Version)
+	if archive := os.Getenv(utils.ReplayEnv); archive != "" {
+		root, err := utils.StartReplay(archive)
+		if err != nil {
+			logger.Fatalf("Failed to replay %v: %v", archive, err)
+		}
+		logger.Warnf("Replaying snapshot %v from %v, readings come from the snapshot rather than this board", archive, root)
+	} else {
+		if root := os.Getenv(utils.HostRootEnv); root != "" {
+			if err := utils.SetHostRoot(root); err != nil {
+				logger.Fatalf("Failed to read the host's files from %v: %v", root, err)
+			}
+			logger.Infof("Reading the host's files from %v", root)
+		}
+		for _, warning := range utils.DetectHostEnvironment().Warnings {
+			logger.Warn(warning)
+		}
+	}
 	moduleutils.AddModularResource(clocks.API, clocks.Model)
 	moduleutils.AddModularResource(cpumanager.API, cpumanager.Model)
 	moduleutils.AddModularResource(temperatures.API, temperatures.Model)
@@ -40,5 +123,69 @@ func main() {
 	moduleutils.AddModularResource(diskmonitor.API, diskmonitor.Model)
 	moduleutils.AddModularResource(wifimonitor.API, wifimonitor.Model)
 	moduleutils.AddModularResource(powermanager.API, powermanager.Model)
+	moduleutils.AddModularResource(pressuremonitor.API, pressuremonitor.Model)
+	moduleutils.AddModularResource(oommonitor.API, oommonitor.Model)
+	moduleutils.AddModularResource(zrammonitor.API, zrammonitor.Model)
+	moduleutils.AddModularResource(kernelmemmonitor.API, kernelmemmonitor.Model)
+	moduleutils.AddModularResource(filesystemmonitor.API, filesystemmonitor.Model)
+	moduleutils.AddModularResource(diskiomonitor.API, diskiomonitor.Model)
+	moduleutils.AddModularResource(mmcmonitor.API, mmcmonitor.Model)
+	moduleutils.AddModularResource(storagearraymonitor.API, storagearraymonitor.Model)
+	moduleutils.AddModularResource(removablemediamonitor.API, removablemediamonitor.Model)
+	moduleutils.AddModularResource(directorymonitor.API, directorymonitor.Model)
+	moduleutils.AddModularResource(bootmonitor.API, bootmonitor.Model)
+	moduleutils.AddModularResource(kernellogmonitor.API, kernellogmonitor.Model)
+	moduleutils.AddModularResource(journalmonitor.API, journalmonitor.Model)
+	moduleutils.AddModularResource(timesyncmonitor.API, timesyncmonitor.Model)
+	moduleutils.AddModularResource(watchdogmonitor.API, watchdogmonitor.Model)
+	moduleutils.AddModularResource(kernelmonitor.API, kernelmonitor.Model)
+	moduleutils.AddModularResource(sessionmonitor.API, sessionmonitor.Model)
+	moduleutils.AddModularResource(firmwaremonitor.API, firmwaremonitor.Model)
+	moduleutils.AddModularResource(coredumpmonitor.API, coredumpmonitor.Model)
+	moduleutils.AddModularResource(clockeventmonitor.API, clockeventmonitor.Model)
+	moduleutils.AddModularResource(gnssmonitor.API, gnssmonitor.Model)
+	moduleutils.AddModularResource(boardinfo.API, boardinfo.Model)
+	moduleutils.AddModularResource(pciemonitor.API, pciemonitor.Model)
+	moduleutils.AddModularResource(orinsummary.API, orinsummary.Model)
+	moduleutils.AddModularResource(jetsonpowermode.API, jetsonpowermode.Model)
+	moduleutils.AddModularResource(remoteprocmonitor.API, remoteprocmonitor.Model)
+	moduleutils.AddModularResource(fanmonitor.API, fanmonitor.Model)
+	moduleutils.AddModularResource(hatmonitor.API, hatmonitor.Model)
+	moduleutils.AddModularResource(boardidentity.API, boardidentity.Model)
+	moduleutils.AddModularResource(gpiomonitor.API, gpiomonitor.Model)
+	moduleutils.AddModularResource(pwmmonitor.API, pwmmonitor.Model)
+	moduleutils.AddModularResource(i2cmonitor.API, i2cmonitor.Model)
+	moduleutils.AddModularResource(iiomonitor.API, iiomonitor.Model)
+	moduleutils.AddModularResource(onewiremonitor.API, onewiremonitor.Model)
+	moduleutils.AddModularResource(environmentmonitor.API, environmentmonitor.Model)
+	moduleutils.AddModularResource(usbmonitor.API, usbmonitor.Model)
+	moduleutils.AddModularResource(csicameramonitor.API, csicameramonitor.Model)
+	moduleutils.AddModularResource(v4l2monitor.API, v4l2monitor.Model)
+	moduleutils.AddModularResource(displaymonitor.API, displaymonitor.Model)
+	moduleutils.AddModularResource(ledmonitor.API, ledmonitor.Model)
+	moduleutils.AddModularResource(serialmonitor.API, serialmonitor.Model)
+	moduleutils.AddModularResource(mqttpublisher.API, mqttpublisher.Model)
+	moduleutils.AddModularResource(otelexporter.API, otelexporter.Model)
+	moduleutils.AddModularResource(influxexporter.API, influxexporter.Model)
+	moduleutils.AddModularResource(statsdexporter.API, statsdexporter.Model)
+	moduleutils.AddModularResource(localapi.API, localapi.Model)
+	moduleutils.AddModularResource(streamapi.API, streamapi.Model)
+	moduleutils.AddModularResource(snmpagent.API, snmpagent.Model)
+	moduleutils.AddModularResource(readinghistory.API, readinghistory.Model)
+	moduleutils.AddModularResource(sqlitestore.API, sqlitestore.Model)
+	moduleutils.AddModularResource(filesink.API, filesink.Model)
+	moduleutils.AddModularResource(alertmonitor.API, alertmonitor.Model)
+	moduleutils.AddModularResource(boardhealth.API, boardhealth.Model)
+	moduleutils.AddModularResource(derivedmetrics.API, derivedmetrics.Model)
+	moduleutils.AddModularResource(rollingstats.API, rollingstats.Model)
+	moduleutils.AddModularResource(hwdiscovery.API, hwdiscovery.Model)
+	moduleutils.AddModularResource(selfmonitor.API, selfmonitor.Model)
+	moduleutils.AddModularResource(containermonitor.API, containermonitor.Model)
+	moduleutils.AddModularResource(kubeletmonitor.API, kubeletmonitor.Model)
+	moduleutils.AddModularResource(cgroupmonitor.API, cgroupmonitor.Model)
+	moduleutils.AddModularResource(thermaltrend.API, thermaltrend.Model)
+	moduleutils.AddModularResource(coolingmonitor.API, coolingmonitor.Model)
+	moduleutils.AddModularResource(thermalevents.API, thermalevents.Model)
+	moduleutils.AddModularResource(batchreadings.API, batchreadings.Model)
 	viamutils.ContextualMain(moduleutils.RunModule, logger)
 }
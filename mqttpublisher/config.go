@@ -0,0 +1,42 @@
+package mqttpublisher
+
+import (
+	"errors"
+	"fmt"
+)
+
+type ComponentConfig struct {
+	Broker             string   `json:"broker"`              // e.g. tcp://broker:1883, or ssl://broker:8883 for TLS
+	ClientID           string   `json:"client_id,omitempty"` // Defaults to hwmonitor-<hostname>
+	Username           string   `json:"username,omitempty"`
+	Password           string   `json:"password,omitempty"`
+	CAFile             string   `json:"ca_file,omitempty"`   // Verifies the broker with this CA rather than the system roots
+	CertFile           string   `json:"cert_file,omitempty"` // Client certificate for brokers that require one
+	KeyFile            string   `json:"key_file,omitempty"`
+	InsecureSkipVerify bool     `json:"insecure_skip_verify,omitempty"` // Don't verify the broker's certificate
+	Sensors            []string `json:"sensors"`                        // The sensors whose readings are published
+	Topic              string   `json:"topic,omitempty"`                // Defaults to hwmonitor/{host}/{sensor}
+	StatusTopic        string   `json:"status_topic,omitempty"`         // Defaults to hwmonitor/{host}/status
+	QoS                int      `json:"qos,omitempty"`                  // 0 or 1
+	Retain             bool     `json:"retain,omitempty"`
+	IntervalSec        float64  `json:"interval_sec,omitempty"` // Defaults to 10
+}
+
+func (conf *ComponentConfig) Validate(path string) ([]string, error) {
+	if conf.Broker == "" {
+		return nil, errors.New("broker is required")
+	}
+	if len(conf.Sensors) == 0 {
+		return nil, errors.New("at least one sensor is required")
+	}
+	if conf.QoS != 0 && conf.QoS != 1 {
+		return nil, fmt.Errorf("qos must be 0 or 1, got %d", conf.QoS)
+	}
+	if (conf.CertFile == "") != (conf.KeyFile == "") {
+		return nil, errors.New("cert_file and key_file must be set together")
+	}
+	if conf.IntervalSec < 0 {
+		return nil, errors.New("interval_sec must not be negative")
+	}
+	return conf.Sensors, nil
+}
@@ -0,0 +1,25 @@
+package mqttpublisher
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidate(t *testing.T) {
+	conf := &ComponentConfig{Broker: "tcp://broker:1883", Sensors: []string{"cpu", "temperatures"}, QoS: 1}
+	deps, err := conf.Validate("")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"cpu", "temperatures"}, deps)
+
+	for _, conf := range []*ComponentConfig{
+		{Sensors: []string{"cpu"}},
+		{Broker: "tcp://broker:1883"},
+		{Broker: "tcp://broker:1883", Sensors: []string{"cpu"}, QoS: 2},
+		{Broker: "ssl://broker:8883", Sensors: []string{"cpu"}, CertFile: "client.pem"},
+		{Broker: "tcp://broker:1883", Sensors: []string{"cpu"}, IntervalSec: -1},
+	} {
+		_, err := conf.Validate("")
+		assert.Error(t, err, conf)
+	}
+}
@@ -0,0 +1,308 @@
+package mqttpublisher
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"go.viam.com/rdk/components/sensor"
+	"go.viam.com/rdk/logging"
+	"go.viam.com/rdk/resource"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/mqtt"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+var (
+	Model       = resource.NewModel(utils.Namespace, "hwmonitor", "mqtt_publisher")
+	API         = sensor.API
+	PrettyName  = "MQTT Publisher"
+	Description = "A sensor that publishes the readings of other sensors to an MQTT broker"
+	Version     = utils.Version
+)
+
+const (
+	defaultInterval    = 10 * time.Second
+	defaultTopic       = "hwmonitor/{host}/{sensor}"
+	defaultStatusTopic = "hwmonitor/{host}/status"
+	publishTimeout     = 5 * time.Second
+	statusOnline       = "online"
+	statusOffline      = "offline"
+)
+
+// source is a sensor the publisher publishes, with the topic its readings are published to.
+type source struct {
+	utils.Source
+	topic string
+}
+
+// publisher is the broker to publish to and what's published to which topic. A reconfigure stops the task and builds a
+// new one, so the connection and its will always match the topics and QoS they were made for.
+type publisher struct {
+	options     mqtt.Options
+	sources     []source
+	statusTopic string
+	qos         byte
+	retain      bool
+	interval    time.Duration
+	// client is the connection to the broker, only the poll touches it until the task is stopped
+	client *mqtt.Client
+}
+
+type Config struct {
+	resource.Named
+	configLock     sync.Mutex
+	readingsLock   sync.RWMutex
+	logger         logging.Logger
+	task           *utils.Task
+	publisher      *publisher
+	broker         string
+	connected      bool
+	publishedCount int
+	failedCount    int
+	lastPublish    time.Time
+	lastErr        error
+}
+
+func init() {
+	resource.RegisterComponent(
+		API,
+		Model,
+		resource.Registration[sensor.Sensor, *ComponentConfig]{Constructor: utils.FilterReadings(NewSensor)})
+}
+
+func NewSensor(ctx context.Context, deps resource.Dependencies, conf resource.Config, logger logging.Logger) (sensor.Sensor, error) {
+	logger.Infof("Starting %s %s", PrettyName, Version)
+	b := Config{
+		Named:  conf.ResourceName().AsNamed(),
+		logger: logger,
+	}
+
+	if err := b.Reconfigure(ctx, deps, conf); err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
+
+func (c *Config) Reconfigure(ctx context.Context, deps resource.Dependencies, rawConf resource.Config) error {
+	c.configLock.Lock()
+	defer c.configLock.Unlock()
+	c.logger.Debugf("Reconfiguring %s", PrettyName)
+
+	c.stopPublishing()
+
+	conf, err := resource.NativeConfig[*ComponentConfig](rawConf)
+	if err != nil {
+		return err
+	}
+
+	// In case the component has changed name
+	c.Named = rawConf.ResourceName().AsNamed()
+
+	p, err := newPublisher(conf, deps)
+	if err != nil {
+		return err
+	}
+
+	c.readingsLock.Lock()
+	c.broker = conf.Broker
+	c.connected = false
+	c.lastErr = nil
+	c.readingsLock.Unlock()
+
+	c.publisher = p
+	c.task = utils.ScheduleAligned(p.interval, utils.ReadingsSource, func(ctx context.Context) {
+		c.publishOnce(ctx, p)
+	})
+	return nil
+}
+
+func newPublisher(conf *ComponentConfig, deps resource.Dependencies) (*publisher, error) {
+	host, err := os.Hostname()
+	if err != nil {
+		return nil, err
+	}
+	clientID := conf.ClientID
+	if clientID == "" {
+		clientID = "hwmonitor-" + host
+	}
+	topic := conf.Topic
+	if topic == "" {
+		topic = defaultTopic
+	}
+	statusTopic := conf.StatusTopic
+	if statusTopic == "" {
+		statusTopic = defaultStatusTopic
+	}
+	interval := time.Duration(conf.IntervalSec * float64(time.Second))
+	if interval <= 0 {
+		interval = defaultInterval
+	}
+	tlsConfig, err := utils.NewTLSConfig(conf.CAFile, conf.CertFile, conf.KeyFile, conf.InsecureSkipVerify)
+	if err != nil {
+		return nil, err
+	}
+
+	resolved, err := utils.ResolveSources(deps, conf.Sensors)
+	if err != nil {
+		return nil, err
+	}
+	sources := make([]source, 0, len(resolved))
+	for _, s := range resolved {
+		sources = append(sources, source{Source: s, topic: expandTopic(topic, host, s.Name)})
+	}
+	statusTopic = expandTopic(statusTopic, host, "")
+
+	return &publisher{
+		options: mqtt.Options{
+			Broker:    conf.Broker,
+			ClientID:  clientID,
+			Username:  conf.Username,
+			Password:  conf.Password,
+			TLSConfig: tlsConfig,
+			// The broker tells subscribers the robot is offline if it disappears without disconnecting
+			Will: &mqtt.Message{Topic: statusTopic, Payload: []byte(statusOffline), QoS: byte(conf.QoS), Retain: true},
+		},
+		sources:     sources,
+		statusTopic: statusTopic,
+		qos:         byte(conf.QoS),
+		retain:      conf.Retain,
+		interval:    interval,
+	}, nil
+}
+
+// expandTopic fills in the {host} and {sensor} placeholders of a topic.
+func expandTopic(topic, host, sensorName string) string {
+	return strings.NewReplacer("{host}", host, "{sensor}", sensorName).Replace(topic)
+}
+
+// stopPublishing stops polling and disconnects from the broker, configLock must be held.
+func (c *Config) stopPublishing() {
+	if c.task == nil {
+		return
+	}
+	c.logger.Debug("Stopping polling")
+	c.task.Stop()
+	if client := c.publisher.client; client != nil {
+		// Disconnecting cleanly means the broker won't publish the will, so the offline status is published here
+		publishCtx, cancel := context.WithTimeout(context.Background(), publishTimeout)
+		defer cancel()
+		if err := client.Publish(publishCtx, mqtt.Message{Topic: c.publisher.statusTopic, Payload: []byte(statusOffline), QoS: c.publisher.qos, Retain: true}); err != nil {
+			c.logger.Debugf("Failed to publish offline status: %v", err)
+		}
+		client.Close()
+	}
+	c.task, c.publisher = nil, nil
+	c.logger.Debugf("Polling stopped")
+}
+
+// publishOnce publishes the readings of every source, connecting to the broker first if it isn't connected. The
+// scheduler calls it every interval.
+func (c *Config) publishOnce(ctx context.Context, p *publisher) {
+	if p.client != nil && p.client.Err() != nil {
+		c.logger.Warnf("Lost connection to MQTT broker: %v", p.client.Err())
+		p.client = nil
+	}
+	if p.client == nil {
+		client, err := c.connect(ctx, p)
+		c.readingsLock.Lock()
+		c.connected = err == nil
+		if err != nil {
+			c.lastErr = err
+		}
+		c.readingsLock.Unlock()
+		if err != nil {
+			c.logger.Warnf("Failed to connect to MQTT broker %s: %v", p.options.Broker, err)
+			return
+		}
+		p.client = client
+	}
+	c.publishAll(ctx, p.client, p)
+}
+
+func (c *Config) connect(ctx context.Context, p *publisher) (*mqtt.Client, error) {
+	connectCtx, cancel := context.WithTimeout(ctx, publishTimeout)
+	defer cancel()
+	client, err := mqtt.Connect(connectCtx, p.options)
+	if err != nil {
+		return nil, err
+	}
+	if err := client.Publish(connectCtx, mqtt.Message{Topic: p.statusTopic, Payload: []byte(statusOnline), QoS: p.qos, Retain: true}); err != nil {
+		client.Close()
+		return nil, err
+	}
+	c.logger.Infof("Connected to MQTT broker %s", p.options.Broker)
+	return client, nil
+}
+
+func (c *Config) publishAll(ctx context.Context, client *mqtt.Client, p *publisher) {
+	for _, s := range p.sources {
+		err := c.publish(ctx, client, p, s)
+		c.readingsLock.Lock()
+		if err != nil {
+			c.failedCount++
+			c.lastErr = err
+		} else {
+			c.publishedCount++
+			c.lastPublish = time.Now()
+		}
+		c.readingsLock.Unlock()
+		if err != nil {
+			c.logger.Debugf("Failed to publish readings of %s: %v", s.Name, err)
+		}
+		if client.Err() != nil {
+			return
+		}
+	}
+}
+
+func (c *Config) publish(ctx context.Context, client *mqtt.Client, p *publisher, s source) error {
+	ctx, cancel := context.WithTimeout(ctx, publishTimeout)
+	defer cancel()
+	readings, err := s.Sensor.Readings(ctx, nil)
+	if err != nil {
+		return err
+	}
+	payload, err := json.Marshal(map[string]interface{}{
+		"time":     time.Now().UTC().Format(time.RFC3339Nano),
+		"readings": readings,
+	})
+	if err != nil {
+		return err
+	}
+	return client.Publish(ctx, mqtt.Message{Topic: s.topic, Payload: payload, QoS: p.qos, Retain: p.retain})
+}
+
+func (c *Config) Readings(ctx context.Context, extra map[string]interface{}) (map[string]interface{}, error) {
+	c.readingsLock.RLock()
+	defer c.readingsLock.RUnlock()
+	ret := map[string]interface{}{
+		"broker":          c.broker,
+		"connected":       c.connected,
+		"published_count": c.publishedCount,
+		"failed_count":    c.failedCount,
+	}
+	if !c.lastPublish.IsZero() {
+		ret["last_publish_time"] = c.lastPublish.Format(time.RFC3339)
+	}
+	if c.lastErr != nil {
+		ret["last_error"] = c.lastErr.Error()
+	}
+	return ret, nil
+}
+
+func (c *Config) Close(ctx context.Context) error {
+	c.configLock.Lock()
+	defer c.configLock.Unlock()
+	c.logger.Infof("Shutting down %s", PrettyName)
+	c.stopPublishing()
+	c.logger.Infof("%s Shutdown complete", PrettyName)
+	return nil
+}
+
+func (c *Config) Ready(ctx context.Context, extra map[string]interface{}) (bool, error) {
+	return false, nil
+}
@@ -0,0 +1,48 @@
+package onewiremonitor
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/linux"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+// w1DevicesRoot is where the 1-Wire bus is checked for, tests point it elsewhere
+var w1DevicesRoot = linux.W1DevicesRoot
+
+type ComponentConfig struct {
+	Aliases     map[string]string `json:"aliases,omitempty"`       // Maps probe IDs, e.g. 28-0316a2794bff, to reading keys
+	SleepTimeMs int               `json:"sleep_time_ms,omitempty"` // Time between reads, defaults to 10 seconds
+	Simulate    bool              `json:"simulate,omitempty"`      // Skips checking the bus exists, see utils.SimulateAttribute
+	// Reads faster while the temperatures change, see utils.AdaptiveInterval
+	Adaptive *utils.AdaptiveInterval `json:"adaptive,omitempty"`
+}
+
+func (conf *ComponentConfig) Validate(path string) ([]string, error) {
+	if conf.SleepTimeMs < 0 {
+		return nil, errors.New("sleep_time_ms must not be negative")
+	}
+	if conf.Adaptive != nil {
+		if err := conf.Adaptive.Validate(); err != nil {
+			return nil, err
+		}
+	}
+	aliases := make(map[string]bool)
+	for id, alias := range conf.Aliases {
+		if alias == "" {
+			return nil, fmt.Errorf("alias for %s must not be empty", id)
+		}
+		if aliases[alias] {
+			return nil, fmt.Errorf("duplicate alias: %s", alias)
+		}
+		aliases[alias] = true
+	}
+	if conf.Simulate {
+		return nil, nil
+	}
+	if err := linux.CheckW1Bus(w1DevicesRoot); err != nil {
+		return nil, err
+	}
+	return nil, nil
+}
@@ -0,0 +1,32 @@
+package onewiremonitor
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidate(t *testing.T) {
+	oldRoot := w1DevicesRoot
+	defer func() { w1DevicesRoot = oldRoot }()
+	w1DevicesRoot = t.TempDir()
+
+	conf := &ComponentConfig{Aliases: map[string]string{"28-0316a2794bff": "enclosure", "28-0417c1b2d4ff": "battery"}}
+	_, err := conf.Validate("")
+	assert.NoError(t, err)
+
+	invalid := []*ComponentConfig{
+		{SleepTimeMs: -1},
+		{Aliases: map[string]string{"28-0316a2794bff": ""}},
+		{Aliases: map[string]string{"28-0316a2794bff": "battery", "28-0417c1b2d4ff": "battery"}},
+	}
+	for _, conf := range invalid {
+		_, err := conf.Validate("")
+		assert.Error(t, err)
+	}
+
+	w1DevicesRoot = filepath.Join(w1DevicesRoot, "missing")
+	_, err = conf.Validate("")
+	assert.ErrorContains(t, err, "dtoverlay=w1-gpio")
+}
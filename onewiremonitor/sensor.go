@@ -0,0 +1,193 @@
+package onewiremonitor
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	"go.viam.com/rdk/components/sensor"
+	"go.viam.com/rdk/logging"
+	"go.viam.com/rdk/resource"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/linux"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+var (
+	Model       = resource.NewModel(utils.Namespace, "hwmonitor", "onewire_monitor")
+	API         = sensor.API
+	PrettyName  = "1-Wire Temperature Monitor"
+	Description = "A sensor that reports the temperatures of DS18B20 and other 1-Wire thermometers"
+	Version     = utils.Version
+)
+
+const (
+	defaultSleepTime = 10 * time.Second
+	// A CRC failure is usually a single corrupted transfer on a long cable, so it is retried before giving up
+	maxAttempts = 3
+)
+
+type Config struct {
+	resource.Named
+	configLock      sync.Mutex
+	readingsLock    sync.RWMutex
+	logger          logging.Logger
+	aliases         map[string]string
+	task            *utils.Task
+	currentReadings map[string]interface{}
+	crcErrors       map[string]int
+	freshness       utils.Freshness
+	adaptive        *utils.AdaptivePoller
+}
+
+func init() {
+	resource.RegisterComponent(
+		API,
+		Model,
+		resource.Registration[sensor.Sensor, *ComponentConfig]{Constructor: utils.FilterReadings(NewSensor)})
+	utils.RegisterSimulator(Model, simulate)
+}
+
+func NewSensor(ctx context.Context, deps resource.Dependencies, conf resource.Config, logger logging.Logger) (sensor.Sensor, error) {
+	logger.Infof("Starting %s %s", PrettyName, Version)
+	b := Config{
+		Named:           conf.ResourceName().AsNamed(),
+		logger:          logger,
+		currentReadings: make(map[string]interface{}),
+		crcErrors:       make(map[string]int),
+	}
+
+	if err := b.Reconfigure(ctx, deps, conf); err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
+
+func (c *Config) Reconfigure(ctx context.Context, _ resource.Dependencies, rawConf resource.Config) error {
+	c.configLock.Lock()
+	defer c.configLock.Unlock()
+	c.logger.Debugf("Reconfiguring %s", PrettyName)
+
+	conf, err := resource.NativeConfig[*ComponentConfig](rawConf)
+	if err != nil {
+		return err
+	}
+
+	// In case the component has changed name
+	c.Named = rawConf.ResourceName().AsNamed()
+	sleepTime := defaultSleepTime
+	if conf.SleepTimeMs > 0 {
+		sleepTime = time.Duration(conf.SleepTimeMs) * time.Millisecond
+	}
+	var adaptive *utils.AdaptivePoller
+	if conf.Adaptive != nil {
+		adaptive = utils.NewAdaptivePoller(*conf.Adaptive)
+		sleepTime = conf.Adaptive.Clamp(sleepTime)
+	}
+	c.readingsLock.Lock()
+	c.aliases = conf.Aliases
+	c.adaptive = adaptive
+	c.readingsLock.Unlock()
+	if c.task != nil {
+		// The task keeps running so the readings and CRC error counts don't have a gap
+		c.task.SetInterval(sleepTime)
+	} else {
+		c.task = utils.Schedule(sleepTime, c.poll)
+	}
+	return nil
+}
+
+// Readings returns the result of the last poll, each probe takes up to 750ms to convert so they aren't read here.
+func (c *Config) Readings(ctx context.Context, extra map[string]interface{}) (map[string]interface{}, error) {
+	c.readingsLock.RLock()
+	defer c.readingsLock.RUnlock()
+	return c.freshness.Stamp(c.currentReadings, c.task.Interval(), time.Now()), nil
+}
+
+// poll reads every probe, the scheduler calls it every interval
+func (c *Config) poll(ctx context.Context) {
+	thermometers, listErr := linux.ListW1Thermometers(linux.W1DevicesRoot)
+	if listErr != nil {
+		c.logger.Warnf("Failed to list 1-Wire thermometers: %v", listErr)
+	}
+	readings := map[string]interface{}{
+		"probe_count": len(thermometers),
+	}
+	failed := make([]string, 0)
+	c.readingsLock.RLock()
+	aliases := c.aliases
+	c.readingsLock.RUnlock()
+	for _, thermometer := range thermometers {
+		key := thermometer.ID
+		if alias, ok := aliases[key]; ok {
+			key = alias
+		}
+		temperature, err := c.readTemperature(ctx, key, thermometer.ID)
+		if ctx.Err() != nil {
+			return
+		}
+		c.readingsLock.RLock()
+		readings[key+"_crc_errors"] = c.crcErrors[key]
+		c.readingsLock.RUnlock()
+		if err != nil {
+			c.logger.Warnf("Failed to read 1-Wire thermometer %s: %v", key, err)
+			failed = append(failed, key)
+			continue
+		}
+		readings[key] = temperature
+	}
+	readings["failed_count"] = len(failed)
+	readings["failed"] = strings.Join(failed, ",")
+
+	c.readingsLock.Lock()
+	c.currentReadings = readings
+	if listErr != nil {
+		c.freshness.Failed(listErr)
+	} else {
+		c.freshness.Succeeded(time.Now())
+	}
+	adaptive := c.adaptive
+	c.readingsLock.Unlock()
+	adaptive.Adjust(c.task, readings)
+}
+
+// readTemperature reads a probe, retrying CRC failures and counting them so flaky wiring shows up in the readings.
+func (c *Config) readTemperature(ctx context.Context, key, id string) (float64, error) {
+	var err error
+	for attempt := 0; attempt < maxAttempts; attempt++ {
+		var temperature float64
+		temperature, err = linux.ReadW1Temperature(ctx, linux.W1DevicesRoot, id)
+		if err == nil {
+			return temperature, nil
+		}
+		if !errors.Is(err, linux.ErrW1CRC) && !errors.Is(err, linux.ErrW1PowerOnReset) {
+			return 0, err
+		}
+		if errors.Is(err, linux.ErrW1CRC) {
+			c.readingsLock.Lock()
+			c.crcErrors[key]++
+			c.readingsLock.Unlock()
+		}
+		if ctx.Err() != nil {
+			return 0, ctx.Err()
+		}
+	}
+	return 0, err
+}
+
+func (c *Config) Close(ctx context.Context) error {
+	c.configLock.Lock()
+	defer c.configLock.Unlock()
+	c.logger.Infof("Shutting down %s", PrettyName)
+	if c.task != nil {
+		c.task.Stop()
+	}
+	c.logger.Infof("%s Shutdown complete", PrettyName)
+	return nil
+}
+
+func (c *Config) Ready(ctx context.Context, extra map[string]interface{}) (bool, error) {
+	return false, nil
+}
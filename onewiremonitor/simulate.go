@@ -0,0 +1,32 @@
+package onewiremonitor
+
+import (
+	"sort"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+// simulatedProbe is the probe on the simulated bus when no probes are aliased.
+const simulatedProbe = "28-0316a2794bff"
+
+// simulate returns a DS18B20 for each aliased probe, or a single one, in a room that warms slightly with the board.
+func simulate(sim *utils.Simulation) map[string]interface{} {
+	keys := []string{simulatedProbe}
+	if conf, ok := sim.Config().(*ComponentConfig); ok && len(conf.Aliases) > 0 {
+		keys = make([]string, 0, len(conf.Aliases))
+		for _, alias := range conf.Aliases {
+			keys = append(keys, alias)
+		}
+		sort.Strings(keys)
+	}
+	ret := map[string]interface{}{
+		"probe_count":  len(keys),
+		"failed_count": 0,
+		"failed":       "",
+	}
+	for _, key := range keys {
+		ret[key+"_crc_errors"] = 0
+		ret[key] = sim.Value(22.5, 23.5, 25, 0.2)
+	}
+	return sim.Stamp(ret)
+}
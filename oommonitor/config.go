@@ -0,0 +1,22 @@
+package oommonitor
+
+import (
+	"context"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/linux"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+type ComponentConfig struct {
+	SleepTimeMs int      `json:"sleep_time_ms"`
+	Cgroups     []string `json:"cgroups"`
+}
+
+func (conf *ComponentConfig) Validate(path string) ([]string, error) {
+	return nil, nil
+}
+
+// Permissions implements utils.PermissionReporter, the OOM kills are read from /dev/kmsg.
+func (conf *ComponentConfig) Permissions(ctx context.Context) utils.Permissions {
+	return linux.KmsgPermissions(ctx)
+}
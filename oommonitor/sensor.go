@@ -0,0 +1,226 @@
+package oommonitor
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/shirou/gopsutil/v4/host"
+	"go.viam.com/rdk/components/sensor"
+	"go.viam.com/rdk/logging"
+	"go.viam.com/rdk/resource"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/linux"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+var (
+	Model       = resource.NewModel(utils.Namespace, "hwmonitor", "oom_monitor")
+	API         = sensor.API
+	PrettyName  = "SBC OOM Killer Monitor"
+	Description = "A sensor that detects OOM killer events and reports the last victim"
+	Version     = utils.Version
+)
+
+type Config struct {
+	resource.Named
+	configLock   sync.Mutex
+	readingsLock sync.RWMutex
+	logger       logging.Logger
+	sleepTime    time.Duration
+	cgroups      []string
+	task         *utils.Task
+	kmsg         *linux.KmsgReader
+	bootTime     time.Time
+	// counters count the OOM kills across reboots
+	counters     *utils.PersistentCounters
+	killsSeen    int
+	lastVictim   *linux.OOMKill
+	lastKillTime time.Time
+	lastSequence uint64
+	// freshness is that of the kills read from the kernel log, the counters are read when they're asked for
+	freshness utils.Freshness
+}
+
+func init() {
+	resource.RegisterComponent(
+		API,
+		Model,
+		resource.Registration[sensor.Sensor, *ComponentConfig]{Constructor: utils.FilterReadings(NewSensor)})
+	utils.RegisterSimulator(Model, simulate)
+}
+
+func NewSensor(ctx context.Context, deps resource.Dependencies, conf resource.Config, logger logging.Logger) (sensor.Sensor, error) {
+	logger.Infof("Starting %s %s", PrettyName, Version)
+	b := Config{
+		Named:  conf.ResourceName().AsNamed(),
+		logger: logger,
+	}
+
+	if err := b.Reconfigure(ctx, deps, conf); err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
+
+func (c *Config) Reconfigure(ctx context.Context, _ resource.Dependencies, rawConf resource.Config) error {
+	c.configLock.Lock()
+	defer c.configLock.Unlock()
+	c.logger.Debugf("Reconfiguring %s", PrettyName)
+
+	c.stopPolling()
+
+	conf, err := resource.NativeConfig[*ComponentConfig](rawConf)
+	if err != nil {
+		return err
+	}
+
+	// In case the component has changed name
+	c.Named = rawConf.ResourceName().AsNamed()
+	if conf.SleepTimeMs <= 0 {
+		// Default to 1000ms if no sleep time is provided
+		c.logger.Warnf("Invalid sleep time %d, defaulting to 1000ms", conf.SleepTimeMs)
+		conf.SleepTimeMs = 1000 // Default to 1 second
+	}
+	c.sleepTime = time.Duration(conf.SleepTimeMs * int(time.Millisecond))
+	c.cgroups = conf.Cgroups
+
+	bootTime, err := host.BootTimeWithContext(ctx)
+	if err != nil {
+		return err
+	}
+	c.bootTime = time.Unix(int64(bootTime), 0)
+
+	bootID, err := linux.ReadBootID(ctx)
+	if err != nil {
+		return err
+	}
+	c.readingsLock.Lock()
+	c.counters = utils.ReloadCounters(c.counters, c.Name().Name, bootID, c.logger)
+	c.readingsLock.Unlock()
+
+	// Read the whole ring buffer so kills that happened before the module started are reported
+	kmsg, err := linux.OpenKmsg(false)
+	if err != nil {
+		c.logger.Warnf("Unable to open /dev/kmsg, only OOM kill counters will be reported: %v", err)
+		c.readingsLock.Lock()
+		c.freshness.Failed(fmt.Errorf("unable to open /dev/kmsg: %w", err))
+		c.readingsLock.Unlock()
+	} else {
+		c.kmsg = kmsg
+		counters := c.counters
+		c.task = utils.Schedule(c.sleepTime, func(ctx context.Context) {
+			c.readKmsg(kmsg)
+			// Counted while polling as well, so kills after the last reading of a boot aren't missing from the total
+			if count, err := linux.ReadOOMKillCount(ctx); err == nil {
+				counters.AddSinceBoot("oom_kill", int64(count))
+			}
+		})
+	}
+
+	return nil
+}
+
+func (c *Config) Readings(ctx context.Context, extra map[string]interface{}) (map[string]interface{}, error) {
+	c.configLock.Lock()
+	cgroups := c.cgroups
+	c.configLock.Unlock()
+
+	c.readingsLock.RLock()
+	counters := c.counters
+	c.readingsLock.RUnlock()
+
+	ret := make(map[string]interface{})
+	count, err := linux.ReadOOMKillCount(ctx)
+	if err != nil {
+		c.logger.Debugf("Failed to read oom_kill from /proc/vmstat: %v", err)
+	} else {
+		ret["oom_kill_count"] = count
+		// The kernel's count starts over at every boot, the total carries on
+		ret["oom_kill_total"] = counters.AddSinceBoot("oom_kill", int64(count))
+		ret["counters_since"] = counters.Since().Format(time.RFC3339)
+	}
+
+	for _, cgroup := range cgroups {
+		events, err := linux.ReadCgroupMemoryEvents(ctx, cgroup)
+		if err != nil {
+			c.logger.Warnf("Failed to read memory.events for %s: %v", cgroup, err)
+			continue
+		}
+		name := linux.CgroupName(cgroup)
+		ret[name+"_oom_kill"] = events["oom_kill"]
+		ret[name+"_oom"] = events["oom"]
+	}
+
+	c.readingsLock.RLock()
+	defer c.readingsLock.RUnlock()
+	ret["oom_kills_observed"] = c.killsSeen
+	if c.lastVictim != nil {
+		ret["last_victim_name"] = c.lastVictim.Name
+		ret["last_victim_pid"] = c.lastVictim.PID
+		ret["last_kill_time"] = c.lastKillTime.Format(time.RFC3339)
+	}
+	return c.freshness.Stamp(ret, c.sleepTime, time.Now()), nil
+}
+
+// stopPolling stops reading the kernel log, configLock must be held.
+func (c *Config) stopPolling() {
+	if c.task == nil {
+		return
+	}
+	c.logger.Debug("Stopping polling")
+	c.task.Stop()
+	c.kmsg.Close()
+	c.task, c.kmsg = nil, nil
+	c.logger.Debugf("Polling stopped")
+}
+
+// readKmsg processes the kernel log records written since the previous poll.
+func (c *Config) readKmsg(kmsg *linux.KmsgReader) {
+	records, err := kmsg.ReadAvailable()
+	if err != nil {
+		c.logger.Warnf("Failed to read kernel log: %v", err)
+	}
+	c.readingsLock.Lock()
+	if err != nil {
+		c.freshness.Failed(err)
+	} else {
+		c.freshness.Succeeded(time.Now())
+	}
+	c.readingsLock.Unlock()
+	for _, record := range records {
+		// Reconfigure re-reads the ring buffer, skip the records we've already processed
+		if record.Sequence <= c.lastSequence && c.lastSequence != 0 {
+			continue
+		}
+		c.lastSequence = record.Sequence
+		victim, ok := linux.ParseOOMKill(record.Message)
+		if !ok {
+			continue
+		}
+		killTime := record.Time(c.bootTime)
+		c.logger.Warnf("OOM killer killed %s (pid %d) at %v", victim.Name, victim.PID, killTime)
+		c.readingsLock.Lock()
+		c.killsSeen++
+		c.lastVictim = victim
+		c.lastKillTime = killTime
+		c.readingsLock.Unlock()
+	}
+}
+
+func (c *Config) Close(ctx context.Context) error {
+	c.configLock.Lock()
+	defer c.configLock.Unlock()
+	c.logger.Infof("Shutting down %s", PrettyName)
+	c.stopPolling()
+	if err := c.counters.Flush(); err != nil {
+		c.logger.Warnf("Failed to save the counters to %s: %v", c.counters.Path(), err)
+	}
+	c.logger.Infof("%s Shutdown complete", PrettyName)
+	return nil
+}
+
+func (c *Config) Ready(ctx context.Context, extra map[string]interface{}) (bool, error) {
+	return false, nil
+}
@@ -0,0 +1,26 @@
+package oommonitor
+
+import (
+	"strings"
+	"time"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+// simulate returns a board where nothing has run out of memory.
+func simulate(sim *utils.Simulation) map[string]interface{} {
+	ret := map[string]interface{}{
+		"oom_kill_count":     uint64(0),
+		"oom_kill_total":     int64(0),
+		"counters_since":     sim.Started().Format(time.RFC3339),
+		"oom_kills_observed": 0,
+	}
+	if conf, ok := sim.Config().(*ComponentConfig); ok {
+		for _, cgroup := range conf.Cgroups {
+			name := strings.Trim(strings.ReplaceAll(cgroup, "/", "_"), "_")
+			ret[name+"_oom_kill"] = uint64(0)
+			ret[name+"_oom"] = uint64(0)
+		}
+	}
+	return ret
+}
@@ -0,0 +1,127 @@
+package orinsummary
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"go.viam.com/rdk/components/sensor"
+	"go.viam.com/rdk/logging"
+	"go.viam.com/rdk/resource"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/linux"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/linux/jetson"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+var (
+	Model       = resource.NewModel(utils.Namespace, "hwmonitor", "orin_summary")
+	API         = sensor.API
+	PrettyName  = "Jetson Orin Summary Sensor"
+	Description = "A sensor that reports the temperatures, power rails, power mode and clock state of a Jetson Orin"
+	Version     = utils.Version
+)
+
+type Config struct {
+	resource.Named
+	mu         sync.RWMutex
+	logger     logging.Logger
+	cancelCtx  context.Context
+	cancelFunc func()
+}
+
+func init() {
+	resource.RegisterComponent(
+		API,
+		Model,
+		resource.Registration[sensor.Sensor, *ComponentConfig]{Constructor: utils.FilterReadings(NewSensor)})
+}
+
+func NewSensor(ctx context.Context, deps resource.Dependencies, conf resource.Config, logger logging.Logger) (sensor.Sensor, error) {
+	logger.Infof("Starting %s %s", PrettyName, Version)
+	if !jetson.IsOrin(linux.Board()) {
+		return nil, jetson.ErrNotOrin
+	}
+	cancelCtx, cancelFunc := context.WithCancel(context.Background())
+
+	b := Config{
+		Named:      conf.ResourceName().AsNamed(),
+		logger:     logger,
+		cancelCtx:  cancelCtx,
+		cancelFunc: cancelFunc,
+		mu:         sync.RWMutex{},
+	}
+
+	if err := b.Reconfigure(ctx, deps, conf); err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
+
+func (c *Config) Reconfigure(ctx context.Context, _ resource.Dependencies, conf resource.Config) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.logger.Debugf("Reconfiguring %s", PrettyName)
+
+	// In case the module has changed name
+	c.Named = conf.ResourceName().AsNamed()
+
+	return nil
+}
+
+func (c *Config) Readings(ctx context.Context, extra map[string]interface{}) (map[string]interface{}, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	summary, err := jetson.GetOrinSummary(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ret := map[string]interface{}{
+		"model":                 linux.Board().Model,
+		"cpu_frequency":         summary.CPUFrequency,
+		"gpu_frequency":         summary.GPUFrequency,
+		"jetson_clocks_enabled": summary.ClocksLocked,
+	}
+	for name, temp := range summary.Temperatures {
+		ret["temperature_"+name] = temp
+	}
+	for name, rail := range summary.Rails {
+		prefix := strings.ToLower(name)
+		ret[prefix+"_voltage"] = rail.Voltage
+		ret[prefix+"_current"] = rail.Current
+		ret[prefix+"_power"] = rail.Power
+	}
+	if rail, ok := summary.Rails["VDD_IN"]; ok {
+		ret["total_power"] = rail.Power
+	}
+
+	// nvpmodel isn't installed in every container image, the rest of the summary is still useful without it
+	if mode, err := jetson.GetPowerMode(ctx); err == nil {
+		ret["power_mode_id"] = mode.ID
+		ret["power_mode_name"] = mode.Name
+	} else {
+		c.logger.Debugf("Failed to read power mode: %v", err)
+	}
+	return ret, nil
+}
+
+// Capabilities reports whether the power mode can be read, it needs nvpmodel.
+func (c *Config) Capabilities(ctx context.Context) utils.Capabilities {
+	caps := utils.Capabilities{Backend: "jetson", Unavailable: make(map[string]string)}
+	if _, err := jetson.GetPowerMode(ctx); err != nil {
+		caps.Unavailable["power_mode_id"] = fmt.Sprintf("failed to read the power mode: %v", err)
+		caps.Unavailable["power_mode_name"] = caps.Unavailable["power_mode_id"]
+	}
+	return caps
+}
+
+func (c *Config) Close(ctx context.Context) error {
+	c.logger.Infof("Shutting down %s", PrettyName)
+	return nil
+}
+
+func (c *Config) Ready(ctx context.Context, extra map[string]interface{}) (bool, error) {
+	return false, nil
+}
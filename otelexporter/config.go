@@ -0,0 +1,51 @@
+package otelexporter
+
+import (
+	"errors"
+	"fmt"
+)
+
+const (
+	protocolGRPC = "grpc"
+	protocolHTTP = "http"
+)
+
+type ComponentConfig struct {
+	Endpoint           string            `json:"endpoint"`            // e.g. collector:4317 for gRPC, http://collector:4318 for HTTP
+	Protocol           string            `json:"protocol,omitempty"`  // grpc or http, defaults to grpc
+	Insecure           bool              `json:"insecure,omitempty"`  // Connect to a gRPC endpoint without TLS
+	Headers            map[string]string `json:"headers,omitempty"`   // Sent with every export, e.g. for authentication
+	CAFile             string            `json:"ca_file,omitempty"`   // Verifies the collector with this CA rather than the system roots
+	CertFile           string            `json:"cert_file,omitempty"` // Client certificate for collectors that require one
+	KeyFile            string            `json:"key_file,omitempty"`
+	InsecureSkipVerify bool              `json:"insecure_skip_verify,omitempty"` // Don't verify the collector's certificate
+	Sensors            []string          `json:"sensors"`                        // The sensors whose readings are exported
+	IdentitySensor     string            `json:"identity_sensor,omitempty"`      // A board_identity sensor to derive resource attributes from
+	ResourceAttributes map[string]string `json:"resource_attributes,omitempty"`  // Added to the resource, e.g. deployment.environment
+	Counters           []string          `json:"counters,omitempty"`             // Reading keys exported as monotonic counters rather than gauges
+	MetricPrefix       string            `json:"metric_prefix,omitempty"`        // Defaults to hwmonitor
+	IntervalSec        float64           `json:"interval_sec,omitempty"`         // Defaults to 60
+}
+
+func (conf *ComponentConfig) Validate(path string) ([]string, error) {
+	if conf.Endpoint == "" {
+		return nil, errors.New("endpoint is required")
+	}
+	if conf.Protocol != "" && conf.Protocol != protocolGRPC && conf.Protocol != protocolHTTP {
+		return nil, fmt.Errorf("protocol must be %s or %s, got %s", protocolGRPC, protocolHTTP, conf.Protocol)
+	}
+	if len(conf.Sensors) == 0 {
+		return nil, errors.New("at least one sensor is required")
+	}
+	if (conf.CertFile == "") != (conf.KeyFile == "") {
+		return nil, errors.New("cert_file and key_file must be set together")
+	}
+	if conf.IntervalSec < 0 {
+		return nil, errors.New("interval_sec must not be negative")
+	}
+	deps := append([]string{}, conf.Sensors...)
+	if conf.IdentitySensor != "" {
+		deps = append(deps, conf.IdentitySensor)
+	}
+	return deps, nil
+}
@@ -0,0 +1,26 @@
+package otelexporter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidate(t *testing.T) {
+	conf := &ComponentConfig{Endpoint: "collector:4317", Sensors: []string{"cpu", "temperatures"}, IdentitySensor: "identity"}
+	deps, err := conf.Validate("")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"cpu", "temperatures", "identity"}, deps)
+	assert.Equal(t, []string{"cpu", "temperatures"}, conf.Sensors)
+
+	for _, conf := range []*ComponentConfig{
+		{Sensors: []string{"cpu"}},
+		{Endpoint: "collector:4317"},
+		{Endpoint: "collector:4317", Sensors: []string{"cpu"}, Protocol: "udp"},
+		{Endpoint: "collector:4317", Sensors: []string{"cpu"}, KeyFile: "client.key"},
+		{Endpoint: "collector:4317", Sensors: []string{"cpu"}, IntervalSec: -1},
+	} {
+		_, err := conf.Validate("")
+		assert.Error(t, err, conf)
+	}
+}
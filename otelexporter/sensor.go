@@ -0,0 +1,335 @@
+package otelexporter
+
+import (
+	"context"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"go.viam.com/rdk/components/sensor"
+	"go.viam.com/rdk/logging"
+	"go.viam.com/rdk/resource"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/otlp"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+var (
+	Model       = resource.NewModel(utils.Namespace, "hwmonitor", "otel_exporter")
+	API         = sensor.API
+	PrettyName  = "OpenTelemetry Exporter"
+	Description = "A sensor that exports the readings of other sensors as OpenTelemetry metrics over OTLP"
+	Version     = utils.Version
+)
+
+const (
+	defaultInterval     = time.Minute
+	defaultMetricPrefix = "hwmonitor"
+	exportTimeout       = 10 * time.Second
+	scopeName           = "github.com/rinzlerlabs/viam-sbc-hwmonitor"
+)
+
+// identityAttributes maps board_identity readings to resource attributes
+var identityAttributes = map[string]string{
+	"machine_id": "host.id",
+	"serial":     "board.serial",
+	"soc_id":     "board.soc_id",
+}
+
+// exporter is an OTLP endpoint and the metrics sent to it, under the resource attributes that identify the robot. The
+// counters are cumulative from start, a reconfigure builds a new exporter and so starts them over.
+type exporter struct {
+	otlp           otlp.Exporter
+	sources        []utils.Source
+	identitySensor sensor.Sensor
+	resource       map[string]string
+	counters       map[string]bool
+	prefix         string
+	interval       time.Duration
+	// start and identityRead are only touched by the poll
+	start        time.Time
+	identityRead bool
+}
+
+type Config struct {
+	resource.Named
+	configLock    sync.Mutex
+	readingsLock  sync.RWMutex
+	logger        logging.Logger
+	task          *utils.Task
+	exporter      *exporter
+	endpoint      string
+	exportedCount int
+	failedCount   int
+	metricCount   int
+	lastExport    time.Time
+	lastErr       error
+}
+
+func init() {
+	resource.RegisterComponent(
+		API,
+		Model,
+		resource.Registration[sensor.Sensor, *ComponentConfig]{Constructor: utils.FilterReadings(NewSensor)})
+}
+
+func NewSensor(ctx context.Context, deps resource.Dependencies, conf resource.Config, logger logging.Logger) (sensor.Sensor, error) {
+	logger.Infof("Starting %s %s", PrettyName, Version)
+	b := Config{
+		Named:  conf.ResourceName().AsNamed(),
+		logger: logger,
+	}
+
+	if err := b.Reconfigure(ctx, deps, conf); err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
+
+func (c *Config) Reconfigure(ctx context.Context, deps resource.Dependencies, rawConf resource.Config) error {
+	c.configLock.Lock()
+	defer c.configLock.Unlock()
+	c.logger.Debugf("Reconfiguring %s", PrettyName)
+
+	c.stopExporting()
+
+	conf, err := resource.NativeConfig[*ComponentConfig](rawConf)
+	if err != nil {
+		return err
+	}
+
+	// In case the component has changed name
+	c.Named = rawConf.ResourceName().AsNamed()
+
+	e, err := newExporter(conf, deps)
+	if err != nil {
+		return err
+	}
+
+	c.readingsLock.Lock()
+	c.endpoint = conf.Endpoint
+	c.lastErr = nil
+	c.readingsLock.Unlock()
+
+	c.exporter = e
+	c.task = utils.ScheduleAligned(e.interval, utils.ReadingsSource, func(ctx context.Context) {
+		c.export(ctx, e)
+	})
+	return nil
+}
+
+func newExporter(conf *ComponentConfig, deps resource.Dependencies) (*exporter, error) {
+	sources, err := utils.ResolveSources(deps, conf.Sensors)
+	if err != nil {
+		return nil, err
+	}
+	var identitySensor sensor.Sensor
+	if conf.IdentitySensor != "" {
+		var err error
+		if identitySensor, err = sensor.FromDependencies(deps, conf.IdentitySensor); err != nil {
+			return nil, err
+		}
+	}
+
+	host, err := os.Hostname()
+	if err != nil {
+		return nil, err
+	}
+	attributes := map[string]string{
+		"service.name":    "viam-sbc-hwmonitor",
+		"service.version": Version,
+		"host.name":       host,
+	}
+	for key, value := range conf.ResourceAttributes {
+		attributes[key] = value
+	}
+
+	counters := make(map[string]bool)
+	for _, key := range conf.Counters {
+		counters[key] = true
+	}
+	prefix := conf.MetricPrefix
+	if prefix == "" {
+		prefix = defaultMetricPrefix
+	}
+	interval := time.Duration(conf.IntervalSec * float64(time.Second))
+	if interval <= 0 {
+		interval = defaultInterval
+	}
+
+	tlsConfig, err := utils.NewTLSConfig(conf.CAFile, conf.CertFile, conf.KeyFile, conf.InsecureSkipVerify)
+	if err != nil {
+		return nil, err
+	}
+	var otlpExporter otlp.Exporter
+	if conf.Protocol == protocolHTTP {
+		otlpExporter, err = otlp.NewHTTPExporter(conf.Endpoint, conf.Headers, tlsConfig)
+	} else {
+		otlpExporter, err = otlp.NewGRPCExporter(conf.Endpoint, conf.Headers, tlsConfig, conf.Insecure)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &exporter{
+		otlp:           otlpExporter,
+		sources:        sources,
+		identitySensor: identitySensor,
+		resource:       attributes,
+		counters:       counters,
+		prefix:         prefix,
+		interval:       interval,
+		start:          time.Now(),
+		identityRead:   identitySensor == nil,
+	}, nil
+}
+
+// stopExporting stops polling and closes the exporter, configLock must be held.
+func (c *Config) stopExporting() {
+	if c.task == nil {
+		return
+	}
+	c.logger.Debug("Stopping polling")
+	c.task.Stop()
+	c.exporter.otlp.Close()
+	c.task, c.exporter = nil, nil
+	c.logger.Debugf("Polling stopped")
+}
+
+// export exports the readings of every source, the scheduler calls it every interval.
+func (c *Config) export(ctx context.Context, e *exporter) {
+	// The identity doesn't change, so it is only read until it has been read once
+	if !e.identityRead {
+		e.identityRead = c.addIdentity(ctx, e)
+	}
+	metrics, err := c.collect(ctx, e)
+	if err == nil {
+		request := otlp.Request{
+			Resource:     e.resource,
+			ScopeName:    scopeName,
+			ScopeVersion: Version,
+			Start:        e.start,
+			Time:         time.Now(),
+			Metrics:      metrics,
+		}
+		exportCtx, cancel := context.WithTimeout(ctx, exportTimeout)
+		err = e.otlp.Export(exportCtx, request.Marshal())
+		cancel()
+	}
+	c.readingsLock.Lock()
+	if err != nil {
+		if c.lastErr == nil {
+			c.logger.Warnf("Failed to export metrics: %v", err)
+		}
+		c.failedCount++
+		c.lastErr = err
+	} else {
+		if c.lastErr != nil {
+			c.logger.Infof("Exporting metrics to %s again", c.endpoint)
+		}
+		c.exportedCount++
+		c.metricCount = len(metrics)
+		c.lastExport = time.Now()
+		c.lastErr = nil
+	}
+	c.readingsLock.Unlock()
+}
+
+// addIdentity adds the board's identity to the resource attributes, it returns false if the identity couldn't be read.
+func (c *Config) addIdentity(ctx context.Context, e *exporter) bool {
+	ctx, cancel := context.WithTimeout(ctx, exportTimeout)
+	defer cancel()
+	readings, err := e.identitySensor.Readings(ctx, nil)
+	if err != nil {
+		c.logger.Debugf("Failed to read the board identity: %v", err)
+		return false
+	}
+	for key, value := range identityResource(readings) {
+		// Attributes from the config win
+		if _, ok := e.resource[key]; !ok {
+			e.resource[key] = value
+		}
+	}
+	return true
+}
+
+func identityResource(readings map[string]interface{}) map[string]string {
+	ret := make(map[string]string)
+	for key, attribute := range identityAttributes {
+		if value, ok := readings[key].(string); ok && value != "" {
+			ret[attribute] = value
+		}
+	}
+	return ret
+}
+
+// collect reads every sensor, a sensor that fails to read is skipped rather than failing the whole export.
+func (c *Config) collect(ctx context.Context, e *exporter) ([]otlp.Metric, error) {
+	metrics := make([]otlp.Metric, 0)
+	for _, s := range e.sources {
+		readCtx, cancel := context.WithTimeout(ctx, exportTimeout)
+		readings, err := s.Sensor.Readings(readCtx, nil)
+		cancel()
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			c.logger.Debugf("Failed to read %s: %v", s.Name, err)
+			continue
+		}
+		metrics = append(metrics, toMetrics(e.prefix, s.Name, readings, e.counters)...)
+	}
+	return metrics, nil
+}
+
+// toMetrics converts a sensor's numeric readings to metrics named <prefix>.<key>, with the sensor as an attribute.
+func toMetrics(prefix, sensorName string, readings map[string]interface{}, counters map[string]bool) []otlp.Metric {
+	values := utils.NumericReadings(readings)
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	metrics := make([]otlp.Metric, 0, len(keys))
+	for _, key := range keys {
+		metrics = append(metrics, otlp.Metric{
+			Name:       prefix + "." + key,
+			Value:      values[key],
+			Counter:    counters[key],
+			Attributes: map[string]string{"sensor": sensorName},
+		})
+	}
+	return metrics
+}
+
+func (c *Config) Readings(ctx context.Context, extra map[string]interface{}) (map[string]interface{}, error) {
+	c.readingsLock.RLock()
+	defer c.readingsLock.RUnlock()
+	ret := map[string]interface{}{
+		"endpoint":       c.endpoint,
+		"exported_count": c.exportedCount,
+		"failed_count":   c.failedCount,
+		"metric_count":   c.metricCount,
+	}
+	if !c.lastExport.IsZero() {
+		ret["last_export_time"] = c.lastExport.Format(time.RFC3339)
+	}
+	if c.lastErr != nil {
+		ret["last_error"] = c.lastErr.Error()
+	}
+	return ret, nil
+}
+
+func (c *Config) Close(ctx context.Context) error {
+	c.configLock.Lock()
+	defer c.configLock.Unlock()
+	c.logger.Infof("Shutting down %s", PrettyName)
+	c.stopExporting()
+	c.logger.Infof("%s Shutdown complete", PrettyName)
+	return nil
+}
+
+func (c *Config) Ready(ctx context.Context, extra map[string]interface{}) (bool, error) {
+	return false, nil
+}
@@ -0,0 +1,36 @@
+package otelexporter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/otlp"
+)
+
+func TestToMetrics(t *testing.T) {
+	readings := map[string]interface{}{
+		"disconnect_events": 3,
+		"device_count":      2,
+		"present":           true,
+		"model":             "Webcam C270",
+	}
+	sensorAttributes := map[string]string{"sensor": "usb"}
+	assert.Equal(t, []otlp.Metric{
+		{Name: "hwmonitor.device_count", Value: 2, Attributes: sensorAttributes},
+		{Name: "hwmonitor.disconnect_events", Value: 3, Counter: true, Attributes: sensorAttributes},
+		{Name: "hwmonitor.present", Value: 1, Attributes: sensorAttributes},
+	}, toMetrics("hwmonitor", "usb", readings, map[string]bool{"disconnect_events": true}))
+}
+
+func TestIdentityResource(t *testing.T) {
+	assert.Equal(t, map[string]string{
+		"host.id":      "3f1c2a9e8b7d4c5f",
+		"board.serial": "10000000abcdef01",
+	}, identityResource(map[string]interface{}{
+		"serial":     "10000000abcdef01",
+		"soc_id":     "",
+		"machine_id": "3f1c2a9e8b7d4c5f",
+		"eth0_mac":   "d8:3a:dd:00:00:01",
+	}))
+}
@@ -0,0 +1,35 @@
+package pciemonitor
+
+import (
+	"errors"
+	"fmt"
+)
+
+type ComponentConfig struct {
+	Devices []DeviceConfig `json:"devices,omitempty"`
+}
+
+// DeviceConfig names a device of interest, matched by its PCI address or by its vendor and device IDs.
+type DeviceConfig struct {
+	Name     string `json:"name"`                // Used as the reading key
+	Address  string `json:"address,omitempty"`   // e.g. 0000:01:00.0
+	VendorID string `json:"vendor_id,omitempty"` // As sysfs reports it, e.g. 0x1e60 for Hailo
+	DeviceID string `json:"device_id,omitempty"` // Optional, e.g. 0x2864
+}
+
+func (conf *ComponentConfig) Validate(path string) ([]string, error) {
+	names := make(map[string]bool)
+	for _, device := range conf.Devices {
+		if device.Name == "" {
+			return nil, errors.New("every device requires a name")
+		}
+		if names[device.Name] {
+			return nil, fmt.Errorf("duplicate device name: %s", device.Name)
+		}
+		names[device.Name] = true
+		if device.Address == "" && device.VendorID == "" {
+			return nil, fmt.Errorf("device %s requires either address or vendor_id", device.Name)
+		}
+	}
+	return nil, nil
+}
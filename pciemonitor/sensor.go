@@ -0,0 +1,184 @@
+package pciemonitor
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"go.viam.com/rdk/components/sensor"
+	"go.viam.com/rdk/logging"
+	"go.viam.com/rdk/resource"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/linux"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+var (
+	Model       = resource.NewModel(utils.Namespace, "hwmonitor", "pcie_monitor")
+	API         = sensor.API
+	PrettyName  = "SBC PCIe Monitor"
+	Description = "A sensor that reports the PCIe link speed, width and AER error counts of each device"
+	Version     = utils.Version
+)
+
+type Config struct {
+	resource.Named
+	mu         sync.RWMutex
+	logger     logging.Logger
+	cancelCtx  context.Context
+	cancelFunc func()
+	devices    []DeviceConfig
+	// lastAER is the previous AER counters per address, so new errors are logged as they happen
+	lastAER map[string]linux.PCIeAERCounters
+	// missing tracks which devices of interest are logged as missing, so each change is only logged once
+	missing map[string]bool
+}
+
+func init() {
+	resource.RegisterComponent(
+		API,
+		Model,
+		resource.Registration[sensor.Sensor, *ComponentConfig]{Constructor: utils.FilterReadings(NewSensor)})
+	utils.RegisterSimulator(Model, simulate)
+}
+
+func NewSensor(ctx context.Context, deps resource.Dependencies, conf resource.Config, logger logging.Logger) (sensor.Sensor, error) {
+	logger.Infof("Starting %s %s", PrettyName, Version)
+	cancelCtx, cancelFunc := context.WithCancel(context.Background())
+
+	b := Config{
+		Named:      conf.ResourceName().AsNamed(),
+		logger:     logger,
+		cancelCtx:  cancelCtx,
+		cancelFunc: cancelFunc,
+		mu:         sync.RWMutex{},
+	}
+
+	if err := b.Reconfigure(ctx, deps, conf); err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
+
+func (c *Config) Reconfigure(ctx context.Context, _ resource.Dependencies, rawConf resource.Config) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.logger.Debugf("Reconfiguring %s", PrettyName)
+
+	conf, err := resource.NativeConfig[*ComponentConfig](rawConf)
+	if err != nil {
+		return err
+	}
+
+	// In case the module has changed name
+	c.Named = rawConf.ResourceName().AsNamed()
+	c.devices = conf.Devices
+	if c.lastAER == nil {
+		// Kept across reconfigures so errors from before one aren't logged as new
+		c.lastAER = make(map[string]linux.PCIeAERCounters)
+	}
+	c.missing = make(map[string]bool)
+
+	return nil
+}
+
+func (c *Config) Readings(ctx context.Context, extra map[string]interface{}) (map[string]interface{}, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	devices, err := linux.GetPCIeDevices(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ret := make(map[string]interface{})
+	downgraded := 0
+	var correctable, uncorrectable int64
+	for _, device := range devices {
+		addDeviceReadings(ret, deviceName(device.Address), device)
+		if device.Downgraded() {
+			downgraded++
+		}
+		if device.AER != nil {
+			correctable += device.AER.Correctable
+			uncorrectable += device.AER.NonFatal + device.AER.Fatal
+			c.logNewErrors(device)
+		}
+	}
+	ret["device_count"] = len(devices)
+	ret["downgraded_count"] = downgraded
+	ret["aer_correctable_total"] = correctable
+	ret["aer_uncorrectable_total"] = uncorrectable
+
+	for _, deviceConf := range c.devices {
+		device := findDevice(devices, deviceConf)
+		ret[deviceConf.Name+"_present"] = device != nil
+		if device != nil {
+			addDeviceReadings(ret, deviceConf.Name, device)
+		} else if !c.missing[deviceConf.Name] {
+			c.logger.Warnf("PCIe device %s is missing", deviceConf.Name)
+		}
+		c.missing[deviceConf.Name] = device == nil
+	}
+	return ret, nil
+}
+
+func addDeviceReadings(ret map[string]interface{}, name string, device *linux.PCIeDevice) {
+	ret[name+"_vendor_id"] = device.VendorID
+	ret[name+"_device_id"] = device.DeviceID
+	ret[name+"_class"] = device.Class
+	ret[name+"_driver"] = device.Driver
+	ret[name+"_link_speed_gts"] = device.LinkSpeed
+	ret[name+"_link_width"] = device.LinkWidth
+	ret[name+"_max_link_speed_gts"] = device.MaxLinkSpeed
+	ret[name+"_max_link_width"] = device.MaxLinkWidth
+	ret[name+"_downgraded"] = device.Downgraded()
+	if device.AER != nil {
+		ret[name+"_aer_correctable"] = device.AER.Correctable
+		ret[name+"_aer_nonfatal"] = device.AER.NonFatal
+		ret[name+"_aer_fatal"] = device.AER.Fatal
+	}
+}
+
+// logNewErrors logs when a device's AER counters have risen since the last reading.
+func (c *Config) logNewErrors(device *linux.PCIeDevice) {
+	last, seen := c.lastAER[device.Address]
+	c.lastAER[device.Address] = *device.AER
+	if !seen {
+		return
+	}
+	if device.AER.NonFatal > last.NonFatal || device.AER.Fatal > last.Fatal {
+		c.logger.Warnf("PCIe device %s reported %d new uncorrectable errors", device.Address,
+			device.AER.NonFatal-last.NonFatal+device.AER.Fatal-last.Fatal)
+	} else if device.AER.Correctable > last.Correctable {
+		c.logger.Infof("PCIe device %s reported %d new correctable errors", device.Address, device.AER.Correctable-last.Correctable)
+	}
+}
+
+func findDevice(devices []*linux.PCIeDevice, conf DeviceConfig) *linux.PCIeDevice {
+	for _, device := range devices {
+		if conf.Address != "" {
+			if device.Address == conf.Address {
+				return device
+			}
+			continue
+		}
+		if strings.EqualFold(device.VendorID, conf.VendorID) && (conf.DeviceID == "" || strings.EqualFold(device.DeviceID, conf.DeviceID)) {
+			return device
+		}
+	}
+	return nil
+}
+
+// deviceName converts a PCI address into a reading key prefix, e.g. 0000:01:00.0 becomes 0000_01_00_0.
+func deviceName(address string) string {
+	return strings.NewReplacer(":", "_", ".", "_").Replace(address)
+}
+
+func (c *Config) Close(ctx context.Context) error {
+	c.logger.Infof("Shutting down %s", PrettyName)
+	return nil
+}
+
+func (c *Config) Ready(ctx context.Context, extra map[string]interface{}) (bool, error) {
+	return false, nil
+}
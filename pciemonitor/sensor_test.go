@@ -0,0 +1,23 @@
+package pciemonitor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/linux"
+)
+
+func TestFindDevice(t *testing.T) {
+	devices := []*linux.PCIeDevice{
+		{Address: "0000:00:00.0", VendorID: "0x14e4", DeviceID: "0x2712"},
+		{Address: "0000:01:00.0", VendorID: "0x1e60", DeviceID: "0x2864"},
+	}
+	device := findDevice(devices, DeviceConfig{VendorID: "0x1E60"})
+	require.NotNil(t, device)
+	assert.Equal(t, "0000:01:00.0", device.Address)
+	assert.Equal(t, devices[0], findDevice(devices, DeviceConfig{Address: "0000:00:00.0"}))
+	assert.Nil(t, findDevice(devices, DeviceConfig{VendorID: "0x1e60", DeviceID: "0x0001"}))
+	assert.Nil(t, findDevice(devices, DeviceConfig{Address: "0000:02:00.0"}))
+}
@@ -0,0 +1,41 @@
+package pciemonitor
+
+import (
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/linux"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+// simulatedDevices are the root port and the RP1 south bridge of a Raspberry Pi 5, both at their full link speed.
+var simulatedDevices = []*linux.PCIeDevice{
+	{
+		Address: "0001:00:00.0", VendorID: "0x14e4", DeviceID: "0x2712", Class: "0x060400", Driver: "pcieport",
+		LinkSpeed: 5, LinkWidth: 4, MaxLinkSpeed: 5, MaxLinkWidth: 4,
+		AER: &linux.PCIeAERCounters{},
+	},
+	{
+		Address: "0001:01:00.0", VendorID: "0x1de4", DeviceID: "0x0001", Class: "0x020000", Driver: "rp1",
+		LinkSpeed: 5, LinkWidth: 4, MaxLinkSpeed: 5, MaxLinkWidth: 4,
+	},
+}
+
+// simulate returns the simulated devices, and whether the configured devices are among them.
+func simulate(sim *utils.Simulation) map[string]interface{} {
+	ret := make(map[string]interface{})
+	for _, device := range simulatedDevices {
+		addDeviceReadings(ret, deviceName(device.Address), device)
+	}
+	ret["device_count"] = len(simulatedDevices)
+	ret["downgraded_count"] = 0
+	ret["aer_correctable_total"] = int64(0)
+	ret["aer_uncorrectable_total"] = int64(0)
+	if conf, ok := sim.Config().(*ComponentConfig); ok {
+		for _, deviceConf := range conf.Devices {
+			device := findDevice(simulatedDevices, deviceConf)
+			ret[deviceConf.Name+"_present"] = device != nil
+			if device != nil {
+				addDeviceReadings(ret, deviceConf.Name, device)
+			}
+		}
+	}
+	return ret
+}
@@ -1,14 +1,15 @@
 package cpufrequtils
 
 import (
-	"os/exec"
+	"context"
 	"strconv"
 	"strings"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
 )
 
-func SetGovernor(governor string) error {
-	proc := exec.Command("cpufreq-set", "-g", governor)
-	outputBytes, err := proc.Output()
+func SetGovernor(ctx context.Context, governor string) error {
+	outputBytes, err := utils.Output(ctx, "cpufreq-set", "-g", governor)
 	if err != nil {
 		return err
 	}
@@ -17,9 +18,8 @@ func SetGovernor(governor string) error {
 	}
 	return nil
 }
-func SetFrequency(frequency int) error {
-	proc := exec.Command("cpufreq-set", "-f", strconv.Itoa(frequency))
-	outputBytes, err := proc.Output()
+func SetFrequency(ctx context.Context, frequency int) error {
+	outputBytes, err := utils.Output(ctx, "cpufreq-set", "-f", strconv.Itoa(frequency))
 	if err != nil {
 		return err
 	}
@@ -29,9 +29,8 @@ func SetFrequency(frequency int) error {
 	return nil
 }
 
-func SetFrequencyLimits(minimum int, maximum int) error {
-	proc := exec.Command("cpufreq-set", "-l", strconv.Itoa(minimum), strconv.Itoa(maximum))
-	outputBytes, err := proc.Output()
+func SetFrequencyLimits(ctx context.Context, minimum int, maximum int) error {
+	outputBytes, err := utils.Output(ctx, "cpufreq-set", "-l", strconv.Itoa(minimum), strconv.Itoa(maximum))
 	if err != nil {
 		return err
 	}
@@ -41,18 +40,16 @@ func SetFrequencyLimits(minimum int, maximum int) error {
 	return nil
 }
 
-func GetAvailableGovernors() ([]string, error) {
-	proc := exec.Command("cpufreq-info", "--governors")
-	outputBytes, err := proc.Output()
+func GetAvailableGovernors(ctx context.Context) ([]string, error) {
+	outputBytes, err := utils.Output(ctx, "cpufreq-info", "--governors")
 	if err != nil {
 		return nil, err
 	}
 	return strings.Split(string(outputBytes), " "), nil
 }
 
-func GetFrequencyLimits() (MinimumFrequency int, MaximumFrequency int, Err error) {
-	proc := exec.Command("cpufreq-info", "-l")
-	outputBytes, err := proc.Output()
+func GetFrequencyLimits(ctx context.Context) (MinimumFrequency int, MaximumFrequency int, Err error) {
+	outputBytes, err := utils.Output(ctx, "cpufreq-info", "-l")
 	if err != nil {
 		return 0, 0, err
 	}
@@ -68,9 +65,8 @@ func GetFrequencyLimits() (MinimumFrequency int, MaximumFrequency int, Err error
 	return min, max, nil
 }
 
-func GetCurrentPolicy() (CurrentFrequency int, MaximumFrequency int, Governor string, Err error) {
-	proc := exec.Command("cpufreq-info", "-p")
-	outputBytes, err := proc.Output()
+func GetCurrentPolicy(ctx context.Context) (CurrentFrequency int, MaximumFrequency int, Governor string, Err error) {
+	outputBytes, err := utils.Output(ctx, "cpufreq-info", "-p")
 	if err != nil {
 		return 0, 0, "", err
 	}
@@ -86,9 +82,8 @@ func GetCurrentPolicy() (CurrentFrequency int, MaximumFrequency int, Governor st
 	return min, max, strings.TrimSpace(policy[2]), nil
 }
 
-func GetCurrentFrequency() (Frequency int, Err error) {
-	proc := exec.Command("cpufreq-info", "-f")
-	outputBytes, err := proc.Output()
+func GetCurrentFrequency(ctx context.Context) (Frequency int, Err error) {
+	outputBytes, err := utils.Output(ctx, "cpufreq-info", "-f")
 	if err != nil {
 		return 0, err
 	}
@@ -1,30 +1,34 @@
 package cpufrequtils
 
-import "github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+import (
+	"context"
 
-func SetGovernor(governor string) error {
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+func SetGovernor(ctx context.Context, governor string) error {
 	return utils.ErrPlatformNotSupported
 }
-func SetFrequency(frequency int) error {
+func SetFrequency(ctx context.Context, frequency int) error {
 	return utils.ErrPlatformNotSupported
 }
 
-func SetFrequencyLimits(minimum int, maximum int) error {
+func SetFrequencyLimits(ctx context.Context, minimum int, maximum int) error {
 	return utils.ErrPlatformNotSupported
 }
 
-func GetAvailableGovernors() ([]string, error) {
+func GetAvailableGovernors(ctx context.Context) ([]string, error) {
 	return nil, utils.ErrPlatformNotSupported
 }
 
-func GetFrequencyLimits() (MinimumFrequency int, MaximumFrequency int, Err error) {
+func GetFrequencyLimits(ctx context.Context) (MinimumFrequency int, MaximumFrequency int, Err error) {
 	return -1, -1, utils.ErrPlatformNotSupported
 }
 
-func GetCurrentPolicy() (CurrentFrequency int, MaximumFrequency int, Governor string, Err error) {
+func GetCurrentPolicy(ctx context.Context) (CurrentFrequency int, MaximumFrequency int, Governor string, Err error) {
 	return -1, -1, "", utils.ErrPlatformNotSupported
 }
 
-func GetCurrentFrequency() (Frequency int, Err error) {
+func GetCurrentFrequency(ctx context.Context) (Frequency int, Err error) {
 	return -1, utils.ErrPlatformNotSupported
 }
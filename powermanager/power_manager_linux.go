@@ -3,7 +3,7 @@ package powermanager
 import (
 	"errors"
 
-	"github.com/rinzlerlabs/sbcidentify"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/linux"
 	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/linux/jetson"
 	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/linux/raspberrypi"
 	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
@@ -21,12 +21,12 @@ func newPowerManager(config *ComponentConfig, logger logging.Logger) (PowerManag
 		return nil, errors.Join(err, errors.New("error installing cpufrequtils"))
 	}
 
-	if sbcidentify.IsJetson() {
+	if linux.IsFamily(linux.BoardFamilyJetson) {
 		if config.Jetson == nil {
 			return nil, ErrNoConfigForBoard
 		}
 		return jetson.NewPowerManager(config.Jetson, logger)
-	} else if sbcidentify.IsRaspberryPi() {
+	} else if linux.IsFamily(linux.BoardFamilyRaspberryPi) {
 		if config.Raspi == nil {
 			return nil, ErrNoConfigForBoard
 		}
@@ -35,3 +35,14 @@ func newPowerManager(config *ComponentConfig, logger logging.Logger) (PowerManag
 
 	return nil, errors.New("unknown power mode")
 }
+
+// backend is what applies the power mode, Jetson power modes are nvpmodel modes and Raspberry Pi only has the cpufreq
+// policy.
+func backend() string {
+	if linux.IsFamily(linux.BoardFamilyJetson) {
+		return "nvpmodel"
+	} else if linux.IsFamily(linux.BoardFamilyRaspberryPi) {
+		return "cpufreq"
+	}
+	return ""
+}
@@ -10,3 +10,8 @@ func newPowerManager(_ *ComponentConfig, logger logging.Logger) (powerManager Po
 	logger.Errorf("Power manager not implemented on windows")
 	return nil, errors.New("not implemented on windows")
 }
+
+// backend is what applies the power mode, nothing does on windows.
+func backend() string {
+	return ""
+}
@@ -32,7 +32,7 @@ func init() {
 	resource.RegisterComponent(
 		API,
 		Model,
-		resource.Registration[sensor.Sensor, *ComponentConfig]{Constructor: NewSensor})
+		resource.Registration[sensor.Sensor, *ComponentConfig]{Constructor: utils.FilterReadings(NewSensor)})
 }
 
 func NewSensor(ctx context.Context, deps resource.Dependencies, conf resource.Config, logger logging.Logger) (sensor.Sensor, error) {
@@ -70,7 +70,7 @@ func (c *Config) Reconfigure(ctx context.Context, _ resource.Dependencies, conf
 	if err != nil {
 		return err
 	}
-	requiresReboot, err := pm.ApplyPowerMode()
+	requiresReboot, err := pm.ApplyPowerMode(ctx)
 	if err != nil {
 		c.logger.Errorf("Failed to apply power mode: %v", err)
 		return err
@@ -87,24 +87,36 @@ func (c *Config) Readings(ctx context.Context, extra map[string]interface{}) (ma
 	c.mu.RLock()
 	defer c.mu.RUnlock()
 
-	minFreq, maxFreq, err := cpufrequtils.GetFrequencyLimits()
-	if err != nil {
-		return nil, err
+	ret := make(map[string]interface{})
+	errs := make(utils.ReadingErrors)
+	if minFreq, maxFreq, err := cpufrequtils.GetFrequencyLimits(ctx); err == nil {
+		ret["MinimumFrequency"] = minFreq
+		ret["MaximumFrequency"] = maxFreq
+	} else {
+		errs.Add("frequency_limits", err)
 	}
-
-	currentFreq, _, governor, err := cpufrequtils.GetCurrentPolicy()
-	if err != nil {
-		return nil, err
+	if currentFreq, _, governor, err := cpufrequtils.GetCurrentPolicy(ctx); err == nil {
+		ret["CurrentFrequency"] = currentFreq
+		ret["Governor"] = governor
+	} else {
+		errs.Add("policy", err)
 	}
-	ret := map[string]interface{}{"MinimumFrequency": minFreq, "MaximumFrequency": maxFreq, "CurrentFrequency": currentFreq, "Governor": governor}
-	powerMode, err := c.pm.GetCurrentPowerMode()
+	powerMode, err := c.pm.GetCurrentPowerMode(ctx)
 	if err != nil {
-		return nil, err
-	}
-	if powerMode != nil {
+		errs.Add("PowerMode", err)
+	} else if powerMode != nil {
 		ret["PowerMode"] = powerMode
 	}
-	return ret, nil
+	return errs.Readings(ret)
+}
+
+// Capabilities reports what applies the power mode, only Jetson boards report one.
+func (c *Config) Capabilities(ctx context.Context) utils.Capabilities {
+	caps := utils.Capabilities{Backend: backend(), Unavailable: make(map[string]string)}
+	if caps.Backend != "nvpmodel" {
+		caps.Unavailable["PowerMode"] = "only Jetson boards have power modes"
+	}
+	return caps
 }
 
 func (c *Config) Close(ctx context.Context) error {
@@ -0,0 +1,21 @@
+package pressuremonitor
+
+import (
+	"fmt"
+	"slices"
+)
+
+var validResources = []string{"memory", "io", "cpu"}
+
+type ComponentConfig struct {
+	Resources []string `json:"resources"`
+}
+
+func (conf *ComponentConfig) Validate(path string) ([]string, error) {
+	for _, r := range conf.Resources {
+		if !slices.Contains(validResources, r) {
+			return nil, fmt.Errorf("unknown resource %q, valid resources are %v", r, validResources)
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,113 @@
+package pressuremonitor
+
+import (
+	"context"
+	"sync"
+
+	"go.viam.com/rdk/components/sensor"
+	"go.viam.com/rdk/logging"
+	"go.viam.com/rdk/resource"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/linux"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+var (
+	Model       = resource.NewModel(utils.Namespace, "hwmonitor", "pressure_monitor")
+	API         = sensor.API
+	PrettyName  = "SBC Pressure Stall Monitor"
+	Description = "A sensor that reports memory and IO pressure stall information (PSI) of the SBC"
+	Version     = utils.Version
+
+	defaultResources = []string{"memory", "io"}
+)
+
+type Config struct {
+	resource.Named
+	mu         sync.RWMutex
+	logger     logging.Logger
+	cancelCtx  context.Context
+	cancelFunc func()
+	resources  []string
+}
+
+func init() {
+	resource.RegisterComponent(
+		API,
+		Model,
+		resource.Registration[sensor.Sensor, *ComponentConfig]{Constructor: utils.FilterReadings(NewSensor)})
+	utils.RegisterSimulator(Model, simulate)
+}
+
+func NewSensor(ctx context.Context, deps resource.Dependencies, conf resource.Config, logger logging.Logger) (sensor.Sensor, error) {
+	logger.Infof("Starting %s %s", PrettyName, Version)
+	cancelCtx, cancelFunc := context.WithCancel(context.Background())
+
+	b := Config{
+		Named:      conf.ResourceName().AsNamed(),
+		logger:     logger,
+		cancelCtx:  cancelCtx,
+		cancelFunc: cancelFunc,
+		mu:         sync.RWMutex{},
+	}
+
+	if err := b.Reconfigure(ctx, deps, conf); err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
+
+func (c *Config) Reconfigure(ctx context.Context, _ resource.Dependencies, conf resource.Config) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.logger.Debugf("Reconfiguring %s", PrettyName)
+
+	newConf, err := resource.NativeConfig[*ComponentConfig](conf)
+	if err != nil {
+		return err
+	}
+
+	// In case the module has changed name
+	c.Named = conf.ResourceName().AsNamed()
+
+	c.resources = newConf.Resources
+	if len(c.resources) == 0 {
+		c.resources = defaultResources
+	}
+
+	return nil
+}
+
+func (c *Config) Readings(ctx context.Context, extra map[string]interface{}) (map[string]interface{}, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	ret := make(map[string]interface{})
+	for _, r := range c.resources {
+		pressure, err := linux.ReadPressure(ctx, r)
+		if err != nil {
+			return nil, err
+		}
+		addPressureStats(ret, r+"_some", pressure.Some)
+		if pressure.Full != nil {
+			addPressureStats(ret, r+"_full", pressure.Full)
+		}
+	}
+	return ret, nil
+}
+
+func addPressureStats(ret map[string]interface{}, prefix string, stats *linux.PressureStats) {
+	ret[prefix+"_avg10"] = stats.Avg10
+	ret[prefix+"_avg60"] = stats.Avg60
+	ret[prefix+"_avg300"] = stats.Avg300
+	ret[prefix+"_total_stall_us"] = stats.Total
+}
+
+func (c *Config) Close(ctx context.Context) error {
+	c.logger.Infof("Shutting down %s", PrettyName)
+	c.cancelFunc()
+	return nil
+}
+
+func (c *Config) Ready(ctx context.Context, extra map[string]interface{}) (bool, error) {
+	return false, nil
+}
@@ -0,0 +1,25 @@
+package pressuremonitor
+
+import (
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/linux"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+// simulate returns the memory and io pressure, which only stall tasks under load.
+func simulate(sim *utils.Simulation) map[string]interface{} {
+	stats := func(idle, loaded, throttling float64) *linux.PressureStats {
+		return &linux.PressureStats{
+			Avg10:  sim.Value(idle, loaded, throttling, loaded/2),
+			Avg60:  sim.Value(idle, loaded, throttling, loaded/4),
+			Avg300: sim.Level(idle, loaded, throttling),
+			// Stalls accumulate at the average share of a second
+			Total: sim.Counter(0, idle*1e4, loaded*1e4, throttling*1e4),
+		}
+	}
+	ret := make(map[string]interface{})
+	addPressureStats(ret, "memory_some", stats(0, 2.5, 4))
+	addPressureStats(ret, "memory_full", stats(0, 1, 1.5))
+	addPressureStats(ret, "io_some", stats(0.1, 6, 6))
+	addPressureStats(ret, "io_full", stats(0, 3, 3))
+	return ret
+}
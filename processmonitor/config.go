@@ -1,9 +1,14 @@
 package processmonitor
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
 )
 
 type ComponentConfig struct {
@@ -19,6 +24,13 @@ type ComponentConfig struct {
 	IncludeNetStats      bool   `json:"include_net_stats"`
 	SleepTimeMs          int    `json:"sleep_time_ms"`       // Sleep time in milliseconds between process checks
 	DisablePIDCaching    bool   `json:"disable_pid_caching"` // Enable caching of PID to avoid repeated lookups
+
+	MemoryTrendWindowSec      int     `json:"memory_trend_window_sec"`       // Window over which RSS growth is fitted, 0 disables trend detection
+	LeakThresholdBytesPerHour float64 `json:"leak_threshold_bytes_per_hour"` // RSS growth above which a leak is suspected
+	Simulate                  bool    `json:"simulate,omitempty"`            // Skips checking the executable exists, see utils.SimulateAttribute
+	SchedLatency              string  `json:"sched_latency,omitempty"`       // Reports how long the threads wait to run: proc, ebpf or auto, off by default
+
+	Adaptive *utils.AdaptiveInterval `json:"adaptive,omitempty"` // Polls faster while the processes' usage changes, see utils.AdaptiveInterval
 }
 
 func (conf *ComponentConfig) Validate(path string) ([]string, error) {
@@ -28,10 +40,48 @@ func (conf *ComponentConfig) Validate(path string) ([]string, error) {
 	if conf.ExecutablePath != "" && conf.Name != "" {
 		return nil, errors.New("only one of executable_path or name is allowed")
 	}
+	if conf.Name != "" {
+		if strings.TrimSpace(conf.Name) != conf.Name {
+			return nil, fmt.Errorf("name %q has leading or trailing whitespace", conf.Name)
+		}
+		if strings.Contains(conf.Name, "/") {
+			return nil, fmt.Errorf("name %q looks like a path, use executable_path for the executable or its base name as name", conf.Name)
+		}
+		if strings.Contains(conf.Name, " -") {
+			return nil, fmt.Errorf("name %q looks like it includes arguments, name is only the process name, e.g. viam-server", conf.Name)
+		}
+	}
 	if conf.ExecutablePath != "" {
-		if _, err := os.Stat(conf.ExecutablePath); os.IsNotExist(err) {
+		if !filepath.IsAbs(conf.ExecutablePath) {
+			return nil, fmt.Errorf("executable_path must be absolute: %s", conf.ExecutablePath)
+		}
+		if _, err := os.Stat(conf.ExecutablePath); os.IsNotExist(err) && !conf.Simulate {
 			return nil, fmt.Errorf("executable_path does not exist: %s", conf.ExecutablePath)
 		}
 	}
+	if conf.MemoryTrendWindowSec < 0 {
+		return nil, errors.New("memory_trend_window_sec must not be negative")
+	}
+	if conf.LeakThresholdBytesPerHour < 0 {
+		return nil, errors.New("leak_threshold_bytes_per_hour must not be negative")
+	}
+	switch conf.SchedLatency {
+	case "", schedLatencyAuto, schedLatencyProc, schedLatencyEBPF:
+	default:
+		return nil, fmt.Errorf("sched_latency must be %s, %s or %s", schedLatencyProc, schedLatencyEBPF, schedLatencyAuto)
+	}
+	if conf.Adaptive != nil {
+		if err := conf.Adaptive.Validate(); err != nil {
+			return nil, err
+		}
+	}
 	return nil, nil
 }
+
+// Permissions implements utils.PermissionReporter, tracing with bpftrace needs root or CAP_BPF and CAP_PERFMON.
+func (conf *ComponentConfig) Permissions(ctx context.Context) utils.Permissions {
+	if conf.SchedLatency != schedLatencyEBPF {
+		return utils.Permissions{}
+	}
+	return utils.Permissions{Capabilities: []string{"CAP_BPF", "CAP_PERFMON"}}
+}
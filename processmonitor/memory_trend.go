@@ -0,0 +1,73 @@
+package processmonitor
+
+import (
+	"sync"
+	"time"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+// defaultLeakThresholdBytesPerHour is used when memory trend detection is enabled without a threshold.
+const defaultLeakThresholdBytesPerHour = 10 * 1024 * 1024
+
+type rssSample struct {
+	at  time.Time
+	rss uint64
+}
+
+// memoryTrend fits the RSS of each monitored process over a sliding window to detect steady growth.
+type memoryTrend struct {
+	mu                 sync.Mutex
+	window             time.Duration
+	leakBytesPerHour   float64
+	samplesByProcesses map[int32][]rssSample
+}
+
+func newMemoryTrend(window time.Duration, leakBytesPerHour float64) *memoryTrend {
+	return &memoryTrend{
+		window:             window,
+		leakBytesPerHour:   leakBytesPerHour,
+		samplesByProcesses: make(map[int32][]rssSample),
+	}
+}
+
+// Add records an RSS sample for the process and returns the fitted growth in bytes per hour.
+// A leak is only suspected once the samples cover at least half of the window, so a process
+// warming up its caches right after start isn't flagged.
+func (m *memoryTrend) Add(pid int32, at time.Time, rss uint64) (bytesPerHour float64, leakSuspected bool, ok bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	samples := append(m.samplesByProcesses[pid], rssSample{at: at, rss: rss})
+	cutoff := at.Add(-m.window)
+	first := 0
+	for first < len(samples) && samples[first].at.Before(cutoff) {
+		first++
+	}
+	samples = samples[first:]
+	m.samplesByProcesses[pid] = samples
+
+	xs := make([]float64, len(samples))
+	ys := make([]float64, len(samples))
+	for i, s := range samples {
+		xs[i] = s.at.Sub(samples[0].at).Hours()
+		ys[i] = float64(s.rss)
+	}
+	slope, _, ok := utils.LinearFit(xs, ys)
+	if !ok {
+		return 0, false, false
+	}
+	covered := samples[len(samples)-1].at.Sub(samples[0].at)
+	leakSuspected = covered >= m.window/2 && slope > m.leakBytesPerHour
+	return utils.RoundValue(slope, 0), leakSuspected, true
+}
+
+// Retain drops the samples of every process that is not in pids.
+func (m *memoryTrend) Retain(pids map[int32]bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for pid := range m.samplesByProcesses {
+		if !pids[pid] {
+			delete(m.samplesByProcesses, pid)
+		}
+	}
+}
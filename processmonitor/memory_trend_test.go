@@ -0,0 +1,44 @@
+package processmonitor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryTrendDetectsGrowth(t *testing.T) {
+	trend := newMemoryTrend(time.Hour, 1024*1024)
+	start := time.Now()
+	var growth float64
+	var suspected, ok bool
+	// 10MB per hour, sampled every minute for 40 minutes
+	for i := 0; i <= 40; i++ {
+		growth, suspected, ok = trend.Add(1, start.Add(time.Duration(i)*time.Minute), uint64(100*1024*1024+i*10*1024*1024/60))
+		if i < 30 {
+			assert.False(t, suspected, "leak should not be suspected before half the window is covered")
+		}
+	}
+	require.True(t, ok)
+	assert.True(t, suspected)
+	assert.InDelta(t, 10*1024*1024, growth, 1024)
+}
+
+func TestMemoryTrendStableProcess(t *testing.T) {
+	trend := newMemoryTrend(10*time.Minute, 1024*1024)
+	start := time.Now()
+	var growth float64
+	var suspected bool
+	for i := 0; i < 120; i++ {
+		// Noisy but flat
+		growth, suspected, _ = trend.Add(1, start.Add(time.Duration(i)*10*time.Second), uint64(50*1024*1024+(i%3)*4096))
+	}
+	assert.False(t, suspected)
+	assert.Less(t, growth, 1024*1024.0)
+	// Only samples inside the window are kept
+	assert.LessOrEqual(t, len(trend.samplesByProcesses[1]), 61)
+
+	trend.Retain(map[int32]bool{2: true})
+	assert.Empty(t, trend.samplesByProcesses)
+}
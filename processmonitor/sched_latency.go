@@ -0,0 +1,120 @@
+package processmonitor
+
+import (
+	"context"
+	"time"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/linux"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+// The sched_latency backends
+const (
+	schedLatencyAuto = "auto"
+	schedLatencyProc = "proc"
+	schedLatencyEBPF = "ebpf"
+)
+
+// ebpfReadings are the readings only the eBPF backend reports.
+var ebpfReadings = []string{"runq_latency_p50_us", "runq_latency_p99_us", "runq_latency_max_us", "runq_latency_count", "offcpu_ms_per_sec"}
+
+// schedSample is a process's scheduler stats and when they were read.
+type schedSample struct {
+	stat linux.SchedStat
+	at   time.Time
+}
+
+// schedLatency reports how long the processes' threads wait to run. The run queue wait from /proc is a total, so it
+// can't tell one 20ms wait from a thousand 20us ones, the eBPF backend adds histograms of the waits from bpftrace.
+type schedLatency struct {
+	// requested is the configured backend
+	requested string
+	// tracer is nil for the proc backend
+	tracer *linux.SchedTracer
+	prev   map[int32]schedSample
+	// unavailable is why the eBPF backend isn't used when it was auto selected
+	unavailable string
+}
+
+// newSchedLatency returns the scheduling latency of the backend, auto uses eBPF when the kernel has BTF and bpftrace is
+// installed. Asking for ebpf fails when they aren't.
+func newSchedLatency(backend string) (*schedLatency, error) {
+	s := &schedLatency{requested: backend, prev: make(map[int32]schedSample)}
+	if backend == schedLatencyProc {
+		return s, nil
+	}
+	if err := linux.SchedTracingAvailable(); err != nil {
+		if backend == schedLatencyEBPF {
+			return nil, err
+		}
+		s.unavailable = err.Error()
+		return s, nil
+	}
+	s.tracer = linux.DefaultSchedTracer()
+	return s, nil
+}
+
+// add adds the process's run queue wait since the previous poll and, with the eBPF backend, its latency over the last
+// trace window.
+func (s *schedLatency) add(ctx context.Context, ret map[string]interface{}, pid int32, now time.Time) error {
+	if s.tracer != nil {
+		latency, ok, err := s.tracer.Latency(pid)
+		if err != nil {
+			return err
+		}
+		if ok {
+			addTraceReadings(ret, latency)
+		}
+	}
+	stat, err := linux.ReadSchedStat(ctx, pid)
+	if err != nil {
+		return err
+	}
+	prev, ok := s.prev[pid]
+	s.prev[pid] = schedSample{stat: stat, at: now}
+	// The totals go down when threads exit, there's nothing to compare with until the next poll
+	if !ok || stat.Waiting < prev.stat.Waiting || stat.Timeslices < prev.stat.Timeslices {
+		return nil
+	}
+	elapsed := now.Sub(prev.at).Seconds()
+	if elapsed <= 0 {
+		return nil
+	}
+	waiting := stat.Waiting - prev.stat.Waiting
+	ret["runq_wait_ms_per_sec"] = utils.RoundValue(float64(waiting)/float64(time.Millisecond)/elapsed, 3)
+	if slices := stat.Timeslices - prev.stat.Timeslices; slices > 0 {
+		ret["runq_latency_avg_us"] = utils.RoundValue(float64(waiting)/float64(time.Microsecond)/float64(slices), 1)
+	}
+	return nil
+}
+
+// addTraceReadings adds the run queue latency percentiles and off-CPU time of a trace window. The percentiles are the
+// upper bounds of the histogram's power of two buckets.
+func addTraceReadings(ret map[string]interface{}, latency linux.SchedLatency) {
+	ret["runq_latency_count"] = latency.RunQueue.Count()
+	if latency.RunQueue.Count() > 0 {
+		ret["runq_latency_p50_us"] = latency.RunQueue.Percentile(50)
+		ret["runq_latency_p99_us"] = latency.RunQueue.Percentile(99)
+		ret["runq_latency_max_us"] = latency.RunQueue.Max()
+	}
+	if latency.Window > 0 {
+		ret["offcpu_ms_per_sec"] = utils.RoundValue(float64(latency.OffCPU)/float64(time.Millisecond)/latency.Window.Seconds(), 3)
+	}
+}
+
+// retain forgets the processes that weren't seen, so a reused pid starts over.
+func (s *schedLatency) retain(seen map[int32]bool) {
+	for pid := range s.prev {
+		if !seen[pid] {
+			delete(s.prev, pid)
+		}
+	}
+}
+
+// backend returns what the readings come from.
+func (s *schedLatency) backend() string {
+	if s.tracer != nil {
+		return "bpftrace"
+	}
+	return "/proc/<pid>/task/*/schedstat"
+}
@@ -0,0 +1,62 @@
+package processmonitor
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/linux"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+func TestSchedLatencyProc(t *testing.T) {
+	t.Cleanup(func() { utils.SetFS(utils.OSFS{}) })
+	root := t.TempDir()
+	schedstat := filepath.Join(root, "proc/812/task/812/schedstat")
+	require.NoError(t, os.MkdirAll(filepath.Dir(schedstat), 0o755))
+	utils.SetFS(utils.RootFS{Root: root})
+	sched, err := newSchedLatency(schedLatencyProc)
+	require.NoError(t, err)
+	now := time.Now()
+
+	// The first poll has nothing to compare with
+	require.NoError(t, os.WriteFile(schedstat, []byte("5000000 200000 40\n"), 0o644))
+	ret := make(map[string]interface{})
+	require.NoError(t, sched.add(context.Background(), ret, 812, now))
+	assert.Empty(t, ret)
+
+	// 8ms waiting over 2s and 100 timeslices
+	require.NoError(t, os.WriteFile(schedstat, []byte("9000000 8200000 140\n"), 0o644))
+	require.NoError(t, sched.add(context.Background(), ret, 812, now.Add(2*time.Second)))
+	assert.Equal(t, map[string]interface{}{"runq_wait_ms_per_sec": 4.0, "runq_latency_avg_us": 80.0}, ret)
+
+	// A thread exited, the totals went down
+	require.NoError(t, os.WriteFile(schedstat, []byte("100 100 1\n"), 0o644))
+	ret = make(map[string]interface{})
+	require.NoError(t, sched.add(context.Background(), ret, 812, now.Add(4*time.Second)))
+	assert.Empty(t, ret)
+
+	sched.retain(map[int32]bool{})
+	assert.Empty(t, sched.prev)
+}
+
+func TestAddTraceReadings(t *testing.T) {
+	ret := make(map[string]interface{})
+	addTraceReadings(ret, linux.SchedLatency{
+		RunQueue: linux.Histogram{{Low: 2, High: 4, Count: 99}, {Low: 1024, High: 2048, Count: 1}},
+		OffCPU:   5 * time.Second,
+		Window:   10 * time.Second,
+	})
+	assert.Equal(t, map[string]interface{}{
+		"runq_latency_count":  uint64(100),
+		"runq_latency_p50_us": uint64(4),
+		"runq_latency_p99_us": uint64(4),
+		"runq_latency_max_us": uint64(2048),
+		"offcpu_ms_per_sec":   500.0,
+	}, ret)
+}
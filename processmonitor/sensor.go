@@ -10,7 +10,6 @@ import (
 	"go.viam.com/rdk/components/sensor"
 	"go.viam.com/rdk/logging"
 	"go.viam.com/rdk/resource"
-	viamutils "go.viam.com/utils"
 
 	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/sensors"
 	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
@@ -31,9 +30,12 @@ type Config struct {
 	logger            logging.Logger
 	info              *procInfo
 	currentReadings   map[string]interface{}
-	workers           *viamutils.StoppableWorkers
-	sleepTime         time.Duration
+	task              *utils.Task
 	disablePIDCaching bool
+	memoryTrend       *memoryTrend
+	schedLatency      *schedLatency
+	freshness         utils.Freshness
+	adaptive          *utils.AdaptivePoller
 }
 
 type procInfo struct {
@@ -53,7 +55,8 @@ func init() {
 	resource.RegisterComponent(
 		API,
 		Model,
-		resource.Registration[sensor.Sensor, *ComponentConfig]{Constructor: NewSensor})
+		resource.Registration[sensor.Sensor, *ComponentConfig]{Constructor: utils.FilterReadings(NewSensor)})
+	utils.RegisterSimulator(Model, simulate)
 }
 
 func NewSensor(ctx context.Context, deps resource.Dependencies, conf resource.Config, logger logging.Logger) (sensor.Sensor, error) {
@@ -74,11 +77,6 @@ func (c *Config) Reconfigure(ctx context.Context, _ resource.Dependencies, rawCo
 	c.configLock.Lock()
 	defer c.configLock.Unlock()
 	c.logger.Debugf("Reconfiguring %s", PrettyName)
-	if c.workers != nil {
-		c.logger.Debug("Stopping background worker")
-		c.workers.Stop()
-		c.logger.Debugf("Background worker stopped")
-	}
 
 	conf, err := resource.NativeConfig[*ComponentConfig](rawConf)
 	if err != nil {
@@ -89,7 +87,7 @@ func (c *Config) Reconfigure(ctx context.Context, _ resource.Dependencies, rawCo
 		return errors.New("either name or executable path must be set")
 	}
 
-	c.info = &procInfo{
+	info := &procInfo{
 		Name:                 conf.Name,
 		ExecutablePath:       conf.ExecutablePath,
 		IncludeEnv:           conf.IncludeEnv,
@@ -109,9 +107,67 @@ func (c *Config) Reconfigure(ctx context.Context, _ resource.Dependencies, rawCo
 		c.logger.Warnf("Invalid sleep time %d, defaulting to 1000ms", conf.SleepTimeMs)
 		conf.SleepTimeMs = 1000 // Default to 1 second
 	}
+	sleepTime := time.Duration(conf.SleepTimeMs * int(time.Millisecond))
+	var adaptive *utils.AdaptivePoller
+	if conf.Adaptive != nil {
+		adaptive = utils.NewAdaptivePoller(*conf.Adaptive)
+		sleepTime = conf.Adaptive.Clamp(sleepTime)
+	}
+
+	// The task only has to be restarted when the processes it monitors change, otherwise it keeps running with the
+	// new settings so the CPU usage and memory trend don't have a gap
+	restart := c.task == nil || c.info.Name != info.Name || c.info.ExecutablePath != info.ExecutablePath ||
+		c.disablePIDCaching != conf.DisablePIDCaching
+	if restart && c.task != nil {
+		c.logger.Debug("Stopping polling")
+		c.task.Stop()
+		c.logger.Debugf("Polling stopped")
+		c.task = nil
+	}
+
+	var trend *memoryTrend
+	if conf.MemoryTrendWindowSec > 0 {
+		threshold := conf.LeakThresholdBytesPerHour
+		if threshold == 0 {
+			threshold = defaultLeakThresholdBytesPerHour
+		}
+		window := time.Duration(conf.MemoryTrendWindowSec) * time.Second
+		if !restart && c.memoryTrend != nil && c.memoryTrend.window == window && c.memoryTrend.leakBytesPerHour == threshold {
+			trend = c.memoryTrend
+		} else {
+			trend = newMemoryTrend(window, threshold)
+		}
+	}
+
+	var sched *schedLatency
+	if conf.SchedLatency != "" {
+		backend := conf.SchedLatency
+		if !restart && c.schedLatency != nil && c.schedLatency.requested == backend {
+			sched = c.schedLatency
+		} else if sched, err = newSchedLatency(backend); err != nil {
+			return err
+		}
+		if sched.unavailable != "" {
+			c.logger.Infof("Not tracing scheduling latency with eBPF, %s", sched.unavailable)
+		}
+	}
+
+	c.readingsLock.Lock()
+	c.info = info
+	c.memoryTrend = trend
+	c.schedLatency = sched
 	c.disablePIDCaching = conf.DisablePIDCaching
-	c.sleepTime = time.Duration(conf.SleepTimeMs * int(time.Millisecond))
-	c.workers = viamutils.NewBackgroundStoppableWorkers(c.startUpdating)
+	c.adaptive = adaptive
+	c.readingsLock.Unlock()
+	if c.task != nil {
+		c.task.SetInterval(sleepTime)
+	} else {
+		procMon := c.newProcessMonitor(info.Name, info.ExecutablePath, conf.DisablePIDCaching)
+		// The monitors of different processes share the process list, they poll at the same phase to read it once
+		c.task = utils.ScheduleAligned(sleepTime, "processes", func(ctx context.Context) {
+			c.poll(ctx, procMon)
+		})
+	}
 
 	if c.currentReadings == nil {
 		// Initialize the current readings map if it is nil, this shouldn't happen but just in case
@@ -124,65 +180,64 @@ func (c *Config) Reconfigure(ctx context.Context, _ resource.Dependencies, rawCo
 func (c *Config) Readings(ctx context.Context, extra map[string]interface{}) (map[string]interface{}, error) {
 	c.readingsLock.RLock()
 	defer c.readingsLock.RUnlock()
-	return c.currentReadings, nil
+	return c.freshness.Stamp(c.currentReadings, c.task.Interval(), time.Now()), nil
 }
 
-func (c *Config) startUpdating(ctx context.Context) {
-	var procMon *sensors.ProcessMonitor
-	if c.info.Name != "" {
-		c.logger.Debugf("Creating process monitor for name: %s", c.info.Name)
-		procMon = sensors.NewProcessMonitor(c.logger, c.info.Name, c.disablePIDCaching)
-	} else if c.info.ExecutablePath != "" {
-		c.logger.Debugf("Creating process monitor for exe: %s", c.info.ExecutablePath)
-		procMon = sensors.NewProcessMonitor(c.logger, c.info.ExecutablePath, c.disablePIDCaching)
-	} else {
-		// fallback to a default process monitor if no name or executable path is provided
-		c.logger.Errorf("No process monitor could be created, neither name nor executable path provided")
-		return
+func (c *Config) newProcessMonitor(name, exe string, disablePIDCaching bool) *sensors.ProcessMonitor {
+	if name != "" {
+		c.logger.Debugf("Creating process monitor for name: %s", name)
+		return sensors.NewProcessMonitor(c.logger, name, disablePIDCaching)
 	}
-	for {
-		select {
-		case <-ctx.Done():
-			// exit the loop if the context is done
-			c.logger.Infof("Stopping %s update loop: %v", PrettyName, ctx.Err())
-			return
-		case <-time.After(c.sleepTime):
-			readings, err := c.getCPUStats(ctx, procMon)
-			if err != nil {
-				// log the error but continue the loop
-				c.logger.Warnf("Failed to get readings: %v", err)
-				c.updateCurrentReadings(make(map[string]interface{}))
-				continue
-			}
-			// Update the readings in the sensor
-			c.updateCurrentReadings(readings)
-			// log the successful update
-			c.logger.Debugf("Successfully updated readings for %s: %v", PrettyName, readings)
-		}
+	c.logger.Debugf("Creating process monitor for exe: %s", exe)
+	return sensors.NewProcessMonitor(c.logger, exe, disablePIDCaching)
+}
+
+// poll updates the readings of the processes, the scheduler calls it every interval
+func (c *Config) poll(ctx context.Context, procMon *sensors.ProcessMonitor) {
+	readings, err := c.getCPUStats(ctx, procMon)
+	if err != nil {
+		// log the error, the next poll tries again
+		c.logger.Warnf("Failed to get readings: %v", err)
+		c.readingsLock.Lock()
+		c.currentReadings = make(map[string]interface{})
+		c.freshness.Failed(err)
+		c.readingsLock.Unlock()
+		return
 	}
+	// Update the readings in the sensor
+	c.updateCurrentReadings(readings)
+	// log the successful update
+	c.logger.Debugf("Successfully updated readings for %s: %v", PrettyName, readings)
 }
 
 func (c *Config) updateCurrentReadings(newReadings map[string]interface{}) {
 	c.readingsLock.Lock()
-	defer c.readingsLock.Unlock()
 	c.currentReadings = newReadings
+	c.freshness.Succeeded(time.Now())
+	adaptive := c.adaptive
+	c.readingsLock.Unlock()
+	adaptive.Adjust(c.task, newReadings)
 }
 
 func (c *Config) getCPUStats(ctx context.Context, procMon *sensors.ProcessMonitor) (map[string]interface{}, error) {
+	c.readingsLock.RLock()
+	info, trend, sched := c.info, c.memoryTrend, c.schedLatency
+	c.readingsLock.RUnlock()
 	resp := make(map[string]interface{})
 	procs, err := procMon.GetProcessesWithContext(ctx)
 	if err != nil {
 		c.logger.Warnf("Error getting process: %v", err)
 		return nil, err
 	}
-	c.logger.Debugf("Found %d processes for %s", procs.Len(), c.info.Name)
+	c.logger.Debugf("Found %d processes for %s", procs.Len(), info.Name)
+	seen := make(map[int32]bool)
 
 	for _, proc := range procs.AllFromFront() {
 		ret := make(map[string]interface{})
-		if c.info.Name != "" {
+		if info.Name != "" {
 			ret["name"] = proc.Name
 		}
-		if c.info.ExecutablePath != "" {
+		if info.ExecutablePath != "" {
 			exe, err := proc.Exe()
 			if err != nil {
 				c.logger.Warnf("Error getting executable path for process %d: %v", proc.PID, err)
@@ -205,35 +260,35 @@ func (c *Config) getCPUStats(ctx context.Context, procMon *sensors.ProcessMonito
 			c.logger.Debugf("Failed to get number of threads for process %d: %v", proc.PID, err)
 		}
 
-		if c.info.IncludeCwd {
+		if info.IncludeCwd {
 			if cwd, err := proc.CwdWithContext(ctx); err == nil {
 				ret["cwd"] = cwd
 			} else {
 				c.logger.Debugf("Failed to get current working directory for process %d: %v", proc.PID, err)
 			}
 		}
-		if c.info.IncludeCmdline {
+		if info.IncludeCmdline {
 			if cmdline, err := proc.CmdlineWithContext(ctx); err == nil {
 				ret["cmdline"] = cmdline
 			} else {
 				c.logger.Debugf("Failed to get command line for process %d: %v", proc.PID, err)
 			}
 		}
-		if c.info.IncludeOpenFileCount {
+		if info.IncludeOpenFileCount {
 			if openFiles, err := proc.OpenFilesWithContext(ctx); err == nil {
 				ret["open_files"] = len(openFiles)
 			} else {
 				c.logger.Debugf("Failed to get open files for process %d: %v", proc.PID, err)
 			}
 		}
-		if c.info.IncludeEnv {
+		if info.IncludeEnv {
 			if env, err := proc.EnvironWithContext(ctx); err == nil {
 				ret["env"] = env
 			} else {
 				c.logger.Debugf("Failed to get environment variables for process %d: %v", proc.PID, err)
 			}
 		}
-		if c.info.IncludeMemInfo {
+		if info.IncludeMemInfo {
 			if mem, err := proc.MemoryInfoWithContext(ctx); err == nil {
 				ret["mem_rss"] = mem.RSS
 				ret["mem_hwm"] = mem.HWM
@@ -245,8 +300,31 @@ func (c *Config) getCPUStats(ctx context.Context, procMon *sensors.ProcessMonito
 				c.logger.Debugf("Failed to get memory info for process %d: %v", proc.PID, err)
 			}
 		}
+		seen[proc.PID] = true
+		if trend != nil {
+			if mem, err := proc.MemoryInfoWithContext(ctx); err == nil {
+				if growth, leakSuspected, ok := trend.Add(proc.PID, time.Now(), mem.RSS); ok {
+					ret["mem_rss_growth_bytes_per_hour"] = growth
+					ret["mem_leak_suspected"] = leakSuspected
+				}
+			} else {
+				c.logger.Debugf("Failed to get memory info for process %d: %v", proc.PID, err)
+			}
+		}
+		if sched != nil {
+			if err := sched.add(ctx, ret, proc.PID, time.Now()); err != nil {
+				c.logger.Debugf("Failed to get scheduling latency for process %d: %v", proc.PID, err)
+			}
+		}
 		resp[fmt.Sprintf("%d", proc.Pid)] = ret
 	}
+	if trend != nil {
+		// Forget processes that exited so a reused PID starts a fresh trend
+		trend.Retain(seen)
+	}
+	if sched != nil {
+		sched.retain(seen)
+	}
 	return resp, nil
 }
 
@@ -289,9 +367,27 @@ func (c *Config) getCPUStats(ctx context.Context, procMon *sensors.ProcessMonito
 // 	}
 // }
 
+// Capabilities reports what the scheduling latency is read from, and why the eBPF readings aren't available when it
+// fell back to /proc.
+func (c *Config) Capabilities(ctx context.Context) utils.Capabilities {
+	c.readingsLock.RLock()
+	defer c.readingsLock.RUnlock()
+	if c.schedLatency == nil {
+		return utils.Capabilities{}
+	}
+	caps := utils.Capabilities{Backend: c.schedLatency.backend()}
+	if c.schedLatency.unavailable != "" {
+		caps.Unavailable = make(map[string]string, len(ebpfReadings))
+		for _, reading := range ebpfReadings {
+			caps.Unavailable[reading] = c.schedLatency.unavailable
+		}
+	}
+	return caps
+}
+
 func (c *Config) Close(ctx context.Context) error {
 	c.logger.Infof("Shutting down %s", PrettyName)
-	c.workers.Stop()
+	c.task.Stop()
 	c.logger.Infof("%s Shutdown complete", PrettyName)
 	return nil
 }
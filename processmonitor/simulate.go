@@ -0,0 +1,74 @@
+package processmonitor
+
+import (
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/linux"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+// simulatedPID is the process id of the simulated process.
+const simulatedPID = 1234
+
+// simulate returns a single process matching the configured name or executable, its CPU follows the board's load.
+func simulate(sim *utils.Simulation) map[string]interface{} {
+	conf, ok := sim.Config().(*ComponentConfig)
+	if !ok {
+		return sim.Stamp(make(map[string]interface{}))
+	}
+	ret := map[string]interface{}{
+		"pid":     int32(simulatedPID),
+		"cpu":     sim.Percent(1.5, 38, 24, 1),
+		"threads": int32(14),
+	}
+	if conf.Name != "" {
+		ret["name"] = conf.Name
+	}
+	if conf.ExecutablePath != "" {
+		ret["exe"] = conf.ExecutablePath
+	}
+	if conf.IncludeCwd {
+		ret["cwd"] = "/"
+	}
+	if conf.IncludeCmdline {
+		name := conf.Name
+		if name == "" {
+			name = filepath.Base(conf.ExecutablePath)
+		}
+		ret["cmdline"] = name
+	}
+	if conf.IncludeOpenFileCount {
+		ret["open_files"] = 24
+	}
+	if conf.IncludeEnv {
+		ret["env"] = []string{"PATH=/usr/local/bin:/usr/bin:/bin", "HOME=/root"}
+	}
+	if conf.IncludeMemInfo {
+		rss := uint64(sim.Value(6.2e7, 9.4e7, 9.4e7, 1e6))
+		ret["mem_rss"] = rss
+		ret["mem_hwm"] = uint64(9.8e7)
+		ret["mem_data"] = uint64(1.4e8)
+		ret["mem_stack"] = uint64(135_168)
+		ret["mem_swap"] = uint64(0)
+		ret["mem_size"] = uint64(1.9e9)
+	}
+	if conf.MemoryTrendWindowSec > 0 {
+		ret["mem_rss_growth_bytes_per_hour"] = 0.0
+		ret["mem_leak_suspected"] = false
+	}
+	if conf.SchedLatency != "" {
+		ret["runq_wait_ms_per_sec"] = utils.RoundValue(sim.Value(0.4, 12, 30, 0.2), 3)
+		ret["runq_latency_avg_us"] = utils.RoundValue(sim.Value(6, 45, 90, 2), 1)
+	}
+	if conf.SchedLatency == schedLatencyEBPF {
+		var runq linux.Histogram
+		for i, count := range []float64{40, 310, 520, sim.Value(180, 900, 1100, 20), sim.Value(20, 400, 700, 10), sim.Value(2, 120, 260, 2), sim.Value(0, 30, 90, 0)} {
+			low := uint64(1) << i >> 1
+			runq = append(runq, linux.HistogramBucket{Low: low, High: uint64(1) << i, Count: uint64(count)})
+		}
+		addTraceReadings(ret, linux.SchedLatency{RunQueue: runq, OffCPU: 8 * time.Second, Window: linux.SchedTraceWindow})
+	}
+	return sim.Stamp(map[string]interface{}{strconv.Itoa(simulatedPID): ret})
+}
@@ -12,11 +12,15 @@ import (
 	"github.com/rinzlerlabs/sbcidentify/boardtype"
 	"go.viam.com/rdk/components/board"
 	"go.viam.com/rdk/resource"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/linux"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
 )
 
 type fan struct {
 	pin         board.GPIOPin
 	internalFan *os.File
+	tachPath    string
 }
 
 func newFan(deps resource.Dependencies, boardName string, pin string, useInternalFan bool) (*fan, error) {
@@ -24,20 +28,19 @@ func newFan(deps resource.Dependencies, boardName string, pin string, useInterna
 		if !sbcidentify.IsBoardType(boardtype.RaspberryPi5B) {
 			return nil, fmt.Errorf("internal fan is only supported on Raspberry Pi 5")
 		}
-		matches, err := filepath.Glob("/sys/class/hwmon/hwmon*/pwm1")
+		// The fan connector is driven by the pwm-fan driver, other hwmon devices such as rp1_adc don't have a pwm1
+		hwmon, err := linux.FindHwmon(context.Background(), "pwmfan")
 		if err != nil {
-			return nil, err
+			return nil, fmt.Errorf("no pwmfan hwmon device found, is the fan connected: %w", err)
 		}
-		if len(matches) == 0 {
-			return nil, fmt.Errorf("no pwm1 file found in /sys/class/hwmon/hwmon*/")
-		}
-		internalFan, err := os.OpenFile(matches[0], os.O_RDWR, 0644)
+		internalFan, err := os.OpenFile(filepath.Join(hwmon, "pwm1"), os.O_RDWR, 0644)
 		if err != nil {
 			return nil, err
 		}
 		return &fan{
 			internalFan: internalFan,
 			pin:         nil,
+			tachPath:    filepath.Join(hwmon, "fan1_input"),
 		}, nil
 	}
 
@@ -90,6 +93,18 @@ func (f *fan) GetSpeed(ctx context.Context) (float64, error) {
 	return f.pin.PWM(ctx, nil)
 }
 
+// GetRPM returns the speed reported by the fan's tachometer, only the Raspberry Pi 5 fan connector has one.
+func (f *fan) GetRPM(ctx context.Context) (int64, bool) {
+	if f.tachPath == "" {
+		return 0, false
+	}
+	rpm, err := utils.ReadInt64FromFileWithContext(ctx, f.tachPath)
+	if err != nil {
+		return 0, false
+	}
+	return rpm, true
+}
+
 func (f *fan) Close() {
 	if f.internalFan != nil {
 		f.internalFan.Close()
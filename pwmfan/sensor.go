@@ -41,7 +41,7 @@ func init() {
 	resource.RegisterComponent(
 		API,
 		Model,
-		resource.Registration[sensor.Sensor, *CloudConfig]{Constructor: NewSensor})
+		resource.Registration[sensor.Sensor, *CloudConfig]{Constructor: utils.FilterReadings(NewSensor)})
 }
 
 func NewSensor(ctx context.Context, deps resource.Dependencies, conf resource.Config, logger logging.Logger) (sensor.Sensor, error) {
@@ -133,10 +133,19 @@ func (c *Config) Readings(ctx context.Context, extra map[string]interface{}) (ma
 		return nil, err
 	}
 
-	return map[string]interface{}{
+	ret := map[string]interface{}{
 		"temperature":   currentTemp,
 		"fan_speed_pct": fan_speed * 100,
-	}, nil
+	}
+	if rpm, ok := c.fan.GetRPM(ctx); ok {
+		ret["fan_rpm"] = rpm
+	}
+	return ret, nil
+}
+
+// Capabilities reports the backend the temperatures the fan follows are read with.
+func (c *Config) Capabilities(ctx context.Context) utils.Capabilities {
+	return utils.Capabilities{Backend: backend()}
 }
 
 func (c *Config) Close(ctx context.Context) error {
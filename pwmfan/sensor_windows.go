@@ -7,6 +7,10 @@ import (
 	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/windows"
 )
 
+func backend() string {
+	return "windows"
+}
+
 func GetTemperatureFunc() (func(ctx context.Context) (*sensors.SystemTemperatures, error), error) {
 	return windows.GetTemperatures, nil
 }
@@ -0,0 +1,54 @@
+package pwmmonitor
+
+import (
+	"errors"
+	"fmt"
+)
+
+type ComponentConfig struct {
+	Channels []ChannelConfig `json:"channels"`
+}
+
+// ChannelConfig is a PWM channel to report and, optionally, how it is expected to be configured.
+type ChannelConfig struct {
+	Name                string   `json:"name"`                            // Used as the reading key
+	Chip                string   `json:"chip"`                            // e.g. pwmchip0
+	Channel             int      `json:"channel"`                         // The channel number on the chip
+	ExpectedEnabled     *bool    `json:"expected_enabled,omitempty"`      // Optional
+	ExpectedPeriodNs    int64    `json:"expected_period_ns,omitempty"`    // Optional
+	ExpectedDutyPercent *float64 `json:"expected_duty_percent,omitempty"` // Optional
+	TolerancePercent    float64  `json:"tolerance_percent,omitempty"`     // Allowed difference from the expected duty cycle, defaults to 1
+}
+
+func (c *ChannelConfig) hasExpectation() bool {
+	return c.ExpectedEnabled != nil || c.ExpectedPeriodNs != 0 || c.ExpectedDutyPercent != nil
+}
+
+func (conf *ComponentConfig) Validate(path string) ([]string, error) {
+	if len(conf.Channels) == 0 {
+		return nil, errors.New("at least one channel is required")
+	}
+	names := make(map[string]bool)
+	for _, channel := range conf.Channels {
+		if channel.Name == "" || channel.Chip == "" {
+			return nil, errors.New("every channel requires a name and chip")
+		}
+		if names[channel.Name] {
+			return nil, fmt.Errorf("duplicate channel name: %s", channel.Name)
+		}
+		names[channel.Name] = true
+		if channel.Channel < 0 {
+			return nil, fmt.Errorf("channel %s must not be negative", channel.Name)
+		}
+		if channel.ExpectedPeriodNs < 0 {
+			return nil, fmt.Errorf("channel %s expected_period_ns must not be negative", channel.Name)
+		}
+		if channel.ExpectedDutyPercent != nil && (*channel.ExpectedDutyPercent < 0 || *channel.ExpectedDutyPercent > 100) {
+			return nil, fmt.Errorf("channel %s expected_duty_percent must be between 0 and 100", channel.Name)
+		}
+		if channel.TolerancePercent < 0 {
+			return nil, fmt.Errorf("channel %s tolerance_percent must not be negative", channel.Name)
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,144 @@
+package pwmmonitor
+
+import (
+	"context"
+	"math"
+	"sync"
+
+	"go.viam.com/rdk/components/sensor"
+	"go.viam.com/rdk/logging"
+	"go.viam.com/rdk/resource"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/linux"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+var (
+	Model       = resource.NewModel(utils.Namespace, "hwmonitor", "pwm_monitor")
+	API         = sensor.API
+	PrettyName  = "PWM Channel Monitor"
+	Description = "A sensor that reports the period, duty cycle and enabled state of PWM channels"
+	Version     = utils.Version
+)
+
+const defaultTolerancePercent = 1
+
+type Config struct {
+	resource.Named
+	mu         sync.RWMutex
+	logger     logging.Logger
+	cancelCtx  context.Context
+	cancelFunc func()
+	channels   []ChannelConfig
+	// mismatched tracks which channels are logged as not as expected, so each change is only logged once
+	mismatched map[string]bool
+}
+
+func init() {
+	resource.RegisterComponent(
+		API,
+		Model,
+		resource.Registration[sensor.Sensor, *ComponentConfig]{Constructor: utils.FilterReadings(NewSensor)})
+	utils.RegisterSimulator(Model, simulate)
+}
+
+func NewSensor(ctx context.Context, deps resource.Dependencies, conf resource.Config, logger logging.Logger) (sensor.Sensor, error) {
+	logger.Infof("Starting %s %s", PrettyName, Version)
+	cancelCtx, cancelFunc := context.WithCancel(context.Background())
+
+	b := Config{
+		Named:      conf.ResourceName().AsNamed(),
+		logger:     logger,
+		cancelCtx:  cancelCtx,
+		cancelFunc: cancelFunc,
+		mu:         sync.RWMutex{},
+	}
+
+	if err := b.Reconfigure(ctx, deps, conf); err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
+
+func (c *Config) Reconfigure(ctx context.Context, _ resource.Dependencies, rawConf resource.Config) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.logger.Debugf("Reconfiguring %s", PrettyName)
+
+	conf, err := resource.NativeConfig[*ComponentConfig](rawConf)
+	if err != nil {
+		return err
+	}
+
+	// In case the module has changed name
+	c.Named = rawConf.ResourceName().AsNamed()
+	c.channels = conf.Channels
+	c.mismatched = make(map[string]bool)
+
+	return nil
+}
+
+func (c *Config) Readings(ctx context.Context, extra map[string]interface{}) (map[string]interface{}, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	ret := make(map[string]interface{})
+	for _, channel := range c.channels {
+		pwm, err := linux.ReadPWMChannel(ctx, linux.PWMRoot, channel.Chip, channel.Channel)
+		if err != nil {
+			return nil, err
+		}
+		name := channel.Name
+		ret[name+"_exported"] = pwm.Exported
+		if pwm.Exported {
+			ret[name+"_enabled"] = pwm.Enabled
+			ret[name+"_period_ns"] = pwm.Period
+			ret[name+"_duty_cycle_ns"] = pwm.DutyCycle
+			ret[name+"_duty_percent"] = pwm.DutyPercent()
+			if pwm.Polarity != "" {
+				ret[name+"_polarity"] = pwm.Polarity
+			}
+		}
+		if !channel.hasExpectation() {
+			continue
+		}
+		asExpected := isAsExpected(&channel, pwm)
+		ret[name+"_as_expected"] = asExpected
+		if !asExpected && !c.mismatched[name] {
+			c.logger.Warnf("PWM channel %s (%s channel %d) is not configured as expected", name, channel.Chip, channel.Channel)
+		}
+		c.mismatched[name] = !asExpected
+	}
+	return ret, nil
+}
+
+func isAsExpected(channel *ChannelConfig, pwm *linux.PWMChannel) bool {
+	if !pwm.Exported {
+		return false
+	}
+	if channel.ExpectedEnabled != nil && *channel.ExpectedEnabled != pwm.Enabled {
+		return false
+	}
+	if channel.ExpectedPeriodNs != 0 && channel.ExpectedPeriodNs != pwm.Period {
+		return false
+	}
+	if channel.ExpectedDutyPercent != nil {
+		tolerance := channel.TolerancePercent
+		if tolerance == 0 {
+			tolerance = defaultTolerancePercent
+		}
+		if math.Abs(pwm.DutyPercent()-*channel.ExpectedDutyPercent) > tolerance {
+			return false
+		}
+	}
+	return true
+}
+
+func (c *Config) Close(ctx context.Context) error {
+	c.logger.Infof("Shutting down %s", PrettyName)
+	c.cancelFunc()
+	return nil
+}
+
+func (c *Config) Ready(ctx context.Context, extra map[string]interface{}) (bool, error) {
+	return false, nil
+}
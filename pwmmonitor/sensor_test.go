@@ -0,0 +1,20 @@
+package pwmmonitor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/linux"
+)
+
+func TestIsAsExpected(t *testing.T) {
+	enabled := true
+	duty := 25.0
+	pwm := &linux.PWMChannel{Exported: true, Enabled: true, Period: 40000, DutyCycle: 10200}
+
+	assert.True(t, isAsExpected(&ChannelConfig{ExpectedEnabled: &enabled, ExpectedPeriodNs: 40000, ExpectedDutyPercent: &duty}, pwm))
+	assert.False(t, isAsExpected(&ChannelConfig{ExpectedPeriodNs: 20000}, pwm))
+	assert.False(t, isAsExpected(&ChannelConfig{ExpectedDutyPercent: &duty, TolerancePercent: 0.1}, pwm))
+	assert.False(t, isAsExpected(&ChannelConfig{ExpectedEnabled: &enabled}, &linux.PWMChannel{}))
+}
@@ -0,0 +1,51 @@
+package pwmmonitor
+
+import (
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/linux"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+// simulatedPeriodNs is 25kHz, the frequency PWM fans expect.
+const simulatedPeriodNs = 40000
+
+// simulate returns the configured channels, each exported and enabled at its expected period and duty cycle so the
+// simulated board is configured as expected.
+func simulate(sim *utils.Simulation) map[string]interface{} {
+	ret := make(map[string]interface{})
+	conf, ok := sim.Config().(*ComponentConfig)
+	if !ok {
+		return ret
+	}
+	for _, channel := range conf.Channels {
+		pwm := &linux.PWMChannel{
+			Chip:     channel.Chip,
+			Channel:  channel.Channel,
+			Exported: true,
+			Enabled:  true,
+			Period:   simulatedPeriodNs,
+			Polarity: "normal",
+		}
+		if channel.ExpectedEnabled != nil {
+			pwm.Enabled = *channel.ExpectedEnabled
+		}
+		if channel.ExpectedPeriodNs != 0 {
+			pwm.Period = channel.ExpectedPeriodNs
+		}
+		duty := 50.0
+		if channel.ExpectedDutyPercent != nil {
+			duty = *channel.ExpectedDutyPercent
+		}
+		pwm.DutyCycle = int64(float64(pwm.Period) * duty / 100)
+		name := channel.Name
+		ret[name+"_exported"] = pwm.Exported
+		ret[name+"_enabled"] = pwm.Enabled
+		ret[name+"_period_ns"] = pwm.Period
+		ret[name+"_duty_cycle_ns"] = pwm.DutyCycle
+		ret[name+"_duty_percent"] = pwm.DutyPercent()
+		ret[name+"_polarity"] = pwm.Polarity
+		if channel.hasExpectation() {
+			ret[name+"_as_expected"] = isAsExpected(&channel, pwm)
+		}
+	}
+	return ret
+}
@@ -0,0 +1,41 @@
+package readinghistory
+
+import (
+	"errors"
+	"fmt"
+)
+
+// maxSamples keeps a mistyped retention from taking all of the board's memory
+const maxSamples = 1000000
+
+type ComponentConfig struct {
+	Sensors        []string `json:"sensors"`                   // The sensors whose readings are kept
+	IntervalSec    float64  `json:"interval_sec,omitempty"`    // How often the sensors are read, defaults to 10
+	RetentionHours float64  `json:"retention_hours,omitempty"` // How long readings are kept, defaults to 24
+}
+
+func (conf *ComponentConfig) Validate(path string) ([]string, error) {
+	if len(conf.Sensors) == 0 {
+		return nil, errors.New("at least one sensor is required")
+	}
+	if conf.IntervalSec < 0 || conf.RetentionHours < 0 {
+		return nil, errors.New("interval_sec and retention_hours must not be negative")
+	}
+	if samples := conf.samples(); samples > maxSamples {
+		return nil, fmt.Errorf("retention_hours and interval_sec keep %d samples per sensor, at most %d are allowed", samples, maxSamples)
+	}
+	return conf.Sensors, nil
+}
+
+// samples is how many samples of each sensor are kept.
+func (conf *ComponentConfig) samples() int {
+	interval := conf.IntervalSec
+	if interval == 0 {
+		interval = defaultIntervalSec
+	}
+	retention := conf.RetentionHours
+	if retention == 0 {
+		retention = defaultRetentionHours
+	}
+	return max(int(retention*3600/interval), 1)
+}
@@ -0,0 +1,30 @@
+package readinghistory
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidate(t *testing.T) {
+	conf := &ComponentConfig{Sensors: []string{"cpu", "temperatures"}}
+	deps, err := conf.Validate("")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"cpu", "temperatures"}, deps)
+	assert.Equal(t, 8640, conf.samples())
+
+	conf = &ComponentConfig{Sensors: []string{"cpu"}, IntervalSec: 1, RetentionHours: 0.5}
+	_, err = conf.Validate("")
+	assert.NoError(t, err)
+	assert.Equal(t, 1800, conf.samples())
+
+	for _, conf := range []*ComponentConfig{
+		{},
+		{Sensors: []string{"cpu"}, IntervalSec: -1},
+		{Sensors: []string{"cpu"}, RetentionHours: -1},
+		{Sensors: []string{"cpu"}, IntervalSec: 0.1, RetentionHours: 720},
+	} {
+		_, err := conf.Validate("")
+		assert.Error(t, err, conf)
+	}
+}
@@ -0,0 +1,202 @@
+package readinghistory
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+const (
+	aggregateMean = "mean"
+	aggregateMin  = "min"
+	aggregateMax  = "max"
+	aggregateLast = "last"
+)
+
+// record is one read of a sensor, values are indexed like the series' keys and NaN where the read didn't have a key.
+type record struct {
+	time   int64
+	values []float64
+}
+
+// series is the history of one sensor. Keys are only ever added, so the values of older records line up with the
+// first keys and are just shorter.
+type series struct {
+	mu      sync.RWMutex
+	keys    []string
+	index   map[string]int
+	records utils.RingBuffer[record]
+}
+
+func newSeries(size int) *series {
+	return &series{
+		index:   make(map[string]int),
+		records: utils.NewRingBuffer[record](size),
+	}
+}
+
+func (s *series) add(t time.Time, readings map[string]float64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for key := range readings {
+		if _, ok := s.index[key]; !ok {
+			s.index[key] = len(s.keys)
+			s.keys = append(s.keys, key)
+		}
+	}
+	values := make([]float64, len(s.keys))
+	for i, key := range s.keys {
+		value, ok := readings[key]
+		if !ok {
+			value = math.NaN()
+		}
+		values[i] = value
+	}
+	s.records.Push(record{time: t.UnixNano(), values: values})
+}
+
+// resized returns a copy of the series that holds size records, keeping the newest, so changing the retention
+// doesn't drop the history.
+func (s *series) resized(size int) *series {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	r := newSeries(size)
+	r.keys = append(r.keys, s.keys...)
+	for key, i := range s.index {
+		r.index[key] = i
+	}
+	for _, rec := range s.records.Items() {
+		r.records.Push(rec)
+	}
+	return r
+}
+
+type query struct {
+	keys      []string
+	start     time.Time
+	end       time.Time
+	step      time.Duration
+	aggregate string
+	maxPoints int
+}
+
+type result struct {
+	keys   []string
+	step   time.Duration
+	times  []time.Time
+	values [][]float64 // values[key][row], NaN where there was no value
+}
+
+// query returns the records between start and end, zero times leave that end open. With a step the records are
+// downsampled into buckets of that size, and when there would be more than maxPoints rows a step is chosen that fits.
+func (s *series) query(q query) (*result, error) {
+	s.mu.RLock()
+	keys := q.keys
+	if len(keys) == 0 {
+		keys = append([]string{}, s.keys...)
+		sort.Strings(keys)
+	}
+	columns := make([]int, len(keys))
+	for i, key := range keys {
+		column, ok := s.index[key]
+		if !ok {
+			s.mu.RUnlock()
+			return nil, fmt.Errorf("unknown key: %s", key)
+		}
+		columns[i] = column
+	}
+	s.mu.RUnlock()
+
+	var records []record
+	for _, r := range s.records.Items() {
+		if (!q.start.IsZero() && r.time < q.start.UnixNano()) || (!q.end.IsZero() && r.time > q.end.UnixNano()) {
+			continue
+		}
+		records = append(records, r)
+	}
+
+	res := &result{keys: keys, step: q.step, values: make([][]float64, len(keys))}
+	if len(records) == 0 {
+		return res, nil
+	}
+	first, last := records[0].time, records[len(records)-1].time
+	if res.step == 0 && len(records) > q.maxPoints {
+		// Whole seconds are easier to read
+		res.step = time.Duration(math.Ceil(float64(last-first+1)/float64(q.maxPoints)/float64(time.Second))) * time.Second
+	}
+	if res.step > 0 && (last-first)/int64(res.step)+1 > int64(q.maxPoints) {
+		return nil, fmt.Errorf("step_sec %v gives more than %d points", res.step.Seconds(), q.maxPoints)
+	}
+
+	if res.step == 0 {
+		for _, r := range records {
+			res.times = append(res.times, time.Unix(0, r.time))
+			for i, column := range columns {
+				res.values[i] = append(res.values[i], value(r, column))
+			}
+		}
+		return res, nil
+	}
+
+	// Records are in time order, so each bucket is a run of them
+	for start := 0; start < len(records); {
+		bucket := (records[start].time - first) / int64(res.step)
+		end := start
+		for end < len(records) && (records[end].time-first)/int64(res.step) == bucket {
+			end++
+		}
+		res.times = append(res.times, time.Unix(0, first+bucket*int64(res.step)))
+		for i, column := range columns {
+			res.values[i] = append(res.values[i], aggregate(records[start:end], column, q.aggregate))
+		}
+		start = end
+	}
+	return res, nil
+}
+
+func value(r record, column int) float64 {
+	if column >= len(r.values) {
+		return math.NaN()
+	}
+	return r.values[column]
+}
+
+func aggregate(records []record, column int, how string) float64 {
+	ret := math.NaN()
+	count := 0
+	for _, r := range records {
+		v := value(r, column)
+		if math.IsNaN(v) {
+			continue
+		}
+		count++
+		switch {
+		case count == 1, how == aggregateLast:
+			ret = v
+		case how == aggregateMin:
+			ret = math.Min(ret, v)
+		case how == aggregateMax:
+			ret = math.Max(ret, v)
+		default:
+			ret += v
+		}
+	}
+	if (how == "" || how == aggregateMean) && count > 0 {
+		ret /= float64(count)
+	}
+	return ret
+}
+
+func validAggregate(how string) error {
+	switch how {
+	case "", aggregateMean, aggregateMin, aggregateMax, aggregateLast:
+		return nil
+	default:
+		return errors.New("aggregate must be mean, min, max or last")
+	}
+}
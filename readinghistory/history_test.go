@@ -0,0 +1,103 @@
+package readinghistory
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueryRaw(t *testing.T) {
+	s := newSeries(3)
+	start := time.Unix(1700000000, 0)
+	for i := 0; i < 4; i++ {
+		s.add(start.Add(time.Duration(i)*time.Second), map[string]float64{"usage": float64(i)})
+	}
+
+	res, err := s.query(query{maxPoints: 10})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"usage"}, res.keys)
+	assert.Equal(t, time.Duration(0), res.step)
+	assert.Equal(t, []float64{1, 2, 3}, res.values[0])
+	assert.Equal(t, start.Add(time.Second).UnixNano(), res.times[0].UnixNano())
+
+	res, err = s.query(query{start: start.Add(2 * time.Second), end: start.Add(2 * time.Second), maxPoints: 10})
+	require.NoError(t, err)
+	assert.Equal(t, []float64{2}, res.values[0])
+
+	_, err = s.query(query{keys: []string{"temp"}, maxPoints: 10})
+	assert.Error(t, err)
+}
+
+func TestQueryKeyAddedLater(t *testing.T) {
+	s := newSeries(10)
+	start := time.Unix(1700000000, 0)
+	s.add(start, map[string]float64{"a": 1})
+	s.add(start.Add(time.Second), map[string]float64{"a": 2, "b": 20})
+	s.add(start.Add(2*time.Second), map[string]float64{"b": 30})
+
+	res, err := s.query(query{maxPoints: 10})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "b"}, res.keys)
+	assert.Equal(t, 1.0, res.values[0][0])
+	assert.Equal(t, 2.0, res.values[0][1])
+	assert.True(t, math.IsNaN(res.values[0][2]))
+	assert.True(t, math.IsNaN(res.values[1][0]))
+	assert.Equal(t, []float64{20, 30}, res.values[1][1:])
+}
+
+func TestQueryDownsample(t *testing.T) {
+	s := newSeries(100)
+	start := time.Unix(1700000000, 0)
+	for i := 0; i < 10; i++ {
+		s.add(start.Add(time.Duration(i)*time.Second), map[string]float64{"usage": float64(i)})
+	}
+
+	for how, expected := range map[string][]float64{
+		"":            {2, 7},
+		aggregateMean: {2, 7},
+		aggregateMin:  {0, 5},
+		aggregateMax:  {4, 9},
+		aggregateLast: {4, 9},
+	} {
+		res, err := s.query(query{step: 5 * time.Second, aggregate: how, maxPoints: 10})
+		require.NoError(t, err)
+		assert.Equal(t, expected, res.values[0], how)
+		assert.Equal(t, []int64{start.UnixNano(), start.Add(5 * time.Second).UnixNano()}, []int64{res.times[0].UnixNano(), res.times[1].UnixNano()})
+	}
+
+	// Too many rows picks a step that fits
+	res, err := s.query(query{maxPoints: 3})
+	require.NoError(t, err)
+	assert.Equal(t, 4*time.Second, res.step)
+	assert.Equal(t, []float64{1.5, 5.5, 8.5}, res.values[0])
+
+	_, err = s.query(query{step: time.Second, maxPoints: 3})
+	assert.Error(t, err)
+}
+
+func TestValidAggregate(t *testing.T) {
+	assert.NoError(t, validAggregate("max"))
+	assert.Error(t, validAggregate("median"))
+}
+
+func TestSeriesResized(t *testing.T) {
+	s := newSeries(4)
+	start := time.Unix(1700000000, 0)
+	for i := 0; i < 4; i++ {
+		s.add(start.Add(time.Duration(i)*time.Second), map[string]float64{"usage": float64(i)})
+	}
+
+	smaller := s.resized(2)
+	res, err := smaller.query(query{maxPoints: 10})
+	require.NoError(t, err)
+	assert.Equal(t, []float64{2, 3}, res.values[0])
+
+	larger := s.resized(10)
+	larger.add(start.Add(4*time.Second), map[string]float64{"usage": 4})
+	res, err = larger.query(query{maxPoints: 10})
+	require.NoError(t, err)
+	assert.Equal(t, []float64{0, 1, 2, 3, 4}, res.values[0])
+}
@@ -0,0 +1,335 @@
+package readinghistory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"go.viam.com/rdk/components/sensor"
+	"go.viam.com/rdk/logging"
+	"go.viam.com/rdk/resource"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+var (
+	Model       = resource.NewModel(utils.Namespace, "hwmonitor", "reading_history")
+	API         = sensor.API
+	PrettyName  = "Reading History"
+	Description = "A sensor that keeps the recent readings of other sensors in memory and answers queries over a time range"
+	Version     = utils.Version
+)
+
+const (
+	defaultIntervalSec    = 10
+	defaultRetentionHours = 24
+	defaultMaxPoints      = 1000
+	maxMaxPoints          = 10000
+	readTimeout           = 5 * time.Second
+)
+
+type Config struct {
+	resource.Named
+	configLock   sync.Mutex
+	readingsLock sync.RWMutex
+	logger       logging.Logger
+	task         *utils.Task
+	series       map[string]*series
+	samples      int
+	sensorCount  int
+	interval     time.Duration
+	lastErr      error
+	freshness    utils.Freshness
+}
+
+func init() {
+	resource.RegisterComponent(
+		API,
+		Model,
+		resource.Registration[sensor.Sensor, *ComponentConfig]{Constructor: utils.FilterReadings(NewSensor)})
+}
+
+func NewSensor(ctx context.Context, deps resource.Dependencies, conf resource.Config, logger logging.Logger) (sensor.Sensor, error) {
+	logger.Infof("Starting %s %s", PrettyName, Version)
+	b := Config{
+		Named:  conf.ResourceName().AsNamed(),
+		logger: logger,
+	}
+
+	if err := b.Reconfigure(ctx, deps, conf); err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
+
+func (c *Config) Reconfigure(ctx context.Context, deps resource.Dependencies, rawConf resource.Config) error {
+	c.configLock.Lock()
+	defer c.configLock.Unlock()
+	c.logger.Debugf("Reconfiguring %s", PrettyName)
+
+	if c.task != nil {
+		c.logger.Debug("Stopping polling")
+		c.task.Stop()
+		c.logger.Debugf("Polling stopped")
+		c.task = nil
+	}
+
+	conf, err := resource.NativeConfig[*ComponentConfig](rawConf)
+	if err != nil {
+		return err
+	}
+
+	// In case the component has changed name
+	c.Named = rawConf.ResourceName().AsNamed()
+
+	sources, err := utils.ResolveSources(deps, conf.Sensors)
+	if err != nil {
+		return err
+	}
+	intervalSec := conf.IntervalSec
+	if intervalSec == 0 {
+		intervalSec = defaultIntervalSec
+	}
+	interval := time.Duration(intervalSec * float64(time.Second))
+
+	// The history is what this sensor is for, so it survives a reconfigure, a new retention only changes how much of it
+	// is kept
+	samples := conf.samples()
+	c.readingsLock.Lock()
+	old := c.series
+	c.series = make(map[string]*series, len(sources))
+	for _, s := range sources {
+		if existing, ok := old[s.Name]; ok {
+			if samples != c.samples {
+				existing = existing.resized(samples)
+			}
+			c.series[s.Name] = existing
+			continue
+		}
+		c.series[s.Name] = newSeries(samples)
+	}
+	c.samples = samples
+	c.sensorCount = len(sources)
+	c.interval = interval
+	c.lastErr = nil
+	c.readingsLock.Unlock()
+
+	c.task = utils.ScheduleAligned(interval, utils.ReadingsSource, func(ctx context.Context) {
+		c.record(ctx, sources)
+	})
+	return nil
+}
+
+// record adds a reading of every source to its history, the scheduler calls it every interval.
+func (c *Config) record(ctx context.Context, sources []utils.Source) {
+	var readErr error
+	for _, s := range sources {
+		readCtx, cancel := context.WithTimeout(ctx, readTimeout)
+		readings, err := s.Sensor.Readings(readCtx, nil)
+		cancel()
+		if ctx.Err() != nil {
+			return
+		}
+		c.readingsLock.Lock()
+		if err != nil {
+			if c.lastErr == nil {
+				c.logger.Warnf("Failed to read %s, its history will have a gap: %v", s.Name, err)
+			}
+			c.lastErr = fmt.Errorf("%s: %w", s.Name, err)
+			readErr = c.lastErr
+		}
+		history := c.series[s.Name]
+		c.readingsLock.Unlock()
+		if err == nil {
+			history.add(time.Now(), utils.NumericReadings(readings))
+		}
+	}
+	c.readingsLock.Lock()
+	defer c.readingsLock.Unlock()
+	if readErr != nil {
+		c.freshness.Failed(readErr)
+	} else {
+		c.freshness.Succeeded(time.Now())
+	}
+}
+
+func (c *Config) Readings(ctx context.Context, extra map[string]interface{}) (map[string]interface{}, error) {
+	c.readingsLock.RLock()
+	defer c.readingsLock.RUnlock()
+	samples := 0
+	var oldest time.Time
+	for _, s := range c.series {
+		records := s.records.Items()
+		samples += len(records)
+		if len(records) > 0 {
+			if t := time.Unix(0, records[0].time); oldest.IsZero() || t.Before(oldest) {
+				oldest = t
+			}
+		}
+	}
+	ret := map[string]interface{}{
+		"sensor_count": c.sensorCount,
+		"sample_count": samples,
+	}
+	if !oldest.IsZero() {
+		ret["oldest_sample_time"] = oldest.Format(time.RFC3339)
+	}
+	if c.lastErr != nil {
+		ret["last_error"] = c.lastErr.Error()
+	}
+	return c.freshness.Stamp(ret, c.interval, time.Now()), nil
+}
+
+func (c *Config) DoCommand(ctx context.Context, cmd map[string]interface{}) (map[string]interface{}, error) {
+	command, ok := cmd["command"].(string)
+	if !ok {
+		return nil, errors.New("missing or invalid 'command' field")
+	}
+
+	switch command {
+	case "list":
+		return c.handleList()
+	case "query":
+		return c.handleQuery(cmd)
+	default:
+		return nil, fmt.Errorf("unknown command: %s", command)
+	}
+}
+
+// handleList returns the keys kept for each sensor and the time range they cover.
+func (c *Config) handleList() (map[string]interface{}, error) {
+	c.readingsLock.RLock()
+	defer c.readingsLock.RUnlock()
+	ret := make(map[string]interface{}, len(c.series))
+	for name, s := range c.series {
+		s.mu.RLock()
+		keys := append([]string{}, s.keys...)
+		s.mu.RUnlock()
+		sort.Strings(keys)
+		entry := map[string]interface{}{
+			"keys":    stringsToInterfaces(keys),
+			"samples": s.records.Len(),
+		}
+		if records := s.records.Items(); len(records) > 0 {
+			entry["start"] = time.Unix(0, records[0].time).UTC().Format(time.RFC3339Nano)
+			entry["end"] = time.Unix(0, records[len(records)-1].time).UTC().Format(time.RFC3339Nano)
+		}
+		ret[name] = entry
+	}
+	return map[string]interface{}{"sensors": ret}, nil
+}
+
+func (c *Config) handleQuery(cmd map[string]interface{}) (map[string]interface{}, error) {
+	name, ok := cmd["sensor"].(string)
+	if !ok {
+		return nil, errors.New("missing or invalid 'sensor' parameter")
+	}
+	c.readingsLock.RLock()
+	s, ok := c.series[name]
+	c.readingsLock.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("unknown sensor: %s", name)
+	}
+
+	q, err := parseQuery(cmd)
+	if err != nil {
+		return nil, err
+	}
+	res, err := s.query(q)
+	if err != nil {
+		return nil, err
+	}
+
+	times := make([]interface{}, 0, len(res.times))
+	for _, t := range res.times {
+		times = append(times, t.UTC().Format(time.RFC3339Nano))
+	}
+	values := make(map[string]interface{}, len(res.keys))
+	for i, key := range res.keys {
+		column := make([]interface{}, 0, len(res.values[i]))
+		for _, v := range res.values[i] {
+			if math.IsNaN(v) {
+				column = append(column, nil)
+			} else {
+				column = append(column, v)
+			}
+		}
+		values[key] = column
+	}
+	return map[string]interface{}{
+		"sensor":   name,
+		"step_sec": res.step.Seconds(),
+		"times":    times,
+		"values":   values,
+	}, nil
+}
+
+func parseQuery(cmd map[string]interface{}) (query, error) {
+	q := query{maxPoints: defaultMaxPoints}
+	if keys, ok := cmd["keys"].([]interface{}); ok {
+		for _, key := range keys {
+			s, ok := key.(string)
+			if !ok {
+				return q, errors.New("invalid 'keys' parameter, it must be a list of strings")
+			}
+			q.keys = append(q.keys, s)
+		}
+	}
+	for param, t := range map[string]*time.Time{"start": &q.start, "end": &q.end} {
+		value, ok := cmd[param].(string)
+		if !ok {
+			continue
+		}
+		parsed, err := time.Parse(time.RFC3339Nano, value)
+		if err != nil {
+			return q, fmt.Errorf("invalid '%s' parameter, it must be an RFC 3339 time", param)
+		}
+		*t = parsed
+	}
+	if step, ok := cmd["step_sec"].(float64); ok {
+		if step < 0 {
+			return q, errors.New("invalid 'step_sec' parameter, it must not be negative")
+		}
+		q.step = time.Duration(step * float64(time.Second))
+	}
+	if how, ok := cmd["aggregate"].(string); ok {
+		if err := validAggregate(how); err != nil {
+			return q, err
+		}
+		q.aggregate = how
+	}
+	if maxPoints, ok := cmd["max_points"].(float64); ok {
+		if maxPoints < 1 || maxPoints > maxMaxPoints {
+			return q, fmt.Errorf("invalid 'max_points' parameter, it must be between 1 and %d", maxMaxPoints)
+		}
+		q.maxPoints = int(maxPoints)
+	}
+	return q, nil
+}
+
+func stringsToInterfaces(s []string) []interface{} {
+	r := make([]interface{}, len(s))
+	for i, v := range s {
+		r[i] = v
+	}
+	return r
+}
+
+func (c *Config) Close(ctx context.Context) error {
+	c.configLock.Lock()
+	defer c.configLock.Unlock()
+	c.logger.Infof("Shutting down %s", PrettyName)
+	if c.task != nil {
+		c.task.Stop()
+	}
+	c.logger.Infof("%s Shutdown complete", PrettyName)
+	return nil
+}
+
+func (c *Config) Ready(ctx context.Context, extra map[string]interface{}) (bool, error) {
+	return false, nil
+}
@@ -0,0 +1,19 @@
+package remoteprocmonitor
+
+import (
+	"errors"
+	"strings"
+)
+
+type ComponentConfig struct {
+	Required []string `json:"required"` // Remote processors that must be running, by name or device, e.g. 4a334000.pru or remoteproc1
+}
+
+func (conf *ComponentConfig) Validate(path string) ([]string, error) {
+	for _, name := range conf.Required {
+		if strings.TrimSpace(name) == "" {
+			return nil, errors.New("required must not contain empty names")
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,144 @@
+package remoteprocmonitor
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+
+	"go.viam.com/rdk/components/sensor"
+	"go.viam.com/rdk/logging"
+	"go.viam.com/rdk/resource"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/linux"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+var (
+	Model       = resource.NewModel(utils.Namespace, "hwmonitor", "remoteproc_monitor")
+	API         = sensor.API
+	PrettyName  = "SBC Remote Processor Monitor"
+	Description = "A sensor that reports the state of coprocessors such as the BeagleBone PRUs"
+	Version     = utils.Version
+)
+
+type Config struct {
+	resource.Named
+	mu         sync.RWMutex
+	logger     logging.Logger
+	cancelCtx  context.Context
+	cancelFunc func()
+	required   []string
+	stopped    map[string]bool
+}
+
+func init() {
+	resource.RegisterComponent(
+		API,
+		Model,
+		resource.Registration[sensor.Sensor, *ComponentConfig]{Constructor: utils.FilterReadings(NewSensor)})
+	utils.RegisterSimulator(Model, simulate)
+}
+
+func NewSensor(ctx context.Context, deps resource.Dependencies, conf resource.Config, logger logging.Logger) (sensor.Sensor, error) {
+	logger.Infof("Starting %s %s", PrettyName, Version)
+	cancelCtx, cancelFunc := context.WithCancel(context.Background())
+
+	b := Config{
+		Named:      conf.ResourceName().AsNamed(),
+		logger:     logger,
+		cancelCtx:  cancelCtx,
+		cancelFunc: cancelFunc,
+		mu:         sync.RWMutex{},
+	}
+
+	if err := b.Reconfigure(ctx, deps, conf); err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
+
+func (c *Config) Reconfigure(ctx context.Context, _ resource.Dependencies, rawConf resource.Config) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.logger.Debugf("Reconfiguring %s", PrettyName)
+
+	conf, err := resource.NativeConfig[*ComponentConfig](rawConf)
+	if err != nil {
+		return err
+	}
+
+	// In case the module has changed name
+	c.Named = rawConf.ResourceName().AsNamed()
+	c.required = conf.Required
+	c.stopped = make(map[string]bool)
+
+	return nil
+}
+
+func (c *Config) Readings(ctx context.Context, extra map[string]interface{}) (map[string]interface{}, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	procs, err := linux.ReadRemoteprocs(ctx, linux.RemoteprocRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	ret := make(map[string]interface{})
+	// Required processors can be named by processor or device name
+	running := make(map[string]bool)
+	runningCount, crashed := 0, 0
+	for _, proc := range procs {
+		name := procName(proc.Name)
+		ret[name+"_state"] = proc.State
+		ret[name+"_running"] = proc.State == "running"
+		ret[name+"_firmware"] = proc.Firmware
+		if proc.State == "running" {
+			running[proc.Name], running[proc.Device] = true, true
+			runningCount++
+		} else if proc.State == "crashed" {
+			crashed++
+		}
+	}
+	ret["remoteproc_count"] = len(procs)
+	ret["running_count"] = runningCount
+	ret["crashed_count"] = crashed
+
+	if len(c.required) == 0 {
+		return ret, nil
+	}
+	var missing []string
+	for _, name := range c.required {
+		isRunning := running[name]
+		if !isRunning {
+			missing = append(missing, name)
+		}
+		// Only log when a processor stops or starts, not on every reading
+		if !isRunning && !c.stopped[name] {
+			c.logger.Warnf("Required remote processor %s is not running", name)
+		} else if isRunning && c.stopped[name] {
+			c.logger.Infof("Required remote processor %s is running", name)
+		}
+		c.stopped[name] = !isRunning
+	}
+	sort.Strings(missing)
+	ret["all_required_running"] = len(missing) == 0
+	ret["missing_required"] = strings.Join(missing, ",")
+
+	return ret, nil
+}
+
+// procName converts a processor name into a reading key prefix, e.g. 4a334000.pru becomes 4a334000_pru.
+func procName(name string) string {
+	return strings.NewReplacer(".", "_", "-", "_", ":", "_").Replace(name)
+}
+
+func (c *Config) Close(ctx context.Context) error {
+	c.logger.Infof("Shutting down %s", PrettyName)
+	c.cancelFunc()
+	return nil
+}
+
+func (c *Config) Ready(ctx context.Context, extra map[string]interface{}) (bool, error) {
+	return false, nil
+}
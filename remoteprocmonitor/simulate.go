@@ -0,0 +1,26 @@
+package remoteprocmonitor
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+// simulate returns a Raspberry Pi 5, which has no remote processors, so every required one is missing.
+func simulate(sim *utils.Simulation) map[string]interface{} {
+	ret := map[string]interface{}{
+		"remoteproc_count": 0,
+		"running_count":    0,
+		"crashed_count":    0,
+	}
+	conf, ok := sim.Config().(*ComponentConfig)
+	if !ok || len(conf.Required) == 0 {
+		return ret
+	}
+	missing := append([]string(nil), conf.Required...)
+	sort.Strings(missing)
+	ret["all_required_running"] = false
+	ret["missing_required"] = strings.Join(missing, ",")
+	return ret
+}
@@ -0,0 +1,8 @@
+package removablemediamonitor
+
+type ComponentConfig struct {
+}
+
+func (conf *ComponentConfig) Validate(path string) ([]string, error) {
+	return nil, nil
+}
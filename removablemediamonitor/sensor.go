@@ -0,0 +1,190 @@
+package removablemediamonitor
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/shirou/gopsutil/v4/disk"
+	"go.viam.com/rdk/components/sensor"
+	"go.viam.com/rdk/logging"
+	"go.viam.com/rdk/resource"
+	viamutils "go.viam.com/utils"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/linux"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+var (
+	Model       = resource.NewModel(utils.Namespace, "hwmonitor", "removable_media_monitor")
+	API         = sensor.API
+	PrettyName  = "Removable Media Monitor"
+	Description = "A sensor that reports attached removable media, their mount status and hotplug events"
+	Version     = utils.Version
+)
+
+type Config struct {
+	resource.Named
+	configLock   sync.Mutex
+	readingsLock sync.RWMutex
+	logger       logging.Logger
+	workers      *viamutils.StoppableWorkers
+	lastEvent    *linux.Uevent
+	lastEventAt  time.Time
+	eventCount   int
+}
+
+func init() {
+	resource.RegisterComponent(
+		API,
+		Model,
+		resource.Registration[sensor.Sensor, *ComponentConfig]{Constructor: utils.FilterReadings(NewSensor)})
+	utils.RegisterSimulator(Model, simulate)
+}
+
+func NewSensor(ctx context.Context, deps resource.Dependencies, conf resource.Config, logger logging.Logger) (sensor.Sensor, error) {
+	logger.Infof("Starting %s %s", PrettyName, Version)
+	b := Config{
+		Named:  conf.ResourceName().AsNamed(),
+		logger: logger,
+	}
+
+	if err := b.Reconfigure(ctx, deps, conf); err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
+
+func (c *Config) Reconfigure(ctx context.Context, _ resource.Dependencies, rawConf resource.Config) error {
+	c.configLock.Lock()
+	defer c.configLock.Unlock()
+	c.logger.Debugf("Reconfiguring %s", PrettyName)
+
+	// In case the component has changed name
+	c.Named = rawConf.ResourceName().AsNamed()
+
+	// The uevent listener doesn't depend on the config, it keeps running so no events are missed while reconfiguring
+	if c.workers != nil {
+		return nil
+	}
+	uevents, err := linux.OpenUevents(time.Second)
+	if err != nil {
+		// Attached media is still reported, only the hotplug events are missing
+		c.logger.Warnf("Unable to subscribe to kernel uevents, hotplug events will not be reported: %v", err)
+	} else {
+		c.workers = viamutils.NewBackgroundStoppableWorkers(func(ctx context.Context) {
+			c.watchUevents(ctx, uevents)
+		})
+	}
+	return nil
+}
+
+func (c *Config) Readings(ctx context.Context, extra map[string]interface{}) (map[string]interface{}, error) {
+	disks, err := linux.GetRemovableDisks(ctx)
+	if err != nil {
+		return nil, err
+	}
+	parts, err := disk.PartitionsWithContext(ctx, true)
+	if err != nil {
+		return nil, err
+	}
+	mountpoints := make(map[string][]string)
+	for _, part := range parts {
+		device := filepath.Base(part.Device)
+		mountpoints[device] = append(mountpoints[device], part.Mountpoint)
+	}
+
+	ret := make(map[string]interface{})
+	ret["attached_count"] = len(disks)
+	for _, d := range disks {
+		name := d.Name
+		mounted := make([]string, 0)
+		mounted = append(mounted, mountpoints[d.Name]...)
+		for _, partition := range d.Partitions {
+			mounted = append(mounted, mountpoints[partition]...)
+		}
+		ret[name+"_vendor"] = d.Vendor
+		ret[name+"_model"] = d.Model
+		ret[name+"_size_bytes"] = d.SizeBytes
+		ret[name+"_usb"] = d.USB
+		ret[name+"_partitions"] = len(d.Partitions)
+		ret[name+"_mounted"] = len(mounted) > 0
+		ret[name+"_mountpoints"] = strings.Join(mounted, ",")
+	}
+
+	c.readingsLock.RLock()
+	defer c.readingsLock.RUnlock()
+	ret["hotplug_events"] = c.eventCount
+	if c.lastEvent != nil {
+		ret["last_event_action"] = c.lastEvent.Action
+		ret["last_event_device"] = c.lastEvent.DevName
+		ret["last_event_time"] = c.lastEventAt.Format(time.RFC3339)
+	}
+	return ret, nil
+}
+
+func (c *Config) watchUevents(ctx context.Context, uevents *linux.UeventReader) {
+	defer uevents.Close()
+	// A removed disk is gone from sysfs before its event arrives, remember which disks were removable so their removal
+	// is counted too
+	known := make(map[string]bool)
+	if disks, err := linux.GetRemovableDisks(ctx); err == nil {
+		for _, d := range disks {
+			known[d.Name] = true
+		}
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		default:
+		}
+		event, err := uevents.Read()
+		if err != nil {
+			c.logger.Warnf("Failed to read kernel uevent: %v", err)
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(time.Second):
+			}
+			continue
+		}
+		if event == nil || (event.Action != "add" && event.Action != "remove" && event.Action != "change") {
+			continue
+		}
+		// Only whole removable disks are interesting, every partition of a stick raises its own event and internal
+		// eMMC, loop and zram devices raise change events too
+		name := filepath.Base(event.DevName)
+		if !linux.IsRemovableDiskEvent(ctx, event) && !(known[name] && event.DevType == "disk") {
+			continue
+		}
+		if event.Action == "remove" {
+			delete(known, name)
+		} else {
+			known[name] = true
+		}
+		c.logger.Infof("Block device %s: %s", event.DevName, event.Action)
+		c.readingsLock.Lock()
+		c.eventCount++
+		c.lastEvent = event
+		c.lastEventAt = time.Now()
+		c.readingsLock.Unlock()
+	}
+}
+
+func (c *Config) Close(ctx context.Context) error {
+	c.configLock.Lock()
+	defer c.configLock.Unlock()
+	c.logger.Infof("Shutting down %s", PrettyName)
+	if c.workers != nil {
+		c.workers.Stop()
+	}
+	c.logger.Infof("%s Shutdown complete", PrettyName)
+	return nil
+}
+
+func (c *Config) Ready(ctx context.Context, extra map[string]interface{}) (bool, error) {
+	return false, nil
+}
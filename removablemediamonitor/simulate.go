@@ -0,0 +1,18 @@
+package removablemediamonitor
+
+import "github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+
+// simulate returns a USB flash drive that was attached at startup and is mounted.
+func simulate(sim *utils.Simulation) map[string]interface{} {
+	return map[string]interface{}{
+		"attached_count":  1,
+		"sda_vendor":      "SanDisk",
+		"sda_model":       "Ultra Fit",
+		"sda_size_bytes":  uint64(61_530_439_680),
+		"sda_usb":         true,
+		"sda_partitions":  1,
+		"sda_mounted":     true,
+		"sda_mountpoints": "/media/usb",
+		"hotplug_events":  0,
+	}
+}
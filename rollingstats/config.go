@@ -0,0 +1,105 @@
+package rollingstats
+
+import (
+	"errors"
+	"fmt"
+)
+
+// maxWindowSamples keeps a mistyped window from taking all of the board's memory
+const maxWindowSamples = 100000
+
+type Aggregate struct {
+	Name          string   `json:"name,omitempty"`           // The prefix of the readings, defaults to the key
+	Sensor        string   `json:"sensor"`                   // The sensor the reading is sampled from
+	Key           string   `json:"key"`                      // The reading, the keys of nested readings are joined with underscores
+	Stats         []string `json:"stats,omitempty"`          // mean, min, max, stddev or a percentile such as p95, defaults to mean, min and max
+	WindowSamples int      `json:"window_samples,omitempty"` // Aggregate over the last N samples
+	WindowSec     float64  `json:"window_sec,omitempty"`     // Aggregate over the samples of the last T seconds, defaults to 60 without window_samples
+}
+
+type ComponentConfig struct {
+	Aggregates        []Aggregate `json:"aggregates"`
+	SampleIntervalSec float64     `json:"sample_interval_sec,omitempty"` // How often the readings are sampled, defaults to 1
+}
+
+func (conf *ComponentConfig) Validate(path string) ([]string, error) {
+	if len(conf.Aggregates) == 0 {
+		return nil, errors.New("at least one aggregate is required")
+	}
+	if conf.SampleIntervalSec < 0 {
+		return nil, errors.New("sample_interval_sec must not be negative")
+	}
+	names := make(map[string]bool, len(conf.Aggregates))
+	for i, a := range conf.Aggregates {
+		if a.Sensor == "" || a.Key == "" {
+			return nil, fmt.Errorf("aggregate %d: sensor and key are required", i)
+		}
+		name := a.name()
+		if names[name] {
+			return nil, fmt.Errorf("aggregate %s: name is used by another aggregate, set a different name", name)
+		}
+		names[name] = true
+		for _, s := range a.Stats {
+			if _, err := parseStat(s); err != nil {
+				return nil, fmt.Errorf("aggregate %s: %w", name, err)
+			}
+		}
+		if a.WindowSamples < 0 || a.WindowSec < 0 {
+			return nil, fmt.Errorf("aggregate %s: window_samples and window_sec must not be negative", name)
+		}
+		if samples := conf.windowSamples(a); samples > maxWindowSamples {
+			return nil, fmt.Errorf("aggregate %s: the window holds %d samples, at most %d are allowed", name, samples, maxWindowSamples)
+		}
+	}
+	return conf.sensors(), nil
+}
+
+func (a Aggregate) name() string {
+	if a.Name != "" {
+		return a.Name
+	}
+	return a.Key
+}
+
+func (a Aggregate) stats() []string {
+	if len(a.Stats) == 0 {
+		return []string{statMean, statMin, statMax}
+	}
+	return a.Stats
+}
+
+func (conf *ComponentConfig) sampleIntervalSec() float64 {
+	if conf.SampleIntervalSec == 0 {
+		return defaultSampleIntervalSec
+	}
+	return conf.SampleIntervalSec
+}
+
+func (a Aggregate) windowSec() float64 {
+	if a.WindowSec == 0 && a.WindowSamples == 0 {
+		return defaultWindowSec
+	}
+	return a.WindowSec
+}
+
+// windowSamples is the most samples the aggregate's window can hold.
+func (conf *ComponentConfig) windowSamples(a Aggregate) int {
+	bySec := int(a.windowSec()/conf.sampleIntervalSec()) + 1
+	if a.WindowSamples > 0 && (a.windowSec() == 0 || a.WindowSamples < bySec) {
+		return a.WindowSamples
+	}
+	return bySec
+}
+
+// sensors returns the sensors the aggregates sample, each once.
+func (conf *ComponentConfig) sensors() []string {
+	var ret []string
+	seen := make(map[string]bool)
+	for _, a := range conf.Aggregates {
+		if !seen[a.Sensor] {
+			seen[a.Sensor] = true
+			ret = append(ret, a.Sensor)
+		}
+	}
+	return ret
+}
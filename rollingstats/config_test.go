@@ -0,0 +1,44 @@
+package rollingstats
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidate(t *testing.T) {
+	conf := &ComponentConfig{Aggregates: []Aggregate{
+		{Sensor: "temperatures", Key: "CPU", Stats: []string{"mean", "max", "p95"}, WindowSec: 60},
+		{Name: "cpu_usage", Sensor: "cpu_monitor", Key: "usage", Stats: []string{"stddev", "p99.9"}, WindowSamples: 30},
+		{Name: "gpu_temp", Sensor: "temperatures", Key: "GPU"},
+	}}
+	deps, err := conf.Validate("")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"temperatures", "cpu_monitor"}, deps)
+
+	for _, aggregates := range [][]Aggregate{
+		nil,
+		{{Sensor: "temperatures"}},
+		{{Sensor: "temperatures", Key: "CPU"}, {Sensor: "temperatures", Key: "CPU"}},
+		{{Sensor: "temperatures", Key: "CPU", Stats: []string{"median"}}},
+		{{Sensor: "temperatures", Key: "CPU", Stats: []string{"p101"}}},
+		{{Sensor: "temperatures", Key: "CPU", WindowSec: -1}},
+		{{Sensor: "temperatures", Key: "CPU", WindowSamples: maxWindowSamples + 1}},
+		{{Sensor: "temperatures", Key: "CPU", WindowSec: 7 * 24 * 3600}},
+	} {
+		_, err := (&ComponentConfig{Aggregates: aggregates}).Validate("")
+		assert.Error(t, err, aggregates)
+	}
+	_, err = (&ComponentConfig{Aggregates: conf.Aggregates, SampleIntervalSec: -1}).Validate("")
+	assert.Error(t, err)
+}
+
+func TestWindowSamples(t *testing.T) {
+	conf := &ComponentConfig{SampleIntervalSec: 2}
+	assert.Equal(t, 31, conf.windowSamples(Aggregate{WindowSec: 60}))
+	assert.Equal(t, 10, conf.windowSamples(Aggregate{WindowSamples: 10}))
+	assert.Equal(t, 10, conf.windowSamples(Aggregate{WindowSamples: 10, WindowSec: 60}))
+	assert.Equal(t, 31, conf.windowSamples(Aggregate{WindowSamples: 100, WindowSec: 60}))
+	// The default window is a minute
+	assert.Equal(t, 61, (&ComponentConfig{}).windowSamples(Aggregate{}))
+}
@@ -0,0 +1,191 @@
+package rollingstats
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.viam.com/rdk/components/sensor"
+	"go.viam.com/rdk/logging"
+	"go.viam.com/rdk/resource"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+var (
+	Model       = resource.NewModel(utils.Namespace, "hwmonitor", "rolling_stats")
+	API         = sensor.API
+	PrettyName  = "Rolling Stats"
+	Description = "A sensor that samples the readings of other sensors and reports their mean, min, max, stddev and percentiles over a rolling window"
+	Version     = utils.Version
+)
+
+const (
+	defaultSampleIntervalSec = 1
+	defaultWindowSec         = 60
+	readTimeout              = 5 * time.Second
+)
+
+type aggregate struct {
+	Aggregate
+	window *window
+}
+
+type Config struct {
+	resource.Named
+	configLock   sync.Mutex
+	readingsLock sync.RWMutex
+	logger       logging.Logger
+	task         *utils.Task
+	aggregates   []aggregate
+	interval     time.Duration
+	lastErr      error
+	freshness    utils.Freshness
+}
+
+func init() {
+	resource.RegisterComponent(
+		API,
+		Model,
+		resource.Registration[sensor.Sensor, *ComponentConfig]{Constructor: utils.FilterReadings(NewSensor)})
+}
+
+func NewSensor(ctx context.Context, deps resource.Dependencies, conf resource.Config, logger logging.Logger) (sensor.Sensor, error) {
+	logger.Infof("Starting %s %s", PrettyName, Version)
+	b := Config{
+		Named:  conf.ResourceName().AsNamed(),
+		logger: logger,
+	}
+
+	if err := b.Reconfigure(ctx, deps, conf); err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
+
+func (c *Config) Reconfigure(ctx context.Context, deps resource.Dependencies, rawConf resource.Config) error {
+	c.configLock.Lock()
+	defer c.configLock.Unlock()
+	c.logger.Debugf("Reconfiguring %s", PrettyName)
+
+	if c.task != nil {
+		c.logger.Debug("Stopping polling")
+		c.task.Stop()
+		c.logger.Debugf("Polling stopped")
+		c.task = nil
+	}
+
+	conf, err := resource.NativeConfig[*ComponentConfig](rawConf)
+	if err != nil {
+		return err
+	}
+
+	// In case the component has changed name
+	c.Named = rawConf.ResourceName().AsNamed()
+
+	sources, err := utils.ResolveSources(deps, conf.sensors())
+	if err != nil {
+		return err
+	}
+	interval := time.Duration(conf.sampleIntervalSec() * float64(time.Second))
+
+	// Readings that are still sampled keep their samples, the new window applies from the next sample
+	c.readingsLock.Lock()
+	previous := make(map[[2]string]*window, len(c.aggregates))
+	for _, a := range c.aggregates {
+		previous[[2]string{a.Sensor, a.Key}] = a.window
+	}
+	c.aggregates = make([]aggregate, 0, len(conf.Aggregates))
+	for _, a := range conf.Aggregates {
+		w, ok := previous[[2]string{a.Sensor, a.Key}]
+		if !ok {
+			w = &window{}
+		}
+		// Aggregates of the same reading can have different windows
+		delete(previous, [2]string{a.Sensor, a.Key})
+		w.maxSamples = conf.windowSamples(a)
+		w.maxAge = time.Duration(a.windowSec() * float64(time.Second))
+		c.aggregates = append(c.aggregates, aggregate{Aggregate: a, window: w})
+	}
+	c.interval = interval
+	c.lastErr = nil
+	c.readingsLock.Unlock()
+
+	c.task = utils.ScheduleAligned(interval, utils.ReadingsSource, func(ctx context.Context) {
+		c.sample(ctx, sources)
+	})
+	return nil
+}
+
+// sample adds a reading of every source to the windows, the scheduler calls it every interval.
+func (c *Config) sample(ctx context.Context, sources []utils.Source) {
+	readings := make(map[string]map[string]float64, len(sources))
+	var readErr error
+	for _, s := range sources {
+		readCtx, cancel := context.WithTimeout(ctx, readTimeout)
+		r, err := s.Sensor.Readings(readCtx, nil)
+		cancel()
+		if ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			readErr = fmt.Errorf("failed to read %s: %w", s.Name, err)
+			continue
+		}
+		readings[s.Name] = utils.NumericReadings(r)
+	}
+
+	now := time.Now()
+	c.readingsLock.Lock()
+	for _, a := range c.aggregates {
+		if value, ok := readings[a.Sensor][a.Key]; ok {
+			a.window.add(now, value)
+		}
+	}
+	if readErr != nil && c.lastErr == nil {
+		c.logger.Warnf("Failed to sample readings: %v", readErr)
+	}
+	c.lastErr = readErr
+	if readErr != nil {
+		c.freshness.Failed(readErr)
+	} else {
+		c.freshness.Succeeded(now)
+	}
+	c.readingsLock.Unlock()
+}
+
+func (c *Config) Readings(ctx context.Context, extra map[string]interface{}) (map[string]interface{}, error) {
+	// Computing the stats drops the samples that aged out of the windows
+	c.readingsLock.Lock()
+	defer c.readingsLock.Unlock()
+	now := time.Now()
+	ret := make(map[string]interface{})
+	for _, a := range c.aggregates {
+		name := a.name()
+		stats, count := a.window.compute(now, a.stats())
+		ret[name+"_samples"] = count
+		for stat, value := range stats {
+			ret[name+"_"+stat] = value
+		}
+	}
+	if c.lastErr != nil {
+		ret["last_error"] = c.lastErr.Error()
+	}
+	return c.freshness.Stamp(ret, c.interval, now), nil
+}
+
+func (c *Config) Close(ctx context.Context) error {
+	c.configLock.Lock()
+	defer c.configLock.Unlock()
+	c.logger.Infof("Shutting down %s", PrettyName)
+	if c.task != nil {
+		c.task.Stop()
+	}
+	c.logger.Infof("%s Shutdown complete", PrettyName)
+	return nil
+}
+
+func (c *Config) Ready(ctx context.Context, extra map[string]interface{}) (bool, error) {
+	return false, nil
+}
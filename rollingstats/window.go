@@ -0,0 +1,101 @@
+package rollingstats
+
+import (
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+const (
+	statMean   = "mean"
+	statMin    = "min"
+	statMax    = "max"
+	statStddev = "stddev"
+)
+
+// parseStat returns the percentile of stats like p95, or -1 for the other stats.
+func parseStat(stat string) (float64, error) {
+	switch stat {
+	case statMean, statMin, statMax, statStddev:
+		return -1, nil
+	}
+	if p, ok := strings.CutPrefix(stat, "p"); ok {
+		if percentile, err := strconv.ParseFloat(p, 64); err == nil && percentile >= 0 && percentile <= 100 {
+			return percentile, nil
+		}
+	}
+	return 0, errors.New("stats must be mean, min, max, stddev or a percentile from p0 to p100, e.g. p95")
+}
+
+type sample struct {
+	time  time.Time
+	value float64
+}
+
+// window keeps the samples of one reading, at most maxSamples of them and none older than maxAge. A zero limit
+// doesn't apply.
+type window struct {
+	maxSamples int
+	maxAge     time.Duration
+	samples    []sample
+}
+
+func (w *window) add(now time.Time, value float64) {
+	w.samples = append(w.samples, sample{time: now, value: value})
+	w.trim(now)
+}
+
+// trim drops the samples that are outside the window at now. It's also done before computing the stats, so samples
+// age out while the sensor can't be read.
+func (w *window) trim(now time.Time) {
+	drop := 0
+	if w.maxSamples > 0 && len(w.samples) > w.maxSamples {
+		drop = len(w.samples) - w.maxSamples
+	}
+	if w.maxAge > 0 {
+		for drop < len(w.samples) && now.Sub(w.samples[drop].time) > w.maxAge {
+			drop++
+		}
+	}
+	if drop > 0 {
+		// Copying keeps the backing array from growing forever
+		w.samples = append(w.samples[:0], w.samples[drop:]...)
+	}
+}
+
+// compute returns each stat of the samples in the window at now, and how many samples there are. There are no stats
+// without samples.
+func (w *window) compute(now time.Time, stats []string) (map[string]float64, int) {
+	w.trim(now)
+	if len(w.samples) == 0 {
+		return nil, 0
+	}
+	values := make([]float64, len(w.samples))
+	for i, s := range w.samples {
+		values[i] = s.value
+	}
+	sort.Float64s(values)
+	mean, stddev := utils.MeanStddev(values)
+
+	ret := make(map[string]float64, len(stats))
+	for _, stat := range stats {
+		switch stat {
+		case statMean:
+			ret[stat] = mean
+		case statMin:
+			ret[stat] = values[0]
+		case statMax:
+			ret[stat] = values[len(values)-1]
+		case statStddev:
+			ret[stat] = stddev
+		default:
+			p, _ := parseStat(stat)
+			ret[stat] = utils.Percentile(values, p)
+		}
+	}
+	return ret, len(values)
+}
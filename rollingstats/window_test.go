@@ -0,0 +1,44 @@
+package rollingstats
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWindowStats(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	w := &window{maxSamples: 10}
+	// A one second spike that a slow capture rate would miss
+	for i, v := range []float64{40, 41, 40, 95, 41, 40, 42, 41, 40, 40, 41, 42} {
+		w.add(now.Add(time.Duration(i)*time.Second), v)
+	}
+	stats, count := w.compute(now.Add(11*time.Second), []string{"mean", "min", "max", "stddev", "p50", "p95"})
+	require.Equal(t, 10, count)
+	assert.InDelta(t, 46.2, stats["mean"], 1e-9)
+	assert.Equal(t, 40.0, stats["min"])
+	assert.Equal(t, 95.0, stats["max"])
+	assert.InDelta(t, 16.284, stats["stddev"], 0.001)
+	assert.Equal(t, 41.0, stats["p50"])
+	assert.InDelta(t, 71.15, stats["p95"], 1e-9)
+}
+
+func TestWindowAge(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	w := &window{maxAge: 10 * time.Second}
+	for i := 0; i < 20; i++ {
+		w.add(now.Add(time.Duration(i)*time.Second), float64(i))
+	}
+	stats, count := w.compute(now.Add(19*time.Second), []string{"min"})
+	assert.Equal(t, 11, count)
+	assert.Equal(t, 9.0, stats["min"])
+
+	// Samples age out while nothing is added
+	_, count = w.compute(now.Add(25*time.Second), nil)
+	assert.Equal(t, 5, count)
+	stats, count = w.compute(now.Add(time.Minute), []string{"mean"})
+	assert.Equal(t, 0, count)
+	assert.Empty(t, stats)
+}
@@ -0,0 +1,202 @@
+package selfmonitor
+
+import (
+	"context"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/shirou/gopsutil/v4/process"
+	"go.viam.com/rdk/components/sensor"
+	"go.viam.com/rdk/logging"
+	"go.viam.com/rdk/resource"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+var (
+	Model       = resource.NewModel(utils.Namespace, "hwmonitor", "self_monitor")
+	API         = sensor.API
+	PrettyName  = "Module Self Monitor"
+	Description = "A sensor that reports the resources the module itself uses and how long its components take to poll"
+	Version     = utils.Version
+)
+
+type Config struct {
+	resource.Named
+	mu         sync.Mutex
+	logger     logging.Logger
+	cancelCtx  context.Context
+	cancelFunc func()
+	proc       *process.Process
+	started    time.Time
+	cpu        cpuUsage
+}
+
+func init() {
+	resource.RegisterComponent(
+		API,
+		Model,
+		resource.Registration[sensor.Sensor, *ComponentConfig]{Constructor: utils.FilterReadings(NewSensor)})
+}
+
+func NewSensor(ctx context.Context, deps resource.Dependencies, conf resource.Config, logger logging.Logger) (sensor.Sensor, error) {
+	logger.Infof("Starting %s %s", PrettyName, Version)
+	cancelCtx, cancelFunc := context.WithCancel(context.Background())
+
+	proc, err := process.NewProcessWithContext(ctx, utils.SelfPid())
+	if err != nil {
+		cancelFunc()
+		return nil, err
+	}
+	b := Config{
+		Named:      conf.ResourceName().AsNamed(),
+		logger:     logger,
+		cancelCtx:  cancelCtx,
+		cancelFunc: cancelFunc,
+		proc:       proc,
+		started:    time.Now(),
+	}
+
+	if err := b.Reconfigure(ctx, deps, conf); err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
+
+func (c *Config) Reconfigure(ctx context.Context, _ resource.Dependencies, conf resource.Config) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.logger.Debugf("Reconfiguring %s", PrettyName)
+
+	// In case the module has changed name
+	c.Named = conf.ResourceName().AsNamed()
+
+	return nil
+}
+
+func (c *Config) Readings(ctx context.Context, extra map[string]interface{}) (map[string]interface{}, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	ret := make(map[string]interface{})
+	errs := make(utils.ReadingErrors)
+	ret["uptime_sec"] = utils.RoundValue(time.Since(c.started).Seconds(), 0)
+
+	if times, err := c.proc.TimesWithContext(ctx); err == nil {
+		// The CPU usage is over the time since the last reading, there's nothing to compare the first reading with
+		if percent, ok := c.cpu.update(times.User+times.System, time.Now()); ok {
+			ret["cpu_percent"] = utils.RoundValue(percent, 2)
+			ret["cpu_board_percent"] = utils.RoundValue(percent/float64(runtime.NumCPU()), 2)
+		}
+	} else {
+		errs.Add("cpu", err)
+	}
+	if mem, err := c.proc.MemoryInfoWithContext(ctx); err == nil {
+		ret["rss_bytes"] = mem.RSS
+	} else {
+		errs.Add("rss", err)
+	}
+	if fds, err := c.proc.NumFDsWithContext(ctx); err == nil {
+		ret["open_fds"] = fds
+	} else {
+		errs.Add("open_fds", err)
+	}
+	if threads, err := c.proc.NumThreadsWithContext(ctx); err == nil {
+		ret["threads"] = threads
+	} else {
+		errs.Add("threads", err)
+	}
+
+	addRuntimeReadings(ret)
+	addHostEnvironment(ret, utils.DetectHostEnvironment())
+	for name, stats := range utils.ComponentPollStats() {
+		addPollStats(ret, "component_"+name, "poll", stats)
+	}
+	health := utils.CommandHealth()
+	for name, stats := range utils.CommandPollStats() {
+		addPollStats(ret, "command_"+name, "run", stats)
+		addCommandHealth(ret, "command_"+name, health[name], time.Now())
+	}
+	return errs.Readings(ret)
+}
+
+// addRuntimeReadings adds the goroutines, heap and garbage collections of the Go runtime.
+func addRuntimeReadings(ret map[string]interface{}) {
+	var stats runtime.MemStats
+	runtime.ReadMemStats(&stats)
+	ret["goroutines"] = runtime.NumGoroutine()
+	ret["heap_bytes"] = stats.HeapAlloc
+	ret["go_sys_bytes"] = stats.Sys
+	ret["gc_count"] = stats.NumGC
+	ret["gc_pause_total_ms"] = utils.RoundValue(float64(stats.PauseTotalNs)/float64(time.Millisecond), 3)
+	if stats.NumGC == 0 {
+		return
+	}
+	// PauseNs is a ring of the most recent pauses
+	last := stats.PauseNs[(stats.NumGC+255)%256]
+	var longest uint64
+	for _, pause := range stats.PauseNs[:min(stats.NumGC, 256)] {
+		longest = max(longest, pause)
+	}
+	ret["gc_last_pause_ms"] = utils.RoundValue(float64(last)/float64(time.Millisecond), 3)
+	ret["gc_max_recent_pause_ms"] = utils.RoundValue(float64(longest)/float64(time.Millisecond), 3)
+}
+
+// addHostEnvironment adds whether the module runs in a container, what it reads the host's files from and why its
+// readings aren't the host's.
+func addHostEnvironment(ret map[string]interface{}, env utils.HostEnvironment) {
+	ret["in_container"] = env.InContainer
+	if env.Root != "" {
+		ret["host_root"] = env.Root
+	}
+	if len(env.Warnings) > 0 {
+		ret["host_warning"] = strings.Join(env.Warnings, "; ")
+	}
+}
+
+// addPollStats adds the stats of a component's polls or a command's runs, e.g. component_cpu_polls or
+// command_vcgencmd_avg_run_ms.
+func addPollStats(ret map[string]interface{}, prefix, verb string, stats utils.PollStats) {
+	ret[prefix+"_"+verb+"s"] = stats.Count
+	ret[prefix+"_errors"] = stats.Errors
+	ret[prefix+"_error_percent"] = utils.RoundValue(stats.ErrorPercent(), 2)
+	ret[prefix+"_avg_"+verb+"_ms"] = utils.RoundValue(float64(stats.Average())/float64(time.Millisecond), 3)
+	ret[prefix+"_max_"+verb+"_ms"] = utils.RoundValue(float64(stats.Max)/float64(time.Millisecond), 3)
+	ret[prefix+"_last_"+verb+"_ms"] = utils.RoundValue(float64(stats.Last)/float64(time.Millisecond), 3)
+}
+
+// addCommandHealth adds whether a command is failing, e.g. command_vcgencmd_degraded, and when a degraded one is
+// retried.
+func addCommandHealth(ret map[string]interface{}, prefix string, health utils.BackendHealth, now time.Time) {
+	ret[prefix+"_consecutive_failures"] = health.ConsecutiveFailures
+	ret[prefix+"_degraded"] = health.Degraded
+	if health.Degraded {
+		ret[prefix+"_retry_in_sec"] = utils.RoundValue(max(health.RetryAt.Sub(now).Seconds(), 0), 1)
+	}
+}
+
+func (c *Config) Close(ctx context.Context) error {
+	c.logger.Infof("Shutting down %s", PrettyName)
+	c.cancelFunc()
+	return nil
+}
+
+func (c *Config) Ready(ctx context.Context, extra map[string]interface{}) (bool, error) {
+	return false, nil
+}
+
+// cpuUsage computes the module's CPU usage from its cumulative CPU time, as a percentage of one core like top.
+type cpuUsage struct {
+	lastSeconds float64
+	lastTime    time.Time
+}
+
+func (u *cpuUsage) update(seconds float64, now time.Time) (float64, bool) {
+	lastSeconds, lastTime := u.lastSeconds, u.lastTime
+	u.lastSeconds, u.lastTime = seconds, now
+	if lastTime.IsZero() || !now.After(lastTime) || seconds < lastSeconds {
+		return 0, false
+	}
+	return (seconds - lastSeconds) / now.Sub(lastTime).Seconds() * 100, true
+}
@@ -0,0 +1,69 @@
+package selfmonitor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+func TestCPUUsage(t *testing.T) {
+	var usage cpuUsage
+	now := time.Now()
+	_, ok := usage.update(10, now)
+	assert.False(t, ok)
+
+	// Half a second of CPU time over two seconds is a quarter of a core
+	percent, ok := usage.update(10.5, now.Add(2*time.Second))
+	assert.True(t, ok)
+	assert.InDelta(t, 25, percent, 0.001)
+
+	// Going back, e.g. after the counters were reset, starts over
+	_, ok = usage.update(1, now.Add(3*time.Second))
+	assert.False(t, ok)
+}
+
+func TestAddPollStats(t *testing.T) {
+	ret := make(map[string]interface{})
+	addPollStats(ret, "command_vcgencmd", "run", utils.PollStats{
+		Count:  4,
+		Errors: 1,
+		Total:  40 * time.Millisecond,
+		Max:    25 * time.Millisecond,
+		Last:   5 * time.Millisecond,
+	})
+	assert.Equal(t, map[string]interface{}{
+		"command_vcgencmd_runs":          uint64(4),
+		"command_vcgencmd_errors":        uint64(1),
+		"command_vcgencmd_error_percent": 25.0,
+		"command_vcgencmd_avg_run_ms":    10.0,
+		"command_vcgencmd_max_run_ms":    25.0,
+		"command_vcgencmd_last_run_ms":   5.0,
+	}, ret)
+}
+
+func TestAddCommandHealth(t *testing.T) {
+	now := time.Now()
+	ret := make(map[string]interface{})
+	addCommandHealth(ret, "command_iw", utils.BackendHealth{}, now)
+	assert.Equal(t, map[string]interface{}{
+		"command_iw_consecutive_failures": 0,
+		"command_iw_degraded":             false,
+	}, ret)
+
+	addCommandHealth(ret, "command_vcgencmd", utils.BackendHealth{ConsecutiveFailures: 5, Degraded: true, RetryAt: now.Add(4 * time.Second)}, now)
+	assert.Equal(t, true, ret["command_vcgencmd_degraded"])
+	assert.Equal(t, 4.0, ret["command_vcgencmd_retry_in_sec"])
+}
+
+func TestAddHostEnvironment(t *testing.T) {
+	ret := map[string]interface{}{}
+	addHostEnvironment(ret, utils.HostEnvironment{})
+	assert.Equal(t, map[string]interface{}{"in_container": false}, ret)
+
+	ret = map[string]interface{}{}
+	addHostEnvironment(ret, utils.HostEnvironment{Root: "/host", InContainer: true, Warnings: []string{"first", "second"}})
+	assert.Equal(t, map[string]interface{}{"in_container": true, "host_root": "/host", "host_warning": "first; second"}, ret)
+}
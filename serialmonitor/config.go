@@ -0,0 +1,46 @@
+package serialmonitor
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+)
+
+type ComponentConfig struct {
+	Expected []ExpectedDevice `json:"expected,omitempty"`
+}
+
+// ExpectedDevice is a serial port or SPI device that should always be present, either a fixed device node or a USB
+// serial adapter found by its IDs wherever it enumerates.
+type ExpectedDevice struct {
+	Name      string `json:"name"`                 // Used as the reading key
+	Device    string `json:"device,omitempty"`     // e.g. /dev/ttyAMA0, /dev/spidev0.0 or a /dev/serial/by-id link
+	VendorID  string `json:"vendor_id,omitempty"`  // As lsusb shows it, e.g. 10c4
+	ProductID string `json:"product_id,omitempty"` // As lsusb shows it, e.g. ea60
+	Serial    string `json:"serial,omitempty"`     // Distinguishes identical adapters
+}
+
+var usbIDRegex = regexp.MustCompile(`^[0-9a-f]{4}$`)
+
+func (conf *ComponentConfig) Validate(path string) ([]string, error) {
+	names := make(map[string]bool)
+	for _, device := range conf.Expected {
+		if device.Name == "" {
+			return nil, errors.New("every expected device requires a name")
+		}
+		if names[device.Name] {
+			return nil, fmt.Errorf("duplicate expected device name: %s", device.Name)
+		}
+		names[device.Name] = true
+		if device.Device != "" {
+			if device.VendorID != "" || device.ProductID != "" || device.Serial != "" {
+				return nil, fmt.Errorf("expected device %s requires either device or vendor_id and product_id, not both", device.Name)
+			}
+			continue
+		}
+		if !usbIDRegex.MatchString(device.VendorID) || !usbIDRegex.MatchString(device.ProductID) {
+			return nil, fmt.Errorf("expected device %s requires a device, or vendor_id and product_id as 4 lowercase hex digits, e.g. 10c4", device.Name)
+		}
+	}
+	return nil, nil
+}
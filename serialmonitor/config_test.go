@@ -0,0 +1,30 @@
+package serialmonitor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidate(t *testing.T) {
+	conf := &ComponentConfig{Expected: []ExpectedDevice{
+		{Name: "lidar", VendorID: "10c4", ProductID: "ea60", Serial: "0001"},
+		{Name: "imu", Device: "/dev/spidev0.0"},
+	}}
+	_, err := conf.Validate("")
+	assert.NoError(t, err)
+
+	for _, device := range []ExpectedDevice{
+		{Device: "/dev/ttyAMA0"},
+		{Name: "gps"},
+		{Name: "gps", VendorID: "1546"},
+		{Name: "gps", VendorID: "1546", ProductID: "01A8"},
+		{Name: "gps", Device: "/dev/ttyACM0", VendorID: "1546", ProductID: "01a8"},
+	} {
+		_, err := (&ComponentConfig{Expected: []ExpectedDevice{device}}).Validate("")
+		assert.Error(t, err, device)
+	}
+
+	_, err = (&ComponentConfig{Expected: []ExpectedDevice{{Name: "a", Device: "/dev/ttyS0"}, {Name: "a", Device: "/dev/ttyS1"}}}).Validate("")
+	assert.Error(t, err)
+}
@@ -0,0 +1,180 @@
+package serialmonitor
+
+import (
+	"context"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"go.viam.com/rdk/components/sensor"
+	"go.viam.com/rdk/logging"
+	"go.viam.com/rdk/resource"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/linux"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+var (
+	Model       = resource.NewModel(utils.Namespace, "hwmonitor", "serial_monitor")
+	API         = sensor.API
+	PrettyName  = "Serial and SPI Device Monitor"
+	Description = "A sensor that reports the serial ports and SPI devices, the USB serial adapters behind them, and whether the expected devices are present"
+	Version     = utils.Version
+)
+
+const devRoot = "/dev"
+
+type Config struct {
+	resource.Named
+	mu         sync.RWMutex
+	logger     logging.Logger
+	cancelCtx  context.Context
+	cancelFunc func()
+	expected   []ExpectedDevice
+	// devices is the node each expected device was last found at, so renumbering can be counted
+	devices       map[string]string
+	deviceChanges map[string]int
+	// missing tracks which expected devices are logged as missing, so each change is only logged once
+	missing map[string]bool
+}
+
+func init() {
+	resource.RegisterComponent(
+		API,
+		Model,
+		resource.Registration[sensor.Sensor, *ComponentConfig]{Constructor: utils.FilterReadings(NewSensor)})
+	utils.RegisterSimulator(Model, simulate)
+}
+
+func NewSensor(ctx context.Context, deps resource.Dependencies, conf resource.Config, logger logging.Logger) (sensor.Sensor, error) {
+	logger.Infof("Starting %s %s", PrettyName, Version)
+	cancelCtx, cancelFunc := context.WithCancel(context.Background())
+
+	b := Config{
+		Named:      conf.ResourceName().AsNamed(),
+		logger:     logger,
+		cancelCtx:  cancelCtx,
+		cancelFunc: cancelFunc,
+		mu:         sync.RWMutex{},
+	}
+
+	if err := b.Reconfigure(ctx, deps, conf); err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
+
+func (c *Config) Reconfigure(ctx context.Context, _ resource.Dependencies, rawConf resource.Config) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.logger.Debugf("Reconfiguring %s", PrettyName)
+
+	conf, err := resource.NativeConfig[*ComponentConfig](rawConf)
+	if err != nil {
+		return err
+	}
+
+	// In case the module has changed name
+	c.Named = rawConf.ResourceName().AsNamed()
+	c.expected = conf.Expected
+	// The device changes are counted across reconfigures
+	if c.devices == nil {
+		c.devices = make(map[string]string)
+		c.deviceChanges = make(map[string]int)
+	}
+	c.missing = make(map[string]bool)
+
+	return nil
+}
+
+func (c *Config) Readings(ctx context.Context, extra map[string]interface{}) (map[string]interface{}, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	serialDevices, err := linux.ReadSerialDevices(ctx, linux.TTYClassRoot, devRoot)
+	if err != nil {
+		return nil, err
+	}
+	spiDevices, err := linux.ListSPIDevices(devRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	ret := make(map[string]interface{})
+	names := make([]string, 0, len(serialDevices))
+	for _, device := range serialDevices {
+		names = append(names, device.Name)
+		ret[device.Name+"_driver"] = device.Driver
+		if device.USB != nil {
+			ret[device.Name+"_usb_id"] = device.USB.ID()
+			ret[device.Name+"_usb_path"] = device.USB.Path
+			ret[device.Name+"_usb_serial"] = device.USB.Serial
+			ret[device.Name+"_usb_product"] = device.USB.Product
+		}
+	}
+	ret["serial_count"] = len(serialDevices)
+	ret["serial_devices"] = strings.Join(names, ",")
+	ret["spi_count"] = len(spiDevices)
+	ret["spi_devices"] = strings.Join(spiDevices, ",")
+	if len(c.expected) == 0 {
+		return ret, nil
+	}
+
+	missing := make([]string, 0)
+	for _, expected := range c.expected {
+		device := findDevice(serialDevices, expected)
+		present := device != ""
+		ret[expected.Name+"_present"] = present
+		if present {
+			ret[expected.Name+"_device"] = device
+			if last, ok := c.devices[expected.Name]; ok && last != device {
+				c.logger.Warnf("Expected device %s moved from %s to %s", expected.Name, last, device)
+				c.deviceChanges[expected.Name]++
+			}
+			c.devices[expected.Name] = device
+		} else {
+			missing = append(missing, expected.Name)
+		}
+		ret[expected.Name+"_device_changes"] = c.deviceChanges[expected.Name]
+		if !present && !c.missing[expected.Name] {
+			c.logger.Warnf("Expected device %s is missing", expected.Name)
+		}
+		c.missing[expected.Name] = !present
+	}
+	sort.Strings(missing)
+	ret["missing_count"] = len(missing)
+	ret["missing"] = strings.Join(missing, ",")
+	return ret, nil
+}
+
+// findDevice returns the node an expected device is currently at, or an empty string when it's missing. Links such as
+// /dev/serial/by-id are resolved, so a renumbered adapter is reported at its new node.
+func findDevice(devices []linux.SerialDevice, expected ExpectedDevice) string {
+	if expected.Device != "" {
+		device, err := filepath.EvalSymlinks(expected.Device)
+		if err != nil {
+			return ""
+		}
+		return device
+	}
+	for _, d := range devices {
+		if d.USB == nil || d.USB.VendorID != expected.VendorID || d.USB.ProductID != expected.ProductID {
+			continue
+		}
+		if expected.Serial != "" && d.USB.Serial != expected.Serial {
+			continue
+		}
+		return d.Device
+	}
+	return ""
+}
+
+func (c *Config) Close(ctx context.Context) error {
+	c.logger.Infof("Shutting down %s", PrettyName)
+	c.cancelFunc()
+	return nil
+}
+
+func (c *Config) Ready(ctx context.Context, extra map[string]interface{}) (bool, error) {
+	return false, nil
+}
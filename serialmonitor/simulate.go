@@ -0,0 +1,84 @@
+package serialmonitor
+
+import (
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/linux"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+// simulatedDevices are the debug UART of a Raspberry Pi 5 and a CP2102 USB serial adapter.
+var simulatedDevices = []linux.SerialDevice{
+	{Name: "ttyAMA10", Device: "/dev/ttyAMA10", Driver: "uart-pl011"},
+	{Name: "ttyUSB0", Device: "/dev/ttyUSB0", Driver: "cp210x", USB: &linux.USBDevice{
+		Path:         "3-1",
+		Bus:          3,
+		VendorID:     "10c4",
+		ProductID:    "ea60",
+		Manufacturer: "Silicon Labs",
+		Product:      "CP2102 USB to UART Bridge Controller",
+		Serial:       "0001",
+		Speed:        12,
+	}},
+}
+
+var simulatedSPIDevices = []string{"spidev0.0", "spidev0.1"}
+
+// simulate returns the simulated devices, and which of the expected devices are among them.
+func simulate(sim *utils.Simulation) map[string]interface{} {
+	ret := make(map[string]interface{})
+	names := make([]string, 0, len(simulatedDevices))
+	for _, device := range simulatedDevices {
+		names = append(names, device.Name)
+		ret[device.Name+"_driver"] = device.Driver
+		if device.USB != nil {
+			ret[device.Name+"_usb_id"] = device.USB.ID()
+			ret[device.Name+"_usb_path"] = device.USB.Path
+			ret[device.Name+"_usb_serial"] = device.USB.Serial
+			ret[device.Name+"_usb_product"] = device.USB.Product
+		}
+	}
+	ret["serial_count"] = len(simulatedDevices)
+	ret["serial_devices"] = strings.Join(names, ",")
+	ret["spi_count"] = len(simulatedSPIDevices)
+	ret["spi_devices"] = strings.Join(simulatedSPIDevices, ",")
+	conf, ok := sim.Config().(*ComponentConfig)
+	if !ok || len(conf.Expected) == 0 {
+		return ret
+	}
+
+	missing := make([]string, 0)
+	for _, expected := range conf.Expected {
+		device := ""
+		if expected.Device != "" {
+			if simulatedNode(expected.Device) {
+				device = expected.Device
+			}
+		} else {
+			device = findDevice(simulatedDevices, expected)
+		}
+		ret[expected.Name+"_present"] = device != ""
+		if device != "" {
+			ret[expected.Name+"_device"] = device
+		} else {
+			missing = append(missing, expected.Name)
+		}
+		ret[expected.Name+"_device_changes"] = 0
+	}
+	sort.Strings(missing)
+	ret["missing_count"] = len(missing)
+	ret["missing"] = strings.Join(missing, ",")
+	return ret
+}
+
+// simulatedNode is whether the device node is one of the simulated serial ports or SPI devices.
+func simulatedNode(node string) bool {
+	for _, device := range simulatedDevices {
+		if device.Device == node {
+			return true
+		}
+	}
+	return slices.Contains(simulatedSPIDevices, strings.TrimPrefix(node, "/dev/"))
+}
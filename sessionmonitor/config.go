@@ -0,0 +1,9 @@
+package sessionmonitor
+
+type ComponentConfig struct {
+	SSHUnits []string `json:"ssh_units"` // systemd units sshd runs as, defaults to ssh.service and sshd.service
+}
+
+func (conf *ComponentConfig) Validate(path string) ([]string, error) {
+	return nil, nil
+}
@@ -0,0 +1,185 @@
+package sessionmonitor
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/shirou/gopsutil/v4/host"
+	"go.viam.com/rdk/components/sensor"
+	"go.viam.com/rdk/logging"
+	"go.viam.com/rdk/resource"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/linux"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+var (
+	Model       = resource.NewModel(utils.Namespace, "hwmonitor", "session_monitor")
+	API         = sensor.API
+	PrettyName  = "SBC Session Monitor"
+	Description = "A sensor that reports logged in users and SSH authentication attempts"
+	Version     = utils.Version
+)
+
+// Debian based distributions name the unit ssh.service, most others sshd.service
+var defaultSSHUnits = []string{"ssh.service", "sshd.service"}
+
+type Config struct {
+	resource.Named
+	mu           sync.RWMutex
+	logger       logging.Logger
+	cancelCtx    context.Context
+	cancelFunc   func()
+	sshUnits     []string
+	cursor       string
+	counts       map[linux.SSHAuthResult]int
+	lastFailed   *linux.SSHAuthEvent
+	lastFailedAt time.Time
+	lastLogin    *linux.SSHAuthEvent
+	lastLoginAt  time.Time
+}
+
+func init() {
+	resource.RegisterComponent(
+		API,
+		Model,
+		resource.Registration[sensor.Sensor, *ComponentConfig]{Constructor: utils.FilterReadings(NewSensor)})
+	utils.RegisterSimulator(Model, simulate)
+}
+
+func NewSensor(ctx context.Context, deps resource.Dependencies, conf resource.Config, logger logging.Logger) (sensor.Sensor, error) {
+	logger.Infof("Starting %s %s", PrettyName, Version)
+	cancelCtx, cancelFunc := context.WithCancel(context.Background())
+
+	b := Config{
+		Named:      conf.ResourceName().AsNamed(),
+		logger:     logger,
+		cancelCtx:  cancelCtx,
+		cancelFunc: cancelFunc,
+		mu:         sync.RWMutex{},
+	}
+
+	if err := b.Reconfigure(ctx, deps, conf); err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
+
+func (c *Config) Reconfigure(ctx context.Context, _ resource.Dependencies, conf resource.Config) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.logger.Debugf("Reconfiguring %s", PrettyName)
+
+	newConf, err := resource.NativeConfig[*ComponentConfig](conf)
+	if err != nil {
+		return err
+	}
+	c.sshUnits = newConf.SSHUnits
+	if len(c.sshUnits) == 0 {
+		c.sshUnits = defaultSSHUnits
+	}
+
+	// Only attempts made after the sensor started are counted, a reconfigure keeps counting from where it was
+	if c.counts == nil {
+		cursor, err := linux.JournalCursor(ctx)
+		if err != nil {
+			return err
+		}
+		c.cursor = cursor
+		c.counts = make(map[linux.SSHAuthResult]int)
+	}
+
+	// In case the module has changed name
+	c.Named = conf.ResourceName().AsNamed()
+
+	return nil
+}
+
+func (c *Config) Readings(ctx context.Context, extra map[string]interface{}) (map[string]interface{}, error) {
+	// The cursor moves on every reading, so this needs the write lock
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	ret := make(map[string]interface{})
+
+	sessions, err := host.UsersWithContext(ctx)
+	if err != nil {
+		// Some distributions no longer write utmp, keep reporting the SSH counters
+		c.logger.Debugf("Failed to read logged in users: %v", err)
+	} else {
+		users := make(map[string]bool)
+		hosts := make(map[string]bool)
+		remote := 0
+		for _, session := range sessions {
+			users[session.User] = true
+			if session.Host != "" {
+				remote++
+				hosts[session.Host] = true
+			}
+		}
+		ret["session_count"] = len(sessions)
+		ret["remote_session_count"] = remote
+		ret["users"] = joinKeys(users)
+		ret["remote_hosts"] = joinKeys(hosts)
+	}
+
+	// sshd logs authentication attempts at info priority
+	entries, err := linux.ReadJournal(ctx, c.cursor, "info", c.sshUnits)
+	if err != nil {
+		return nil, err
+	}
+	if len(entries) > 0 {
+		c.cursor = entries[len(entries)-1].Cursor
+	}
+	for _, entry := range entries {
+		event, ok := linux.ParseSSHAuthEvent(entry.Message)
+		if !ok {
+			continue
+		}
+		c.counts[event.Result]++
+		switch event.Result {
+		case linux.SSHAuthAccepted:
+			c.lastLogin = event
+			c.lastLoginAt = entry.Time
+		case linux.SSHAuthFailed, linux.SSHAuthInvalidUser:
+			c.lastFailed = event
+			c.lastFailedAt = entry.Time
+		}
+	}
+
+	ret["ssh_accepted_count"] = c.counts[linux.SSHAuthAccepted]
+	ret["ssh_failed_count"] = c.counts[linux.SSHAuthFailed]
+	ret["ssh_invalid_user_count"] = c.counts[linux.SSHAuthInvalidUser]
+	if c.lastLogin != nil {
+		ret["ssh_last_login_user"] = c.lastLogin.User
+		ret["ssh_last_login_address"] = c.lastLogin.Address
+		ret["ssh_last_login_method"] = c.lastLogin.Method
+		ret["ssh_last_login_time"] = c.lastLoginAt.Format(time.RFC3339)
+	}
+	if c.lastFailed != nil {
+		ret["ssh_last_failed_user"] = c.lastFailed.User
+		ret["ssh_last_failed_address"] = c.lastFailed.Address
+		ret["ssh_last_failed_time"] = c.lastFailedAt.Format(time.RFC3339)
+	}
+	return ret, nil
+}
+
+func joinKeys(set map[string]bool) string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return strings.Join(keys, ",")
+}
+
+func (c *Config) Close(ctx context.Context) error {
+	c.logger.Infof("Shutting down %s", PrettyName)
+	return nil
+}
+
+func (c *Config) Ready(ctx context.Context, extra map[string]interface{}) (bool, error) {
+	return false, nil
+}
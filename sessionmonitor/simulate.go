@@ -0,0 +1,16 @@
+package sessionmonitor
+
+import "github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+
+// simulate returns a board nobody is logged in to and that sshd hasn't seen any attempts on.
+func simulate(sim *utils.Simulation) map[string]interface{} {
+	return map[string]interface{}{
+		"session_count":          0,
+		"remote_session_count":   0,
+		"users":                  "",
+		"remote_hosts":           "",
+		"ssh_accepted_count":     0,
+		"ssh_failed_count":       0,
+		"ssh_invalid_user_count": 0,
+	}
+}
@@ -0,0 +1,105 @@
+package snmpagent
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"regexp"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/snmp"
+)
+
+const (
+	typeInteger = "integer"
+	typeGauge   = "gauge"
+	typeCounter = "counter"
+	typeString  = "string"
+)
+
+var (
+	enterprisesOID = snmp.OID{1, 3, 6, 1, 4, 1}
+	mibNamePattern = regexp.MustCompile(`^[a-z][A-Za-z0-9]*$`)
+)
+
+type Metric struct {
+	Sensor string  `json:"sensor"`
+	Key    string  `json:"key"`
+	Index  int     `json:"index"`           // The metric's OID is <base_oid>.1.<index>.0, it shouldn't change once a manager uses it
+	Type   string  `json:"type,omitempty"`  // integer, gauge, counter or string, defaults to integer for numbers and string for strings
+	Scale  float64 `json:"scale,omitempty"` // Numbers are multiplied by this before being rounded, e.g. 10 for tenths, defaults to 1
+	Name   string  `json:"name,omitempty"`  // The object's name in the MIB, derived from the sensor and key by default
+}
+
+type ComponentConfig struct {
+	Listen      string   `json:"listen,omitempty"`   // Defaults to :161
+	Community   string   `json:"community"`          // The read only community
+	BaseOID     string   `json:"base_oid,omitempty"` // Must be under enterprises, defaults to 1.3.6.1.4.1.8072.9999.9999
+	Contact     string   `json:"contact,omitempty"`  // sysContact
+	Location    string   `json:"location,omitempty"` // sysLocation
+	IntervalSec float64  `json:"interval_sec,omitempty"`
+	Metrics     []Metric `json:"metrics"`
+}
+
+func (conf *ComponentConfig) Validate(path string) ([]string, error) {
+	if conf.Listen != "" {
+		if _, _, err := net.SplitHostPort(conf.Listen); err != nil {
+			return nil, errors.New("listen must be host:port")
+		}
+	}
+	if conf.Community == "" {
+		return nil, errors.New("community is required")
+	}
+	if conf.BaseOID != "" {
+		oid, err := snmp.ParseOID(conf.BaseOID)
+		if err != nil {
+			return nil, err
+		}
+		if !oid.HasPrefix(enterprisesOID) || len(oid) == len(enterprisesOID) {
+			return nil, fmt.Errorf("base_oid must be under enterprises (%s)", enterprisesOID)
+		}
+	}
+	if conf.IntervalSec < 0 {
+		return nil, errors.New("interval_sec must not be negative")
+	}
+	if len(conf.Metrics) == 0 {
+		return nil, errors.New("at least one metric is required")
+	}
+
+	indexes := make(map[int]bool)
+	names := make(map[string]bool)
+	var deps []string
+	seen := make(map[string]bool)
+	for _, m := range conf.Metrics {
+		if m.Sensor == "" || m.Key == "" {
+			return nil, errors.New("every metric needs a sensor and key")
+		}
+		if m.Index <= 0 {
+			return nil, fmt.Errorf("index of %s %s must be positive", m.Sensor, m.Key)
+		}
+		if indexes[m.Index] {
+			return nil, fmt.Errorf("index %d is used more than once", m.Index)
+		}
+		indexes[m.Index] = true
+		switch m.Type {
+		case "", typeInteger, typeGauge, typeCounter, typeString:
+		default:
+			return nil, fmt.Errorf("type of %s %s must be %s, %s, %s or %s", m.Sensor, m.Key, typeInteger, typeGauge, typeCounter, typeString)
+		}
+		if m.Scale < 0 {
+			return nil, fmt.Errorf("scale of %s %s must not be negative", m.Sensor, m.Key)
+		}
+		if m.Name != "" && !mibNamePattern.MatchString(m.Name) {
+			return nil, fmt.Errorf("name %s must start with a lowercase letter and contain only letters and digits", m.Name)
+		}
+		name := mibName(m)
+		if names[name] {
+			return nil, fmt.Errorf("MIB name %s is used more than once, set name on one of them", name)
+		}
+		names[name] = true
+		if !seen[m.Sensor] {
+			seen[m.Sensor] = true
+			deps = append(deps, m.Sensor)
+		}
+	}
+	return deps, nil
+}
@@ -0,0 +1,46 @@
+package snmpagent
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidate(t *testing.T) {
+	conf := &ComponentConfig{
+		Community: "robots",
+		BaseOID:   "1.3.6.1.4.1.99999",
+		Metrics: []Metric{
+			{Sensor: "temperatures", Key: "cpu", Index: 1, Scale: 10},
+			{Sensor: "cpu_monitor", Key: "usage", Index: 2, Type: typeGauge},
+			{Sensor: "temperatures", Key: "gpu", Index: 3},
+		},
+	}
+	deps, err := conf.Validate("")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"temperatures", "cpu_monitor"}, deps)
+
+	metric := []Metric{{Sensor: "cpu_monitor", Key: "usage", Index: 1}}
+	for _, conf := range []*ComponentConfig{
+		{Metrics: metric},
+		{Community: "robots"},
+		{Community: "robots", Listen: "161", Metrics: metric},
+		{Community: "robots", BaseOID: "1.3.6.1.2.1", Metrics: metric},
+		{Community: "robots", BaseOID: "1.3.6.1.4.1", Metrics: metric},
+		{Community: "robots", Metrics: []Metric{{Sensor: "cpu_monitor", Index: 1}}},
+		{Community: "robots", Metrics: []Metric{{Sensor: "cpu_monitor", Key: "usage"}}},
+		{Community: "robots", Metrics: []Metric{{Sensor: "cpu_monitor", Key: "usage", Index: 1, Type: "float"}}},
+		{Community: "robots", Metrics: []Metric{{Sensor: "cpu_monitor", Key: "usage", Index: 1, Name: "CPU usage"}}},
+		{Community: "robots", Metrics: []Metric{
+			{Sensor: "cpu_monitor", Key: "usage", Index: 1},
+			{Sensor: "cpu_monitor", Key: "usage", Index: 2},
+		}},
+		{Community: "robots", Metrics: []Metric{
+			{Sensor: "cpu_monitor", Key: "usage", Index: 1},
+			{Sensor: "cpu_monitor", Key: "load", Index: 1},
+		}},
+	} {
+		_, err := conf.Validate("")
+		assert.Error(t, err, conf)
+	}
+}
@@ -0,0 +1,161 @@
+package snmpagent
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/snmp"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+// The MIB-2 system group, which managers read to identify a device
+var (
+	sysDescr    = snmp.OID{1, 3, 6, 1, 2, 1, 1, 1, 0}
+	sysObjectID = snmp.OID{1, 3, 6, 1, 2, 1, 1, 2, 0}
+	sysUpTime   = snmp.OID{1, 3, 6, 1, 2, 1, 1, 3, 0}
+	sysContact  = snmp.OID{1, 3, 6, 1, 2, 1, 1, 4, 0}
+	sysName     = snmp.OID{1, 3, 6, 1, 2, 1, 1, 5, 0}
+	sysLocation = snmp.OID{1, 3, 6, 1, 2, 1, 1, 6, 0}
+)
+
+// metricOID is <base>.1.<index>.0, the instance of the metric's scalar object.
+func metricOID(base snmp.OID, m Metric) snmp.OID {
+	return base.Append(1, uint32(m.Index), 0)
+}
+
+// mibName is the metric's object name in the MIB, e.g. cpuMonitorUsage for cpu_monitor usage.
+func mibName(m Metric) string {
+	if m.Name != "" {
+		return m.Name
+	}
+	var name strings.Builder
+	words := strings.FieldsFunc(m.Sensor+"_"+m.Key, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) || r > unicode.MaxASCII
+	})
+	for i, word := range words {
+		if i == 0 {
+			name.WriteString(strings.ToLower(word[:1]) + word[1:])
+		} else {
+			name.WriteString(strings.ToUpper(word[:1]) + word[1:])
+		}
+	}
+	ret := name.String()
+	switch {
+	case ret == "":
+		return "hwmonitorMetric" + strconv.Itoa(m.Index)
+	case ret[0] < 'a' || ret[0] > 'z':
+		// Names must start with a lowercase letter
+		return "hwmonitor" + strings.ToUpper(ret[:1]) + ret[1:]
+	default:
+		return ret
+	}
+}
+
+func scale(m Metric) float64 {
+	if m.Scale == 0 {
+		return 1
+	}
+	return m.Scale
+}
+
+// toValue converts a reading to the metric's SNMP type, ok is false when the reading can't be converted.
+func toValue(m Metric, readings map[string]interface{}) (interface{}, bool) {
+	reading, ok := readings[m.Key]
+	if !ok {
+		// Nested readings are addressed by their flattened key
+		number, ok := utils.NumericReadings(readings)[m.Key]
+		if !ok {
+			return nil, false
+		}
+		reading = number
+	}
+	if b, ok := reading.(bool); ok {
+		reading = 0
+		if b {
+			reading = 1
+		}
+	}
+
+	if s, ok := reading.(string); ok {
+		if m.Type == "" || m.Type == typeString {
+			return s, true
+		}
+		return nil, false
+	}
+	number, ok := utils.ToFloat64(reading)
+	if !ok || math.IsNaN(number) || math.IsInf(number, 0) {
+		return nil, false
+	}
+	number = math.Round(number * scale(m))
+	switch m.Type {
+	case typeString:
+		return strconv.FormatFloat(number, 'f', -1, 64), true
+	case typeGauge:
+		return snmp.Gauge32(math.Min(math.Max(number, 0), math.MaxUint32)), true
+	case typeCounter:
+		if number < 0 {
+			return nil, false
+		}
+		if number >= math.MaxUint64 {
+			return snmp.Counter64(math.MaxUint64), true
+		}
+		return snmp.Counter64(number), true
+	default:
+		return int(math.Min(math.Max(number, math.MinInt32), math.MaxInt32)), true
+	}
+}
+
+func mibSyntax(m Metric) string {
+	switch m.Type {
+	case typeGauge:
+		return "Gauge32"
+	case typeCounter:
+		return "Counter64"
+	case typeString:
+		return "OCTET STRING"
+	default:
+		return "Integer32"
+	}
+}
+
+// generateMIB writes the SMIv2 module describing the metrics, for loading into a manager.
+func generateMIB(base snmp.OID, metrics []Metric) string {
+	var mib strings.Builder
+	arcs := strings.ReplaceAll(base[len(enterprisesOID):].String(), ".", " ")
+	fmt.Fprintf(&mib, `HWMONITOR-MIB DEFINITIONS ::= BEGIN
+
+IMPORTS
+    MODULE-IDENTITY, OBJECT-TYPE, Integer32, Gauge32, Counter64, enterprises
+        FROM SNMPv2-SMI;
+
+hwmonitor MODULE-IDENTITY
+    LAST-UPDATED "%s"
+    ORGANIZATION "viam-sbc-hwmonitor"
+    CONTACT-INFO "https://github.com/gambit-robotics/gambit-sbc-hwmonitor"
+    DESCRIPTION  "Hardware readings of a single board computer, exported by the viam-sbc-hwmonitor module."
+    ::= { enterprises %s }
+
+hwmonitorMetrics OBJECT IDENTIFIER ::= { hwmonitor 1 }
+`, time.Now().UTC().Format("200601021504Z"), arcs)
+
+	for _, m := range metrics {
+		description := fmt.Sprintf("%s of %s", m.Key, m.Sensor)
+		if s := scale(m); s != 1 && m.Type != typeString {
+			description += ", multiplied by " + strconv.FormatFloat(s, 'f', -1, 64)
+		}
+		fmt.Fprintf(&mib, `
+%s OBJECT-TYPE
+    SYNTAX      %s
+    MAX-ACCESS  read-only
+    STATUS      current
+    DESCRIPTION "%s"
+    ::= { hwmonitorMetrics %d }
+`, mibName(m), mibSyntax(m), description, m.Index)
+	}
+	mib.WriteString("\nEND\n")
+	return mib.String()
+}
@@ -0,0 +1,65 @@
+package snmpagent
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/snmp"
+)
+
+func TestMIBName(t *testing.T) {
+	assert.Equal(t, "cpuMonitorUsage", mibName(Metric{Sensor: "cpu_monitor", Key: "usage"}))
+	assert.Equal(t, "temperaturesCpu0", mibName(Metric{Sensor: "temperatures", Key: "cpu0"}))
+	assert.Equal(t, "hwmonitor1wireTemp", mibName(Metric{Sensor: "1wire", Key: "temp"}))
+	assert.Equal(t, "boardTemp", mibName(Metric{Sensor: "temperatures", Key: "cpu", Name: "boardTemp"}))
+}
+
+func TestToValue(t *testing.T) {
+	readings := map[string]interface{}{
+		"cpu":       48.25,
+		"governor":  "ondemand",
+		"throttled": true,
+		"events":    uint64(12),
+		"load":      -3,
+		"cores":     map[string]interface{}{"cpu0": 10.0},
+	}
+	for _, tc := range []struct {
+		metric Metric
+		value  interface{}
+	}{
+		{Metric{Key: "cpu"}, 48},
+		{Metric{Key: "cpu", Scale: 10}, 483},
+		{Metric{Key: "cpu", Type: typeString}, "48"},
+		{Metric{Key: "governor"}, "ondemand"},
+		{Metric{Key: "throttled"}, 1},
+		{Metric{Key: "events", Type: typeCounter}, snmp.Counter64(12)},
+		{Metric{Key: "load", Type: typeGauge}, snmp.Gauge32(0)},
+		{Metric{Key: "cores_cpu0"}, 10},
+	} {
+		value, ok := toValue(tc.metric, readings)
+		assert.True(t, ok, tc.metric.Key)
+		assert.Equal(t, tc.value, value, tc.metric.Key)
+	}
+
+	for _, m := range []Metric{{Key: "missing"}, {Key: "governor", Type: typeGauge}, {Key: "load", Type: typeCounter}} {
+		_, ok := toValue(m, readings)
+		assert.False(t, ok, m.Key)
+	}
+}
+
+func TestGenerateMIB(t *testing.T) {
+	mib := generateMIB(snmp.OID{1, 3, 6, 1, 4, 1, 8072, 9999, 9999}, []Metric{
+		{Sensor: "temperatures", Key: "cpu", Index: 1, Scale: 10},
+		{Sensor: "usb_monitor", Key: "disconnect_events", Index: 2, Type: typeCounter},
+	})
+	assert.Contains(t, mib, "::= { enterprises 8072 9999 9999 }")
+	assert.Contains(t, mib, `temperaturesCpu OBJECT-TYPE
+    SYNTAX      Integer32
+    MAX-ACCESS  read-only
+    STATUS      current
+    DESCRIPTION "cpu of temperatures, multiplied by 10"
+    ::= { hwmonitorMetrics 1 }`)
+	assert.Contains(t, mib, "usbMonitorDisconnectEvents OBJECT-TYPE\n    SYNTAX      Counter64")
+	assert.Contains(t, mib, "\nEND\n")
+}
@@ -0,0 +1,312 @@
+package snmpagent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"sync"
+	"time"
+
+	"go.viam.com/rdk/components/sensor"
+	"go.viam.com/rdk/logging"
+	"go.viam.com/rdk/resource"
+	viamutils "go.viam.com/utils"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/snmp"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+var (
+	Model       = resource.NewModel(utils.Namespace, "hwmonitor", "snmp_agent")
+	API         = sensor.API
+	PrettyName  = "SNMP Agent"
+	Description = "A sensor that serves readings of other sensors over SNMPv2c, under a private MIB"
+	Version     = utils.Version
+)
+
+const (
+	defaultListen   = ":161"
+	defaultBaseOID  = "1.3.6.1.4.1.8072.9999.9999"
+	defaultInterval = 10 * time.Second
+	readTimeout     = 5 * time.Second
+)
+
+type Config struct {
+	resource.Named
+	configLock        sync.Mutex
+	readingsLock      sync.RWMutex
+	logger            logging.Logger
+	workers           *viamutils.StoppableWorkers
+	task              *utils.Task
+	listen            string
+	baseOID           snmp.OID
+	metrics           []Metric
+	system            []snmp.Variable
+	started           time.Time
+	values            map[int]interface{}
+	requestCount      int
+	badCommunityCount int
+	lastErr           error
+}
+
+func init() {
+	resource.RegisterComponent(
+		API,
+		Model,
+		resource.Registration[sensor.Sensor, *ComponentConfig]{Constructor: utils.FilterReadings(NewSensor)})
+}
+
+func NewSensor(ctx context.Context, deps resource.Dependencies, conf resource.Config, logger logging.Logger) (sensor.Sensor, error) {
+	logger.Infof("Starting %s %s", PrettyName, Version)
+	b := Config{
+		Named:  conf.ResourceName().AsNamed(),
+		logger: logger,
+	}
+
+	if err := b.Reconfigure(ctx, deps, conf); err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
+
+func (c *Config) Reconfigure(ctx context.Context, deps resource.Dependencies, rawConf resource.Config) error {
+	c.configLock.Lock()
+	defer c.configLock.Unlock()
+	c.logger.Debugf("Reconfiguring %s", PrettyName)
+
+	// Stopping the workers also closes the socket, so the port is free to be listened on again
+	c.stopWorkers()
+
+	conf, err := resource.NativeConfig[*ComponentConfig](rawConf)
+	if err != nil {
+		return err
+	}
+
+	// In case the component has changed name
+	c.Named = rawConf.ResourceName().AsNamed()
+
+	sensors := make(map[string]sensor.Sensor)
+	for _, m := range conf.Metrics {
+		if _, ok := sensors[m.Sensor]; ok {
+			continue
+		}
+		s, err := sensor.FromDependencies(deps, m.Sensor)
+		if err != nil {
+			return err
+		}
+		sensors[m.Sensor] = s
+	}
+	baseOID := conf.BaseOID
+	if baseOID == "" {
+		baseOID = defaultBaseOID
+	}
+	base, err := snmp.ParseOID(baseOID)
+	if err != nil {
+		return err
+	}
+	listen := conf.Listen
+	if listen == "" {
+		listen = defaultListen
+	}
+	interval := time.Duration(conf.IntervalSec * float64(time.Second))
+	if interval <= 0 {
+		interval = defaultInterval
+	}
+	host, err := os.Hostname()
+	if err != nil {
+		return err
+	}
+
+	conn, err := net.ListenPacket("udp", listen)
+	if err != nil {
+		return err
+	}
+
+	c.readingsLock.Lock()
+	c.listen = listen
+	c.baseOID = base
+	c.metrics = conf.Metrics
+	c.system = []snmp.Variable{
+		{OID: sysDescr, Value: fmt.Sprintf("viam-sbc-hwmonitor %s on %s", Version, host)},
+		{OID: sysObjectID, Value: base},
+		{OID: sysContact, Value: conf.Contact},
+		{OID: sysName, Value: host},
+		{OID: sysLocation, Value: conf.Location},
+	}
+	c.started = time.Now()
+	c.values = make(map[int]interface{})
+	c.lastErr = nil
+	c.readingsLock.Unlock()
+
+	metrics := conf.Metrics
+	community := conf.Community
+	c.workers = viamutils.NewBackgroundStoppableWorkers(func(ctx context.Context) {
+		c.serve(ctx, conn, community)
+	})
+	c.task = utils.ScheduleAligned(interval, utils.ReadingsSource, func(ctx context.Context) {
+		c.poll(ctx, sensors, metrics)
+	})
+	return nil
+}
+
+// stopWorkers stops polling and then the other background workers, configLock must be held.
+func (c *Config) stopWorkers() {
+	if c.task == nil {
+		return
+	}
+	c.logger.Debug("Stopping background workers")
+	c.task.Stop()
+	c.workers.Stop()
+	c.task, c.workers = nil, nil
+	c.logger.Debugf("Background workers stopped")
+}
+
+// poll updates the metrics from a reading of every sensor, the scheduler calls it every interval.
+func (c *Config) poll(ctx context.Context, sensors map[string]sensor.Sensor, metrics []Metric) {
+	readings := make(map[string]map[string]interface{}, len(sensors))
+	for name, s := range sensors {
+		readCtx, cancel := context.WithTimeout(ctx, readTimeout)
+		r, err := s.Readings(readCtx, nil)
+		cancel()
+		if ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			// Its metrics are left out, so managers see noSuchObject rather than a stale value
+			c.logger.Debugf("Failed to read %s: %v", name, err)
+			continue
+		}
+		readings[name] = r
+	}
+	values := make(map[int]interface{}, len(metrics))
+	for _, m := range metrics {
+		if r, ok := readings[m.Sensor]; ok {
+			if value, ok := toValue(m, r); ok {
+				values[m.Index] = value
+			}
+		}
+	}
+	c.readingsLock.Lock()
+	c.values = values
+	c.readingsLock.Unlock()
+}
+
+func (c *Config) serve(ctx context.Context, conn net.PacketConn, community string) {
+	go func() {
+		<-ctx.Done()
+		conn.Close()
+	}()
+	buf := make([]byte, 65535)
+	for {
+		n, addr, err := conn.ReadFrom(buf)
+		if err != nil {
+			if ctx.Err() == nil {
+				c.logger.Errorf("SNMP agent stopped: %v", err)
+				c.readingsLock.Lock()
+				c.lastErr = err
+				c.readingsLock.Unlock()
+			}
+			return
+		}
+		response, err := snmp.Handle(buf[:n], community, c.table())
+		c.readingsLock.Lock()
+		c.requestCount++
+		if errors.Is(err, snmp.ErrBadCommunity) {
+			c.badCommunityCount++
+		}
+		c.readingsLock.Unlock()
+		if err != nil {
+			c.logger.Debugf("Ignoring request from %s: %v", addr, err)
+			continue
+		}
+		if _, err := conn.WriteTo(response, addr); err != nil {
+			c.logger.Debugf("Failed to respond to %s: %v", addr, err)
+		}
+	}
+}
+
+// table is the MIB as of now, it is small enough to rebuild for every request.
+func (c *Config) table() snmp.Table {
+	c.readingsLock.RLock()
+	defer c.readingsLock.RUnlock()
+	variables := append([]snmp.Variable{}, c.system...)
+	// TimeTicks are hundredths of a second
+	variables = append(variables, snmp.Variable{OID: sysUpTime, Value: snmp.TimeTicks(time.Since(c.started) / (10 * time.Millisecond))})
+	for _, m := range c.metrics {
+		if value, ok := c.values[m.Index]; ok {
+			variables = append(variables, snmp.Variable{OID: metricOID(c.baseOID, m), Value: value})
+		}
+	}
+	return snmp.NewTable(variables)
+}
+
+func (c *Config) Readings(ctx context.Context, extra map[string]interface{}) (map[string]interface{}, error) {
+	c.readingsLock.RLock()
+	defer c.readingsLock.RUnlock()
+	ret := map[string]interface{}{
+		"listen":              c.listen,
+		"request_count":       c.requestCount,
+		"bad_community_count": c.badCommunityCount,
+		"metric_count":        len(c.values),
+	}
+	if c.lastErr != nil {
+		ret["last_error"] = c.lastErr.Error()
+	}
+	return ret, nil
+}
+
+func (c *Config) DoCommand(ctx context.Context, cmd map[string]interface{}) (map[string]interface{}, error) {
+	c.configLock.Lock()
+	defer c.configLock.Unlock()
+
+	command, ok := cmd["command"].(string)
+	if !ok {
+		return nil, errors.New("missing or invalid 'command' field")
+	}
+
+	switch command {
+	case "get_mib":
+		return c.handleGetMIB()
+	case "list_oids":
+		return c.handleListOIDs()
+	default:
+		return nil, fmt.Errorf("unknown command: %s", command)
+	}
+}
+
+func (c *Config) handleGetMIB() (map[string]interface{}, error) {
+	c.readingsLock.RLock()
+	defer c.readingsLock.RUnlock()
+	return map[string]interface{}{"mib": generateMIB(c.baseOID, c.metrics)}, nil
+}
+
+func (c *Config) handleListOIDs() (map[string]interface{}, error) {
+	c.readingsLock.RLock()
+	defer c.readingsLock.RUnlock()
+	oids := make([]interface{}, 0, len(c.metrics))
+	for _, m := range c.metrics {
+		oids = append(oids, map[string]interface{}{
+			"oid":    metricOID(c.baseOID, m).String(),
+			"name":   mibName(m),
+			"sensor": m.Sensor,
+			"key":    m.Key,
+			"syntax": mibSyntax(m),
+		})
+	}
+	return map[string]interface{}{"oids": oids}, nil
+}
+
+func (c *Config) Close(ctx context.Context) error {
+	c.configLock.Lock()
+	defer c.configLock.Unlock()
+	c.logger.Infof("Shutting down %s", PrettyName)
+	c.stopWorkers()
+	c.logger.Infof("%s Shutdown complete", PrettyName)
+	return nil
+}
+
+func (c *Config) Ready(ctx context.Context, extra map[string]interface{}) (bool, error) {
+	return false, nil
+}
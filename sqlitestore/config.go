@@ -0,0 +1,27 @@
+package sqlitestore
+
+import (
+	"errors"
+	"path/filepath"
+)
+
+type ComponentConfig struct {
+	Path          string   `json:"path,omitempty"`           // The database file, defaults to readings.db in the module data directory
+	Sensors       []string `json:"sensors"`                  // The sensors whose readings are stored
+	IntervalSec   float64  `json:"interval_sec,omitempty"`   // How often the sensors are read, defaults to 10
+	RetentionDays float64  `json:"retention_days,omitempty"` // How long readings are kept, defaults to 30
+	MaxSizeMB     float64  `json:"max_size_mb,omitempty"`    // The oldest readings are deleted when the database grows past this, defaults to 256
+}
+
+func (conf *ComponentConfig) Validate(path string) ([]string, error) {
+	if len(conf.Sensors) == 0 {
+		return nil, errors.New("at least one sensor is required")
+	}
+	if conf.Path != "" && !filepath.IsAbs(conf.Path) {
+		return nil, errors.New("path must be absolute")
+	}
+	if conf.IntervalSec < 0 || conf.RetentionDays < 0 || conf.MaxSizeMB < 0 {
+		return nil, errors.New("interval_sec, retention_days and max_size_mb must not be negative")
+	}
+	return conf.Sensors, nil
+}
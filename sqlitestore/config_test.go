@@ -0,0 +1,29 @@
+package sqlitestore
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidate(t *testing.T) {
+	for _, conf := range []*ComponentConfig{
+		{Sensors: []string{"cpu"}},
+		{Path: "/data/hwmonitor/readings.db", Sensors: []string{"cpu"}, IntervalSec: 60, RetentionDays: 365, MaxSizeMB: 1024},
+	} {
+		deps, err := conf.Validate("")
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"cpu"}, deps)
+	}
+
+	for _, conf := range []*ComponentConfig{
+		{},
+		{Path: "readings.db", Sensors: []string{"cpu"}},
+		{Sensors: []string{"cpu"}, IntervalSec: -1},
+		{Sensors: []string{"cpu"}, RetentionDays: -1},
+		{Sensors: []string{"cpu"}, MaxSizeMB: -1},
+	} {
+		_, err := conf.Validate("")
+		assert.Error(t, err, conf)
+	}
+}
@@ -0,0 +1,383 @@
+package sqlitestore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"go.viam.com/rdk/components/sensor"
+	"go.viam.com/rdk/logging"
+	"go.viam.com/rdk/resource"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+var (
+	Model       = resource.NewModel(utils.Namespace, "hwmonitor", "sqlite_store")
+	API         = sensor.API
+	PrettyName  = "SQLite Store"
+	Description = "A sensor that stores the readings of other sensors in a local SQLite database that survives restarts, and answers queries over it"
+	Version     = utils.Version
+)
+
+const (
+	defaultFileName      = "readings.db"
+	defaultIntervalSec   = 10
+	defaultRetentionDays = 30
+	defaultMaxSizeMB     = 256
+	defaultMaxPoints     = 1000
+	maxMaxPoints         = 10000
+	pruneInterval        = 10 * time.Minute
+	readTimeout          = 5 * time.Second
+)
+
+type Config struct {
+	resource.Named
+	configLock   sync.Mutex
+	readingsLock sync.RWMutex
+	logger       logging.Logger
+	storeTask    *utils.Task
+	pruneTask    *utils.Task
+	store        *store
+	path         string
+	sensorCount  int
+	writeCount   int
+	failedCount  int
+	valueCount   int
+	lastWrite    time.Time
+	oldest       time.Time
+	usedBytes    int64
+	lastErr      error
+}
+
+func init() {
+	resource.RegisterComponent(
+		API,
+		Model,
+		resource.Registration[sensor.Sensor, *ComponentConfig]{Constructor: utils.FilterReadings(NewSensor)})
+}
+
+func NewSensor(ctx context.Context, deps resource.Dependencies, conf resource.Config, logger logging.Logger) (sensor.Sensor, error) {
+	logger.Infof("Starting %s %s", PrettyName, Version)
+	b := Config{
+		Named:  conf.ResourceName().AsNamed(),
+		logger: logger,
+	}
+
+	if err := b.Reconfigure(ctx, deps, conf); err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
+
+func (c *Config) Reconfigure(ctx context.Context, deps resource.Dependencies, rawConf resource.Config) error {
+	c.configLock.Lock()
+	defer c.configLock.Unlock()
+	c.logger.Debugf("Reconfiguring %s", PrettyName)
+
+	c.stopPolling()
+
+	conf, err := resource.NativeConfig[*ComponentConfig](rawConf)
+	if err != nil {
+		return err
+	}
+
+	// In case the component has changed name
+	c.Named = rawConf.ResourceName().AsNamed()
+
+	sources, err := utils.ResolveSources(deps, conf.Sensors)
+	if err != nil {
+		return err
+	}
+	path := conf.Path
+	if path == "" {
+		path = filepath.Join(utils.ModuleDataDir(), defaultFileName)
+	}
+	intervalSec := conf.IntervalSec
+	if intervalSec == 0 {
+		intervalSec = defaultIntervalSec
+	}
+	retentionDays := conf.RetentionDays
+	if retentionDays == 0 {
+		retentionDays = defaultRetentionDays
+	}
+	maxSizeMB := conf.MaxSizeMB
+	if maxSizeMB == 0 {
+		maxSizeMB = defaultMaxSizeMB
+	}
+	interval := time.Duration(intervalSec * float64(time.Second))
+	retention := time.Duration(retentionDays * float64(24*time.Hour))
+	maxBytes := int64(maxSizeMB * 1024 * 1024)
+
+	c.readingsLock.Lock()
+	defer c.readingsLock.Unlock()
+	if c.store == nil || path != c.path {
+		if c.store != nil {
+			if err := c.store.Close(); err != nil {
+				c.logger.Warnf("Failed to close %s: %v", c.path, err)
+			}
+			c.store = nil
+		}
+		if c.store, err = openStore(path); err != nil {
+			return fmt.Errorf("failed to open %s: %w", path, err)
+		}
+		c.path = path
+	}
+	c.sensorCount = len(sources)
+	c.lastErr = nil
+
+	st := c.store
+	c.storeTask = utils.ScheduleAligned(interval, utils.ReadingsSource, func(ctx context.Context) {
+		c.storeReadings(ctx, st, sources)
+	})
+	c.pruneTask = utils.Schedule(pruneInterval, func(ctx context.Context) {
+		c.prune(st, retention, maxBytes)
+	})
+	return nil
+}
+
+// stopPolling stops storing and pruning, configLock must be held.
+func (c *Config) stopPolling() {
+	if c.storeTask == nil {
+		return
+	}
+	c.logger.Debug("Stopping polling")
+	c.storeTask.Stop()
+	c.pruneTask.Stop()
+	c.storeTask, c.pruneTask = nil, nil
+	c.logger.Debugf("Polling stopped")
+}
+
+// storeReadings writes the readings of every source, the scheduler calls it every interval.
+func (c *Config) storeReadings(ctx context.Context, st *store, sources []utils.Source) {
+	now := time.Now()
+	readings := make(map[string]map[string]float64, len(sources))
+	var readErr error
+	for _, s := range sources {
+		readCtx, cancel := context.WithTimeout(ctx, readTimeout)
+		r, err := s.Sensor.Readings(readCtx, nil)
+		cancel()
+		if ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			readErr = fmt.Errorf("failed to read %s: %w", s.Name, err)
+			continue
+		}
+		readings[s.Name] = utils.NumericReadings(r)
+	}
+
+	written, err := st.write(now, readings)
+	c.readingsLock.Lock()
+	defer c.readingsLock.Unlock()
+	if err != nil {
+		c.failedCount++
+		readErr = fmt.Errorf("failed to write readings: %w", err)
+	} else {
+		c.writeCount++
+		c.valueCount += written
+		c.lastWrite = now
+	}
+	if readErr != nil && c.lastErr == nil {
+		c.logger.Warnf("Failed to store readings: %v", readErr)
+	} else if readErr == nil && c.lastErr != nil {
+		c.logger.Infof("Storing readings again")
+	}
+	c.lastErr = readErr
+}
+
+// prune enforces the retention and size limit, the scheduler calls it every prune interval. Its first call is within
+// seconds of a reconfigure, so a lowered limit applies soon after it's set.
+func (c *Config) prune(st *store, retention time.Duration, maxBytes int64) {
+	deleted, err := st.prune(time.Now().Add(-retention), maxBytes)
+	if err != nil {
+		c.logger.Warnf("Failed to prune old readings: %v", err)
+	} else if deleted > 0 {
+		c.logger.Debugf("Pruned %d old readings", deleted)
+	}
+	used, usedErr := st.usedBytes()
+	oldest, ok, oldestErr := st.oldest()
+	c.readingsLock.Lock()
+	defer c.readingsLock.Unlock()
+	if usedErr == nil {
+		c.usedBytes = used
+	}
+	if oldestErr == nil {
+		c.oldest = time.Time{}
+		if ok {
+			c.oldest = oldest
+		}
+	}
+}
+
+func (c *Config) Readings(ctx context.Context, extra map[string]interface{}) (map[string]interface{}, error) {
+	c.readingsLock.RLock()
+	defer c.readingsLock.RUnlock()
+	ret := map[string]interface{}{
+		"path":                c.path,
+		"sensor_count":        c.sensorCount,
+		"write_count":         c.writeCount,
+		"failed_count":        c.failedCount,
+		"value_count":         c.valueCount,
+		"database_size_bytes": c.usedBytes,
+	}
+	if !c.lastWrite.IsZero() {
+		ret["last_write_time"] = c.lastWrite.Format(time.RFC3339)
+	}
+	if !c.oldest.IsZero() {
+		ret["oldest_sample_time"] = c.oldest.Format(time.RFC3339)
+	}
+	if c.lastErr != nil {
+		ret["last_error"] = c.lastErr.Error()
+	}
+	return ret, nil
+}
+
+func (c *Config) DoCommand(ctx context.Context, cmd map[string]interface{}) (map[string]interface{}, error) {
+	command, ok := cmd["command"].(string)
+	if !ok {
+		return nil, errors.New("missing or invalid 'command' field")
+	}
+
+	c.readingsLock.RLock()
+	st := c.store
+	c.readingsLock.RUnlock()
+	if st == nil {
+		return nil, errors.New("the store is closed")
+	}
+	switch command {
+	case "list":
+		return handleList(st)
+	case "query":
+		return handleQuery(st, cmd)
+	default:
+		return nil, fmt.Errorf("unknown command: %s", command)
+	}
+}
+
+// handleList returns the stored keys of each sensor and the time range they cover.
+func handleList(st *store) (map[string]interface{}, error) {
+	series, err := st.list()
+	if err != nil {
+		return nil, err
+	}
+	ret := make(map[string]interface{})
+	for _, s := range series {
+		keys, ok := ret[s.sensor].(map[string]interface{})
+		if !ok {
+			keys = make(map[string]interface{})
+			ret[s.sensor] = keys
+		}
+		keys[s.key] = map[string]interface{}{
+			"start": s.start.UTC().Format(time.RFC3339Nano),
+			"end":   s.end.UTC().Format(time.RFC3339Nano),
+		}
+	}
+	return map[string]interface{}{"sensors": ret}, nil
+}
+
+func handleQuery(st *store, cmd map[string]interface{}) (map[string]interface{}, error) {
+	q, err := parseQuery(cmd)
+	if err != nil {
+		return nil, err
+	}
+	res, err := st.query(q)
+	if err != nil {
+		return nil, err
+	}
+
+	times := make([]interface{}, 0, len(res.times))
+	for _, t := range res.times {
+		times = append(times, t.UTC().Format(time.RFC3339Nano))
+	}
+	values := make(map[string]interface{}, len(res.keys))
+	for i, key := range res.keys {
+		column := make([]interface{}, 0, len(res.values[i]))
+		for _, v := range res.values[i] {
+			if math.IsNaN(v) {
+				column = append(column, nil)
+			} else {
+				column = append(column, v)
+			}
+		}
+		values[key] = column
+	}
+	return map[string]interface{}{
+		"sensor":   q.sensor,
+		"step_sec": res.step.Seconds(),
+		"times":    times,
+		"values":   values,
+	}, nil
+}
+
+func parseQuery(cmd map[string]interface{}) (query, error) {
+	q := query{maxPoints: defaultMaxPoints}
+	var ok bool
+	if q.sensor, ok = cmd["sensor"].(string); !ok {
+		return q, errors.New("missing or invalid 'sensor' parameter")
+	}
+	if keys, ok := cmd["keys"].([]interface{}); ok {
+		for _, key := range keys {
+			s, ok := key.(string)
+			if !ok {
+				return q, errors.New("invalid 'keys' parameter, it must be a list of strings")
+			}
+			q.keys = append(q.keys, s)
+		}
+	}
+	for param, t := range map[string]*time.Time{"start": &q.start, "end": &q.end} {
+		value, ok := cmd[param].(string)
+		if !ok {
+			continue
+		}
+		parsed, err := time.Parse(time.RFC3339Nano, value)
+		if err != nil {
+			return q, fmt.Errorf("invalid '%s' parameter, it must be an RFC 3339 time", param)
+		}
+		*t = parsed
+	}
+	if step, ok := cmd["step_sec"].(float64); ok {
+		if step < 0 {
+			return q, errors.New("invalid 'step_sec' parameter, it must not be negative")
+		}
+		q.step = time.Duration(step * float64(time.Second))
+	}
+	if how, ok := cmd["aggregate"].(string); ok {
+		if err := validAggregate(how); err != nil {
+			return q, err
+		}
+		q.aggregate = how
+	}
+	if maxPoints, ok := cmd["max_points"].(float64); ok {
+		if maxPoints < 1 || maxPoints > maxMaxPoints {
+			return q, fmt.Errorf("invalid 'max_points' parameter, it must be between 1 and %d", maxMaxPoints)
+		}
+		q.maxPoints = int(maxPoints)
+	}
+	return q, nil
+}
+
+func (c *Config) Close(ctx context.Context) error {
+	c.configLock.Lock()
+	defer c.configLock.Unlock()
+	c.logger.Infof("Shutting down %s", PrettyName)
+	c.stopPolling()
+	c.readingsLock.Lock()
+	defer c.readingsLock.Unlock()
+	if c.store != nil {
+		if err := c.store.Close(); err != nil {
+			c.logger.Warnf("Failed to close %s: %v", c.path, err)
+		}
+		c.store = nil
+	}
+	c.logger.Infof("%s Shutdown complete", PrettyName)
+	return nil
+}
+
+func (c *Config) Ready(ctx context.Context, extra map[string]interface{}) (bool, error) {
+	return false, nil
+}
@@ -0,0 +1,394 @@
+package sqlitestore
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	_ "modernc.org/sqlite"
+)
+
+const (
+	aggregateMean = "mean"
+	aggregateMin  = "min"
+	aggregateMax  = "max"
+	aggregateLast = "last"
+)
+
+// WAL with synchronous=NORMAL can lose the last writes on power loss but never corrupts the database, and it writes
+// far less than the default rollback journal, which matters on SD cards and eMMC.
+const pragmas = "?_pragma=busy_timeout(5000)&_pragma=journal_mode(WAL)&_pragma=synchronous(NORMAL)&_pragma=auto_vacuum(INCREMENTAL)"
+
+// Values are stored per sensor and key, a series, so the names aren't repeated in every row. Times are Unix
+// milliseconds.
+const schema = `
+CREATE TABLE IF NOT EXISTS series (
+	id INTEGER PRIMARY KEY,
+	sensor TEXT NOT NULL,
+	key TEXT NOT NULL,
+	UNIQUE (sensor, key)
+);
+CREATE TABLE IF NOT EXISTS samples (
+	series INTEGER NOT NULL REFERENCES series (id),
+	time INTEGER NOT NULL,
+	value REAL NOT NULL,
+	PRIMARY KEY (series, time)
+) WITHOUT ROWID;
+CREATE INDEX IF NOT EXISTS samples_time ON samples (time);
+`
+
+type store struct {
+	mu     sync.Mutex
+	db     *sql.DB
+	series map[string]map[string]int64 // sensor -> key -> series id
+}
+
+func openStore(path string) (*store, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, err
+	}
+	db, err := sql.Open("sqlite", path+pragmas)
+	if err != nil {
+		return nil, err
+	}
+	// SQLite only has one writer anyway, and a single connection keeps writes from failing with SQLITE_BUSY
+	db.SetMaxOpenConns(1)
+	if _, err := db.Exec(schema); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to create the schema of %s: %w", path, err)
+	}
+	return &store{db: db, series: make(map[string]map[string]int64)}, nil
+}
+
+func (s *store) Close() error {
+	return s.db.Close()
+}
+
+// write stores one read of every sensor in a single transaction and returns the number of values written. NaN can't
+// be stored so it's skipped.
+func (s *store) write(t time.Time, readings map[string]map[string]float64) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	tx, err := s.db.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+	insert, err := tx.Prepare("INSERT OR REPLACE INTO samples (series, time, value) VALUES (?, ?, ?)")
+	if err != nil {
+		return 0, err
+	}
+	defer insert.Close()
+
+	written := 0
+	for sensor, values := range readings {
+		for key, value := range values {
+			if math.IsNaN(value) {
+				continue
+			}
+			id, err := s.seriesID(tx, sensor, key)
+			if err != nil {
+				return 0, err
+			}
+			if _, err := insert.Exec(id, t.UnixMilli(), value); err != nil {
+				return 0, err
+			}
+			written++
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		// The ids of series created in the transaction are gone with it
+		s.series = make(map[string]map[string]int64)
+		return 0, err
+	}
+	return written, nil
+}
+
+func (s *store) seriesID(tx *sql.Tx, sensor, key string) (int64, error) {
+	if id, ok := s.series[sensor][key]; ok {
+		return id, nil
+	}
+	if _, err := tx.Exec("INSERT OR IGNORE INTO series (sensor, key) VALUES (?, ?)", sensor, key); err != nil {
+		return 0, err
+	}
+	var id int64
+	if err := tx.QueryRow("SELECT id FROM series WHERE sensor = ? AND key = ?", sensor, key).Scan(&id); err != nil {
+		return 0, err
+	}
+	if s.series[sensor] == nil {
+		s.series[sensor] = make(map[string]int64)
+	}
+	s.series[sensor][key] = id
+	return id, nil
+}
+
+// prune deletes the samples older than before, then the oldest samples until the database uses at most maxBytes,
+// and returns the number of samples deleted. The freed pages are given back to the filesystem.
+func (s *store) prune(before time.Time, maxBytes int64) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	res, err := s.db.Exec("DELETE FROM samples WHERE time < ?", before.UnixMilli())
+	if err != nil {
+		return 0, err
+	}
+	deleted, _ := res.RowsAffected()
+
+	// Deleting a tenth of the samples at a time bounds how long the database is locked
+	for i := 0; i < 10; i++ {
+		used, err := s.usedBytes()
+		if err != nil {
+			return deleted, err
+		}
+		if used <= maxBytes {
+			break
+		}
+		res, err := s.db.Exec(`DELETE FROM samples WHERE time <= (
+			SELECT time FROM samples ORDER BY time LIMIT 1 OFFSET (SELECT COUNT(*) / 10 FROM samples))`)
+		if err != nil {
+			return deleted, err
+		}
+		n, _ := res.RowsAffected()
+		if n == 0 {
+			break
+		}
+		deleted += n
+	}
+
+	if deleted > 0 {
+		if _, err := s.db.Exec("DELETE FROM series WHERE NOT EXISTS (SELECT 1 FROM samples WHERE samples.series = series.id)"); err != nil {
+			return deleted, err
+		}
+		s.series = make(map[string]map[string]int64)
+		if _, err := s.db.Exec("PRAGMA incremental_vacuum"); err != nil {
+			return deleted, err
+		}
+	}
+	return deleted, nil
+}
+
+// usedBytes is the size of the pages holding data, the file can be larger until it's vacuumed.
+func (s *store) usedBytes() (int64, error) {
+	var pages, free, size int64
+	if err := s.db.QueryRow("PRAGMA page_count").Scan(&pages); err != nil {
+		return 0, err
+	}
+	if err := s.db.QueryRow("PRAGMA freelist_count").Scan(&free); err != nil {
+		return 0, err
+	}
+	if err := s.db.QueryRow("PRAGMA page_size").Scan(&size); err != nil {
+		return 0, err
+	}
+	return (pages - free) * size, nil
+}
+
+// oldest returns the time of the oldest sample, false when there are none.
+func (s *store) oldest() (time.Time, bool, error) {
+	var oldest sql.NullInt64
+	if err := s.db.QueryRow("SELECT MIN(time) FROM samples").Scan(&oldest); err != nil {
+		return time.Time{}, false, err
+	}
+	if !oldest.Valid {
+		return time.Time{}, false, nil
+	}
+	return time.UnixMilli(oldest.Int64), true, nil
+}
+
+type seriesInfo struct {
+	sensor string
+	key    string
+	start  time.Time
+	end    time.Time
+}
+
+// list returns every stored series with the time range it covers, ordered by sensor and key.
+func (s *store) list() ([]seriesInfo, error) {
+	rows, err := s.db.Query(`SELECT sensor, key,
+		(SELECT MIN(time) FROM samples WHERE samples.series = series.id),
+		(SELECT MAX(time) FROM samples WHERE samples.series = series.id)
+		FROM series ORDER BY sensor, key`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var ret []seriesInfo
+	for rows.Next() {
+		var info seriesInfo
+		var start, end sql.NullInt64
+		if err := rows.Scan(&info.sensor, &info.key, &start, &end); err != nil {
+			return nil, err
+		}
+		if !start.Valid {
+			continue
+		}
+		info.start, info.end = time.UnixMilli(start.Int64), time.UnixMilli(end.Int64)
+		ret = append(ret, info)
+	}
+	return ret, rows.Err()
+}
+
+type query struct {
+	sensor    string
+	keys      []string
+	start     time.Time
+	end       time.Time
+	step      time.Duration
+	aggregate string
+	maxPoints int
+}
+
+type result struct {
+	keys   []string
+	step   time.Duration
+	times  []time.Time
+	values [][]float64 // values[key][row], NaN where there was no value
+}
+
+// query returns the samples of a sensor between start and end, zero times leave that end open. With a step the
+// samples are downsampled into buckets of that size, and when there would be more than maxPoints rows a step is
+// chosen that fits.
+func (s *store) query(q query) (*result, error) {
+	ids, keys, err := s.queryKeys(q.sensor, q.keys)
+	if err != nil {
+		return nil, err
+	}
+	res := &result{keys: keys, step: q.step, values: make([][]float64, len(keys))}
+	columns := make(map[int64]int, len(ids))
+	for i, id := range ids {
+		columns[id] = i
+	}
+
+	where := "series IN (?" + strings.Repeat(", ?", len(ids)-1) + ")"
+	args := make([]interface{}, 0, len(ids)+2)
+	for _, id := range ids {
+		args = append(args, id)
+	}
+	if !q.start.IsZero() {
+		where += " AND time >= ?"
+		args = append(args, q.start.UnixMilli())
+	}
+	if !q.end.IsZero() {
+		where += " AND time <= ?"
+		args = append(args, q.end.UnixMilli())
+	}
+
+	var first, last sql.NullInt64
+	var rowCount int64
+	if err := s.db.QueryRow("SELECT MIN(time), MAX(time), COUNT(DISTINCT time) FROM samples WHERE "+where, args...).Scan(&first, &last, &rowCount); err != nil {
+		return nil, err
+	}
+	if !first.Valid {
+		return res, nil
+	}
+	if res.step == 0 && rowCount > int64(q.maxPoints) {
+		// Whole seconds are easier to read
+		res.step = time.Duration(math.Ceil(float64(last.Int64-first.Int64+1)/float64(q.maxPoints)/1000)) * time.Second
+	}
+	step := res.step.Milliseconds()
+	if res.step > 0 && step == 0 {
+		return nil, errors.New("step_sec must be at least a millisecond")
+	}
+	if step > 0 && (last.Int64-first.Int64)/step+1 > int64(q.maxPoints) {
+		return nil, fmt.Errorf("step_sec %v gives more than %d points", res.step.Seconds(), q.maxPoints)
+	}
+
+	var rows *sql.Rows
+	if step == 0 {
+		rows, err = s.db.Query("SELECT time, series, value FROM samples WHERE "+where+" ORDER BY time", args...)
+	} else {
+		// SQLite takes the bare value column from the row with the MAX(time), which is what last needs
+		selectValue := map[string]string{
+			"":            "AVG(value), 0",
+			aggregateMean: "AVG(value), 0",
+			aggregateMin:  "MIN(value), 0",
+			aggregateMax:  "MAX(value), 0",
+			aggregateLast: "value, MAX(time)",
+		}[q.aggregate]
+		rows, err = s.db.Query(fmt.Sprintf("SELECT ? + (time - ?) / ? * ? AS bucket, series, %s FROM samples WHERE %s GROUP BY bucket, series ORDER BY bucket", selectValue, where),
+			append([]interface{}{first.Int64, first.Int64, step, step}, args...)...)
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	row := -1
+	var rowTime int64
+	for rows.Next() {
+		var t, id int64
+		var value float64
+		var ignored int64
+		dest := []interface{}{&t, &id, &value}
+		if step > 0 {
+			dest = append(dest, &ignored)
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return nil, err
+		}
+		if row < 0 || t != rowTime {
+			row++
+			rowTime = t
+			res.times = append(res.times, time.UnixMilli(t))
+			for i := range res.values {
+				res.values[i] = append(res.values[i], math.NaN())
+			}
+		}
+		res.values[columns[id]][row] = value
+	}
+	return res, rows.Err()
+}
+
+// queryKeys returns the series ids of the keys of a sensor, every key it has when keys is empty.
+func (s *store) queryKeys(sensor string, keys []string) ([]int64, []string, error) {
+	rows, err := s.db.Query("SELECT id, key FROM series WHERE sensor = ?", sensor)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+	all := make(map[string]int64)
+	for rows.Next() {
+		var id int64
+		var key string
+		if err := rows.Scan(&id, &key); err != nil {
+			return nil, nil, err
+		}
+		all[key] = id
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, err
+	}
+	if len(all) == 0 {
+		return nil, nil, fmt.Errorf("no readings are stored for %s", sensor)
+	}
+
+	if len(keys) == 0 {
+		for key := range all {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+	}
+	ids := make([]int64, len(keys))
+	for i, key := range keys {
+		id, ok := all[key]
+		if !ok {
+			return nil, nil, fmt.Errorf("unknown key: %s", key)
+		}
+		ids[i] = id
+	}
+	return ids, keys, nil
+}
+
+func validAggregate(how string) error {
+	switch how {
+	case "", aggregateMean, aggregateMin, aggregateMax, aggregateLast:
+		return nil
+	default:
+		return errors.New("aggregate must be mean, min, max or last")
+	}
+}
@@ -0,0 +1,113 @@
+package sqlitestore
+
+import (
+	"math"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestStore(t *testing.T) *store {
+	t.Helper()
+	s, err := openStore(filepath.Join(t.TempDir(), "readings.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() { s.Close() })
+	return s
+}
+
+func TestStoreQuery(t *testing.T) {
+	s := newTestStore(t)
+	start := time.UnixMilli(1700000000000)
+	for i := 0; i < 10; i++ {
+		readings := map[string]map[string]float64{"cpu": {"usage": float64(i)}}
+		if i >= 8 {
+			readings["cpu"]["temp"] = float64(i * 10)
+		}
+		written, err := s.write(start.Add(time.Duration(i)*time.Second), readings)
+		require.NoError(t, err)
+		assert.Equal(t, len(readings["cpu"]), written)
+	}
+
+	res, err := s.query(query{sensor: "cpu", maxPoints: 100})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"temp", "usage"}, res.keys)
+	assert.Len(t, res.times, 10)
+	assert.True(t, math.IsNaN(res.values[0][0]))
+	assert.Equal(t, []float64{80, 90}, res.values[0][8:])
+	assert.Equal(t, 9.0, res.values[1][9])
+
+	for how, expected := range map[string][]float64{
+		aggregateMean: {2, 7},
+		aggregateMin:  {0, 5},
+		aggregateMax:  {4, 9},
+		aggregateLast: {4, 9},
+	} {
+		res, err := s.query(query{sensor: "cpu", keys: []string{"usage"}, step: 5 * time.Second, aggregate: how, maxPoints: 100})
+		require.NoError(t, err)
+		assert.Equal(t, expected, res.values[0], how)
+		assert.Equal(t, start.Add(5*time.Second).UnixMilli(), res.times[1].UnixMilli())
+	}
+
+	res, err = s.query(query{sensor: "cpu", keys: []string{"usage"}, start: start.Add(2 * time.Second), end: start.Add(3 * time.Second), maxPoints: 100})
+	require.NoError(t, err)
+	assert.Equal(t, []float64{2, 3}, res.values[0])
+
+	// Too many rows picks a step that fits
+	res, err = s.query(query{sensor: "cpu", keys: []string{"usage"}, maxPoints: 3})
+	require.NoError(t, err)
+	assert.Equal(t, 4*time.Second, res.step)
+	assert.Equal(t, []float64{1.5, 5.5, 8.5}, res.values[0])
+
+	_, err = s.query(query{sensor: "cpu", keys: []string{"usage"}, step: time.Second, maxPoints: 3})
+	assert.Error(t, err)
+	_, err = s.query(query{sensor: "cpu", keys: []string{"fan"}, maxPoints: 100})
+	assert.Error(t, err)
+	_, err = s.query(query{sensor: "gpu", maxPoints: 100})
+	assert.Error(t, err)
+}
+
+func TestStorePrune(t *testing.T) {
+	s := newTestStore(t)
+	start := time.UnixMilli(1700000000000)
+	for i := 0; i < 10; i++ {
+		_, err := s.write(start.Add(time.Duration(i)*time.Hour), map[string]map[string]float64{
+			"cpu": {"usage": float64(i), "temp": math.NaN()},
+		})
+		require.NoError(t, err)
+	}
+	_, err := s.write(start, map[string]map[string]float64{"fan": {"rpm": 1200}})
+	require.NoError(t, err)
+
+	deleted, err := s.prune(start.Add(5*time.Hour), math.MaxInt64)
+	require.NoError(t, err)
+	assert.Equal(t, int64(6), deleted)
+	oldest, ok, err := s.oldest()
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, start.Add(5*time.Hour).UnixMilli(), oldest.UnixMilli())
+
+	series, err := s.list()
+	require.NoError(t, err)
+	require.Len(t, series, 1)
+	assert.Equal(t, "cpu", series[0].sensor)
+	assert.Equal(t, "usage", series[0].key)
+	assert.Equal(t, start.Add(9*time.Hour).UnixMilli(), series[0].end.UnixMilli())
+
+	// A fan reading after its series was pruned gets a new series
+	_, err = s.write(start.Add(10*time.Hour), map[string]map[string]float64{"fan": {"rpm": 1300}})
+	require.NoError(t, err)
+	res, err := s.query(query{sensor: "fan", maxPoints: 100})
+	require.NoError(t, err)
+	assert.Equal(t, []float64{1300}, res.values[0])
+
+	// Over the size limit, the oldest samples go first
+	deleted, err = s.prune(start, 0)
+	require.NoError(t, err)
+	assert.Greater(t, deleted, int64(0))
+	_, ok, err = s.oldest()
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
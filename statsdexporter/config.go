@@ -0,0 +1,31 @@
+package statsdexporter
+
+import (
+	"errors"
+	"net"
+)
+
+type ComponentConfig struct {
+	Address       string            `json:"address,omitempty"`         // host:port of the StatsD server, defaults to 127.0.0.1:8125
+	Sensors       []string          `json:"sensors"`                   // The sensors whose readings are sent
+	MetricPrefix  string            `json:"metric_prefix,omitempty"`   // Defaults to hwmonitor
+	Tags          map[string]string `json:"tags,omitempty"`            // Sent with every metric along with sensor
+	Counters      []string          `json:"counters,omitempty"`        // Reading keys sent as counters of their increase rather than gauges
+	IntervalSec   float64           `json:"interval_sec,omitempty"`    // Defaults to 10
+	MaxPacketSize int               `json:"max_packet_size,omitempty"` // Defaults to 1432, which fits in an Ethernet frame
+}
+
+func (conf *ComponentConfig) Validate(path string) ([]string, error) {
+	if conf.Address != "" {
+		if _, _, err := net.SplitHostPort(conf.Address); err != nil {
+			return nil, errors.New("address must be host:port")
+		}
+	}
+	if len(conf.Sensors) == 0 {
+		return nil, errors.New("at least one sensor is required")
+	}
+	if conf.IntervalSec < 0 || conf.MaxPacketSize < 0 {
+		return nil, errors.New("interval_sec and max_packet_size must not be negative")
+	}
+	return conf.Sensors, nil
+}
@@ -0,0 +1,28 @@
+package statsdexporter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidate(t *testing.T) {
+	for _, conf := range []*ComponentConfig{
+		{Sensors: []string{"cpu"}},
+		{Address: "datadog-agent:8125", Sensors: []string{"cpu"}},
+	} {
+		deps, err := conf.Validate("")
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"cpu"}, deps)
+	}
+
+	for _, conf := range []*ComponentConfig{
+		{Address: "datadog-agent", Sensors: []string{"cpu"}},
+		{Address: "127.0.0.1:8125"},
+		{Sensors: []string{"cpu"}, IntervalSec: -1},
+		{Sensors: []string{"cpu"}, MaxPacketSize: -1},
+	} {
+		_, err := conf.Validate("")
+		assert.Error(t, err, conf)
+	}
+}
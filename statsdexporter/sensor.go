@@ -0,0 +1,281 @@
+package statsdexporter
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"go.viam.com/rdk/components/sensor"
+	"go.viam.com/rdk/logging"
+	"go.viam.com/rdk/resource"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/statsd"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+var (
+	Model       = resource.NewModel(utils.Namespace, "hwmonitor", "statsd_exporter")
+	API         = sensor.API
+	PrettyName  = "StatsD Exporter"
+	Description = "A sensor that sends the readings of other sensors to a StatsD or DogStatsD server over UDP"
+	Version     = utils.Version
+)
+
+const (
+	defaultAddress       = "127.0.0.1:8125"
+	defaultMetricPrefix  = "hwmonitor"
+	defaultInterval      = 10 * time.Second
+	defaultMaxPacketSize = 1432
+	readTimeout          = 5 * time.Second
+)
+
+// source is a sensor the exporter sends, with the tags its metrics are sent with.
+type source struct {
+	utils.Source
+	tags map[string]string
+}
+
+// exporter is the StatsD client, the sensors it sends and which of their readings are counters. StatsD counters are
+// sent as the increase since the previous send, so the exporter also keeps the last value of each.
+type exporter struct {
+	client   *statsd.Client
+	sources  []source
+	counters map[string]bool
+	prefix   string
+	interval time.Duration
+	// last is the last value of every counter, keyed by sensor and reading, only the poll touches it
+	last map[string]float64
+}
+
+type Config struct {
+	resource.Named
+	configLock   sync.Mutex
+	readingsLock sync.RWMutex
+	logger       logging.Logger
+	task         *utils.Task
+	exporter     *exporter
+	address      string
+	sentCount    int
+	failedCount  int
+	metricCount  int
+	lastSend     time.Time
+	lastErr      error
+}
+
+func init() {
+	resource.RegisterComponent(
+		API,
+		Model,
+		resource.Registration[sensor.Sensor, *ComponentConfig]{Constructor: utils.FilterReadings(NewSensor)})
+}
+
+func NewSensor(ctx context.Context, deps resource.Dependencies, conf resource.Config, logger logging.Logger) (sensor.Sensor, error) {
+	logger.Infof("Starting %s %s", PrettyName, Version)
+	b := Config{
+		Named:  conf.ResourceName().AsNamed(),
+		logger: logger,
+	}
+
+	if err := b.Reconfigure(ctx, deps, conf); err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
+
+func (c *Config) Reconfigure(ctx context.Context, deps resource.Dependencies, rawConf resource.Config) error {
+	c.configLock.Lock()
+	defer c.configLock.Unlock()
+	c.logger.Debugf("Reconfiguring %s", PrettyName)
+
+	c.stopExporting()
+
+	conf, err := resource.NativeConfig[*ComponentConfig](rawConf)
+	if err != nil {
+		return err
+	}
+
+	// In case the component has changed name
+	c.Named = rawConf.ResourceName().AsNamed()
+
+	address := conf.Address
+	if address == "" {
+		address = defaultAddress
+	}
+	e, err := newExporter(conf, address, deps)
+	if err != nil {
+		return err
+	}
+
+	c.readingsLock.Lock()
+	c.address = address
+	c.lastErr = nil
+	c.readingsLock.Unlock()
+
+	c.exporter = e
+	c.task = utils.ScheduleAligned(e.interval, utils.ReadingsSource, func(ctx context.Context) {
+		c.export(ctx, e)
+	})
+	return nil
+}
+
+func newExporter(conf *ComponentConfig, address string, deps resource.Dependencies) (*exporter, error) {
+	resolved, err := utils.ResolveSources(deps, conf.Sensors)
+	if err != nil {
+		return nil, err
+	}
+	sources := make([]source, 0, len(resolved))
+	for _, s := range resolved {
+		tags := map[string]string{"sensor": s.Name}
+		for key, value := range conf.Tags {
+			tags[key] = value
+		}
+		sources = append(sources, source{Source: s, tags: tags})
+	}
+
+	counters := make(map[string]bool)
+	for _, key := range conf.Counters {
+		counters[key] = true
+	}
+	prefix := conf.MetricPrefix
+	if prefix == "" {
+		prefix = defaultMetricPrefix
+	}
+	interval := time.Duration(conf.IntervalSec * float64(time.Second))
+	if interval <= 0 {
+		interval = defaultInterval
+	}
+	maxPacketSize := conf.MaxPacketSize
+	if maxPacketSize == 0 {
+		maxPacketSize = defaultMaxPacketSize
+	}
+
+	client, err := statsd.Dial(address, maxPacketSize)
+	if err != nil {
+		return nil, err
+	}
+	return &exporter{
+		client:   client,
+		sources:  sources,
+		counters: counters,
+		prefix:   prefix,
+		interval: interval,
+		last:     make(map[string]float64),
+	}, nil
+}
+
+// stopExporting stops polling and closes the client, configLock must be held.
+func (c *Config) stopExporting() {
+	if c.task == nil {
+		return
+	}
+	c.logger.Debug("Stopping polling")
+	c.task.Stop()
+	c.exporter.client.Close()
+	c.task, c.exporter = nil, nil
+	c.logger.Debugf("Polling stopped")
+}
+
+// export sends the readings of every source, the scheduler calls it every interval.
+func (c *Config) export(ctx context.Context, e *exporter) {
+	lines := c.collect(ctx, e, e.last)
+	_, err := e.client.Send(lines)
+	c.readingsLock.Lock()
+	if err != nil {
+		if c.lastErr == nil {
+			c.logger.Warnf("Failed to send metrics to %s: %v", c.address, err)
+		}
+		c.failedCount++
+		c.lastErr = err
+	} else {
+		if c.lastErr != nil {
+			c.logger.Infof("Sending metrics to %s again", c.address)
+		}
+		c.sentCount++
+		c.metricCount = len(lines)
+		c.lastSend = time.Now()
+		c.lastErr = nil
+	}
+	c.readingsLock.Unlock()
+}
+
+// collect reads every sensor, a sensor that fails to read is skipped rather than failing the whole send.
+func (c *Config) collect(ctx context.Context, e *exporter, last map[string]float64) []string {
+	lines := make([]string, 0)
+	for _, s := range e.sources {
+		readCtx, cancel := context.WithTimeout(ctx, readTimeout)
+		readings, err := s.Sensor.Readings(readCtx, nil)
+		cancel()
+		if err != nil {
+			c.logger.Debugf("Failed to read %s: %v", s.Name, err)
+			continue
+		}
+		for _, m := range toMetrics(e.prefix, s, readings, e.counters, last) {
+			if line := m.Line(); line != "" {
+				lines = append(lines, line)
+			}
+		}
+	}
+	return lines
+}
+
+// toMetrics converts a sensor's numeric readings to metrics named <prefix>.<key>. StatsD counters are increments, so
+// a counter is sent as its increase since the last read, and not at all the first time it is read.
+func toMetrics(prefix string, s source, readings map[string]interface{}, counters map[string]bool, last map[string]float64) []statsd.Metric {
+	values := utils.NumericReadings(readings)
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	metrics := make([]statsd.Metric, 0, len(keys))
+	for _, key := range keys {
+		m := statsd.Metric{Name: prefix + "." + key, Value: values[key], Type: statsd.TypeGauge, Tags: s.tags}
+		if counters[key] {
+			lastKey := s.Name + "/" + key
+			previous, seen := last[lastKey]
+			last[lastKey] = m.Value
+			if !seen {
+				continue
+			}
+			// A counter that went backwards was reset, everything it counted since then is new
+			if m.Value >= previous {
+				m.Value -= previous
+			}
+			m.Type = statsd.TypeCounter
+		}
+		metrics = append(metrics, m)
+	}
+	return metrics
+}
+
+func (c *Config) Readings(ctx context.Context, extra map[string]interface{}) (map[string]interface{}, error) {
+	c.readingsLock.RLock()
+	defer c.readingsLock.RUnlock()
+	ret := map[string]interface{}{
+		"address":      c.address,
+		"sent_count":   c.sentCount,
+		"failed_count": c.failedCount,
+		"metric_count": c.metricCount,
+	}
+	if !c.lastSend.IsZero() {
+		ret["last_send_time"] = c.lastSend.Format(time.RFC3339)
+	}
+	if c.lastErr != nil {
+		ret["last_error"] = c.lastErr.Error()
+	}
+	return ret, nil
+}
+
+func (c *Config) Close(ctx context.Context) error {
+	c.configLock.Lock()
+	defer c.configLock.Unlock()
+	c.logger.Infof("Shutting down %s", PrettyName)
+	c.stopExporting()
+	c.logger.Infof("%s Shutdown complete", PrettyName)
+	return nil
+}
+
+func (c *Config) Ready(ctx context.Context, extra map[string]interface{}) (bool, error) {
+	return false, nil
+}
@@ -0,0 +1,35 @@
+package statsdexporter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/statsd"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+func TestToMetrics(t *testing.T) {
+	s := source{Source: utils.Source{Name: "usb"}, tags: map[string]string{"sensor": "usb"}}
+	counters := map[string]bool{"disconnect_events": true}
+	last := make(map[string]float64)
+
+	readings := map[string]interface{}{
+		"disconnect_events": 3,
+		"device_count":      2,
+		"present":           true,
+		"model":             "Webcam C270",
+	}
+	assert.Equal(t, []statsd.Metric{
+		{Name: "hwmonitor.device_count", Value: 2, Type: statsd.TypeGauge, Tags: s.tags},
+		{Name: "hwmonitor.present", Value: 1, Type: statsd.TypeGauge, Tags: s.tags},
+	}, toMetrics("hwmonitor", s, readings, counters, last))
+
+	readings["disconnect_events"] = 5
+	assert.Contains(t, toMetrics("hwmonitor", s, readings, counters, last),
+		statsd.Metric{Name: "hwmonitor.disconnect_events", Value: 2, Type: statsd.TypeCounter, Tags: s.tags})
+
+	readings["disconnect_events"] = 1
+	assert.Contains(t, toMetrics("hwmonitor", s, readings, counters, last),
+		statsd.Metric{Name: "hwmonitor.disconnect_events", Value: 1, Type: statsd.TypeCounter, Tags: s.tags})
+}
@@ -0,0 +1,143 @@
+package storagearraymonitor
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"go.viam.com/rdk/components/sensor"
+	"go.viam.com/rdk/logging"
+	"go.viam.com/rdk/resource"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/linux"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+var (
+	Model       = resource.NewModel(utils.Namespace, "hwmonitor", "storage_array_monitor")
+	API         = sensor.API
+	PrettyName  = "Storage Array Monitor"
+	Description = "A sensor that reports the health of md RAID arrays, btrfs filesystems and ZFS pools"
+	Version     = utils.Version
+)
+
+type Config struct {
+	resource.Named
+	mu         sync.RWMutex
+	logger     logging.Logger
+	cancelCtx  context.Context
+	cancelFunc func()
+}
+
+func init() {
+	resource.RegisterComponent(
+		API,
+		Model,
+		resource.Registration[sensor.Sensor, *ComponentConfig]{Constructor: utils.FilterReadings(NewSensor)})
+	utils.RegisterSimulator(Model, simulate)
+}
+
+func NewSensor(ctx context.Context, deps resource.Dependencies, conf resource.Config, logger logging.Logger) (sensor.Sensor, error) {
+	logger.Infof("Starting %s %s", PrettyName, Version)
+	cancelCtx, cancelFunc := context.WithCancel(context.Background())
+
+	b := Config{
+		Named:      conf.ResourceName().AsNamed(),
+		logger:     logger,
+		cancelCtx:  cancelCtx,
+		cancelFunc: cancelFunc,
+		mu:         sync.RWMutex{},
+	}
+
+	if err := b.Reconfigure(ctx, deps, conf); err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
+
+func (c *Config) Reconfigure(ctx context.Context, _ resource.Dependencies, conf resource.Config) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.logger.Debugf("Reconfiguring %s", PrettyName)
+
+	// In case the module has changed name
+	c.Named = conf.ResourceName().AsNamed()
+
+	return nil
+}
+
+func (c *Config) Readings(ctx context.Context, extra map[string]interface{}) (map[string]interface{}, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	ret := make(map[string]interface{})
+	anyDegraded := false
+
+	arrays, err := linux.ReadMDArrays(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, array := range arrays {
+		name := array.Name
+		ret[name+"_state"] = array.State
+		ret[name+"_level"] = array.Level
+		ret[name+"_devices_total"] = array.DevicesTotal
+		ret[name+"_devices_active"] = array.DevicesActive
+		ret[name+"_failed_devices"] = strings.Join(array.FailedDevices, ",")
+		ret[name+"_degraded"] = array.Degraded()
+		ret[name+"_sync_action"] = array.SyncAction
+		ret[name+"_sync_progress_percent"] = array.SyncProgress
+		ret[name+"_sync_finish_minutes"] = array.SyncFinishMinute
+		ret[name+"_sync_delayed"] = array.SyncDelayed
+		anyDegraded = anyDegraded || array.Degraded()
+	}
+
+	filesystems, err := linux.ReadBtrfsFilesystems(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, fs := range filesystems {
+		name := "btrfs_" + fs.Label
+		if fs.Label == "" {
+			name = "btrfs_" + fs.UUID
+		}
+		degraded := fs.MissingDevices > 0
+		ret[name+"_uuid"] = fs.UUID
+		ret[name+"_devices"] = fs.Devices
+		ret[name+"_missing_devices"] = fs.MissingDevices
+		ret[name+"_degraded"] = degraded
+		for k, v := range fs.Errors {
+			ret[name+"_"+k] = v
+		}
+		anyDegraded = anyDegraded || degraded
+	}
+
+	pools, err := linux.ReadZFSPools(ctx)
+	if err != nil {
+		// zpool fails when the kernel module isn't loaded, that shouldn't hide the md and btrfs readings
+		c.logger.Debugf("Failed to list ZFS pools: %v", err)
+	}
+	for _, pool := range pools {
+		name := "zfs_" + pool.Name
+		degraded := pool.Health != "ONLINE"
+		ret[name+"_health"] = pool.Health
+		ret[name+"_degraded"] = degraded
+		ret[name+"_size"] = pool.Size
+		ret[name+"_allocated"] = pool.Allocated
+		ret[name+"_free"] = pool.Free
+		ret[name+"_fragmentation_percent"] = pool.Fragmentation
+		ret[name+"_capacity_percent"] = pool.Capacity
+		anyDegraded = anyDegraded || degraded
+	}
+
+	ret["degraded"] = anyDegraded
+	return ret, nil
+}
+
+func (c *Config) Close(ctx context.Context) error {
+	c.logger.Infof("Shutting down %s", PrettyName)
+	return nil
+}
+
+func (c *Config) Ready(ctx context.Context, extra map[string]interface{}) (bool, error) {
+	return false, nil
+}
@@ -0,0 +1,10 @@
+package storagearraymonitor
+
+import "github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+
+// simulate returns a board booting from an SD card, it has no md arrays, btrfs filesystems or ZFS pools.
+func simulate(sim *utils.Simulation) map[string]interface{} {
+	return map[string]interface{}{
+		"degraded": false,
+	}
+}
@@ -0,0 +1,36 @@
+package streamapi
+
+import (
+	"errors"
+	"net"
+	"path/filepath"
+	"strconv"
+)
+
+type ComponentConfig struct {
+	Listen      string   `json:"listen,omitempty"`       // A Unix socket path or host:port, defaults to /run/hwmonitor/stream.sock
+	SocketMode  string   `json:"socket_mode,omitempty"`  // Octal permissions of a Unix socket, defaults to 0660
+	Sensors     []string `json:"sensors"`                // The sensors that can be subscribed to
+	IntervalSec float64  `json:"interval_sec,omitempty"` // How often each sensor is read, defaults to 0.5
+	OnDemand    bool     `json:"on_demand,omitempty"`    // Only serve between the start and stop DoCommands
+}
+
+func (conf *ComponentConfig) Validate(path string) ([]string, error) {
+	if conf.Listen != "" && !filepath.IsAbs(conf.Listen) {
+		if _, _, err := net.SplitHostPort(conf.Listen); err != nil {
+			return nil, errors.New("listen must be an absolute socket path or host:port")
+		}
+	}
+	if conf.SocketMode != "" {
+		if _, err := strconv.ParseUint(conf.SocketMode, 8, 32); err != nil {
+			return nil, errors.New("socket_mode must be octal, e.g. 0660")
+		}
+	}
+	if len(conf.Sensors) == 0 {
+		return nil, errors.New("at least one sensor is required")
+	}
+	if conf.IntervalSec < 0 {
+		return nil, errors.New("interval_sec must not be negative")
+	}
+	return conf.Sensors, nil
+}
@@ -0,0 +1,29 @@
+package streamapi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidate(t *testing.T) {
+	for _, conf := range []*ComponentConfig{
+		{Sensors: []string{"cpu"}},
+		{Listen: "/run/robot/stream.sock", SocketMode: "0600", Sensors: []string{"cpu"}},
+		{Listen: "127.0.0.1:50051", Sensors: []string{"cpu"}},
+	} {
+		deps, err := conf.Validate("")
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"cpu"}, deps)
+	}
+
+	for _, conf := range []*ComponentConfig{
+		{Listen: "stream.sock", Sensors: []string{"cpu"}},
+		{SocketMode: "rw", Sensors: []string{"cpu"}},
+		{},
+		{Sensors: []string{"cpu"}, IntervalSec: -1},
+	} {
+		_, err := conf.Validate("")
+		assert.Error(t, err, conf)
+	}
+}
@@ -0,0 +1,142 @@
+package streamapi
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// subscriptionBuffer is how many updates a slow client can fall behind before updates are dropped
+const subscriptionBuffer = 64
+
+// update is one read of a sensor.
+type update struct {
+	sensor   string
+	time     time.Time
+	readings map[string]interface{}
+	err      string
+}
+
+type subscriptionFilter struct {
+	sensors     []string
+	keys        []string
+	changesOnly bool
+}
+
+type subscription struct {
+	filter  subscriptionFilter
+	sensors map[string]bool
+	keys    map[string]bool
+	updates chan update
+	dropped atomic.Uint64
+}
+
+func (s *subscription) wants(sensor string) bool {
+	return len(s.sensors) == 0 || s.sensors[sensor]
+}
+
+// filtered returns the update with only the subscribed keys.
+func (s *subscription) filtered(u update) update {
+	if len(s.keys) == 0 || u.readings == nil {
+		return u
+	}
+	readings := make(map[string]interface{}, len(s.keys))
+	for key := range s.keys {
+		if value, ok := u.readings[key]; ok {
+			readings[key] = value
+		}
+	}
+	u.readings = readings
+	return u
+}
+
+// hub fans the reads of every sensor out to the subscriptions that want them. A subscription that isn't keeping up
+// has updates dropped rather than slowing down the others.
+type hub struct {
+	mu            sync.Mutex
+	names         map[string]bool
+	latest        map[string]update
+	subscriptions map[*subscription]bool
+}
+
+func newHub(names []string) *hub {
+	h := &hub{
+		names:         make(map[string]bool, len(names)),
+		latest:        make(map[string]update),
+		subscriptions: make(map[*subscription]bool),
+	}
+	for _, name := range names {
+		h.names[name] = true
+	}
+	return h
+}
+
+// subscribe starts a subscription with the latest read of each subscribed sensor already queued.
+func (h *hub) subscribe(filter subscriptionFilter) (*subscription, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	sub := &subscription{
+		filter:  filter,
+		sensors: make(map[string]bool),
+		keys:    make(map[string]bool),
+		updates: make(chan update, max(subscriptionBuffer, len(h.names))),
+	}
+	for _, name := range filter.sensors {
+		if !h.names[name] {
+			return nil, fmt.Errorf("unknown sensor: %s", name)
+		}
+		sub.sensors[name] = true
+	}
+	for _, key := range filter.keys {
+		sub.keys[key] = true
+	}
+	for name, u := range h.latest {
+		if sub.wants(name) {
+			sub.updates <- sub.filtered(u)
+		}
+	}
+	h.subscriptions[sub] = true
+	return sub, nil
+}
+
+func (h *hub) unsubscribe(sub *subscription) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	delete(h.subscriptions, sub)
+}
+
+func (h *hub) publish(u update) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	previous, seen := h.latest[u.sensor]
+	h.latest[u.sensor] = u
+	for sub := range h.subscriptions {
+		if !sub.wants(u.sensor) {
+			continue
+		}
+		filtered := sub.filtered(u)
+		if sub.filter.changesOnly && seen {
+			before := sub.filtered(previous)
+			if before.err == filtered.err && reflect.DeepEqual(before.readings, filtered.readings) {
+				continue
+			}
+		}
+		select {
+		case sub.updates <- filtered:
+		default:
+			sub.dropped.Add(1)
+		}
+	}
+}
+
+func (h *hub) subscriptionFilters() []subscriptionFilter {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	ret := make([]subscriptionFilter, 0, len(h.subscriptions))
+	for sub := range h.subscriptions {
+		ret = append(ret, sub.filter)
+	}
+	return ret
+}
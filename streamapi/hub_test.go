@@ -0,0 +1,63 @@
+package streamapi
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func receive(t *testing.T, sub *subscription) []update {
+	t.Helper()
+	var ret []update
+	for {
+		select {
+		case u := <-sub.updates:
+			ret = append(ret, u)
+		default:
+			return ret
+		}
+	}
+}
+
+func TestHub(t *testing.T) {
+	h := newHub([]string{"cpu", "throttling"})
+	now := time.Now()
+	h.publish(update{sensor: "cpu", time: now, readings: map[string]interface{}{"usage": 10.0, "governor": "ondemand"}})
+
+	_, err := h.subscribe(subscriptionFilter{sensors: []string{"gpu"}})
+	assert.ErrorContains(t, err, "unknown sensor")
+
+	all, err := h.subscribe(subscriptionFilter{})
+	require.NoError(t, err)
+	throttled, err := h.subscribe(subscriptionFilter{sensors: []string{"throttling"}, keys: []string{"under_voltage"}, changesOnly: true})
+	require.NoError(t, err)
+	assert.Len(t, h.subscriptionFilters(), 2)
+
+	// Subscriptions start with the latest read
+	assert.Equal(t, []update{{sensor: "cpu", time: now, readings: map[string]interface{}{"usage": 10.0, "governor": "ondemand"}}}, receive(t, all))
+	assert.Empty(t, receive(t, throttled))
+
+	h.publish(update{sensor: "throttling", time: now, readings: map[string]interface{}{"under_voltage": false, "temperature": 50.0}})
+	h.publish(update{sensor: "throttling", time: now, readings: map[string]interface{}{"under_voltage": false, "temperature": 51.0}})
+	h.publish(update{sensor: "throttling", time: now, readings: map[string]interface{}{"under_voltage": true, "temperature": 51.0}})
+	h.publish(update{sensor: "throttling", time: now, err: "timed out"})
+	assert.Len(t, receive(t, all), 4)
+	assert.Equal(t, []update{
+		{sensor: "throttling", time: now, readings: map[string]interface{}{"under_voltage": false}},
+		{sensor: "throttling", time: now, readings: map[string]interface{}{"under_voltage": true}},
+		{sensor: "throttling", time: now, err: "timed out"},
+	}, receive(t, throttled))
+
+	// A subscription that doesn't keep up has updates dropped
+	for range subscriptionBuffer + 3 {
+		h.publish(update{sensor: "cpu", time: now})
+	}
+	assert.Len(t, receive(t, all), subscriptionBuffer)
+	assert.Equal(t, uint64(3), all.dropped.Load())
+
+	h.unsubscribe(all)
+	h.unsubscribe(throttled)
+	assert.Empty(t, h.subscriptionFilters())
+}
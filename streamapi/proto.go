@@ -0,0 +1,179 @@
+package streamapi
+
+import (
+	"encoding/json"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Field numbers from readings.proto
+const (
+	subscribeRequestSensors     = 1
+	subscribeRequestKeys        = 2
+	subscribeRequestChangesOnly = 3
+
+	readingUpdateSensor   = 1
+	readingUpdateTime     = 2
+	readingUpdateReadings = 3
+	readingUpdateError    = 4
+	readingUpdateDropped  = 5
+)
+
+const subscribeMethod = "/hwmonitor.v1.ReadingsService/Subscribe"
+
+var serviceDesc = grpc.ServiceDesc{
+	ServiceName: "hwmonitor.v1.ReadingsService",
+	HandlerType: (*any)(nil),
+	Streams: []grpc.StreamDesc{{
+		StreamName:    "Subscribe",
+		Handler:       handleSubscribe,
+		ServerStreams: true,
+	}},
+	Metadata: "readings.proto",
+}
+
+// rawCodec passes already encoded protobuf through gRPC, so the protos don't need to be generated.
+type rawCodec struct{}
+
+func (rawCodec) Marshal(v any) ([]byte, error) {
+	return *v.(*[]byte), nil
+}
+
+func (rawCodec) Unmarshal(data []byte, v any) error {
+	*v.(*[]byte) = data
+	return nil
+}
+
+func (rawCodec) Name() string {
+	return "proto"
+}
+
+func handleSubscribe(srv any, stream grpc.ServerStream) error {
+	var request []byte
+	if err := stream.RecvMsg(&request); err != nil {
+		return err
+	}
+	filter, err := unmarshalSubscribeRequest(request)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	h := srv.(*hub)
+	sub, err := h.subscribe(filter)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	defer h.unsubscribe(sub)
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case u := <-sub.updates:
+			msg, err := marshalReadingUpdate(u, sub.dropped.Swap(0))
+			if err != nil {
+				return status.Error(codes.Internal, err.Error())
+			}
+			if err := stream.SendMsg(&msg); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func unmarshalSubscribeRequest(b []byte) (subscriptionFilter, error) {
+	var filter subscriptionFilter
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			return filter, protowire.ParseError(n)
+		}
+		b = b[n:]
+		switch {
+		case num == subscribeRequestSensors && typ == protowire.BytesType:
+			value, n := protowire.ConsumeString(b)
+			if n < 0 {
+				return filter, protowire.ParseError(n)
+			}
+			filter.sensors = append(filter.sensors, value)
+			b = b[n:]
+		case num == subscribeRequestKeys && typ == protowire.BytesType:
+			value, n := protowire.ConsumeString(b)
+			if n < 0 {
+				return filter, protowire.ParseError(n)
+			}
+			filter.keys = append(filter.keys, value)
+			b = b[n:]
+		case num == subscribeRequestChangesOnly && typ == protowire.VarintType:
+			value, n := protowire.ConsumeVarint(b)
+			if n < 0 {
+				return filter, protowire.ParseError(n)
+			}
+			filter.changesOnly = protowire.DecodeBool(value)
+			b = b[n:]
+		default:
+			// Unknown fields are skipped, so newer clients still work
+			n := protowire.ConsumeFieldValue(num, typ, b)
+			if n < 0 {
+				return filter, protowire.ParseError(n)
+			}
+			b = b[n:]
+		}
+	}
+	return filter, nil
+}
+
+func marshalReadingUpdate(u update, dropped uint64) ([]byte, error) {
+	var b []byte
+	b = protowire.AppendTag(b, readingUpdateSensor, protowire.BytesType)
+	b = protowire.AppendString(b, u.sensor)
+	t, err := proto.Marshal(timestamppb.New(u.time))
+	if err != nil {
+		return nil, err
+	}
+	b = protowire.AppendTag(b, readingUpdateTime, protowire.BytesType)
+	b = protowire.AppendBytes(b, t)
+	if u.readings != nil {
+		readings, err := toStruct(u.readings)
+		if err != nil {
+			return nil, err
+		}
+		r, err := proto.Marshal(readings)
+		if err != nil {
+			return nil, err
+		}
+		b = protowire.AppendTag(b, readingUpdateReadings, protowire.BytesType)
+		b = protowire.AppendBytes(b, r)
+	}
+	if u.err != "" {
+		b = protowire.AppendTag(b, readingUpdateError, protowire.BytesType)
+		b = protowire.AppendString(b, u.err)
+	}
+	if dropped > 0 {
+		b = protowire.AppendTag(b, readingUpdateDropped, protowire.VarintType)
+		b = protowire.AppendVarint(b, dropped)
+	}
+	return b, nil
+}
+
+// toStruct converts readings to a Struct, going through JSON for values such as []string that Struct doesn't take
+// directly.
+func toStruct(readings map[string]interface{}) (*structpb.Struct, error) {
+	if s, err := structpb.NewStruct(readings); err == nil {
+		return s, nil
+	}
+	data, err := json.Marshal(readings)
+	if err != nil {
+		return nil, err
+	}
+	var converted map[string]interface{}
+	if err := json.Unmarshal(data, &converted); err != nil {
+		return nil, err
+	}
+	return structpb.NewStruct(converted)
+}
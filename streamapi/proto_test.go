@@ -0,0 +1,99 @@
+package streamapi
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func marshalSubscribeRequest(sensors []string, changesOnly bool) []byte {
+	var b []byte
+	for _, name := range sensors {
+		b = protowire.AppendTag(b, subscribeRequestSensors, protowire.BytesType)
+		b = protowire.AppendString(b, name)
+	}
+	if changesOnly {
+		b = protowire.AppendTag(b, subscribeRequestChangesOnly, protowire.VarintType)
+		b = protowire.AppendVarint(b, 1)
+	}
+	return b
+}
+
+func TestUnmarshalSubscribeRequest(t *testing.T) {
+	b := marshalSubscribeRequest([]string{"cpu", "fan"}, true)
+	b = protowire.AppendTag(b, subscribeRequestKeys, protowire.BytesType)
+	b = protowire.AppendString(b, "usage")
+	// An unknown field
+	b = protowire.AppendTag(b, 99, protowire.VarintType)
+	b = protowire.AppendVarint(b, 7)
+
+	filter, err := unmarshalSubscribeRequest(b)
+	require.NoError(t, err)
+	assert.Equal(t, subscriptionFilter{sensors: []string{"cpu", "fan"}, keys: []string{"usage"}, changesOnly: true}, filter)
+
+	_, err = unmarshalSubscribeRequest([]byte{0x0a, 0x05, 'c'})
+	assert.Error(t, err)
+}
+
+func TestSubscribe(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	h := newHub([]string{"cpu", "fan"})
+	server := grpc.NewServer(grpc.ForceServerCodec(rawCodec{}))
+	server.RegisterService(&serviceDesc, h)
+	go server.Serve(listener)
+	defer server.Stop()
+
+	now := time.Unix(1700000000, 500)
+	h.publish(update{sensor: "cpu", time: now, readings: map[string]interface{}{"usage": 12.5, "cores": []string{"cpu0", "cpu1"}}})
+
+	conn, err := grpc.NewClient(listener.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer conn.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	subscribe := func(request []byte) grpc.ClientStream {
+		stream, err := conn.NewStream(ctx, &serviceDesc.Streams[0], subscribeMethod, grpc.ForceCodec(rawCodec{}))
+		require.NoError(t, err)
+		require.NoError(t, stream.SendMsg(&request))
+		require.NoError(t, stream.CloseSend())
+		return stream
+	}
+
+	stream := subscribe(marshalSubscribeRequest([]string{"cpu"}, false))
+	var response []byte
+	require.NoError(t, stream.RecvMsg(&response))
+	fields := make(map[protowire.Number][]byte)
+	for b := response; len(b) > 0; {
+		num, _, n := protowire.ConsumeTag(b)
+		require.GreaterOrEqual(t, n, 0)
+		value, m := protowire.ConsumeBytes(b[n:])
+		require.GreaterOrEqual(t, m, 0)
+		fields[num] = value
+		b = b[n+m:]
+	}
+	assert.Equal(t, "cpu", string(fields[readingUpdateSensor]))
+	var timestamp timestamppb.Timestamp
+	require.NoError(t, proto.Unmarshal(fields[readingUpdateTime], &timestamp))
+	assert.Equal(t, now.UnixNano(), timestamp.AsTime().UnixNano())
+	var readings structpb.Struct
+	require.NoError(t, proto.Unmarshal(fields[readingUpdateReadings], &readings))
+	assert.Equal(t, map[string]interface{}{"usage": 12.5, "cores": []interface{}{"cpu0", "cpu1"}}, readings.AsMap())
+
+	stream = subscribe(marshalSubscribeRequest([]string{"gpu"}, false))
+	err = stream.RecvMsg(&response)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
@@ -0,0 +1,305 @@
+package streamapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+
+	"go.viam.com/rdk/components/sensor"
+	"go.viam.com/rdk/logging"
+	"go.viam.com/rdk/resource"
+	viamutils "go.viam.com/utils"
+	"google.golang.org/grpc"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+var (
+	Model       = resource.NewModel(utils.Namespace, "hwmonitor", "stream_api")
+	API         = sensor.API
+	PrettyName  = "Stream API"
+	Description = "A sensor that streams the readings of other sensors over gRPC as they are read"
+	Version     = utils.Version
+)
+
+const (
+	defaultListen     = "/run/hwmonitor/stream.sock"
+	defaultSocketMode = 0660
+	defaultInterval   = 500 * time.Millisecond
+	readTimeout       = 5 * time.Second
+)
+
+type Config struct {
+	resource.Named
+	configLock   sync.Mutex
+	readingsLock sync.RWMutex
+	logger       logging.Logger
+	workers      *viamutils.StoppableWorkers
+	tasks        []*utils.Task
+	hub          *hub
+	sources      []utils.Source
+	listen       string
+	socketMode   os.FileMode
+	interval     time.Duration
+	readCount    int
+	lastErr      error
+}
+
+func init() {
+	resource.RegisterComponent(
+		API,
+		Model,
+		resource.Registration[sensor.Sensor, *ComponentConfig]{Constructor: utils.FilterReadings(NewSensor)})
+}
+
+func NewSensor(ctx context.Context, deps resource.Dependencies, conf resource.Config, logger logging.Logger) (sensor.Sensor, error) {
+	logger.Infof("Starting %s %s", PrettyName, Version)
+	b := Config{
+		Named:  conf.ResourceName().AsNamed(),
+		logger: logger,
+	}
+
+	if err := b.Reconfigure(ctx, deps, conf); err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
+
+func (c *Config) Reconfigure(ctx context.Context, deps resource.Dependencies, rawConf resource.Config) error {
+	c.configLock.Lock()
+	defer c.configLock.Unlock()
+	c.logger.Debugf("Reconfiguring %s", PrettyName)
+
+	c.stop()
+
+	conf, err := resource.NativeConfig[*ComponentConfig](rawConf)
+	if err != nil {
+		return err
+	}
+
+	// In case the component has changed name
+	c.Named = rawConf.ResourceName().AsNamed()
+
+	sources, err := utils.ResolveSources(deps, conf.Sensors)
+	if err != nil {
+		return err
+	}
+	c.sources = sources
+	c.listen = conf.Listen
+	if c.listen == "" {
+		c.listen = defaultListen
+	}
+	c.socketMode = defaultSocketMode
+	if conf.SocketMode != "" {
+		mode, err := strconv.ParseUint(conf.SocketMode, 8, 32)
+		if err != nil {
+			return err
+		}
+		c.socketMode = os.FileMode(mode)
+	}
+	c.interval = time.Duration(conf.IntervalSec * float64(time.Second))
+	if c.interval <= 0 {
+		c.interval = defaultInterval
+	}
+
+	if conf.OnDemand {
+		return nil
+	}
+	return c.start()
+}
+
+// start listens and starts reading the sensors, it does nothing if already started. The config lock must be held.
+func (c *Config) start() error {
+	if c.workers != nil {
+		return nil
+	}
+	var listener net.Listener
+	var err error
+	if filepath.IsAbs(c.listen) {
+		listener, err = utils.ListenUnix(c.listen, c.socketMode)
+	} else {
+		listener, err = net.Listen("tcp", c.listen)
+	}
+	if err != nil {
+		return err
+	}
+
+	names := make([]string, 0, len(c.sources))
+	for _, s := range c.sources {
+		names = append(names, s.Name)
+	}
+	h := newHub(names)
+	server := grpc.NewServer(grpc.ForceServerCodec(rawCodec{}))
+	server.RegisterService(&serviceDesc, h)
+
+	c.readingsLock.Lock()
+	c.readCount = 0
+	c.lastErr = nil
+	c.readingsLock.Unlock()
+
+	c.workers = viamutils.NewBackgroundStoppableWorkers(func(ctx context.Context) {
+		c.serve(ctx, server, listener)
+	})
+	for _, s := range c.sources {
+		c.tasks = append(c.tasks, utils.ScheduleAligned(c.interval, utils.ReadingsSource, func(ctx context.Context) {
+			c.read(ctx, h, s)
+		}))
+	}
+	c.hub = h
+	c.logger.Infof("Streaming readings on %s", c.listen)
+	return nil
+}
+
+// stop stops serving and reading the sensors. The config lock must be held.
+func (c *Config) stop() {
+	if c.workers == nil {
+		return
+	}
+	c.logger.Debug("Stopping background workers")
+	for _, task := range c.tasks {
+		task.Stop()
+	}
+	c.workers.Stop()
+	c.logger.Debugf("Background workers stopped")
+	c.tasks = nil
+	c.workers = nil
+	c.hub = nil
+}
+
+func (c *Config) serve(ctx context.Context, server *grpc.Server, listener net.Listener) {
+	go func() {
+		<-ctx.Done()
+		// Stop rather than GracefulStop, subscriptions never finish on their own
+		server.Stop()
+	}()
+	if err := server.Serve(listener); err != nil && !errors.Is(err, grpc.ErrServerStopped) {
+		c.logger.Errorf("Stream API stopped: %v", err)
+		c.readingsLock.Lock()
+		c.lastErr = err
+		c.readingsLock.Unlock()
+	}
+}
+
+// read publishes a reading of a sensor. Each sensor is its own task, so a slow sensor doesn't delay the updates of the
+// others.
+func (c *Config) read(ctx context.Context, h *hub, s utils.Source) {
+	readCtx, cancel := context.WithTimeout(ctx, readTimeout)
+	readings, err := s.Sensor.Readings(readCtx, nil)
+	cancel()
+	if ctx.Err() != nil {
+		return
+	}
+	u := update{sensor: s.Name, time: time.Now(), readings: readings}
+	if err != nil {
+		u = update{sensor: s.Name, time: u.time, err: err.Error()}
+	}
+	h.publish(u)
+	c.readingsLock.Lock()
+	c.readCount++
+	c.readingsLock.Unlock()
+}
+
+func (c *Config) Readings(ctx context.Context, extra map[string]interface{}) (map[string]interface{}, error) {
+	c.configLock.Lock()
+	listening := c.workers != nil
+	subscriptions := 0
+	if c.hub != nil {
+		subscriptions = len(c.hub.subscriptionFilters())
+	}
+	c.configLock.Unlock()
+
+	c.readingsLock.RLock()
+	defer c.readingsLock.RUnlock()
+	ret := map[string]interface{}{
+		"listen":        c.listen,
+		"listening":     listening,
+		"subscriptions": subscriptions,
+		"read_count":    c.readCount,
+	}
+	if c.lastErr != nil {
+		ret["last_error"] = c.lastErr.Error()
+	}
+	return ret, nil
+}
+
+func (c *Config) DoCommand(ctx context.Context, cmd map[string]interface{}) (map[string]interface{}, error) {
+	c.configLock.Lock()
+	defer c.configLock.Unlock()
+
+	command, ok := cmd["command"].(string)
+	if !ok {
+		return nil, errors.New("missing or invalid 'command' field")
+	}
+
+	switch command {
+	case "start":
+		return c.handleStart()
+	case "stop":
+		return c.handleStop()
+	case "status":
+		return c.handleStatus()
+	default:
+		return nil, fmt.Errorf("unknown command: %s", command)
+	}
+}
+
+func (c *Config) handleStart() (map[string]interface{}, error) {
+	if err := c.start(); err != nil {
+		return nil, err
+	}
+	return c.handleStatus()
+}
+
+func (c *Config) handleStop() (map[string]interface{}, error) {
+	c.stop()
+	return c.handleStatus()
+}
+
+// handleStatus returns where to connect and what is subscribed, so a client that only knows the robot can find the
+// stream.
+func (c *Config) handleStatus() (map[string]interface{}, error) {
+	subscriptions := make([]interface{}, 0)
+	if c.hub != nil {
+		for _, filter := range c.hub.subscriptionFilters() {
+			subscriptions = append(subscriptions, map[string]interface{}{
+				"sensors":      stringsToInterfaces(filter.sensors),
+				"keys":         stringsToInterfaces(filter.keys),
+				"changes_only": filter.changesOnly,
+			})
+		}
+	}
+	return map[string]interface{}{
+		"listen":        c.listen,
+		"listening":     c.workers != nil,
+		"method":        subscribeMethod,
+		"subscriptions": subscriptions,
+	}, nil
+}
+
+// stringsToInterfaces converts a []string so the result can be returned over DoCommand.
+func stringsToInterfaces(values []string) []interface{} {
+	ret := make([]interface{}, 0, len(values))
+	for _, value := range values {
+		ret = append(ret, value)
+	}
+	return ret
+}
+
+func (c *Config) Close(ctx context.Context) error {
+	c.configLock.Lock()
+	defer c.configLock.Unlock()
+	c.logger.Infof("Shutting down %s", PrettyName)
+	c.stop()
+	c.logger.Infof("%s Shutdown complete", PrettyName)
+	return nil
+}
+
+func (c *Config) Ready(ctx context.Context, extra map[string]interface{}) (bool, error) {
+	return false, nil
+}
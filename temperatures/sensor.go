@@ -2,6 +2,7 @@ package temperatures
 
 import (
 	"context"
+	"fmt"
 	"sync"
 
 	"go.viam.com/rdk/components/sensor"
@@ -27,13 +28,15 @@ type Config struct {
 	cancelCtx       context.Context
 	cancelFunc      func()
 	temperatureFunc func(ctx context.Context) (*sensors.SystemTemperatures, error)
+	units           utils.Units
 }
 
 func init() {
 	resource.RegisterComponent(
 		API,
 		Model,
-		resource.Registration[sensor.Sensor, *ComponentConfig]{Constructor: NewSensor})
+		resource.Registration[sensor.Sensor, *ComponentConfig]{Constructor: utils.FilterReadings(NewSensor)})
+	utils.RegisterSimulator(Model, simulate)
 }
 
 func NewSensor(ctx context.Context, deps resource.Dependencies, conf resource.Config, logger logging.Logger) (sensor.Sensor, error) {
@@ -62,6 +65,12 @@ func (c *Config) Reconfigure(ctx context.Context, _ resource.Dependencies, conf
 	// In case the module has changed name
 	c.Named = conf.ResourceName().AsNamed()
 
+	newConf, err := resource.NativeConfig[*ComponentConfig](conf)
+	if err != nil {
+		return err
+	}
+	c.units = newConf.Units
+
 	temperatureFunc, err := GetTemperatureFunc()
 	if err != nil {
 		return err
@@ -92,7 +101,34 @@ func (c *Config) Readings(ctx context.Context, extra map[string]interface{}) (ma
 		res[key] = value
 	}
 
-	return res, nil
+	return convertReadings(c.units, res), nil
+}
+
+// convertReadings converts the temperatures from Celsius to the configured unit.
+func convertReadings(units utils.Units, readings map[string]interface{}) map[string]interface{} {
+	for key, value := range readings {
+		readings[key] = units.Convert(utils.Temperature, value)
+	}
+	units.AddUnit(readings, utils.Temperature)
+	return readings
+}
+
+// Capabilities reports the backend and whether it reports a CPU and GPU temperature on this board.
+func (c *Config) Capabilities(ctx context.Context) utils.Capabilities {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	caps := utils.Capabilities{Backend: backend(), Unavailable: make(map[string]string)}
+	temperatures, err := c.temperatureFunc(ctx)
+	if err != nil {
+		return caps
+	}
+	if temperatures.CPU == nil {
+		caps.Unavailable["CPU"] = fmt.Sprintf("not reported by the %s backend", caps.Backend)
+	}
+	if temperatures.GPU == nil {
+		caps.Unavailable["GPU"] = fmt.Sprintf("not reported by the %s backend", caps.Backend)
+	}
+	return caps
 }
 
 func (c *Config) Close(ctx context.Context) error {
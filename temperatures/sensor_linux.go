@@ -3,19 +3,37 @@ package temperatures
 import (
 	"context"
 
-	"github.com/rinzlerlabs/sbcidentify"
-	"github.com/rinzlerlabs/sbcidentify/boardtype"
 	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/linux"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/linux/allwinner"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/linux/beaglebone"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/linux/imx"
 	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/linux/jetson"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/linux/odroid"
 	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/linux/raspberrypi"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/linux/rockchip"
 	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/sensors"
 )
 
+// backend is the board family whose temperature sensors are read.
+func backend() string {
+	return string(linux.Board().Family)
+}
+
 func GetTemperatureFunc() (func(ctx context.Context) (*sensors.SystemTemperatures, error), error) {
-	if sbcidentify.IsBoardType(boardtype.RaspberryPi) {
+	if linux.IsFamily(linux.BoardFamilyRaspberryPi) {
 		return raspberrypi.GetTemperatures, nil
-	} else if sbcidentify.IsBoardType(boardtype.Jetson) {
+	} else if linux.IsFamily(linux.BoardFamilyJetson) {
 		return jetson.GetTemperatures, nil
+	} else if linux.IsFamily(linux.BoardFamilyRockchip) {
+		return rockchip.GetTemperatures, nil
+	} else if linux.IsFamily(linux.BoardFamilyAllwinner) {
+		return allwinner.GetTemperatures, nil
+	} else if linux.IsFamily(linux.BoardFamilyBeagleBone) {
+		return beaglebone.GetTemperatures, nil
+	} else if linux.IsFamily(linux.BoardFamilyODROID) {
+		return odroid.GetTemperatures, nil
+	} else if linux.IsFamily(linux.BoardFamilyIMX) {
+		return imx.GetTemperatures, nil
 	} else {
 		return linux.GetTemperatures, nil
 	}
@@ -7,6 +7,11 @@ import (
 	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/windows"
 )
 
+// backend is where the temperatures are read from.
+func backend() string {
+	return "windows"
+}
+
 func GetTemperatureFunc() (func(ctx context.Context) (*sensors.SystemTemperatures, error), error) {
 	return windows.GetTemperatures, nil
 }
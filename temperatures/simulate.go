@@ -0,0 +1,12 @@
+package temperatures
+
+import "github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+
+// simulate returns the temperatures of a Raspberry Pi 5, which throttles at 85C.
+func simulate(sim *utils.Simulation) map[string]interface{} {
+	return map[string]interface{}{
+		"CPU":  sim.Value(46, 71, 84.5, 2),
+		"PMIC": sim.Value(41, 54, 61, 1.5),
+		"RP1":  sim.Value(39, 49, 57, 1.5),
+	}
+}
@@ -0,0 +1,109 @@
+package thermalevents
+
+import (
+	"errors"
+	"fmt"
+)
+
+type Zone struct {
+	ThermalZone  string  `json:"thermal_zone"`            // The type of the thermal zone, e.g. cpu-thermal
+	WarningTemp  float64 `json:"warning_temp,omitempty"`  // Defaults to the component's warning_temp
+	CriticalTemp float64 `json:"critical_temp,omitempty"` // Defaults to the component's critical_temp
+}
+
+type ComponentConfig struct {
+	Zones             []Zone  `json:"zones,omitempty"`               // Defaults to every thermal zone
+	WarningTemp       float64 `json:"warning_temp,omitempty"`        // Defaults to 10C below critical_temp
+	CriticalTemp      float64 `json:"critical_temp,omitempty"`       // Defaults to the zone's lowest passive trip point, or 80C without one
+	Hysteresis        float64 `json:"hysteresis,omitempty"`          // How far below a threshold a zone has to cool before it's back under it, defaults to 3C
+	SampleIntervalSec float64 `json:"sample_interval_sec,omitempty"` // How often the temperatures are sampled, defaults to 1
+}
+
+func (conf *ComponentConfig) Validate(path string) ([]string, error) {
+	if conf.Hysteresis < 0 || conf.SampleIntervalSec < 0 {
+		return nil, errors.New("hysteresis and sample_interval_sec must not be negative")
+	}
+	if err := validateThresholds(conf.WarningTemp, conf.CriticalTemp); err != nil {
+		return nil, err
+	}
+	seen := make(map[string]bool, len(conf.Zones))
+	for i, z := range conf.Zones {
+		if z.ThermalZone == "" {
+			return nil, fmt.Errorf("zone %d: thermal_zone is required", i)
+		}
+		if seen[z.ThermalZone] {
+			return nil, fmt.Errorf("zone %s: configured more than once", z.ThermalZone)
+		}
+		seen[z.ThermalZone] = true
+		if err := validateThresholds(z.WarningTemp, z.CriticalTemp); err != nil {
+			return nil, fmt.Errorf("zone %s: %w", z.ThermalZone, err)
+		}
+	}
+	return nil, nil
+}
+
+// validateThresholds checks the configured thresholds, 0 is one that's left to its default.
+func validateThresholds(warning, critical float64) error {
+	if warning < 0 || critical < 0 {
+		return errors.New("warning_temp and critical_temp must not be negative")
+	}
+	if warning > 0 && critical > 0 && warning >= critical {
+		return errors.New("warning_temp must be below critical_temp")
+	}
+	return nil
+}
+
+func (conf *ComponentConfig) hysteresis() float64 {
+	if conf.Hysteresis == 0 {
+		return defaultHysteresis
+	}
+	return conf.Hysteresis
+}
+
+func (conf *ComponentConfig) sampleIntervalSec() float64 {
+	if conf.SampleIntervalSec == 0 {
+		return defaultSampleIntervalSec
+	}
+	return conf.SampleIntervalSec
+}
+
+// thresholds returns the warning and critical temperatures of a thermal zone with the trip points, the zone's own
+// thresholds first, then the component's, then the defaults.
+func (conf *ComponentConfig) thresholds(zoneType string, passiveTrip float64) (warning, critical float64, err error) {
+	var zone Zone
+	for _, z := range conf.Zones {
+		if z.ThermalZone == zoneType {
+			zone = z
+		}
+	}
+	critical = firstSet(zone.CriticalTemp, conf.CriticalTemp, passiveTrip, defaultCriticalTemp)
+	warning = firstSet(zone.WarningTemp, conf.WarningTemp, critical-defaultWarningMargin)
+	// A configured warning can be above a critical threshold that's a default
+	if warning >= critical {
+		return 0, 0, fmt.Errorf("zone %s: warning_temp %v must be below critical_temp %v", zoneType, warning, critical)
+	}
+	return warning, critical, nil
+}
+
+// follows returns whether the zone is followed, every zone is when none are configured.
+func (conf *ComponentConfig) follows(zone string) bool {
+	if len(conf.Zones) == 0 {
+		return true
+	}
+	for _, z := range conf.Zones {
+		if z.ThermalZone == zone {
+			return true
+		}
+	}
+	return false
+}
+
+// firstSet returns the first value that isn't 0.
+func firstSet(values ...float64) float64 {
+	for _, v := range values {
+		if v != 0 {
+			return v
+		}
+	}
+	return 0
+}
@@ -0,0 +1,51 @@
+package thermalevents
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidate(t *testing.T) {
+	_, err := (&ComponentConfig{}).Validate("")
+	assert.NoError(t, err)
+	_, err = (&ComponentConfig{WarningTemp: 65, Zones: []Zone{{ThermalZone: "cpu-thermal", CriticalTemp: 85}, {ThermalZone: "gpu-thermal"}}}).Validate("")
+	assert.NoError(t, err)
+
+	for _, conf := range []*ComponentConfig{
+		{Hysteresis: -1},
+		{SampleIntervalSec: -1},
+		{WarningTemp: 80, CriticalTemp: 70},
+		{CriticalTemp: -1},
+		{Zones: []Zone{{}}},
+		{Zones: []Zone{{ThermalZone: "cpu-thermal"}, {ThermalZone: "cpu-thermal"}}},
+		{Zones: []Zone{{ThermalZone: "cpu-thermal", WarningTemp: 90, CriticalTemp: 85}}},
+	} {
+		_, err := conf.Validate("")
+		assert.Error(t, err, conf)
+	}
+}
+
+func TestThresholds(t *testing.T) {
+	conf := &ComponentConfig{WarningTemp: 65, Zones: []Zone{{ThermalZone: "cpu-thermal", CriticalTemp: 85}, {ThermalZone: "soc-thermal", WarningTemp: 90}}}
+	warning, critical, err := conf.thresholds("cpu-thermal", 95)
+	require.NoError(t, err)
+	assert.Equal(t, []float64{65, 85}, []float64{warning, critical})
+
+	// Without a critical temperature it's the passive trip point, or 80
+	warning, critical, err = (&ComponentConfig{}).thresholds("gpu-thermal", 95)
+	require.NoError(t, err)
+	assert.Equal(t, []float64{85, 95}, []float64{warning, critical})
+	warning, critical, err = (&ComponentConfig{}).thresholds("cpu-thermal", 0)
+	require.NoError(t, err)
+	assert.Equal(t, []float64{70, 80}, []float64{warning, critical})
+
+	// A warning above the default critical temperature
+	_, _, err = conf.thresholds("soc-thermal", 0)
+	assert.Error(t, err)
+
+	assert.True(t, conf.follows("cpu-thermal"))
+	assert.False(t, conf.follows("gpu-thermal"))
+	assert.True(t, (&ComponentConfig{}).follows("gpu-thermal"))
+}
@@ -0,0 +1,318 @@
+package thermalevents
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"go.viam.com/rdk/components/sensor"
+	"go.viam.com/rdk/logging"
+	"go.viam.com/rdk/resource"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/linux"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+var (
+	Model       = resource.NewModel(utils.Namespace, "hwmonitor", "thermal_event_monitor")
+	API         = sensor.API
+	PrettyName  = "Thermal Event Monitor"
+	Description = "A sensor that logs when thermal zones cross their warning and critical temperatures and counts how long they were over them"
+	Version     = utils.Version
+)
+
+const (
+	defaultSampleIntervalSec = 1
+	defaultHysteresis        = 3
+	defaultCriticalTemp      = 80
+	defaultWarningMargin     = 10
+	maxEvents                = 100
+	// maxGapSamples is how many sample intervals can pass between samples before the time in between isn't counted
+	maxGapSamples = 3
+)
+
+type Config struct {
+	resource.Named
+	configLock   sync.Mutex
+	readingsLock sync.RWMutex
+	logger       logging.Logger
+	task         *utils.Task
+	conf         *ComponentConfig
+	trips        map[string]linux.ThermalTrips
+	zones        map[string]*zoneTracker
+	events       utils.CappedCollection[thermalEvent]
+	lastEvent    *thermalEvent
+	lastErr      error
+	freshness    utils.Freshness
+	// counters count the crossings and the time over the thresholds across restarts
+	counters *utils.PersistentCounters
+}
+
+func init() {
+	resource.RegisterComponent(
+		API,
+		Model,
+		resource.Registration[sensor.Sensor, *ComponentConfig]{Constructor: utils.FilterReadings(NewSensor)})
+	utils.RegisterSimulator(Model, simulate)
+}
+
+func NewSensor(ctx context.Context, deps resource.Dependencies, conf resource.Config, logger logging.Logger) (sensor.Sensor, error) {
+	logger.Infof("Starting %s %s", PrettyName, Version)
+	b := Config{
+		Named:  conf.ResourceName().AsNamed(),
+		logger: logger,
+		zones:  make(map[string]*zoneTracker),
+		events: utils.NewCappedCollection[thermalEvent](maxEvents),
+	}
+
+	if err := b.Reconfigure(ctx, deps, conf); err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
+
+func (c *Config) Reconfigure(ctx context.Context, _ resource.Dependencies, rawConf resource.Config) error {
+	c.configLock.Lock()
+	defer c.configLock.Unlock()
+	c.logger.Debugf("Reconfiguring %s", PrettyName)
+
+	if c.task != nil {
+		c.logger.Debug("Stopping polling")
+		c.task.Stop()
+		c.logger.Debugf("Polling stopped")
+		c.task = nil
+	}
+
+	conf, err := resource.NativeConfig[*ComponentConfig](rawConf)
+	if err != nil {
+		return err
+	}
+
+	// In case the component has changed name
+	c.Named = rawConf.ResourceName().AsNamed()
+
+	trips, err := linux.ReadThermalTrips(ctx, linux.ThermalZonesRoot)
+	if err != nil {
+		return fmt.Errorf("failed to read the thermal zones' trip points: %w", err)
+	}
+	for _, z := range conf.Zones {
+		if _, _, err := conf.thresholds(z.ThermalZone, trips[z.ThermalZone].Passive); err != nil {
+			return err
+		}
+	}
+	bootID, err := linux.ReadBootID(ctx)
+	if err != nil {
+		return err
+	}
+
+	c.readingsLock.Lock()
+	// Zones keep their state unless their thresholds changed, a zone over new thresholds logs a crossing again
+	zones := make(map[string]*zoneTracker, len(c.zones))
+	for zone, tracker := range c.zones {
+		if !conf.follows(zone) {
+			continue
+		}
+		warning, critical, err := conf.thresholds(zone, trips[zone].Passive)
+		if err == nil && tracker.thresholds[0].temp == warning && tracker.thresholds[1].temp == critical {
+			tracker.hysteresis = conf.hysteresis()
+			zones[zone] = tracker
+		}
+	}
+	c.conf = conf
+	c.trips = trips
+	c.zones = zones
+	c.lastErr = nil
+	c.counters = utils.ReloadCounters(c.counters, c.Name().Name, bootID, c.logger)
+	c.readingsLock.Unlock()
+
+	interval := time.Duration(conf.sampleIntervalSec() * float64(time.Second))
+	c.task = utils.Schedule(interval, func(ctx context.Context) {
+		c.sample(ctx, maxGapSamples*interval)
+	})
+	return nil
+}
+
+// sample follows the temperature of every zone across its thresholds, the scheduler calls it every interval.
+func (c *Config) sample(ctx context.Context, maxGap time.Duration) {
+	temperatures, err := linux.ReadThermalZones(ctx, linux.ThermalZonesRoot)
+	if ctx.Err() != nil {
+		return
+	}
+	now := time.Now()
+	c.readingsLock.Lock()
+	defer c.readingsLock.Unlock()
+	if err != nil {
+		if c.lastErr == nil {
+			c.logger.Warnf("Failed to read the thermal zones: %v", err)
+		}
+		c.lastErr = err
+		c.freshness.Failed(err)
+		return
+	}
+	c.lastErr = nil
+	c.freshness.Succeeded(now)
+	for zone, temp := range temperatures {
+		tracker, err := c.tracker(zone)
+		if err != nil {
+			c.lastErr = err
+			continue
+		}
+		if tracker == nil {
+			continue
+		}
+		events, over := tracker.add(now, temp, maxGap)
+		for level, duration := range over {
+			c.counters.Add(overKey(zone, level), duration.Milliseconds())
+		}
+		for _, event := range events {
+			c.recordEvent(event)
+		}
+	}
+}
+
+// tracker returns the tracker of a zone, nil when the zone isn't followed. The lock must be held.
+func (c *Config) tracker(zone string) (*zoneTracker, error) {
+	if tracker, ok := c.zones[zone]; ok {
+		return tracker, nil
+	}
+	if !c.conf.follows(zone) {
+		return nil, nil
+	}
+	warning, critical, err := c.conf.thresholds(zone, c.trips[zone].Passive)
+	if err != nil {
+		return nil, err
+	}
+	tracker := newZoneTracker(zone, warning, critical, c.conf.hysteresis())
+	c.zones[zone] = tracker
+	return tracker, nil
+}
+
+// recordEvent logs and keeps an event, and counts crossings. The lock must be held.
+func (c *Config) recordEvent(event thermalEvent) {
+	c.events.Push(event)
+	c.lastEvent = &event
+	switch event.Type {
+	case eventCrossed:
+		c.counters.Add(countKey(event.Zone, event.Level), 1)
+		c.logger.Warnf("Thermal zone %s is over its %s temperature at %.1fC", event.Zone, event.Level, event.Temp)
+	case eventCleared:
+		c.logger.Infof("Thermal zone %s is back under its %s temperature after %v, it peaked at %.1fC", event.Zone, event.Level, event.Duration.Round(time.Second), event.PeakTemp)
+	}
+}
+
+func countKey(zone, level string) string {
+	return zone + "_" + level + "_count"
+}
+
+func overKey(zone, level string) string {
+	return zone + "_" + level + "_ms"
+}
+
+func (c *Config) Readings(ctx context.Context, extra map[string]interface{}) (map[string]interface{}, error) {
+	c.readingsLock.RLock()
+	defer c.readingsLock.RUnlock()
+	ret := make(map[string]interface{})
+	highest := levelNormal
+	for _, tracker := range c.zones {
+		addZoneReadings(ret, tracker, c.counters.Get)
+		if level := tracker.level(); level == levelCritical || highest == levelNormal {
+			highest = level
+		}
+	}
+	ret["highest_level"] = highest
+	if c.lastEvent != nil {
+		addLastEventReadings(ret, *c.lastEvent)
+	}
+	ret["counters_since"] = c.counters.Since().Format(time.RFC3339)
+	if c.lastErr != nil {
+		ret["last_error"] = c.lastErr.Error()
+	}
+	interval := time.Duration(c.conf.sampleIntervalSec() * float64(time.Second))
+	return c.freshness.Stamp(ret, interval, time.Now()), nil
+}
+
+// addZoneReadings adds a zone's temperature, thresholds and level, and from the counters the crossings of and the time
+// over each threshold.
+func addZoneReadings(ret map[string]interface{}, z *zoneTracker, counter func(key string) int64) {
+	ret[z.zone+"_temp"] = utils.RoundValue(z.temp, 2)
+	ret[z.zone+"_level"] = z.level()
+	for _, t := range z.thresholds {
+		ret[z.zone+"_"+t.level+"_temp"] = t.temp
+		ret[z.zone+"_"+t.level+"_count"] = counter(countKey(z.zone, t.level))
+		ret[z.zone+"_"+t.level+"_sec"] = utils.RoundValue(float64(counter(overKey(z.zone, t.level)))/1000, 1)
+	}
+}
+
+// addLastEventReadings adds which zone crossed or cleared which threshold last, and when.
+func addLastEventReadings(ret map[string]interface{}, event thermalEvent) {
+	ret["last_event_zone"] = event.Zone
+	ret["last_event_level"] = event.Level
+	ret["last_event_type"] = event.Type
+	ret["last_event_time"] = event.Time.Format(time.RFC3339)
+}
+
+func (c *Config) DoCommand(ctx context.Context, cmd map[string]interface{}) (map[string]interface{}, error) {
+	command, ok := cmd["command"].(string)
+	if !ok {
+		return nil, errors.New("missing or invalid 'command' field")
+	}
+
+	switch command {
+	case "get_events":
+		return c.handleGetEvents()
+	default:
+		return nil, fmt.Errorf("unknown command: %s", command)
+	}
+}
+
+func (c *Config) handleGetEvents() (map[string]interface{}, error) {
+	c.readingsLock.RLock()
+	events := c.events.Items()
+	c.readingsLock.RUnlock()
+	// The collection overwrites the oldest event once it is full, so it isn't in order
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].Time.Before(events[j].Time)
+	})
+	ret := make([]interface{}, 0, len(events))
+	for _, event := range events {
+		ret = append(ret, eventMap(event))
+	}
+	return map[string]interface{}{"events": ret}, nil
+}
+
+// eventMap returns an event as get_events reports it.
+func eventMap(event thermalEvent) map[string]interface{} {
+	ret := map[string]interface{}{
+		"zone":  event.Zone,
+		"level": event.Level,
+		"type":  event.Type,
+		"time":  event.Time.Format(time.RFC3339),
+		"temp":  utils.RoundValue(event.Temp, 2),
+	}
+	if event.Type == eventCleared {
+		ret["peak_temp"] = utils.RoundValue(event.PeakTemp, 2)
+		ret["duration_sec"] = utils.RoundValue(event.Duration.Seconds(), 1)
+	}
+	return ret
+}
+
+func (c *Config) Close(ctx context.Context) error {
+	c.configLock.Lock()
+	defer c.configLock.Unlock()
+	c.logger.Infof("Shutting down %s", PrettyName)
+	if c.task != nil {
+		c.task.Stop()
+	}
+	if err := c.counters.Flush(); err != nil {
+		c.logger.Warnf("Failed to save the counters to %s: %v", c.counters.Path(), err)
+	}
+	c.logger.Infof("%s Shutdown complete", PrettyName)
+	return nil
+}
+
+func (c *Config) Ready(ctx context.Context, extra map[string]interface{}) (bool, error) {
+	return false, nil
+}
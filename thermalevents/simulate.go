@@ -0,0 +1,33 @@
+package thermalevents
+
+import (
+	"time"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+// simulate returns the CPU zone of a Raspberry Pi 5, which has no passive trip point so its thresholds are the
+// defaults. It's over its warning temperature about half of the time when loaded and over both when throttling.
+func simulate(sim *utils.Simulation) map[string]interface{} {
+	now := time.Now()
+	tracker := newZoneTracker("cpu-thermal", defaultCriticalTemp-defaultWarningMargin, defaultCriticalTemp, defaultHysteresis)
+	events, _ := tracker.add(now, sim.Value(46, 71, 84.5, 2), time.Second)
+	counters := map[string]int64{
+		countKey("cpu-thermal", levelWarning):  int64(sim.Level(0, 4, 1)),
+		overKey("cpu-thermal", levelWarning):   int64(sim.Counter(0, 0, 500, 1000)),
+		countKey("cpu-thermal", levelCritical): int64(sim.Level(0, 0, 1)),
+		overKey("cpu-thermal", levelCritical):  int64(sim.Counter(0, 0, 0, 1000)),
+	}
+	ret := map[string]interface{}{
+		"highest_level":  tracker.level(),
+		"counters_since": sim.Started().Format(time.RFC3339),
+	}
+	addZoneReadings(ret, tracker, func(key string) int64 { return counters[key] })
+	if len(events) > 0 {
+		// The zone has been over since the simulation started
+		event := events[len(events)-1]
+		event.Time = sim.Started()
+		addLastEventReadings(ret, event)
+	}
+	return ret
+}
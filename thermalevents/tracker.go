@@ -0,0 +1,91 @@
+package thermalevents
+
+import (
+	"time"
+)
+
+const (
+	levelNormal   = "normal"
+	levelWarning  = "warning"
+	levelCritical = "critical"
+
+	eventCrossed = "crossed"
+	eventCleared = "cleared"
+)
+
+type thermalEvent struct {
+	Zone  string
+	Level string
+	// Type is crossed when the zone went over the level's threshold and cleared when it cooled down past the hysteresis
+	Type string
+	Time time.Time
+	Temp float64
+	// PeakTemp and Duration are of the whole excursion, for a cleared event
+	PeakTemp float64
+	Duration time.Duration
+}
+
+// threshold is one level of a zone, it's over from when the zone reaches temp until it cools below temp less the
+// hysteresis, so a zone hovering around a threshold doesn't flood the log.
+type threshold struct {
+	level string
+	temp  float64
+	over  bool
+	since time.Time
+	peak  float64
+}
+
+// zoneTracker follows a thermal zone's temperature across its thresholds.
+type zoneTracker struct {
+	zone       string
+	hysteresis float64
+	// thresholds are warning then critical
+	thresholds []*threshold
+	temp       float64
+	last       time.Time
+}
+
+func newZoneTracker(zone string, warning, critical, hysteresis float64) *zoneTracker {
+	return &zoneTracker{
+		zone:       zone,
+		hysteresis: hysteresis,
+		thresholds: []*threshold{{level: levelWarning, temp: warning}, {level: levelCritical, temp: critical}},
+	}
+}
+
+// add adds a sample of the zone's temperature and returns the thresholds it crossed or cleared, and how long it's been
+// over each level since the previous sample. A gap longer than maxGap, e.g. a suspend, isn't counted as over.
+func (z *zoneTracker) add(now time.Time, temp float64, maxGap time.Duration) ([]thermalEvent, map[string]time.Duration) {
+	var events []thermalEvent
+	over := make(map[string]time.Duration)
+	elapsed := now.Sub(z.last)
+	counted := !z.last.IsZero() && elapsed > 0 && elapsed <= maxGap
+	for _, t := range z.thresholds {
+		if t.over && counted {
+			over[t.level] = elapsed
+		}
+		switch {
+		case !t.over && temp >= t.temp:
+			t.over, t.since, t.peak = true, now, temp
+			events = append(events, thermalEvent{Zone: z.zone, Level: t.level, Type: eventCrossed, Time: now, Temp: temp})
+		case t.over && temp < t.temp-z.hysteresis:
+			t.over = false
+			events = append(events, thermalEvent{Zone: z.zone, Level: t.level, Type: eventCleared, Time: now, Temp: temp, PeakTemp: t.peak, Duration: now.Sub(t.since)})
+		case t.over:
+			t.peak = max(t.peak, temp)
+		}
+	}
+	z.temp, z.last = temp, now
+	return events, over
+}
+
+// level returns the highest level the zone is over.
+func (z *zoneTracker) level() string {
+	level := levelNormal
+	for _, t := range z.thresholds {
+		if t.over {
+			level = t.level
+		}
+	}
+	return level
+}
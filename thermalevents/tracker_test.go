@@ -0,0 +1,67 @@
+package thermalevents
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestZoneTracker(t *testing.T) {
+	start := time.Unix(1700000000, 0)
+	tracker := newZoneTracker("cpu-thermal", 70, 80, 3)
+	overTotal := make(map[string]time.Duration)
+	var events []thermalEvent
+	// A 30 second excursion over both thresholds that hovers around the warning threshold as it cools down
+	temps := []float64{65, 69, 72, 78, 81, 84, 79, 76, 70, 69, 68, 66, 65}
+	for i, temp := range temps {
+		e, over := tracker.add(start.Add(time.Duration(i*5)*time.Second), temp, 15*time.Second)
+		events = append(events, e...)
+		for level, d := range over {
+			overTotal[level] += d
+		}
+		if temp == 84 {
+			assert.Equal(t, levelCritical, tracker.level())
+		}
+	}
+	require.Len(t, events, 4)
+	assert.Equal(t, thermalEvent{Zone: "cpu-thermal", Level: levelWarning, Type: eventCrossed, Time: start.Add(10 * time.Second), Temp: 72}, events[0])
+	assert.Equal(t, thermalEvent{Zone: "cpu-thermal", Level: levelCritical, Type: eventCrossed, Time: start.Add(20 * time.Second), Temp: 81}, events[1])
+	// 79 is within the hysteresis of the critical threshold, 76 isn't
+	assert.Equal(t, thermalEvent{Zone: "cpu-thermal", Level: levelCritical, Type: eventCleared, Time: start.Add(35 * time.Second), Temp: 76, PeakTemp: 84, Duration: 15 * time.Second}, events[2])
+	// 69 and 68 are within the hysteresis of the warning threshold
+	assert.Equal(t, thermalEvent{Zone: "cpu-thermal", Level: levelWarning, Type: eventCleared, Time: start.Add(55 * time.Second), Temp: 66, PeakTemp: 84, Duration: 45 * time.Second}, events[3])
+	assert.Equal(t, 45*time.Second, overTotal[levelWarning])
+	assert.Equal(t, 15*time.Second, overTotal[levelCritical])
+	assert.Equal(t, levelNormal, tracker.level())
+}
+
+func TestZoneTrackerGap(t *testing.T) {
+	start := time.Unix(1700000000, 0)
+	tracker := newZoneTracker("cpu-thermal", 70, 80, 3)
+	tracker.add(start, 75, 3*time.Second)
+	// A suspend isn't counted as time over the threshold
+	_, over := tracker.add(start.Add(time.Hour), 75, 3*time.Second)
+	assert.Empty(t, over)
+	_, over = tracker.add(start.Add(time.Hour+time.Second), 75, 3*time.Second)
+	assert.Equal(t, map[string]time.Duration{levelWarning: time.Second}, over)
+}
+
+func TestZoneReadings(t *testing.T) {
+	tracker := newZoneTracker("cpu-thermal", 70, 80, 3)
+	tracker.add(time.Unix(1700000000, 0), 74.456, time.Second)
+	counters := map[string]int64{countKey("cpu-thermal", levelWarning): 3, overKey("cpu-thermal", levelWarning): 95250}
+	ret := make(map[string]interface{})
+	addZoneReadings(ret, tracker, func(key string) int64 { return counters[key] })
+	assert.Equal(t, map[string]interface{}{
+		"cpu-thermal_temp":           74.46,
+		"cpu-thermal_level":          levelWarning,
+		"cpu-thermal_warning_temp":   70.0,
+		"cpu-thermal_warning_count":  int64(3),
+		"cpu-thermal_warning_sec":    95.3,
+		"cpu-thermal_critical_temp":  80.0,
+		"cpu-thermal_critical_count": int64(0),
+		"cpu-thermal_critical_sec":   0.0,
+	}, ret)
+}
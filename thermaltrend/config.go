@@ -0,0 +1,98 @@
+package thermaltrend
+
+import (
+	"errors"
+	"fmt"
+)
+
+// maxWindowSec keeps a mistyped window from taking all of the board's memory
+const maxWindowSec = 24 * 3600
+
+type Zone struct {
+	Name         string  `json:"name,omitempty"`          // The prefix of the readings, defaults to the thermal zone or the key
+	ThermalZone  string  `json:"thermal_zone,omitempty"`  // The type of the thermal zone, e.g. cpu-thermal
+	Sensor       string  `json:"sensor,omitempty"`        // Or the sensor the temperature is read from, in celsius
+	Key          string  `json:"key,omitempty"`           // The reading, the keys of nested readings are joined with underscores
+	ThrottleTemp float64 `json:"throttle_temp,omitempty"` // Defaults to the thermal zone's lowest passive trip point
+	ShutdownTemp float64 `json:"shutdown_temp,omitempty"` // Defaults to the thermal zone's lowest critical trip point
+}
+
+type ComponentConfig struct {
+	Zones             []Zone  `json:"zones,omitempty"`               // Defaults to every thermal zone with a passive or critical trip point
+	WindowSec         float64 `json:"window_sec,omitempty"`          // The trend is fitted to the samples of the last T seconds, defaults to 300
+	SampleIntervalSec float64 `json:"sample_interval_sec,omitempty"` // How often the temperatures are sampled, defaults to 5
+}
+
+func (conf *ComponentConfig) Validate(path string) ([]string, error) {
+	if conf.WindowSec < 0 || conf.SampleIntervalSec < 0 {
+		return nil, errors.New("window_sec and sample_interval_sec must not be negative")
+	}
+	if conf.windowSec() > maxWindowSec {
+		return nil, fmt.Errorf("window_sec must be at most %d", maxWindowSec)
+	}
+	if conf.sampleIntervalSec()*minSamples > conf.windowSec() {
+		return nil, fmt.Errorf("the window must hold at least %d samples", minSamples)
+	}
+	names := make(map[string]bool, len(conf.Zones))
+	for i, z := range conf.Zones {
+		if (z.ThermalZone == "") == (z.Sensor == "") {
+			return nil, fmt.Errorf("zone %d: either thermal_zone or sensor is required", i)
+		}
+		if z.Sensor != "" && z.Key == "" {
+			return nil, fmt.Errorf("zone %d: key is required with sensor", i)
+		}
+		name := z.name()
+		if names[name] {
+			return nil, fmt.Errorf("zone %s: name is used by another zone, set a different name", name)
+		}
+		names[name] = true
+		if z.ThrottleTemp < 0 || z.ShutdownTemp < 0 {
+			return nil, fmt.Errorf("zone %s: throttle_temp and shutdown_temp must not be negative", name)
+		}
+		// Sensors have no trip points to default to
+		if z.Sensor != "" && z.ThrottleTemp == 0 && z.ShutdownTemp == 0 {
+			return nil, fmt.Errorf("zone %s: throttle_temp or shutdown_temp is required with sensor", name)
+		}
+		if z.ThrottleTemp > 0 && z.ShutdownTemp > 0 && z.ShutdownTemp <= z.ThrottleTemp {
+			return nil, fmt.Errorf("zone %s: shutdown_temp must be above throttle_temp", name)
+		}
+	}
+	return conf.sensors(), nil
+}
+
+func (z Zone) name() string {
+	if z.Name != "" {
+		return z.Name
+	}
+	if z.ThermalZone != "" {
+		return z.ThermalZone
+	}
+	return z.Key
+}
+
+func (conf *ComponentConfig) windowSec() float64 {
+	if conf.WindowSec == 0 {
+		return defaultWindowSec
+	}
+	return conf.WindowSec
+}
+
+func (conf *ComponentConfig) sampleIntervalSec() float64 {
+	if conf.SampleIntervalSec == 0 {
+		return defaultSampleIntervalSec
+	}
+	return conf.SampleIntervalSec
+}
+
+// sensors returns the sensors the zones are read from, each once.
+func (conf *ComponentConfig) sensors() []string {
+	var ret []string
+	seen := make(map[string]bool)
+	for _, z := range conf.Zones {
+		if z.Sensor != "" && !seen[z.Sensor] {
+			seen[z.Sensor] = true
+			ret = append(ret, z.Sensor)
+		}
+	}
+	return ret
+}
@@ -0,0 +1,39 @@
+package thermaltrend
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidate(t *testing.T) {
+	deps, err := (&ComponentConfig{}).Validate("")
+	assert.NoError(t, err)
+	assert.Empty(t, deps)
+
+	conf := &ComponentConfig{Zones: []Zone{
+		{ThermalZone: "cpu-thermal", ThrottleTemp: 85},
+		{Name: "cpu", Sensor: "temperatures", Key: "CPU", ThrottleTemp: 85, ShutdownTemp: 110},
+		{Sensor: "temperatures", Key: "GPU", ShutdownTemp: 105},
+	}}
+	deps, err = conf.Validate("")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"temperatures"}, deps)
+
+	for _, zones := range [][]Zone{
+		{{}},
+		{{ThermalZone: "cpu-thermal", Sensor: "temperatures", Key: "CPU"}},
+		{{Sensor: "temperatures", ThrottleTemp: 85}},
+		{{Sensor: "temperatures", Key: "CPU"}},
+		{{ThermalZone: "cpu-thermal"}, {ThermalZone: "cpu-thermal"}},
+		{{ThermalZone: "cpu-thermal", ThrottleTemp: 85, ShutdownTemp: 80}},
+		{{ThermalZone: "cpu-thermal", ThrottleTemp: -1}},
+	} {
+		_, err := (&ComponentConfig{Zones: zones}).Validate("")
+		assert.Error(t, err, zones)
+	}
+	for _, conf := range []*ComponentConfig{{WindowSec: -1}, {WindowSec: 20}, {WindowSec: 7 * 24 * 3600}} {
+		_, err := conf.Validate("")
+		assert.Error(t, err)
+	}
+}
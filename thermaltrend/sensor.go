@@ -0,0 +1,306 @@
+package thermaltrend
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"go.viam.com/rdk/components/sensor"
+	"go.viam.com/rdk/logging"
+	"go.viam.com/rdk/resource"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/linux"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+var (
+	Model       = resource.NewModel(utils.Namespace, "hwmonitor", "thermal_trend")
+	API         = sensor.API
+	PrettyName  = "Thermal Trend"
+	Description = "A sensor that fits the recent trend of temperatures and estimates how long until they reach the throttling and shutdown trip points"
+	Version     = utils.Version
+)
+
+const (
+	defaultSampleIntervalSec = 5
+	defaultWindowSec         = 300
+	readTimeout              = 5 * time.Second
+)
+
+type zone struct {
+	Zone
+	name     string
+	throttle float64
+	shutdown float64
+	trend    *trend
+}
+
+type Config struct {
+	resource.Named
+	configLock   sync.Mutex
+	readingsLock sync.RWMutex
+	logger       logging.Logger
+	task         *utils.Task
+	zones        []zone
+	interval     time.Duration
+	lastErr      error
+	freshness    utils.Freshness
+}
+
+func init() {
+	resource.RegisterComponent(
+		API,
+		Model,
+		resource.Registration[sensor.Sensor, *ComponentConfig]{Constructor: utils.FilterReadings(NewSensor)})
+	utils.RegisterSimulator(Model, simulate)
+}
+
+func NewSensor(ctx context.Context, deps resource.Dependencies, conf resource.Config, logger logging.Logger) (sensor.Sensor, error) {
+	logger.Infof("Starting %s %s", PrettyName, Version)
+	b := Config{
+		Named:  conf.ResourceName().AsNamed(),
+		logger: logger,
+	}
+
+	if err := b.Reconfigure(ctx, deps, conf); err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
+
+func (c *Config) Reconfigure(ctx context.Context, deps resource.Dependencies, rawConf resource.Config) error {
+	c.configLock.Lock()
+	defer c.configLock.Unlock()
+	c.logger.Debugf("Reconfiguring %s", PrettyName)
+
+	if c.task != nil {
+		c.logger.Debug("Stopping polling")
+		c.task.Stop()
+		c.logger.Debugf("Polling stopped")
+		c.task = nil
+	}
+
+	conf, err := resource.NativeConfig[*ComponentConfig](rawConf)
+	if err != nil {
+		return err
+	}
+
+	// In case the component has changed name
+	c.Named = rawConf.ResourceName().AsNamed()
+
+	sources, err := utils.ResolveSources(deps, conf.sensors())
+	if err != nil {
+		return err
+	}
+	zones, err := resolveZones(ctx, conf.Zones)
+	if err != nil {
+		return err
+	}
+	interval := time.Duration(conf.sampleIntervalSec() * float64(time.Second))
+
+	// Zones that are still sampled keep their samples, the new window applies from the next sample
+	c.readingsLock.Lock()
+	previous := make(map[Zone]*trend, len(c.zones))
+	for _, z := range c.zones {
+		previous[z.source()] = z.trend
+	}
+	for i := range zones {
+		t, ok := previous[zones[i].source()]
+		if !ok {
+			t = &trend{}
+		}
+		delete(previous, zones[i].source())
+		t.maxAge = time.Duration(conf.windowSec() * float64(time.Second))
+		zones[i].trend = t
+	}
+	c.zones = zones
+	c.interval = interval
+	c.lastErr = nil
+	c.readingsLock.Unlock()
+
+	c.task = utils.ScheduleAligned(interval, utils.ReadingsSource, func(ctx context.Context) {
+		c.sample(ctx, sources)
+	})
+	return nil
+}
+
+// resolveZones returns the configured zones with the trip points of their thermal zones, or, when none are
+// configured, every thermal zone with a passive or critical trip point.
+func resolveZones(ctx context.Context, configured []Zone) ([]zone, error) {
+	var trips map[string]linux.ThermalTrips
+	needTrips := len(configured) == 0
+	for _, z := range configured {
+		needTrips = needTrips || z.ThermalZone != ""
+	}
+	if needTrips {
+		var err error
+		if trips, err = linux.ReadThermalTrips(ctx, linux.ThermalZonesRoot); err != nil {
+			return nil, fmt.Errorf("failed to read the thermal zones' trip points: %w", err)
+		}
+	}
+	if len(configured) == 0 {
+		if len(trips) == 0 {
+			return nil, utils.NewError(utils.ErrHardwareAbsent, "no thermal zone has a passive or critical trip point, configure the zones")
+		}
+		for zoneType := range trips {
+			configured = append(configured, Zone{ThermalZone: zoneType})
+		}
+		sort.Slice(configured, func(i, j int) bool { return configured[i].ThermalZone < configured[j].ThermalZone })
+	}
+	zones := make([]zone, 0, len(configured))
+	for _, z := range configured {
+		resolved := zone{Zone: z, name: z.name(), throttle: z.ThrottleTemp, shutdown: z.ShutdownTemp}
+		if z.ThermalZone != "" {
+			if resolved.throttle == 0 {
+				resolved.throttle = trips[z.ThermalZone].Passive
+			}
+			if resolved.shutdown == 0 {
+				resolved.shutdown = trips[z.ThermalZone].Critical
+			}
+			if resolved.throttle == 0 && resolved.shutdown == 0 {
+				return nil, fmt.Errorf("zone %s: thermal zone %s doesn't exist or has no passive or critical trip point, set throttle_temp or shutdown_temp", resolved.name, z.ThermalZone)
+			}
+		}
+		zones = append(zones, resolved)
+	}
+	return zones, nil
+}
+
+// source is where the zone's temperature is read from, a zone keeps its samples while that doesn't change.
+func (z zone) source() Zone {
+	return Zone{ThermalZone: z.ThermalZone, Sensor: z.Sensor, Key: z.Key}
+}
+
+// sample adds the temperature of every zone to its trend, the scheduler calls it every interval.
+func (c *Config) sample(ctx context.Context, sources []utils.Source) {
+	readings := make(map[string]map[string]float64, len(sources))
+	var readErr error
+	for _, s := range sources {
+		readCtx, cancel := context.WithTimeout(ctx, readTimeout)
+		r, err := s.Sensor.Readings(readCtx, nil)
+		cancel()
+		if ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			readErr = fmt.Errorf("failed to read %s: %w", s.Name, err)
+			continue
+		}
+		readings[s.Name] = utils.NumericReadings(r)
+	}
+	c.readingsLock.RLock()
+	zones := c.zones
+	c.readingsLock.RUnlock()
+	var thermalZones map[string]float64
+	for _, z := range zones {
+		if z.ThermalZone != "" {
+			var err error
+			if thermalZones, err = linux.ReadThermalZones(ctx, linux.ThermalZonesRoot); err != nil {
+				readErr = fmt.Errorf("failed to read the thermal zones: %w", err)
+			}
+			break
+		}
+	}
+
+	now := time.Now()
+	c.readingsLock.Lock()
+	for _, z := range c.zones {
+		var temp float64
+		var ok bool
+		if z.ThermalZone != "" {
+			temp, ok = thermalZones[z.ThermalZone]
+		} else {
+			temp, ok = readings[z.Sensor][z.Key]
+		}
+		if ok {
+			z.trend.add(now, temp)
+		}
+	}
+	if readErr != nil && c.lastErr == nil {
+		c.logger.Warnf("Failed to sample temperatures: %v", readErr)
+	}
+	c.lastErr = readErr
+	if readErr != nil {
+		c.freshness.Failed(readErr)
+	} else {
+		c.freshness.Succeeded(now)
+	}
+	c.readingsLock.Unlock()
+}
+
+func (c *Config) Readings(ctx context.Context, extra map[string]interface{}) (map[string]interface{}, error) {
+	// Fitting the trends drops the samples that aged out of the windows
+	c.readingsLock.Lock()
+	defer c.readingsLock.Unlock()
+	ret := make(map[string]interface{})
+	now := time.Now()
+	addTrendReadings(ret, c.zones, now)
+	if c.lastErr != nil {
+		ret["last_error"] = c.lastErr.Error()
+	}
+	return c.freshness.Stamp(ret, c.interval, now), nil
+}
+
+// addTrendReadings adds each zone's temperature, trend and estimates, and the soonest of the zones to throttle and to
+// shut down.
+func addTrendReadings(ret map[string]interface{}, zones []zone, now time.Time) {
+	soonest := map[string]float64{}
+	for _, z := range zones {
+		slope, fitted, fitOk := z.trend.fit(now)
+		ret[z.name+"_samples"] = len(z.trend.samples)
+		latest, ok := z.trend.latest()
+		if !ok {
+			continue
+		}
+		ret[z.name+"_temp"] = utils.RoundValue(latest, 2)
+		if fitOk {
+			ret[z.name+"_slope_c_per_min"] = utils.RoundValue(slope*60, 3)
+		}
+		for _, trip := range []struct {
+			name string
+			temp float64
+		}{{"throttle", z.throttle}, {"shutdown", z.shutdown}} {
+			if trip.temp == 0 {
+				continue
+			}
+			ret[z.name+"_"+trip.name+"_temp"] = trip.temp
+			var seconds float64
+			var estimated bool
+			switch {
+			case latest >= trip.temp:
+				seconds, estimated = 0, true
+			case fitOk:
+				seconds, estimated = secondsUntil(fitted, slope, trip.temp)
+			}
+			if !estimated {
+				continue
+			}
+			ret[z.name+"_"+trip.name+"_in_sec"] = utils.RoundValue(seconds, 0)
+			if current, ok := soonest[trip.name]; !ok || seconds < current {
+				soonest[trip.name] = seconds
+				ret[trip.name+"_in_sec"] = utils.RoundValue(seconds, 0)
+				ret[trip.name+"_zone"] = z.name
+			}
+		}
+		if z.throttle > 0 {
+			ret[z.name+"_throttling"] = latest >= z.throttle
+		}
+	}
+}
+
+func (c *Config) Close(ctx context.Context) error {
+	c.configLock.Lock()
+	defer c.configLock.Unlock()
+	c.logger.Infof("Shutting down %s", PrettyName)
+	if c.task != nil {
+		c.task.Stop()
+	}
+	c.logger.Infof("%s Shutdown complete", PrettyName)
+	return nil
+}
+
+func (c *Config) Ready(ctx context.Context, extra map[string]interface{}) (bool, error) {
+	return false, nil
+}
@@ -0,0 +1,34 @@
+package thermaltrend
+
+import (
+	"time"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+// simulate returns the trend of a Raspberry Pi 5's CPU, which throttles at 85C, or of the configured zones. It's steady
+// when idle and rises towards throttling under load.
+func simulate(sim *utils.Simulation) map[string]interface{} {
+	configured := []Zone{{ThermalZone: "cpu-thermal", ThrottleTemp: 85, ShutdownTemp: 110}}
+	if conf, ok := sim.Config().(*ComponentConfig); ok && len(conf.Zones) > 0 {
+		configured = conf.Zones
+	}
+	now := time.Now()
+	temp := sim.Value(46, 71, 84.5, 2)
+	slope := sim.Level(0, 0.4, 1.5) / 60
+	zones := make([]zone, 0, len(configured))
+	for _, z := range configured {
+		simulated := zone{Zone: z, name: z.name(), throttle: z.ThrottleTemp, shutdown: z.ShutdownTemp, trend: &trend{maxAge: defaultWindowSec * time.Second}}
+		if simulated.throttle == 0 && simulated.shutdown == 0 {
+			simulated.throttle, simulated.shutdown = 85, 110
+		}
+		for i := defaultWindowSec / defaultSampleIntervalSec; i >= 0; i-- {
+			ago := time.Duration(i*defaultSampleIntervalSec) * time.Second
+			simulated.trend.add(now.Add(-ago), temp-slope*ago.Seconds())
+		}
+		zones = append(zones, simulated)
+	}
+	ret := make(map[string]interface{})
+	addTrendReadings(ret, zones, now)
+	return ret
+}
@@ -0,0 +1,82 @@
+package thermaltrend
+
+import (
+	"time"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+const (
+	// minSamples is the fewest samples a trend is fitted to
+	minSamples = 5
+	// minRisingSlope is the slope in degrees per second below which the temperature is steady, a slower rise is within
+	// what the noise of a sensor that reads in whole degrees fits to
+	minRisingSlope = 0.1 / 60.0
+)
+
+type sample struct {
+	time time.Time
+	temp float64
+}
+
+// trend keeps the temperatures of one zone over the window.
+type trend struct {
+	maxAge  time.Duration
+	samples []sample
+}
+
+func (t *trend) add(now time.Time, temp float64) {
+	t.samples = append(t.samples, sample{time: now, temp: temp})
+	t.trim(now)
+}
+
+// trim drops the samples older than the window at now, so a zone that can't be read stops being extrapolated.
+func (t *trend) trim(now time.Time) {
+	drop := 0
+	for drop < len(t.samples) && now.Sub(t.samples[drop].time) > t.maxAge {
+		drop++
+	}
+	t.samples = t.samples[drop:]
+}
+
+// fit returns the slope of a line fitted to the samples in degrees per second, and the temperature it's at at now.
+// It's false until the samples span half of the window, a trend over a shorter time follows the noise of the sensor.
+func (t *trend) fit(now time.Time) (slope, temp float64, ok bool) {
+	t.trim(now)
+	if len(t.samples) < minSamples || t.samples[len(t.samples)-1].time.Sub(t.samples[0].time) < t.maxAge/2 {
+		return 0, 0, false
+	}
+	// Seconds since the first sample rather than since the epoch keep the sums precise
+	first := t.samples[0].time
+	xs := make([]float64, len(t.samples))
+	ys := make([]float64, len(t.samples))
+	for i, s := range t.samples {
+		xs[i] = s.time.Sub(first).Seconds()
+		ys[i] = s.temp
+	}
+	slope, intercept, ok := utils.LinearFit(xs, ys)
+	if !ok {
+		return 0, 0, false
+	}
+	return slope, intercept + slope*now.Sub(first).Seconds(), true
+}
+
+// latest returns the last sample's temperature.
+func (t *trend) latest() (float64, bool) {
+	if len(t.samples) == 0 {
+		return 0, false
+	}
+	return t.samples[len(t.samples)-1].temp, true
+}
+
+// secondsUntil returns how long until a temperature rising at slope reaches trip, 0 when it's already there. It's
+// false when the temperature isn't rising.
+func secondsUntil(temp, slope, trip float64) (float64, bool) {
+	if temp >= trip {
+		return 0, true
+	}
+	if slope < minRisingSlope {
+		return 0, false
+	}
+	return (trip - temp) / slope, true
+}
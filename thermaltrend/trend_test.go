@@ -0,0 +1,89 @@
+package thermaltrend
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTrendFit(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	tr := &trend{maxAge: 60 * time.Second}
+	// Rising 1C a minute with some noise
+	for i := 0; i <= 12; i++ {
+		tr.add(now.Add(time.Duration(i*5)*time.Second), 70+float64(i*5)/60+[]float64{0.3, -0.3}[i%2])
+	}
+	slope, temp, ok := tr.fit(now.Add(60 * time.Second))
+	require.True(t, ok)
+	assert.InDelta(t, 1.0/60, slope, 0.002)
+	assert.InDelta(t, 71, temp, 0.5)
+
+	// Too few samples, and samples that don't span half of the window yet
+	short := &trend{maxAge: 60 * time.Second}
+	for i := 0; i < 4; i++ {
+		short.add(now.Add(time.Duration(i*10)*time.Second), 70)
+	}
+	_, _, ok = short.fit(now.Add(30 * time.Second))
+	assert.False(t, ok)
+	short = &trend{maxAge: 60 * time.Second}
+	for i := 0; i < 10; i++ {
+		short.add(now.Add(time.Duration(i)*time.Second), 70)
+	}
+	_, _, ok = short.fit(now.Add(10 * time.Second))
+	assert.False(t, ok)
+
+	// Samples age out while the zone can't be read
+	_, _, ok = tr.fit(now.Add(5 * time.Minute))
+	assert.False(t, ok)
+	assert.Empty(t, tr.samples)
+}
+
+func TestSecondsUntil(t *testing.T) {
+	seconds, ok := secondsUntil(79, 1.0/60, 85)
+	assert.True(t, ok)
+	assert.InDelta(t, 360, seconds, 0.001)
+
+	seconds, ok = secondsUntil(86, -1.0/60, 85)
+	assert.True(t, ok)
+	assert.Equal(t, 0.0, seconds)
+
+	_, ok = secondsUntil(79, 0.05/60, 85)
+	assert.False(t, ok)
+}
+
+func TestTrendReadings(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	cpu := zone{name: "cpu-thermal", throttle: 85, shutdown: 110, trend: &trend{maxAge: 5 * time.Minute}}
+	gpu := zone{name: "gpu-thermal", shutdown: 105, trend: &trend{maxAge: 5 * time.Minute}}
+	for i := 60; i >= 0; i-- {
+		ago := time.Duration(i*5) * time.Second
+		// The CPU has ~6 minutes at 1C a minute, the GPU is steady
+		cpu.trend.add(now.Add(-ago), 79-ago.Minutes())
+		gpu.trend.add(now.Add(-ago), 60)
+	}
+	ret := make(map[string]interface{})
+	addTrendReadings(ret, []zone{cpu, gpu}, now)
+	assert.Equal(t, 79.0, ret["cpu-thermal_temp"])
+	assert.Equal(t, 1.0, ret["cpu-thermal_slope_c_per_min"])
+	assert.Equal(t, 85.0, ret["cpu-thermal_throttle_temp"])
+	assert.Equal(t, 360.0, ret["cpu-thermal_throttle_in_sec"])
+	assert.Equal(t, 1860.0, ret["cpu-thermal_shutdown_in_sec"])
+	assert.Equal(t, false, ret["cpu-thermal_throttling"])
+	assert.Equal(t, 61, ret["cpu-thermal_samples"])
+	assert.Equal(t, 0.0, ret["gpu-thermal_slope_c_per_min"])
+	assert.NotContains(t, ret, "gpu-thermal_shutdown_in_sec")
+	assert.NotContains(t, ret, "gpu-thermal_throttling")
+	assert.Equal(t, 360.0, ret["throttle_in_sec"])
+	assert.Equal(t, "cpu-thermal", ret["throttle_zone"])
+	assert.Equal(t, 1860.0, ret["shutdown_in_sec"])
+	assert.Equal(t, "cpu-thermal", ret["shutdown_zone"])
+
+	// Over the trip point it's throttling now, whichever way the trend goes
+	cpu.trend.add(now.Add(5*time.Second), 86)
+	ret = make(map[string]interface{})
+	addTrendReadings(ret, []zone{cpu}, now.Add(5*time.Second))
+	assert.Equal(t, 0.0, ret["cpu-thermal_throttle_in_sec"])
+	assert.Equal(t, true, ret["cpu-thermal_throttling"])
+}
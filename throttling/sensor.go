@@ -2,12 +2,15 @@ package throttling
 
 import (
 	"context"
+	"fmt"
 	"sync"
+	"time"
 
 	"go.viam.com/rdk/components/sensor"
 	"go.viam.com/rdk/logging"
 	"go.viam.com/rdk/resource"
 
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/linux"
 	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
 )
 
@@ -25,13 +28,16 @@ type Config struct {
 	logger     logging.Logger
 	cancelCtx  context.Context
 	cancelFunc func()
+	// counters count the undervoltages across restarts
+	counters *utils.PersistentCounters
 }
 
 func init() {
 	resource.RegisterComponent(
 		API,
 		Model,
-		resource.Registration[sensor.Sensor, *ComponentConfig]{Constructor: NewSensor})
+		resource.Registration[sensor.Sensor, *ComponentConfig]{Constructor: utils.FilterReadings(NewSensor)})
+	utils.RegisterSimulator(Model, simulate)
 }
 
 func NewSensor(ctx context.Context, deps resource.Dependencies, conf resource.Config, logger logging.Logger) (sensor.Sensor, error) {
@@ -60,17 +66,47 @@ func (c *Config) Reconfigure(ctx context.Context, _ resource.Dependencies, conf
 	// In case the module has changed name
 	c.Named = conf.ResourceName().AsNamed()
 
+	bootID, err := linux.ReadBootID(ctx)
+	if err != nil {
+		return err
+	}
+	c.counters = utils.ReloadCounters(c.counters, c.Name().Name, bootID, c.logger)
+
 	return nil
 }
 
 func (c *Config) Readings(ctx context.Context, extra map[string]interface{}) (map[string]interface{}, error) {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
-	return getThrottlingStates(ctx)
+	states, err := getThrottlingStates(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// Only Raspberry Pis report undervoltage
+	if undervolt, ok := states[Undervolt].(bool); ok {
+		occurred, _ := states[UnderVoltOccurred].(bool)
+		states[UndervoltCount] = countUndervoltage(c.counters, undervolt, occurred)
+		states[CountersSince] = c.counters.Since().Format(time.RFC3339)
+	}
+	return states, nil
+}
+
+// Capabilities reports what the throttling states are read from, only Raspberry Pi and Jetson boards report them.
+func (c *Config) Capabilities(ctx context.Context) utils.Capabilities {
+	caps := utils.Capabilities{Backend: backend(), Unavailable: make(map[string]string)}
+	if caps.Backend == "" {
+		caps.Unavailable["throttling"] = fmt.Sprintf("not reported by %s boards", linux.Board().Family)
+	}
+	return caps
 }
 
 func (c *Config) Close(ctx context.Context) error {
 	c.logger.Infof("Shutting down %s", PrettyName)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.counters.Flush(); err != nil {
+		c.logger.Warnf("Failed to save the counters to %s: %v", c.counters.Path(), err)
+	}
 	return nil
 }
 
@@ -0,0 +1,24 @@
+package throttling
+
+import (
+	"time"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+// simulate returns the throttling states of a Raspberry Pi, which only throttles in the thermal_throttling profile.
+func simulate(sim *utils.Simulation) map[string]interface{} {
+	throttling := sim.Throttling()
+	return map[string]interface{}{
+		Undervolt:               false,
+		ArmFrequencyCapped:      throttling,
+		CurrentlyThrottled:      throttling,
+		SoftTempLimitActive:     throttling,
+		UnderVoltOccurred:       false,
+		ArmFrequencyCapOccurred: throttling,
+		ThrottlingOccurred:      throttling,
+		SoftTempLimitOccurred:   throttling,
+		UndervoltCount:          int64(0),
+		CountersSince:           sim.Started().Format(time.RFC3339),
+	}
+}
@@ -0,0 +1,31 @@
+package throttling
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+func TestSimulate(t *testing.T) {
+	// The simulated states have the same keys as the ones read from the board
+	parsed, err := parseRasPiThrottlingStates("throttled=0x0")
+	require.NoError(t, err)
+
+	sim, err := utils.NewSimulation(utils.ProfileIdle)
+	require.NoError(t, err)
+	// Along with the undervoltage counter the readings add
+	parsed[UndervoltCount] = int64(0)
+	parsed[CountersSince] = sim.Started().Format(time.RFC3339)
+	assert.Equal(t, parsed, simulate(sim))
+
+	sim, err = utils.NewSimulation(utils.ProfileThermalThrottling)
+	require.NoError(t, err)
+	res := simulate(sim)
+	assert.Len(t, res, len(parsed))
+	assert.True(t, res[CurrentlyThrottled].(bool))
+	assert.False(t, res[Undervolt].(bool))
+}
@@ -3,14 +3,13 @@ package throttling
 import (
 	"context"
 	"fmt"
-	"os/exec"
 	"path/filepath"
 	"strconv"
 	"strings"
 	"time"
 
-	"github.com/rinzlerlabs/sbcidentify"
-	"github.com/rinzlerlabs/sbcidentify/boardtype"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/linux"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/linux/raspberrypi"
 	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
 )
 
@@ -23,37 +22,64 @@ const (
 	ArmFrequencyCapOccurred = "armFrequencyCapOccurred"
 	ThrottlingOccurred      = "throttlingOccurred"
 	SoftTempLimitOccurred   = "softTempLimitOccurred"
+	// UndervoltCount counts the undervoltages across restarts and reboots
+	UndervoltCount = "undervoltCount"
+	CountersSince  = "countersSince"
 )
 
 func getThrottlingStates(ctx context.Context) (map[string]interface{}, error) {
-	if sbcidentify.IsBoardType(boardtype.RaspberryPi) {
+	if linux.IsFamily(linux.BoardFamilyRaspberryPi) {
 		return getRasPiThrottlingStates(ctx)
-	} else if sbcidentify.IsBoardType(boardtype.NVIDIA) {
+	} else if linux.IsFamily(linux.BoardFamilyJetson) {
 		return getJetsonThrottlingStates(ctx)
 	}
 	return nil, fmt.Errorf("board not supported")
 }
 
+// backend is what the throttling states are read from, empty on boards that don't report them.
+func backend() string {
+	if linux.IsFamily(linux.BoardFamilyRaspberryPi) {
+		return "vcgencmd"
+	} else if linux.IsFamily(linux.BoardFamilyJetson) {
+		return "cooling_devices"
+	}
+	return ""
+}
+
+// countUndervoltage counts an undervoltage when the board is undervolted and wasn't at the last reading, and returns
+// the undervoltages across restarts. The firmware only reports whether the board is undervolted now and whether it
+// has been since boot, so one that's over by the first reading of a boot is counted from the latter.
+func countUndervoltage(counters *utils.PersistentCounters, undervolt, occurred bool) int64 {
+	last, seen := counters.BootValue(Undervolt)
+	if undervolt && (!seen || last == 0) || !undervolt && occurred && !seen {
+		counters.Add(UndervoltCount, 1)
+	}
+	var value int64
+	if undervolt {
+		value = 1
+	}
+	counters.SetBootValue(Undervolt, value)
+	return counters.Get(UndervoltCount)
+}
+
 func getRasPiThrottlingStates(ctx context.Context) (map[string]interface{}, error) {
-	proc := exec.CommandContext(ctx, "vcgencmd", "get_throttled")
-	outputBytes, err := proc.Output()
+	throttlingStates, err := raspberrypi.ReadThrottled(ctx)
 	if err != nil {
 		return nil, err
 	}
-	output := string(outputBytes)
-	return parseRasPiThrottlingStates(output)
+	return rasPiThrottlingStates(throttlingStates), nil
 }
 
 func parseRasPiThrottlingStates(output string) (map[string]interface{}, error) {
-	parts := strings.Split(output, "=")
-	if len(parts) != 2 {
-		return nil, fmt.Errorf("unexpected output from vcgencmd %s", output)
-	}
-	hex := strings.TrimSpace(strings.Replace(parts[1], "0x", "", 1))
-	throttlingStates, err := strconv.ParseInt(hex, 16, 64)
+	throttlingStates, err := raspberrypi.ParseThrottled(output)
 	if err != nil {
 		return nil, err
 	}
+	return rasPiThrottlingStates(throttlingStates), nil
+}
+
+// rasPiThrottlingStates decodes the firmware's throttled flags.
+func rasPiThrottlingStates(throttlingStates int64) map[string]interface{} {
 	return map[string]interface{}{
 		Undervolt:               throttlingStates&0x1 != 0,
 		ArmFrequencyCapped:      throttlingStates&0x2 != 0,
@@ -63,13 +89,13 @@ func parseRasPiThrottlingStates(output string) (map[string]interface{}, error) {
 		ArmFrequencyCapOccurred: throttlingStates&0x20000 != 0,
 		ThrottlingOccurred:      throttlingStates&0x40000 != 0,
 		SoftTempLimitOccurred:   throttlingStates&0x80000 != 0,
-	}, nil
+	}
 }
 
 func getJetsonThrottlingStates(ctx context.Context) (map[string]interface{}, error) {
 	ctxWithTimeout, cancel := context.WithTimeout(ctx, 100*time.Millisecond)
 	defer cancel()
-	dirs, err := filepath.Glob("/sys/class/thermal/cooling_device*")
+	dirs, err := utils.Glob("/sys/class/thermal/cooling_device*")
 	if err != nil {
 		return nil, err
 	}
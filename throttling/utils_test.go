@@ -1,9 +1,14 @@
 package throttling
 
 import (
+	"context"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
 )
 
 func Test_GetThrottlingStatesForRasPi(t *testing.T) {
@@ -31,3 +36,42 @@ func Test_GetThrottlingStatesForRasPi(t *testing.T) {
 	assert.True(t, res[ThrottlingOccurred].(bool))
 	assert.True(t, res[SoftTempLimitOccurred].(bool))
 }
+
+func TestGetRasPiThrottlingStates(t *testing.T) {
+	t.Cleanup(func() { utils.SetCommandRunner(utils.DefaultCommandRunner()) })
+	utils.SetCommandRunner(utils.CommandRunnerFunc(func(ctx context.Context, cmd utils.Command) ([]byte, error) {
+		assert.Equal(t, utils.Command{Name: "vcgencmd", Args: []string{"get_throttled"}}, cmd)
+		return []byte("throttled=0x50005\n"), nil
+	}))
+	res, err := getRasPiThrottlingStates(context.Background())
+	assert.NoError(t, err)
+	assert.True(t, res[Undervolt].(bool))
+	assert.True(t, res[CurrentlyThrottled].(bool))
+	assert.True(t, res[UnderVoltOccurred].(bool))
+}
+
+func TestCountUndervoltage(t *testing.T) {
+	counters, err := utils.LoadPersistentCounters(filepath.Join(t.TempDir(), "throttling.json"), "boot-1")
+	require.NoError(t, err)
+
+	// An undervoltage that was over before the first reading of the boot
+	assert.Equal(t, int64(1), countUndervoltage(counters, false, true))
+	assert.Equal(t, int64(1), countUndervoltage(counters, false, true))
+
+	// An undervoltage is counted once while it lasts
+	assert.Equal(t, int64(2), countUndervoltage(counters, true, true))
+	assert.Equal(t, int64(2), countUndervoltage(counters, true, true))
+	assert.Equal(t, int64(2), countUndervoltage(counters, false, true))
+	assert.Equal(t, int64(3), countUndervoltage(counters, true, true))
+	require.NoError(t, counters.Flush())
+
+	// A module restart during the same undervoltage doesn't count it again
+	counters, err = utils.LoadPersistentCounters(counters.Path(), "boot-1")
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), countUndervoltage(counters, true, true))
+
+	// Nor does a reboot without one
+	counters, err = utils.LoadPersistentCounters(counters.Path(), "boot-2")
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), countUndervoltage(counters, false, false))
+}
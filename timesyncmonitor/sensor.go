@@ -0,0 +1,127 @@
+package timesyncmonitor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.viam.com/rdk/components/sensor"
+	"go.viam.com/rdk/logging"
+	"go.viam.com/rdk/resource"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/linux"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+var (
+	Model       = resource.NewModel(utils.Namespace, "hwmonitor", "time_sync_monitor")
+	API         = sensor.API
+	PrettyName  = "Time Sync Monitor"
+	Description = "A sensor that reports the clock synchronization state, offset and stratum"
+	Version     = utils.Version
+)
+
+type Config struct {
+	resource.Named
+	mu         sync.RWMutex
+	logger     logging.Logger
+	cancelCtx  context.Context
+	cancelFunc func()
+}
+
+func init() {
+	resource.RegisterComponent(
+		API,
+		Model,
+		resource.Registration[sensor.Sensor, *ComponentConfig]{Constructor: utils.FilterReadings(NewSensor)})
+	utils.RegisterSimulator(Model, simulate)
+}
+
+func NewSensor(ctx context.Context, deps resource.Dependencies, conf resource.Config, logger logging.Logger) (sensor.Sensor, error) {
+	logger.Infof("Starting %s %s", PrettyName, Version)
+	cancelCtx, cancelFunc := context.WithCancel(context.Background())
+
+	b := Config{
+		Named:      conf.ResourceName().AsNamed(),
+		logger:     logger,
+		cancelCtx:  cancelCtx,
+		cancelFunc: cancelFunc,
+		mu:         sync.RWMutex{},
+	}
+
+	if err := b.Reconfigure(ctx, deps, conf); err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
+
+func (c *Config) Reconfigure(ctx context.Context, _ resource.Dependencies, conf resource.Config) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.logger.Debugf("Reconfiguring %s", PrettyName)
+
+	// In case the module has changed name
+	c.Named = conf.ResourceName().AsNamed()
+
+	return nil
+}
+
+func (c *Config) Readings(ctx context.Context, extra map[string]interface{}) (map[string]interface{}, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	ret := make(map[string]interface{})
+
+	// The kernel status is available regardless of which daemon disciplines the clock
+	synchronized, maxError, err := linux.KernelClockStatus()
+	if err != nil {
+		return nil, err
+	}
+	ret["kernel_synchronized"] = synchronized
+	ret["kernel_max_error_ms"] = utils.RoundValue(float64(maxError)/float64(time.Millisecond), 3)
+
+	status, err := linux.GetTimeSyncStatus(ctx)
+	if err != nil {
+		if errors.Is(err, linux.ErrNoTimeSyncDaemon) {
+			ret["source"] = "none"
+			ret["synchronized"] = synchronized
+			return ret, nil
+		}
+		return nil, err
+	}
+	ret["source"] = status.Source
+	ret["synchronized"] = status.Synchronized
+	ret["offset_ms"] = utils.RoundValue(float64(status.Offset)/float64(time.Millisecond), 3)
+	ret["stratum"] = status.Stratum
+	ret["server"] = status.Server
+	return ret, nil
+}
+
+// Capabilities reports the daemon disciplining the clock, without one only the kernel's status is reported.
+func (c *Config) Capabilities(ctx context.Context) utils.Capabilities {
+	caps := utils.Capabilities{Unavailable: make(map[string]string)}
+	status, err := linux.GetTimeSyncStatus(ctx)
+	if err != nil {
+		caps.Backend = "kernel"
+		reason := fmt.Sprintf("failed to read the time sync status: %v", err)
+		if errors.Is(err, linux.ErrNoTimeSyncDaemon) {
+			reason = "no chrony, timesyncd or ntpd running"
+		}
+		for _, key := range []string{"offset_ms", "stratum", "server"} {
+			caps.Unavailable[key] = reason
+		}
+		return caps
+	}
+	caps.Backend = status.Source
+	return caps
+}
+
+func (c *Config) Close(ctx context.Context) error {
+	c.logger.Infof("Shutting down %s", PrettyName)
+	return nil
+}
+
+func (c *Config) Ready(ctx context.Context, extra map[string]interface{}) (bool, error) {
+	return false, nil
+}
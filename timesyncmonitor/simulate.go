@@ -0,0 +1,17 @@
+package timesyncmonitor
+
+import "github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+
+// simulate returns a clock that systemd-timesyncd keeps within a few milliseconds of a pool server.
+func simulate(sim *utils.Simulation) map[string]interface{} {
+	offset := sim.Value(2, 2, 2, 4) - 2
+	return map[string]interface{}{
+		"kernel_synchronized": true,
+		"kernel_max_error_ms": utils.RoundValue(sim.Value(48, 48, 48, 20), 3),
+		"source":              "timesyncd",
+		"synchronized":        true,
+		"offset_ms":           utils.RoundValue(offset, 3),
+		"stratum":             2,
+		"server":              "2.debian.pool.ntp.org",
+	}
+}
@@ -0,0 +1,42 @@
+package usbmonitor
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+)
+
+type ComponentConfig struct {
+	Expected []ExpectedDevice `json:"expected,omitempty"`
+}
+
+// ExpectedDevice is a peripheral that should always be attached, e.g. a camera or lidar.
+type ExpectedDevice struct {
+	Name         string  `json:"name"`                     // Used as the reading key
+	VendorID     string  `json:"vendor_id"`                // As lsusb shows it, e.g. 046d
+	ProductID    string  `json:"product_id"`               // As lsusb shows it, e.g. 0825
+	Serial       string  `json:"serial,omitempty"`         // Distinguishes identical devices
+	MinSpeedMbps float64 `json:"min_speed_mbps,omitempty"` // e.g. 5000 to flag a USB 3 camera that enumerated at USB 2 speed
+}
+
+var usbIDRegex = regexp.MustCompile(`^[0-9a-f]{4}$`)
+
+func (conf *ComponentConfig) Validate(path string) ([]string, error) {
+	names := make(map[string]bool)
+	for _, device := range conf.Expected {
+		if device.Name == "" {
+			return nil, errors.New("every expected device requires a name")
+		}
+		if names[device.Name] {
+			return nil, fmt.Errorf("duplicate expected device name: %s", device.Name)
+		}
+		names[device.Name] = true
+		if !usbIDRegex.MatchString(device.VendorID) || !usbIDRegex.MatchString(device.ProductID) {
+			return nil, fmt.Errorf("expected device %s requires vendor_id and product_id as 4 lowercase hex digits, e.g. 046d", device.Name)
+		}
+		if device.MinSpeedMbps < 0 {
+			return nil, fmt.Errorf("expected device %s min_speed_mbps must not be negative", device.Name)
+		}
+	}
+	return nil, nil
+}
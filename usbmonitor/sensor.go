@@ -0,0 +1,394 @@
+package usbmonitor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.viam.com/rdk/components/sensor"
+	"go.viam.com/rdk/logging"
+	"go.viam.com/rdk/resource"
+	viamutils "go.viam.com/utils"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/linux"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+var (
+	Model       = resource.NewModel(utils.Namespace, "hwmonitor", "usb_monitor")
+	API         = sensor.API
+	PrettyName  = "USB Device Monitor"
+	Description = "A sensor that reports the attached USB devices, their hotplug events and whether the expected devices are present, and switches port power"
+	Version     = utils.Version
+)
+
+// Long enough for a device's capacitors to drain so it fully resets
+const defaultPowerCycleDelay = 2 * time.Second
+
+type Config struct {
+	resource.Named
+	configLock   sync.Mutex
+	readingsLock sync.RWMutex
+	logger       logging.Logger
+	workers      *viamutils.StoppableWorkers
+	expected     []ExpectedDevice
+	// counters count the add and remove events, and the remove events per vendor:product ID, across restarts
+	counters    *utils.PersistentCounters
+	lastEvent   *linux.Uevent
+	lastEventAt time.Time
+	// missing tracks which expected devices are logged as missing, so each change is only logged once
+	missing map[string]bool
+}
+
+func init() {
+	resource.RegisterComponent(
+		API,
+		Model,
+		resource.Registration[sensor.Sensor, *ComponentConfig]{Constructor: utils.FilterReadings(NewSensor)})
+	utils.RegisterSimulator(Model, simulate)
+}
+
+func NewSensor(ctx context.Context, deps resource.Dependencies, conf resource.Config, logger logging.Logger) (sensor.Sensor, error) {
+	logger.Infof("Starting %s %s", PrettyName, Version)
+	b := Config{
+		Named:  conf.ResourceName().AsNamed(),
+		logger: logger,
+	}
+
+	if err := b.Reconfigure(ctx, deps, conf); err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
+
+func (c *Config) Reconfigure(ctx context.Context, _ resource.Dependencies, rawConf resource.Config) error {
+	c.configLock.Lock()
+	defer c.configLock.Unlock()
+	c.logger.Debugf("Reconfiguring %s", PrettyName)
+
+	conf, err := resource.NativeConfig[*ComponentConfig](rawConf)
+	if err != nil {
+		return err
+	}
+
+	// In case the component has changed name
+	c.Named = rawConf.ResourceName().AsNamed()
+
+	bootID, err := linux.ReadBootID(ctx)
+	if err != nil {
+		return err
+	}
+	c.readingsLock.Lock()
+	c.expected = conf.Expected
+	// Devices that are still expected keep their state, so a reconfigure doesn't log them as missing again
+	if c.missing == nil {
+		c.missing = make(map[string]bool)
+	}
+	for name := range c.missing {
+		if !slices.ContainsFunc(c.expected, func(e ExpectedDevice) bool { return e.Name == name }) {
+			delete(c.missing, name)
+		}
+	}
+	c.counters = utils.ReloadCounters(c.counters, c.Name().Name, bootID, c.logger)
+	c.readingsLock.Unlock()
+
+	// The uevent listener doesn't depend on the config, it keeps running so no events are missed while reconfiguring
+	if c.workers != nil {
+		return nil
+	}
+	uevents, err := linux.OpenUevents(time.Second)
+	if err != nil {
+		// Attached devices are still reported, only the hotplug events are missing
+		c.logger.Warnf("Unable to subscribe to kernel uevents, hotplug events will not be reported: %v", err)
+	} else {
+		c.workers = viamutils.NewBackgroundStoppableWorkers(func(ctx context.Context) {
+			c.watchUevents(ctx, uevents)
+		})
+	}
+	return nil
+}
+
+func (c *Config) Readings(ctx context.Context, extra map[string]interface{}) (map[string]interface{}, error) {
+	devices, err := linux.ReadUSBDevices(ctx, linux.USBDevicesRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	ret := make(map[string]interface{})
+	ret["device_count"] = len(devices)
+	for _, d := range devices {
+		key := deviceKey(d.Path)
+		ret[key+"_id"] = d.ID()
+		ret[key+"_product"] = d.Product
+		ret[key+"_speed_mbps"] = d.Speed
+		ret[key+"_drivers"] = strings.Join(d.Drivers, ",")
+	}
+	ports, err := linux.ReadUSBPorts(ctx, linux.USBDevicesRoot)
+	if err != nil {
+		return nil, err
+	}
+	for _, port := range ports {
+		key := portKey(port.Name)
+		ret[key+"_disabled"] = port.Disabled
+		ret[key+"_over_current_count"] = port.OverCurrentCount
+		ret[key+"_device"] = port.Device
+	}
+
+	c.readingsLock.Lock()
+	defer c.readingsLock.Unlock()
+	ret["connect_events"] = c.counters.Get(connectEventsKey)
+	ret["disconnect_events"] = c.counters.Get(disconnectEventsKey)
+	ret["counters_since"] = c.counters.Since().Format(time.RFC3339)
+	if c.lastEvent != nil {
+		ret["last_event_action"] = c.lastEvent.Action
+		ret["last_event_device"] = productID(c.lastEvent.Env["PRODUCT"])
+		ret["last_event_time"] = c.lastEventAt.Format(time.RFC3339)
+	}
+	if len(c.expected) == 0 {
+		return ret, nil
+	}
+
+	missing := make([]string, 0)
+	for _, expected := range c.expected {
+		device := findDevice(devices, expected)
+		present := device != nil
+		ret[expected.Name+"_present"] = present
+		ret[expected.Name+"_disconnects"] = c.counters.Get(disconnectsKey(expected.VendorID + ":" + expected.ProductID))
+		if present {
+			ret[expected.Name+"_path"] = device.Path
+			ret[expected.Name+"_speed_mbps"] = device.Speed
+			if expected.MinSpeedMbps > 0 {
+				ret[expected.Name+"_speed_ok"] = device.Speed >= expected.MinSpeedMbps
+			}
+		} else {
+			missing = append(missing, expected.Name)
+		}
+		if !present && !c.missing[expected.Name] {
+			c.logger.Warnf("Expected USB device %s (%s:%s) is missing", expected.Name, expected.VendorID, expected.ProductID)
+		}
+		c.missing[expected.Name] = !present
+	}
+	sort.Strings(missing)
+	ret["missing_count"] = len(missing)
+	ret["missing"] = strings.Join(missing, ",")
+	return ret, nil
+}
+
+const (
+	connectEventsKey    = "connect_events"
+	disconnectEventsKey = "disconnect_events"
+)
+
+// disconnectsKey is the counter of the remove events of a vendor:product ID.
+func disconnectsKey(id string) string {
+	return "disconnects_" + id
+}
+
+func findDevice(devices []linux.USBDevice, expected ExpectedDevice) *linux.USBDevice {
+	for i, d := range devices {
+		if d.VendorID != expected.VendorID || d.ProductID != expected.ProductID {
+			continue
+		}
+		if expected.Serial != "" && d.Serial != expected.Serial {
+			continue
+		}
+		return &devices[i]
+	}
+	return nil
+}
+
+// deviceKey converts a sysfs path into a reading key prefix, e.g. 1-1.2 becomes usb_1_1_2.
+func deviceKey(path string) string {
+	return "usb_" + strings.NewReplacer("-", "_", ".", "_").Replace(path)
+}
+
+// portKey converts a port name into a reading key prefix, e.g. 1-1-port2 becomes usb_1_1_port2 and usb1-port1
+// becomes usb1_port1.
+func portKey(name string) string {
+	key := strings.NewReplacer("-", "_", ".", "_").Replace(name)
+	if !strings.HasPrefix(key, "usb") {
+		key = "usb_" + key
+	}
+	return key
+}
+
+// productID converts the PRODUCT uevent variable, the vendor, product and device release in hex without leading
+// zeros, e.g. 46d/825/10, into the vendor:product form lsusb uses.
+func productID(product string) string {
+	parts := strings.Split(product, "/")
+	if len(parts) < 2 {
+		return product
+	}
+	vendor, err := strconv.ParseUint(parts[0], 16, 16)
+	if err != nil {
+		return product
+	}
+	id, err := strconv.ParseUint(parts[1], 16, 16)
+	if err != nil {
+		return product
+	}
+	return fmt.Sprintf("%04x:%04x", vendor, id)
+}
+
+func (c *Config) watchUevents(ctx context.Context, uevents *linux.UeventReader) {
+	defer uevents.Close()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		default:
+		}
+		event, err := uevents.Read()
+		if err != nil {
+			c.logger.Warnf("Failed to read kernel uevent: %v", err)
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(time.Second):
+			}
+			continue
+		}
+		// Only whole devices are interesting, every interface of a device raises its own event
+		if event == nil || event.Subsystem != "usb" || event.DevType != "usb_device" {
+			continue
+		}
+		if event.Action != "add" && event.Action != "remove" {
+			continue
+		}
+		id := productID(event.Env["PRODUCT"])
+		c.logger.Infof("USB device %s %s: %s", id, event.DevPath, event.Action)
+		c.readingsLock.Lock()
+		if event.Action == "add" {
+			c.counters.Add(connectEventsKey, 1)
+		} else {
+			c.counters.Add(disconnectEventsKey, 1)
+			c.counters.Add(disconnectsKey(id), 1)
+		}
+		c.lastEvent = event
+		c.lastEventAt = time.Now()
+		c.readingsLock.Unlock()
+	}
+}
+
+func (c *Config) DoCommand(ctx context.Context, cmd map[string]interface{}) (map[string]interface{}, error) {
+	c.configLock.Lock()
+	defer c.configLock.Unlock()
+
+	command, ok := cmd["command"].(string)
+	if !ok {
+		return nil, errors.New("missing or invalid 'command' field")
+	}
+
+	switch command {
+	case "list_ports":
+		return c.handleListPorts(ctx)
+	case "power_off":
+		return c.handleSetPortPower(ctx, cmd, false)
+	case "power_on":
+		return c.handleSetPortPower(ctx, cmd, true)
+	case "power_cycle":
+		return c.handlePowerCycle(ctx, cmd)
+	default:
+		return nil, fmt.Errorf("unknown command: %s", command)
+	}
+}
+
+func (c *Config) handleListPorts(ctx context.Context) (map[string]interface{}, error) {
+	ports, err := linux.ReadUSBPorts(ctx, linux.USBDevicesRoot)
+	if err != nil {
+		return nil, err
+	}
+	list := make([]interface{}, 0, len(ports))
+	for _, port := range ports {
+		list = append(list, map[string]interface{}{
+			"port":               port.Name,
+			"device":             port.Device,
+			"disabled":           port.Disabled,
+			"over_current_count": port.OverCurrentCount,
+			"connect_type":       port.ConnectType,
+		})
+	}
+	return map[string]interface{}{"ports": list}, nil
+}
+
+// findPort looks up the port named by the 'port' parameter, which is either a port name, e.g. 1-1-port2, or the
+// path of the device attached to it, e.g. 1-1.2.
+func (c *Config) findPort(ctx context.Context, cmd map[string]interface{}) (*linux.USBPort, error) {
+	name, ok := cmd["port"].(string)
+	if !ok || name == "" {
+		return nil, errors.New("missing or invalid 'port' parameter")
+	}
+	ports, err := linux.ReadUSBPorts(ctx, linux.USBDevicesRoot)
+	if err != nil {
+		return nil, err
+	}
+	return linux.FindUSBPort(ports, name)
+}
+
+func (c *Config) handleSetPortPower(ctx context.Context, cmd map[string]interface{}, on bool) (map[string]interface{}, error) {
+	port, err := c.findPort(ctx, cmd)
+	if err != nil {
+		return nil, err
+	}
+	state := "off"
+	if on {
+		state = "on"
+	}
+	c.logger.Infof("Powering %s USB port %s", state, port.Name)
+	if err := port.SetPower(on); err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{"status": "ok", "port": port.Name, "disabled": port.Disabled}, nil
+}
+
+func (c *Config) handlePowerCycle(ctx context.Context, cmd map[string]interface{}) (map[string]interface{}, error) {
+	port, err := c.findPort(ctx, cmd)
+	if err != nil {
+		return nil, err
+	}
+	delay := defaultPowerCycleDelay
+	if delayMs, ok := cmd["delay_ms"].(float64); ok && delayMs > 0 {
+		delay = time.Duration(delayMs) * time.Millisecond
+	}
+	c.logger.Infof("Power cycling USB port %s", port.Name)
+	if err := port.SetPower(false); err != nil {
+		return nil, err
+	}
+	select {
+	case <-ctx.Done():
+		// Never leave the port off, even if the caller gave up waiting
+		if err := port.SetPower(true); err != nil {
+			return nil, err
+		}
+		return nil, ctx.Err()
+	case <-time.After(delay):
+	}
+	if err := port.SetPower(true); err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{"status": "ok", "port": port.Name}, nil
+}
+
+func (c *Config) Close(ctx context.Context) error {
+	c.configLock.Lock()
+	defer c.configLock.Unlock()
+	c.logger.Infof("Shutting down %s", PrettyName)
+	if c.workers != nil {
+		c.workers.Stop()
+	}
+	if err := c.counters.Flush(); err != nil {
+		c.logger.Warnf("Failed to save the counters to %s: %v", c.counters.Path(), err)
+	}
+	c.logger.Infof("%s Shutdown complete", PrettyName)
+	return nil
+}
+
+func (c *Config) Ready(ctx context.Context, extra map[string]interface{}) (bool, error) {
+	return false, nil
+}
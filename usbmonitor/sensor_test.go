@@ -0,0 +1,35 @@
+package usbmonitor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/linux"
+)
+
+func TestProductID(t *testing.T) {
+	assert.Equal(t, "046d:0825", productID("46d/825/10"))
+	assert.Equal(t, "garbage", productID("garbage"))
+}
+
+func TestFindDevice(t *testing.T) {
+	devices := []linux.USBDevice{
+		{Path: "1-1.1", VendorID: "046d", ProductID: "0825", Serial: "AAAA"},
+		{Path: "1-1.2", VendorID: "046d", ProductID: "0825", Serial: "BBBB"},
+	}
+	device := findDevice(devices, ExpectedDevice{VendorID: "046d", ProductID: "0825", Serial: "BBBB"})
+	require.NotNil(t, device)
+	assert.Equal(t, "1-1.2", device.Path)
+	assert.Nil(t, findDevice(devices, ExpectedDevice{VendorID: "10c4", ProductID: "ea60"}))
+}
+
+func TestDeviceKey(t *testing.T) {
+	assert.Equal(t, "usb_1_1_2", deviceKey("1-1.2"))
+}
+
+func TestPortKey(t *testing.T) {
+	assert.Equal(t, "usb_1_1_port2", portKey("1-1-port2"))
+	assert.Equal(t, "usb1_port1", portKey("usb1-port1"))
+}
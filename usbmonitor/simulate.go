@@ -0,0 +1,70 @@
+package usbmonitor
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/linux"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+// simulatedDevices are a USB 3 webcam and a CP2102 serial adapter on the USB ports of a Raspberry Pi 5.
+var simulatedDevices = []linux.USBDevice{
+	{Path: "2-1", Bus: 2, VendorID: "046d", ProductID: "085e", Manufacturer: "Logitech", Product: "BRIO",
+		Serial: "4E1A2F30", Speed: 5000, Drivers: []string{"uvcvideo", "snd-usb-audio"}},
+	{Path: "3-1", Bus: 3, VendorID: "10c4", ProductID: "ea60", Manufacturer: "Silicon Labs",
+		Product: "CP2102 USB to UART Bridge Controller", Serial: "0001", Speed: 12, Drivers: []string{"cp210x"}},
+}
+
+var simulatedPorts = []linux.USBPort{
+	{Name: "usb2-port1", Device: "2-1"},
+	{Name: "usb3-port1", Device: "3-1"},
+}
+
+// simulate returns the simulated devices, and which of the expected devices are among them.
+func simulate(sim *utils.Simulation) map[string]interface{} {
+	ret := map[string]interface{}{
+		"device_count":      len(simulatedDevices),
+		"connect_events":    int64(0),
+		"disconnect_events": int64(0),
+		"counters_since":    sim.Started().Format(time.RFC3339),
+	}
+	for _, d := range simulatedDevices {
+		key := deviceKey(d.Path)
+		ret[key+"_id"] = d.ID()
+		ret[key+"_product"] = d.Product
+		ret[key+"_speed_mbps"] = d.Speed
+		ret[key+"_drivers"] = strings.Join(d.Drivers, ",")
+	}
+	for _, port := range simulatedPorts {
+		key := portKey(port.Name)
+		ret[key+"_disabled"] = port.Disabled
+		ret[key+"_over_current_count"] = port.OverCurrentCount
+		ret[key+"_device"] = port.Device
+	}
+	conf, ok := sim.Config().(*ComponentConfig)
+	if !ok || len(conf.Expected) == 0 {
+		return ret
+	}
+
+	missing := make([]string, 0)
+	for _, expected := range conf.Expected {
+		device := findDevice(simulatedDevices, expected)
+		ret[expected.Name+"_present"] = device != nil
+		ret[expected.Name+"_disconnects"] = int64(0)
+		if device == nil {
+			missing = append(missing, expected.Name)
+			continue
+		}
+		ret[expected.Name+"_path"] = device.Path
+		ret[expected.Name+"_speed_mbps"] = device.Speed
+		if expected.MinSpeedMbps > 0 {
+			ret[expected.Name+"_speed_ok"] = device.Speed >= expected.MinSpeedMbps
+		}
+	}
+	sort.Strings(missing)
+	ret["missing_count"] = len(missing)
+	ret["missing"] = strings.Join(missing, ",")
+	return ret
+}
@@ -0,0 +1,114 @@
+package utils
+
+import (
+	"errors"
+	"math"
+	"time"
+)
+
+// defaultChangePercent is how much a reading changes between polls to count as changing, unless it's configured.
+const defaultChangePercent = 5
+
+// AdaptiveInterval is the adaptive attribute of the components that poll in the background. Polling slows down while
+// the readings are stable and speeds up when they change quickly or are past a warning threshold, so an idle board
+// isn't polled as often as a busy one.
+type AdaptiveInterval struct {
+	MinIntervalMs int                `json:"min_interval_ms"`          // The fastest the component is polled at
+	MaxIntervalMs int                `json:"max_interval_ms"`          // The slowest the component is polled at
+	ChangePercent float64            `json:"change_percent,omitempty"` // How much a reading changes between polls to count as changing, defaults to 5
+	Warnings      map[string]float64 `json:"warnings,omitempty"`       // Patterns of readings polled at the min interval while at or above the value
+}
+
+func (a *AdaptiveInterval) Validate() error {
+	if a.MinIntervalMs <= 0 {
+		return errors.New("adaptive.min_interval_ms must be positive")
+	}
+	if a.MaxIntervalMs < a.MinIntervalMs {
+		return errors.New("adaptive.max_interval_ms must not be less than adaptive.min_interval_ms")
+	}
+	if a.ChangePercent < 0 {
+		return errors.New("adaptive.change_percent must not be negative")
+	}
+	for pattern := range a.Warnings {
+		if pattern == "" {
+			return errors.New("adaptive.warnings patterns can't be empty")
+		}
+	}
+	return nil
+}
+
+// Clamp returns interval within the min and max intervals.
+func (a *AdaptiveInterval) Clamp(interval time.Duration) time.Duration {
+	return min(max(interval, a.minInterval()), a.maxInterval())
+}
+
+func (a *AdaptiveInterval) minInterval() time.Duration {
+	return time.Duration(a.MinIntervalMs) * time.Millisecond
+}
+
+func (a *AdaptiveInterval) maxInterval() time.Duration {
+	return time.Duration(a.MaxIntervalMs) * time.Millisecond
+}
+
+// AdaptivePoller picks how long to wait for a task's next poll from the readings of its last one. It's only used from
+// the task's poll, which the scheduler never runs twice at once.
+type AdaptivePoller struct {
+	conf AdaptiveInterval
+	last map[string]float64
+}
+
+func NewAdaptivePoller(conf AdaptiveInterval) *AdaptivePoller {
+	return &AdaptivePoller{conf: conf}
+}
+
+// Next returns the interval to poll at after readings were polled at interval. A reading at or above its warning, or
+// that just dropped below it, polls at the min interval. A reading that changed polls twice as often, and when none
+// changed the interval grows by half, up to the max interval.
+func (p *AdaptivePoller) Next(interval time.Duration, readings map[string]interface{}) time.Duration {
+	values := NumericReadings(readings)
+	last := p.last
+	p.last = values
+	for key, value := range values {
+		for pattern, warning := range p.conf.Warnings {
+			if !MatchPattern(pattern, key) {
+				continue
+			}
+			if prev, ok := last[key]; value >= warning || (ok && prev >= warning) {
+				return p.conf.minInterval()
+			}
+		}
+	}
+	if last == nil {
+		return p.conf.Clamp(interval)
+	}
+	changePercent := p.conf.ChangePercent
+	if changePercent == 0 {
+		changePercent = defaultChangePercent
+	}
+	// Readings that come and go, e.g. a probe that failed to read, count as changing
+	if len(values) != len(last) {
+		return p.conf.Clamp(interval / 2)
+	}
+	for key, value := range values {
+		if prev, ok := last[key]; !ok || changed(prev, value, changePercent) {
+			return p.conf.Clamp(interval / 2)
+		}
+	}
+	return p.conf.Clamp(interval * 3 / 2)
+}
+
+// Adjust sets the interval t polls at from the readings of its last poll. It does nothing when the component isn't
+// adaptive, p is nil, or isn't polling yet, t is nil.
+func (p *AdaptivePoller) Adjust(t *Task, readings map[string]interface{}) {
+	if p == nil || t == nil {
+		return
+	}
+	t.SetInterval(p.Next(t.Interval(), readings))
+}
+
+// changed reports whether value differs from prev by more than percent of the larger of the two. Values smaller than 1
+// are compared with 1, so a reading going from 0.01 to 0.02 doesn't count as doubling.
+func changed(prev, value, percent float64) bool {
+	scale := max(math.Abs(prev), math.Abs(value), 1)
+	return math.Abs(value-prev) > scale*percent/100
+}
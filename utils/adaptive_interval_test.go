@@ -0,0 +1,40 @@
+package utils
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAdaptivePoller(t *testing.T) {
+	conf := AdaptiveInterval{MinIntervalMs: 1000, MaxIntervalMs: 8000, Warnings: map[string]float64{"cpu*": 80}}
+	p := NewAdaptivePoller(conf)
+
+	// The first poll has nothing to compare with, the interval is only kept within the limits
+	assert.Equal(t, 8*time.Second, p.Next(30*time.Second, map[string]interface{}{"cpu": 10.0, "temp": 45.0}))
+
+	// Stable readings slow down up to the max interval
+	assert.Equal(t, 3*time.Second, p.Next(2*time.Second, map[string]interface{}{"cpu": 10.2, "temp": 45.0}))
+	assert.Equal(t, 8*time.Second, p.Next(8*time.Second, map[string]interface{}{"cpu": 10.0, "temp": 45.1}))
+
+	// A reading that changes speeds up
+	assert.Equal(t, 4*time.Second, p.Next(8*time.Second, map[string]interface{}{"cpu": 40.0, "temp": 45.1}))
+
+	// Past a warning polls at the min interval, and so does the poll after it drops back below
+	assert.Equal(t, time.Second, p.Next(4*time.Second, map[string]interface{}{"cpu": 85.0, "temp": 45.1}))
+	assert.Equal(t, time.Second, p.Next(time.Second, map[string]interface{}{"cpu": 40.0, "temp": 45.1}))
+	assert.Equal(t, 1500*time.Millisecond, p.Next(time.Second, map[string]interface{}{"cpu": 40.0, "temp": 45.1}))
+
+	// A reading that disappears or appears counts as changing
+	assert.Equal(t, time.Second, p.Next(1500*time.Millisecond, map[string]interface{}{"cpu": 40.0}))
+	assert.Equal(t, time.Second, p.Next(time.Second, map[string]interface{}{"cpu": 40.0, "probe": 21.0}))
+}
+
+func TestAdaptiveIntervalValidate(t *testing.T) {
+	assert.NoError(t, (&AdaptiveInterval{MinIntervalMs: 1000, MaxIntervalMs: 1000}).Validate())
+	assert.Error(t, (&AdaptiveInterval{MaxIntervalMs: 1000}).Validate())
+	assert.Error(t, (&AdaptiveInterval{MinIntervalMs: 2000, MaxIntervalMs: 1000}).Validate())
+	assert.Error(t, (&AdaptiveInterval{MinIntervalMs: 1000, MaxIntervalMs: 2000, ChangePercent: -1}).Validate())
+	assert.Error(t, (&AdaptiveInterval{MinIntervalMs: 1000, MaxIntervalMs: 2000, Warnings: map[string]float64{"": 1}}).Validate())
+}
@@ -0,0 +1,130 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ErrBackendDegraded is returned for commands that aren't run because they've kept failing, until they're retried.
+var ErrBackendDegraded = errors.New("backend degraded")
+
+const (
+	// backendFailureThreshold is how many times in a row a command fails before it's degraded
+	backendFailureThreshold = 3
+	// backendMinRetryDelay is how long a degraded command waits to be retried, doubling every time the retry fails
+	backendMinRetryDelay = time.Second
+	// backendMaxRetryDelay is the longest a degraded command waits to be retried, so one that starts working again,
+	// e.g. after its package is installed, is picked up within a few minutes
+	backendMaxRetryDelay = 5 * time.Minute
+)
+
+// BackendHealth is whether a command the module reads from is failing, e.g. vcgencmd in a container without
+// /dev/vchiq or a binary that isn't installed.
+type BackendHealth struct {
+	ConsecutiveFailures int
+	// Degraded is whether the command has failed often enough that it's only run to retry it
+	Degraded bool
+	// RetryAt is when a degraded command is next run
+	RetryAt   time.Time
+	LastError string
+}
+
+// circuitBreaker stops running commands that keep failing, so a missing binary isn't run and its failure logged at
+// every poll. A degraded command is retried with an exponential delay, and one that succeeds is healthy again.
+type circuitBreaker struct {
+	mu       sync.Mutex
+	backends map[string]*backendState
+	now      func() time.Time
+}
+
+type backendState struct {
+	failures int
+	retryAt  time.Time
+	lastErr  error
+}
+
+func newCircuitBreaker() *circuitBreaker {
+	return &circuitBreaker{backends: make(map[string]*backendState), now: time.Now}
+}
+
+// allow returns ErrBackendDegraded if name is degraded and isn't due to be retried. The caller that's let through to
+// retry it holds the others off until its result is recorded.
+func (b *circuitBreaker) allow(name string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	state, ok := b.backends[name]
+	if !ok || state.failures < backendFailureThreshold {
+		return nil
+	}
+	now := b.now()
+	if now.Before(state.retryAt) {
+		return fmt.Errorf("%w: %s failed %d times in a row, retrying in %s: %w", ErrBackendDegraded, name, state.failures,
+			state.retryAt.Sub(now).Round(time.Millisecond), state.lastErr)
+	}
+	state.retryAt = now.Add(retryDelay(state.failures))
+	return nil
+}
+
+// record records the result of running name.
+func (b *circuitBreaker) record(name string, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if err == nil {
+		delete(b.backends, name)
+		return
+	}
+	state, ok := b.backends[name]
+	if !ok {
+		state = &backendState{}
+		b.backends[name] = state
+	}
+	state.failures++
+	state.lastErr = err
+	if state.failures >= backendFailureThreshold {
+		state.retryAt = b.now().Add(retryDelay(state.failures))
+	}
+}
+
+func (b *circuitBreaker) health() map[string]BackendHealth {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	ret := make(map[string]BackendHealth, len(b.backends))
+	for name, state := range b.backends {
+		health := BackendHealth{
+			ConsecutiveFailures: state.failures,
+			Degraded:            state.failures >= backendFailureThreshold,
+			LastError:           state.lastErr.Error(),
+		}
+		if health.Degraded {
+			health.RetryAt = state.retryAt
+		}
+		ret[name] = health
+	}
+	return ret
+}
+
+func (b *circuitBreaker) reset() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.backends = make(map[string]*backendState)
+}
+
+// retryDelay is how long a command that's failed failures times in a row waits to be retried.
+func retryDelay(failures int) time.Duration {
+	delay := backendMinRetryDelay
+	for i := backendFailureThreshold; i < failures && delay < backendMaxRetryDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, backendMaxRetryDelay)
+}
+
+// commandBreaker is the circuit breaker of the commands run through Output, CombinedOutput and
+// CombinedOutputWithInput, keyed by the command.
+var commandBreaker = newCircuitBreaker()
+
+// CommandHealth returns the health of every command that's failed since it last succeeded, keyed by the command.
+func CommandHealth() map[string]BackendHealth {
+	return commandBreaker.health()
+}
@@ -0,0 +1,86 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"os/exec"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	now := time.Now()
+	breaker := newCircuitBreaker()
+	breaker.now = func() time.Time { return now }
+	missing := exec.ErrNotFound
+
+	// Failures below the threshold are retried at once
+	for range backendFailureThreshold - 1 {
+		require.NoError(t, breaker.allow("vcgencmd"))
+		breaker.record("vcgencmd", missing)
+	}
+	require.NoError(t, breaker.allow("vcgencmd"))
+	breaker.record("vcgencmd", missing)
+
+	// Then it's degraded until it's due to be retried
+	err := breaker.allow("vcgencmd")
+	assert.ErrorIs(t, err, ErrBackendDegraded)
+	assert.ErrorIs(t, err, exec.ErrNotFound)
+	assert.NoError(t, breaker.allow("iw"))
+	health := breaker.health()["vcgencmd"]
+	assert.True(t, health.Degraded)
+	assert.Equal(t, backendFailureThreshold, health.ConsecutiveFailures)
+	assert.Equal(t, now.Add(backendMinRetryDelay), health.RetryAt)
+
+	// One caller retries it, the others wait for its result, and a failed retry doubles the delay
+	now = now.Add(backendMinRetryDelay)
+	require.NoError(t, breaker.allow("vcgencmd"))
+	assert.ErrorIs(t, breaker.allow("vcgencmd"), ErrBackendDegraded)
+	breaker.record("vcgencmd", missing)
+	assert.Equal(t, now.Add(2*backendMinRetryDelay), breaker.health()["vcgencmd"].RetryAt)
+
+	// A retry that succeeds makes it healthy again
+	now = now.Add(2 * backendMinRetryDelay)
+	require.NoError(t, breaker.allow("vcgencmd"))
+	breaker.record("vcgencmd", nil)
+	assert.Empty(t, breaker.health())
+	assert.NoError(t, breaker.allow("vcgencmd"))
+}
+
+func TestRetryDelay(t *testing.T) {
+	assert.Equal(t, backendMinRetryDelay, retryDelay(backendFailureThreshold))
+	assert.Equal(t, 4*backendMinRetryDelay, retryDelay(backendFailureThreshold+2))
+	assert.Equal(t, backendMaxRetryDelay, retryDelay(backendFailureThreshold+100))
+}
+
+func TestCommandHealth(t *testing.T) {
+	t.Cleanup(func() { SetCommandRunner(DefaultCommandRunner()) })
+
+	var ran int
+	SetCommandRunner(CommandRunnerFunc(func(ctx context.Context, cmd Command) ([]byte, error) {
+		ran++
+		return nil, exec.ErrNotFound
+	}))
+	for range backendFailureThreshold + 2 {
+		_, err := Output(context.Background(), "nvidia-smi")
+		assert.Error(t, err)
+	}
+	// A missing binary isn't run again until it's due to be retried
+	assert.Equal(t, backendFailureThreshold, ran)
+	assert.True(t, CommandHealth()["nvidia-smi"].Degraded)
+
+	// Commands the caller gave up on aren't the command's failures
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	SetCommandRunner(CommandRunnerFunc(func(ctx context.Context, cmd Command) ([]byte, error) {
+		return nil, ctx.Err()
+	}))
+	for range backendFailureThreshold {
+		_, err := Output(ctx, "iw", "dev")
+		assert.True(t, errors.Is(err, context.Canceled))
+	}
+	assert.Empty(t, CommandHealth())
+}
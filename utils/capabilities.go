@@ -0,0 +1,90 @@
+package utils
+
+import (
+	"context"
+	"sort"
+	"time"
+)
+
+// CapabilitiesCommand is the DoCommand every component takes to report how it collects its readings.
+const CapabilitiesCommand = "get_capabilities"
+
+// Capabilities describes the backend a component selected and which of its optional readings aren't available, so a
+// missing reading can be explained without the module's debug logs.
+type Capabilities struct {
+	// Backend is what the readings come from, e.g. iw, nmcli or /proc/net/wireless
+	Backend string
+	// Unavailable maps the readings that aren't available to why
+	Unavailable map[string]string
+}
+
+// CapabilityReporter is implemented by components that select a backend or have readings that depend on the board.
+type CapabilityReporter interface {
+	Capabilities(ctx context.Context) Capabilities
+}
+
+// capabilitiesResult builds the response to the capabilities command from what the component reported and its
+// current readings. Readings dropped by the readings filter and the metrics the component failed to read are reported
+// as unavailable too.
+func capabilitiesResult(caps Capabilities, readings map[string]interface{}, readingsErr error, filter *ReadingsFilter) map[string]interface{} {
+	unavailable := make(map[string]interface{}, len(caps.Unavailable))
+	for key, reason := range caps.Unavailable {
+		unavailable[key] = reason
+	}
+	ret := map[string]interface{}{
+		"backend":     caps.Backend,
+		"unavailable": unavailable,
+	}
+	if readingsErr != nil {
+		ret["readings_error"] = readingsErr.Error()
+		ret["readings_error_kind"] = ErrorKind(readingsErr)
+		return ret
+	}
+	filtered := filter.Apply(readings)
+	keys := make([]string, 0, len(filtered))
+	for key := range filtered {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	ret["readings"] = stringsToInterfaces(keys)
+	if errs, ok := readings[ReadingErrorsKey].(map[string]interface{}); ok {
+		for metric, reason := range errs {
+			unavailable[metric] = reason
+		}
+	}
+	for key := range readings {
+		if key == ReadingErrorsKey || key == ReadingErrorKindsKey {
+			continue
+		}
+		if filter != nil && !filter.included(key) {
+			unavailable[key] = "excluded by " + ReadingsFilterAttribute
+		}
+	}
+	return ret
+}
+
+// addBackendHealth adds the health of the component's backend to the capabilities result, when the backend is a command
+// that's failed since it last succeeded.
+func addBackendHealth(ret map[string]interface{}, backend string, health map[string]BackendHealth) {
+	h, ok := health[backend]
+	if !ok {
+		return
+	}
+	result := map[string]interface{}{
+		"consecutive_failures": h.ConsecutiveFailures,
+		"degraded":             h.Degraded,
+		"last_error":           h.LastError,
+	}
+	if h.Degraded {
+		result["retry_at"] = h.RetryAt.Format(time.RFC3339)
+	}
+	ret["backend_health"] = result
+}
+
+func stringsToInterfaces(s []string) []interface{} {
+	r := make([]interface{}, len(s))
+	for i, v := range s {
+		r[i] = v
+	}
+	return r
+}
@@ -0,0 +1,56 @@
+package utils
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCapabilitiesResult(t *testing.T) {
+	caps := Capabilities{Backend: "nmcli", Unavailable: map[string]string{"noise": "only measured by iw, which isn't installed"}}
+	readings := map[string]interface{}{"network": "home", "signal_strength": -52, "noise": 0}
+	filter := &ReadingsFilter{Exclude: []string{"signal_*"}, Prefix: "nav_"}
+
+	ret := capabilitiesResult(caps, readings, nil, filter)
+	assert.Equal(t, "nmcli", ret["backend"])
+	assert.Equal(t, []interface{}{"nav_network", "nav_noise"}, ret["readings"])
+	assert.Equal(t, map[string]interface{}{
+		"noise":           "only measured by iw, which isn't installed",
+		"signal_strength": "excluded by readings_filter",
+	}, ret["unavailable"])
+	// What the component reported isn't changed
+	assert.Len(t, caps.Unavailable, 1)
+
+	// The metrics the component failed to read are unavailable
+	readings[ReadingErrorsKey] = map[string]interface{}{"saved_networks": "nmcli timed out"}
+	ret = capabilitiesResult(caps, readings, nil, nil)
+	assert.Equal(t, "nmcli timed out", ret["unavailable"].(map[string]interface{})["saved_networks"])
+
+	ret = capabilitiesResult(Capabilities{}, nil, errors.New("adapter not found"), nil)
+	assert.Equal(t, "", ret["backend"])
+	assert.Equal(t, "adapter not found", ret["readings_error"])
+	assert.Equal(t, ErrorKindUnknown, ret["readings_error_kind"])
+
+	ret = capabilitiesResult(Capabilities{}, nil, NewError(ErrHardwareAbsent, "adapter not found"), nil)
+	assert.Equal(t, ErrorKindHardwareAbsent, ret["readings_error_kind"])
+	assert.NotContains(t, ret, "readings")
+}
+
+func TestAddBackendHealth(t *testing.T) {
+	retryAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	health := map[string]BackendHealth{"iw": {ConsecutiveFailures: 4, Degraded: true, RetryAt: retryAt, LastError: "executable file not found"}}
+
+	ret := map[string]interface{}{}
+	addBackendHealth(ret, "nmcli", health)
+	assert.Empty(t, ret)
+
+	addBackendHealth(ret, "iw", health)
+	assert.Equal(t, map[string]interface{}{
+		"consecutive_failures": 4,
+		"degraded":             true,
+		"last_error":           "executable file not found",
+		"retry_at":             retryAt.Format(time.RFC3339),
+	}, ret["backend_health"])
+}
@@ -0,0 +1,208 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrCommandNotAllowed is returned for commands a restricted CommandRunner doesn't run.
+	ErrCommandNotAllowed = errors.New("command not allowed")
+	// ErrCommandTimedOut is returned for commands that didn't get a worker and finish within their timeout.
+	ErrCommandTimedOut = errors.New("command timed out")
+)
+
+const (
+	// defaultCommandWorkers is how many commands run at once, enough that a hung command doesn't hold up the others
+	defaultCommandWorkers = 4
+	// defaultCommandTimeout is how long a command has to get a worker and finish, unless it's in commandTimeouts
+	defaultCommandTimeout = 10 * time.Second
+	// commandWaitDelay is how long a command that's been killed has to close its output, a child it left running that
+	// holds the output open would otherwise keep the read waiting
+	commandWaitDelay = time.Second
+)
+
+// commandTimeouts are the timeouts of the commands that take longer than defaultCommandTimeout.
+var commandTimeouts = map[string]time.Duration{
+	"dpkg-query":        30 * time.Second,
+	"jetson_clocks":     30 * time.Second,
+	"nvpmodel":          30 * time.Second,
+	"rpi-eeprom-update": 30 * time.Second,
+	"smartctl":          30 * time.Second,
+}
+
+// Command is a command for a CommandRunner to run.
+type Command struct {
+	Name string
+	Args []string
+	// Stdin is written to the command's standard input, empty for none
+	Stdin string
+	// Env is added to the module's environment
+	Env []string
+	// Combined returns standard error along with standard output
+	Combined bool
+	// Timeout overrides the runner's timeout for the command, e.g. for commands the user configured, zero for the
+	// runner's
+	Timeout time.Duration
+}
+
+// CommandRunner runs the commands sensors read from and the ones components run to change the board, e.g. vcgencmd or
+// cpufreq-set. Output, CombinedOutput and CombinedOutputWithInput run through it, so tests can supply canned output and
+// a policy can restrict what runs.
+type CommandRunner interface {
+	Run(ctx context.Context, cmd Command) ([]byte, error)
+}
+
+// CommandRunnerFunc is a function that runs commands, e.g. to answer with canned output in tests.
+type CommandRunnerFunc func(ctx context.Context, cmd Command) ([]byte, error)
+
+func (f CommandRunnerFunc) Run(ctx context.Context, cmd Command) ([]byte, error) {
+	return f(ctx, cmd)
+}
+
+// ExecRunner runs commands on the host, the default runner runs them with it.
+type ExecRunner struct{}
+
+func (ExecRunner) Run(ctx context.Context, cmd Command) ([]byte, error) {
+	c := exec.CommandContext(ctx, cmd.Name, cmd.Args...)
+	c.WaitDelay = commandWaitDelay
+	if cmd.Stdin != "" {
+		c.Stdin = strings.NewReader(cmd.Stdin)
+	}
+	if len(cmd.Env) > 0 {
+		c.Env = append(os.Environ(), cmd.Env...)
+	}
+	if cmd.Combined {
+		return c.CombinedOutput()
+	}
+	return c.Output()
+}
+
+// RestrictedRunner only runs the commands in Allowed, the others fail with ErrCommandNotAllowed.
+type RestrictedRunner struct {
+	Runner  CommandRunner
+	Allowed []string
+}
+
+func (r RestrictedRunner) Run(ctx context.Context, cmd Command) ([]byte, error) {
+	for _, allowed := range r.Allowed {
+		if cmd.Name == allowed {
+			return r.Runner.Run(ctx, cmd)
+		}
+	}
+	return nil, fmt.Errorf("%w: %s", ErrCommandNotAllowed, cmd.Name)
+}
+
+// PooledRunner runs at most as many commands at once as it has workers, the others queue for a free worker. Each
+// command has a timeout covering its wait and its run, so a command that hangs is killed and frees its worker rather
+// than the commands queued behind it waiting for good.
+type PooledRunner struct {
+	Runner CommandRunner
+	// Timeout is the timeout of the commands that aren't in Timeouts
+	Timeout  time.Duration
+	Timeouts map[string]time.Duration
+	workers  chan struct{}
+}
+
+func NewPooledRunner(runner CommandRunner, workers int, timeout time.Duration, timeouts map[string]time.Duration) *PooledRunner {
+	return &PooledRunner{Runner: runner, Timeout: timeout, Timeouts: timeouts, workers: make(chan struct{}, workers)}
+}
+
+// DefaultCommandRunner returns what runs commands unless it's been set, the host's commands run by a pool of workers.
+func DefaultCommandRunner() CommandRunner {
+	return NewPooledRunner(ExecRunner{}, defaultCommandWorkers, defaultCommandTimeout, commandTimeouts)
+}
+
+func (r *PooledRunner) Run(ctx context.Context, cmd Command) ([]byte, error) {
+	timeout, ok := r.Timeouts[cmd.Name]
+	if !ok {
+		timeout = r.Timeout
+	}
+	if cmd.Timeout > 0 {
+		timeout = cmd.Timeout
+	}
+	runCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	select {
+	case r.workers <- struct{}{}:
+	case <-runCtx.Done():
+		return nil, r.err(ctx, cmd, timeout, runCtx.Err())
+	}
+	defer func() { <-r.workers }()
+	out, err := r.Runner.Run(runCtx, cmd)
+	if err != nil && runCtx.Err() != nil {
+		return out, r.err(ctx, cmd, timeout, err)
+	}
+	return out, err
+}
+
+// err returns ErrCommandTimedOut if the command ran out of its own time rather than the caller's.
+func (r *PooledRunner) err(ctx context.Context, cmd Command, timeout time.Duration, err error) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	return fmt.Errorf("%w: %s after %s: %w", ErrCommandTimedOut, cmd.Name, timeout, err)
+}
+
+var (
+	commandRunnerLock sync.RWMutex
+	commandRunner     = DefaultCommandRunner()
+)
+
+// SetCommandRunner sets what runs commands.
+func SetCommandRunner(runner CommandRunner) {
+	commandRunnerLock.Lock()
+	defer commandRunnerLock.Unlock()
+	commandRunner = runner
+	ResetSharedReads()
+	commandBreaker.reset()
+}
+
+// CurrentCommandRunner returns what runs commands.
+func CurrentCommandRunner() CommandRunner {
+	commandRunnerLock.RLock()
+	defer commandRunnerLock.RUnlock()
+	return commandRunner
+}
+
+// Output runs a command and returns its standard output, like exec.Cmd.Output.
+func Output(ctx context.Context, name string, args ...string) ([]byte, error) {
+	return runCommand(ctx, Command{Name: name, Args: args})
+}
+
+// CombinedOutput runs a command and returns its standard output and error, like exec.Cmd.CombinedOutput.
+func CombinedOutput(ctx context.Context, name string, args ...string) ([]byte, error) {
+	return runCommand(ctx, Command{Name: name, Args: args, Combined: true})
+}
+
+// CombinedOutputWithInput is CombinedOutput with stdin written to the command's standard input.
+func CombinedOutputWithInput(ctx context.Context, stdin string, name string, args ...string) ([]byte, error) {
+	return runCommand(ctx, Command{Name: name, Args: args, Stdin: stdin, Combined: true})
+}
+
+// RunCommand runs cmd like Output, for commands that need e.g. a timeout of their own.
+func RunCommand(ctx context.Context, cmd Command) ([]byte, error) {
+	return runCommand(ctx, cmd)
+}
+
+// runCommand runs cmd with the current runner and records how long it took in the command's stats, unless the command
+// is degraded and isn't due to be retried.
+func runCommand(ctx context.Context, cmd Command) ([]byte, error) {
+	if err := commandBreaker.allow(cmd.Name); err != nil {
+		return nil, err
+	}
+	start := time.Now()
+	out, err := CurrentCommandRunner().Run(ctx, cmd)
+	commandRuns.record(cmd.Name, time.Since(start), err)
+	// Failures the caller caused, by giving up waiting or running a command that isn't allowed, aren't the command's
+	if err == nil || ctx.Err() == nil && !errors.Is(err, ErrCommandNotAllowed) {
+		commandBreaker.record(cmd.Name, err)
+	}
+	return out, err
+}
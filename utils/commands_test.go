@@ -0,0 +1,76 @@
+package utils
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCommandRunner(t *testing.T) {
+	t.Cleanup(func() { SetCommandRunner(DefaultCommandRunner()) })
+
+	var ran []Command
+	canned := CommandRunnerFunc(func(ctx context.Context, cmd Command) ([]byte, error) {
+		ran = append(ran, cmd)
+		return []byte("frequency(48)=1500000000"), nil
+	})
+	SetCommandRunner(RestrictedRunner{Runner: canned, Allowed: []string{"vcgencmd"}})
+
+	out, err := Output(context.Background(), "vcgencmd", "measure_clock", "arm")
+	require.NoError(t, err)
+	assert.Equal(t, "frequency(48)=1500000000", string(out))
+	_, err = CombinedOutputWithInput(context.Background(), "no\n", "nvpmodel", "-m", "0")
+	assert.ErrorIs(t, err, ErrCommandNotAllowed)
+	assert.Equal(t, []Command{{Name: "vcgencmd", Args: []string{"measure_clock", "arm"}}}, ran)
+}
+
+func TestExecRunner(t *testing.T) {
+	out, err := ExecRunner{}.Run(context.Background(), Command{Name: "sh", Args: []string{"-c", "cat; echo $HWMONITOR_TEST >&2"}, Stdin: "in", Env: []string{"HWMONITOR_TEST=env"}, Combined: true})
+	require.NoError(t, err)
+	assert.Equal(t, "inenv\n", string(out))
+}
+
+func TestPooledRunner(t *testing.T) {
+	release := make(chan struct{})
+	blocking := CommandRunnerFunc(func(ctx context.Context, cmd Command) ([]byte, error) {
+		if cmd.Name == "hung" {
+			<-ctx.Done()
+			return nil, ctx.Err()
+		}
+		<-release
+		return []byte(cmd.Name), nil
+	})
+	runner := NewPooledRunner(blocking, 1, 50*time.Millisecond, map[string]time.Duration{"slow": time.Second})
+
+	// A hung command is killed at its timeout, freeing its worker
+	_, err := runner.Run(context.Background(), Command{Name: "hung"})
+	assert.ErrorIs(t, err, ErrCommandTimedOut)
+
+	// Commands queue for the worker, one that doesn't get it within its timeout times out
+	done := make(chan error)
+	go func() {
+		_, err := runner.Run(context.Background(), Command{Name: "slow"})
+		done <- err
+	}()
+	time.Sleep(10 * time.Millisecond)
+	_, err = runner.Run(context.Background(), Command{Name: "queued"})
+	assert.ErrorIs(t, err, ErrCommandTimedOut)
+	close(release)
+	require.NoError(t, <-done)
+
+	// The caller's own cancelation isn't a timeout
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = runner.Run(ctx, Command{Name: "hung"})
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.NotErrorIs(t, err, ErrCommandTimedOut)
+
+	// A command's own timeout overrides the runner's
+	start := time.Now()
+	_, err = runner.Run(context.Background(), Command{Name: "hung", Timeout: 200 * time.Millisecond})
+	assert.ErrorIs(t, err, ErrCommandTimedOut)
+	assert.GreaterOrEqual(t, time.Since(start), 200*time.Millisecond)
+}
@@ -5,20 +5,19 @@ import (
 	"errors"
 	"io"
 	"math"
-	"os"
 	"strconv"
 	"strings"
 )
 
 var (
-	ErrBoardNotSupported    = errors.New("board not supported")
-	ErrPlatformNotSupported = errors.New("platform not supported")
+	ErrBoardNotSupported    = NewError(ErrHardwareAbsent, "board not supported")
+	ErrPlatformNotSupported = NewError(ErrHardwareAbsent, "platform not supported")
 )
 
 func ReadFileWithContext(ctx context.Context, path string) (string, error) {
 	fileChan := make(chan []byte, 1)
 	errChan := make(chan error, 1)
-	f, err := os.Open(path)
+	f, err := CurrentFS().Open(path)
 	if err != nil {
 		return "", err
 	}
@@ -40,6 +39,7 @@ func ReadFileWithContext(ctx context.Context, path string) (string, error) {
 		}
 		return "", ctx.Err()
 	case data := <-fileChan:
+		record(hostFS.read, path)
 		return strings.TrimSpace(string(data)), nil
 	case err := <-errChan:
 		return "", err
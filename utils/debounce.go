@@ -0,0 +1,110 @@
+package utils
+
+import (
+	"math"
+	"reflect"
+	"sync"
+	"time"
+)
+
+// readingsDebouncer holds back the changes of the readings a filter debounces or applies hysteresis to, so a reading
+// that flaps, such as a throttling flag or a link state, is only returned once it has settled. It keeps the value it
+// returns for each of those keys and since when the reading has differed from it.
+type readingsDebouncer struct {
+	mu       sync.Mutex
+	reported map[string]interface{}
+	changed  map[string]time.Time
+}
+
+// apply returns a copy of readings where the debounced keys have the value they last settled on. A reading has changed
+// when it's no longer equal to the returned value, or for those with hysteresis when it's moved at least that far from
+// it, and a debounced change is returned once the reading has differed for the debounce time. Keys are matched before
+// they're renamed, so it's applied before the filter.
+func (d *readingsDebouncer) apply(f *ReadingsFilter, readings map[string]interface{}, now time.Time) map[string]interface{} {
+	if f == nil || readings == nil || (len(f.DebounceSec) == 0 && len(f.Hysteresis) == 0) {
+		return readings
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.reported == nil {
+		d.reported = make(map[string]interface{})
+		d.changed = make(map[string]time.Time)
+	}
+	// Forget the keys that are no longer read, so they start from their next value if they come back
+	for key := range d.reported {
+		if _, ok := readings[key]; !ok {
+			delete(d.reported, key)
+			delete(d.changed, key)
+		}
+	}
+	ret := make(map[string]interface{}, len(readings))
+	for key, value := range readings {
+		ret[key] = value
+		if key == ReadingErrorsKey || key == ReadingErrorKindsKey {
+			continue
+		}
+		hold, debounced := longestMatch(f.DebounceSec, key)
+		band, hysteresis := longestMatch(f.Hysteresis, key)
+		if !debounced && !hysteresis {
+			continue
+		}
+		reported, ok := d.reported[key]
+		if !ok {
+			d.reported[key] = value
+			continue
+		}
+		if !readingChanged(reported, value, band) {
+			delete(d.changed, key)
+			ret[key] = reported
+			continue
+		}
+		if debounced {
+			since, ok := d.changed[key]
+			if !ok {
+				since = now
+				d.changed[key] = now
+			}
+			if now.Sub(since) < time.Duration(hold*float64(time.Second)) {
+				ret[key] = reported
+				continue
+			}
+		}
+		d.reported[key] = value
+		delete(d.changed, key)
+	}
+	return ret
+}
+
+// reset forgets the returned values, for when the filter changes.
+func (d *readingsDebouncer) reset() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.reported = nil
+	d.changed = nil
+}
+
+// readingChanged reports whether value differs from the returned reading, by at least band when both are numbers.
+func readingChanged(reported, value interface{}, band float64) bool {
+	from, ok1 := ToFloat64(reported)
+	to, ok2 := ToFloat64(value)
+	if ok1 && ok2 {
+		if band > 0 {
+			return math.Abs(to-from) >= band
+		}
+		return to != from
+	}
+	return !reflect.DeepEqual(reported, value)
+}
+
+// longestMatch returns the setting of the longest pattern that matches key, so a specific pattern overrides a wider one.
+func longestMatch(settings map[string]float64, key string) (float64, bool) {
+	var ret float64
+	matched := ""
+	found := false
+	for pattern, setting := range settings {
+		if MatchPattern(pattern, key) && (!found || len(pattern) > len(matched) || (len(pattern) == len(matched) && pattern < matched)) {
+			ret, matched, found = setting, pattern, true
+		}
+	}
+	return ret, found
+}
@@ -0,0 +1,57 @@
+package utils
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadingsDebouncer(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	f := &ReadingsFilter{
+		DebounceSec: map[string]float64{"*_throttled": 5},
+		Hysteresis:  map[string]float64{"temp": 2},
+	}
+	d := &readingsDebouncer{}
+	read := func(throttled bool, temp float64, after time.Duration) map[string]interface{} {
+		return d.apply(f, map[string]interface{}{"is_throttled": throttled, "temp": temp, "usage": temp}, now.Add(after))
+	}
+
+	assert.Equal(t, map[string]interface{}{"is_throttled": false, "temp": 50.0, "usage": 50.0}, read(false, 50, 0))
+	// A flag that flaps back before the debounce time isn't returned
+	assert.Equal(t, false, read(true, 50, time.Second)["is_throttled"])
+	assert.Equal(t, false, read(false, 50, 2*time.Second)["is_throttled"])
+	assert.Equal(t, false, read(true, 50, 3*time.Second)["is_throttled"])
+	assert.Equal(t, false, read(true, 50, 7*time.Second)["is_throttled"])
+	// It's returned once it has held for the debounce time
+	assert.Equal(t, true, read(true, 50, 8*time.Second)["is_throttled"])
+
+	// Numbers change once they've moved past the hysteresis, the other readings aren't held back
+	ret := read(true, 51.5, 9*time.Second)
+	assert.Equal(t, 50.0, ret["temp"])
+	assert.Equal(t, 51.5, ret["usage"])
+	assert.Equal(t, 52.0, read(true, 52, 10*time.Second)["temp"])
+	assert.Equal(t, 52.0, read(true, 50.5, 11*time.Second)["temp"])
+
+	// Keys that are no longer read start from their next value
+	d.apply(f, map[string]interface{}{"temp": 52.0}, now.Add(12*time.Second))
+	assert.Equal(t, false, read(false, 52, 13*time.Second)["is_throttled"])
+
+	// Without debounce settings the readings are returned as they are
+	readings := map[string]interface{}{"is_throttled": true}
+	assert.Equal(t, readings, (&readingsDebouncer{}).apply(&ReadingsFilter{}, readings, now))
+}
+
+func TestLongestMatch(t *testing.T) {
+	settings := map[string]float64{"*": 1, "link_*": 10, "link_up": 30}
+	hold, ok := longestMatch(settings, "link_up")
+	assert.True(t, ok)
+	assert.Equal(t, 30.0, hold)
+	hold, _ = longestMatch(settings, "link_speed")
+	assert.Equal(t, 10.0, hold)
+	hold, _ = longestMatch(settings, "usage")
+	assert.Equal(t, 1.0, hold)
+	_, ok = longestMatch(map[string]float64{"link_*": 10}, "usage")
+	assert.False(t, ok)
+}
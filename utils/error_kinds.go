@@ -0,0 +1,87 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os/exec"
+)
+
+// The kinds of errors the backends return, so a caller can tell "this board doesn't have that" from "something
+// broke" with errors.Is. Backends give their errors a kind with NewError or WithKind, and ErrorKind also recognizes the
+// standard errors that stand for one, e.g. exec.ErrNotFound.
+var (
+	// ErrBackendUnavailable is a tool or interface the readings come from that isn't there, e.g. a binary that isn't
+	// installed or a kernel built without the feature
+	ErrBackendUnavailable = errors.New("backend unavailable")
+	// ErrPermissionDenied is a backend the module isn't allowed to use, e.g. a device that needs root or a group
+	ErrPermissionDenied = errors.New("permission denied")
+	// ErrHardwareAbsent is hardware the board doesn't have or that isn't connected, e.g. a GPU or a fan
+	ErrHardwareAbsent = errors.New("hardware absent")
+	// ErrTransient is a failure that's expected to go away by itself, e.g. a command that timed out
+	ErrTransient = errors.New("transient error")
+)
+
+// The names of the kinds of errors in readings, see ErrorKind.
+const (
+	ErrorKindBackendUnavailable = "backend_unavailable"
+	ErrorKindPermissionDenied   = "permission_denied"
+	ErrorKindHardwareAbsent     = "hardware_absent"
+	ErrorKindTransient          = "transient"
+	ErrorKindUnknown            = "unknown"
+)
+
+// kindError gives an error a kind without changing its message.
+type kindError struct {
+	kind error
+	err  error
+}
+
+func (e *kindError) Error() string {
+	return e.err.Error()
+}
+
+func (e *kindError) Unwrap() []error {
+	return []error{e.kind, e.err}
+}
+
+// NewError returns an error of kind with message, e.g. NewError(ErrHardwareAbsent, "no HAT EEPROM found").
+func NewError(kind error, message string) error {
+	return &kindError{kind: kind, err: errors.New(message)}
+}
+
+// WithKind gives err the kind, keeping its message. It returns nil if err is nil.
+func WithKind(kind, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &kindError{kind: kind, err: err}
+}
+
+// ErrorKind returns the name of the kind of err, ErrorKindUnknown when it hasn't got one, or empty if err is nil. A
+// kind the backend gave the error wins over one the standard errors it wraps stand for.
+func ErrorKind(err error) string {
+	switch {
+	case err == nil:
+		return ""
+	case errors.Is(err, ErrPermissionDenied):
+		return ErrorKindPermissionDenied
+	case errors.Is(err, ErrHardwareAbsent):
+		return ErrorKindHardwareAbsent
+	case errors.Is(err, ErrBackendUnavailable):
+		return ErrorKindBackendUnavailable
+	case errors.Is(err, ErrTransient):
+		return ErrorKindTransient
+	case errors.Is(err, fs.ErrPermission):
+		return ErrorKindPermissionDenied
+	case errors.Is(err, exec.ErrNotFound), errors.Is(err, ErrCommandNotAllowed), errors.Is(err, ErrBackendDegraded):
+		// A degraded command isn't run until it's retried, it's unavailable until then whatever degraded it
+		return ErrorKindBackendUnavailable
+	case errors.Is(err, fs.ErrNotExist):
+		// A sysfs or device node that isn't there is hardware or a driver the board hasn't got
+		return ErrorKindHardwareAbsent
+	case errors.Is(err, ErrCommandTimedOut), errors.Is(err, context.DeadlineExceeded):
+		return ErrorKindTransient
+	}
+	return ErrorKindUnknown
+}
@@ -0,0 +1,53 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os/exec"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrorKind(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		kind string
+	}{
+		{name: "nil", err: nil, kind: ""},
+		{name: "plain", err: errors.New("parse error"), kind: ErrorKindUnknown},
+		{name: "tagged", err: NewError(ErrHardwareAbsent, "no HAT EEPROM found"), kind: ErrorKindHardwareAbsent},
+		{name: "wrapped tagged", err: fmt.Errorf("reading gpu: %w", ErrBoardNotSupported), kind: ErrorKindHardwareAbsent},
+		{name: "missing binary", err: &exec.Error{Name: "iw", Err: exec.ErrNotFound}, kind: ErrorKindBackendUnavailable},
+		{name: "command not allowed", err: fmt.Errorf("%w: nmcli", ErrCommandNotAllowed), kind: ErrorKindBackendUnavailable},
+		{name: "missing file", err: &fs.PathError{Op: "open", Path: "/sys/class/hwmon/hwmon9/fan1_input", Err: fs.ErrNotExist}, kind: ErrorKindHardwareAbsent},
+		{name: "no permission", err: &fs.PathError{Op: "open", Path: "/dev/mem", Err: fs.ErrPermission}, kind: ErrorKindPermissionDenied},
+		{name: "timed out", err: fmt.Errorf("%w: vcgencmd", ErrCommandTimedOut), kind: ErrorKindTransient},
+		{name: "deadline", err: context.DeadlineExceeded, kind: ErrorKindTransient},
+		// The backend's kind wins over the one of the error it wraps
+		{name: "explicit over implied", err: WithKind(ErrTransient, fs.ErrNotExist), kind: ErrorKindTransient},
+		{name: "degraded", err: fmt.Errorf("%w: iw failed 3 times in a row: %w", ErrBackendDegraded, errors.New("exit status 1")), kind: ErrorKindBackendUnavailable},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.kind, ErrorKind(tt.err))
+		})
+	}
+}
+
+func TestNewErrorKeepsMessage(t *testing.T) {
+	err := NewError(ErrBackendUnavailable, "nmcli is not available on this system")
+	assert.Equal(t, "nmcli is not available on this system", err.Error())
+	assert.ErrorIs(t, err, ErrBackendUnavailable)
+	assert.NotErrorIs(t, err, ErrHardwareAbsent)
+
+	cause := errors.New("exit status 1")
+	err = WithKind(ErrTransient, cause)
+	assert.Equal(t, "exit status 1", err.Error())
+	assert.ErrorIs(t, err, cause)
+	assert.ErrorIs(t, err, ErrTransient)
+	assert.NoError(t, WithKind(ErrTransient, nil))
+}
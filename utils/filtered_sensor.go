@@ -0,0 +1,174 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+
+	"go.viam.com/rdk/components/sensor"
+	"go.viam.com/rdk/logging"
+	"go.viam.com/rdk/resource"
+)
+
+// FilterReadings wraps a sensor's constructor so its readings are filtered by its readings_filter attribute, it's
+// simulated when its simulate attribute is set and it answers the capabilities and permissions commands, which every
+// component takes without having to declare them. The permissions its config reports it needs are checked before it's
+// constructed. The sensor is one of the ModuleSensors until it's closed.
+func FilterReadings(constructor resource.Create[sensor.Sensor]) resource.Create[sensor.Sensor] {
+	return func(ctx context.Context, deps resource.Dependencies, conf resource.Config, logger logging.Logger) (sensor.Sensor, error) {
+		filter, err := ParseReadingsFilter(conf.Attributes)
+		if err != nil {
+			return nil, err
+		}
+		sim, err := ParseSimulation(conf.Attributes)
+		if err != nil {
+			return nil, err
+		}
+		var s sensor.Sensor
+		var perms PermissionReporter
+		if sim != nil {
+			s, err = newSimulatedSensor(conf, sim, logger)
+		} else {
+			perms, _ = conf.ConvertedAttributes.(PermissionReporter)
+			missing := checkComponentPermissions(ctx, perms, conf.ResourceName().Name, logger)
+			s, err = constructor(ctx, deps, conf, logger)
+			if err != nil && len(missing) > 0 {
+				return nil, WithKind(ErrPermissionDenied, fmt.Errorf("%w (missing permissions: %s)", err, joinMissingPermissions(missing)))
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
+		fs := &filteredSensor{Sensor: s, filter: filter, perms: perms, logger: logger}
+		addModuleSensor(fs)
+		return fs, nil
+	}
+}
+
+type filteredSensor struct {
+	sensor.Sensor
+	mu     sync.RWMutex
+	filter *ReadingsFilter
+	// debounce holds back the changes of the readings the filter debounces
+	debounce readingsDebouncer
+	// perms is the config of a component that needs permissions, nil for those that don't or are simulated
+	perms  PermissionReporter
+	logger logging.Logger
+}
+
+func (s *filteredSensor) Reconfigure(ctx context.Context, deps resource.Dependencies, conf resource.Config) error {
+	filter, err := ParseReadingsFilter(conf.Attributes)
+	if err != nil {
+		return err
+	}
+	sim, err := ParseSimulation(conf.Attributes)
+	if err != nil {
+		return err
+	}
+	// Switching between the hardware and the simulation needs a new sensor
+	if _, simulated := s.Sensor.(*simulatedSensor); simulated != (sim != nil) {
+		return resource.NewMustRebuildError(conf.ResourceName())
+	}
+	var perms PermissionReporter
+	if sim == nil {
+		perms, _ = conf.ConvertedAttributes.(PermissionReporter)
+	}
+	missing := checkComponentPermissions(ctx, perms, conf.ResourceName().Name, s.logger)
+	if err := s.Sensor.Reconfigure(ctx, deps, conf); err != nil {
+		if len(missing) > 0 {
+			return WithKind(ErrPermissionDenied, fmt.Errorf("%w (missing permissions: %s)", err, joinMissingPermissions(missing)))
+		}
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	// What was held back was held back for the old settings
+	if s.filter == nil || filter == nil || !reflect.DeepEqual(s.filter.DebounceSec, filter.DebounceSec) ||
+		!reflect.DeepEqual(s.filter.Hysteresis, filter.Hysteresis) {
+		s.debounce.reset()
+	}
+	s.filter = filter
+	s.perms = perms
+	return nil
+}
+
+func (s *filteredSensor) Readings(ctx context.Context, extra map[string]interface{}) (map[string]interface{}, error) {
+	start := time.Now()
+	readings, err := s.Sensor.Readings(ctx, extra)
+	componentPolls.record(s.Name().Name, time.Since(start), err)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	readings = StampRead(readings, now)
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.filter.Apply(s.debounce.apply(s.filter, readings, now)), nil
+}
+
+func (s *filteredSensor) Close(ctx context.Context) error {
+	removeModuleSensor(s)
+	return s.Sensor.Close(ctx)
+}
+
+// DoCommand answers the capabilities, permissions and capture commands for every component, other commands are passed
+// on to the component.
+func (s *filteredSensor) DoCommand(ctx context.Context, cmd map[string]interface{}) (map[string]interface{}, error) {
+	switch command, _ := cmd["command"].(string); command {
+	case CapabilitiesCommand:
+		return s.capabilities(ctx)
+	case PermissionsCommand:
+		s.mu.RLock()
+		perms := s.perms
+		s.mu.RUnlock()
+		var missing []MissingPermission
+		if perms != nil {
+			missing = CheckPermissions(ctx, perms.Permissions(ctx))
+		}
+		return permissionsResult(missing), nil
+	case CaptureCommand:
+		return s.captureSnapshot(ctx, cmd)
+	default:
+		return s.Sensor.DoCommand(ctx, cmd)
+	}
+}
+
+// capabilities reports what the component supports along with the readings it currently returns, after the filter.
+func (s *filteredSensor) capabilities(ctx context.Context) (map[string]interface{}, error) {
+	var caps Capabilities
+	if reporter, ok := s.Sensor.(CapabilityReporter); ok {
+		caps = reporter.Capabilities(ctx)
+	}
+	readings, err := s.Sensor.Readings(ctx, nil)
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	ret := capabilitiesResult(caps, readings, err, s.filter)
+	addBackendHealth(ret, caps.Backend, CommandHealth())
+	return ret, nil
+}
+
+func (s *filteredSensor) captureSnapshot(ctx context.Context, cmd map[string]interface{}) (map[string]interface{}, error) {
+	path := DefaultSnapshotPath()
+	if raw, ok := cmd["path"]; ok {
+		name, ok := raw.(string)
+		if !ok || name == "" {
+			return nil, errors.New("path must be a non-empty string")
+		}
+		var err error
+		if path, err = SnapshotPath(name); err != nil {
+			return nil, err
+		}
+	}
+	// Take a reading first so the component's files are in the snapshot even if nothing has asked for readings yet
+	if _, err := s.Sensor.Readings(ctx, nil); err != nil {
+		return nil, fmt.Errorf("failed to get readings before capturing: %w", err)
+	}
+	files, size, err := CaptureSnapshot(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to capture snapshot: %w", err)
+	}
+	return map[string]interface{}{"path": path, "file_count": files, "size_bytes": size}, nil
+}
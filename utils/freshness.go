@@ -0,0 +1,70 @@
+package utils
+
+import (
+	"errors"
+	"time"
+)
+
+// StaleIntervals is how many update intervals readings can miss before they're stale.
+const StaleIntervals = 3
+
+// ReadingTimeKey is the reading of when the readings were collected.
+const ReadingTimeKey = "reading_time"
+
+var errNotCollected = errors.New("no readings have been collected yet")
+
+// Freshness records when a background worker last collected a sensor's readings and why its last attempt failed, so
+// readings served from memory say how old they are rather than passing off old values as current.
+type Freshness struct {
+	collected time.Time
+	err       error
+}
+
+// Succeeded records that the readings were collected at now.
+func (f *Freshness) Succeeded(now time.Time) {
+	f.collected = now
+	f.err = nil
+}
+
+// Failed records that collecting the readings failed, the readings are stale until the next success.
+func (f *Freshness) Failed(err error) {
+	f.err = err
+}
+
+// Stamp returns a copy of readings with reading_time, when they were collected, reading_age_sec and stale. The readings
+// are stale when the last attempt to collect them failed or they're older than StaleIntervals update intervals, in
+// which case stale_reason says why.
+func (f *Freshness) Stamp(readings map[string]interface{}, interval time.Duration, now time.Time) map[string]interface{} {
+	ret := make(map[string]interface{}, len(readings)+4)
+	for key, value := range readings {
+		ret[key] = value
+	}
+	err := f.err
+	if f.collected.IsZero() {
+		if err == nil {
+			err = errNotCollected
+		}
+	} else {
+		age := now.Sub(f.collected)
+		ret[ReadingTimeKey] = f.collected.Format(time.RFC3339Nano)
+		ret["reading_age_sec"] = RoundValue(age.Seconds(), 3)
+		if err == nil && age > StaleIntervals*interval {
+			err = errors.New("the readings haven't been updated for " + age.Round(time.Second).String())
+		}
+	}
+	ret["stale"] = err != nil
+	if err != nil {
+		ret["stale_reason"] = err.Error()
+	}
+	return ret
+}
+
+// StampRead stamps readings that were read when they were asked for as collected at now, so every component's readings
+// say when they were collected. Readings a component has already stamped are returned as they are.
+func StampRead(readings map[string]interface{}, now time.Time) map[string]interface{} {
+	if _, ok := readings[ReadingTimeKey]; ok {
+		return readings
+	}
+	f := Freshness{collected: now}
+	return f.Stamp(readings, 0, now)
+}
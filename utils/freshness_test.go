@@ -0,0 +1,64 @@
+package utils
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFreshness(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	readings := map[string]interface{}{"usage": 12.5}
+	f := &Freshness{}
+
+	ret := f.Stamp(readings, time.Second, now)
+	assert.Equal(t, true, ret["stale"])
+	assert.Equal(t, "no readings have been collected yet", ret["stale_reason"])
+	assert.NotContains(t, ret, "reading_time")
+
+	f.Succeeded(now)
+	ret = f.Stamp(readings, time.Second, now.Add(1500*time.Millisecond))
+	assert.Equal(t, 12.5, ret["usage"])
+	assert.Equal(t, "2024-05-01T12:00:00Z", ret["reading_time"])
+	assert.Equal(t, 1.5, ret["reading_age_sec"])
+	assert.Equal(t, false, ret["stale"])
+	assert.NotContains(t, ret, "stale_reason")
+	// The readings themselves aren't changed
+	assert.Len(t, readings, 1)
+
+	ret = f.Stamp(readings, time.Second, now.Add(10*time.Second))
+	assert.Equal(t, true, ret["stale"])
+	assert.Equal(t, "the readings haven't been updated for 10s", ret["stale_reason"])
+
+	f.Failed(errors.New("timed out"))
+	ret = f.Stamp(readings, time.Second, now.Add(time.Second))
+	assert.Equal(t, true, ret["stale"])
+	assert.Equal(t, "timed out", ret["stale_reason"])
+	assert.Equal(t, "2024-05-01T12:00:00Z", ret["reading_time"])
+
+	f.Succeeded(now.Add(2 * time.Second))
+	ret = f.Stamp(readings, time.Second, now.Add(2*time.Second))
+	assert.Equal(t, false, ret["stale"])
+}
+
+func TestStampRead(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	readings := map[string]interface{}{"usage": 12.5}
+	ret := StampRead(readings, now)
+	assert.Equal(t, map[string]interface{}{
+		"usage":           12.5,
+		"reading_time":    "2024-05-01T12:00:00Z",
+		"reading_age_sec": 0.0,
+		"stale":           false,
+	}, ret)
+	assert.Len(t, readings, 1)
+
+	// Readings collected in the background keep their own stamp
+	f := &Freshness{}
+	f.Failed(errors.New("timed out"))
+	stamped := f.Stamp(readings, time.Second, now)
+	stamped[ReadingTimeKey] = "2024-05-01T11:00:00Z"
+	assert.Equal(t, stamped, StampRead(stamped, now))
+}
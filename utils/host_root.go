@@ -0,0 +1,130 @@
+package utils
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// HostRootEnv is the environment variable that makes the module read the host's /proc, /sys, /dev and /etc from under
+// a directory, e.g. /host when it runs in a container with them mounted there.
+const HostRootEnv = "HWMONITOR_HOST_ROOT"
+
+// initPidNamespace is the pid namespace of the host, the kernel gives the namespaces it starts with fixed inode
+// numbers, see include/linux/proc_ns.h.
+const initPidNamespace = "pid:[4026531836]"
+
+// containerCgroups are in /proc/self/cgroup when the module runs in a container.
+var containerCgroups = []string{"docker", "kubepods", "containerd", "libpod", "lxc"}
+
+// HostEnvironment is where the module runs and whether what it reads is the host's.
+type HostEnvironment struct {
+	// Root is what the host's files are read from under, empty when they're read from where they are
+	Root        string
+	InContainer bool
+	// Warnings are why the readings aren't the host's
+	Warnings []string
+}
+
+var hostRoot struct {
+	mu   sync.Mutex
+	root string
+}
+
+// SetHostRoot makes the module, including gopsutil, read the host's /proc, /sys, /dev and /etc from under root. The
+// commands it runs, e.g. vcgencmd or iw, still run where the module does.
+func SetHostRoot(root string) error {
+	if !filepath.IsAbs(root) {
+		return fmt.Errorf("host root %s must be an absolute path", root)
+	}
+	for _, dir := range []string{"proc", "sys"} {
+		if info, err := os.Stat(filepath.Join(root, dir)); err != nil || !info.IsDir() {
+			return fmt.Errorf("host root %s has no %s, mount the host's /%s there, e.g. -v /%s:%s:ro", root, dir, dir, dir, filepath.Join(root, dir))
+		}
+	}
+	SetFS(RootFS{Root: root})
+	for env, dir := range gopsutilEnv {
+		if err := os.Setenv(env, filepath.Join(root, dir)); err != nil {
+			return err
+		}
+	}
+	hostRoot.mu.Lock()
+	defer hostRoot.mu.Unlock()
+	hostRoot.root = root
+	return nil
+}
+
+// SelfPid returns the module's pid in the /proc being read. It's not os.Getpid when that's the host's /proc and the
+// module runs in a container with its own pid namespace.
+func SelfPid() int32 {
+	link, err := Readlink("/proc/self")
+	if err != nil {
+		return int32(os.Getpid())
+	}
+	pid, err := strconv.ParseInt(link, 10, 32)
+	if err != nil {
+		return int32(os.Getpid())
+	}
+	return int32(pid)
+}
+
+// DetectHostEnvironment returns whether the module runs in a container and warns when it's reading the container's
+// namespaces rather than the host's.
+func DetectHostEnvironment() HostEnvironment {
+	hostRoot.mu.Lock()
+	root := hostRoot.root
+	hostRoot.mu.Unlock()
+	env := HostEnvironment{Root: root, InContainer: inContainer()}
+	// /proc/1/ns can only be read with the privileges to trace pid 1, without them there's nothing to compare
+	pidNS, _ := Readlink("/proc/1/ns/pid")
+	netNS, _ := Readlink("/proc/1/ns/net")
+	ownNetNS, _ := os.Readlink("/proc/self/ns/net")
+	env.Warnings = namespaceWarnings(root, env.InContainer, pidNS, netNS, ownNetNS)
+	return env
+}
+
+// namespaceWarnings returns why the readings aren't the host's, from the pid and network namespaces of pid 1 of the
+// /proc being read and the module's own network namespace. Those that couldn't be read are empty.
+func namespaceWarnings(root string, container bool, pidNS, netNS, ownNetNS string) []string {
+	var warnings []string
+	procHost := pidNS == initPidNamespace
+	switch {
+	case pidNS != "" && !procHost && root != "":
+		warnings = append(warnings, fmt.Sprintf("%s is a container's /proc rather than the host's, mount the host's /proc there, e.g. -v /proc:%s:ro", filepath.Join(root, "proc"), filepath.Join(root, "proc")))
+	case pidNS != "" && !procHost:
+		warnings = append(warnings, fmt.Sprintf("/proc is the container's rather than the host's, mount the host's /proc, /sys, /dev and /etc under a directory and set %s to it, or run the container in the host's pid namespace", HostRootEnv))
+	case pidNS == "" && container && root == "":
+		warnings = append(warnings, fmt.Sprintf("running in a container, the readings may be the container's rather than the host's, mount the host's /proc, /sys, /dev and /etc under a directory and set %s to it", HostRootEnv))
+	}
+	// The network readings, e.g. /proc/net and the wireless adapters, are of the namespace of the process reading them
+	if procHost && netNS != "" && ownNetNS != "" && netNS != ownNetNS {
+		warnings = append(warnings, "the module has its own network namespace, the network readings are the container's rather than the host's, run the container with the host's network")
+	}
+	return warnings
+}
+
+// inContainer reports whether the module runs in a container, from what Docker, Podman, systemd-nspawn and Kubernetes
+// leave behind. It reads the module's own files, not the host's.
+func inContainer() bool {
+	for _, marker := range []string{"/.dockerenv", "/run/.containerenv"} {
+		if _, err := os.Stat(marker); err == nil {
+			return true
+		}
+	}
+	if os.Getenv("container") != "" {
+		return true
+	}
+	cgroups, err := os.ReadFile("/proc/self/cgroup")
+	if err != nil {
+		return false
+	}
+	for _, name := range containerCgroups {
+		if strings.Contains(string(cgroups), name) {
+			return true
+		}
+	}
+	return false
+}
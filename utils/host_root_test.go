@@ -0,0 +1,62 @@
+package utils
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetHostRoot(t *testing.T) {
+	t.Cleanup(func() {
+		stopReplay()
+		hostRoot.root = ""
+	})
+	root := t.TempDir()
+	assert.ErrorContains(t, SetHostRoot("host"), "must be an absolute path")
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "proc"), 0o755))
+	assert.ErrorContains(t, SetHostRoot(root), "has no sys")
+
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "sys/class/thermal/thermal_zone0"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "sys/class/thermal/thermal_zone0/temp"), []byte("51000\n"), 0o644))
+	require.NoError(t, os.Symlink("4242", filepath.Join(root, "proc/self")))
+	require.NoError(t, SetHostRoot(root))
+	assert.Equal(t, filepath.Join(root, "proc"), os.Getenv("HOST_PROC"))
+	temp, err := ReadInt64FromFileWithContext(context.Background(), "/sys/class/thermal/thermal_zone0/temp")
+	require.NoError(t, err)
+	assert.Equal(t, int64(51000), temp)
+	// The module's pid is the one in the host's /proc
+	assert.Equal(t, int32(4242), SelfPid())
+	assert.Equal(t, root, DetectHostEnvironment().Root)
+}
+
+func TestNamespaceWarnings(t *testing.T) {
+	const containerPidNS = "pid:[4026532301]"
+	// The host's /proc, read from the host's network namespace
+	assert.Empty(t, namespaceWarnings("/host", true, initPidNamespace, "net:[4026531840]", "net:[4026531840]"))
+	assert.Empty(t, namespaceWarnings("", false, initPidNamespace, "net:[4026531840]", "net:[4026531840]"))
+
+	// host_root points at the container's own /proc
+	warnings := namespaceWarnings("/host", true, containerPidNS, "net:[4026532304]", "net:[4026532304]")
+	require.Len(t, warnings, 1)
+	assert.Contains(t, warnings[0], "/host/proc is a container's /proc")
+
+	// No host_root in a container with its own pid namespace
+	warnings = namespaceWarnings("", true, containerPidNS, "net:[4026532304]", "net:[4026532304]")
+	require.Len(t, warnings, 1)
+	assert.Contains(t, warnings[0], HostRootEnv)
+
+	// pid 1's namespaces can't be read, but it's a container without host_root
+	warnings = namespaceWarnings("", true, "", "", "net:[4026532304]")
+	require.Len(t, warnings, 1)
+	assert.Contains(t, warnings[0], "may be the container's")
+	assert.Empty(t, namespaceWarnings("/host", true, "", "", "net:[4026532304]"))
+
+	// The host's /proc, but the container's network
+	warnings = namespaceWarnings("/host", true, initPidNamespace, "net:[4026531840]", "net:[4026532304]")
+	require.Len(t, warnings, 1)
+	assert.Contains(t, warnings[0], "network namespace")
+}
@@ -0,0 +1,180 @@
+package utils
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// hostPrefixes are the directories whose files describe the board, they're what a snapshot captures and an FS other
+// than the host's serves.
+var hostPrefixes = []string{"/proc/", "/sys/", "/dev/", "/etc/"}
+
+// maxRecordedPaths bounds what's recorded for snapshots, the files of processes that come and go would otherwise grow it
+// forever.
+const maxRecordedPaths = 20000
+
+// FS is where sensors read the host's files from. The functions of this package with the same names read through it,
+// so a replay, a container's root or a test can supply the files instead of the host.
+type FS interface {
+	Open(path string) (io.ReadCloser, error)
+	ReadDir(path string) ([]os.DirEntry, error)
+	Stat(path string) (os.FileInfo, error)
+	Readlink(path string) (string, error)
+	Glob(pattern string) ([]string, error)
+}
+
+// OSFS reads the host's files, it's the default.
+type OSFS struct{}
+
+func (OSFS) Open(path string) (io.ReadCloser, error)    { return os.Open(path) }
+func (OSFS) ReadDir(path string) ([]os.DirEntry, error) { return os.ReadDir(path) }
+func (OSFS) Stat(path string) (os.FileInfo, error)      { return os.Stat(path) }
+func (OSFS) Readlink(path string) (string, error)       { return os.Readlink(path) }
+func (OSFS) Glob(pattern string) ([]string, error)      { return filepath.Glob(pattern) }
+
+// RootFS reads the files under /proc, /sys, /dev and /etc from under Root instead, other paths are read from the host.
+type RootFS struct {
+	Root string
+}
+
+func (r RootFS) path(path string) string {
+	if !isHostPath(path) {
+		return path
+	}
+	return filepath.Join(r.Root, path)
+}
+
+func (r RootFS) Open(path string) (io.ReadCloser, error)    { return os.Open(r.path(path)) }
+func (r RootFS) ReadDir(path string) ([]os.DirEntry, error) { return os.ReadDir(r.path(path)) }
+func (r RootFS) Stat(path string) (os.FileInfo, error)      { return os.Stat(r.path(path)) }
+func (r RootFS) Readlink(path string) (string, error)       { return os.Readlink(r.path(path)) }
+
+// Glob returns the matches as host paths, so they can be read through the FS again.
+func (r RootFS) Glob(pattern string) ([]string, error) {
+	matches, err := filepath.Glob(r.path(pattern))
+	if err != nil || !isHostPath(pattern) {
+		return matches, err
+	}
+	for i, match := range matches {
+		if rel, ok := strings.CutPrefix(match, filepath.Clean(r.Root)); ok {
+			matches[i] = rel
+		}
+	}
+	return matches, nil
+}
+
+var hostFS = struct {
+	mu sync.RWMutex
+	fs FS
+	// read, listed, statted and linked are the host paths that have been read, listed, checked for and whose links
+	// have been read, what a snapshot captures
+	read    map[string]struct{}
+	listed  map[string]struct{}
+	statted map[string]struct{}
+	linked  map[string]struct{}
+}{
+	fs:      OSFS{},
+	read:    make(map[string]struct{}),
+	listed:  make(map[string]struct{}),
+	statted: make(map[string]struct{}),
+	linked:  make(map[string]struct{}),
+}
+
+// SetFS sets where the host's files are read from.
+func SetFS(fs FS) {
+	hostFS.mu.Lock()
+	defer hostFS.mu.Unlock()
+	hostFS.fs = fs
+	ResetSharedReads()
+}
+
+// CurrentFS returns where the host's files are read from.
+func CurrentFS() FS {
+	hostFS.mu.RLock()
+	defer hostFS.mu.RUnlock()
+	return hostFS.fs
+}
+
+func isHostPath(path string) bool {
+	for _, prefix := range hostPrefixes {
+		if strings.HasPrefix(path, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func record(paths map[string]struct{}, path string) {
+	if !isHostPath(path) {
+		return
+	}
+	// Nearly every read is of a path that's already recorded, so they only take the read lock
+	hostFS.mu.RLock()
+	_, ok := paths[path]
+	full := len(paths) >= maxRecordedPaths
+	hostFS.mu.RUnlock()
+	if ok || full {
+		return
+	}
+	hostFS.mu.Lock()
+	defer hostFS.mu.Unlock()
+	if len(paths) < maxRecordedPaths {
+		paths[path] = struct{}{}
+	}
+}
+
+// ReadFile is os.ReadFile for host paths, the file is read through the current FS and recorded for snapshots.
+func ReadFile(path string) ([]byte, error) {
+	f, err := CurrentFS().Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	data, err := io.ReadAll(f)
+	if err == nil {
+		record(hostFS.read, path)
+	}
+	return data, err
+}
+
+// ReadDir is os.ReadDir for host paths.
+func ReadDir(path string) ([]os.DirEntry, error) {
+	entries, err := CurrentFS().ReadDir(path)
+	if err == nil {
+		record(hostFS.listed, path)
+	}
+	return entries, err
+}
+
+// Glob is filepath.Glob for host paths, the matches are recorded for snapshots.
+func Glob(pattern string) ([]string, error) {
+	matches, err := CurrentFS().Glob(pattern)
+	if err != nil {
+		return nil, err
+	}
+	for _, match := range matches {
+		record(hostFS.statted, match)
+	}
+	return matches, nil
+}
+
+// Stat is os.Stat for host paths.
+func Stat(path string) (os.FileInfo, error) {
+	info, err := CurrentFS().Stat(path)
+	if err == nil {
+		record(hostFS.statted, path)
+	}
+	return info, err
+}
+
+// Readlink is os.Readlink for host paths.
+func Readlink(path string) (string, error) {
+	target, err := CurrentFS().Readlink(path)
+	if err == nil {
+		record(hostFS.linked, path)
+	}
+	return target, err
+}
@@ -0,0 +1,15 @@
+package utils
+
+import "os"
+
+// fallbackDataDir is used when the module runs outside of viam-server, e.g. in tests or from the command line.
+const fallbackDataDir = "/var/lib/sbc-hwmonitor"
+
+// ModuleDataDir returns the directory for state that needs to survive restarts and reboots.
+// viam-server provides a per module directory in VIAM_MODULE_DATA.
+func ModuleDataDir() string {
+	if dir := os.Getenv("VIAM_MODULE_DATA"); dir != "" {
+		return dir
+	}
+	return fallbackDataDir
+}
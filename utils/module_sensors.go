@@ -0,0 +1,38 @@
+package utils
+
+import (
+	"sync"
+
+	"go.viam.com/rdk/components/sensor"
+)
+
+// moduleSensors are the components of the module that are running, every component built with FilterReadings is added
+// when it's built and removed when it's closed.
+var moduleSensors = struct {
+	mu      sync.RWMutex
+	sensors map[*filteredSensor]struct{}
+}{sensors: make(map[*filteredSensor]struct{})}
+
+func addModuleSensor(s *filteredSensor) {
+	moduleSensors.mu.Lock()
+	defer moduleSensors.mu.Unlock()
+	moduleSensors.sensors[s] = struct{}{}
+}
+
+func removeModuleSensor(s *filteredSensor) {
+	moduleSensors.mu.Lock()
+	defer moduleSensors.mu.Unlock()
+	delete(moduleSensors.sensors, s)
+}
+
+// ModuleSensors returns the components of the module that are running keyed by their names, so a component can read
+// every other one without each of them being a dependency. Their readings are filtered as they are for other callers.
+func ModuleSensors() map[string]sensor.Sensor {
+	moduleSensors.mu.RLock()
+	defer moduleSensors.mu.RUnlock()
+	ret := make(map[string]sensor.Sensor, len(moduleSensors.sensors))
+	for s := range moduleSensors.sensors {
+		ret[s.Name().Name] = s
+	}
+	return ret
+}
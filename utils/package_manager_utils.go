@@ -1,17 +1,15 @@
 package utils
 
 import (
-	"errors"
-	"os/exec"
+	"context"
 	"strings"
 )
 
-var ErrNoPackageManagerFound = errors.New("no package manager found")
+var ErrNoPackageManagerFound = NewError(ErrBackendUnavailable, "no package manager found")
 
 func InstallPackage(packageName string) error {
 	if isAptInstalled() {
-		proc := exec.Command("apt", "install", packageName, "-y")
-		_, err := proc.Output()
+		_, err := Output(context.Background(), "apt", "install", packageName, "-y")
 		if err != nil {
 			return err
 		}
@@ -19,8 +17,7 @@ func InstallPackage(packageName string) error {
 	}
 
 	if isYumInstalled() {
-		proc := exec.Command("yum", "install", packageName, "-y")
-		_, err := proc.Output()
+		_, err := Output(context.Background(), "yum", "install", packageName, "-y")
 		if err != nil {
 			return err
 		}
@@ -31,8 +28,7 @@ func InstallPackage(packageName string) error {
 }
 
 func isAptInstalled() bool {
-	proc := exec.Command("apt", "-v")
-	outputBytes, err := proc.Output()
+	outputBytes, err := Output(context.Background(), "apt", "-v")
 	if err != nil {
 		return false
 	}
@@ -44,8 +40,7 @@ func isAptInstalled() bool {
 }
 
 func isYumInstalled() bool {
-	proc := exec.Command("yum", "-v")
-	outputBytes, err := proc.Output()
+	outputBytes, err := Output(context.Background(), "yum", "-v")
 	if err != nil {
 		return false
 	}
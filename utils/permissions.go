@@ -0,0 +1,230 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/user"
+	"slices"
+	"strconv"
+	"strings"
+
+	"go.viam.com/rdk/logging"
+)
+
+// PermissionsCommand is the DoCommand every component takes to check the module has the permissions it needs.
+const PermissionsCommand = "check_permissions"
+
+// Permissions are what a component needs from the host beyond what every user has.
+type Permissions struct {
+	// Groups are the groups that own the devices it opens, e.g. i2c, gpio or video
+	Groups []string
+	// Capabilities are the Linux capabilities it needs, e.g. CAP_SYSLOG
+	Capabilities []string
+	// Readable and Writable are the files it opens, they can be patterns, e.g. /dev/gpiochip*
+	Readable []string
+	Writable []string
+}
+
+// PermissionReporter is implemented by the configs of components that need more than what every user has, so what's
+// missing can be reported when the component starts rather than as a failure at every poll.
+type PermissionReporter interface {
+	Permissions(ctx context.Context) Permissions
+}
+
+// MissingPermission is a permission the module hasn't got and how to grant it.
+type MissingPermission struct {
+	// Kind is group, capability, readable or writable
+	Kind string
+	// Name is the group, capability or file
+	Name string
+	Fix  string
+}
+
+func (m MissingPermission) String() string {
+	return fmt.Sprintf("%s %s, %s", m.Kind, m.Name, m.Fix)
+}
+
+// capabilityBits are the bits of the capabilities components need in the capability sets of /proc/self/status, see
+// include/uapi/linux/capability.h.
+var capabilityBits = map[string]uint{
+	"CAP_DAC_READ_SEARCH": 2,
+	"CAP_NET_ADMIN":       12,
+	"CAP_NET_RAW":         13,
+	"CAP_SYS_RAWIO":       17,
+	"CAP_SYS_ADMIN":       21,
+	"CAP_SYS_NICE":        23,
+	"CAP_SYSLOG":          34,
+	"CAP_PERFMON":         38,
+	"CAP_BPF":             39,
+}
+
+// permissionChecker checks permissions against the process's credentials, tests replace them.
+type permissionChecker struct {
+	euid int
+	// groups returns the groups the process is in
+	groups func() ([]int, error)
+	// lookupGroup returns the id of the group named name
+	lookupGroup func(name string) (int, error)
+	// access returns an error if the process can't open path for reading, or writing when write is set
+	access func(path string, write bool) error
+}
+
+func defaultPermissionChecker() *permissionChecker {
+	return &permissionChecker{
+		euid: os.Geteuid(),
+		groups: func() ([]int, error) {
+			groups, err := os.Getgroups()
+			return append(groups, os.Getegid()), err
+		},
+		lookupGroup: func(name string) (int, error) {
+			group, err := user.LookupGroup(name)
+			if err != nil {
+				return 0, err
+			}
+			return strconv.Atoi(group.Gid)
+		},
+		access: accessPath,
+	}
+}
+
+// CheckPermissions returns what of perms the module hasn't got.
+func CheckPermissions(ctx context.Context, perms Permissions) []MissingPermission {
+	return defaultPermissionChecker().check(ctx, perms)
+}
+
+func (c *permissionChecker) check(ctx context.Context, perms Permissions) []MissingPermission {
+	var missing []MissingPermission
+	missing = append(missing, c.checkGroups(perms.Groups)...)
+	missing = append(missing, c.checkCapabilities(ctx, perms.Capabilities)...)
+	missing = append(missing, c.checkFiles(perms.Readable, false)...)
+	missing = append(missing, c.checkFiles(perms.Writable, true)...)
+	return missing
+}
+
+// checkGroups returns the groups the process isn't in. Root doesn't need them, and groups the host doesn't have are
+// skipped, the devices are then owned by another group, which checkFiles reports.
+func (c *permissionChecker) checkGroups(names []string) []MissingPermission {
+	if c.euid == 0 || len(names) == 0 {
+		return nil
+	}
+	groups, err := c.groups()
+	if err != nil {
+		return nil
+	}
+	var missing []MissingPermission
+	for _, name := range names {
+		gid, err := c.lookupGroup(name)
+		if err != nil || slices.Contains(groups, gid) {
+			continue
+		}
+		missing = append(missing, MissingPermission{
+			Kind: "group",
+			Name: name,
+			Fix:  fmt.Sprintf("add the user viam-server runs as to the %s group, e.g. sudo usermod -aG %s <user>, and restart viam-server", name, name),
+		})
+	}
+	return missing
+}
+
+// checkCapabilities returns the capabilities the process hasn't got in its effective set, which root has too unless
+// they were dropped, e.g. in a container.
+func (c *permissionChecker) checkCapabilities(ctx context.Context, names []string) []MissingPermission {
+	if len(names) == 0 {
+		return nil
+	}
+	effective, err := effectiveCapabilities(ctx)
+	if err != nil {
+		return nil
+	}
+	var missing []MissingPermission
+	for _, name := range names {
+		bit, ok := capabilityBits[name]
+		if ok && effective&(1<<bit) != 0 {
+			continue
+		}
+		missing = append(missing, MissingPermission{
+			Kind: "capability",
+			Name: name,
+			Fix:  fmt.Sprintf("run viam-server as root or grant it %s, e.g. AmbientCapabilities=%s in its systemd unit, or --cap-add %s for a container", name, name, strings.TrimPrefix(name, "CAP_")),
+		})
+	}
+	return missing
+}
+
+// checkFiles returns the files matching patterns the process can't open, each once. Patterns that match nothing are
+// skipped, the hardware isn't there to be opened.
+func (c *permissionChecker) checkFiles(patterns []string, write bool) []MissingPermission {
+	kind, access := "readable", "read"
+	if write {
+		kind, access = "writable", "write"
+	}
+	var missing []MissingPermission
+	seen := make(map[string]bool)
+	for _, pattern := range patterns {
+		paths, err := Glob(pattern)
+		if err != nil {
+			continue
+		}
+		for _, path := range paths {
+			if seen[path] {
+				continue
+			}
+			seen[path] = true
+			if err := c.access(path, write); err == nil {
+				continue
+			}
+			missing = append(missing, MissingPermission{
+				Kind: kind,
+				Name: path,
+				Fix:  fmt.Sprintf("run viam-server as root or give the user it runs as %s access to %s", access, path),
+			})
+		}
+	}
+	return missing
+}
+
+// effectiveCapabilities returns the process's effective capability set from /proc/self/status.
+func effectiveCapabilities(ctx context.Context) (uint64, error) {
+	status, err := ReadFileWithContext(ctx, "/proc/self/status")
+	if err != nil {
+		return 0, err
+	}
+	for _, line := range strings.Split(status, "\n") {
+		if value, ok := strings.CutPrefix(line, "CapEff:"); ok {
+			return strconv.ParseUint(strings.TrimSpace(value), 16, 64)
+		}
+	}
+	return 0, fmt.Errorf("no CapEff in /proc/self/status")
+}
+
+// permissionsResult builds the response to the permissions command.
+func permissionsResult(missing []MissingPermission) map[string]interface{} {
+	ret := make([]interface{}, 0, len(missing))
+	for _, m := range missing {
+		ret = append(ret, map[string]interface{}{"kind": m.Kind, "name": m.Name, "fix": m.Fix})
+	}
+	return map[string]interface{}{"ok": len(missing) == 0, "missing": ret}
+}
+
+// checkComponentPermissions checks the permissions of the component named name, whose config is perms, and logs each
+// that's missing. It does nothing when perms is nil.
+func checkComponentPermissions(ctx context.Context, perms PermissionReporter, name string, logger logging.Logger) []MissingPermission {
+	if perms == nil {
+		return nil
+	}
+	missing := CheckPermissions(ctx, perms.Permissions(ctx))
+	for _, m := range missing {
+		logger.Warnf("%s is missing a permission, its readings will fail until it's granted: %s", name, m)
+	}
+	return missing
+}
+
+// joinMissingPermissions lists the missing permissions for an error.
+func joinMissingPermissions(missing []MissingPermission) string {
+	names := make([]string, 0, len(missing))
+	for _, m := range missing {
+		names = append(names, m.String())
+	}
+	return strings.Join(names, "; ")
+}
@@ -0,0 +1,18 @@
+package utils
+
+import "syscall"
+
+const (
+	accessRead  = 4 // R_OK
+	accessWrite = 2 // W_OK
+)
+
+// accessPath checks the process can open path without opening it, opening some devices has side effects, e.g. a
+// watchdog is armed when it's opened.
+func accessPath(path string, write bool) error {
+	mode := uint32(accessRead)
+	if write {
+		mode = accessWrite
+	}
+	return syscall.Access(path, mode)
+}
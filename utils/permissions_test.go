@@ -0,0 +1,74 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckPermissions(t *testing.T) {
+	t.Cleanup(resetHostFS)
+	root := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "proc/self"), 0o755))
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "dev"), 0o755))
+	// CAP_NET_ADMIN and CAP_NET_RAW but not CAP_SYSLOG
+	require.NoError(t, os.WriteFile(filepath.Join(root, "proc/self/status"), []byte("Name:\tviam-server\nCapEff:\t0000000000003000\n"), 0o644))
+	for _, name := range []string{"gpiochip0", "gpiochip1", "i2c-1"} {
+		require.NoError(t, os.WriteFile(filepath.Join(root, "dev", name), nil, 0o644))
+	}
+	SetFS(RootFS{Root: root})
+
+	checker := &permissionChecker{
+		euid:   1000,
+		groups: func() ([]int, error) { return []int{1000, 44}, nil },
+		lookupGroup: func(name string) (int, error) {
+			gids := map[string]int{"video": 44, "gpio": 997, "i2c": 998}
+			gid, ok := gids[name]
+			if !ok {
+				return 0, errors.New("unknown group")
+			}
+			return gid, nil
+		},
+		access: func(path string, write bool) error {
+			if path == "/dev/gpiochip1" || (path == "/dev/i2c-1" && write) {
+				return syscall.EACCES
+			}
+			return nil
+		},
+	}
+	missing := checker.check(context.Background(), Permissions{
+		Groups:       []string{"video", "gpio", "spi"},
+		Capabilities: []string{"CAP_NET_ADMIN", "CAP_SYSLOG"},
+		Readable:     []string{"/dev/gpiochip*", "/dev/gpiochip1", "/dev/i2c-1", "/dev/i2c-2"},
+		Writable:     []string{"/dev/i2c-1"},
+	})
+	var got []string
+	for _, m := range missing {
+		got = append(got, m.Kind+" "+m.Name)
+	}
+	// The spi group and /dev/i2c-2 aren't on the host, /dev/gpiochip1 is only reported once
+	assert.Equal(t, []string{"group gpio", "capability CAP_SYSLOG", "readable /dev/gpiochip1", "writable /dev/i2c-1"}, got)
+	assert.Contains(t, missing[0].Fix, "usermod -aG gpio")
+	assert.Contains(t, missing[1].Fix, "AmbientCapabilities=CAP_SYSLOG")
+
+	// Root is in every group it needs, but not past the capabilities it's been given
+	checker.euid = 0
+	missing = checker.check(context.Background(), Permissions{Groups: []string{"gpio"}, Capabilities: []string{"CAP_SYSLOG"}})
+	require.Len(t, missing, 1)
+	assert.Equal(t, "CAP_SYSLOG", missing[0].Name)
+}
+
+func TestPermissionsResult(t *testing.T) {
+	assert.Equal(t, map[string]interface{}{"ok": true, "missing": []interface{}{}}, permissionsResult(nil))
+
+	ret := permissionsResult([]MissingPermission{{Kind: "group", Name: "i2c", Fix: "add the user"}})
+	assert.Equal(t, false, ret["ok"])
+	assert.Equal(t, []interface{}{map[string]interface{}{"kind": "group", "name": "i2c", "fix": "add the user"}}, ret["missing"])
+	assert.Equal(t, "group i2c, add the user", joinMissingPermissions([]MissingPermission{{Kind: "group", Name: "i2c", Fix: "add the user"}}))
+}
@@ -0,0 +1,5 @@
+package utils
+
+func accessPath(path string, write bool) error {
+	return nil
+}
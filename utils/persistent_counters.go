@@ -0,0 +1,199 @@
+package utils
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"go.viam.com/rdk/logging"
+)
+
+// minCounterSaveInterval limits how often the counters are written, so a device that keeps dropping off the bus
+// doesn't wear out the SD card. Counts since the last save are written by the next change after the interval, or by
+// Flush.
+const minCounterSaveInterval = 5 * time.Second
+
+// PersistentCounters are counters that carry on across module restarts and reboots, so a restart doesn't cut long term
+// trends short. They're saved to a JSON file, see CountersPath.
+type PersistentCounters struct {
+	mu       sync.Mutex
+	path     string
+	state    counterState
+	dirty    bool
+	lastSave time.Time
+}
+
+type counterState struct {
+	Since  time.Time        `json:"since"`
+	BootID string           `json:"boot_id"`
+	Counts map[string]int64 `json:"counts"`
+	// BootValues are the last values seen of what only lasts a boot, e.g. the kernel's counters, they're dropped when
+	// the board has rebooted
+	BootValues map[string]int64 `json:"boot_values"`
+}
+
+// CountersPath returns where a component's counters are saved, in the module's data directory.
+func CountersPath(component string) string {
+	return filepath.Join(ModuleDataDir(), "counters", component+".json")
+}
+
+// LoadPersistentCounters loads the counters saved to path during the boot bootID or an earlier one. When the file
+// can't be read the counters start over and the error is returned to be logged, a corrupt file shouldn't keep the
+// component from starting.
+func LoadPersistentCounters(path, bootID string) (*PersistentCounters, error) {
+	c := &PersistentCounters{path: path}
+	err := c.load()
+	if err != nil || c.state.Since.IsZero() {
+		c.state = counterState{Since: time.Now()}
+	}
+	if c.state.Counts == nil {
+		c.state.Counts = make(map[string]int64)
+	}
+	if c.state.BootID != bootID || c.state.BootValues == nil {
+		c.state.BootID = bootID
+		c.state.BootValues = make(map[string]int64)
+	}
+	return c, err
+}
+
+// ReloadCounters returns the counters of the component named name, counters itself when they're already the
+// component's, e.g. when it's reconfigured without being renamed. Counters of an earlier name are saved first.
+func ReloadCounters(counters *PersistentCounters, name, bootID string, logger logging.Logger) *PersistentCounters {
+	path := CountersPath(name)
+	if counters != nil {
+		if counters.path == path {
+			return counters
+		}
+		if err := counters.Flush(); err != nil {
+			logger.Warnf("Failed to save the counters to %s: %v", counters.path, err)
+		}
+	}
+	counters, err := LoadPersistentCounters(path, bootID)
+	if err != nil {
+		logger.Warnf("Failed to load the counters from %s, starting over: %v", path, err)
+	}
+	return counters
+}
+
+func (c *PersistentCounters) load() error {
+	data, err := os.ReadFile(c.path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+	return json.Unmarshal(data, &c.state)
+}
+
+// Since returns when the counters started counting.
+func (c *PersistentCounters) Since() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.state.Since
+}
+
+// Get returns the count of key.
+func (c *PersistentCounters) Get(key string) int64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.state.Counts[key]
+}
+
+// Add adds delta to key and returns its count.
+func (c *PersistentCounters) Add(key string, delta int64) int64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.state.Counts[key] += delta
+	c.changed()
+	return c.state.Counts[key]
+}
+
+// AddSinceBoot counts a counter that starts over at every boot, e.g. oom_kill in /proc/vmstat, from its current
+// value and returns its count across boots. A value lower than the last one, e.g. a cgroup that was recreated, counts
+// as having started over.
+func (c *PersistentCounters) AddSinceBoot(key string, value int64) int64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	stored, ok := c.state.BootValues[key]
+	last := stored
+	if value < last {
+		last = 0
+	}
+	if !ok || value != stored {
+		c.state.Counts[key] += value - last
+		c.state.BootValues[key] = value
+		c.changed()
+	}
+	return c.state.Counts[key]
+}
+
+// BootValue returns the value set for key during this boot, if one was.
+func (c *PersistentCounters) BootValue(key string) (int64, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	value, ok := c.state.BootValues[key]
+	return value, ok
+}
+
+// SetBootValue sets a value that lasts until the board reboots, e.g. whether the board was undervolted when it was
+// last read, so a module restart doesn't count it twice.
+func (c *PersistentCounters) SetBootValue(key string, value int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if last, ok := c.state.BootValues[key]; ok && last == value {
+		return
+	}
+	c.state.BootValues[key] = value
+	c.changed()
+}
+
+// Path returns the file the counters are saved to.
+func (c *PersistentCounters) Path() string {
+	return c.path
+}
+
+// Flush saves the counters if they've changed since they were last saved.
+func (c *PersistentCounters) Flush() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.dirty {
+		return nil
+	}
+	return c.save()
+}
+
+// changed saves the counters unless they were saved less than minCounterSaveInterval ago. The lock must be held.
+func (c *PersistentCounters) changed() {
+	c.dirty = true
+	if time.Since(c.lastSave) < minCounterSaveInterval {
+		return
+	}
+	// A failed save is retried by the next change or Flush, which returns the error
+	_ = c.save()
+}
+
+// save writes the counters, the lock must be held.
+func (c *PersistentCounters) save() error {
+	c.lastSave = time.Now()
+	if err := os.MkdirAll(filepath.Dir(c.path), 0o755); err != nil {
+		return err
+	}
+	data, err := json.Marshal(c.state)
+	if err != nil {
+		return err
+	}
+	// Write and rename so a power cut while saving can't leave a truncated file behind
+	tmp := c.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, c.path); err != nil {
+		return err
+	}
+	c.dirty = false
+	return nil
+}
@@ -0,0 +1,49 @@
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPersistentCounters(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "counters", "usb.json")
+	counters, err := LoadPersistentCounters(path, "boot-1")
+	require.NoError(t, err)
+	since := counters.Since()
+	assert.Equal(t, int64(1), counters.Add("disconnects", 1))
+	assert.Equal(t, int64(2), counters.Add("disconnects", 1))
+	assert.Equal(t, int64(3), counters.AddSinceBoot("oom_kill", 3))
+	counters.SetBootValue("undervolt", 1)
+	require.NoError(t, counters.Flush())
+
+	// A module restart within the same boot carries on without counting the kernel's counter twice
+	counters, err = LoadPersistentCounters(path, "boot-1")
+	require.NoError(t, err)
+	assert.Equal(t, since.Unix(), counters.Since().Unix())
+	assert.Equal(t, int64(2), counters.Get("disconnects"))
+	assert.Equal(t, int64(4), counters.AddSinceBoot("oom_kill", 4))
+	value, ok := counters.BootValue("undervolt")
+	assert.True(t, ok)
+	assert.Equal(t, int64(1), value)
+	require.NoError(t, counters.Flush())
+
+	// After a reboot the kernel's counter starts over and is added to the earlier boots
+	counters, err = LoadPersistentCounters(path, "boot-2")
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), counters.Get("disconnects"))
+	assert.Equal(t, int64(5), counters.AddSinceBoot("oom_kill", 1))
+	_, ok = counters.BootValue("undervolt")
+	assert.False(t, ok)
+
+	// A corrupt file starts over
+	require.NoError(t, os.WriteFile(path, []byte("{"), 0o644))
+	counters, err = LoadPersistentCounters(path, "boot-2")
+	assert.Error(t, err)
+	require.NotNil(t, counters)
+	assert.Equal(t, int64(0), counters.Get("disconnects"))
+	assert.Equal(t, int64(1), counters.Add("disconnects", 1))
+}
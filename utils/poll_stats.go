@@ -0,0 +1,82 @@
+package utils
+
+import (
+	"maps"
+	"sync"
+	"time"
+)
+
+// PollStats are how often something the module polls was polled, how many of the polls failed and how long they took,
+// since the module started.
+type PollStats struct {
+	Count  uint64
+	Errors uint64
+	Total  time.Duration
+	Max    time.Duration
+	Last   time.Duration
+}
+
+// ErrorPercent is the percentage of the polls that failed.
+func (s PollStats) ErrorPercent() float64 {
+	if s.Count == 0 {
+		return 0
+	}
+	return float64(s.Errors) / float64(s.Count) * 100
+}
+
+// Average is how long a poll took on average.
+func (s PollStats) Average() time.Duration {
+	if s.Count == 0 {
+		return 0
+	}
+	return s.Total / time.Duration(s.Count)
+}
+
+// pollRecorder keeps the PollStats of each name it's recorded polls for.
+type pollRecorder struct {
+	mu    sync.Mutex
+	stats map[string]PollStats
+}
+
+func newPollRecorder() *pollRecorder {
+	return &pollRecorder{stats: make(map[string]PollStats)}
+}
+
+func (r *pollRecorder) record(name string, duration time.Duration, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stats := r.stats[name]
+	stats.Count++
+	if err != nil {
+		stats.Errors++
+	}
+	stats.Total += duration
+	stats.Max = max(stats.Max, duration)
+	stats.Last = duration
+	r.stats[name] = stats
+}
+
+func (r *pollRecorder) snapshot() map[string]PollStats {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return maps.Clone(r.stats)
+}
+
+var (
+	// componentPolls are the readings of every component, keyed by its name
+	componentPolls = newPollRecorder()
+	// commandRuns are the commands the module runs, keyed by the command
+	commandRuns = newPollRecorder()
+)
+
+// ComponentPollStats returns the stats of the readings of every component that's been read, keyed by its name.
+// Components that collect their readings in the background return them without waiting for the hardware, the time
+// they take collecting them is in the stats of the commands they run.
+func ComponentPollStats() map[string]PollStats {
+	return componentPolls.snapshot()
+}
+
+// CommandPollStats returns the stats of every command the module has run, keyed by the command.
+func CommandPollStats() map[string]PollStats {
+	return commandRuns.snapshot()
+}
@@ -0,0 +1,27 @@
+package utils
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPollRecorder(t *testing.T) {
+	r := newPollRecorder()
+	r.record("vcgencmd", 30*time.Millisecond, nil)
+	r.record("vcgencmd", 10*time.Millisecond, errors.New("failed"))
+	r.record("iw", time.Millisecond, nil)
+
+	stats := r.snapshot()
+	assert.Equal(t, PollStats{Count: 2, Errors: 1, Total: 40 * time.Millisecond, Max: 30 * time.Millisecond, Last: 10 * time.Millisecond}, stats["vcgencmd"])
+	assert.Equal(t, 50.0, stats["vcgencmd"].ErrorPercent())
+	assert.Equal(t, 20*time.Millisecond, stats["vcgencmd"].Average())
+	assert.Equal(t, uint64(1), stats["iw"].Count)
+
+	// The snapshot isn't changed by later polls
+	r.record("iw", time.Millisecond, nil)
+	assert.Equal(t, uint64(1), stats["iw"].Count)
+	assert.Zero(t, PollStats{}.ErrorPercent())
+}
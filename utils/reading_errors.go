@@ -0,0 +1,53 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// ReadingErrorsKey is the reading a component reports the metrics it failed to read in, keyed by the metric, when it
+// still has others to report.
+const ReadingErrorsKey = "reading_errors"
+
+// ReadingErrorKindsKey is the reading a component reports the kind of each of the errors in ReadingErrorsKey in, see
+// ErrorKind.
+const ReadingErrorKindsKey = "reading_error_kinds"
+
+// ReadingErrors collects the errors of the metrics a component failed to read, so it returns the readings it did read
+// rather than failing the whole reading when e.g. one disk or one power sensor fails.
+type ReadingErrors map[string]error
+
+// Add records that metric failed to be read.
+func (e ReadingErrors) Add(metric string, err error) {
+	e[metric] = err
+}
+
+// Readings returns readings with the errors under ReadingErrorsKey and their kinds under ReadingErrorKindsKey. It only
+// fails when nothing was read, with every error joined.
+func (e ReadingErrors) Readings(readings map[string]interface{}) (map[string]interface{}, error) {
+	if len(e) == 0 {
+		return readings, nil
+	}
+	metrics := make([]string, 0, len(e))
+	for metric := range e {
+		metrics = append(metrics, metric)
+	}
+	sort.Strings(metrics)
+	if len(readings) == 0 {
+		errs := make([]error, 0, len(metrics))
+		for _, metric := range metrics {
+			errs = append(errs, fmt.Errorf("%s: %w", metric, e[metric]))
+		}
+		return nil, errors.Join(errs...)
+	}
+	reasons := make(map[string]interface{}, len(e))
+	kinds := make(map[string]interface{}, len(e))
+	for _, metric := range metrics {
+		reasons[metric] = e[metric].Error()
+		kinds[metric] = ErrorKind(e[metric])
+	}
+	readings[ReadingErrorsKey] = reasons
+	readings[ReadingErrorKindsKey] = kinds
+	return readings, nil
+}
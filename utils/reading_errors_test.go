@@ -0,0 +1,31 @@
+package utils
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadingErrors(t *testing.T) {
+	errs := make(ReadingErrors)
+	readings, err := errs.Readings(map[string]interface{}{"mmcblk0_used": 1024})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"mmcblk0_used": 1024}, readings)
+
+	// The readings that were read are returned with why the others weren't
+	errs.Add("sda", errors.New("no such device"))
+	readings, err = errs.Readings(map[string]interface{}{"mmcblk0_used": 1024})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"mmcblk0_used":       1024,
+		ReadingErrorsKey:     map[string]interface{}{"sda": "no such device"},
+		ReadingErrorKindsKey: map[string]interface{}{"sda": ErrorKindUnknown},
+	}, readings)
+
+	// Nothing read fails with every error
+	errs.Add("mmcblk0", errors.New("permission denied"))
+	_, err = errs.Readings(map[string]interface{}{})
+	assert.EqualError(t, err, "mmcblk0: permission denied\nsda: no such device")
+}
@@ -0,0 +1,53 @@
+package utils
+
+// ToFloat64 converts a numeric reading to a float64, ok is false for anything that isn't a number.
+func ToFloat64(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case float32:
+		return float64(v), true
+	case int:
+		return float64(v), true
+	case int32:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case uint:
+		return float64(v), true
+	case uint32:
+		return float64(v), true
+	case uint64:
+		return float64(v), true
+	default:
+		return 0, false
+	}
+}
+
+// NumericReadings returns the readings that can be exported as metrics. Bools become 1 or 0, nested maps are
+// flattened with their keys joined by underscores, and strings and anything else are dropped.
+func NumericReadings(readings map[string]interface{}) map[string]float64 {
+	ret := make(map[string]float64)
+	addNumericReadings(ret, "", readings)
+	return ret
+}
+
+func addNumericReadings(ret map[string]float64, prefix string, readings map[string]interface{}) {
+	for key, value := range readings {
+		key = prefix + key
+		switch v := value.(type) {
+		case bool:
+			if v {
+				ret[key] = 1
+			} else {
+				ret[key] = 0
+			}
+		case map[string]interface{}:
+			addNumericReadings(ret, key+"_", v)
+		default:
+			if number, ok := ToFloat64(v); ok {
+				ret[key] = number
+			}
+		}
+	}
+}
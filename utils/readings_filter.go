@@ -0,0 +1,135 @@
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// ReadingsFilterAttribute is the attribute every component takes to filter and rename its readings.
+const ReadingsFilterAttribute = "readings_filter"
+
+// ReadingsFilter selects which readings a component returns and what they're called, so readings nobody looks at
+// don't have to be captured. Only the top level keys are filtered, the keys of nested readings are left as they are.
+type ReadingsFilter struct {
+	Include []string          `json:"include,omitempty"` // Patterns of the keys to return, all of them when empty
+	Exclude []string          `json:"exclude,omitempty"` // Patterns of the keys to drop, applied after include
+	Rename  map[string]string `json:"rename,omitempty"`  // Keys to return under another name
+	Prefix  string            `json:"prefix,omitempty"`  // Prepended to every key, after renaming
+	// Patterns of the keys whose changes are only returned once they've held for that many seconds
+	DebounceSec map[string]float64 `json:"debounce_sec,omitempty"`
+	// Patterns of the numeric keys whose changes are only returned once they're at least that large
+	Hysteresis map[string]float64 `json:"hysteresis,omitempty"`
+}
+
+// ParseReadingsFilter parses the readings_filter attribute, it returns nil when there isn't one.
+func ParseReadingsFilter(attributes map[string]interface{}) (*ReadingsFilter, error) {
+	raw, ok := attributes[ReadingsFilterAttribute]
+	if !ok || raw == nil {
+		return nil, nil
+	}
+	// The attribute has already been decoded from JSON, round trip it to get the struct
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", ReadingsFilterAttribute, err)
+	}
+	var filter ReadingsFilter
+	if err := json.Unmarshal(data, &filter); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", ReadingsFilterAttribute, err)
+	}
+	if err := filter.Validate(); err != nil {
+		return nil, err
+	}
+	return &filter, nil
+}
+
+// Validate checks that the patterns and new names aren't empty and that no two keys are renamed to the same name.
+func (f *ReadingsFilter) Validate() error {
+	for _, pattern := range append(append([]string{}, f.Include...), f.Exclude...) {
+		if pattern == "" {
+			return fmt.Errorf("%s patterns can't be empty", ReadingsFilterAttribute)
+		}
+	}
+	for pattern, sec := range f.DebounceSec {
+		if pattern == "" || sec <= 0 {
+			return fmt.Errorf("%s.debounce_sec needs patterns and a positive number of seconds", ReadingsFilterAttribute)
+		}
+	}
+	for pattern, band := range f.Hysteresis {
+		if pattern == "" || band <= 0 {
+			return fmt.Errorf("%s.hysteresis needs patterns and a positive change", ReadingsFilterAttribute)
+		}
+	}
+	renamed := make(map[string]string, len(f.Rename))
+	for key, name := range f.Rename {
+		if key == "" || name == "" {
+			return fmt.Errorf("%s.rename can't have empty keys or names", ReadingsFilterAttribute)
+		}
+		if other, ok := renamed[name]; ok {
+			return fmt.Errorf("%s.rename renames both %s and %s to %s", ReadingsFilterAttribute, min(key, other), max(key, other), name)
+		}
+		renamed[name] = key
+	}
+	return nil
+}
+
+// Apply returns the filtered and renamed readings. Keys are matched before they're renamed.
+func (f *ReadingsFilter) Apply(readings map[string]interface{}) map[string]interface{} {
+	if f == nil || readings == nil {
+		return readings
+	}
+	ret := make(map[string]interface{}, len(readings))
+	for key, value := range readings {
+		// The errors explain the readings that are missing, they're kept whatever the filter selects
+		if key == ReadingErrorsKey || key == ReadingErrorKindsKey {
+			ret[key] = value
+			continue
+		}
+		if !f.included(key) {
+			continue
+		}
+		if name, ok := f.Rename[key]; ok {
+			key = name
+		}
+		ret[f.Prefix+key] = value
+	}
+	return ret
+}
+
+func (f *ReadingsFilter) included(key string) bool {
+	if len(f.Include) > 0 && !matchAny(f.Include, key) {
+		return false
+	}
+	return !matchAny(f.Exclude, key)
+}
+
+func matchAny(patterns []string, key string) bool {
+	for _, pattern := range patterns {
+		if MatchPattern(pattern, key) {
+			return true
+		}
+	}
+	return false
+}
+
+// MatchPattern reports whether key matches pattern, where * matches any run of characters, including none. Unlike
+// path.Match it also matches /, which is in keys such as the device paths of the disk readings.
+func MatchPattern(pattern, key string) bool {
+	parts := strings.Split(pattern, "*")
+	if len(parts) == 1 {
+		return pattern == key
+	}
+	if !strings.HasPrefix(key, parts[0]) {
+		return false
+	}
+	key = key[len(parts[0]):]
+	last := parts[len(parts)-1]
+	for _, part := range parts[1 : len(parts)-1] {
+		i := strings.Index(key, part)
+		if i < 0 {
+			return false
+		}
+		key = key[i+len(part):]
+	}
+	return len(key) >= len(last) && strings.HasSuffix(key, last)
+}
@@ -0,0 +1,93 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatchPattern(t *testing.T) {
+	for _, tc := range []struct {
+		pattern, key string
+		match        bool
+	}{
+		{"CPU", "CPU", true},
+		{"CPU", "GPU", false},
+		{"*", "anything", true},
+		{"cpu_*", "cpu_big0", true},
+		{"cpu_*", "gpu", false},
+		{"*_percent", "/dev/mmcblk0p2_used_percent", true},
+		{"swap_*_used", "swap_device_zram0_used", true},
+		{"swap_*_used", "swap_device_zram0_used_percent", false},
+		{"a*a", "a", false},
+		{"a*a", "aa", true},
+	} {
+		assert.Equal(t, tc.match, MatchPattern(tc.pattern, tc.key), "%s %s", tc.pattern, tc.key)
+	}
+}
+
+func TestReadingsFilterApply(t *testing.T) {
+	readings := map[string]interface{}{
+		"cpu_big0":      1800000000,
+		"cpu_big0_pvtm": 1650,
+		"cpu_little":    1200000000,
+		"gpu":           800000000,
+	}
+	f := &ReadingsFilter{
+		Include: []string{"cpu_*"},
+		Exclude: []string{"*_pvtm"},
+		Rename:  map[string]string{"cpu_little": "cpu_efficiency"},
+		Prefix:  "nav_",
+	}
+	assert.Equal(t, map[string]interface{}{
+		"nav_cpu_big0":       1800000000,
+		"nav_cpu_efficiency": 1200000000,
+	}, f.Apply(readings))
+	// The readings themselves aren't changed
+	assert.Len(t, readings, 4)
+
+	var none *ReadingsFilter
+	assert.Equal(t, readings, none.Apply(readings))
+
+	// The reading errors are kept whatever is included and aren't prefixed
+	readings[ReadingErrorsKey] = map[string]interface{}{"gpu": "not found"}
+	assert.Equal(t, map[string]interface{}{"gpu": "not found"}, f.Apply(readings)[ReadingErrorsKey])
+	readings[ReadingErrorKindsKey] = map[string]interface{}{"gpu": ErrorKindHardwareAbsent}
+	assert.Equal(t, map[string]interface{}{"gpu": ErrorKindHardwareAbsent}, f.Apply(readings)[ReadingErrorKindsKey])
+}
+
+func TestParseReadingsFilter(t *testing.T) {
+	f, err := ParseReadingsFilter(map[string]interface{}{"adapter": "wlan0"})
+	assert.NoError(t, err)
+	assert.Nil(t, f)
+
+	f, err = ParseReadingsFilter(map[string]interface{}{
+		"readings_filter": map[string]interface{}{
+			"exclude": []interface{}{"swap_device_*"},
+			"prefix":  "nav_",
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"swap_device_*"}, f.Exclude)
+	assert.Equal(t, "nav_", f.Prefix)
+
+	_, err = ParseReadingsFilter(map[string]interface{}{"readings_filter": "cpu"})
+	assert.ErrorContains(t, err, "invalid readings_filter")
+	_, err = ParseReadingsFilter(map[string]interface{}{
+		"readings_filter": map[string]interface{}{"include": []interface{}{""}},
+	})
+	assert.EqualError(t, err, "readings_filter patterns can't be empty")
+	_, err = ParseReadingsFilter(map[string]interface{}{
+		"readings_filter": map[string]interface{}{"rename": map[string]interface{}{"BIG_CORE0": "cpu", "CPU": "cpu"}},
+	})
+	assert.EqualError(t, err, "readings_filter.rename renames both BIG_CORE0 and CPU to cpu")
+	_, err = ParseReadingsFilter(map[string]interface{}{
+		"readings_filter": map[string]interface{}{"debounce_sec": map[string]interface{}{"*_throttled": 0}},
+	})
+	assert.EqualError(t, err, "readings_filter.debounce_sec needs patterns and a positive number of seconds")
+	_, err = ParseReadingsFilter(map[string]interface{}{
+		"readings_filter": map[string]interface{}{"hysteresis": map[string]interface{}{"": 2}},
+	})
+	assert.EqualError(t, err, "readings_filter.hysteresis needs patterns and a positive change")
+}
@@ -0,0 +1,31 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_NumericReadings(t *testing.T) {
+	readings := map[string]interface{}{
+		"temperature": 45.5,
+		"count":       3,
+		"bytes":       uint64(1024),
+		"throttled":   true,
+		"online":      false,
+		"model":       "Raspberry Pi 5",
+		"cpu": map[string]interface{}{
+			"usage": 12.5,
+			"name":  "cortex-a76",
+		},
+		"list": []interface{}{1, 2},
+	}
+	assert.Equal(t, map[string]float64{
+		"temperature": 45.5,
+		"count":       3,
+		"bytes":       1024,
+		"throttled":   1,
+		"online":      0,
+		"cpu_usage":   12.5,
+	}, NumericReadings(readings))
+}
@@ -0,0 +1,63 @@
+package utils
+
+import "sync"
+
+// RingBuffer keeps the most recent items pushed to it, unlike CappedCollection its items are always oldest first.
+type RingBuffer[T interface{}] interface {
+	Push(item T)
+	Items() []T
+	Last() (T, bool)
+	Len() int
+}
+
+type ringBuffer[T interface{}] struct {
+	mu    sync.Mutex
+	items []T
+	start int
+	size  int
+}
+
+// NewRingBuffer creates a RingBuffer holding up to size items, once full each push replaces the oldest item.
+func NewRingBuffer[T interface{}](size int) RingBuffer[T] {
+	return &ringBuffer[T]{
+		items: make([]T, 0, size),
+		size:  size,
+	}
+}
+
+func (r *ringBuffer[T]) Push(item T) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if len(r.items) < r.size {
+		r.items = append(r.items, item)
+		return
+	}
+	r.items[r.start] = item
+	r.start = (r.start + 1) % r.size
+}
+
+// Items returns a copy of the items, oldest first.
+func (r *ringBuffer[T]) Items() []T {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	ret := make([]T, 0, len(r.items))
+	ret = append(ret, r.items[r.start:]...)
+	return append(ret, r.items[:r.start]...)
+}
+
+// Last returns the most recently pushed item, ok is false if nothing has been pushed.
+func (r *ringBuffer[T]) Last() (T, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if len(r.items) == 0 {
+		var zero T
+		return zero, false
+	}
+	return r.items[(r.start+len(r.items)-1)%len(r.items)], true
+}
+
+func (r *ringBuffer[T]) Len() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.items)
+}
@@ -0,0 +1,29 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRingBuffer(t *testing.T) {
+	ring := NewRingBuffer[int](3)
+	assert.Equal(t, []int{}, ring.Items())
+	_, ok := ring.Last()
+	assert.False(t, ok)
+
+	ring.Push(1)
+	ring.Push(2)
+	assert.Equal(t, []int{1, 2}, ring.Items())
+	last, ok := ring.Last()
+	assert.True(t, ok)
+	assert.Equal(t, 2, last)
+
+	ring.Push(3)
+	ring.Push(4)
+	ring.Push(5)
+	assert.Equal(t, []int{3, 4, 5}, ring.Items())
+	assert.Equal(t, 3, ring.Len())
+	last, _ = ring.Last()
+	assert.Equal(t, 5, last)
+}
@@ -0,0 +1,232 @@
+package utils
+
+import (
+	"context"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// maxJitter bounds the random phase of a task, so the first poll of a task with a long interval isn't long after it's
+// scheduled.
+const maxJitter = 5 * time.Second
+
+// minInterval is the shortest interval a task is polled at, shorter intervals, including ones that aren't positive,
+// are polled at it instead so a bad interval can't spin the loop.
+const minInterval = 10 * time.Millisecond
+
+// Scheduler polls every task with the same interval from one loop, rather than each component running its own ticker.
+// Each task runs at a random phase within its interval, so tasks with the same interval don't all poll at once and
+// cause a spike, unless they read the same source, see ScheduleAligned. A task's polls are always at least an interval
+// apart, a poll that runs late pushes the ones after it back rather than being followed by a shorter gap, so rates
+// computed over the interval stay honest.
+type Scheduler struct {
+	mu     sync.Mutex
+	groups map[time.Duration]*scheduleGroup
+	rand   *rand.Rand
+}
+
+type scheduleGroup struct {
+	interval time.Duration
+	tasks    map[*Task]struct{}
+	// wake interrupts the loop's wait when a task is added
+	wake chan struct{}
+}
+
+// Task is a poll function the scheduler calls every interval until it's stopped.
+type Task struct {
+	scheduler *Scheduler
+	poll      func(ctx context.Context)
+	ctx       context.Context
+	cancel    context.CancelFunc
+	// source is what the task reads, tasks with the same source and interval poll at the same phase
+	source string
+	// interval and next are guarded by the scheduler's lock
+	interval time.Duration
+	next     time.Time
+
+	mu      sync.Mutex
+	stopped bool
+	running bool
+	wg      sync.WaitGroup
+}
+
+func NewScheduler() *Scheduler {
+	return &Scheduler{
+		groups: make(map[time.Duration]*scheduleGroup),
+		rand:   rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+var defaultScheduler = NewScheduler()
+
+// ReadingsSource is the source of the tasks that poll the readings of other components, e.g. the exporters and stores,
+// so the vcgencmd, iw and process list reads behind those readings are shared between them.
+const ReadingsSource = "readings"
+
+// Schedule calls poll every interval from the module's scheduler, the first call is within one interval or 5 seconds,
+// whichever is shorter.
+func Schedule(interval time.Duration, poll func(ctx context.Context)) *Task {
+	return defaultScheduler.Schedule(interval, poll)
+}
+
+// Schedule calls poll every interval, the first call is within one interval or 5 seconds, whichever is shorter. A poll
+// that's still running when it's due again is skipped rather than run twice at once.
+func (s *Scheduler) Schedule(interval time.Duration, poll func(ctx context.Context)) *Task {
+	return s.ScheduleAligned(interval, "", poll)
+}
+
+// ScheduleAligned calls poll every interval from the module's scheduler, at the same phase as the other tasks with the
+// same source and interval. Sources read with ReadShared are only shared by reads within their max age of each other,
+// tasks that poll them at random phases would each read them.
+func ScheduleAligned(interval time.Duration, source string, poll func(ctx context.Context)) *Task {
+	return defaultScheduler.ScheduleAligned(interval, source, poll)
+}
+
+// ScheduleAligned is Schedule for tasks that read the same source, the task polls at the same phase as the others with
+// the same source and interval, the first of them at a random phase. An empty source isn't aligned with anything.
+func (s *Scheduler) ScheduleAligned(interval time.Duration, source string, poll func(ctx context.Context)) *Task {
+	interval = max(interval, minInterval)
+	ctx, cancel := context.WithCancel(context.Background())
+	t := &Task{scheduler: s, poll: poll, source: source, ctx: ctx, cancel: cancel}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	t.interval = interval
+	t.next = time.Now().Add(time.Duration(s.rand.Int63n(int64(min(interval, maxJitter)))))
+	s.align(t)
+	s.add(t)
+	return t
+}
+
+// align moves the task to the phase of another task with the same source and interval, s.mu must be held.
+func (s *Scheduler) align(t *Task) {
+	g, ok := s.groups[t.interval]
+	if !ok || t.source == "" {
+		return
+	}
+	for other := range g.tasks {
+		if other != t && other.source == t.source {
+			t.next = other.next
+			return
+		}
+	}
+}
+
+// add adds the task to the group for its interval, starting the group's loop if it's the first, s.mu must be held.
+func (s *Scheduler) add(t *Task) {
+	g, ok := s.groups[t.interval]
+	if !ok {
+		g = &scheduleGroup{interval: t.interval, tasks: make(map[*Task]struct{}), wake: make(chan struct{}, 1)}
+		s.groups[t.interval] = g
+		go s.loop(g)
+	}
+	g.tasks[t] = struct{}{}
+	select {
+	case g.wake <- struct{}{}:
+	default:
+	}
+}
+
+// remove removes the task from its group, the group's loop exits once it has no tasks left, s.mu must be held.
+func (s *Scheduler) remove(t *Task) {
+	g, ok := s.groups[t.interval]
+	if !ok {
+		return
+	}
+	delete(g.tasks, t)
+	select {
+	case g.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (s *Scheduler) loop(g *scheduleGroup) {
+	timer := time.NewTimer(g.interval)
+	defer timer.Stop()
+	for {
+		s.mu.Lock()
+		if len(g.tasks) == 0 {
+			delete(s.groups, g.interval)
+			s.mu.Unlock()
+			return
+		}
+		now := time.Now()
+		var due []*Task
+		next := now.Add(g.interval)
+		for t := range g.tasks {
+			if !t.next.After(now) {
+				due = append(due, t)
+				// Counting from when the poll actually runs skips the polls that were missed, and tasks that were due
+				// together stay together
+				t.next = now.Add(g.interval)
+			}
+			if t.next.Before(next) {
+				next = t.next
+			}
+		}
+		s.mu.Unlock()
+
+		for _, t := range due {
+			t.run()
+		}
+		timer.Reset(time.Until(next))
+		select {
+		case <-timer.C:
+		case <-g.wake:
+		}
+	}
+}
+
+func (t *Task) run() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.stopped || t.running {
+		return
+	}
+	t.running = true
+	t.wg.Add(1)
+	go func() {
+		defer t.wg.Done()
+		t.poll(t.ctx)
+		t.mu.Lock()
+		t.running = false
+		t.mu.Unlock()
+	}()
+}
+
+// Interval returns how often the task is polled.
+func (t *Task) Interval() time.Duration {
+	t.scheduler.mu.Lock()
+	defer t.scheduler.mu.Unlock()
+	return t.interval
+}
+
+// SetInterval changes how often the task is polled, the next poll is the new interval after the previous one, or at
+// the phase of another task with the same source and the new interval.
+func (t *Task) SetInterval(d time.Duration) {
+	d = max(d, minInterval)
+	s := t.scheduler
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if d == t.interval {
+		return
+	}
+	s.remove(t)
+	t.next = t.next.Add(d - t.interval)
+	t.interval = d
+	s.align(t)
+	s.add(t)
+}
+
+// Stop stops polling the task, it waits for a poll that's running to return. The poll's context is canceled so it can
+// return early.
+func (t *Task) Stop() {
+	t.mu.Lock()
+	t.stopped = true
+	t.mu.Unlock()
+	t.cancel()
+	t.scheduler.mu.Lock()
+	t.scheduler.remove(t)
+	t.scheduler.mu.Unlock()
+	t.wg.Wait()
+}
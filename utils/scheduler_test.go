@@ -0,0 +1,132 @@
+package utils
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchedulerPollsEveryInterval(t *testing.T) {
+	s := NewScheduler()
+	var polls atomic.Int32
+	task := s.Schedule(20*time.Millisecond, func(ctx context.Context) {
+		polls.Add(1)
+	})
+	time.Sleep(110 * time.Millisecond)
+	task.Stop()
+	n := polls.Load()
+	assert.GreaterOrEqual(t, n, int32(4))
+	assert.LessOrEqual(t, n, int32(6))
+
+	// No polls after the task is stopped
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, n, polls.Load())
+}
+
+func TestSchedulerPollsAtLeastAnIntervalApart(t *testing.T) {
+	s := NewScheduler()
+	starts := make(chan time.Time, 10)
+	task := s.Schedule(20*time.Millisecond, func(ctx context.Context) {
+		starts <- time.Now()
+	})
+	prev := <-starts
+	for i := 0; i < 5; i++ {
+		start := <-starts
+		assert.GreaterOrEqual(t, start.Sub(prev), 20*time.Millisecond)
+		prev = start
+	}
+	task.Stop()
+}
+
+func TestSchedulerSharesLoopForSameInterval(t *testing.T) {
+	s := NewScheduler()
+	a := s.Schedule(time.Hour, func(ctx context.Context) {})
+	b := s.Schedule(time.Hour, func(ctx context.Context) {})
+	c := s.Schedule(time.Minute, func(ctx context.Context) {})
+	s.mu.Lock()
+	assert.Len(t, s.groups, 2)
+	assert.Len(t, s.groups[time.Hour].tasks, 2)
+	s.mu.Unlock()
+
+	c.SetInterval(time.Hour)
+	s.mu.Lock()
+	assert.Len(t, s.groups[time.Hour].tasks, 3)
+	s.mu.Unlock()
+	assert.Equal(t, time.Hour, c.Interval())
+
+	a.Stop()
+	b.Stop()
+	c.Stop()
+	require.Eventually(t, func() bool {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		return len(s.groups) == 0
+	}, time.Second, time.Millisecond)
+}
+
+func TestSchedulerAlignsTasksReadingTheSameSource(t *testing.T) {
+	t.Cleanup(ResetSharedReads)
+	s := NewScheduler()
+	var reads, polls atomic.Int32
+	read := func(ctx context.Context) (int, error) {
+		reads.Add(1)
+		return 1, nil
+	}
+	poll := func(ctx context.Context) {
+		_, _ = ReadShared(ctx, "aligned", SharedReadAge, read)
+		polls.Add(1)
+	}
+	// The consumers poll together, so each round they share one read rather than each reading it
+	a := s.ScheduleAligned(time.Second, "test", poll)
+	b := s.ScheduleAligned(time.Second, "test", poll)
+	other := s.ScheduleAligned(time.Second, "other", func(ctx context.Context) {})
+	s.mu.Lock()
+	assert.Equal(t, a.next, b.next)
+	s.mu.Unlock()
+
+	require.Eventually(t, func() bool { return polls.Load() == 4 }, 5*time.Second, time.Millisecond)
+	a.Stop()
+	b.Stop()
+	other.Stop()
+	assert.Equal(t, int32(2), reads.Load())
+}
+
+func TestSchedulerStopCancelsRunningPoll(t *testing.T) {
+	s := NewScheduler()
+	started := make(chan struct{})
+	var once sync.Once
+	task := s.Schedule(time.Millisecond, func(ctx context.Context) {
+		once.Do(func() { close(started) })
+		<-ctx.Done()
+	})
+	<-started
+	done := make(chan struct{})
+	go func() {
+		task.Stop()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("stop didn't cancel the running poll")
+	}
+}
+
+func TestSchedulerClampsShortIntervals(t *testing.T) {
+	s := NewScheduler()
+	var polls atomic.Int32
+	task := s.Schedule(0, func(ctx context.Context) {
+		polls.Add(1)
+	})
+	defer task.Stop()
+	assert.Equal(t, minInterval, task.Interval())
+	require.Eventually(t, func() bool { return polls.Load() > 0 }, time.Second, time.Millisecond)
+
+	task.SetInterval(-time.Second)
+	assert.Equal(t, minInterval, task.Interval())
+}
@@ -0,0 +1,88 @@
+package utils
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+)
+
+// SharedReadAge is how long the output of a command read through SharedOutput is shared. It's half of stream_api's
+// default interval of 0.5s, the fastest sensors are polled at by default, so a sensor polled at that rate or slower
+// never sees its own previous read again. The components that poll other components are scheduled at the same phase,
+// see ScheduleAligned, so their polls with the same interval fall within it of each other.
+const SharedReadAge = 250 * time.Millisecond
+
+// sharedReadTimeout bounds a shared read, it isn't bounded by any one caller's context as the others are waiting for it.
+const sharedReadTimeout = 10 * time.Second
+
+// sharedReads are the reads of sources several sensors read from, e.g. the process list or vcgencmd, so sensors
+// reading the same source at about the same time share one read rather than each reading it.
+var sharedReads = struct {
+	mu      sync.Mutex
+	entries map[string]*sharedRead
+}{entries: make(map[string]*sharedRead)}
+
+type sharedRead struct {
+	// done is closed once value and err are set
+	done  chan struct{}
+	value any
+	err   error
+	at    time.Time
+}
+
+// ReadShared returns what read returns, the callers with the same key within maxAge of a read share it rather than
+// reading again. The read runs with its own timeout rather than the context of the caller that started it, so a caller
+// that's canceled doesn't fail the others. Each caller waits for the read until its ctx is done. Failed reads aren't
+// shared with later callers, so they retry. The value is shared, so callers must not modify it.
+func ReadShared[T any](ctx context.Context, key string, maxAge time.Duration, read func(ctx context.Context) (T, error)) (T, error) {
+	sharedReads.mu.Lock()
+	entry, ok := sharedReads.entries[key]
+	if ok {
+		select {
+		case <-entry.done:
+			if entry.err != nil || time.Since(entry.at) > maxAge {
+				ok = false
+			}
+		default:
+		}
+	}
+	if !ok {
+		entry = &sharedRead{done: make(chan struct{})}
+		sharedReads.entries[key] = entry
+		go func() {
+			readCtx, cancel := context.WithTimeout(context.Background(), sharedReadTimeout)
+			defer cancel()
+			value, err := read(readCtx)
+			entry.value, entry.err, entry.at = value, err, time.Now()
+			close(entry.done)
+		}()
+	}
+	sharedReads.mu.Unlock()
+
+	select {
+	case <-entry.done:
+	case <-ctx.Done():
+		var zero T
+		return zero, ctx.Err()
+	}
+	value, _ := entry.value.(T)
+	return value, entry.err
+}
+
+// ResetSharedReads forgets every shared read, so the next read of each source reads it again. Changing where files are
+// read from or what runs commands resets them.
+func ResetSharedReads() {
+	sharedReads.mu.Lock()
+	defer sharedReads.mu.Unlock()
+	sharedReads.entries = make(map[string]*sharedRead)
+}
+
+// SharedOutput is Output for commands several sensors run, e.g. vcgencmd or iw, callers running the same command within
+// SharedReadAge of each other share its output.
+func SharedOutput(ctx context.Context, name string, args ...string) ([]byte, error) {
+	key := "exec:" + name + " " + strings.Join(args, " ")
+	return ReadShared(ctx, key, SharedReadAge, func(ctx context.Context) ([]byte, error) {
+		return Output(ctx, name, args...)
+	})
+}
@@ -0,0 +1,89 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadShared(t *testing.T) {
+	t.Cleanup(ResetSharedReads)
+
+	var reads atomic.Int32
+	release := make(chan struct{})
+	read := func(ctx context.Context) (int, error) {
+		reads.Add(1)
+		<-release
+		return 42, nil
+	}
+
+	// Callers asking while a read is running wait for it rather than reading again, the caller that started it being
+	// canceled doesn't fail the others
+	canceled, cancel := context.WithCancel(context.Background())
+	started := make(chan error)
+	go func() {
+		_, err := ReadShared(canceled, "test", time.Hour, read)
+		started <- err
+	}()
+	require.Eventually(t, func() bool { return reads.Load() == 1 }, time.Second, time.Millisecond)
+	cancel()
+	assert.ErrorIs(t, <-started, context.Canceled)
+	var wg sync.WaitGroup
+	for range 3 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			v, err := ReadShared(context.Background(), "test", time.Hour, read)
+			assert.NoError(t, err)
+			assert.Equal(t, 42, v)
+		}()
+	}
+	require.Eventually(t, func() bool { return reads.Load() == 1 }, time.Second, time.Millisecond)
+	time.Sleep(10 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	assert.Equal(t, int32(1), reads.Load())
+
+	// A read older than maxAge is read again
+	_, err := ReadShared(context.Background(), "test", 0, read)
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), reads.Load())
+
+	// Failed reads are retried
+	failed := errors.New("failed")
+	_, err = ReadShared(context.Background(), "failing", time.Hour, func(ctx context.Context) (int, error) { return 0, failed })
+	assert.ErrorIs(t, err, failed)
+	v, err := ReadShared(context.Background(), "failing", time.Hour, func(ctx context.Context) (int, error) { return 1, nil })
+	require.NoError(t, err)
+	assert.Equal(t, 1, v)
+}
+
+func TestSharedOutput(t *testing.T) {
+	t.Cleanup(func() { SetCommandRunner(DefaultCommandRunner()) })
+
+	var ran int
+	SetCommandRunner(CommandRunnerFunc(func(ctx context.Context, cmd Command) ([]byte, error) {
+		ran++
+		return []byte("temp=48.3'C"), nil
+	}))
+	for range 3 {
+		out, err := SharedOutput(context.Background(), "vcgencmd", "measure_temp")
+		require.NoError(t, err)
+		assert.Equal(t, "temp=48.3'C", string(out))
+	}
+	assert.Equal(t, 1, ran)
+
+	// Changing what runs commands forgets the shared output
+	SetCommandRunner(CommandRunnerFunc(func(ctx context.Context, cmd Command) ([]byte, error) {
+		return []byte("temp=50.0'C"), nil
+	}))
+	out, err := SharedOutput(context.Background(), "vcgencmd", "measure_temp")
+	require.NoError(t, err)
+	assert.Equal(t, "temp=50.0'C", string(out))
+}
@@ -0,0 +1,75 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"go.viam.com/rdk/components/sensor"
+	"go.viam.com/rdk/logging"
+	"go.viam.com/rdk/resource"
+)
+
+// ReadingsConverter is implemented by the configs of components that report their readings in the units they're
+// configured with, simulated readings are converted with it as the component converts its real ones.
+type ReadingsConverter interface {
+	ConvertReadings(readings map[string]interface{}) map[string]interface{}
+}
+
+// simulatedSensor returns the synthetic readings of its model instead of reading the hardware.
+type simulatedSensor struct {
+	resource.Named
+	resource.TriviallyCloseable
+	mu        sync.RWMutex
+	simulator Simulator
+	sim       *Simulation
+	converter ReadingsConverter
+}
+
+func newSimulatedSensor(conf resource.Config, sim *Simulation, logger logging.Logger) (sensor.Sensor, error) {
+	simulator, ok := lookupSimulator(conf.Model)
+	if !ok {
+		return nil, fmt.Errorf("%s can't be simulated", conf.Model.Name)
+	}
+	logger.Infof("Simulating %s with the %s profile", conf.ResourceName().Name, sim.Profile)
+	converter, _ := conf.ConvertedAttributes.(ReadingsConverter)
+	sim.setConfig(conf.ConvertedAttributes)
+	return &simulatedSensor{Named: conf.ResourceName().AsNamed(), simulator: simulator, sim: sim, converter: converter}, nil
+}
+
+func (s *simulatedSensor) Reconfigure(ctx context.Context, _ resource.Dependencies, conf resource.Config) error {
+	sim, err := ParseSimulation(conf.Attributes)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.Named = conf.ResourceName().AsNamed()
+	s.converter, _ = conf.ConvertedAttributes.(ReadingsConverter)
+	// Keep the simulation running unless the profile changed, so its counters don't start over
+	if sim.Profile != s.sim.Profile {
+		s.sim = sim
+	}
+	s.sim.setConfig(conf.ConvertedAttributes)
+	return nil
+}
+
+func (s *simulatedSensor) Readings(ctx context.Context, extra map[string]interface{}) (map[string]interface{}, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	readings := s.simulator(s.sim)
+	if s.converter != nil {
+		readings = s.converter.ConvertReadings(readings)
+	}
+	return readings, nil
+}
+
+func (s *simulatedSensor) DoCommand(ctx context.Context, cmd map[string]interface{}) (map[string]interface{}, error) {
+	return nil, fmt.Errorf("%v isn't available when simulating", cmd["command"])
+}
+
+func (s *simulatedSensor) Capabilities(ctx context.Context) Capabilities {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return Capabilities{Backend: "simulated " + s.sim.Profile}
+}
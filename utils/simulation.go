@@ -0,0 +1,163 @@
+package utils
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sync"
+	"time"
+
+	"go.viam.com/rdk/resource"
+)
+
+const (
+	// SimulateAttribute is the attribute every component takes to generate synthetic readings instead of reading the
+	// hardware, so applications can be developed on machines that don't have it.
+	SimulateAttribute = "simulate"
+	// SimulateProfileAttribute selects what the simulated board is doing.
+	SimulateProfileAttribute = "simulate_profile"
+
+	ProfileIdle              = "idle"
+	ProfileLoaded            = "loaded"
+	ProfileThermalThrottling = "thermal_throttling"
+)
+
+// driftPeriod is how long the slow drift of simulated values takes to repeat, so graphs of them aren't flat noise.
+const driftPeriod = 5 * time.Minute
+
+// Simulator returns the synthetic readings of a model, they have the same keys as the readings of the real sensor.
+type Simulator func(sim *Simulation) map[string]interface{}
+
+var (
+	simulatorsLock sync.RWMutex
+	simulators     = make(map[resource.Model]Simulator)
+)
+
+// RegisterSimulator registers the synthetic readings of a model. Components of models without one can't be simulated.
+func RegisterSimulator(model resource.Model, simulator Simulator) {
+	simulatorsLock.Lock()
+	defer simulatorsLock.Unlock()
+	simulators[model] = simulator
+}
+
+func lookupSimulator(model resource.Model) (Simulator, bool) {
+	simulatorsLock.RLock()
+	defer simulatorsLock.RUnlock()
+	simulator, ok := simulators[model]
+	return simulator, ok
+}
+
+// Simulation generates plausible values for a profile: idle, loaded or thermal_throttling. Values drift slowly and
+// have some noise, and counters keep increasing, like the real readings would.
+type Simulation struct {
+	Profile string
+	mu      sync.Mutex
+	started time.Time
+	rand    *rand.Rand
+	config  resource.ConfigValidator
+}
+
+func NewSimulation(profile string) (*Simulation, error) {
+	switch profile {
+	case "":
+		profile = ProfileIdle
+	case ProfileIdle, ProfileLoaded, ProfileThermalThrottling:
+	default:
+		return nil, fmt.Errorf("%s must be one of %s, %s or %s", SimulateProfileAttribute, ProfileIdle, ProfileLoaded, ProfileThermalThrottling)
+	}
+	now := time.Now()
+	return &Simulation{
+		Profile: profile,
+		started: now,
+		rand:    rand.New(rand.NewSource(now.UnixNano())),
+	}, nil
+}
+
+// ParseSimulation parses the simulate and simulate_profile attributes, it returns nil when the component isn't
+// simulated.
+func ParseSimulation(attributes map[string]interface{}) (*Simulation, error) {
+	raw, ok := attributes[SimulateAttribute]
+	if !ok || raw == nil {
+		return nil, nil
+	}
+	simulate, ok := raw.(bool)
+	if !ok {
+		return nil, fmt.Errorf("%s must be true or false", SimulateAttribute)
+	}
+	if !simulate {
+		return nil, nil
+	}
+	profile := ""
+	if raw, ok := attributes[SimulateProfileAttribute]; ok && raw != nil {
+		if profile, ok = raw.(string); !ok {
+			return nil, fmt.Errorf("%s must be a string", SimulateProfileAttribute)
+		}
+	}
+	return NewSimulation(profile)
+}
+
+// Config returns the component's config, e.g. so the readings of a process monitor are of the processes it's configured
+// with. It's nil when the component has none.
+func (s *Simulation) Config() resource.ConfigValidator {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.config
+}
+
+func (s *Simulation) setConfig(config resource.ConfigValidator) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.config = config
+}
+
+// Started returns when the simulation started, e.g. for when the simulated counters started counting.
+func (s *Simulation) Started() time.Time {
+	return s.started
+}
+
+// Throttling is whether the simulated board is thermally throttling.
+func (s *Simulation) Throttling() bool {
+	return s.Profile == ProfileThermalThrottling
+}
+
+// Level returns the value for the profile without drift or noise.
+func (s *Simulation) Level(idle, loaded, throttling float64) float64 {
+	switch s.Profile {
+	case ProfileLoaded:
+		return loaded
+	case ProfileThermalThrottling:
+		return throttling
+	default:
+		return idle
+	}
+}
+
+// Value returns the value for the profile, drifting slowly and with noise of up to spread either way. Values don't go
+// below zero.
+func (s *Simulation) Value(idle, loaded, throttling, spread float64) float64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	phase := 2 * math.Pi * float64(time.Since(s.started)) / float64(driftPeriod)
+	value := s.Level(idle, loaded, throttling) + spread*(math.Sin(phase)/2+s.rand.Float64()-0.5)
+	return math.Round(math.Max(value, 0)*100) / 100
+}
+
+// Percent is Value capped at 100.
+func (s *Simulation) Percent(idle, loaded, throttling, spread float64) float64 {
+	return math.Min(s.Value(idle, loaded, throttling, spread), 100)
+}
+
+// Stamp adds what Freshness.Stamp adds to the readings of components that poll in the background, simulated readings
+// are always fresh.
+func (s *Simulation) Stamp(readings map[string]interface{}) map[string]interface{} {
+	readings[ReadingTimeKey] = time.Now().Format(time.RFC3339Nano)
+	readings["reading_age_sec"] = 0.0
+	readings["stale"] = false
+	return readings
+}
+
+// Counter returns a counter that has increased from start at the profile's rate per second since the simulation
+// started.
+func (s *Simulation) Counter(start, idle, loaded, throttling float64) uint64 {
+	return uint64(start + s.Level(idle, loaded, throttling)*time.Since(s.started).Seconds())
+}
@@ -0,0 +1,136 @@
+package utils
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.viam.com/rdk/components/sensor"
+	"go.viam.com/rdk/logging"
+	"go.viam.com/rdk/resource"
+)
+
+func TestParseSimulation(t *testing.T) {
+	sim, err := ParseSimulation(map[string]interface{}{})
+	require.NoError(t, err)
+	assert.Nil(t, sim)
+
+	sim, err = ParseSimulation(map[string]interface{}{SimulateAttribute: false, SimulateProfileAttribute: ProfileLoaded})
+	require.NoError(t, err)
+	assert.Nil(t, sim)
+
+	sim, err = ParseSimulation(map[string]interface{}{SimulateAttribute: true})
+	require.NoError(t, err)
+	assert.Equal(t, ProfileIdle, sim.Profile)
+
+	for _, attributes := range []map[string]interface{}{
+		{SimulateAttribute: "yes"},
+		{SimulateAttribute: true, SimulateProfileAttribute: "busy"},
+		{SimulateAttribute: true, SimulateProfileAttribute: 1},
+	} {
+		_, err := ParseSimulation(attributes)
+		assert.Error(t, err, attributes)
+	}
+}
+
+func TestSimulationValues(t *testing.T) {
+	sim, err := NewSimulation(ProfileThermalThrottling)
+	require.NoError(t, err)
+	assert.True(t, sim.Throttling())
+	for i := 0; i < 100; i++ {
+		assert.InDelta(t, 84, sim.Value(45, 70, 84, 4), 4)
+		assert.LessOrEqual(t, sim.Percent(5, 80, 99, 4), 100.0)
+		assert.GreaterOrEqual(t, sim.Value(0, 0, 0, 4), 0.0)
+	}
+	first := sim.Counter(1000, 1, 1e6, 1e6)
+	assert.GreaterOrEqual(t, sim.Counter(1000, 1, 1e6, 1e6), first)
+}
+
+func TestSimulatedSensor(t *testing.T) {
+	model := resource.NewModel(Namespace, "hwmonitor", "simulation_test")
+	RegisterSimulator(model, func(sim *Simulation) map[string]interface{} {
+		return map[string]interface{}{"usage": sim.Level(5, 80, 99), "throttled": sim.Throttling()}
+	})
+	constructor := FilterReadings(func(context.Context, resource.Dependencies, resource.Config, logging.Logger) (sensor.Sensor, error) {
+		t.Fatal("the hardware sensor shouldn't be created when simulating")
+		return nil, nil
+	})
+	conf := resource.Config{Name: "test", API: sensor.API, Model: model, Attributes: map[string]interface{}{
+		SimulateAttribute:        true,
+		SimulateProfileAttribute: ProfileLoaded,
+		ReadingsFilterAttribute:  map[string]interface{}{"prefix": "sim_"},
+	}}
+	s, err := constructor(context.Background(), nil, conf, logging.NewTestLogger(t))
+	require.NoError(t, err)
+
+	readings, err := s.Readings(context.Background(), nil)
+	require.NoError(t, err)
+	assert.Equal(t, false, readings["sim_stale"])
+	assert.Contains(t, readings, "sim_reading_time")
+	assert.Equal(t, map[string]interface{}{"sim_usage": 80.0, "sim_throttled": false}, withoutFreshness(readings, "sim_"))
+
+	ret, err := s.DoCommand(context.Background(), map[string]interface{}{"command": CapabilitiesCommand})
+	require.NoError(t, err)
+	assert.Equal(t, "simulated loaded", ret["backend"])
+
+	// Turning the simulation off needs the hardware sensor
+	conf.Attributes = map[string]interface{}{}
+	assert.True(t, resource.IsMustRebuildError(s.Reconfigure(context.Background(), nil, conf)))
+
+	conf.Model = resource.NewModel(Namespace, "hwmonitor", "not_simulated")
+	conf.Attributes = map[string]interface{}{SimulateAttribute: true}
+	_, err = constructor(context.Background(), nil, conf, logging.NewTestLogger(t))
+	assert.EqualError(t, err, "not_simulated can't be simulated")
+}
+
+type unitsTestConfig struct {
+	Units Units
+}
+
+func (conf *unitsTestConfig) Validate(path string) ([]string, error) {
+	return nil, nil
+}
+
+func (conf *unitsTestConfig) ConvertReadings(readings map[string]interface{}) map[string]interface{} {
+	readings["CPU"] = conf.Units.Convert(Temperature, readings["CPU"])
+	conf.Units.AddUnit(readings, Temperature)
+	return readings
+}
+
+func TestSimulatedSensorUsesConfig(t *testing.T) {
+	model := resource.NewModel(Namespace, "hwmonitor", "simulation_units_test")
+	RegisterSimulator(model, func(sim *Simulation) map[string]interface{} {
+		_, configured := sim.Config().(*unitsTestConfig)
+		return map[string]interface{}{"CPU": sim.Level(50, 70, 85), "configured": configured}
+	})
+	constructor := FilterReadings(func(context.Context, resource.Dependencies, resource.Config, logging.Logger) (sensor.Sensor, error) {
+		t.Fatal("the hardware sensor shouldn't be created when simulating")
+		return nil, nil
+	})
+	conf := resource.Config{
+		Name: "test", API: sensor.API, Model: model,
+		Attributes:          map[string]interface{}{SimulateAttribute: true},
+		ConvertedAttributes: &unitsTestConfig{Units: Units{Temperature: "fahrenheit"}},
+	}
+	s, err := constructor(context.Background(), nil, conf, logging.NewTestLogger(t))
+	require.NoError(t, err)
+	readings, err := s.Readings(context.Background(), nil)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"CPU": 122.0, "temperature_unit": "fahrenheit", "configured": true}, withoutFreshness(readings, ""))
+
+	// A reconfigure applies the new units to the next reading
+	conf.ConvertedAttributes = &unitsTestConfig{}
+	require.NoError(t, s.Reconfigure(context.Background(), nil, conf))
+	readings, err = s.Readings(context.Background(), nil)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"CPU": 50.0, "temperature_unit": "celsius", "configured": true}, withoutFreshness(readings, ""))
+}
+
+// withoutFreshness drops what StampRead adds to every component's readings, under the filter's prefix.
+func withoutFreshness(readings map[string]interface{}, prefix string) map[string]interface{} {
+	for _, key := range []string{ReadingTimeKey, "reading_age_sec", "stale"} {
+		delete(readings, prefix+key)
+	}
+	return readings
+}
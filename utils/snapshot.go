@@ -0,0 +1,274 @@
+package utils
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// CaptureCommand is the DoCommand every component takes to snapshot the files the module has read from /proc, /sys,
+	// /dev and /etc, so a board's readings can be reproduced without the board.
+	CaptureCommand = "capture_snapshot"
+	// ReplayEnv is the environment variable that makes the module read the host's files from a snapshot instead.
+	ReplayEnv = "HWMONITOR_REPLAY"
+)
+
+// gopsutilFiles are read by gopsutil rather than by the module, so they're never recorded but the cpu, memory and disk
+// readings need them.
+var gopsutilFiles = []string{
+	"/proc/stat", "/proc/meminfo", "/proc/vmstat", "/proc/diskstats", "/proc/swaps", "/proc/uptime", "/proc/loadavg",
+	"/proc/cpuinfo", "/proc/net/dev", "/proc/1/mountinfo",
+}
+
+// gopsutilEnv are the variables gopsutil reads the host's files from.
+var gopsutilEnv = map[string]string{"HOST_PROC": "proc", "HOST_SYS": "sys", "HOST_DEV": "dev", "HOST_ETC": "etc"}
+
+// SnapshotDir is where snapshots are written, the capture command can't write them anywhere else.
+func SnapshotDir() string {
+	return filepath.Join(ModuleDataDir(), "snapshots")
+}
+
+// DefaultSnapshotPath is where a snapshot is written when the capture command doesn't say.
+func DefaultSnapshotPath() string {
+	return filepath.Join(SnapshotDir(), "snapshot-"+time.Now().UTC().Format("20060102T150405Z")+".tar.gz")
+}
+
+// SnapshotPath resolves the path the capture command was given, relative paths are relative to SnapshotDir and paths
+// that resolve outside of it are rejected.
+func SnapshotPath(path string) (string, error) {
+	dir := SnapshotDir()
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(dir, path)
+	}
+	path = filepath.Clean(path)
+	if !strings.HasPrefix(path, dir+string(filepath.Separator)) {
+		return "", fmt.Errorf("%s isn't in %s", path, dir)
+	}
+	return path, nil
+}
+
+func recorded(paths map[string]struct{}) []string {
+	hostFS.mu.RLock()
+	defer hostFS.mu.RUnlock()
+	sorted := make([]string, 0, len(paths))
+	for path := range paths {
+		sorted = append(sorted, path)
+	}
+	sort.Strings(sorted)
+	return sorted
+}
+
+type snapshotWriter struct {
+	fs      FS
+	tw      *tar.Writer
+	written map[string]struct{}
+	modTime time.Time
+}
+
+func (w *snapshotWriter) write(hdr *tar.Header, data []byte) error {
+	name := strings.TrimPrefix(hdr.Name, "/")
+	if _, ok := w.written[name]; ok {
+		return nil
+	}
+	w.written[name] = struct{}{}
+	hdr.Name = name
+	hdr.ModTime = w.modTime
+	if err := w.tw.WriteHeader(hdr); err != nil {
+		return err
+	}
+	_, err := w.tw.Write(data)
+	return err
+}
+
+func (w *snapshotWriter) file(path string, data []byte) error {
+	return w.write(&tar.Header{Name: path, Typeflag: tar.TypeReg, Mode: 0o644, Size: int64(len(data))}, data)
+}
+
+func (w *snapshotWriter) dir(path string) error {
+	return w.write(&tar.Header{Name: path + "/", Typeflag: tar.TypeDir, Mode: 0o755}, nil)
+}
+
+// entry writes what the file at path is without its contents, the contents of files that weren't read don't matter.
+func (w *snapshotWriter) entry(path string) error {
+	info, err := w.fs.Stat(path)
+	if err != nil {
+		return nil
+	}
+	if info.IsDir() {
+		return w.dir(path)
+	}
+	return w.file(path, nil)
+}
+
+func readAll(fs FS, path string) ([]byte, error) {
+	f, err := fs.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return io.ReadAll(f)
+}
+
+// CaptureSnapshot writes the files that have been read from /proc, /sys, /dev and /etc since the module started to a
+// tar.gz at path, along with the directories that were listed and the files that were checked for. It returns the
+// number of entries and the size of the archive. It never overwrites an existing file.
+func CaptureSnapshot(path string) (int, int64, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return 0, 0, err
+	}
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer f.Close()
+	gw := gzip.NewWriter(f)
+	fs := CurrentFS()
+	w := &snapshotWriter{fs: fs, tw: tar.NewWriter(gw), written: make(map[string]struct{}), modTime: time.Now()}
+
+	read := append(recorded(hostFS.read), gopsutilFiles...)
+	for _, file := range read {
+		data, err := readAll(fs, file)
+		if err != nil {
+			// The file went away since it was read, e.g. the process exited
+			continue
+		}
+		if err := w.file(file, data); err != nil {
+			return 0, 0, err
+		}
+	}
+	for _, dir := range recorded(hostFS.listed) {
+		entries, err := fs.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		if err := w.dir(dir); err != nil {
+			return 0, 0, err
+		}
+		for _, entry := range entries {
+			if err := w.entry(filepath.Join(dir, entry.Name())); err != nil {
+				return 0, 0, err
+			}
+		}
+	}
+	for _, statted := range recorded(hostFS.statted) {
+		if err := w.entry(statted); err != nil {
+			return 0, 0, err
+		}
+	}
+	// Links go last, a replay creates them after everything else so nothing is extracted through them
+	for _, link := range recorded(hostFS.linked) {
+		target, err := fs.Readlink(link)
+		if err != nil {
+			continue
+		}
+		if err := w.write(&tar.Header{Name: link, Typeflag: tar.TypeSymlink, Linkname: target, Mode: 0o777}, nil); err != nil {
+			return 0, 0, err
+		}
+	}
+
+	if err := w.tw.Close(); err != nil {
+		return 0, 0, err
+	}
+	if err := gw.Close(); err != nil {
+		return 0, 0, err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		return 0, 0, err
+	}
+	return len(w.written), info.Size(), nil
+}
+
+// StartReplay extracts a snapshot and makes the module, including gopsutil, read the host's files from it. It returns
+// where the snapshot was extracted to. Commands such as vcgencmd and iw still run on the machine doing the replay.
+func StartReplay(archive string) (string, error) {
+	f, err := os.Open(archive)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	gr, err := gzip.NewReader(f)
+	if err != nil {
+		return "", fmt.Errorf("%s isn't a snapshot: %w", archive, err)
+	}
+	root, err := os.MkdirTemp("", "hwmonitor-replay-")
+	if err != nil {
+		return "", err
+	}
+	if err := extractSnapshot(tar.NewReader(gr), root); err != nil {
+		os.RemoveAll(root)
+		return "", fmt.Errorf("failed to extract %s: %w", archive, err)
+	}
+
+	SetFS(RootFS{Root: root})
+	for env, dir := range gopsutilEnv {
+		if err := os.Setenv(env, filepath.Join(root, dir)); err != nil {
+			return "", err
+		}
+	}
+	return root, nil
+}
+
+func stopReplay() {
+	SetFS(OSFS{})
+	for env := range gopsutilEnv {
+		os.Unsetenv(env)
+	}
+}
+
+func extractSnapshot(tr *tar.Reader, root string) error {
+	links := make(map[string]string)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		name := filepath.Clean(hdr.Name)
+		if filepath.IsAbs(name) || name == ".." || strings.HasPrefix(name, "../") || !isHostPath("/"+name) {
+			return fmt.Errorf("unexpected entry %s", hdr.Name)
+		}
+		target := filepath.Join(root, name)
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(target, 0o755); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+				return err
+			}
+			data, err := io.ReadAll(tr)
+			if err != nil {
+				return err
+			}
+			if err := os.WriteFile(target, data, 0o644); err != nil {
+				return err
+			}
+		case tar.TypeSymlink:
+			links[target] = hdr.Linkname
+		}
+	}
+	for target, linkname := range links {
+		if _, err := os.Lstat(target); err == nil {
+			continue
+		}
+		if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+			return err
+		}
+		if err := os.Symlink(linkname, target); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,138 @@
+package utils
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func resetHostFS() {
+	stopReplay()
+	hostFS.mu.Lock()
+	defer hostFS.mu.Unlock()
+	for _, paths := range []map[string]struct{}{hostFS.read, hostFS.listed, hostFS.statted, hostFS.linked} {
+		clear(paths)
+	}
+}
+
+func TestSnapshotRoundTrip(t *testing.T) {
+	t.Cleanup(resetHostFS)
+
+	// Stand in for the board's /sys
+	board := t.TempDir()
+	zone := filepath.Join(board, "sys/class/thermal/thermal_zone0")
+	require.NoError(t, os.MkdirAll(zone, 0o755))
+	require.NoError(t, os.MkdirAll(filepath.Join(board, "sys/class/thermal/cooling_device0"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(zone, "temp"), []byte("42000\n"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(zone, "type"), []byte("cpu-thermal\n"), 0o644))
+	require.NoError(t, os.Symlink("../../../bus/thermal/drivers/thermal", filepath.Join(zone, "driver")))
+	SetFS(RootFS{Root: board})
+
+	temp, err := ReadFileWithContext(context.Background(), "/sys/class/thermal/thermal_zone0/temp")
+	require.NoError(t, err)
+	assert.Equal(t, "42000", temp)
+	_, err = ReadDir("/sys/class/thermal")
+	require.NoError(t, err)
+	zones, err := Glob("/sys/class/thermal/thermal_zone*")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"/sys/class/thermal/thermal_zone0"}, zones)
+	_, err = Readlink("/sys/class/thermal/thermal_zone0/driver")
+	require.NoError(t, err)
+
+	archive := filepath.Join(t.TempDir(), "snapshot.tar.gz")
+	files, size, err := CaptureSnapshot(archive)
+	require.NoError(t, err)
+	assert.Equal(t, 5, files)
+	assert.Positive(t, size)
+
+	resetHostFS()
+	root, err := StartReplay(archive)
+	require.NoError(t, err)
+	defer os.RemoveAll(root)
+	assert.Equal(t, filepath.Join(root, "proc"), os.Getenv("HOST_PROC"))
+
+	temp, err = ReadFileWithContext(context.Background(), "/sys/class/thermal/thermal_zone0/temp")
+	require.NoError(t, err)
+	assert.Equal(t, "42000", temp)
+	entries, err := ReadDir("/sys/class/thermal")
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.True(t, entries[0].IsDir())
+	target, err := Readlink("/sys/class/thermal/thermal_zone0/driver")
+	require.NoError(t, err)
+	assert.Equal(t, "thermal", filepath.Base(target))
+	// Files that weren't read aren't in the snapshot
+	data, err := ReadFile("/sys/class/thermal/thermal_zone0/type")
+	assert.Error(t, err)
+	assert.Empty(t, data)
+	// Paths outside the host's directories are read from the host
+	_, err = ReadFile(archive)
+	assert.NoError(t, err)
+}
+
+func TestStartReplayRejectsEntriesOutsideTheHostDirectories(t *testing.T) {
+	t.Cleanup(resetHostFS)
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "escape"), []byte("x"), 0o644))
+	SetFS(RootFS{Root: dir})
+	archive := filepath.Join(t.TempDir(), "snapshot.tar.gz")
+	// Record a path the capture will write as an entry outside of /proc, /sys, /dev and /etc
+	hostFS.mu.Lock()
+	hostFS.read["/sys/../escape"] = struct{}{}
+	hostFS.mu.Unlock()
+	_, _, err := CaptureSnapshot(archive)
+	require.NoError(t, err)
+	stopReplay()
+	_, err = StartReplay(archive)
+	assert.ErrorContains(t, err, "unexpected entry")
+}
+
+func TestRootFS(t *testing.T) {
+	root := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "proc/1"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "proc/1/comm"), []byte("systemd\n"), 0o644))
+	fs := RootFS{Root: root}
+
+	matches, err := fs.Glob("/proc/*/comm")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"/proc/1/comm"}, matches)
+	_, err = fs.Stat("/proc/1/comm")
+	assert.NoError(t, err)
+	// Paths outside of /proc, /sys, /dev and /etc aren't under the root
+	_, err = fs.Stat(root)
+	assert.NoError(t, err)
+}
+
+func TestSnapshotPath(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("VIAM_MODULE_DATA", dir)
+	snapshots := filepath.Join(dir, "snapshots")
+
+	path, err := SnapshotPath("board.tar.gz")
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(snapshots, "board.tar.gz"), path)
+	path, err = SnapshotPath(filepath.Join(snapshots, "board.tar.gz"))
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(snapshots, "board.tar.gz"), path)
+
+	// Paths outside of the snapshot directory are rejected
+	for _, path := range []string{"../module.db", "/etc/passwd", snapshots, filepath.Join(snapshots, "..", "x.tar.gz")} {
+		_, err = SnapshotPath(path)
+		assert.Error(t, err, path)
+	}
+}
+
+func TestCaptureSnapshotDoesNotOverwrite(t *testing.T) {
+	t.Cleanup(resetHostFS)
+	archive := filepath.Join(t.TempDir(), "snapshot.tar.gz")
+	require.NoError(t, os.WriteFile(archive, []byte("keep"), 0o644))
+	_, _, err := CaptureSnapshot(archive)
+	assert.ErrorIs(t, err, os.ErrExist)
+	data, err := os.ReadFile(archive)
+	require.NoError(t, err)
+	assert.Equal(t, "keep", string(data))
+}
@@ -0,0 +1,27 @@
+package utils
+
+import (
+	"go.viam.com/rdk/components/sensor"
+	"go.viam.com/rdk/resource"
+)
+
+// Source is a sensor whose readings another component reads, e.g. the sensors an exporter exports or an alert monitor
+// evaluates.
+type Source struct {
+	Name   string
+	Sensor sensor.Sensor
+}
+
+// ResolveSources looks up the sensors with the given names in a component's dependencies, in the same order. The names
+// must be in the component's dependencies, which Validate returns them as.
+func ResolveSources(deps resource.Dependencies, names []string) ([]Source, error) {
+	sources := make([]Source, 0, len(names))
+	for _, name := range names {
+		s, err := sensor.FromDependencies(deps, name)
+		if err != nil {
+			return nil, err
+		}
+		sources = append(sources, Source{Name: name, Sensor: s})
+	}
+	return sources, nil
+}
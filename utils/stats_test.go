@@ -0,0 +1,44 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_LinearFit(t *testing.T) {
+	slope, intercept, ok := LinearFit([]float64{0, 1, 2, 3}, []float64{1, 3, 5, 7})
+	assert.True(t, ok)
+	assert.InDelta(t, 2.0, slope, 1e-9)
+	assert.InDelta(t, 1.0, intercept, 1e-9)
+
+	slope, _, ok = LinearFit([]float64{0, 1, 2, 3}, []float64{5, 5, 5, 5})
+	assert.True(t, ok)
+	assert.InDelta(t, 0.0, slope, 1e-9)
+
+	_, _, ok = LinearFit([]float64{1}, []float64{1})
+	assert.False(t, ok)
+
+	_, _, ok = LinearFit([]float64{2, 2}, []float64{1, 3})
+	assert.False(t, ok)
+}
+
+func Test_MeanStddev(t *testing.T) {
+	mean, stddev := MeanStddev([]float64{2, 4, 4, 4, 5, 5, 7, 9})
+	assert.InDelta(t, 5.0, mean, 1e-9)
+	assert.InDelta(t, 2.0, stddev, 1e-9)
+
+	mean, stddev = MeanStddev(nil)
+	assert.Equal(t, 0.0, mean)
+	assert.Equal(t, 0.0, stddev)
+}
+
+func Test_Percentile(t *testing.T) {
+	sorted := []float64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11}
+	assert.InDelta(t, 1.0, Percentile(sorted, 0), 1e-9)
+	assert.InDelta(t, 6.0, Percentile(sorted, 50), 1e-9)
+	assert.InDelta(t, 10.5, Percentile(sorted, 95), 1e-9)
+	assert.InDelta(t, 11.0, Percentile(sorted, 100), 1e-9)
+	assert.InDelta(t, 7.0, Percentile([]float64{7}, 95), 1e-9)
+	assert.Equal(t, 0.0, Percentile(nil, 95))
+}
@@ -0,0 +1,105 @@
+package utils
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Quantity is a kind of reading that can be reported in different units.
+type Quantity string
+
+const (
+	Temperature Quantity = "temperature" // Sensors report Celsius
+	Data        Quantity = "data"        // Sensors report bytes
+	Frequency   Quantity = "frequency"   // Sensors report Hz
+	Bitrate     Quantity = "bitrate"     // Sensors report bits per second
+)
+
+type unit struct {
+	name   string
+	factor float64 // How many base units one of this unit is
+}
+
+var units = map[Quantity][]unit{
+	Temperature: {{"celsius", 1}, {"fahrenheit", 0}}, // Converted by formula rather than a factor
+	Data:        {{"bytes", 1}, {"KiB", 1 << 10}, {"MiB", 1 << 20}, {"GiB", 1 << 30}, {"KB", 1e3}, {"MB", 1e6}, {"GB", 1e9}},
+	Frequency:   {{"Hz", 1}, {"kHz", 1e3}, {"MHz", 1e6}, {"GHz", 1e9}},
+	Bitrate:     {{"bps", 1}, {"kbps", 1e3}, {"Mbps", 1e6}, {"Gbps", 1e9}},
+}
+
+// Units selects the units a component reports its readings in. Each defaults to the first unit listed.
+type Units struct {
+	Temperature string `json:"temperature,omitempty"` // celsius or fahrenheit
+	Data        string `json:"data,omitempty"`        // bytes, KiB, MiB, GiB, KB, MB or GB
+	Frequency   string `json:"frequency,omitempty"`   // Hz, kHz, MHz or GHz
+	Bitrate     string `json:"bitrate,omitempty"`     // bps, kbps, Mbps or Gbps
+}
+
+func (u *Units) selected(q Quantity) string {
+	if u == nil {
+		return ""
+	}
+	switch q {
+	case Temperature:
+		return u.Temperature
+	case Data:
+		return u.Data
+	case Frequency:
+		return u.Frequency
+	default:
+		return u.Bitrate
+	}
+}
+
+func (u *Units) unit(q Quantity) unit {
+	name := u.selected(q)
+	for _, candidate := range units[q] {
+		if candidate.name == name {
+			return candidate
+		}
+	}
+	return units[q][0]
+}
+
+// Validate checks that every unit is known.
+func (u *Units) Validate() error {
+	for _, q := range []Quantity{Temperature, Data, Frequency, Bitrate} {
+		name := u.selected(q)
+		if name == "" || u.unit(q).name == name {
+			continue
+		}
+		names := make([]string, 0, len(units[q]))
+		for _, candidate := range units[q] {
+			names = append(names, candidate.name)
+		}
+		return fmt.Errorf("units.%s must be one of %s", q, strings.Join(names, ", "))
+	}
+	return nil
+}
+
+// Name returns the name of the unit q is reported in.
+func (u *Units) Name(q Quantity) string {
+	return u.unit(q).name
+}
+
+// Convert converts a reading from the unit sensors report q in to the selected unit. Readings in the default unit are
+// returned as they are, so they keep their type, and anything that isn't a number is returned as it is.
+func (u *Units) Convert(q Quantity, value interface{}) interface{} {
+	to := u.unit(q)
+	if to.factor == 1 {
+		return value
+	}
+	v, ok := ToFloat64(value)
+	if !ok {
+		return value
+	}
+	if q == Temperature {
+		return RoundValue(v*9/5+32, 2)
+	}
+	return RoundValue(v/to.factor, 3)
+}
+
+// AddUnit adds the name of the unit q is reported in to readings, as <quantity>_unit.
+func (u *Units) AddUnit(readings map[string]interface{}, q Quantity) {
+	readings[string(q)+"_unit"] = u.Name(q)
+}
@@ -0,0 +1,36 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnitsConvert(t *testing.T) {
+	var defaults *Units
+	assert.Equal(t, uint64(2048), defaults.Convert(Data, uint64(2048)))
+	assert.Equal(t, "celsius", defaults.Name(Temperature))
+
+	u := &Units{Temperature: "fahrenheit", Data: "MiB", Frequency: "MHz", Bitrate: "Mbps"}
+	assert.Equal(t, 212.0, u.Convert(Temperature, 100.0))
+	assert.Equal(t, -40.0, u.Convert(Temperature, -40))
+	assert.Equal(t, 1.5, u.Convert(Data, uint64(3<<19)))
+	assert.Equal(t, 1500.0, u.Convert(Frequency, int64(1500000000)))
+	assert.Equal(t, 866.7, u.Convert(Bitrate, 866.7e6))
+	assert.Equal(t, "n/a", u.Convert(Data, "n/a"))
+	assert.Equal(t, 1.5, (&Units{Data: "KB"}).Convert(Data, 1500))
+
+	readings := map[string]interface{}{}
+	u.AddUnit(readings, Data)
+	(&Units{}).AddUnit(readings, Frequency)
+	assert.Equal(t, map[string]interface{}{"data_unit": "MiB", "frequency_unit": "Hz"}, readings)
+}
+
+func TestUnitsValidate(t *testing.T) {
+	assert.NoError(t, (&Units{}).Validate())
+	assert.NoError(t, (&Units{Temperature: "celsius", Data: "GiB", Frequency: "kHz", Bitrate: "Gbps"}).Validate())
+	assert.EqualError(t, (&Units{Temperature: "kelvin"}).Validate(), "units.temperature must be one of celsius, fahrenheit")
+	assert.Error(t, (&Units{Data: "mib"}).Validate())
+	assert.Error(t, (&Units{Frequency: "mhz"}).Validate())
+	assert.Error(t, (&Units{Bitrate: "Mb/s"}).Validate())
+}
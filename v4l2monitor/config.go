@@ -0,0 +1,20 @@
+package v4l2monitor
+
+import (
+	"context"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/linux"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+type ComponentConfig struct {
+}
+
+func (conf *ComponentConfig) Validate(path string) ([]string, error) {
+	return nil, nil
+}
+
+// Permissions implements utils.PermissionReporter, the video nodes need the video group.
+func (conf *ComponentConfig) Permissions(ctx context.Context) utils.Permissions {
+	return linux.V4L2Permissions()
+}
@@ -0,0 +1,202 @@
+package v4l2monitor
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"go.viam.com/rdk/components/sensor"
+	"go.viam.com/rdk/logging"
+	"go.viam.com/rdk/resource"
+	viamutils "go.viam.com/utils"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/linux"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+var (
+	Model       = resource.NewModel(utils.Namespace, "hwmonitor", "v4l2_monitor")
+	API         = sensor.API
+	PrettyName  = "V4L2 Device Monitor"
+	Description = "A sensor that reports the video devices, their drivers and formats, whether they can be opened and how often they appear and disappear"
+	Version     = utils.Version
+)
+
+const devRoot = "/dev"
+
+type Config struct {
+	resource.Named
+	configLock      sync.Mutex
+	readingsLock    sync.RWMutex
+	logger          logging.Logger
+	workers         *viamutils.StoppableWorkers
+	appearEvents    int
+	disappearEvents int
+	lastEvent       *linux.Uevent
+	lastEventAt     time.Time
+	// unopenable tracks which nodes are logged as failing to open, so each change is only logged once
+	unopenable map[string]bool
+}
+
+func init() {
+	resource.RegisterComponent(
+		API,
+		Model,
+		resource.Registration[sensor.Sensor, *ComponentConfig]{Constructor: utils.FilterReadings(NewSensor)})
+	utils.RegisterSimulator(Model, simulate)
+}
+
+func NewSensor(ctx context.Context, deps resource.Dependencies, conf resource.Config, logger logging.Logger) (sensor.Sensor, error) {
+	logger.Infof("Starting %s %s", PrettyName, Version)
+	b := Config{
+		Named:  conf.ResourceName().AsNamed(),
+		logger: logger,
+	}
+
+	if err := b.Reconfigure(ctx, deps, conf); err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
+
+func (c *Config) Reconfigure(ctx context.Context, _ resource.Dependencies, rawConf resource.Config) error {
+	c.configLock.Lock()
+	defer c.configLock.Unlock()
+	c.logger.Debugf("Reconfiguring %s", PrettyName)
+
+	if c.workers != nil {
+		c.logger.Debug("Stopping background worker")
+		c.workers.Stop()
+		c.logger.Debugf("Background worker stopped")
+		c.workers = nil
+	}
+
+	if _, err := resource.NativeConfig[*ComponentConfig](rawConf); err != nil {
+		return err
+	}
+
+	// In case the component has changed name
+	c.Named = rawConf.ResourceName().AsNamed()
+
+	c.readingsLock.Lock()
+	c.unopenable = make(map[string]bool)
+	c.readingsLock.Unlock()
+
+	uevents, err := linux.OpenUevents(time.Second)
+	if err != nil {
+		// The devices are still reported, only the appear and disappear counters are missing
+		c.logger.Warnf("Unable to subscribe to kernel uevents, devices appearing and disappearing will not be counted: %v", err)
+	} else {
+		c.workers = viamutils.NewBackgroundStoppableWorkers(func(ctx context.Context) {
+			c.watchUevents(ctx, uevents)
+		})
+	}
+	return nil
+}
+
+func (c *Config) Readings(ctx context.Context, extra map[string]interface{}) (map[string]interface{}, error) {
+	nodes, err := linux.ListVideoNodes(devRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	ret := make(map[string]interface{})
+	captureCount := 0
+	unopenable := make(map[string]bool)
+	for _, node := range nodes {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		device := linux.QueryV4L2Device(node)
+		key := filepath.Base(node)
+		openable := device.OpenError == nil
+		ret[key+"_openable"] = openable
+		if !openable {
+			ret[key+"_open_error"] = device.OpenError.Error()
+			unopenable[key] = true
+			continue
+		}
+		ret[key+"_driver"] = device.Driver
+		ret[key+"_card"] = device.Card
+		ret[key+"_bus_info"] = device.BusInfo
+		ret[key+"_capture"] = device.Capture
+		if device.Capture {
+			captureCount++
+			ret[key+"_formats"] = strings.Join(device.Formats, ",")
+		}
+	}
+	ret["device_count"] = len(nodes)
+	ret["capture_count"] = captureCount
+	ret["unopenable_count"] = len(unopenable)
+
+	c.readingsLock.Lock()
+	defer c.readingsLock.Unlock()
+	for key := range unopenable {
+		if !c.unopenable[key] {
+			c.logger.Warnf("Video device %s can't be opened: %v", key, ret[key+"_open_error"])
+		}
+	}
+	c.unopenable = unopenable
+	ret["appear_events"] = c.appearEvents
+	ret["disappear_events"] = c.disappearEvents
+	if c.lastEvent != nil {
+		ret["last_event_action"] = c.lastEvent.Action
+		ret["last_event_device"] = c.lastEvent.DevName
+		ret["last_event_time"] = c.lastEventAt.Format(time.RFC3339)
+	}
+	return ret, nil
+}
+
+func (c *Config) watchUevents(ctx context.Context, uevents *linux.UeventReader) {
+	defer uevents.Close()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		default:
+		}
+		event, err := uevents.Read()
+		if err != nil {
+			c.logger.Warnf("Failed to read kernel uevent: %v", err)
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(time.Second):
+			}
+			continue
+		}
+		if event == nil || event.Subsystem != "video4linux" {
+			continue
+		}
+		if event.Action != "add" && event.Action != "remove" {
+			continue
+		}
+		c.logger.Infof("Video device %s %s: %s", event.DevName, event.DevPath, event.Action)
+		c.readingsLock.Lock()
+		if event.Action == "add" {
+			c.appearEvents++
+		} else {
+			c.disappearEvents++
+		}
+		c.lastEvent = event
+		c.lastEventAt = time.Now()
+		c.readingsLock.Unlock()
+	}
+}
+
+func (c *Config) Close(ctx context.Context) error {
+	c.configLock.Lock()
+	defer c.configLock.Unlock()
+	c.logger.Infof("Shutting down %s", PrettyName)
+	if c.workers != nil {
+		c.workers.Stop()
+	}
+	c.logger.Infof("%s Shutdown complete", PrettyName)
+	return nil
+}
+
+func (c *Config) Ready(ctx context.Context, extra map[string]interface{}) (bool, error) {
+	return false, nil
+}
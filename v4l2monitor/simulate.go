@@ -0,0 +1,25 @@
+package v4l2monitor
+
+import "github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+
+// simulate returns the capture and metadata nodes of a USB webcam.
+func simulate(sim *utils.Simulation) map[string]interface{} {
+	return map[string]interface{}{
+		"video0_openable":  true,
+		"video0_driver":    "uvcvideo",
+		"video0_card":      "Logitech BRIO",
+		"video0_bus_info":  "usb-xhci-hcd.1-1",
+		"video0_capture":   true,
+		"video0_formats":   "MJPG,YUYV,H264",
+		"video1_openable":  true,
+		"video1_driver":    "uvcvideo",
+		"video1_card":      "Logitech BRIO",
+		"video1_bus_info":  "usb-xhci-hcd.1-1",
+		"video1_capture":   false,
+		"device_count":     2,
+		"capture_count":    1,
+		"unopenable_count": 0,
+		"appear_events":    0,
+		"disappear_events": 0,
+	}
+}
@@ -2,12 +2,14 @@ package voltages
 
 import (
 	"context"
+	"fmt"
 	"sync"
 
 	"go.viam.com/rdk/components/sensor"
 	"go.viam.com/rdk/logging"
 	"go.viam.com/rdk/resource"
 
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/linux"
 	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/sensors"
 	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
 )
@@ -33,7 +35,8 @@ func init() {
 	resource.RegisterComponent(
 		API,
 		Model,
-		resource.Registration[sensor.Sensor, *ComponentConfig]{Constructor: NewSensor})
+		resource.Registration[sensor.Sensor, *ComponentConfig]{Constructor: utils.FilterReadings(NewSensor)})
+	utils.RegisterSimulator(Model, simulate)
 }
 
 func NewSensor(ctx context.Context, deps resource.Dependencies, conf resource.Config, logger logging.Logger) (sensor.Sensor, error) {
@@ -83,18 +86,33 @@ func (c *Config) Readings(ctx context.Context, extra map[string]interface{}) (ma
 	c.mu.RLock()
 	defer c.mu.RUnlock()
 	ret := make(map[string]interface{})
+	errs := make(utils.ReadingErrors)
 	for _, s := range c.sensors {
 		name := s.GetName()
-		readings, err := s.GetReadingMap()
+		readings, err := s.GetReadingMap(ctx)
 		if err != nil {
 			c.logger.Warnf("Failed to get readings from %s: %v", name, err)
+			errs.Add(name, err)
 			continue
 		}
 		for k, v := range readings {
 			ret[name+"_"+k] = v
 		}
 	}
-	return ret, nil
+	return errs.Readings(ret)
+}
+
+// Capabilities reports what the power sensors are read from, and when none were found.
+func (c *Config) Capabilities(ctx context.Context) utils.Capabilities {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	caps := utils.Capabilities{Backend: backend(), Unavailable: make(map[string]string)}
+	if caps.Backend == "" {
+		caps.Unavailable["voltages"] = fmt.Sprintf("not reported by %s boards", linux.Board().Family)
+	} else if len(c.sensors) == 0 {
+		caps.Unavailable["voltages"] = fmt.Sprintf("no power sensors found by the %s backend", caps.Backend)
+	}
+	return caps
 }
 
 func (c *Config) Close(ctx context.Context) error {
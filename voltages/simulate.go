@@ -0,0 +1,25 @@
+package voltages
+
+import (
+	"math"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+// simulate returns the main PMIC rails of a Raspberry Pi 5. The core draws more current under load, and the 5V input
+// sags a little with it.
+func simulate(sim *utils.Simulation) map[string]interface{} {
+	ret := map[string]interface{}{
+		"ext5v_voltage": sim.Value(5.14, 5.08, 5.07, 0.02),
+	}
+	rail := func(name string, voltage, idle, loaded, throttling float64) {
+		current := sim.Value(idle, loaded, throttling, idle/5)
+		ret[name+"_voltage"] = voltage
+		ret[name+"_current"] = current
+		ret[name+"_power"] = math.Round(voltage*current*1000) / 1000
+	}
+	rail("vdd_core", sim.Level(0.72, 0.92, 0.86), 0.8, 3.2, 2.6)
+	rail("3v3_sys", 3.31, 0.08, 0.11, 0.11)
+	rail("1v8_sys", 1.8, 0.17, 0.31, 0.3)
+	return ret
+}
@@ -3,8 +3,8 @@ package voltages
 import (
 	"context"
 
-	"github.com/rinzlerlabs/sbcidentify"
-	"github.com/rinzlerlabs/sbcidentify/boardtype"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/linux"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/linux/allwinner"
 	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/linux/jetson"
 	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/linux/raspberrypi"
 	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/sensors"
@@ -12,10 +12,28 @@ import (
 )
 
 func getPowerSensors(ctx context.Context, logger logging.Logger) ([]sensors.PowerSensor, error) {
-	if sbcidentify.IsBoardType(boardtype.RaspberryPi) {
+	if linux.IsFamily(linux.BoardFamilyRaspberryPi) {
 		return raspberrypi.GetPowerSensors(ctx, logger)
-	} else if sbcidentify.IsBoardType(boardtype.NVIDIA) {
+	} else if linux.IsFamily(linux.BoardFamilyJetson) {
 		return jetson.GetPowerSensors(ctx, logger)
+	} else if linux.IsFamily(linux.BoardFamilyAllwinner) {
+		return allwinner.GetPowerSensors(ctx, logger)
+	} else if linux.IsFamily(linux.BoardFamilyBeagleBone) || linux.IsFamily(linux.BoardFamilyIMX) {
+		return linux.GetRegulatorPowerSensors(ctx, logger, linux.RegulatorRoot)
 	}
 	return make([]sensors.PowerSensor, 0), nil
 }
+
+// backend is what the power sensors are read from, empty on boards without any.
+func backend() string {
+	if linux.IsFamily(linux.BoardFamilyRaspberryPi) {
+		return "vcgencmd"
+	} else if linux.IsFamily(linux.BoardFamilyJetson) {
+		return "ina3221"
+	} else if linux.IsFamily(linux.BoardFamilyAllwinner) {
+		return "power_supply"
+	} else if linux.IsFamily(linux.BoardFamilyBeagleBone) || linux.IsFamily(linux.BoardFamilyIMX) {
+		return "regulator"
+	}
+	return ""
+}
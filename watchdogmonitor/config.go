@@ -0,0 +1,40 @@
+package watchdogmonitor
+
+import (
+	"errors"
+)
+
+type ComponentConfig struct {
+	Device         string       `json:"device"`           // Watchdog device to feed, defaults to /dev/watchdog
+	Feed           bool         `json:"feed"`             // Feed the watchdog from the module
+	FeedIntervalMs int          `json:"feed_interval_ms"` // Defaults to a third of the watchdog timeout
+	HealthCheck    *HealthCheck `json:"health_check"`     // The watchdog is only fed while this check passes
+}
+
+// HealthCheck compares a reading of another sensor against limits.
+type HealthCheck struct {
+	Sensor string      `json:"sensor"`
+	Key    string      `json:"key"`
+	Min    *float64    `json:"min"`
+	Max    *float64    `json:"max"`
+	Equals interface{} `json:"equals"`
+}
+
+func (conf *ComponentConfig) Validate(path string) ([]string, error) {
+	if conf.FeedIntervalMs < 0 {
+		return nil, errors.New("feed_interval_ms must not be negative")
+	}
+	if conf.HealthCheck == nil {
+		return nil, nil
+	}
+	if !conf.Feed {
+		return nil, errors.New("health_check requires feed to be enabled")
+	}
+	if conf.HealthCheck.Sensor == "" || conf.HealthCheck.Key == "" {
+		return nil, errors.New("health_check requires sensor and key")
+	}
+	if conf.HealthCheck.Min == nil && conf.HealthCheck.Max == nil && conf.HealthCheck.Equals == nil {
+		return nil, errors.New("health_check requires at least one of min, max or equals")
+	}
+	return []string{conf.HealthCheck.Sensor}, nil
+}
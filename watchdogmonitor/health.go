@@ -0,0 +1,32 @@
+package watchdogmonitor
+
+import (
+	"fmt"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+// evaluate returns nil when the reading passes the check, otherwise an error describing why it failed.
+func (h *HealthCheck) evaluate(readings map[string]interface{}) error {
+	value, ok := readings[h.Key]
+	if !ok {
+		return fmt.Errorf("reading %s is missing", h.Key)
+	}
+	if h.Equals != nil && fmt.Sprint(value) != fmt.Sprint(h.Equals) {
+		return fmt.Errorf("%s is %v, expected %v", h.Key, value, h.Equals)
+	}
+	if h.Min == nil && h.Max == nil {
+		return nil
+	}
+	number, ok := utils.ToFloat64(value)
+	if !ok {
+		return fmt.Errorf("%s is %v, which is not a number", h.Key, value)
+	}
+	if h.Min != nil && number < *h.Min {
+		return fmt.Errorf("%s is %v, below %v", h.Key, number, *h.Min)
+	}
+	if h.Max != nil && number > *h.Max {
+		return fmt.Errorf("%s is %v, above %v", h.Key, number, *h.Max)
+	}
+	return nil
+}
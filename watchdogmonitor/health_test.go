@@ -0,0 +1,36 @@
+package watchdogmonitor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHealthCheck(t *testing.T) {
+	minimum, maximum := 10.0, 80.0
+	tests := []struct {
+		name     string
+		check    HealthCheck
+		readings map[string]interface{}
+		healthy  bool
+	}{
+		{"InRange", HealthCheck{Key: "temp", Min: &minimum, Max: &maximum}, map[string]interface{}{"temp": 55.5}, true},
+		{"TooHigh", HealthCheck{Key: "temp", Max: &maximum}, map[string]interface{}{"temp": 81}, false},
+		{"TooLow", HealthCheck{Key: "temp", Min: &minimum}, map[string]interface{}{"temp": uint64(3)}, false},
+		{"Missing", HealthCheck{Key: "temp", Max: &maximum}, map[string]interface{}{"other": 1}, false},
+		{"NotANumber", HealthCheck{Key: "temp", Max: &maximum}, map[string]interface{}{"temp": "hot"}, false},
+		{"EqualsBool", HealthCheck{Key: "degraded", Equals: false}, map[string]interface{}{"degraded": false}, true},
+		{"NotEqualsBool", HealthCheck{Key: "degraded", Equals: false}, map[string]interface{}{"degraded": true}, false},
+		{"EqualsString", HealthCheck{Key: "state", Equals: "active"}, map[string]interface{}{"state": "active"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.check.evaluate(tt.readings)
+			if tt.healthy {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,238 @@
+package watchdogmonitor
+
+import (
+	"context"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"go.viam.com/rdk/components/sensor"
+	"go.viam.com/rdk/logging"
+	"go.viam.com/rdk/resource"
+	viamutils "go.viam.com/utils"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/linux"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+var (
+	Model       = resource.NewModel(utils.Namespace, "hwmonitor", "watchdog_monitor")
+	API         = sensor.API
+	PrettyName  = "SBC Watchdog Monitor"
+	Description = "A sensor that reports hardware watchdog configuration and can feed the watchdog while the system is healthy"
+	Version     = utils.Version
+)
+
+const defaultDevice = "/dev/watchdog"
+
+type Config struct {
+	resource.Named
+	configLock   sync.Mutex
+	readingsLock sync.RWMutex
+	logger       logging.Logger
+	workers      *viamutils.StoppableWorkers
+	device       *linux.WatchdogDevice
+	healthCheck  *HealthCheck
+	healthSensor sensor.Sensor
+	feeding      bool
+	healthErr    error
+	lastFeed     time.Time
+}
+
+func init() {
+	resource.RegisterComponent(
+		API,
+		Model,
+		resource.Registration[sensor.Sensor, *ComponentConfig]{Constructor: utils.FilterReadings(NewSensor)})
+}
+
+func NewSensor(ctx context.Context, deps resource.Dependencies, conf resource.Config, logger logging.Logger) (sensor.Sensor, error) {
+	logger.Infof("Starting %s %s", PrettyName, Version)
+	b := Config{
+		Named:  conf.ResourceName().AsNamed(),
+		logger: logger,
+	}
+
+	if err := b.Reconfigure(ctx, deps, conf); err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
+
+func (c *Config) Reconfigure(ctx context.Context, deps resource.Dependencies, rawConf resource.Config) error {
+	c.configLock.Lock()
+	defer c.configLock.Unlock()
+	c.logger.Debugf("Reconfiguring %s", PrettyName)
+
+	c.stopFeeding()
+
+	conf, err := resource.NativeConfig[*ComponentConfig](rawConf)
+	if err != nil {
+		return err
+	}
+
+	// In case the component has changed name
+	c.Named = rawConf.ResourceName().AsNamed()
+	c.healthCheck = conf.HealthCheck
+	c.healthSensor = nil
+	if conf.HealthCheck != nil {
+		c.healthSensor, err = sensor.FromDependencies(deps, conf.HealthCheck.Sensor)
+		if err != nil {
+			return err
+		}
+	}
+
+	if !conf.Feed {
+		return nil
+	}
+
+	if conf.Device == "" {
+		conf.Device = defaultDevice
+	}
+	interval := time.Duration(conf.FeedIntervalMs) * time.Millisecond
+	if interval <= 0 {
+		interval = c.defaultFeedInterval(ctx, conf.Device)
+	}
+
+	device, err := linux.OpenWatchdog(conf.Device)
+	if err != nil {
+		return err
+	}
+	c.device = device
+	c.logger.Infof("Feeding %s every %v", conf.Device, interval)
+	c.workers = viamutils.NewBackgroundStoppableWorkers(func(ctx context.Context) {
+		c.feedWatchdog(ctx, interval)
+	})
+	return nil
+}
+
+// defaultFeedInterval feeds three times per timeout period so a single slow health check doesn't trigger a reboot.
+func (c *Config) defaultFeedInterval(ctx context.Context, device string) time.Duration {
+	name := filepath.Base(device)
+	if device == defaultDevice {
+		// /dev/watchdog is the legacy alias for the first watchdog
+		name = "watchdog0"
+	}
+	watchdogs, err := linux.GetWatchdogs(ctx)
+	if err != nil {
+		c.logger.Debugf("Failed to read watchdogs: %v", err)
+	}
+	for _, watchdog := range watchdogs {
+		if watchdog.Name == name && watchdog.Timeout > 0 {
+			return watchdog.Timeout / 3
+		}
+	}
+	c.logger.Warnf("Unable to read the timeout of %s, feeding every second", device)
+	return time.Second
+}
+
+func (c *Config) feedWatchdog(ctx context.Context, interval time.Duration) {
+	for {
+		healthErr := c.checkHealth(ctx)
+		c.readingsLock.Lock()
+		if healthErr != nil && c.feeding {
+			c.logger.Errorf("Health check failed, no longer feeding the watchdog: %v", healthErr)
+		} else if healthErr == nil && !c.feeding && !c.lastFeed.IsZero() {
+			c.logger.Infof("Health check passed, resuming feeding the watchdog")
+		}
+		c.healthErr = healthErr
+		c.feeding = healthErr == nil
+		c.readingsLock.Unlock()
+
+		if healthErr == nil {
+			if err := c.device.Feed(); err != nil {
+				c.logger.Warnf("Failed to feed the watchdog: %v", err)
+			} else {
+				c.readingsLock.Lock()
+				c.lastFeed = time.Now()
+				c.readingsLock.Unlock()
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(interval):
+		}
+	}
+}
+
+func (c *Config) checkHealth(ctx context.Context) error {
+	if c.healthSensor == nil {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	readings, err := c.healthSensor.Readings(ctx, nil)
+	if err != nil {
+		return err
+	}
+	return c.healthCheck.evaluate(readings)
+}
+
+// stopFeeding stops the background worker and disarms the watchdog, configLock must be held.
+func (c *Config) stopFeeding() {
+	if c.workers != nil {
+		c.logger.Debug("Stopping background worker")
+		c.workers.Stop()
+		c.workers = nil
+		c.logger.Debugf("Background worker stopped")
+	}
+	if c.device != nil {
+		if err := c.device.Close(true); err != nil {
+			c.logger.Warnf("Failed to disarm the watchdog: %v", err)
+		}
+		c.device = nil
+	}
+	c.readingsLock.Lock()
+	c.feeding = false
+	c.healthErr = nil
+	c.lastFeed = time.Time{}
+	c.readingsLock.Unlock()
+}
+
+func (c *Config) Readings(ctx context.Context, extra map[string]interface{}) (map[string]interface{}, error) {
+	watchdogs, err := linux.GetWatchdogs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ret := make(map[string]interface{})
+	ret["present"] = len(watchdogs) > 0
+	for _, watchdog := range watchdogs {
+		ret[watchdog.Name+"_identity"] = watchdog.Identity
+		ret[watchdog.Name+"_state"] = watchdog.State
+		ret[watchdog.Name+"_timeout_sec"] = watchdog.Timeout.Seconds()
+		ret[watchdog.Name+"_pretimeout_sec"] = watchdog.Pretimeout.Seconds()
+		ret[watchdog.Name+"_time_left_sec"] = watchdog.TimeLeft.Seconds()
+		ret[watchdog.Name+"_nowayout"] = watchdog.Nowayout
+		ret[watchdog.Name+"_boot_status"] = watchdog.BootStatus
+		if watchdog.PretimeoutGovernor != "" {
+			ret[watchdog.Name+"_pretimeout_governor"] = watchdog.PretimeoutGovernor
+		}
+	}
+
+	c.readingsLock.RLock()
+	defer c.readingsLock.RUnlock()
+	ret["feeding"] = c.feeding
+	if !c.lastFeed.IsZero() {
+		ret["last_feed_time"] = c.lastFeed.Format(time.RFC3339)
+	}
+	if c.healthErr != nil {
+		ret["health_error"] = c.healthErr.Error()
+	}
+	return ret, nil
+}
+
+func (c *Config) Close(ctx context.Context) error {
+	c.configLock.Lock()
+	defer c.configLock.Unlock()
+	c.logger.Infof("Shutting down %s", PrettyName)
+	c.stopFeeding()
+	c.logger.Infof("%s Shutdown complete", PrettyName)
+	return nil
+}
+
+func (c *Config) Ready(ctx context.Context, extra map[string]interface{}) (bool, error) {
+	return false, nil
+}
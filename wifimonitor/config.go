@@ -2,19 +2,47 @@ package wifimonitor
 
 import (
 	"errors"
+	"os/exec"
 	"runtime"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/linux"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
 )
 
 type ComponentConfig struct {
-	Adapter string `json:"adapter"`
+	Adapter  string      `json:"adapter"`
+	Units    utils.Units `json:"units,omitempty"`
+	Simulate bool        `json:"simulate,omitempty"` // Skips checking the adapter and backends exist, see utils.SimulateAttribute
 }
 
 func (conf *ComponentConfig) Validate(path string) ([]string, error) {
 	if conf.Adapter == "" {
 		return nil, errors.New("adapter is required")
 	}
+	if err := conf.Units.Validate(); err != nil {
+		return nil, err
+	}
+	if conf.Simulate {
+		return nil, nil
+	}
 	if runtime.GOOS != "linux" {
 		return nil, errors.New("only linux is supported")
 	}
+	if err := linux.CheckNetworkAdapter(linux.NetClassRoot, conf.Adapter); err != nil {
+		return nil, err
+	}
+	// The same backends newWifiMonitor chooses from
+	_, iwErr := exec.LookPath("iw")
+	_, nmcliErr := exec.LookPath("nmcli")
+	_, procErr := utils.Stat("/proc/net/wireless")
+	if iwErr != nil && nmcliErr != nil && procErr != nil {
+		return nil, errors.New("none of iw, nmcli or /proc/net/wireless is available, install iw")
+	}
 	return nil, nil
 }
+
+// ConvertReadings adds the converted readings to simulated readings as the sensor adds them to its own.
+func (conf *ComponentConfig) ConvertReadings(readings map[string]interface{}) map[string]interface{} {
+	addConvertedReadings(conf.Units, readings)
+	return readings
+}
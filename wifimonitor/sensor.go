@@ -34,13 +34,15 @@ type Config struct {
 	networkManager        WifiNetworkManager
 	savedNetworksCache    []string
 	savedNetworksCacheExp time.Time
+	units                 utils.Units
 }
 
 func init() {
 	resource.RegisterComponent(
 		API,
 		Model,
-		resource.Registration[sensor.Sensor, *ComponentConfig]{Constructor: NewSensor})
+		resource.Registration[sensor.Sensor, *ComponentConfig]{Constructor: utils.FilterReadings(NewSensor)})
+	utils.RegisterSimulator(Model, simulate)
 }
 
 func NewSensor(ctx context.Context, deps resource.Dependencies, conf resource.Config, logger logging.Logger) (sensor.Sensor, error) {
@@ -74,6 +76,7 @@ func (c *Config) Reconfigure(ctx context.Context, _ resource.Dependencies, conf
 
 	// In case the module has changed name
 	c.Named = conf.ResourceName().AsNamed()
+	c.units = newConf.Units
 
 	mon := c.newWifiMonitor(newConf.Adapter)
 	if mon == nil {
@@ -92,21 +95,23 @@ func (c *Config) Readings(ctx context.Context, extra map[string]interface{}) (ma
 	c.mu.Lock()
 	defer c.mu.Unlock()
 	ret := make(map[string]interface{})
+	errs := make(utils.ReadingErrors)
 	if c.wifiMonitor != nil {
-		status, err := c.wifiMonitor.GetNetworkStatus()
+		status, err := c.wifiMonitor.GetNetworkStatus(ctx)
 		if err == ErrAdapterNotFound {
 			ret["err"] = "adapter not found"
 		} else if err == ErrNotConnected {
 			ret["err"] = "not connected to a network"
 		} else if err != nil {
 			c.logger.Infof("Error getting network status: %v", err)
-			return nil, err
+			errs.Add("network", err)
 		} else {
 			ret["network"] = status.NetworkName
 			ret["signal_strength"] = status.SignalStrength
 			ret["tx_speed_mbps"] = status.TxSpeedMbps
 			ret["rx_speed_mbps"] = status.RxSpeedMbps
 			ret["frequency_mhz"] = status.FrequencyMHz
+			addConvertedReadings(c.units, ret)
 			ret["tx_retries"] = status.TxRetries
 			ret["tx_failed"] = status.TxFailed
 			ret["beacon_signal_avg"] = status.BeaconSignalAvg
@@ -121,9 +126,10 @@ func (c *Config) Readings(ctx context.Context, extra map[string]interface{}) (ma
 	}
 
 	if c.networkManager != nil {
-		networks, err := c.getSavedNetworks()
+		networks, err := c.getSavedNetworks(ctx)
 		if err != nil {
 			c.logger.Warnf("Failed to list saved networks: %v", err)
+			errs.Add("saved_networks", err)
 		} else {
 			ret["saved_networks"] = stringsToInterfaces(networks)
 		}
@@ -131,16 +137,16 @@ func (c *Config) Readings(ctx context.Context, extra map[string]interface{}) (ma
 		ret["saved_networks_unavailable"] = true
 	}
 
-	return ret, nil
+	return errs.Readings(ret)
 }
 
 // getSavedNetworks returns cached saved networks, refreshing if expired.
 // Must be called with c.mu held.
-func (c *Config) getSavedNetworks() ([]string, error) {
+func (c *Config) getSavedNetworks(ctx context.Context) ([]string, error) {
 	if time.Now().Before(c.savedNetworksCacheExp) {
 		return c.savedNetworksCache, nil
 	}
-	networks, err := c.networkManager.ListSavedNetworks()
+	networks, err := c.networkManager.ListSavedNetworks(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -164,26 +170,26 @@ func (c *Config) DoCommand(ctx context.Context, cmd map[string]interface{}) (map
 
 	switch command {
 	case "list_saved_networks":
-		return c.handleListNetworks()
+		return c.handleListNetworks(ctx)
 	case "forget_network":
-		return c.handleForgetNetwork(cmd)
+		return c.handleForgetNetwork(ctx, cmd)
 	default:
 		return nil, fmt.Errorf("unknown command: %s", command)
 	}
 }
 
-func (c *Config) handleListNetworks() (map[string]interface{}, error) {
+func (c *Config) handleListNetworks(ctx context.Context) (map[string]interface{}, error) {
 	if c.networkManager == nil {
 		return nil, ErrNmcliNotAvailable
 	}
-	networks, err := c.getSavedNetworks()
+	networks, err := c.getSavedNetworks(ctx)
 	if err != nil {
 		return nil, err
 	}
 	return map[string]interface{}{"networks": stringsToInterfaces(networks)}, nil
 }
 
-func (c *Config) handleForgetNetwork(cmd map[string]interface{}) (map[string]interface{}, error) {
+func (c *Config) handleForgetNetwork(ctx context.Context, cmd map[string]interface{}) (map[string]interface{}, error) {
 	if c.networkManager == nil {
 		return nil, ErrNmcliNotAvailable
 	}
@@ -195,14 +201,14 @@ func (c *Config) handleForgetNetwork(cmd map[string]interface{}) (map[string]int
 		return nil, errors.New("network name cannot be empty")
 	}
 
-	if err := c.networkManager.ForgetNetwork(name); err != nil {
+	if err := c.networkManager.ForgetNetwork(ctx, name); err != nil {
 		return nil, err
 	}
 	c.invalidateSavedNetworksCache()
 
 	result := map[string]interface{}{"status": "ok", "name": name}
 	if c.wifiMonitor != nil {
-		status, err := c.wifiMonitor.GetNetworkStatus()
+		status, err := c.wifiMonitor.GetNetworkStatus(ctx)
 		if err == nil && status.NetworkName == name {
 			result["warning"] = "forgot the active network; device may lose connectivity. If viam-agent provisioning is enabled, it will start the hotspot flow."
 		}
@@ -210,6 +216,36 @@ func (c *Config) handleForgetNetwork(cmd map[string]interface{}) (map[string]int
 	return result, nil
 }
 
+// Capabilities reports the wifi backend, the readings it doesn't measure and whether saved networks can be listed.
+func (c *Config) Capabilities(ctx context.Context) utils.Capabilities {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	caps := wifiCapabilities(c.wifiMonitor)
+	if c.networkManager == nil {
+		caps.Unavailable["saved_networks"] = "nmcli isn't installed"
+	}
+	return caps
+}
+
+// addConvertedReadings adds the link speeds and frequency in the configured units. The _mbps and _mhz readings are kept
+// as they are so existing configs keep working.
+func addConvertedReadings(units utils.Units, ret map[string]interface{}) {
+	if units.Bitrate != "" {
+		for _, key := range []string{"tx_speed", "rx_speed"} {
+			if mbps, ok := utils.ToFloat64(ret[key+"_mbps"]); ok {
+				ret[key] = units.Convert(utils.Bitrate, mbps*1e6)
+			}
+		}
+		units.AddUnit(ret, utils.Bitrate)
+	}
+	if units.Frequency != "" {
+		if mhz, ok := utils.ToFloat64(ret["frequency_mhz"]); ok {
+			ret["frequency"] = units.Convert(utils.Frequency, mhz*1e6)
+		}
+		units.AddUnit(ret, utils.Frequency)
+	}
+}
+
 func (c *Config) Close(ctx context.Context) error {
 	c.logger.Infof("Shutting down %s", PrettyName)
 	c.cancelFunc()
@@ -0,0 +1,27 @@
+package wifimonitor
+
+import "github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+
+const simulatedNetwork = "SimulatedWiFi"
+
+// simulate returns a connection to a 5GHz network, its signal doesn't depend on the profile but the retries increase
+// with the traffic.
+func simulate(sim *utils.Simulation) map[string]interface{} {
+	signal := int(sim.Value(52, 52, 52, 4))
+	return map[string]interface{}{
+		"network":            simulatedNetwork,
+		"signal_strength":    -signal,
+		"tx_speed_mbps":      sim.Level(433.3, 390, 390),
+		"rx_speed_mbps":      sim.Level(390, 433.3, 433.3),
+		"frequency_mhz":      5180,
+		"tx_retries":         int(sim.Counter(120, 0.05, 4, 4)),
+		"tx_failed":          int(sim.Counter(3, 0, 0.01, 0.01)),
+		"beacon_signal_avg":  -signal + 2,
+		"signal_avg":         -signal,
+		"ack_signal_avg":     -signal - 1,
+		"noise":              -92,
+		"connected_time_sec": int(sim.Counter(0, 1, 1, 1)),
+		"inactive_time_ms":   int(sim.Value(40, 2, 2, 20)),
+		"saved_networks":     []interface{}{simulatedNetwork},
+	}
+}
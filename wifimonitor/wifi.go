@@ -1,21 +1,32 @@
 package wifimonitor
 
-import "errors"
+import (
+	"context"
+	"errors"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
 
 var (
 	ErrNotConnected      = errors.New("not connected to a network")
-	ErrAdapterNotFound   = errors.New("adapter not found")
-	ErrNoAdaptersFound   = errors.New("no adapters found")
-	ErrNmcliNotAvailable = errors.New("nmcli is not available on this system")
+	ErrAdapterNotFound   = utils.NewError(utils.ErrHardwareAbsent, "adapter not found")
+	ErrNoAdaptersFound   = utils.NewError(utils.ErrHardwareAbsent, "no adapters found")
+	ErrNmcliNotAvailable = utils.NewError(utils.ErrBackendUnavailable, "nmcli is not available on this system")
 )
 
 type WifiMonitor interface {
-	GetNetworkStatus() (*networkStatus, error)
+	GetNetworkStatus(ctx context.Context) (*networkStatus, error)
 }
 
 type WifiNetworkManager interface {
-	ListSavedNetworks() ([]string, error)
-	ForgetNetwork(name string) error
+	ListSavedNetworks(ctx context.Context) ([]string, error)
+	ForgetNetwork(ctx context.Context, name string) error
+}
+
+// iwOnlyReadings are only measured by iw, the other backends report them as 0
+var iwOnlyReadings = []string{
+	"rx_speed_mbps", "frequency_mhz", "tx_retries", "tx_failed", "beacon_signal_avg", "signal_avg", "ack_signal_avg",
+	"noise", "connected_time_sec", "inactive_time_ms",
 }
 
 type networkStatus struct {
@@ -1,14 +1,16 @@
 package wifimonitor
 
 import (
+	"context"
 	"errors"
 	"fmt"
-	"os"
 	"os/exec"
 	"strconv"
 	"strings"
 
 	"go.viam.com/rdk/logging"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
 )
 
 func (c *Config) newWifiMonitor(adapter string) WifiMonitor {
@@ -23,21 +25,42 @@ func (c *Config) newWifiMonitor(adapter string) WifiMonitor {
 		return &nmcliWifiMonitor{adapter: adapter, logger: c.logger}
 	}
 	// proc has basic stats
-	if _, err := os.Stat("/proc/net/wireless"); err == nil {
+	if _, err := utils.Stat("/proc/net/wireless"); err == nil {
 		c.logger.Infof("Using /proc/net/wireless for wifi stats")
 		return &procWifiMonitor{adapter: adapter, logger: c.logger}
 	}
 	return nil
 }
 
+// wifiCapabilities names the backend newWifiMonitor selected and the readings it doesn't measure.
+func wifiCapabilities(mon WifiMonitor) utils.Capabilities {
+	caps := utils.Capabilities{Unavailable: make(map[string]string)}
+	switch mon.(type) {
+	case *iwWifiMonitor:
+		caps.Backend = "iw"
+		return caps
+	case *nmcliWifiMonitor:
+		caps.Backend = "nmcli"
+	case *procWifiMonitor:
+		caps.Backend = "/proc/net/wireless"
+		caps.Unavailable["network"] = "not reported by /proc/net/wireless"
+	default:
+		caps.Unavailable["network"] = "none of iw, nmcli or /proc/net/wireless is available"
+		return caps
+	}
+	for _, key := range iwOnlyReadings {
+		caps.Unavailable[key] = "only measured by iw, which isn't installed"
+	}
+	return caps
+}
+
 type nmcliWifiMonitor struct {
 	logger  logging.Logger
 	adapter string
 }
 
-func (w *nmcliWifiMonitor) GetNetworkStatus() (*networkStatus, error) {
-	cmd := exec.Command("nmcli", "-t", "-f", "ACTIVE,NAME,SSID,CHAN,FREQ,RATE,SIGNAL,DEVICE", "dev", "wifi")
-	out, err := cmd.Output()
+func (w *nmcliWifiMonitor) GetNetworkStatus(ctx context.Context) (*networkStatus, error) {
+	out, err := utils.Output(ctx, "nmcli", "-t", "-f", "ACTIVE,NAME,SSID,CHAN,FREQ,RATE,SIGNAL,DEVICE", "dev", "wifi")
 	if err != nil {
 		return nil, err
 	}
@@ -86,9 +109,8 @@ type iwWifiMonitor struct {
 	adapter string
 }
 
-func (w *iwWifiMonitor) GetNetworkStatus() (*networkStatus, error) {
-	cmd := exec.Command("iw", "dev", w.adapter, "link")
-	out, err := cmd.Output()
+func (w *iwWifiMonitor) GetNetworkStatus(ctx context.Context) (*networkStatus, error) {
+	out, err := utils.SharedOutput(ctx, "iw", "dev", w.adapter, "link")
 	if err != nil {
 		if err.Error() == "exit status 237" {
 			return nil, ErrAdapterNotFound
@@ -102,10 +124,10 @@ func (w *iwWifiMonitor) GetNetworkStatus() (*networkStatus, error) {
 	}
 
 	// Get additional stats from station dump (retries, failures, etc.)
-	w.enrichWithStationDump(status)
+	w.enrichWithStationDump(ctx, status)
 
 	// Get noise floor from survey dump
-	w.enrichWithSurveyDump(status)
+	w.enrichWithSurveyDump(ctx, status)
 
 	return status, nil
 }
@@ -166,9 +188,8 @@ func (w *iwWifiMonitor) parseNetworkStatus(out string) (*networkStatus, error) {
 }
 
 // enrichWithStationDump adds retry/failure stats from iw station dump
-func (w *iwWifiMonitor) enrichWithStationDump(status *networkStatus) {
-	cmd := exec.Command("iw", "dev", w.adapter, "station", "dump")
-	out, err := cmd.Output()
+func (w *iwWifiMonitor) enrichWithStationDump(ctx context.Context, status *networkStatus) {
+	out, err := utils.SharedOutput(ctx, "iw", "dev", w.adapter, "station", "dump")
 	if err != nil {
 		return // silently fail - these are optional stats
 	}
@@ -227,9 +248,8 @@ func (w *iwWifiMonitor) parseStationDump(out string, status *networkStatus) {
 }
 
 // enrichWithSurveyDump adds noise floor from iw survey dump
-func (w *iwWifiMonitor) enrichWithSurveyDump(status *networkStatus) {
-	cmd := exec.Command("iw", "dev", w.adapter, "survey", "dump")
-	out, err := cmd.Output()
+func (w *iwWifiMonitor) enrichWithSurveyDump(ctx context.Context, status *networkStatus) {
+	out, err := utils.SharedOutput(ctx, "iw", "dev", w.adapter, "survey", "dump")
 	if err != nil {
 		return // silently fail - this is optional
 	}
@@ -280,12 +300,12 @@ type procWifiMonitor struct {
 	adapter string
 }
 
-func (w *procWifiMonitor) GetNetworkStatus() (*networkStatus, error) {
-	out, err := os.ReadFile("/proc/net/wireless")
+func (w *procWifiMonitor) GetNetworkStatus(ctx context.Context) (*networkStatus, error) {
+	out, err := utils.ReadFileWithContext(ctx, "/proc/net/wireless")
 	if err != nil {
 		return nil, err
 	}
-	return w.parseNetworkStatus(string(out))
+	return w.parseNetworkStatus(out)
 }
 
 func (w *procWifiMonitor) parseNetworkStatus(out string) (*networkStatus, error) {
@@ -323,9 +343,8 @@ func newNetworkManager(logger logging.Logger) WifiNetworkManager {
 	return &nmcliNetworkManager{logger: logger}
 }
 
-func (m *nmcliNetworkManager) ListSavedNetworks() ([]string, error) {
-	cmd := exec.Command("nmcli", "-t", "-f", "NAME,TYPE", "connection", "show")
-	out, err := cmd.Output()
+func (m *nmcliNetworkManager) ListSavedNetworks(ctx context.Context) ([]string, error) {
+	out, err := utils.Output(ctx, "nmcli", "-t", "-f", "NAME,TYPE", "connection", "show")
 	if err != nil {
 		return nil, fmt.Errorf("failed to list connections: %w", err)
 	}
@@ -348,9 +367,8 @@ func (m *nmcliNetworkManager) parseConnectionList(out string) []string {
 	return networks
 }
 
-func (m *nmcliNetworkManager) ForgetNetwork(name string) error {
-	cmd := exec.Command("nmcli", "connection", "delete", name)
-	out, err := cmd.CombinedOutput()
+func (m *nmcliNetworkManager) ForgetNetwork(ctx context.Context, name string) error {
+	out, err := utils.CombinedOutput(ctx, "nmcli", "connection", "delete", name)
 	if err != nil {
 		return fmt.Errorf("failed to delete network %q: %s: %w", name, strings.TrimSpace(string(out)), err)
 	}
@@ -11,6 +11,8 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.viam.com/rdk/logging"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
 )
 
 func TestLinuxProcWifiMonitor(t *testing.T) {
@@ -204,11 +206,11 @@ type mockNetworkManager struct {
 	forgottenName string
 }
 
-func (m *mockNetworkManager) ListSavedNetworks() ([]string, error) {
+func (m *mockNetworkManager) ListSavedNetworks(ctx context.Context) ([]string, error) {
 	return m.networks, nil
 }
 
-func (m *mockNetworkManager) ForgetNetwork(name string) error {
+func (m *mockNetworkManager) ForgetNetwork(ctx context.Context, name string) error {
 	m.forgottenName = name
 	return m.forgetErr
 }
@@ -219,7 +221,7 @@ type mockWifiMonitor struct {
 	err    error
 }
 
-func (m *mockWifiMonitor) GetNetworkStatus() (*networkStatus, error) {
+func (m *mockWifiMonitor) GetNetworkStatus(ctx context.Context) (*networkStatus, error) {
 	return m.status, m.err
 }
 
@@ -369,3 +371,14 @@ func TestReadingsOmitsSavedNetworksWhenNoManager(t *testing.T) {
 	assert.False(t, hasSaved)
 	assert.Equal(t, true, readings["saved_networks_unavailable"])
 }
+
+func TestReadingsKeepsSavedNetworksWhenStatusFails(t *testing.T) {
+	mock := &mockNetworkManager{networks: []string{"HomeWiFi"}}
+	c := newTestConfig(t, mock)
+	c.wifiMonitor = &mockWifiMonitor{err: errors.New("station dump failed")}
+
+	readings, err := c.Readings(context.Background(), nil)
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{"HomeWiFi"}, readings["saved_networks"])
+	assert.Equal(t, map[string]interface{}{"network": "station dump failed"}, readings[utils.ReadingErrorsKey])
+}
@@ -3,19 +3,32 @@ package wifimonitor
 import (
 	"bufio"
 	"bytes"
+	"context"
 	"errors"
 	"fmt"
-	"os/exec"
 	"strconv"
 	"strings"
 
 	"go.viam.com/rdk/logging"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
 )
 
 func (c *Config) newWifiMonitor(adapter string) WifiMonitor {
 	return &wifiMonitor{adapter: adapter, logger: c.logger}
 }
 
+// wifiCapabilities names the backend and the readings netsh doesn't report.
+func wifiCapabilities(mon WifiMonitor) utils.Capabilities {
+	caps := utils.Capabilities{Backend: "netsh", Unavailable: make(map[string]string)}
+	for _, key := range iwOnlyReadings {
+		if key != "rx_speed_mbps" {
+			caps.Unavailable[key] = "not reported by netsh"
+		}
+	}
+	return caps
+}
+
 func newNetworkManager(logger logging.Logger) WifiNetworkManager {
 	return nil
 }
@@ -25,9 +38,8 @@ type wifiMonitor struct {
 	logger  logging.Logger
 }
 
-func (w *wifiMonitor) GetNetworkStatus() (*networkStatus, error) {
-	cmd := exec.Command("netsh", "wlan", "show", "interfaces")
-	out, err := cmd.Output()
+func (w *wifiMonitor) GetNetworkStatus(ctx context.Context) (*networkStatus, error) {
+	out, err := utils.Output(ctx, "netsh", "wlan", "show", "interfaces")
 	if err != nil {
 		return nil, errors.Join(err, errors.New("error running command"))
 	}
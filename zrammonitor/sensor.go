@@ -0,0 +1,121 @@
+package zrammonitor
+
+import (
+	"context"
+	"errors"
+	"os"
+	"sync"
+
+	"go.viam.com/rdk/components/sensor"
+	"go.viam.com/rdk/logging"
+	"go.viam.com/rdk/resource"
+
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/linux"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+var (
+	Model       = resource.NewModel(utils.Namespace, "hwmonitor", "zram_monitor")
+	API         = sensor.API
+	PrettyName  = "SBC zram and zswap Monitor"
+	Description = "A sensor that reports zram device usage, compression ratio and zswap pool stats"
+	Version     = utils.Version
+)
+
+type Config struct {
+	resource.Named
+	mu         sync.RWMutex
+	logger     logging.Logger
+	cancelCtx  context.Context
+	cancelFunc func()
+}
+
+func init() {
+	resource.RegisterComponent(
+		API,
+		Model,
+		resource.Registration[sensor.Sensor, *ComponentConfig]{Constructor: utils.FilterReadings(NewSensor)})
+	utils.RegisterSimulator(Model, simulate)
+}
+
+func NewSensor(ctx context.Context, deps resource.Dependencies, conf resource.Config, logger logging.Logger) (sensor.Sensor, error) {
+	logger.Infof("Starting %s %s", PrettyName, Version)
+	cancelCtx, cancelFunc := context.WithCancel(context.Background())
+
+	b := Config{
+		Named:      conf.ResourceName().AsNamed(),
+		logger:     logger,
+		cancelCtx:  cancelCtx,
+		cancelFunc: cancelFunc,
+		mu:         sync.RWMutex{},
+	}
+
+	if err := b.Reconfigure(ctx, deps, conf); err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
+
+func (c *Config) Reconfigure(ctx context.Context, _ resource.Dependencies, conf resource.Config) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.logger.Debugf("Reconfiguring %s", PrettyName)
+
+	// In case the module has changed name
+	c.Named = conf.ResourceName().AsNamed()
+
+	return nil
+}
+
+func (c *Config) Readings(ctx context.Context, extra map[string]interface{}) (map[string]interface{}, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	ret := make(map[string]interface{})
+
+	devices, err := linux.GetZramDevices(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, device := range devices {
+		name := device.Name
+		ret[name+"_algorithm"] = device.Algorithm
+		ret[name+"_disksize"] = device.DiskSize
+		ret[name+"_orig_data_size"] = device.OrigDataSize
+		ret[name+"_compr_data_size"] = device.ComprDataSize
+		ret[name+"_mem_used_total"] = device.MemUsedTotal
+		ret[name+"_mem_limit"] = device.MemLimit
+		ret[name+"_mem_used_max"] = device.MemUsedMax
+		ret[name+"_same_pages"] = device.SamePages
+		ret[name+"_huge_pages"] = device.HugePages
+		ret[name+"_compression_ratio"] = device.CompressionRatio()
+		ret[name+"_used_percent"] = device.UsedPercent()
+	}
+
+	zswap, err := linux.GetZswapStats(ctx)
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			return nil, err
+		}
+		// zswap is not built into this kernel
+		c.logger.Debugf("zswap is not available: %v", err)
+	} else {
+		ret["zswap_enabled"] = zswap.Enabled
+		ret["zswap_compressor"] = zswap.Compressor
+		ret["zswap_max_pool_percent"] = zswap.MaxPoolPercent
+		for k, v := range zswap.Stats {
+			ret["zswap_"+k] = v
+		}
+	}
+
+	return ret, nil
+}
+
+func (c *Config) Close(ctx context.Context) error {
+	c.logger.Infof("Shutting down %s", PrettyName)
+	c.cancelFunc()
+	return nil
+}
+
+func (c *Config) Ready(ctx context.Context, extra map[string]interface{}) (bool, error) {
+	return false, nil
+}
@@ -0,0 +1,38 @@
+package zrammonitor
+
+import (
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/internal/linux"
+	"github.com/rinzlerlabs/viam-sbc-hwmonitor/utils"
+)
+
+// simulate returns the zram swap device of Raspberry Pi OS, more is swapped to it when the board is busy.
+func simulate(sim *utils.Simulation) map[string]interface{} {
+	orig := uint64(sim.Value(1.2e8, 6.4e8, 6.4e8, 1e7))
+	device := &linux.ZramDevice{
+		Name:          "zram0",
+		Algorithm:     "zstd",
+		DiskSize:      2_147_483_648,
+		OrigDataSize:  orig,
+		ComprDataSize: orig / 3,
+		MemUsedTotal:  orig/3 + 1_048_576,
+		MemUsedMax:    orig/3 + 4_194_304,
+		SamePages:     orig / 4096 / 20,
+	}
+	name := device.Name
+	return map[string]interface{}{
+		name + "_algorithm":         device.Algorithm,
+		name + "_disksize":          device.DiskSize,
+		name + "_orig_data_size":    device.OrigDataSize,
+		name + "_compr_data_size":   device.ComprDataSize,
+		name + "_mem_used_total":    device.MemUsedTotal,
+		name + "_mem_limit":         device.MemLimit,
+		name + "_mem_used_max":      device.MemUsedMax,
+		name + "_same_pages":        device.SamePages,
+		name + "_huge_pages":        device.HugePages,
+		name + "_compression_ratio": device.CompressionRatio(),
+		name + "_used_percent":      device.UsedPercent(),
+		"zswap_enabled":             false,
+		"zswap_compressor":          "zstd",
+		"zswap_max_pool_percent":    int64(20),
+	}
+}